@@ -5,14 +5,14 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
+	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/config"
 	"github.com/fluxorio/fluxor/pkg/core"
 	"github.com/fluxorio/fluxor/pkg/db"
+	"github.com/fluxorio/fluxor/pkg/fluxor"
 	"github.com/fluxorio/fluxor/pkg/fx"
 	"github.com/fluxorio/fluxor/pkg/observability/otel"
 	"github.com/fluxorio/fluxor/pkg/observability/prometheus"
@@ -33,32 +33,32 @@ type AppConfig struct {
 }
 
 type ServerConfig struct {
-	Port               string `yaml:"port"`
-	MaxCCU             int    `yaml:"max_ccu"`
-	UtilizationPercent int    `yaml:"utilization_percent"`
+	Port               string `yaml:"port" default:":8080" validate:"required"`
+	MaxCCU             int    `yaml:"max_ccu" default:"5000" validate:"min=1"`
+	UtilizationPercent int    `yaml:"utilization_percent" default:"67" validate:"min=1,max=100"`
 }
 
 type DatabaseConfig struct {
-	Host           string `yaml:"host"`
-	Port           int    `yaml:"port"`
-	Database       string `yaml:"database"`
-	User           string `yaml:"user"`
-	Password       string `yaml:"password"`
-	MaxConnections int    `yaml:"max_connections"`
-	MinConnections int    `yaml:"min_connections"`
-	MaxIdleTime    int    `yaml:"max_idle_time"`
+	Host           string `yaml:"host" default:"localhost" validate:"required"`
+	Port           int    `yaml:"port" default:"5432" validate:"min=1,max=65535"`
+	Database       string `yaml:"database" default:"fluxor"`
+	User           string `yaml:"user" default:"fluxor"`
+	Password       string `yaml:"password" default:"password"`
+	MaxConnections int    `yaml:"max_connections" default:"100" validate:"min=1"`
+	MinConnections int    `yaml:"min_connections" default:"10" validate:"min=0"`
+	MaxIdleTime    int    `yaml:"max_idle_time" default:"300" validate:"min=0"`
 }
 
 type AuthConfig struct {
-	JWTSecret      string   `yaml:"jwt_secret"`
-	AllowedOrigins []string `yaml:"allowed_origins"`
+	JWTSecret      string   `yaml:"jwt_secret" default:"your-secret-key-change-in-production" validate:"required"`
+	AllowedOrigins []string `yaml:"allowed_origins" default:"http://localhost:3000"`
 }
 
 type ObservabilityConfig struct {
-	EnableTracing  bool   `yaml:"enable_tracing"`
-	EnableMetrics  bool   `yaml:"enable_metrics"`
-	JaegerEndpoint string `yaml:"jaeger_endpoint"`
-	PrometheusPort string `yaml:"prometheus_port"`
+	EnableTracing  bool   `yaml:"enable_tracing" default:"true"`
+	EnableMetrics  bool   `yaml:"enable_metrics" default:"true"`
+	JaegerEndpoint string `yaml:"jaeger_endpoint" default:"http://localhost:14268/api/traces"`
+	PrometheusPort string `yaml:"prometheus_port" default:":9090"`
 }
 
 func main() {
@@ -75,12 +75,15 @@ func main() {
 	logger := core.NewJSONLogger()
 	logger.Info("Starting enterprise application")
 
+	// CORS origins are the one setting SIGHUP applies without a restart
+	cors := newReloadableCORS(cfg.Auth.AllowedOrigins)
+
 	// Create Fluxor application with dependency injection
 	app, err := fx.New(ctx,
 		fx.Provide(fx.NewValueProvider(cfg)),
 		fx.Provide(fx.NewValueProvider(logger)),
 		fx.Invoke(fx.NewInvoker(func(deps map[reflect.Type]interface{}) error {
-			return setupEnterpriseApplication(deps, cfg, logger)
+			return setupEnterpriseApplication(deps, cfg, cors, logger)
 		})),
 	)
 	if err != nil {
@@ -94,54 +97,31 @@ func main() {
 
 	logger.Info("Application started successfully")
 
-	// Setup graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-	// Wait for shutdown signal
-	<-sigChan
-	logger.Info("Shutting down gracefully...")
-
-	// Graceful shutdown with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
-
-	if err := app.Stop(); err != nil {
+	// Block until SIGINT/SIGTERM, then shut down gracefully; SIGHUP reloads the config
+	reloadConfig := func(map[string]any) error {
+		newCfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		cors.set(newCfg.Auth.AllowedOrigins)
+		logger.Info("CORS origins reloaded", "allowed_origins", newCfg.Auth.AllowedOrigins)
+		if newCfg.Server != cfg.Server || newCfg.Database != cfg.Database ||
+			newCfg.Observability != cfg.Observability || newCfg.Auth.JWTSecret != cfg.Auth.JWTSecret {
+			logger.Info("Server, database, observability and JWT changes take effect after a restart")
+		}
+		return nil
+	}
+	if err := fluxor.RunWithSignals(app, reloadConfig); err != nil {
 		logger.Error("Error during shutdown", "error", err)
 	}
-
-	<-shutdownCtx.Done()
 	logger.Info("Application stopped")
 }
 
 func loadConfig() (*AppConfig, error) {
-	// Default configuration
-	cfg := &AppConfig{
-		Server: ServerConfig{
-			Port:               ":8080",
-			MaxCCU:             5000,
-			UtilizationPercent: 67,
-		},
-		Database: DatabaseConfig{
-			Host:           "localhost",
-			Port:           5432,
-			Database:       "fluxor",
-			User:           "fluxor",
-			Password:       "password",
-			MaxConnections: 100,
-			MinConnections: 10,
-			MaxIdleTime:    300,
-		},
-		Auth: AuthConfig{
-			JWTSecret:      "your-secret-key-change-in-production",
-			AllowedOrigins: []string{"http://localhost:3000"},
-		},
-		Observability: ObservabilityConfig{
-			EnableTracing:  true,
-			EnableMetrics:  true,
-			JaegerEndpoint: "http://localhost:14268/api/traces",
-			PrometheusPort: ":9090",
-		},
+	// Defaults come from the struct tags; a config file, if present, overrides them
+	cfg := &AppConfig{}
+	if err := config.ApplyDefaults(cfg); err != nil {
+		return nil, err
 	}
 
 	// Try to load from config file if exists
@@ -167,10 +147,13 @@ func loadConfig() (*AppConfig, error) {
 		cfg.Auth.JWTSecret = jwtSecret
 	}
 
+	if err := config.ValidateStruct(cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
-func setupEnterpriseApplication(deps map[reflect.Type]interface{}, cfg *AppConfig, logger core.Logger) error {
+func setupEnterpriseApplication(deps map[reflect.Type]interface{}, cfg *AppConfig, cors *reloadableCORS, logger core.Logger) error {
 	vertx := deps[reflect.TypeOf((*core.GoCMD)(nil)).Elem()].(core.GoCMD)
 	eventBus := vertx.EventBus()
 
@@ -253,12 +236,7 @@ func setupEnterpriseApplication(deps map[reflect.Type]interface{}, cfg *AppConfi
 	// 6. Setup Middleware Chain (Express-like)
 
 	// Security middleware (CORS, Security Headers, Rate Limiting)
-	corsMiddleware := security.CORS(security.CORSConfig{
-		AllowedOrigins: cfg.Auth.AllowedOrigins,
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders: []string{"Content-Type", "Authorization"},
-		MaxAge:         3600,
-	})
+	corsMiddleware := cors.middleware()
 
 	securityHeadersMiddleware := security.Headers(security.HeadersConfig{
 		HSTS:                true,
@@ -345,6 +323,9 @@ func setupEnterpriseApplication(deps map[reflect.Type]interface{}, cfg *AppConfi
 	// Add database health check
 	registry.Register("database", health.DatabaseComponentCheck(dbComponent))
 
+	// Add event bus health check (NATS connection when clustered)
+	registry.Register("eventbus", health.EventBusCheck(eventBus))
+
 	// Add external service health check (example - optional, may fail if service doesn't exist)
 	// registry.Register("external_api", health.HTTPCheck("https://api.example.com/health", 5*time.Second))
 
@@ -374,6 +355,35 @@ func setupEnterpriseApplication(deps map[reflect.Type]interface{}, cfg *AppConfi
 	return nil
 }
 
+// reloadableCORS serves CORS through a middleware rebuilt whenever the allowed
+// origins change, so a config reload applies to the next request
+type reloadableCORS struct {
+	current atomic.Value // web.FastMiddleware
+}
+
+func newReloadableCORS(origins []string) *reloadableCORS {
+	r := &reloadableCORS{}
+	r.set(origins)
+	return r
+}
+
+func (r *reloadableCORS) set(origins []string) {
+	r.current.Store(security.CORS(security.CORSConfig{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:         3600,
+	}))
+}
+
+func (r *reloadableCORS) middleware() web.FastMiddleware {
+	return func(next web.FastRequestHandler) web.FastRequestHandler {
+		return func(ctx *web.FastRequestContext) error {
+			return r.current.Load().(web.FastMiddleware)(next)(ctx)
+		}
+	}
+}
+
 // Helper function to apply middleware chain
 func applyMiddleware(middlewares []web.FastMiddleware, handler web.FastRequestHandler) web.FastRequestHandler {
 	result := handler
@@ -478,7 +488,8 @@ func handleGetUsers(eventBus core.EventBus, logger core.Logger) web.FastRequestH
 		logger.WithContext(ctx.Context()).Info("Fetching users")
 
 		// Example: Send request to user service via event bus
-		// msg, err := eventBus.Request("user.service.get", nil, 5*time.Second)
+		// ctx.EventBus carries the request ID to the consumer and its reply
+		// msg, err := ctx.EventBus.Request("user.service.get", nil, 5*time.Second)
 
 		return ctx.JSON(200, map[string]interface{}{
 			"users": []map[string]interface{}{
@@ -2,9 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core"
@@ -69,9 +66,8 @@ func main() {
 	rt.Deploy(&PongReactor{}, nil)
 
 	// Wait for Ctrl+C
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-	<-sig
-
-	rt.Shutdown()
+	fluxor.RunWithSignals(fluxor.StopFunc(func() error {
+		rt.Shutdown()
+		return nil
+	}), nil)
 }
@@ -27,9 +27,11 @@ func main() {
 		// Demo: WorkerPool + Bus (avoid capturing request context into goroutine)
 		wp := c.Worker()
 		bus := c.Bus()
-		wp.Submit(func() {
+		if err := wp.Submit(func() {
 			bus.Publish("log", "Heavy Task Done")
-		})
+		}); err != nil {
+			return c.Error(503, "busy, try again later")
+		}
 
 		return c.Ok(fx.JSON{"status": "processing"})
 	})
@@ -4,10 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core"
@@ -38,15 +35,8 @@ func main() {
 		log.Fatalf("Failed to start Fluxor app: %v", err)
 	}
 
-	// Setup graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-	// Wait for shutdown signal
-	<-sigChan
-	log.Println("Shutting down...")
-
-	if err := app.Stop(); err != nil {
+	// Block until SIGINT/SIGTERM, then shut down gracefully
+	if err := fluxor.RunWithSignals(app, nil); err != nil {
 		log.Fatalf("Error stopping app: %v", err)
 	}
 }
@@ -109,13 +99,7 @@ func setupApplication(deps map[reflect.Type]interface{}) error {
 	})
 
 	// Start metrics updater
-	go func() {
-		ticker := time.NewTicker(5 * time.Second)
-		defer ticker.Stop()
-		for range ticker.C {
-			prometheus.UpdateServerMetrics(server)
-		}
-	}()
+	prometheus.ExportServerMetrics(server, 5*time.Second)
 
 	// Liveness check endpoint (formerly /health)
 	router.GETFast("/live", func(ctx *web.FastRequestContext) error {
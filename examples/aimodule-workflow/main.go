@@ -58,17 +58,9 @@ func createCustomerSupportWorkflow() *workflow.WorkflowDefinition {
 					"maxTokens": 500,
 					"responseField": "response"
 				},
-				"next": ["format-response"]
-			},
-			{
-				"id": "format-response",
-				"type": "set",
-				"name": "Format Response",
-				"config": {
-					"values": {
-						"message": "{{ $.response }}",
-						"timestamp": "{{ $.now }}"
-					}
+				"outputMapping": {
+					"message": "{{ $.response }}",
+					"timestamp": "{{ $.now }}"
 				},
 				"next": ["output"]
 			},
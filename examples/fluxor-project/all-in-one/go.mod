@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/fluxorio/fluxor v0.0.0
 	github.com/quadgatefoundation/fluxor/examples/fluxor-project/api-gateway v0.0.0
+	github.com/quadgatefoundation/fluxor/examples/fluxor-project/common v0.0.0
 	github.com/quadgatefoundation/fluxor/examples/fluxor-project/payment-service v0.0.0
 )
 
@@ -16,7 +17,6 @@ require (
 	github.com/nats-io/nats.go v1.48.0 // indirect
 	github.com/nats-io/nkeys v0.4.12 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
-	github.com/quadgatefoundation/fluxor/examples/fluxor-project/common v0.0.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.68.0 // indirect
 	golang.org/x/crypto v0.46.0 // indirect
@@ -9,6 +9,7 @@ import (
 	"github.com/fluxorio/fluxor/pkg/fluxor"
 
 	apigw "github.com/quadgatefoundation/fluxor/examples/fluxor-project/api-gateway/verticles"
+	"github.com/quadgatefoundation/fluxor/examples/fluxor-project/common/contracts"
 	pay "github.com/quadgatefoundation/fluxor/examples/fluxor-project/payment-service/verticles"
 )
 
@@ -26,10 +27,15 @@ func main() {
 				Prefix:  prefix,
 				Service: "all-in-one",
 			})
-			if err == nil {
-				core.Info(fmt.Sprintf("Connecting to NATS: %s (prefix: %s)", url, prefix))
+			if err != nil {
+				return nil, err
 			}
-			return eventBus, err
+			core.Info(fmt.Sprintf("Connecting to NATS: %s (prefix: %s)", url, prefix))
+
+			// Gateway -> payment requests stay in-process; logs are consumed by other services
+			return core.NewHybridEventBus(ctx, gocmd, eventBus, core.HybridConfig{
+				RemoteAddresses: []string{contracts.AddressLogs},
+			}), nil
 		},
 	})
 	if err != nil {
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/examples/load-balancing/contracts"
@@ -19,7 +18,7 @@ type MasterVerticle struct {
 	*core.BaseVerticle
 
 	workerIDs []string
-	counter   uint64
+	balancer  *core.LoadBalancer // nil without workers
 
 	httpPort     string
 	tcpAddr      string
@@ -30,11 +29,20 @@ type MasterVerticle struct {
 }
 
 func NewMasterVerticle(workerIDs []string) *MasterVerticle {
-	return &MasterVerticle{
+	v := &MasterVerticle{
 		BaseVerticle: core.NewBaseVerticle("master"),
 		workerIDs:    workerIDs,
 		logger:       core.NewDefaultLogger(),
 	}
+	if len(workerIDs) > 0 {
+		addresses := make([]string, len(workerIDs))
+		for i, id := range workerIDs {
+			addresses[i] = fmt.Sprintf("%s.%s", contracts.WorkerAddress, id)
+		}
+		// Least-outstanding keeps a slow worker from piling up requests
+		v.balancer = core.NewLoadBalancer(addresses, core.LoadBalancerConfig{Strategy: core.BalanceLeastOutstanding})
+	}
+	return v
 }
 
 // Start overrides BaseVerticle.Start to call doStart
@@ -99,13 +107,12 @@ func (v *MasterVerticle) startHTTPVerticle(ctx core.FluxorContext) error {
 			payload = "default-data"
 		}
 
-		workerAddr := v.nextWorkerAddress()
 		req := contracts.WorkRequest{
 			ID:      fmt.Sprintf("http-%d", time.Now().UnixNano()),
 			Payload: payload,
 		}
 
-		reply, err := v.EventBus().Request(workerAddr, req, 5*time.Second)
+		reply, err := v.dispatch(v.EventBus(), req)
 		if err != nil {
 			return c.JSON(502, map[string]any{"error": err.Error()})
 		}
@@ -117,11 +124,12 @@ func (v *MasterVerticle) startHTTPVerticle(ctx core.FluxorContext) error {
 
 	r.GET("/status", func(c *web.RequestContext) error {
 		type status struct {
-			Role     string            `json:"role"`
-			Workers  []string          `json:"workers"`
-			TCPAddr  string            `json:"tcp_addr"`
-			HTTPPort string            `json:"http_port"`
-			Metrics  tcp.ServerMetrics `json:"tcp_metrics"`
+			Role     string              `json:"role"`
+			Workers  []string            `json:"workers"`
+			Balancer []core.WorkerStatus `json:"balancer,omitempty"`
+			TCPAddr  string              `json:"tcp_addr"`
+			HTTPPort string              `json:"http_port"`
+			Metrics  tcp.ServerMetrics   `json:"tcp_metrics"`
 		}
 
 		m := tcp.ServerMetrics{}
@@ -131,9 +139,14 @@ func (v *MasterVerticle) startHTTPVerticle(ctx core.FluxorContext) error {
 		if tcpSrv != nil {
 			m = tcpSrv.Metrics()
 		}
+		var balancer []core.WorkerStatus
+		if v.balancer != nil {
+			balancer = v.balancer.Workers()
+		}
 		return c.JSON(200, status{
 			Role:     "master",
 			Workers:  v.workerIDs,
+			Balancer: balancer,
 			TCPAddr:  v.tcpAddr,
 			HTTPPort: v.httpPort,
 			Metrics:  m,
@@ -163,13 +176,12 @@ func (v *MasterVerticle) startTCPServer(ctx core.FluxorContext) {
 			payload = payload[:len(payload)-1]
 		}
 
-		workerAddr := v.nextWorkerAddress()
 		req := contracts.WorkRequest{
 			ID:      fmt.Sprintf("tcp-%d", time.Now().UnixNano()),
 			Payload: payload,
 		}
 
-		reply, reqErr := c.EventBus.Request(workerAddr, req, 5*time.Second)
+		reply, reqErr := v.dispatch(c.EventBus, req)
 		if reqErr != nil {
 			_, _ = c.Conn.Write([]byte(fmt.Sprintf("Error: %v\n", reqErr)))
 			return nil
@@ -200,12 +212,10 @@ func (v *MasterVerticle) startTCPServer(ctx core.FluxorContext) {
 	}()
 }
 
-func (v *MasterVerticle) nextWorkerAddress() string {
-	if len(v.workerIDs) == 0 {
-		// Fail-fast: return empty address if no workers
-		return ""
+// dispatch sends req to a worker picked by the balancer
+func (v *MasterVerticle) dispatch(eb core.EventBus, req contracts.WorkRequest) (core.Message, error) {
+	if v.balancer == nil {
+		return nil, fmt.Errorf("no workers configured")
 	}
-	idx := atomic.AddUint64(&v.counter, 1) % uint64(len(v.workerIDs))
-	workerID := v.workerIDs[idx]
-	return fmt.Sprintf("%s.%s", contracts.WorkerAddress, workerID)
+	return v.balancer.Request(eb, req, 5*time.Second)
 }
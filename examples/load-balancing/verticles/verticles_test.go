@@ -38,33 +38,59 @@ func TestNewMasterVerticle(t *testing.T) {
 	}
 }
 
-func TestMasterVerticle_nextWorkerAddress(t *testing.T) {
-	workerIDs := []string{"1", "2", "3"}
-	master := NewMasterVerticle(workerIDs)
-
-	// Test round-robin distribution
-	addresses := make(map[string]int)
-	for i := 0; i < 9; i++ {
-		addr := master.nextWorkerAddress()
-		addresses[addr]++
+func TestMasterVerticle_dispatch_LeastOutstanding(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// "slow" holds its requests until released; "fast" is a real worker
+	release := make(chan struct{})
+	slow := eb.Consumer(contracts.WorkerAddress + ".slow").Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		<-release
+		return msg.Reply(contracts.WorkResponse{Worker: "slow"})
+	})
+	defer close(release)
+	<-slow.Ready()
+	deploymentID, err := gocmd.DeployVerticle(NewWorkerVerticle("fast"))
+	if err != nil {
+		t.Fatalf("DeployVerticle failed: %v", err)
 	}
-
-	// Should have 3 addresses, each appearing 3 times
-	if len(addresses) != 3 {
-		t.Errorf("Expected 3 unique addresses, got %d", len(addresses))
+	defer gocmd.UndeployVerticle(deploymentID)
+	if err := core.WaitForConsumer(eb, contracts.WorkerAddress+".fast", 2*time.Second); err != nil {
+		t.Fatalf("fast worker not ready: %v", err)
 	}
 
-	for _, count := range addresses {
-		if count != 3 {
-			t.Errorf("Expected each address to appear 3 times, got %d", count)
+	master := NewMasterVerticle([]string{"slow", "fast"})
+	slowBusy := func() bool {
+		for _, w := range master.balancer.Workers() {
+			if w.Address == contracts.WorkerAddress+".slow" && w.Outstanding > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	// Dispatch until a request is stuck on the slow worker
+	deadline := time.Now().Add(2 * time.Second)
+	for !slowBusy() {
+		if time.Now().After(deadline) {
+			t.Fatal("no request reached the slow worker")
 		}
+		go func() { _, _ = master.dispatch(eb, contracts.WorkRequest{ID: "stuck"}) }()
+		time.Sleep(20 * time.Millisecond)
 	}
 
-	// Verify address format
-	for addr := range addresses {
-		expectedPrefix := contracts.WorkerAddress + "."
-		if len(addr) <= len(expectedPrefix) || addr[:len(expectedPrefix)] != expectedPrefix {
-			t.Errorf("Address %s should start with %s", addr, expectedPrefix)
+	// Every new request goes to the worker with nothing in flight
+	for i := 0; i < 4; i++ {
+		reply, err := master.dispatch(eb, contracts.WorkRequest{ID: fmt.Sprintf("req-%d", i), Payload: "data"})
+		if err != nil {
+			t.Fatalf("dispatch %d failed: %v", i, err)
+		}
+		var resp contracts.WorkResponse
+		if err := reply.DecodeBody(&resp); err != nil {
+			t.Fatalf("DecodeBody %d failed: %v", i, err)
+		}
+		if resp.Worker != "fast" {
+			t.Errorf("request %d handled by %q while slow was busy, want fast", i, resp.Worker)
 		}
 	}
 }
@@ -431,15 +457,14 @@ func TestMasterVerticle_HTTPHandler_Process(t *testing.T) {
 	// In a real scenario, we'd make an HTTP request to http://localhost:8080/process?data=test
 
 	// Test the handler logic by simulating what it does
-	workerAddr := master.nextWorkerAddress()
 	req := contracts.WorkRequest{
 		ID:      "http-test-1",
 		Payload: "test-data",
 	}
 
-	reply, err := gocmd.EventBus().Request(workerAddr, req, 5*time.Second)
+	reply, err := master.dispatch(gocmd.EventBus(), req)
 	if err != nil {
-		t.Fatalf("Request failed: %v", err)
+		t.Fatalf("dispatch failed: %v", err)
 	}
 
 	var resp contracts.WorkResponse
@@ -524,15 +549,14 @@ func TestMasterVerticle_HTTPHandler_Process_DefaultPayload(t *testing.T) {
 	}
 
 	// Test with empty payload (should use "default-data")
-	workerAddr := master.nextWorkerAddress()
 	req := contracts.WorkRequest{
 		ID:      "http-default-test",
 		Payload: "", // Empty payload
 	}
 
-	reply, err := gocmd.EventBus().Request(workerAddr, req, 5*time.Second)
+	reply, err := master.dispatch(gocmd.EventBus(), req)
 	if err != nil {
-		t.Fatalf("Request failed: %v", err)
+		t.Fatalf("dispatch failed: %v", err)
 	}
 
 	var resp contracts.WorkResponse
@@ -569,13 +593,12 @@ func TestMasterVerticle_HTTPHandler_Process_Error(t *testing.T) {
 	}
 
 	// Try to request from non-existent worker (should fail)
-	workerAddr := master.nextWorkerAddress()
 	req := contracts.WorkRequest{
 		ID:      "error-test",
 		Payload: "test",
 	}
 
-	_, err = gocmd.EventBus().Request(workerAddr, req, 1*time.Second)
+	_, err = master.dispatch(gocmd.EventBus(), req)
 	if err == nil {
 		t.Error("Expected error for non-existent worker, got nil")
 	}
@@ -622,13 +645,11 @@ func TestMasterVerticle_startTCPServer(t *testing.T) {
 	}
 }
 
-func TestMasterVerticle_nextWorkerAddress_EmptyWorkers(t *testing.T) {
+func TestMasterVerticle_dispatch_NoWorkers(t *testing.T) {
 	master := NewMasterVerticle([]string{})
 
-	// This should return empty string for empty workers (fail-fast behavior)
-	addr := master.nextWorkerAddress()
-	if addr != "" {
-		t.Errorf("Expected empty address for empty workers, got %s", addr)
+	if _, err := master.dispatch(nil, contracts.WorkRequest{ID: "none"}); err == nil {
+		t.Error("Expected an error without workers, got nil")
 	}
 }
 
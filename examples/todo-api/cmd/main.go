@@ -5,14 +5,13 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	"github.com/fluxorio/fluxor/examples/todo-api/pkg/auth"
 	"github.com/fluxorio/fluxor/examples/todo-api/pkg/todo"
 	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/fluxor"
 	"github.com/fluxorio/fluxor/pkg/fx"
 	"github.com/fluxorio/fluxor/pkg/observability/prometheus"
 	"github.com/fluxorio/fluxor/pkg/web"
@@ -51,13 +50,8 @@ func main() {
 		log.Fatalf("Failed to start app: %v", err)
 	}
 
-	// Graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-	log.Println("Shutting down...")
-
-	if err := app.Stop(); err != nil {
+	// Block until SIGINT/SIGTERM, then shut down gracefully
+	if err := fluxor.RunWithSignals(app, nil); err != nil {
 		log.Fatalf("Error stopping app: %v", err)
 	}
 }
@@ -131,13 +125,7 @@ func setupApplication(deps map[reflect.Type]interface{}) error {
 	})
 
 	// Start Metrics Updater
-	go func() {
-		ticker := time.NewTicker(5 * time.Second)
-		defer ticker.Stop()
-		for range ticker.C {
-			prometheus.UpdateServerMetrics(server)
-		}
-	}()
+	prometheus.ExportServerMetrics(server, 5*time.Second)
 
 	// Start Server
 	go func() {
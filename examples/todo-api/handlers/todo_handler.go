@@ -108,29 +108,22 @@ func (h *TodoHandler) ListTodos(ctx *web.FastRequestContext) error {
 	}
 
 	// Parse query parameters
-	page := 1
-	pageSize := 20
-	var completed *bool
-
-	if pageStr := ctx.Query("page"); pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-
-	if sizeStr := ctx.Query("page_size"); sizeStr != "" {
-		if s, err := strconv.Atoi(sizeStr); err == nil && s > 0 && s <= 100 {
-			pageSize = s
-		}
+	pagination, err := web.ParsePagination(ctx, web.DefaultPaginationConfig())
+	if err != nil {
+		return ctx.JSON(400, map[string]interface{}{
+			"error":   "invalid_pagination",
+			"message": err.Error(),
+		})
 	}
 
+	var completed *bool
 	if completedStr := ctx.Query("completed"); completedStr != "" {
 		if c, err := strconv.ParseBool(completedStr); err == nil {
 			completed = &c
 		}
 	}
 
-	result, err := h.todoService.ListTodos(ctx.Context(), userID, page, pageSize, completed)
+	result, err := h.todoService.ListTodos(ctx.Context(), userID, pagination.Page, pagination.PageSize, completed)
 	if err != nil {
 		return ctx.JSON(500, map[string]interface{}{
 			"error":   "list_failed",
@@ -3,11 +3,11 @@ package main
 import (
 	"context"
 	"database/sql"
+	"embed"
+	"io/fs"
 	"log"
 	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -17,6 +17,7 @@ import (
 	"github.com/fluxorio/fluxor/examples/todo-api/services"
 	"github.com/fluxorio/fluxor/pkg/core"
 	"github.com/fluxorio/fluxor/pkg/db"
+	"github.com/fluxorio/fluxor/pkg/fluxor"
 	"github.com/fluxorio/fluxor/pkg/fx"
 	"github.com/fluxorio/fluxor/pkg/observability/prometheus"
 	"github.com/fluxorio/fluxor/pkg/web"
@@ -25,6 +26,11 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// migrations holds the schema, applied in order by db.Migrator at startup
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -56,7 +62,15 @@ func main() {
 	defer dbPool.Close()
 
 	// Run migrations
-	if err := runMigrations(dbPool.DB()); err != nil {
+	migrationFS, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	migrator, err := db.NewMigrator(dbPool.DB(), migrationFS, db.MigratorConfig{})
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -74,10 +88,6 @@ func main() {
 		log.Fatalf("Failed to start Fluxor app: %v", err)
 	}
 
-	// Setup graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
 	log.Println("Todo API server started successfully!")
 	log.Println("API endpoints:")
 	log.Println("  POST   /api/auth/register - Register a new user")
@@ -92,11 +102,8 @@ func main() {
 	log.Println("  GET    /health - Health check")
 	log.Println("  GET    /ready - Readiness check")
 
-	// Wait for shutdown signal
-	<-sigChan
-	log.Println("Shutting down...")
-
-	if err := app.Stop(); err != nil {
+	// Block until SIGINT/SIGTERM, then shut down gracefully
+	if err := fluxor.RunWithSignals(app, nil); err != nil {
 		log.Fatalf("Error stopping app: %v", err)
 	}
 }
@@ -123,43 +130,37 @@ func setupApplication(database *sql.DB, jwtSecret string) func(map[reflect.Type]
 		// Setup routes
 		router := server.FastRouter()
 
-		// Create metrics middleware
-		metricsMiddleware := middleware.MetricsMiddleware()
+		// Metrics for every request, including 404s
+		router.UseFast(middleware.MetricsMiddleware())
 
-		// Public routes (no auth required) - wrap with metrics middleware
-		router.POSTFast("/api/auth/register", metricsMiddleware(authHandler.Register))
-		router.POSTFast("/api/auth/login", metricsMiddleware(authHandler.Login))
+		// Public routes (no auth required)
+		router.POSTFast("/api/auth/register", authHandler.Register)
+		router.POSTFast("/api/auth/login", authHandler.Login)
 
-		// Protected routes (require JWT auth)
-		// Apply JWT middleware to protected routes
+		// Protected routes (require JWT auth, rate limited)
 		jwtConfig := auth.DefaultJWTConfig(jwtSecret)
 		jwtConfig.SkipPaths = []string{"/api/auth/register", "/api/auth/login", "/health", "/ready", "/metrics"}
-		jwtMiddleware := auth.JWT(jwtConfig)
-
-		// Apply rate limiting to protected routes
 		rateLimitConfig := security.DefaultRateLimitConfig()
 		rateLimitConfig.RequestsPerMinute = 100 // 100 requests per minute per IP
-		rateLimitMiddleware := security.RateLimit(rateLimitConfig)
+		protected := router.Group("/api", auth.JWT(jwtConfig), security.RateLimit(rateLimitConfig))
 
-		// Protected auth routes - wrap with metrics, jwt, and rate limit middleware
-		router.GETFast("/api/auth/profile", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(authHandler.GetProfile))))
+		protected.GETFast("/auth/profile", authHandler.GetProfile)
 
-		// Protected todo routes - wrap with metrics, jwt, and rate limit middleware
-		router.GETFast("/api/todos", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(todoHandler.ListTodos))))
-		router.POSTFast("/api/todos", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(todoHandler.CreateTodo))))
-		router.GETFast("/api/todos/:id", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(todoHandler.GetTodo))))
-		router.PUTFast("/api/todos/:id", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(todoHandler.UpdateTodo))))
-		router.DELETEFast("/api/todos/:id", metricsMiddleware(jwtMiddleware(rateLimitMiddleware(todoHandler.DeleteTodo))))
+		protected.GETFast("/todos", todoHandler.ListTodos)
+		protected.POSTFast("/todos", todoHandler.CreateTodo)
+		protected.GETFast("/todos/:id", todoHandler.GetTodo)
+		protected.PUTFast("/todos/:id", todoHandler.UpdateTodo)
+		protected.DELETEFast("/todos/:id", todoHandler.DeleteTodo)
 
-		// Health and metrics endpoints - wrap with metrics middleware
-		router.GETFast("/health", metricsMiddleware(func(ctx *web.FastRequestContext) error {
+		// Health and readiness endpoints
+		router.GETFast("/health", func(ctx *web.FastRequestContext) error {
 			return ctx.JSON(200, map[string]interface{}{
 				"status":  "UP",
 				"service": "todo-api",
 			})
-		}))
+		})
 
-		router.GETFast("/ready", metricsMiddleware(func(ctx *web.FastRequestContext) error {
+		router.GETFast("/ready", func(ctx *web.FastRequestContext) error {
 			metrics := server.Metrics()
 			ready := metrics.QueueUtilization < 90.0 && metrics.CCUUtilization < 90.0
 			statusCode := 200
@@ -175,7 +176,7 @@ func setupApplication(database *sql.DB, jwtSecret string) func(map[reflect.Type]
 				}(),
 				"metrics": metrics,
 			})
-		}))
+		})
 
 		// Prometheus metrics endpoint
 		prometheus.RegisterMetricsEndpoint(router, "/metrics")
@@ -203,55 +204,3 @@ func setupApplication(database *sql.DB, jwtSecret string) func(map[reflect.Type]
 		return nil
 	}
 }
-
-func runMigrations(db *sql.DB) error {
-	migrationSQL := `
-	-- Create users table
-	CREATE TABLE IF NOT EXISTS users (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		username VARCHAR(255) UNIQUE NOT NULL,
-		email VARCHAR(255) UNIQUE NOT NULL,
-		password_hash VARCHAR(255) NOT NULL,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Create todos table
-	CREATE TABLE IF NOT EXISTS todos (
-		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-		user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-		title VARCHAR(500) NOT NULL,
-		description TEXT,
-		completed BOOLEAN DEFAULT FALSE,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Create indexes
-	CREATE INDEX IF NOT EXISTS idx_todos_user_id ON todos(user_id);
-	CREATE INDEX IF NOT EXISTS idx_todos_completed ON todos(completed);
-	CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
-	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
-
-	-- Create updated_at trigger function
-	CREATE OR REPLACE FUNCTION update_updated_at_column()
-	RETURNS TRIGGER AS $$
-	BEGIN
-		NEW.updated_at = CURRENT_TIMESTAMP;
-		RETURN NEW;
-	END;
-	$$ language 'plpgsql';
-
-	-- Create triggers
-	DROP TRIGGER IF EXISTS update_users_updated_at ON users;
-	CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
-		FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
-	DROP TRIGGER IF EXISTS update_todos_updated_at ON todos;
-	CREATE TRIGGER update_todos_updated_at BEFORE UPDATE ON todos
-		FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-	`
-
-	_, err := db.Exec(migrationSQL)
-	return err
-}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // Loader loads configuration from various sources
@@ -32,31 +33,48 @@ func (f ValidatorFunc) Validate(config interface{}) error {
 
 // Load loads configuration from a file (YAML or JSON)
 // Automatically detects file type by extension
+// Fields are defaulted from their default tags first and checked against their
+// validate tags after (see ApplyDefaults and ValidateStruct)
 func Load(path string, target interface{}) error {
-	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
-		return LoadYAML(path, target)
-	}
+	return loadValidated(path, target, decodeFile)
+}
+
+// decodeFile reads path into target by extension, without defaults or validation
+func decodeFile(path string, target interface{}) error {
 	if strings.HasSuffix(path, ".json") {
-		return LoadJSON(path, target)
+		return decodeJSON(path, target)
 	}
 	// Default to YAML
-	return LoadYAML(path, target)
+	return decodeYAML(path, target)
+}
+
+// loadValidated applies defaults, runs decode, then checks validate tags
+func loadValidated(path string, target interface{}, decode func(string, interface{}) error) error {
+	if err := ApplyDefaults(target); err != nil {
+		return err
+	}
+	if err := decode(path, target); err != nil {
+		return err
+	}
+	return ValidateStruct(target)
 }
 
 // LoadWithEnv loads configuration from file and applies environment variable overrides
 // Environment variables use format: PREFIX_FIELD_SUBFIELD (e.g., APP_DATABASE_DSN)
+// Validate tags are checked after the overrides are applied
 func LoadWithEnv(path string, prefix string, target interface{}) error {
-	// Load from file first
-	if err := Load(path, target); err != nil {
-		return fmt.Errorf("failed to load config file: %w", err)
-	}
-
-	// Apply environment variable overrides
-	if err := ApplyEnvOverrides(prefix, target); err != nil {
-		return fmt.Errorf("failed to apply env overrides: %w", err)
-	}
+	return loadValidated(path, target, func(path string, target interface{}) error {
+		// Load from file first
+		if err := decodeFile(path, target); err != nil {
+			return fmt.Errorf("failed to load config file: %w", err)
+		}
 
-	return nil
+		// Apply environment variable overrides
+		if err := ApplyEnvOverrides(prefix, target); err != nil {
+			return fmt.Errorf("failed to apply env overrides: %w", err)
+		}
+		return nil
+	})
 }
 
 // ApplyEnvOverrides applies environment variable overrides to configuration struct
@@ -127,6 +145,15 @@ func applyEnvToStruct(prefix string, val reflect.Value) error {
 
 // setFieldFromEnv sets a struct field value from environment variable string
 func setFieldFromEnv(field reflect.Value, envValue string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(envValue)
+		if err != nil {
+			return fmt.Errorf("invalid duration value: %s", envValue)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(envValue)
@@ -6,8 +6,14 @@ import (
 	"os"
 )
 
-// LoadJSON loads configuration from a JSON file
+// LoadJSON loads configuration from a JSON file, applying default tags
+// before and checking validate tags after
 func LoadJSON(path string, target interface{}) error {
+	return loadValidated(path, target, decodeJSON)
+}
+
+// decodeJSON reads a JSON file into target
+func decodeJSON(path string, target interface{}) error {
 	// #nosec G304 -- path is provided by the caller (library function); callers should validate/lock down inputs if untrusted.
 	data, err := os.ReadFile(path)
 	if err != nil {
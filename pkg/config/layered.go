@@ -0,0 +1,269 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// LayeredLoader loads configuration from an ordered list of sources. Later
+// sources override earlier ones, field by field:
+//
+//	var cfg AppConfig
+//	loader := config.NewLoader().
+//		AddFile("config.yaml").
+//		AddEnvironmentFile(os.Getenv("APP_ENV")). // config.production.yaml, if present
+//		AddEnvVars("APP").                        // APP_SERVER_PORT=9090
+//		AddFlags(flag.CommandLine)                // -server.port=9090
+//	if err := loader.Load(&cfg); err != nil { ... }
+//	loader.Dump(os.Stdout) // effective values and where each came from
+//
+// default tags are applied before the first source and validate tags are
+// checked after the last one, as with Load.
+type LayeredLoader struct {
+	layers   []layer
+	lastFile string            // Base for AddEnvironmentFile
+	sources  map[string]string // Field path -> source that last changed it
+	values   map[string]interface{}
+	order    []string // Field paths in struct order
+}
+
+// layer is one source: label names it in Sources and Dump
+type layer struct {
+	label string
+	apply func(target interface{}) error
+}
+
+// NewLoader creates an empty LayeredLoader
+func NewLoader() *LayeredLoader {
+	return &LayeredLoader{}
+}
+
+// AddFile adds a YAML or JSON file (by extension); a missing file fails Load
+func (l *LayeredLoader) AddFile(path string) *LayeredLoader {
+	l.lastFile = path
+	l.layers = append(l.layers, layer{label: "file " + path, apply: func(target interface{}) error {
+		return decodeFile(path, target)
+	}})
+	return l
+}
+
+// AddEnvironmentFile adds the environment-specific variant of the last file added,
+// e.g. config.production.yaml for config.yaml and env "production". It is skipped
+// if env is empty or the file does not exist.
+func (l *LayeredLoader) AddEnvironmentFile(env string) *LayeredLoader {
+	if env == "" {
+		return l
+	}
+	base := l.lastFile
+	if base == "" {
+		l.layers = append(l.layers, layer{label: "env file " + env, apply: func(interface{}) error {
+			return fmt.Errorf("AddEnvironmentFile(%q) needs a file added with AddFile first", env)
+		}})
+		return l
+	}
+	ext := filepath.Ext(base)
+	path := strings.TrimSuffix(base, ext) + "." + env + ext
+	l.layers = append(l.layers, layer{label: "file " + path, apply: func(target interface{}) error {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return decodeFile(path, target)
+	}})
+	return l
+}
+
+// AddEnvVars adds environment variable overrides named PREFIX_FIELD_SUBFIELD
+// (see ApplyEnvOverrides)
+func (l *LayeredLoader) AddEnvVars(prefix string) *LayeredLoader {
+	l.layers = append(l.layers, layer{label: "env " + prefix, apply: func(target interface{}) error {
+		return ApplyEnvOverrides(prefix, target)
+	}})
+	return l
+}
+
+// AddFlags adds the flags of fs that were set on the command line (fs must be parsed
+// before Load). A flag overrides the field whose dotted path it names, by Go field
+// name or yaml/json tag, case-insensitively: -server.port or -Server.Port.
+// Flags that name no field are ignored.
+func (l *LayeredLoader) AddFlags(fs *flag.FlagSet) *LayeredLoader {
+	l.layers = append(l.layers, layer{label: "flags", apply: func(target interface{}) error {
+		return applyFlags(fs, target)
+	}})
+	return l
+}
+
+// Load applies default tags, then every source in order, then checks validate tags.
+// target must be a pointer to a struct.
+func (l *LayeredLoader) Load(target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a pointer to a struct")
+	}
+
+	l.sources = make(map[string]string)
+	l.values = nil
+	l.order = nil
+	before := snapshot(target)
+
+	if err := ApplyDefaults(target); err != nil {
+		return err
+	}
+	before = l.record("default", before, target)
+
+	for _, ly := range l.layers {
+		if err := ly.apply(target); err != nil {
+			return fmt.Errorf("config %s: %w", ly.label, err)
+		}
+		before = l.record(ly.label, before, target)
+	}
+
+	l.values = before
+	return ValidateStruct(target)
+}
+
+// record attributes the fields that changed since before to source and returns the new snapshot
+func (l *LayeredLoader) record(source string, before map[string]interface{}, target interface{}) map[string]interface{} {
+	after := snapshot(target)
+	l.order = l.order[:0]
+	for _, f := range leafFields(reflect.ValueOf(target).Elem(), "", "") {
+		l.order = append(l.order, f.path)
+		if !reflect.DeepEqual(before[f.path], after[f.path]) {
+			l.sources[f.path] = source
+		}
+	}
+	return after
+}
+
+// Sources returns the source that set each field in the last Load, keyed by dotted
+// Go field path (e.g. "Server.Port"). Fields left at their zero value are absent.
+func (l *LayeredLoader) Sources() map[string]string {
+	sources := make(map[string]string, len(l.sources))
+	for k, v := range l.sources {
+		sources[k] = v
+	}
+	return sources
+}
+
+// Dump writes the effective configuration from the last Load, one "path = value
+// (source)" line per field, for debugging. Values of fields whose name contains
+// "secret", "password" or "token" are masked.
+func (l *LayeredLoader) Dump(w io.Writer) error {
+	paths := append([]string(nil), l.order...)
+	if len(paths) == 0 {
+		for path := range l.values {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+	}
+	for _, path := range paths {
+		source := l.sources[path]
+		if source == "" {
+			source = "unset"
+		}
+		value := fmt.Sprintf("%v", l.values[path])
+		if isSecretField(path) && value != "" {
+			value = "******"
+		}
+		if _, err := fmt.Fprintf(w, "%s = %s (%s)\n", path, value, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSecretField(path string) bool {
+	lower := strings.ToLower(path)
+	for _, word := range []string{"secret", "password", "token"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// leafField is a non-struct field reached from the config root
+type leafField struct {
+	path    string // Dotted Go field path
+	tagPath string // Dotted yaml/json tag path
+	value   reflect.Value
+}
+
+// leafFields lists the exported non-struct fields of val, recursing into nested structs
+func leafFields(val reflect.Value, path, tagPath string) []leafField {
+	var fields []leafField
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		name := joinPath(path, fieldType.Name)
+		tagName := joinPath(tagPath, fieldTagName(fieldType))
+		if nested, ok := nestedStruct(field); ok && !isLeafStruct(nested) {
+			fields = append(fields, leafFields(nested, name, tagName)...)
+			continue
+		}
+		fields = append(fields, leafField{path: name, tagPath: tagName, value: field})
+	}
+	return fields
+}
+
+// isLeafStruct reports struct types set as one value (e.g. time.Time)
+func isLeafStruct(val reflect.Value) bool {
+	return val.Type().PkgPath() == "time"
+}
+
+// fieldTagName returns the yaml, then json, tag name of a field, or its Go name
+func fieldTagName(field reflect.StructField) string {
+	for _, key := range []string{"yaml", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// snapshot copies every leaf value of target, keyed by Go field path
+func snapshot(target interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, f := range leafFields(reflect.ValueOf(target).Elem(), "", "") {
+		v := f.value.Interface()
+		if f.value.Kind() == reflect.Slice && !f.value.IsNil() {
+			// Copy so an in-place change is still seen as a change
+			cp := reflect.MakeSlice(f.value.Type(), f.value.Len(), f.value.Len())
+			reflect.Copy(cp, f.value)
+			v = cp.Interface()
+		}
+		values[f.path] = v
+	}
+	return values
+}
+
+// applyFlags sets the fields named by the flags set on fs
+func applyFlags(fs *flag.FlagSet, target interface{}) error {
+	fields := leafFields(reflect.ValueOf(target).Elem(), "", "")
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		for _, field := range fields {
+			if !strings.EqualFold(f.Name, field.path) && !strings.EqualFold(f.Name, field.tagPath) {
+				continue
+			}
+			if setErr := setFieldFromEnv(field.value, f.Value.String()); setErr != nil {
+				err = fmt.Errorf("failed to set field %s from flag -%s: %w", field.path, f.Name, setErr)
+			}
+			return
+		}
+	})
+	return err
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type layeredConfig struct {
+	Name   string `yaml:"name" default:"app"`
+	Server struct {
+		Host string `yaml:"host" default:"localhost"`
+		Port int    `yaml:"port" validate:"min=1"`
+	} `yaml:"server"`
+	Database struct {
+		DSN      string `yaml:"dsn"`
+		Password string `yaml:"password"`
+	} `yaml:"database"`
+}
+
+func TestLayeredLoader_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	writeFile(t, base, "server:\n  port: 8080\ndatabase:\n  dsn: postgres://base\n  password: base-secret\n")
+	writeFile(t, filepath.Join(dir, "config.production.yaml"), "server:\n  port: 9090\ndatabase:\n  dsn: postgres://prod\n")
+	t.Setenv("LAYERED_DATABASE_DSN", "postgres://env")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("server.port", 0, "")
+	fs.String("unrelated", "", "")
+	if err := fs.Parse([]string{"-server.port=7070", "-unrelated=x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg layeredConfig
+	loader := NewLoader().
+		AddFile(base).
+		AddEnvironmentFile("production").
+		AddEnvironmentFile(""). // skipped
+		AddEnvVars("LAYERED").
+		AddFlags(fs)
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Name != "app" || cfg.Server.Host != "localhost" {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if cfg.Server.Port != 7070 {
+		t.Errorf("Server.Port = %d, want 7070 from the flag", cfg.Server.Port)
+	}
+	if cfg.Database.DSN != "postgres://env" {
+		t.Errorf("Database.DSN = %q, want the env value", cfg.Database.DSN)
+	}
+	if cfg.Database.Password != "base-secret" {
+		t.Errorf("Database.Password = %q, want the base file value", cfg.Database.Password)
+	}
+
+	sources := loader.Sources()
+	want := map[string]string{
+		"Name":              "default",
+		"Server.Port":       "flags",
+		"Database.DSN":      "env LAYERED",
+		"Database.Password": "file " + base,
+	}
+	for path, source := range want {
+		if sources[path] != source {
+			t.Errorf("Sources()[%s] = %q, want %q", path, sources[path], source)
+		}
+	}
+
+	var dump strings.Builder
+	if err := loader.Dump(&dump); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	for _, line := range []string{"Server.Port = 7070 (flags)", "Database.Password = ****** (file " + base + ")"} {
+		if !strings.Contains(dump.String(), line) {
+			t.Errorf("Dump() missing %q:\n%s", line, dump.String())
+		}
+	}
+}
+
+func TestLayeredLoader_Errors(t *testing.T) {
+	var cfg layeredConfig
+	if err := NewLoader().AddFile(filepath.Join(t.TempDir(), "missing.yaml")).Load(&cfg); err == nil {
+		t.Error("Load() should fail on a missing file")
+	}
+	if err := NewLoader().AddEnvironmentFile("production").Load(&cfg); err == nil {
+		t.Error("Load() should fail when AddEnvironmentFile has no base file")
+	}
+	// validate tags are checked after every source: Server.Port has min=1
+	if err := NewLoader().Load(&cfg); err == nil {
+		t.Error("Load() should return the validation error")
+	}
+	if err := NewLoader().Load(cfg); err == nil {
+		t.Error("Load() should reject a non-pointer target")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
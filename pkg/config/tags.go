@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Struct tags understood by the loaders:
+//
+//	type ServerConfig struct {
+//		Port     int    `yaml:"port" default:"8080" validate:"min=1,max=65535"`
+//		LogLevel string `yaml:"log_level" default:"info" validate:"oneof=debug info warn error"`
+//		Name     string `yaml:"name" validate:"required"`
+//	}
+//
+// default is applied to fields before the file is read, so a value in the file
+// (or an env override) wins. validate is checked after loading; min/max bound
+// numbers by value and strings, slices and maps by length.
+
+// FieldViolation is one field that failed its validate tag
+type FieldViolation struct {
+	Field      string // Dotted Go field path, e.g. "Database.MaxConns"
+	Constraint string // The failing rule, e.g. "max=100"
+	Message    string
+}
+
+// ValidationError lists every field that failed its validate tag
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("%s: %s", v.Field, v.Message)
+	}
+	return "invalid config: " + strings.Join(parts, "; ")
+}
+
+// ApplyDefaults sets zero-valued fields of target (a pointer to a struct) from their
+// default tags, recursing into nested structs. Non-struct targets are left unchanged.
+func ApplyDefaults(target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return applyDefaultsToStruct("", val.Elem())
+}
+
+func applyDefaultsToStruct(path string, val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		name := joinPath(path, fieldType.Name)
+
+		if nested, ok := nestedStruct(field); ok {
+			if err := applyDefaultsToStruct(name, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		def, ok := fieldType.Tag.Lookup("default")
+		if !ok || !field.IsZero() {
+			continue
+		}
+		if err := setFieldFromEnv(field, def); err != nil {
+			return fmt.Errorf("invalid default for field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateStruct checks the validate tags of target (a struct or pointer to one) and
+// returns a *ValidationError listing every violation. Non-struct targets pass.
+func ValidateStruct(target interface{}) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var violations []FieldViolation
+	if err := validateStruct("", val, &violations); err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// StructTags returns a Validator that checks validate tags, for use with Manager
+func StructTags() Validator {
+	return ValidatorFunc(ValidateStruct)
+}
+
+func validateStruct(path string, val reflect.Value, violations *[]FieldViolation) error {
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		name := joinPath(path, fieldType.Name)
+
+		if rules := fieldType.Tag.Get("validate"); rules != "" {
+			for _, rule := range strings.Split(rules, ",") {
+				msg, err := checkRule(field, strings.TrimSpace(rule))
+				if err != nil {
+					return fmt.Errorf("invalid validate tag on field %s: %w", name, err)
+				}
+				if msg != "" {
+					*violations = append(*violations, FieldViolation{Field: name, Constraint: rule, Message: msg})
+				}
+			}
+		}
+
+		if nested, ok := nestedStruct(field); ok {
+			if err := validateStruct(name, nested, violations); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRule returns a message describing how field breaks rule, or "" if it does not
+func checkRule(field reflect.Value, rule string) (string, error) {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if isEmpty(field) {
+			return "is required", nil
+		}
+	case "min", "max":
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s needs a number, got %q", name, arg)
+		}
+		n, isLength, ok := measure(field)
+		if !ok {
+			return "", fmt.Errorf("%s does not apply to %s", name, field.Kind())
+		}
+		what := "value"
+		if isLength {
+			what = "length"
+		}
+		if name == "min" && n < bound {
+			return fmt.Sprintf("%s %v is less than min %v", what, n, bound), nil
+		}
+		if name == "max" && n > bound {
+			return fmt.Sprintf("%s %v is greater than max %v", what, n, bound), nil
+		}
+	case "oneof":
+		allowed := strings.Fields(arg)
+		got := fmt.Sprint(field.Interface())
+		for _, a := range allowed {
+			if got == a {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("value %q is not one of [%s]", got, strings.Join(allowed, " ")), nil
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+	return "", nil
+}
+
+// measure returns the number min/max compare: the value of a number, the length of anything else
+func measure(field reflect.Value) (n float64, isLength bool, ok bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(field.Len()), true, true
+	default:
+		return 0, false, false
+	}
+}
+
+// nestedStruct returns the struct a field holds directly or through a non-nil pointer
+func nestedStruct(field reflect.Value) (reflect.Value, bool) {
+	if field.Kind() == reflect.Ptr && !field.IsNil() {
+		field = field.Elem()
+	}
+	return field, field.Kind() == reflect.Struct
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+type TaggedConfig struct {
+	Server struct {
+		Port    int           `yaml:"port" default:"8080" validate:"min=1,max=65535"`
+		Host    string        `yaml:"host" default:"localhost"`
+		Timeout time.Duration `yaml:"timeout" default:"5s"`
+	} `yaml:"server"`
+	LogLevel string   `yaml:"log_level" default:"info" validate:"oneof=debug info warn error"`
+	Origins  []string `yaml:"origins" default:"http://a,http://b"`
+	Name     string   `yaml:"name" validate:"required,max=8"`
+}
+
+func TestLoadYAML_AppliesDefaults(t *testing.T) {
+	tmpFile := createTempFile(t, "tagged.yaml", "name: api\nserver:\n  port: 9000\n")
+	defer os.Remove(tmpFile)
+
+	var cfg TaggedConfig
+	if err := LoadYAML(tmpFile, &cfg); err != nil {
+		t.Fatalf("LoadYAML failed: %v", err)
+	}
+
+	// Values in the file win over defaults
+	if cfg.Server.Port != 9000 {
+		t.Errorf("Server.Port = %d, want 9000", cfg.Server.Port)
+	}
+	if cfg.Server.Host != "localhost" || cfg.Server.Timeout != 5*time.Second || cfg.LogLevel != "info" {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+	if len(cfg.Origins) != 2 || cfg.Origins[1] != "http://b" {
+		t.Errorf("Origins = %v", cfg.Origins)
+	}
+}
+
+func TestLoadYAML_ValidationErrorListsEveryField(t *testing.T) {
+	tmpFile := createTempFile(t, "invalid.yaml", "log_level: trace\nserver:\n  port: 70000\n")
+	defer os.Remove(tmpFile)
+
+	var cfg TaggedConfig
+	err := LoadYAML(tmpFile, &cfg)
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	got := map[string]string{}
+	for _, v := range verr.Violations {
+		got[v.Field] = v.Constraint
+	}
+	want := map[string]string{"Server.Port": "max=65535", "LogLevel": "oneof=debug info warn error", "Name": "required"}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %+v, want %v", verr.Violations, want)
+	}
+	for field, constraint := range want {
+		if got[field] != constraint {
+			t.Errorf("%s violated %q, want %q", field, got[field], constraint)
+		}
+	}
+}
+
+func TestLoadWithEnv_ValidatesAfterOverrides(t *testing.T) {
+	tmpFile := createTempFile(t, "env.yaml", "name: api\nserver:\n  port: 0\n")
+	defer os.Remove(tmpFile)
+
+	t.Setenv("TAGGED_SERVER_PORT", "8443")
+
+	var cfg TaggedConfig
+	if err := LoadWithEnv(tmpFile, "TAGGED", &cfg); err != nil {
+		t.Fatalf("LoadWithEnv failed: %v", err)
+	}
+	if cfg.Server.Port != 8443 {
+		t.Errorf("Server.Port = %d, want 8443", cfg.Server.Port)
+	}
+}
+
+func TestValidateStruct_LengthAndBadTags(t *testing.T) {
+	cfg := TaggedConfig{Name: "much-too-long", LogLevel: "info"}
+	cfg.Server.Port = 80
+	if err := ValidateStruct(&cfg); err == nil {
+		t.Error("expected max length violation for Name")
+	}
+
+	var bad struct {
+		Port int `validate:"between=1"`
+	}
+	var verr *ValidationError
+	if err := ValidateStruct(&bad); err == nil || errors.As(err, &verr) {
+		t.Errorf("error = %v, want invalid tag error", err)
+	}
+}
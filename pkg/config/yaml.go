@@ -7,8 +7,14 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// LoadYAML loads configuration from a YAML file
+// LoadYAML loads configuration from a YAML file, applying default tags
+// before and checking validate tags after
 func LoadYAML(path string, target interface{}) error {
+	return loadValidated(path, target, decodeYAML)
+}
+
+// decodeYAML reads a YAML file into target
+func decodeYAML(path string, target interface{}) error {
 	// #nosec G304 -- path is provided by the caller (library function); callers should validate/lock down inputs if untrusted.
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// AuditAddress is the EventBus address EventBusAuditSink publishes entries to
+const AuditAddress = "fluxor.audit"
+
+// DefaultAuditBuffer is how many entries the provided sinks hold before dropping
+const DefaultAuditBuffer = 1024
+
+// AuditEntry is one record of the audit trail the orchestration engines write,
+// e.g. a workflow node execution
+type AuditEntry struct {
+	Time       time.Time              `json:"time"`
+	Source     string                 `json:"source"`               // Subsystem, e.g. "workflow"
+	Event      string                 `json:"event"`                // e.g. "node.completed"
+	Definition string                 `json:"definition,omitempty"` // Workflow ID
+	InstanceID string                 `json:"instanceId,omitempty"` // Execution ID
+	Step       string                 `json:"step,omitempty"`       // Node ID
+	Status     string                 `json:"status,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+// AuditSink receives audit entries. Engines call Record on their own goroutines,
+// so it must not block: the provided sinks queue entries and write them in the
+// background, dropping entries when the queue is full.
+type AuditSink interface {
+	Record(entry AuditEntry)
+}
+
+// auditQueue writes entries in the background, dropping them when full
+type auditQueue struct {
+	entries chan AuditEntry
+	write   func(AuditEntry) error
+	logger  Logger
+	dropped int64
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAuditQueue(buffer int, write func(AuditEntry) error) *auditQueue {
+	if buffer <= 0 {
+		buffer = DefaultAuditBuffer
+	}
+	q := &auditQueue{
+		entries: make(chan AuditEntry, buffer),
+		write:   write,
+		logger:  NewDefaultLogger(),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Record implements AuditSink
+func (q *auditQueue) Record(entry AuditEntry) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		atomic.AddInt64(&q.dropped, 1)
+		return
+	}
+	select {
+	case q.entries <- entry:
+	default:
+		atomic.AddInt64(&q.dropped, 1)
+	}
+}
+
+// Dropped returns how many entries were dropped because the queue was full or closed
+func (q *auditQueue) Dropped() int64 {
+	return atomic.LoadInt64(&q.dropped)
+}
+
+func (q *auditQueue) run() {
+	defer close(q.done)
+	for entry := range q.entries {
+		if err := q.write(entry); err != nil {
+			q.logger.Error(fmt.Sprintf("audit entry %s/%s not written: %v", entry.Source, entry.Event, err))
+		}
+	}
+}
+
+// close stops accepting entries and waits until the queued ones are written
+func (q *auditQueue) close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.entries)
+	}
+	q.mu.Unlock()
+	<-q.done
+}
+
+// FileAuditSink appends entries to a file as JSON lines
+type FileAuditSink struct {
+	*auditQueue
+	file *os.File
+}
+
+// NewFileAuditSink opens (or creates) path for appending; buffer is the queue
+// size (0 means DefaultAuditBuffer). Close it to flush queued entries.
+func NewFileAuditSink(path string, buffer int) (*FileAuditSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit file path is required")
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open audit file: %w", err)
+	}
+	enc := json.NewEncoder(file)
+	s := &FileAuditSink{file: file}
+	s.auditQueue = newAuditQueue(buffer, func(entry AuditEntry) error {
+		return enc.Encode(entry) // One line per entry
+	})
+	return s, nil
+}
+
+// Close writes the queued entries and closes the file
+func (s *FileAuditSink) Close() error {
+	s.auditQueue.close()
+	return s.file.Close()
+}
+
+// EventBusAuditSink publishes entries to AuditAddress, so any verticle (or
+// another node, on a clustered bus) can consume the audit trail
+type EventBusAuditSink struct {
+	*auditQueue
+}
+
+// NewEventBusAuditSink publishes entries on eb; buffer is the queue size
+// (0 means DefaultAuditBuffer).
+// Fail-fast: panics on a nil eventBus
+func NewEventBusAuditSink(eb EventBus, buffer int) *EventBusAuditSink {
+	failfast.NotNil(eb, "eventBus")
+	return &EventBusAuditSink{auditQueue: newAuditQueue(buffer, func(entry AuditEntry) error {
+		return ignoreBackpressure(eb.Publish(AuditAddress, entry))
+	})}
+}
+
+// Close publishes the queued entries; later entries are dropped
+func (s *EventBusAuditSink) Close() error {
+	s.auditQueue.close()
+	return nil
+}
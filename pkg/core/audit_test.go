@@ -0,0 +1,96 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileAuditSink(path, 0)
+	if err != nil {
+		t.Fatalf("NewFileAuditSink() error = %v", err)
+	}
+	sink.Record(AuditEntry{Source: "workflow", Event: "node.started", InstanceID: "e1", Step: "a"})
+	sink.Record(AuditEntry{Source: "workflow", Event: "node.failed", InstanceID: "e1", Step: "a", Error: "boom"})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Entries after Close are dropped, not written
+	sink.Record(AuditEntry{Source: "workflow", Event: "late"})
+	if sink.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", sink.Dropped())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var events []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, entry.Event)
+	}
+	if len(events) != 2 || events[0] != "node.started" || events[1] != "node.failed" {
+		t.Errorf("file entries = %v, want [node.started node.failed]", events)
+	}
+}
+
+func TestAuditQueue_DropsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	q := newAuditQueue(1, func(AuditEntry) error {
+		<-release
+		return nil
+	})
+
+	// One entry is being written, one queued; the rest are dropped without blocking
+	for i := 0; i < 5; i++ {
+		q.Record(AuditEntry{Event: "e"})
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := q.Dropped(); got != 3 {
+		t.Errorf("Dropped() = %d, want 3", got)
+	}
+	close(release)
+	q.close()
+}
+
+func TestEventBusAuditSink(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	received := make(chan AuditEntry, 1)
+	c := eb.Consumer(AuditAddress).Handler(func(ctx FluxorContext, msg Message) error {
+		var entry AuditEntry
+		if err := msg.DecodeBody(&entry); err != nil {
+			return err
+		}
+		received <- entry
+		return nil
+	})
+	waitReady(t, c)
+
+	sink := NewEventBusAuditSink(eb, 0)
+	defer sink.Close()
+	sink.Record(AuditEntry{Source: "workflow", Event: "execution.finished", InstanceID: "e1", Status: "completed"})
+	select {
+	case entry := <-received:
+		if entry.InstanceID != "e1" || entry.Status != "completed" {
+			t.Errorf("received %+v", entry)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("audit entry not published")
+	}
+}
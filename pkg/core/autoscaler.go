@@ -0,0 +1,322 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AutoscalerConfig configures an Autoscaler.
+type AutoscalerConfig struct {
+	// Address is the EventBus address whose aggregate mailbox depth drives scaling
+	Address string
+
+	// Factory creates a new worker verticle instance consuming Address
+	Factory func() Verticle
+
+	// MinInstances and MaxInstances bound the instance count (defaults: 1, MinInstances)
+	MinInstances int
+	MaxInstances int
+
+	// ScaleUpDepth: scale up when aggregate mailbox depth stays above it (default: 100)
+	ScaleUpDepth int
+
+	// ScaleDownDepth: scale down when depth stays at or below it (default: 0)
+	ScaleDownDepth int
+
+	// Interval between depth samples (default: 1s)
+	Interval time.Duration
+
+	// Sustain is how many consecutive samples must cross a threshold before acting (default: 3)
+	Sustain int
+
+	// OnScale is called after each scaling decision, e.g. to update metrics (optional)
+	OnScale func(event ScaleEvent)
+}
+
+// ScaleEvent describes one scaling decision.
+type ScaleEvent struct {
+	Address   string    `json:"address"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	Depth     int       `json:"depth"`
+	Timestamp time.Time `json:"timestamp"`
+	Err       error     `json:"-"` // Deploy/undeploy failure, if any
+}
+
+// AutoscalerStats is a snapshot of autoscaler state.
+type AutoscalerStats struct {
+	Address    string      `json:"address"`
+	Instances  int         `json:"instances"`
+	Depth      int         `json:"depth"` // Last sampled aggregate mailbox depth
+	ScaleUps   int64       `json:"scale_ups"`
+	ScaleDowns int64       `json:"scale_downs"`
+	LastEvent  *ScaleEvent `json:"last_event,omitempty"`
+}
+
+// Autoscaler deploys and undeploys instances of a worker verticle based on the
+// mailbox depth of the address it consumes (local EventBus only; cluster buses
+// report no per-address depth).
+//
+// Note: every local consumer occupies one EventBus executor worker, so instances
+// beyond the bus executor size wait for a free worker.
+type Autoscaler struct {
+	gocmd  GoCMD
+	config AutoscalerConfig
+	logger Logger
+
+	mu         sync.Mutex
+	instances  []string // Deployment IDs, newest last
+	depth      int
+	above      int // Consecutive samples above ScaleUpDepth
+	below      int // Consecutive samples at or below ScaleDownDepth
+	scaleUps   int64
+	scaleDowns int64
+	lastEvent  *ScaleEvent
+
+	stop    chan struct{}
+	done    chan struct{}
+	running bool
+}
+
+// NewAutoscaler creates an autoscaler - fail-fast on invalid config
+func NewAutoscaler(gocmd GoCMD, config AutoscalerConfig) (*Autoscaler, error) {
+	if gocmd == nil {
+		return nil, fmt.Errorf("gocmd cannot be nil")
+	}
+	if err := ValidateAddress(config.Address); err != nil {
+		return nil, err
+	}
+	if config.Factory == nil {
+		return nil, fmt.Errorf("factory cannot be nil")
+	}
+	if config.MinInstances < 0 || config.MaxInstances < 0 || config.ScaleDownDepth < 0 || config.ScaleUpDepth < 0 || config.Interval < 0 || config.Sustain < 0 {
+		return nil, fmt.Errorf("autoscaler config values cannot be negative")
+	}
+	if config.MinInstances == 0 {
+		config.MinInstances = 1
+	}
+	if config.MaxInstances == 0 {
+		config.MaxInstances = config.MinInstances
+	}
+	if config.MaxInstances < config.MinInstances {
+		return nil, fmt.Errorf("max instances (%d) cannot be less than min instances (%d)", config.MaxInstances, config.MinInstances)
+	}
+	if config.ScaleUpDepth == 0 {
+		config.ScaleUpDepth = 100
+	}
+	if config.ScaleDownDepth >= config.ScaleUpDepth {
+		return nil, fmt.Errorf("scale down depth must be below scale up depth")
+	}
+	if config.Interval == 0 {
+		config.Interval = time.Second
+	}
+	if config.Sustain == 0 {
+		config.Sustain = 3
+	}
+
+	return &Autoscaler{
+		gocmd:  gocmd,
+		config: config,
+		logger: NewDefaultLogger(),
+	}, nil
+}
+
+// Start deploys MinInstances and begins sampling
+func (a *Autoscaler) Start() error {
+	a.mu.Lock()
+	if a.running {
+		a.mu.Unlock()
+		return fmt.Errorf("autoscaler for %s already started", a.config.Address)
+	}
+	a.running = true
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	a.mu.Unlock()
+
+	for i := 0; i < a.config.MinInstances; i++ {
+		if err := a.scaleUp(); err != nil {
+			// Roll back what was deployed
+			a.mu.Lock()
+			ids := a.instances
+			a.instances = nil
+			a.running = false
+			a.mu.Unlock()
+			for _, id := range ids {
+				if uerr := a.gocmd.UndeployVerticle(id); uerr != nil {
+					// Best-effort rollback; ignore on error.
+				}
+			}
+			return err
+		}
+	}
+
+	go a.run()
+	return nil
+}
+
+// Stop stops sampling and undeploys all instances
+func (a *Autoscaler) Stop() error {
+	a.mu.Lock()
+	if !a.running {
+		a.mu.Unlock()
+		return nil
+	}
+	a.running = false
+	close(a.stop)
+	done := a.done
+	a.mu.Unlock()
+
+	// Wait for the sampler so no scale-up races the undeploy below
+	<-done
+
+	a.mu.Lock()
+	ids := a.instances
+	a.instances = nil
+	a.mu.Unlock()
+
+	var firstErr error
+	for _, id := range ids {
+		if err := a.gocmd.UndeployVerticle(id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Instances returns the current instance count
+func (a *Autoscaler) Instances() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.instances)
+}
+
+// Stats returns a snapshot of autoscaler state
+func (a *Autoscaler) Stats() AutoscalerStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stats := AutoscalerStats{
+		Address:    a.config.Address,
+		Instances:  len(a.instances),
+		Depth:      a.depth,
+		ScaleUps:   a.scaleUps,
+		ScaleDowns: a.scaleDowns,
+	}
+	if a.lastEvent != nil {
+		event := *a.lastEvent
+		stats.LastEvent = &event
+	}
+	return stats
+}
+
+func (a *Autoscaler) run() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.evaluate()
+		case <-a.stop:
+			return
+		case <-a.gocmd.Context().Done():
+			return
+		}
+	}
+}
+
+// evaluate samples mailbox depth and scales by at most one instance
+func (a *Autoscaler) evaluate() {
+	depth := a.gocmd.EventBus().Stats().Addresses[a.config.Address].MailboxDepth
+
+	a.mu.Lock()
+	a.depth = depth
+	switch {
+	case depth > a.config.ScaleUpDepth:
+		a.above++
+		a.below = 0
+	case depth <= a.config.ScaleDownDepth:
+		a.below++
+		a.above = 0
+	default:
+		a.above, a.below = 0, 0
+	}
+	up := a.above >= a.config.Sustain && len(a.instances) < a.config.MaxInstances
+	down := a.below >= a.config.Sustain && len(a.instances) > a.config.MinInstances
+	if up || down {
+		a.above, a.below = 0, 0
+	}
+	a.mu.Unlock()
+
+	switch {
+	case up:
+		if err := a.scaleUp(); err != nil {
+			a.logger.Error(fmt.Sprintf("autoscaler %s: scale up failed: %v", a.config.Address, err))
+		}
+	case down:
+		if err := a.scaleDown(); err != nil {
+			a.logger.Error(fmt.Sprintf("autoscaler %s: scale down failed: %v", a.config.Address, err))
+		}
+	}
+}
+
+func (a *Autoscaler) scaleUp() error {
+	id, err := a.gocmd.DeployVerticle(a.config.Factory())
+
+	a.mu.Lock()
+	from := len(a.instances)
+	if err == nil {
+		a.instances = append(a.instances, id)
+		a.scaleUps++
+	}
+	event := a.recordLocked(from, len(a.instances), err)
+	a.mu.Unlock()
+
+	a.notify(event)
+	return err
+}
+
+func (a *Autoscaler) scaleDown() error {
+	a.mu.Lock()
+	if len(a.instances) == 0 {
+		a.mu.Unlock()
+		return nil
+	}
+	from := len(a.instances)
+	id := a.instances[from-1] // Newest first
+	a.instances = a.instances[:from-1]
+	a.scaleDowns++
+	a.mu.Unlock()
+
+	err := a.gocmd.UndeployVerticle(id)
+
+	a.mu.Lock()
+	event := a.recordLocked(from, from-1, err)
+	a.mu.Unlock()
+
+	a.notify(event)
+	return err
+}
+
+func (a *Autoscaler) recordLocked(from, to int, err error) ScaleEvent {
+	event := ScaleEvent{
+		Address:   a.config.Address,
+		From:      from,
+		To:        to,
+		Depth:     a.depth,
+		Timestamp: time.Now(),
+		Err:       err,
+	}
+	a.lastEvent = &event
+	return event
+}
+
+func (a *Autoscaler) notify(event ScaleEvent) {
+	if event.Err == nil {
+		a.logger.Info(fmt.Sprintf("autoscaler %s: %d -> %d instances (mailbox depth %d)", event.Address, event.From, event.To, event.Depth))
+	}
+	if a.config.OnScale != nil {
+		a.config.OnScale(event)
+	}
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type scalingWorker struct {
+	*BaseVerticle
+	release chan struct{}
+}
+
+func (v *scalingWorker) Start(ctx FluxorContext) error {
+	if err := v.BaseVerticle.Start(ctx); err != nil {
+		return err
+	}
+	v.Consumer("jobs.process").Handler(func(ctx FluxorContext, msg Message) error {
+		<-v.release
+		return nil
+	})
+	return nil
+}
+
+func waitForInstances(t *testing.T, a *Autoscaler, want int) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if a.Instances() == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Instances() = %d, want %d", a.Instances(), want)
+}
+
+func TestAutoscaler_ScalesOnMailboxDepth(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	release := make(chan struct{})
+	var eventsMu sync.Mutex
+	var events []ScaleEvent
+	scaler, err := NewAutoscaler(gocmd, AutoscalerConfig{
+		Address:        "jobs.process",
+		Factory:        func() Verticle { return &scalingWorker{BaseVerticle: NewBaseVerticle("worker"), release: release} },
+		MinInstances:   1,
+		MaxInstances:   3,
+		ScaleUpDepth:   5,
+		ScaleDownDepth: 0,
+		Interval:       20 * time.Millisecond,
+		Sustain:        2,
+		OnScale: func(e ScaleEvent) {
+			eventsMu.Lock()
+			events = append(events, e)
+			eventsMu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAutoscaler() error = %v", err)
+	}
+	if err := scaler.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer scaler.Stop()
+	waitForInstances(t, scaler, 1)
+
+	// Wait for the first worker's consumer, then back its mailbox up
+	deadline := time.Now().Add(time.Second)
+	for gocmd.EventBus().Stats().Addresses["jobs.process"].Consumers == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	for i := 0; i < 20; i++ {
+		if err := gocmd.EventBus().Send("jobs.process", i); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	waitForInstances(t, scaler, 3)
+
+	// Drain and go idle: back down to MinInstances
+	close(release)
+	waitForInstances(t, scaler, 1)
+
+	stats := scaler.Stats()
+	if stats.ScaleDowns != 2 || stats.LastEvent == nil || stats.LastEvent.To != 1 {
+		t.Errorf("Stats() = %+v, want 2 scale downs ending at 1 instance", stats)
+	}
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if len(events) < 5 {
+		t.Errorf("OnScale called %d times, want at least 5 (1 initial + 2 up + 2 down)", len(events))
+	}
+}
+
+func TestNewAutoscaler_FailFast(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	factory := func() Verticle { return NewBaseVerticle("w") }
+
+	tests := []AutoscalerConfig{
+		{Address: "", Factory: factory},
+		{Address: "a", Factory: nil},
+		{Address: "a", Factory: factory, MinInstances: 3, MaxInstances: 2},
+		{Address: "a", Factory: factory, ScaleUpDepth: 5, ScaleDownDepth: 5},
+		{Address: "a", Factory: factory, Interval: -time.Second},
+	}
+	for i, cfg := range tests {
+		if _, err := NewAutoscaler(gocmd, cfg); err == nil {
+			t.Errorf("case %d: NewAutoscaler() should reject %+v", i, cfg)
+		}
+	}
+}
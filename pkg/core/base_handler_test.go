@@ -19,6 +19,12 @@ func (m *mockMessage) Headers() map[string]string {
 	return m.headers
 }
 
+func (m *mockMessage) Header(key string) string {
+	return m.headers[key]
+}
+
+func (m *mockMessage) SetHeader(key, value string) {}
+
 func (m *mockMessage) Body() interface{} {
 	return m.body
 }
@@ -39,6 +45,14 @@ func (m *mockMessage) Fail(failureCode int, message string) error {
 	return nil
 }
 
+func (m *mockMessage) Stream(chunk interface{}) error {
+	return nil
+}
+
+func (m *mockMessage) EndStream() error {
+	return nil
+}
+
 func TestNewBaseHandler(t *testing.T) {
 	handler := NewBaseHandler("test-handler")
 	if handler == nil {
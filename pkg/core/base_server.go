@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"sync"
 
 	"github.com/fluxorio/fluxor/pkg/core/failfast"
@@ -34,11 +35,22 @@ type BaseServer struct {
 // NewBaseServer creates a new BaseServer
 func NewBaseServer(name string, gocmd GoCMD) *BaseServer {
 	failfast.NotNil(gocmd, "gocmd") // Fail-fast: gocmd cannot be nil
-	return &BaseServer{
+	bs := &BaseServer{
 		name:   name,
 		gocmd:  gocmd,
 		logger: NewDefaultLogger(),
 	}
+	// Stop before verticles and the EventBus, so in-flight handlers can still use them
+	gocmd.OnShutdownPhase(PhaseDrainRequests, bs.stopOnShutdown)
+	return bs
+}
+
+// stopOnShutdown stops a started server when its GoCMD closes
+func (bs *BaseServer) stopOnShutdown(ctx context.Context) error {
+	if !bs.IsStarted() {
+		return nil
+	}
+	return bs.Stop()
 }
 
 // SetHooks configures hook functions for Start/Stop.
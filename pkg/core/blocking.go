@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// DefaultBlockingPoolSize is the default number of ExecuteBlocking calls that run at once
+const DefaultBlockingPoolSize = 20
+
+// DefaultBlockingQueueSize is the default number of ExecuteBlocking calls that wait for a slot
+const DefaultBlockingQueueSize = 10000
+
+// ErrBlockingQueueFull fails the Future of an ExecuteBlocking call made while
+// every slot is busy and BlockingQueueSize calls are already waiting
+var ErrBlockingQueueFull = &EventBusError{Code: "BLOCKING_QUEUE_FULL", Message: "blocking pool queue full"}
+
+// Future is the pending result of an ExecuteBlocking call
+// (pkg/fluxor has the richer Future/Promise API for composing async work)
+type Future interface {
+	// Done is closed once the result is available
+	Done() <-chan struct{}
+
+	// Await waits for the result or for ctx to be done
+	Await(ctx context.Context) (interface{}, error)
+
+	// OnComplete registers handler to run once with the result; it runs immediately
+	// if the future is already complete, otherwise on the blocking pool goroutine.
+	// Handlers must not touch verticle state directly - send a message instead
+	OnComplete(handler func(result interface{}, err error)) Future
+}
+
+// future implements Future
+type future struct {
+	done     chan struct{}
+	mu       sync.Mutex
+	result   interface{}
+	err      error
+	handlers []func(interface{}, error)
+}
+
+func newFuture() *future {
+	return &future{done: make(chan struct{})}
+}
+
+// complete sets the result and runs the registered handlers; only the first call counts
+func (f *future) complete(result interface{}, err error) {
+	f.mu.Lock()
+	select {
+	case <-f.done:
+		f.mu.Unlock()
+		return
+	default:
+	}
+	f.result, f.err = result, err
+	handlers := f.handlers
+	f.handlers = nil
+	close(f.done)
+	f.mu.Unlock()
+
+	for _, h := range handlers {
+		h(result, err)
+	}
+}
+
+func (f *future) Done() <-chan struct{} {
+	return f.done
+}
+
+func (f *future) Await(ctx context.Context) (interface{}, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *future) OnComplete(handler func(result interface{}, err error)) Future {
+	failfast.NotNil(handler, "handler")
+	f.mu.Lock()
+	select {
+	case <-f.done:
+		f.mu.Unlock()
+		handler(f.result, f.err)
+		return f
+	default:
+	}
+	f.handlers = append(f.handlers, handler)
+	f.mu.Unlock()
+	return f
+}
+
+// blockingPool runs blocking functions off the event loop, at most size at a time.
+// Calls beyond the limit queue without a goroutine of their own and run as slots
+// free up; they fail with ctx.Err() if ctx is done first, and with
+// ErrBlockingQueueFull if queueSize calls are already waiting
+type blockingPool struct {
+	ctx       context.Context
+	size      int
+	queueSize int
+	mu        sync.Mutex
+	running   int            // goroutines running calls, at most size
+	pending   []blockingCall // calls waiting for a slot, oldest first
+}
+
+// blockingCall is an ExecuteBlocking call and its Future
+type blockingCall struct {
+	fn func() (interface{}, error)
+	f  *future
+}
+
+func newBlockingPool(ctx context.Context, size, queueSize int) *blockingPool {
+	p := &blockingPool{ctx: ctx, size: size, queueSize: queueSize}
+	context.AfterFunc(ctx, p.failPending)
+	return p
+}
+
+// execute runs fn on the pool; a panic in fn fails the returned Future. A
+// goroutine is only started once a slot is taken.
+func (p *blockingPool) execute(fn func() (interface{}, error)) Future {
+	failfast.NotNil(fn, "fn")
+	call := blockingCall{fn: fn, f: newFuture()}
+
+	p.mu.Lock()
+	if err := p.ctx.Err(); err != nil {
+		p.mu.Unlock()
+		call.f.complete(nil, err)
+		return call.f
+	}
+	if p.running >= p.size {
+		if len(p.pending) >= p.queueSize {
+			p.mu.Unlock()
+			call.f.complete(nil, ErrBlockingQueueFull)
+			return call.f
+		}
+		p.pending = append(p.pending, call)
+		p.mu.Unlock()
+		return call.f
+	}
+	p.running++
+	p.mu.Unlock()
+
+	// Hidden: goroutine creation
+	go p.work(call)
+	return call.f
+}
+
+// work runs call, then queued calls until none are left, and frees its slot
+func (p *blockingPool) work(call blockingCall) {
+	for {
+		result, err := runBlocking(call.fn)
+		call.f.complete(result, err)
+
+		p.mu.Lock()
+		if len(p.pending) == 0 || p.ctx.Err() != nil {
+			p.running--
+			p.mu.Unlock()
+			return
+		}
+		call = p.pending[0]
+		p.pending[0] = blockingCall{}
+		p.pending = p.pending[1:]
+		p.mu.Unlock()
+	}
+}
+
+// failPending fails the calls still waiting for a slot once ctx is done
+func (p *blockingPool) failPending() {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	for _, call := range pending {
+		call.f.complete(nil, p.ctx.Err())
+	}
+}
+
+// runBlocking calls fn, turning a panic into an error
+func runBlocking(fn func() (interface{}, error)) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("blocking operation panic: %v", r)
+		}
+	}()
+	return fn()
+}
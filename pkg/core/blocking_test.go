@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFluxorContext_ExecuteBlocking(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// The handler replies from OnComplete without blocking its message loop
+	c := eb.Consumer("blocking.query").Handler(func(ctx FluxorContext, msg Message) error {
+		ctx.ExecuteBlocking(func() (interface{}, error) {
+			time.Sleep(20 * time.Millisecond)
+			return "row", nil
+		}).OnComplete(func(result interface{}, err error) {
+			msg.Reply(result)
+		})
+		return nil
+	})
+	waitReady(t, c)
+
+	reply, err := eb.Request("blocking.query", "select", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var body string
+	if err := reply.DecodeBody(&body); err != nil || body != "row" {
+		t.Errorf("reply = %q, %v; want %q", body, err, "row")
+	}
+}
+
+func TestGoCMD_ExecuteBlockingErrors(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	want := errors.New("db down")
+	if _, err := gocmd.ExecuteBlocking(func() (interface{}, error) {
+		return nil, want
+	}).Await(context.Background()); !errors.Is(err, want) {
+		t.Errorf("Await() error = %v, want %v", err, want)
+	}
+
+	if _, err := gocmd.ExecuteBlocking(func() (interface{}, error) {
+		panic("boom")
+	}).Await(context.Background()); err == nil {
+		t.Error("Await() should return an error when fn panics")
+	}
+}
+
+func TestGoCMD_ExecuteBlockingPoolSize(t *testing.T) {
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{BlockingPoolSize: 2})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+
+	var running, peak int32
+	release := make(chan struct{})
+	futures := make([]Future, 5)
+	for i := range futures {
+		futures[i] = gocmd.ExecuteBlocking(func() (interface{}, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&running, -1)
+			return nil, nil
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for _, f := range futures {
+		if _, err := f.Await(context.Background()); err != nil {
+			t.Fatalf("Await() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&peak); got != 2 {
+		t.Errorf("peak concurrency = %d, want 2", got)
+	}
+
+	// Calls still waiting for a slot fail once the instance is closed
+	block, started := make(chan struct{}), make(chan struct{}, 2)
+	defer close(block)
+	for i := 0; i < 2; i++ {
+		gocmd.ExecuteBlocking(func() (interface{}, error) {
+			started <- struct{}{}
+			<-block
+			return nil, nil
+		})
+	}
+	<-started
+	<-started
+	queued := gocmd.ExecuteBlocking(func() (interface{}, error) { return nil, nil })
+	gocmd.Close()
+	if _, err := queued.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("queued Await() error = %v, want context.Canceled", err)
+	}
+
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{BlockingPoolSize: -1}); err == nil {
+		t.Error("NewGoCMDWithOptions() should reject a negative BlockingPoolSize")
+	}
+}
+
+func TestGoCMD_ExecuteBlockingQueueFull(t *testing.T) {
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{BlockingPoolSize: 1, BlockingQueueSize: 1})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	defer gocmd.Close()
+
+	release := make(chan struct{})
+	block := func() (interface{}, error) {
+		<-release
+		return nil, nil
+	}
+	running := gocmd.ExecuteBlocking(block)
+	queued := gocmd.ExecuteBlocking(block)
+	rejected := gocmd.ExecuteBlocking(block)
+	if _, err := rejected.Await(context.Background()); !errors.Is(err, ErrBlockingQueueFull) {
+		t.Errorf("call beyond the queue error = %v, want ErrBlockingQueueFull", err)
+	}
+
+	close(release)
+	for _, f := range []Future{running, queued} {
+		if _, err := f.Await(context.Background()); err != nil {
+			t.Errorf("Await() error = %v", err)
+		}
+	}
+
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{BlockingQueueSize: -1}); err == nil {
+		t.Error("NewGoCMDWithOptions() should reject a negative BlockingQueueSize")
+	}
+}
+
+func TestBlockingPool_QueuedCallsHaveNoGoroutine(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool := newBlockingPool(ctx, 2, DefaultBlockingQueueSize)
+
+	release := make(chan struct{})
+	before := runtime.NumGoroutine()
+	futures := make([]Future, 100)
+	for i := range futures {
+		futures[i] = pool.execute(func() (interface{}, error) {
+			<-release
+			return nil, nil
+		})
+	}
+	if grown := runtime.NumGoroutine() - before; grown > 2 {
+		t.Errorf("%d goroutines started for 100 calls on a pool of 2", grown)
+	}
+	close(release)
+	for _, f := range futures {
+		if _, err := f.Await(context.Background()); err != nil {
+			t.Fatalf("Await() error = %v", err)
+		}
+	}
+}
@@ -13,6 +13,12 @@ type ExecutorStats struct {
 	RejectedTasks    int64   // Total rejected tasks (backpressure)
 	QueueCapacity    int     // Maximum queue capacity
 	QueueUtilization float64 // Queue utilization percentage
+
+	BusyWorkers     int           // Workers currently running a task
+	AllBusyFor      time.Duration // How long every worker has been busy (0 if one is idle)
+	OverflowWorkers int           // Temporary overflow workers currently running (included in ActiveWorkers)
+	OverflowSpawned int64         // Total overflow workers started
+	OverflowRetired int64         // Total overflow workers retired after idling
 }
 
 // Executor abstracts goroutine pool management and task execution
@@ -26,12 +26,34 @@ type defaultExecutor struct {
 	queuedTasks    int64
 	completedTasks int64
 	rejectedTasks  int64
+
+	// Busy tracking is atomic so running a task takes no lock; busyMu only
+	// serializes starting and retiring overflow workers
+	maxOverflow     int
+	overflowIdle    time.Duration
+	busy            int32
+	overflow        int32
+	allBusySince    int64 // Unix nanoseconds; 0 while a worker is idle
+	busyMu          sync.Mutex
+	overflowSpawned int64 // Guarded by busyMu
+	overflowRetired int64 // Guarded by busyMu
 }
 
+// DefaultOverflowIdleTimeout is how long an overflow worker waits for a task before retiring
+const DefaultOverflowIdleTimeout = 5 * time.Second
+
 // ExecutorConfig configures an Executor
 type ExecutorConfig struct {
 	Workers   int // Number of worker goroutines
 	QueueSize int // Maximum queue size (bounded for backpressure)
+
+	// MaxOverflowWorkers caps the temporary workers started when a task is queued
+	// while every worker is busy, e.g. blocked on slow I/O (0 disables overflow)
+	MaxOverflowWorkers int
+
+	// OverflowIdleTimeout is how long an overflow worker stays without a task
+	// before it is retired (default: DefaultOverflowIdleTimeout)
+	OverflowIdleTimeout time.Duration
 }
 
 // DefaultExecutorConfig returns default executor configuration
@@ -55,6 +77,12 @@ func NewExecutor(ctx context.Context, config ExecutorConfig) Executor {
 	if config.QueueSize < 1 {
 		config.QueueSize = 100
 	}
+	if config.MaxOverflowWorkers < 0 {
+		config.MaxOverflowWorkers = 0
+	}
+	if config.OverflowIdleTimeout <= 0 {
+		config.OverflowIdleTimeout = DefaultOverflowIdleTimeout
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -65,6 +93,9 @@ func NewExecutor(ctx context.Context, config ExecutorConfig) Executor {
 		ctx:       ctx,
 		cancel:    cancel,
 		logger:    newDefaultSimpleLogger(),
+
+		maxOverflow:  config.MaxOverflowWorkers,
+		overflowIdle: config.OverflowIdleTimeout,
 	}
 
 	// Start worker goroutines (hidden from public API)
@@ -91,16 +122,39 @@ func (e *defaultExecutor) worker(id int) {
 			if !ok {
 				return // Channel closed
 			}
-			atomic.AddInt64(&e.queuedTasks, -1)
-
-			// Execute task
-			if err := task.Execute(e.ctx); err != nil {
-				// Don't log context.Canceled or ErrMailboxClosed - they're expected during shutdown
-				if !errors.Is(err, context.Canceled) && !errors.Is(err, ErrMailboxClosed) {
-					e.logger.Errorf("task %s failed: %v", task.Name(), err)
-				}
+			e.runTask(task)
+
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// overflowWorker processes tasks like worker until it idles for overflowIdle
+func (e *defaultExecutor) overflowWorker() {
+	defer e.wg.Done()
+
+	idle := time.NewTimer(e.overflowIdle)
+	defer idle.Stop()
+	for {
+		select {
+		case task, ok := <-e.taskChan:
+			if !ok {
+				return
 			}
-			atomic.AddInt64(&e.completedTasks, 1)
+			e.runTask(task)
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(e.overflowIdle)
+
+		case <-idle.C:
+			e.busyMu.Lock()
+			atomic.AddInt32(&e.overflow, -1)
+			e.overflowRetired++
+			e.busyMu.Unlock()
+			e.updateAllBusy()
+			return
 
 		case <-e.ctx.Done():
 			return
@@ -108,6 +162,54 @@ func (e *defaultExecutor) worker(id int) {
 	}
 }
 
+// runTask executes a dequeued task, tracking busy workers
+func (e *defaultExecutor) runTask(task Task) {
+	atomic.AddInt64(&e.queuedTasks, -1)
+	atomic.AddInt32(&e.busy, 1)
+	e.updateAllBusy()
+
+	// Execute task
+	if err := task.Execute(e.ctx); err != nil {
+		// Don't log context.Canceled or ErrMailboxClosed - they're expected during shutdown
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, ErrMailboxClosed) {
+			e.logger.Errorf("task %s failed: %v", task.Name(), err)
+		}
+	}
+	atomic.AddInt64(&e.completedTasks, 1)
+
+	atomic.AddInt32(&e.busy, -1)
+	e.updateAllBusy()
+}
+
+// updateAllBusy starts or clears the all-busy clock. Workers changing state at
+// the same moment can clear it early, so AllBusyFor is a lower bound.
+func (e *defaultExecutor) updateAllBusy() {
+	if int(atomic.LoadInt32(&e.busy)) >= e.workers+int(atomic.LoadInt32(&e.overflow)) {
+		atomic.CompareAndSwapInt64(&e.allBusySince, 0, time.Now().UnixNano())
+	} else if atomic.LoadInt64(&e.allBusySince) != 0 {
+		atomic.StoreInt64(&e.allBusySince, 0)
+	}
+}
+
+// maybeOverflow starts an overflow worker if tasks are waiting while every worker
+// is busy and the overflow cap isn't reached
+func (e *defaultExecutor) maybeOverflow() {
+	if e.maxOverflow == 0 || len(e.taskChan) == 0 {
+		return
+	}
+	e.busyMu.Lock()
+	defer e.busyMu.Unlock()
+	overflow := int(atomic.LoadInt32(&e.overflow))
+	if overflow >= e.maxOverflow || int(atomic.LoadInt32(&e.busy)) < e.workers+overflow || e.ctx.Err() != nil {
+		return
+	}
+	atomic.AddInt32(&e.overflow, 1)
+	e.overflowSpawned++
+	e.updateAllBusy()
+	e.wg.Add(1)
+	go e.overflowWorker() // Hidden: goroutine creation
+}
+
 // Submit implements Executor interface
 // Hides channel send operations and select statements
 func (e *defaultExecutor) Submit(task Task) error {
@@ -128,6 +230,7 @@ func (e *defaultExecutor) Submit(task Task) error {
 	select {
 	case e.taskChan <- task: // Hidden: channel send
 		atomic.AddInt64(&e.queuedTasks, 1)
+		e.maybeOverflow()
 		return nil
 	case <-e.ctx.Done():
 		return e.ctx.Err()
@@ -161,6 +264,7 @@ func (e *defaultExecutor) SubmitWithTimeout(task Task, timeout time.Duration) er
 	select {
 	case e.taskChan <- task: // Hidden: channel send
 		atomic.AddInt64(&e.queuedTasks, 1)
+		e.maybeOverflow()
 		return nil
 	case <-time.After(timeout):
 		atomic.AddInt64(&e.rejectedTasks, 1)
@@ -213,12 +317,25 @@ func (e *defaultExecutor) Stats() ExecutorStats {
 		queueUtilization = 100.0
 	}
 
+	var allBusyFor time.Duration
+	if since := atomic.LoadInt64(&e.allBusySince); since != 0 {
+		allBusyFor = time.Since(time.Unix(0, since))
+	}
+	overflow := int(atomic.LoadInt32(&e.overflow))
+
+	e.busyMu.Lock()
+	defer e.busyMu.Unlock()
 	return ExecutorStats{
 		QueuedTasks:      queued,
-		ActiveWorkers:    e.workers,
+		ActiveWorkers:    e.workers + overflow,
 		CompletedTasks:   atomic.LoadInt64(&e.completedTasks),
 		RejectedTasks:    atomic.LoadInt64(&e.rejectedTasks),
 		QueueCapacity:    e.queueSize,
 		QueueUtilization: queueUtilization,
+		BusyWorkers:      int(atomic.LoadInt32(&e.busy)),
+		AllBusyFor:       allBusyFor,
+		OverflowWorkers:  overflow,
+		OverflowSpawned:  e.overflowSpawned,
+		OverflowRetired:  e.overflowRetired,
 	}
 }
@@ -114,3 +114,60 @@ func TestExecutor_Stats(t *testing.T) {
 		t.Errorf("Stats().QueueCapacity = %d, want 10", stats.QueueCapacity)
 	}
 }
+
+func TestExecutor_OverflowWorkers(t *testing.T) {
+	executor := NewExecutor(context.Background(), ExecutorConfig{
+		Workers:             1,
+		QueueSize:           10,
+		MaxOverflowWorkers:  2,
+		OverflowIdleTimeout: 50 * time.Millisecond,
+	})
+	defer executor.Shutdown(context.Background())
+
+	// A slow task occupies the only worker
+	release := make(chan struct{})
+	executor.Submit(NewNamedTask("slow", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+	time.Sleep(20 * time.Millisecond)
+	if stats := executor.Stats(); stats.BusyWorkers != 1 || stats.AllBusyFor <= 0 {
+		t.Errorf("Stats() busy = %d, all busy for %v; want 1 and > 0", stats.BusyWorkers, stats.AllBusyFor)
+	}
+
+	// Queued tasks start overflow workers up to the cap instead of waiting
+	done := make(chan struct{}, 4)
+	for i := 0; i < 4; i++ {
+		executor.Submit(NewNamedTask("quick", func(ctx context.Context) error {
+			done <- struct{}{}
+			return nil
+		}))
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("quick task %d did not run while the worker was busy", i)
+		}
+	}
+	stats := executor.Stats()
+	if stats.OverflowSpawned < 1 || stats.OverflowSpawned > 2 {
+		t.Errorf("Stats().OverflowSpawned = %d, want 1-2", stats.OverflowSpawned)
+	}
+	if stats.ActiveWorkers != 1+stats.OverflowWorkers {
+		t.Errorf("Stats().ActiveWorkers = %d, want %d", stats.ActiveWorkers, 1+stats.OverflowWorkers)
+	}
+
+	// Idle overflow workers retire
+	time.Sleep(150 * time.Millisecond)
+	stats = executor.Stats()
+	if stats.OverflowWorkers != 0 || stats.OverflowRetired != stats.OverflowSpawned {
+		t.Errorf("Stats() overflow = %d, retired = %d; want 0 and %d", stats.OverflowWorkers, stats.OverflowRetired, stats.OverflowSpawned)
+	}
+
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	if stats := executor.Stats(); stats.BusyWorkers != 0 || stats.AllBusyFor != 0 {
+		t.Errorf("Stats() busy = %d, all busy for %v after release; want 0", stats.BusyWorkers, stats.AllBusyFor)
+	}
+}
@@ -2,6 +2,7 @@ package concurrency
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 )
 
@@ -10,6 +11,7 @@ import (
 type boundedMailbox struct {
 	ch       chan interface{} // Hidden: internal channel
 	closed   int32            // Atomic flag for thread-safe close check
+	closeMu  sync.RWMutex     // Send holds RLock so Close never closes ch mid-send
 	capacity int
 }
 
@@ -30,6 +32,8 @@ func NewBoundedMailbox(capacity int) Mailbox {
 // Send implements Mailbox interface
 // Hides channel send and select statements
 func (mb *boundedMailbox) Send(msg interface{}) error {
+	mb.closeMu.RLock()
+	defer mb.closeMu.RUnlock()
 	if atomic.LoadInt32(&mb.closed) == 1 {
 		return ErrMailboxClosed
 	}
@@ -90,6 +94,8 @@ func (mb *boundedMailbox) TryReceive() (interface{}, bool, error) {
 // Close implements Mailbox interface
 // Hides channel close operation
 func (mb *boundedMailbox) Close() {
+	mb.closeMu.Lock()
+	defer mb.closeMu.Unlock()
 	if atomic.CompareAndSwapInt32(&mb.closed, 0, 1) {
 		close(mb.ch) // Hidden: channel close
 	}
@@ -2,6 +2,7 @@ package concurrency
 
 import (
 	"context"
+	"sync"
 	"testing"
 )
 
@@ -106,6 +107,26 @@ func TestMailbox_Close(t *testing.T) {
 	}
 }
 
+func TestMailbox_SendDuringClose(t *testing.T) {
+	mailbox := NewBoundedMailbox(1000)
+
+	// Sends racing Close get ErrMailboxClosed or succeed, never a send on a closed channel
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := mailbox.Send(j); err == ErrMailboxClosed {
+					return
+				}
+			}
+		}()
+	}
+	mailbox.Close()
+	wg.Wait()
+}
+
 func TestMailbox_Size(t *testing.T) {
 	mailbox := NewBoundedMailbox(10)
 
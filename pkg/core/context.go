@@ -14,7 +14,10 @@ import (
 // functionality. Use Context() to get the underlying context.Context when needed
 // for cancellation or passing to Go standard library functions.
 type FluxorContext interface {
-	// Context returns the underlying context.Context (Go's standard context)
+	// Context returns the underlying context.Context (Go's standard context).
+	// For a verticle it is cancelled when the verticle is undeployed, its Start
+	// fails, or GoCMD is closed, so long-running goroutines can select on
+	// Context().Done() to stop.
 	Context() context.Context
 
 	// EventBus returns the event bus instance
@@ -34,13 +37,19 @@ type FluxorContext interface {
 
 	// Undeploy undeploys a verticle by deployment ID
 	Undeploy(deploymentID string) error
+
+	// ExecuteBlocking runs fn on the GoCMD blocking pool and returns a Future for
+	// its result. Use it for blocking I/O (e.g. a slow DB call) inside handlers
+	// instead of blocking the consumer's message loop
+	ExecuteBlocking(fn func() (interface{}, error)) Future
 }
 
 // gocmdContext implements FluxorContext
 type gocmdContext struct {
-	goCtx  context.Context // renamed from 'ctx' for clarity: this is Go's context.Context
-	gocmd  GoCMD
-	config map[string]interface{}
+	goCtx    context.Context // renamed from 'ctx' for clarity: this is Go's context.Context
+	gocmd    GoCMD
+	eventBus EventBus // overrides gocmd.EventBus() for deployments with a dedicated executor
+	config   map[string]interface{}
 }
 
 // newFluxorContext creates a new FluxorContext wrapping the given context.Context.
@@ -71,6 +80,9 @@ func (c *gocmdContext) EventBus() EventBus {
 		// Fail-fast: gocmd is nil
 		panic("gocmd is nil, cannot get EventBus")
 	}
+	if c.eventBus != nil {
+		return c.eventBus
+	}
 	return c.gocmd.EventBus()
 }
 
@@ -93,3 +105,7 @@ func (c *gocmdContext) Deploy(verticle Verticle) (string, error) {
 func (c *gocmdContext) Undeploy(deploymentID string) error {
 	return c.gocmd.UndeployVerticle(deploymentID)
 }
+
+func (c *gocmdContext) ExecuteBlocking(fn func() (interface{}, error)) Future {
+	return c.gocmd.ExecuteBlocking(fn)
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"strings"
+)
+
+// Generic error codes
+const (
+	ErrorCodeInternal = "INTERNAL" // AsError's code for errors that aren't a *FluxorError
+	ErrorCodeFailed   = "FAILED"   // ReplyError's code for failures sent without one (Message.Fail)
+)
+
+// FluxorError is the structured error used across the framework (fluxor.Error in
+// pkg/fluxor): a machine-readable Code (e.g. "TIMEOUT"), a Message for people, an
+// optional Cause and Details. EventBus errors are FluxorErrors (EventBusError is an
+// alias), and web.HTTPError and ReplyFailure convert to one with errors.As.
+//
+// errors.Is matches two FluxorErrors by Code, so a wrapped or re-created error
+// still matches its sentinel:
+//
+//	if errors.Is(err, core.ErrTimeout) { ... }
+type FluxorError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Cause   error                  `json:"-"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewError creates a FluxorError with code and message
+func NewError(code, message string) *FluxorError {
+	return &FluxorError{Code: code, Message: message}
+}
+
+// WrapError creates a FluxorError with code and message caused by cause
+func WrapError(code, message string, cause error) *FluxorError {
+	return &FluxorError{Code: code, Message: message, Cause: cause}
+}
+
+func (e *FluxorError) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+func (e *FluxorError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a *FluxorError with the same Code
+func (e *FluxorError) Is(target error) bool {
+	t, ok := target.(*FluxorError)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// WithCause returns a copy of e caused by cause, e.g. core.ErrTimeout.WithCause(err)
+func (e *FluxorError) WithCause(cause error) *FluxorError {
+	c := *e
+	c.Cause = cause
+	return &c
+}
+
+// WithDetail returns a copy of e with one more detail
+func (e *FluxorError) WithDetail(key string, value interface{}) *FluxorError {
+	c := *e
+	c.Details = make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		c.Details[k] = v
+	}
+	c.Details[key] = value
+	return &c
+}
+
+// AsError returns the *FluxorError in err's chain (converting web.HTTPError and
+// ReplyFailure), or wraps err as an ErrorCodeInternal FluxorError. Nil stays nil.
+func AsError(err error) *FluxorError {
+	if err == nil {
+		return nil
+	}
+	var e *FluxorError
+	if errors.As(err, &e) {
+		return e
+	}
+	return &FluxorError{Code: ErrorCodeInternal, Message: err.Error(), Cause: err}
+}
+
+// ErrorBody renders err as a JSON API error body:
+//
+//	{"error":"timeout","message":"Request timeout","details":{...}}
+//
+// The code is lowercased. Cause is left out, and errors that aren't a *FluxorError get
+// a generic message, so internal detail isn't sent.
+func ErrorBody(err error) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+	var e *FluxorError
+	if !errors.As(err, &e) {
+		e = &FluxorError{Code: ErrorCodeInternal, Message: "internal error"}
+	}
+	body := map[string]interface{}{
+		"error":   strings.ToLower(e.Code),
+		"message": e.Message,
+	}
+	if len(e.Details) > 0 {
+		body["details"] = e.Details
+	}
+	return body
+}
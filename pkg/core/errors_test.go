@@ -0,0 +1,57 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFluxorError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("fetch order: %w", ErrTimeout.WithCause(cause).WithDetail("address", "orders.get"))
+
+	// Is matches by Code through wrapping and copies
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("errors.Is(err, ErrTimeout) = false")
+	}
+	if errors.Is(err, ErrBusClosing) {
+		t.Error("errors.Is(err, ErrBusClosing) = true")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false")
+	}
+	if ErrTimeout.Cause != nil || ErrTimeout.Details != nil {
+		t.Error("WithCause/WithDetail changed the sentinel")
+	}
+
+	var fe *FluxorError
+	if !errors.As(err, &fe) || fe.Details["address"] != "orders.get" {
+		t.Fatalf("errors.As() = %+v", fe)
+	}
+	if got := fe.Error(); got != "Request timeout: connection refused" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	if got := AsError(errors.New("boom")); got.Code != ErrorCodeInternal || got.Message != "boom" {
+		t.Errorf("AsError(plain) = %+v", got)
+	}
+	if AsError(nil) != nil {
+		t.Error("AsError(nil) should be nil")
+	}
+}
+
+func TestErrorBody(t *testing.T) {
+	body := ErrorBody(NewError("INVALID_INPUT", "name is required").WithDetail("field", "name"))
+	if body["error"] != "invalid_input" || body["message"] != "name is required" {
+		t.Errorf("ErrorBody() = %v", body)
+	}
+	if details, _ := body["details"].(map[string]interface{}); details["field"] != "name" {
+		t.Errorf("ErrorBody() details = %v", body["details"])
+	}
+
+	// Plain errors don't leak their message
+	body = ErrorBody(errors.New("db password rejected"))
+	if body["error"] != "internal" || body["message"] != "internal error" {
+		t.Errorf("ErrorBody(plain) = %v", body)
+	}
+}
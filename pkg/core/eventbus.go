@@ -14,6 +14,14 @@ type Message interface {
 	// Headers returns the message headers
 	Headers() map[string]string
 
+	// Header returns one header value ("" if absent); an exact key match wins,
+	// otherwise the key is matched case-insensitively
+	Header(key string) string
+
+	// SetHeader sets a header sent with this message's replies (Reply, Fail,
+	// Stream, EndStream). It doesn't change Headers(); reserved headers are ignored.
+	SetHeader(key, value string)
+
 	// ReplyAddress returns the reply address if this is a request message
 	ReplyAddress() string
 
@@ -25,6 +33,12 @@ type Message interface {
 
 	// Fail indicates that processing failed
 	Fail(failureCode int, message string) error
+
+	// Stream sends one chunk of a streamed reply to a RequestStream requester
+	Stream(chunk interface{}) error
+
+	// EndStream ends a streamed reply; the requester's channel closes
+	EndStream() error
 }
 
 // message implements Message
@@ -32,6 +46,7 @@ type message struct {
 	body         interface{}
 	headers      map[string]string
 	replyAddress string
+	replyHeaders map[string]string // Set by SetHeader, sent with replies
 	eventBus     EventBus
 	mu           sync.RWMutex
 }
@@ -64,6 +79,46 @@ func (m *message) Headers() map[string]string {
 	return result
 }
 
+func (m *message) Header(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return headerValue(m.headers, key)
+}
+
+func (m *message) SetHeader(key, value string) {
+	if key == "" || IsReservedHeader(key) {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.replyHeaders == nil {
+		m.replyHeaders = make(map[string]string)
+	}
+	m.replyHeaders[key] = value
+}
+
+// outgoingHeaders returns the request ID (echoed so the requester can correlate
+// the reply), the SetHeader headers and extra, or nil if there are none
+func (m *message) outgoingHeaders(extra map[string]string) map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	requestID := m.headers[HeaderRequestID]
+	if requestID == "" && len(m.replyHeaders) == 0 && len(extra) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(m.replyHeaders)+len(extra)+1)
+	if requestID != "" {
+		headers[HeaderRequestID] = requestID
+	}
+	for k, v := range m.replyHeaders {
+		headers[k] = v
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+	return headers
+}
+
 func (m *message) ReplyAddress() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -74,6 +129,11 @@ func (m *message) Reply(body interface{}) error {
 	if m.replyAddress == "" {
 		return ErrNoReplyAddress
 	}
+	if headers := m.outgoingHeaders(nil); headers != nil {
+		if hs, ok := m.eventBus.(headerSender); ok {
+			return hs.sendWithHeaders(m.replyAddress, body, headers)
+		}
+	}
 	return m.eventBus.Send(m.replyAddress, body)
 }
 
@@ -87,6 +147,25 @@ func (m *message) DecodeBody(v interface{}) error {
 	return fmt.Errorf("body is not []byte, got %T", m.body)
 }
 
+func (m *message) Stream(chunk interface{}) error {
+	return m.replyStream(chunk, StreamChunk)
+}
+
+func (m *message) EndStream() error {
+	return m.replyStream([]byte("null"), StreamEnd)
+}
+
+func (m *message) replyStream(body interface{}, kind string) error {
+	if m.replyAddress == "" {
+		return ErrNoReplyAddress
+	}
+	hs, ok := m.eventBus.(headerSender)
+	if !ok {
+		return &EventBusError{Code: "STREAM_UNSUPPORTED", Message: "event bus cannot stream replies"}
+	}
+	return hs.sendWithHeaders(m.replyAddress, body, m.outgoingHeaders(map[string]string{StreamHeader: kind}))
+}
+
 func (m *message) Fail(failureCode int, message string) error {
 	// In a real implementation, this would send a failure response
 	return m.Reply(map[string]interface{}{
@@ -107,22 +186,67 @@ func (m *message) Fail(failureCode int, message string) error {
 // The different error handling for Consumer is intentional:
 //   - Invalid address in Consumer is a programming bug (should be caught in dev)
 //   - Runtime errors in Publish/Send/Request are expected (network issues, etc.)
+//
+// Ordering (local bus):
+//   - Each consumer has one FIFO mailbox drained by a single goroutine, so it
+//     handles messages from one address in the order they were sent
+//   - Messages from concurrent senders are ordered by mailbox arrival
+//   - A full mailbox drops the message for that consumer (Publish) or returns
+//     ErrTimeout (Send/Request); delivered messages are never reordered. With
+//     GoCMDOptions.ReportBackpressure both return ErrConsumerBackpressure
+//   - Use KeyedHandler for parallelism across keys while keeping per-key order
 type EventBus interface {
 	// Publish publishes a message to all handlers registered for the address.
 	// Body is automatically JSON encoded if not already []byte.
 	// Returns error if address is invalid or encoding fails.
 	Publish(address string, body interface{}) error
 
+	// PublishBatch publishes each body to address like Publish, for bulk producers:
+	// handlers are resolved once for the batch (in-memory), and JetStream acks are
+	// awaited together (cluster). Bodies that fail are reported by index in a
+	// *BatchError; the others are still published.
+	PublishBatch(address string, bodies []interface{}) error
+
+	// PublishWithHeaders is Publish with custom headers, delivered to handlers
+	// (Message.Header) on the local and cluster buses alike. Returns an error
+	// for empty or reserved header keys (see ValidateHeaders).
+	PublishWithHeaders(address string, body interface{}, headers map[string]string) error
+
+	// PublishAfter publishes body to address once delay has elapsed and returns an ID
+	// for CancelScheduled. Body is encoded immediately; errors at due time are logged.
+	// Delivery is at-least-once on JetStream (survives restarts) and in-process
+	// otherwise (pending messages are dropped on Close).
+	PublishAfter(address string, body interface{}, delay time.Duration) (string, error)
+
+	// PublishAt is like PublishAfter with an absolute due time; past times publish immediately.
+	PublishAt(address string, body interface{}, at time.Time) (string, error)
+
+	// CancelScheduled cancels a pending PublishAfter/PublishAt.
+	// Returns false if the ID is unknown or the message was already published.
+	CancelScheduled(id string) bool
+
 	// Send sends a point-to-point message to one handler.
 	// Body is automatically JSON encoded if not already []byte.
 	// Returns error if address is invalid, no handlers registered, or encoding fails.
 	Send(address string, body interface{}) error
 
-	// Request sends a message and expects a reply within timeout.
+	// SendWithHeaders is Send with custom headers (see PublishWithHeaders).
+	SendWithHeaders(address string, body interface{}, headers map[string]string) error
+
+	// Request sends a message and expects a reply within timeout; a timeout <= 0
+	// uses the bus's default (DefaultRequestTimeout unless configured).
 	// Body is automatically JSON encoded if not already []byte.
 	// Returns error if address is invalid, no handlers, timeout exceeded, or encoding fails.
 	Request(address string, body interface{}, timeout time.Duration) (Message, error)
 
+	// RequestStream sends a request whose handler replies with any number of
+	// msg.Stream chunks followed by msg.EndStream. Chunks arrive on the returned
+	// channel, which closes after the end marker. A plain reply (e.g. msg.Fail)
+	// is delivered as the last message; if no chunk arrives within timeout, a
+	// failure with code 504 is. A timeout <= 0 uses the bus's default, as in
+	// Request. Works across cluster nodes.
+	RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error)
+
 	// Consumer creates a consumer for the given address.
 	//
 	// IMPORTANT: This method PANICS if address is invalid (empty or too long).
@@ -137,6 +261,10 @@ type EventBus interface {
 	//   defer consumer.Unregister()
 	Consumer(address string) Consumer
 
+	// Stats returns a snapshot of message totals and per-address consumer state
+	// (consumer counts, mailbox depths, drops) for health checks and debugging
+	Stats() EventBusStats
+
 	// Close closes the event bus and releases all resources.
 	// After Close, all other methods will fail.
 	Close() error
@@ -147,9 +275,28 @@ type Consumer interface {
 	// Handler sets the message handler
 	Handler(handler MessageHandler) Consumer
 
+	// HandlerN sets a handler run by n goroutines draining the consumer concurrently,
+	// for I/O-bound handlers of independent messages. Messages are then handled out
+	// of order; Handler (n = 1) keeps send order. n is fixed by the first call.
+	HandlerN(n int, handler MessageHandler) Consumer
+
+	// HandlerReply sets a handler whose return value is sent as the reply
+	// (an error is sent via Message.Fail); use instead of Handler for request/reply
+	HandlerReply(handler ReplyHandler) Consumer
+
+	// OnError sets a callback for messages the handler failed on (returned an
+	// error or panicked). It runs under the same panic isolation as the handler.
+	// Failures are still logged; on JetStream the message is still NAKed.
+	OnError(handler ErrorHandler) Consumer
+
 	// Completion returns a channel that will be closed when the consumer is closed
 	Completion() <-chan struct{}
 
+	// Ready returns a channel that is closed once a handler is set and the consumer
+	// is receiving: its processing loop runs (in-memory) or its subscriptions are
+	// registered with the server (cluster). Use it instead of sleeping before sending.
+	Ready() <-chan struct{}
+
 	// Unregister unregisters the consumer
 	Unregister() error
 }
@@ -157,18 +304,19 @@ type Consumer interface {
 // MessageHandler handles incoming messages
 type MessageHandler func(ctx FluxorContext, msg Message) error
 
+// MessageFilter reports whether a consumer's handler should get msg; see FilterConsumer
+type MessageFilter func(msg Message) bool
+
+// ErrorHandler is called with the message a handler failed on and the failure
+type ErrorHandler func(ctx FluxorContext, msg Message, err error)
+
 // Errors
 var (
 	ErrNoReplyAddress = &EventBusError{Code: "NO_REPLY_ADDRESS", Message: "No reply address available"}
 	ErrTimeout        = &EventBusError{Code: "TIMEOUT", Message: "Request timeout"}
+	ErrBusClosing     = &EventBusError{Code: "CLOSING", Message: "Event bus is closing"}
+	ErrNoHandlers     = &EventBusError{Code: "NO_HANDLERS", Message: "No handlers registered"}
 )
 
 // EventBusError represents an event bus error
-type EventBusError struct {
-	Code    string
-	Message string
-}
-
-func (e *EventBusError) Error() string {
-	return e.Message
-}
+type EventBusError = FluxorError
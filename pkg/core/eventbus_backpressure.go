@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConsumerBackpressure is returned by the in-memory bus with
+// GoCMDOptions.ReportBackpressure when consumer mailboxes were full. Its Details
+// hold the "address", how many consumers "dropped" the message and how many it
+// was "delivered" to. Publish still reaches the consumers that had room; Send and
+// Request errors also match ErrTimeout, which they return without the option.
+//
+//	if err := bus.Publish("orders.created", order); errors.Is(err, core.ErrConsumerBackpressure) {
+//		// Slow down, or shed load
+//	}
+var ErrConsumerBackpressure = &EventBusError{Code: "CONSUMER_BACKPRESSURE", Message: "Consumer mailbox full"}
+
+// backpressureError reports that dropped of address's consumers had a full mailbox
+func backpressureError(address string, dropped, delivered int, cause error) error {
+	return &EventBusError{
+		Code:    ErrConsumerBackpressure.Code,
+		Message: fmt.Sprintf("%d of %d consumers of %s are saturated", dropped, dropped+delivered, address),
+		Cause:   cause,
+		Details: map[string]interface{}{
+			"address":   address,
+			"dropped":   dropped,
+			"delivered": delivered,
+		},
+	}
+}
+
+// ignoreBackpressure returns err unless it is a Publish's ErrConsumerBackpressure:
+// the message still reached the consumers with room, which counts as delivered
+// for the bus's own announcements, scheduled publishes and audit entries
+func ignoreBackpressure(err error) error {
+	if errors.Is(err, ErrConsumerBackpressure) {
+		return nil
+	}
+	return err
+}
+
+// deliveryError is the error for a publish that dropped consumers of address
+// couldn't take: none by default, backpressureError with ReportBackpressure
+func (eb *eventBus) deliveryError(address string, dropped, delivered int) error {
+	if dropped == 0 || !eb.reportBackpressure {
+		return nil
+	}
+	return backpressureError(address, dropped, delivered, nil)
+}
+
+// mailboxFullError is what Send and Request return when their consumer's mailbox is full
+func (eb *eventBus) mailboxFullError(address string) error {
+	if !eb.reportBackpressure {
+		return ErrTimeout
+	}
+	return backpressureError(address, 1, 0, ErrTimeout)
+}
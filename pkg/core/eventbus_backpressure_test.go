@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEventBus_ReportBackpressure(t *testing.T) {
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{ReportBackpressure: true})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// One consumer blocks so its mailbox fills up, the other keeps up
+	release := make(chan struct{})
+	defer close(release)
+	slow := eb.Consumer("bp.address").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	})
+	fast := eb.Consumer("bp.address").Handler(func(ctx FluxorContext, msg Message) error {
+		return nil
+	})
+	waitReady(t, slow, fast)
+
+	var bpErr error
+	for i := 0; i < 200 && bpErr == nil; i++ {
+		bpErr = eb.Publish("bp.address", i)
+	}
+	if !errors.Is(bpErr, ErrConsumerBackpressure) {
+		t.Fatalf("Publish() to a full mailbox error = %v, want ErrConsumerBackpressure", bpErr)
+	}
+	details := AsError(bpErr).Details
+	dropped, _ := details["dropped"].(int)
+	delivered, _ := details["delivered"].(int)
+	if details["address"] != "bp.address" || dropped < 1 || dropped+delivered != 2 {
+		t.Errorf("backpressure details = %v, want bp.address with drops out of 2 consumers", details)
+	}
+
+	// Send and Request report it too, still matching ErrTimeout
+	eb.Consumer("bp.send").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	})
+	var sendErr error
+	for i := 0; i < 200 && sendErr == nil; i++ {
+		sendErr = eb.Send("bp.send", i)
+	}
+	if !errors.Is(sendErr, ErrConsumerBackpressure) || !errors.Is(sendErr, ErrTimeout) {
+		t.Errorf("Send() to a full mailbox error = %v, want ErrConsumerBackpressure and ErrTimeout", sendErr)
+	}
+	if _, err := eb.Request("bp.send", "ping", 0); !errors.Is(err, ErrConsumerBackpressure) {
+		t.Errorf("Request() to a full mailbox error = %v, want ErrConsumerBackpressure", err)
+	}
+
+	err = eb.PublishBatch("bp.address", []interface{}{"a", "b"})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 2 || !errors.Is(batchErr.Failures[0].Err, ErrConsumerBackpressure) {
+		t.Errorf("PublishBatch() to a full mailbox error = %v, want both bodies failed with ErrConsumerBackpressure", err)
+	}
+}
+
+func TestEventBus_BackpressureOffByDefault(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	release := make(chan struct{})
+	defer close(release)
+	waitReady(t, eb.Consumer("bp.default").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	}))
+
+	for i := 0; i < 200; i++ {
+		if err := eb.Publish("bp.default", i); err != nil {
+			t.Fatalf("Publish() error = %v, want drops to stay silent", err)
+		}
+	}
+	if err := eb.Send("bp.default", "one"); err != ErrTimeout {
+		t.Errorf("Send() to a full mailbox error = %v, want ErrTimeout", err)
+	}
+}
@@ -0,0 +1,292 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// BalanceStrategy chooses the worker a LoadBalancer dispatches to
+type BalanceStrategy string
+
+const (
+	BalanceRoundRobin       BalanceStrategy = "round-robin"       // Each worker in turn (default)
+	BalanceLeastOutstanding BalanceStrategy = "least-outstanding" // The worker with the fewest requests in flight
+	BalanceWeighted         BalanceStrategy = "weighted"          // Round-robin in proportion to Weights
+	BalanceConsistentHash   BalanceStrategy = "consistent-hash"   // The worker owning Key(body) on a hash ring
+)
+
+// DefaultBalancerCooldown is how long a failing worker is skipped by default
+const DefaultBalancerCooldown = 5 * time.Second
+
+// hashRingReplicas is the number of points each worker has on the hash ring
+const hashRingReplicas = 100
+
+// LoadBalancerConfig configures a LoadBalancer
+type LoadBalancerConfig struct {
+	// Strategy picks the worker (default: BalanceRoundRobin)
+	Strategy BalanceStrategy
+
+	// Weights maps worker addresses to their share for BalanceWeighted (default: 1 each)
+	Weights map[string]int
+
+	// Key derives the routing key from a body for BalanceConsistentHash (required there)
+	Key func(body interface{}) string
+
+	// Cooldown is how long a worker that timed out or had no handler is skipped (default: DefaultBalancerCooldown)
+	Cooldown time.Duration
+}
+
+// WorkerStatus is a snapshot of one worker of a LoadBalancer
+type WorkerStatus struct {
+	Address     string    `json:"address"`
+	Available   bool      `json:"available"`
+	Outstanding int       `json:"outstanding"` // Requests in flight
+	Dispatched  uint64    `json:"dispatched"`  // Sends and requests routed to it
+	Failures    uint64    `json:"failures"`    // Timeouts and missing handlers
+	RetryAt     time.Time `json:"retry_at"`    // When an unavailable worker is tried again; zero if available
+}
+
+// LoadBalancer dispatches Send and Request over a fixed set of worker
+// addresses, e.g. a master verticle spreading work over "worker.1".."worker.n":
+//
+//	lb := core.NewLoadBalancer([]string{"worker.1", "worker.2"}, core.LoadBalancerConfig{
+//		Strategy: core.BalanceLeastOutstanding,
+//	})
+//	reply, err := lb.Request(eb, job, 5*time.Second)
+//
+// The bus is passed per call so request-scoped wrappers (tenant, request ID)
+// keep working. A worker that times out or has no handler is skipped for the
+// Cooldown; if every worker is unavailable, all of them are tried again. Failed
+// replies (Message.Fail) come from a live worker and don't count.
+//
+// Send and Request move on to the next worker when one has no handler, since
+// nothing was delivered; timeouts are returned, as the worker may have acted.
+type LoadBalancer struct {
+	strategy BalanceStrategy
+	key      func(body interface{}) string
+	cooldown time.Duration
+
+	mu      sync.Mutex
+	workers []*balancedWorker
+	next    int      // Round-robin cursor
+	ring    []uint32 // Sorted hash points, for BalanceConsistentHash
+	owners  map[uint32]*balancedWorker
+}
+
+type balancedWorker struct {
+	address     string
+	weight      int
+	current     int // Smooth weighted round-robin credit
+	outstanding int
+	dispatched  uint64
+	failures    uint64
+	retryAt     time.Time
+}
+
+// NewLoadBalancer creates a LoadBalancer over addresses.
+// Fail-fast: panics on no or invalid addresses, duplicates, an unknown strategy,
+// weights that are not positive or name an unknown address, a negative Cooldown,
+// or BalanceConsistentHash without a Key
+func NewLoadBalancer(addresses []string, cfg LoadBalancerConfig) *LoadBalancer {
+	failfast.If(len(addresses) > 0, "load balancer needs at least one address")
+	failfast.If(cfg.Cooldown >= 0, "balancer cooldown cannot be negative")
+	if cfg.Strategy == "" {
+		cfg.Strategy = BalanceRoundRobin
+	}
+	switch cfg.Strategy {
+	case BalanceRoundRobin, BalanceLeastOutstanding, BalanceWeighted:
+	case BalanceConsistentHash:
+		failfast.If(cfg.Key != nil, "consistent-hash balancing requires a Key")
+	default:
+		failfast.Err(fmt.Errorf("unknown balance strategy %q", cfg.Strategy))
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = DefaultBalancerCooldown
+	}
+
+	lb := &LoadBalancer{strategy: cfg.Strategy, key: cfg.Key, cooldown: cfg.Cooldown}
+	seen := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		failfast.Err(ValidateAddress(address))
+		failfast.If(!seen[address], "duplicate balancer address %s", address)
+		seen[address] = true
+		w := &balancedWorker{address: address, weight: 1}
+		if weight, ok := cfg.Weights[address]; ok {
+			failfast.If(weight > 0, "weight of %s must be positive", address)
+			w.weight = weight
+		}
+		lb.workers = append(lb.workers, w)
+	}
+	for address := range cfg.Weights {
+		failfast.If(seen[address], "weight for unknown balancer address %s", address)
+	}
+
+	if lb.strategy == BalanceConsistentHash {
+		lb.owners = make(map[uint32]*balancedWorker)
+		for _, w := range lb.workers {
+			for i := 0; i < hashRingReplicas; i++ {
+				point := hashKey(w.address + "#" + strconv.Itoa(i))
+				if _, taken := lb.owners[point]; !taken {
+					lb.owners[point] = w
+					lb.ring = append(lb.ring, point)
+				}
+			}
+		}
+		sort.Slice(lb.ring, func(i, j int) bool { return lb.ring[i] < lb.ring[j] })
+	}
+	return lb
+}
+
+// Next returns the address the next dispatch of body would go to, without
+// dispatching; body is only used by BalanceConsistentHash
+func (lb *LoadBalancer) Next(body interface{}) string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.pick(body, nil, time.Now()).address
+}
+
+// Send sends body to one worker
+func (lb *LoadBalancer) Send(eb EventBus, body interface{}) error {
+	failfast.NotNil(eb, "eventBus")
+	return lb.dispatch(body, func(address string) error {
+		return eb.Send(address, body)
+	})
+}
+
+// Request sends body to one worker and waits for its reply, as eb.Request
+func (lb *LoadBalancer) Request(eb EventBus, body interface{}, timeout time.Duration) (Message, error) {
+	failfast.NotNil(eb, "eventBus")
+	var reply Message
+	err := lb.dispatch(body, func(address string) error {
+		var err error
+		reply, err = eb.Request(address, body, timeout)
+		return err
+	})
+	return reply, err
+}
+
+// Workers returns the status of every worker, in the order they were given
+func (lb *LoadBalancer) Workers() []WorkerStatus {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	now := time.Now()
+	status := make([]WorkerStatus, len(lb.workers))
+	for i, w := range lb.workers {
+		status[i] = WorkerStatus{
+			Address:     w.address,
+			Available:   !now.Before(w.retryAt),
+			Outstanding: w.outstanding,
+			Dispatched:  w.dispatched,
+			Failures:    w.failures,
+		}
+		if !status[i].Available {
+			status[i].RetryAt = w.retryAt
+		}
+	}
+	return status
+}
+
+// dispatch runs send against picked workers until one has a handler or every
+// worker was tried
+func (lb *LoadBalancer) dispatch(body interface{}, send func(address string) error) error {
+	tried := make(map[*balancedWorker]bool)
+	for {
+		lb.mu.Lock()
+		w := lb.pick(body, tried, time.Now())
+		tried[w] = true
+		w.outstanding++
+		w.dispatched++
+		lb.mu.Unlock()
+
+		err := send(w.address)
+
+		lb.mu.Lock()
+		w.outstanding--
+		if errors.Is(err, ErrNoHandlers) || errors.Is(err, ErrTimeout) {
+			w.failures++
+			w.retryAt = time.Now().Add(lb.cooldown)
+		} else if err == nil {
+			w.retryAt = time.Time{}
+		}
+		lb.mu.Unlock()
+
+		if !errors.Is(err, ErrNoHandlers) || len(tried) == len(lb.workers) {
+			return err
+		}
+	}
+}
+
+// pick chooses a worker not in skip, preferring available ones. Caller holds lb.mu.
+func (lb *LoadBalancer) pick(body interface{}, skip map[*balancedWorker]bool, now time.Time) *balancedWorker {
+	candidates := make([]*balancedWorker, 0, len(lb.workers))
+	for _, w := range lb.workers {
+		if !skip[w] && !now.Before(w.retryAt) {
+			candidates = append(candidates, w)
+		}
+	}
+	if len(candidates) == 0 {
+		// Nothing available: try the rest anyway rather than fail without sending
+		for _, w := range lb.workers {
+			if !skip[w] {
+				candidates = append(candidates, w)
+			}
+		}
+	}
+
+	switch lb.strategy {
+	case BalanceLeastOutstanding:
+		// Ties rotate, so an idle pool still spreads the load
+		start := lb.next % len(candidates)
+		lb.next++
+		best := candidates[start]
+		for i := 1; i < len(candidates); i++ {
+			if w := candidates[(start+i)%len(candidates)]; w.outstanding < best.outstanding {
+				best = w
+			}
+		}
+		return best
+	case BalanceWeighted:
+		// Smooth weighted round-robin: spreads a heavy worker's turns out
+		total := 0
+		var best *balancedWorker
+		for _, w := range candidates {
+			w.current += w.weight
+			total += w.weight
+			if best == nil || w.current > best.current {
+				best = w
+			}
+		}
+		best.current -= total
+		return best
+	case BalanceConsistentHash:
+		allowed := make(map[*balancedWorker]bool, len(candidates))
+		for _, w := range candidates {
+			allowed[w] = true
+		}
+		h := hashKey(lb.key(body))
+		i := sort.Search(len(lb.ring), func(i int) bool { return lb.ring[i] >= h })
+		for n := 0; n < len(lb.ring); n++ {
+			if w := lb.owners[lb.ring[(i+n)%len(lb.ring)]]; allowed[w] {
+				return w
+			}
+		}
+		return candidates[0]
+	default:
+		w := candidates[lb.next%len(candidates)]
+		lb.next++
+		return w
+	}
+}
+
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
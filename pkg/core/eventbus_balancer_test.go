@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLoadBalancer_Strategies(t *testing.T) {
+	workers := []string{"lb.w1", "lb.w2", "lb.w3"}
+
+	counts := func(lb *LoadBalancer, n int, body func(i int) interface{}) map[string]int {
+		got := make(map[string]int)
+		for i := 0; i < n; i++ {
+			got[lb.Next(body(i))]++
+		}
+		return got
+	}
+	nobody := func(int) interface{} { return nil }
+
+	rr := counts(NewLoadBalancer(workers, LoadBalancerConfig{}), 9, nobody)
+	for _, w := range workers {
+		if rr[w] != 3 {
+			t.Errorf("round-robin picks = %v, want 3 each", rr)
+			break
+		}
+	}
+
+	weighted := counts(NewLoadBalancer(workers, LoadBalancerConfig{
+		Strategy: BalanceWeighted,
+		Weights:  map[string]int{"lb.w1": 3, "lb.w3": 2},
+	}), 12, nobody)
+	if weighted["lb.w1"] != 6 || weighted["lb.w2"] != 2 || weighted["lb.w3"] != 4 {
+		t.Errorf("weighted picks = %v, want 6/2/4", weighted)
+	}
+
+	hashed := NewLoadBalancer(workers, LoadBalancerConfig{
+		Strategy: BalanceConsistentHash,
+		Key:      func(body interface{}) string { return body.(string) },
+	})
+	spread := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("order-%d", i)
+		first := hashed.Next(key)
+		if again := hashed.Next(key); again != first {
+			t.Fatalf("consistent-hash picked %s then %s for %s", first, again, key)
+		}
+		spread[first] = true
+	}
+	if len(spread) != len(workers) {
+		t.Errorf("consistent-hash used %d of %d workers for 50 keys", len(spread), len(workers))
+	}
+}
+
+func TestLoadBalancer_LeastOutstanding(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	release := make(chan struct{})
+	slow := eb.Consumer("lb.slow").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return msg.Reply("slow")
+	})
+	fast := eb.Consumer("lb.fast").Handler(func(ctx FluxorContext, msg Message) error {
+		return msg.Reply("fast")
+	})
+	waitReady(t, slow, fast)
+
+	lb := NewLoadBalancer([]string{"lb.slow", "lb.fast"}, LoadBalancerConfig{Strategy: BalanceLeastOutstanding})
+	// Requests that tie go either way; keep starting them until one is stuck on the slow worker
+	deadline := time.Now().Add(time.Second)
+	for lb.Workers()[0].Outstanding == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no request reached the slow worker")
+		}
+		go func() { _, _ = lb.Request(eb, "job", time.Second) }()
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The slow worker is busy, so everything goes to the fast one
+	for i := 0; i < 4; i++ {
+		reply, err := lb.Request(eb, "job", time.Second)
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		var got string
+		_ = reply.DecodeBody(&got)
+		if got != "fast" {
+			t.Errorf("reply %d from %s, want fast", i, got)
+		}
+	}
+	close(release)
+	for lb.Workers()[0].Outstanding > 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLoadBalancer_Health(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	live := eb.Consumer("lb.live").Handler(func(ctx FluxorContext, msg Message) error {
+		if msg.ReplyAddress() == "" {
+			return nil
+		}
+		return msg.Reply("ok")
+	})
+	stuck := eb.Consumer("lb.stuck").Handler(func(ctx FluxorContext, msg Message) error {
+		return nil // Never replies
+	})
+	waitReady(t, live, stuck)
+
+	// lb.gone has no consumer: sends move on to the next worker
+	lb := NewLoadBalancer([]string{"lb.gone", "lb.live"}, LoadBalancerConfig{Cooldown: time.Hour})
+	for i := 0; i < 3; i++ {
+		if err := lb.Send(eb, "event"); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	status := lb.Workers()
+	if status[0].Available || status[0].Failures != 1 || status[0].RetryAt.IsZero() {
+		t.Errorf("worker without handler status = %+v, want unavailable after one failure", status[0])
+	}
+	if status[1].Dispatched != 3 {
+		t.Errorf("live worker dispatched = %d, want 3", status[1].Dispatched)
+	}
+
+	// A timeout is returned, not retried, and benches the worker
+	lb = NewLoadBalancer([]string{"lb.stuck", "lb.live"}, LoadBalancerConfig{Cooldown: time.Hour})
+	if _, err := lb.Request(eb, "job", 20*time.Millisecond); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Request() to a stuck worker error = %v, want ErrTimeout", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := lb.Request(eb, "job", time.Second); err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+	}
+	if got := lb.Workers()[0].Dispatched; got != 1 {
+		t.Errorf("stuck worker dispatched = %d, want 1", got)
+	}
+
+	// With every worker benched, they are tried anyway
+	lb = NewLoadBalancer([]string{"lb.none1", "lb.none2"}, LoadBalancerConfig{Cooldown: time.Hour})
+	for i := 0; i < 2; i++ {
+		if err := lb.Send(eb, "event"); !errors.Is(err, ErrNoHandlers) {
+			t.Errorf("Send() without any handler error = %v, want ErrNoHandlers", err)
+		}
+	}
+	for _, w := range lb.Workers() {
+		if w.Failures != 2 {
+			t.Errorf("%s failures = %d, want 2", w.Address, w.Failures)
+		}
+	}
+}
+
+func TestNewLoadBalancer_FailFast(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		cfg       LoadBalancerConfig
+	}{
+		{"no addresses", nil, LoadBalancerConfig{}},
+		{"empty address", []string{"lb.a", ""}, LoadBalancerConfig{}},
+		{"duplicate", []string{"lb.a", "lb.a"}, LoadBalancerConfig{}},
+		{"unknown strategy", []string{"lb.a"}, LoadBalancerConfig{Strategy: "random"}},
+		{"hash without key", []string{"lb.a"}, LoadBalancerConfig{Strategy: BalanceConsistentHash}},
+		{"zero weight", []string{"lb.a"}, LoadBalancerConfig{Strategy: BalanceWeighted, Weights: map[string]int{"lb.a": 0}}},
+		{"unknown weight", []string{"lb.a"}, LoadBalancerConfig{Strategy: BalanceWeighted, Weights: map[string]int{"lb.b": 1}}},
+		{"negative cooldown", []string{"lb.a"}, LoadBalancerConfig{Cooldown: -time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("NewLoadBalancer() should panic")
+				}
+			}()
+			NewLoadBalancer(tt.addresses, tt.cfg)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+	"github.com/nats-io/nats.go"
+)
+
+// BatchFailure is one body of a PublishBatch that was not published
+type BatchFailure struct {
+	Index int // Position in the bodies slice
+	Err   error
+}
+
+// BatchError is returned by PublishBatch when some bodies failed; the others were published
+type BatchError struct {
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	first := e.Failures[0]
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("publish batch: body %d failed: %v", first.Index, first.Err)
+	}
+	return fmt.Sprintf("publish batch: %d bodies failed (first: body %d: %v)", len(e.Failures), first.Index, first.Err)
+}
+
+// Unwrap returns the per-body errors, so errors.Is/As see through the batch
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// batchResult collects per-body failures
+type batchResult struct {
+	failures []BatchFailure
+}
+
+func (r *batchResult) fail(index int, err error) {
+	r.failures = append(r.failures, BatchFailure{Index: index, Err: err})
+}
+
+func (r *batchResult) err() error {
+	if len(r.failures) == 0 {
+		return nil
+	}
+	sort.Slice(r.failures, func(i, j int) bool { return r.failures[i].Index < r.failures[j].Index })
+	return &BatchError{Failures: r.failures}
+}
+
+// PublishBatch implements EventBus.
+func (eb *eventBus) PublishBatch(address string, bodies []interface{}) error {
+	return eb.publishBatchWithHeaders(address, bodies, nil)
+}
+
+func (eb *eventBus) publishBatchWithHeaders(address string, bodies []interface{}, extra map[string]string) error {
+	if err := ValidateAddress(address); err != nil {
+		return err
+	}
+	if err := eb.accepting(address); err != nil {
+		return err
+	}
+
+	var result batchResult
+	encoded := make([]interface{}, len(bodies))
+	for i, body := range bodies {
+		jsonBody, err := eb.encodeBody(address, body)
+		if err != nil {
+			result.fail(i, fmt.Errorf("encode body failed: %w", err))
+			continue
+		}
+		encoded[i] = jsonBody
+	}
+
+	// Resolve handlers once for the whole batch
+	eb.mu.RLock()
+	consumers := eb.consumers[address]
+	eb.mu.RUnlock()
+
+	headers := make(map[string]string)
+	if requestID := GetRequestID(eb.ctx); requestID != "" {
+		headers["X-Request-ID"] = requestID
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+
+	for i, jsonBody := range encoded {
+		if jsonBody == nil {
+			continue
+		}
+		msg := newMessage(jsonBody, headers, "", eb)
+		atomic.AddInt64(&eb.counters.published, 1)
+		eb.observe(address, msg)
+		dropped := 0
+		for _, c := range consumers {
+			if err := c.mailbox.Send(msg); err != nil {
+				if err == concurrency.ErrMailboxFull {
+					// Same as Publish: a busy handler misses the message
+					c.recordDrop()
+					dropped++
+					continue
+				}
+				if err == concurrency.ErrMailboxClosed {
+					return eb.ctx.Err()
+				}
+				result.fail(i, err)
+				dropped = 0
+				break
+			}
+		}
+		if err := eb.deliveryError(address, dropped, len(consumers)-dropped); err != nil {
+			result.fail(i, err)
+		}
+	}
+
+	return result.err()
+}
+
+// PublishBatch implements EventBus. Messages are buffered by the connection
+// and flushed together.
+func (eb *clusterNATSEventBus) PublishBatch(address string, bodies []interface{}) error {
+	return eb.publishBatchWithHeaders(address, bodies, nil)
+}
+
+func (eb *clusterNATSEventBus) publishBatchWithHeaders(address string, bodies []interface{}, extra map[string]string) error {
+	if err := ValidateAddress(address); err != nil {
+		return err
+	}
+
+	var result batchResult
+	subject := eb.subjectPub(address)
+	for i, body := range bodies {
+		msg, err := eb.batchMsg(subject, body, extra)
+		if err != nil {
+			result.fail(i, err)
+			continue
+		}
+		if err := eb.nc.PublishMsg(msg); err != nil {
+			result.fail(i, err)
+			continue
+		}
+		atomic.AddInt64(&eb.counters.published, 1)
+	}
+	return result.err()
+}
+
+// batchMsg builds the NATS message for one body of a batch
+func (eb *clusterNATSEventBus) batchMsg(subject string, body interface{}, extra map[string]string) (*nats.Msg, error) {
+	if err := ValidateBody(body); err != nil {
+		return nil, err
+	}
+	data, err := eb.encodeMessage(subject, body)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    data,
+		Header:  nats.Header{},
+	}
+	if rid := GetRequestID(eb.ctx); rid != "" {
+		msg.Header.Set("X-Request-ID", rid)
+	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
+	return msg, nil
+}
+
+// PublishBatch implements EventBus. Bodies are published asynchronously and
+// their acks awaited together, bounded by the request timeout.
+func (eb *clusterJSEventBus) PublishBatch(address string, bodies []interface{}) error {
+	return eb.publishBatchWithHeaders(address, bodies, nil)
+}
+
+func (eb *clusterJSEventBus) publishBatchWithHeaders(address string, bodies []interface{}, extra map[string]string) error {
+	if err := ValidateAddress(address); err != nil {
+		return err
+	}
+
+	var result batchResult
+	subject := eb.subjectPub(address)
+	nb := eb.coreBus()
+	futures := make([]nats.PubAckFuture, len(bodies))
+	for i, body := range bodies {
+		msg, err := nb.batchMsg(subject, body, extra)
+		if err != nil {
+			result.fail(i, err)
+			continue
+		}
+		future, err := eb.js.PublishMsgAsync(msg)
+		if err != nil {
+			result.fail(i, err)
+			continue
+		}
+		futures[i] = future
+	}
+
+	deadline := time.NewTimer(eb.requestTimeout)
+	defer deadline.Stop()
+	for i, future := range futures {
+		if future == nil {
+			continue
+		}
+		select {
+		case <-future.Ok():
+			atomic.AddInt64(&eb.counters.published, 1)
+		case err := <-future.Err():
+			result.fail(i, err)
+		case <-deadline.C:
+			// Out of time; every ack still outstanding counts as failed
+			for j := i; j < len(futures); j++ {
+				if futures[j] != nil {
+					result.fail(j, ErrTimeout)
+				}
+			}
+			return result.err()
+		}
+	}
+	return result.err()
+}
+
+// PublishBatch implements EventBus, recording the batch as one "publish" entry.
+func (t *TracingEventBus) PublishBatch(address string, bodies []interface{}) error {
+	entry, headers := t.begin("publish", address)
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		err = hs.publishBatchWithHeaders(address, bodies, headers)
+	} else {
+		err = t.EventBus.PublishBatch(address, bodies)
+	}
+	t.finish(entry, err)
+	return err
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_PublishBatch(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var got int64
+	eb.Consumer("import.rows").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&got, 1)
+		return nil
+	})
+
+	bodies := make([]interface{}, 100)
+	for i := range bodies {
+		bodies[i] = map[string]int{"row": i}
+	}
+	if err := eb.PublishBatch("import.rows", bodies); err != nil {
+		t.Fatalf("PublishBatch() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(&got) < 100 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&got); n != 100 {
+		t.Errorf("delivered %d messages, want 100", n)
+	}
+	if stats := eb.Stats(); stats.Published != 100 {
+		t.Errorf("Stats().Published = %d, want 100", stats.Published)
+	}
+}
+
+func TestEventBus_PublishBatch_PartialFailure(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var got int64
+	eb.Consumer("import.rows").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&got, 1)
+		return nil
+	})
+
+	err := eb.PublishBatch("import.rows", []interface{}{"a", nil, "c", make(chan int)})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("PublishBatch() error = %v, want *BatchError", err)
+	}
+	if len(batchErr.Failures) != 2 || batchErr.Failures[0].Index != 1 || batchErr.Failures[1].Index != 3 {
+		t.Errorf("failures = %+v, want indices 1 and 3", batchErr.Failures)
+	}
+	var ebErr *EventBusError
+	if !errors.As(err, &ebErr) || ebErr.Code != "INVALID_BODY" {
+		t.Errorf("errors.As(err, *EventBusError) = %v, want INVALID_BODY", ebErr)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(&got) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&got); n != 2 {
+		t.Errorf("delivered %d messages, want the 2 valid bodies", n)
+	}
+}
+
+func TestEventBus_PublishBatch_InvalidAddress(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	err := gocmd.EventBus().PublishBatch("", []interface{}{"a"})
+	var batchErr *BatchError
+	if err == nil || errors.As(err, &batchErr) {
+		t.Errorf("PublishBatch(\"\") error = %v, want an address error for the whole batch", err)
+	}
+}
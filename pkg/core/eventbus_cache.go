@@ -0,0 +1,216 @@
+package core
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// Default CacheConfig values
+const (
+	DefaultCacheTTL  = time.Minute
+	DefaultCacheSize = 10000
+)
+
+// CacheInvalidateAll is the body to publish on CacheConfig.InvalidateAddress to
+// drop every cached reply
+const CacheInvalidateAll = "*"
+
+// CacheConfig configures a CachedConsumer
+type CacheConfig struct {
+	// TTL is how long a reply is served from the cache (default: DefaultCacheTTL)
+	TTL time.Duration
+
+	// Size is how many replies are kept, evicting the least recently used (default: DefaultCacheSize)
+	Size int
+
+	// Key derives the cache key from a request (default: the encoded body)
+	Key func(msg Message) string
+
+	// InvalidateAddress, if set, is consumed on EventBus: publishing a request
+	// body there drops its cached reply, and publishing CacheInvalidateAll drops them all
+	InvalidateAddress string
+	EventBus          EventBus
+}
+
+// CachedConsumer returns a handler that caches inner's replies by request key for
+// the TTL and answers repeated requests from the cache without calling inner,
+// for read-heavy request/reply addresses:
+//
+//	eb.Consumer("product.get").Handler(core.CachedConsumer(getProduct, core.CacheConfig{
+//		TTL:               30 * time.Second,
+//		InvalidateAddress: "product.changed",
+//		EventBus:          eb,
+//	}))
+//
+// Only Reply is cached, with the reply headers set by SetHeader; failures,
+// streamed replies and messages without a reply address always reach inner.
+// The cache is per handler and in memory, so publish invalidations (rather than
+// send them) to reach every replica.
+// Fail-fast: panics on a nil handler, negative config values, or an
+// InvalidateAddress without an EventBus
+func CachedConsumer(inner MessageHandler, cfg CacheConfig) MessageHandler {
+	failfast.NotNil(inner, "handler")
+	failfast.If(cfg.TTL >= 0, "cache TTL cannot be negative")
+	failfast.If(cfg.Size >= 0, "cache size cannot be negative")
+	failfast.If(cfg.InvalidateAddress == "" || cfg.EventBus != nil, "cache InvalidateAddress requires an EventBus")
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultCacheTTL
+	}
+	if cfg.Size == 0 {
+		cfg.Size = DefaultCacheSize
+	}
+	if cfg.Key == nil {
+		cfg.Key = bodyKey
+	}
+
+	cache := newReplyCache(cfg.Size)
+	if cfg.InvalidateAddress != "" {
+		cfg.EventBus.Consumer(cfg.InvalidateAddress).Handler(func(ctx FluxorContext, msg Message) error {
+			var all string
+			if msg.DecodeBody(&all) == nil && all == CacheInvalidateAll {
+				cache.clear()
+			} else {
+				cache.remove(cfg.Key(msg))
+			}
+			return nil
+		})
+	}
+
+	return func(ctx FluxorContext, msg Message) error {
+		if msg.ReplyAddress() == "" {
+			return inner(ctx, msg)
+		}
+		key := cfg.Key(msg)
+		if cached, ok := cache.get(key, time.Now()); ok {
+			for k, v := range cached.headers {
+				msg.SetHeader(k, v)
+			}
+			return msg.Reply(cached.body)
+		}
+
+		rec := &recordingMessage{Message: msg}
+		err := inner(ctx, rec)
+		if err == nil && rec.replied {
+			cache.put(key, cachedReply{body: rec.body, headers: rec.headers}, time.Now().Add(cfg.TTL))
+		}
+		return err
+	}
+}
+
+// bodyKey is the default cache key: the encoded request body
+func bodyKey(msg Message) string {
+	if data, ok := msg.Body().([]byte); ok {
+		return string(data)
+	}
+	data, err := JSONEncode(msg.Body())
+	if err != nil {
+		return fmt.Sprint(msg.Body())
+	}
+	return string(data)
+}
+
+// recordingMessage keeps the reply a handler sends, for CachedConsumer
+type recordingMessage struct {
+	Message
+	replied bool
+	body    []byte
+	headers map[string]string
+}
+
+func (m *recordingMessage) SetHeader(key, value string) {
+	if m.headers == nil {
+		m.headers = make(map[string]string)
+	}
+	m.headers[key] = value
+	m.Message.SetHeader(key, value)
+}
+
+func (m *recordingMessage) Reply(body interface{}) error {
+	// Encoded now, so the cached reply doesn't change with the handler's value
+	data, ok := body.([]byte)
+	if !ok {
+		var err error
+		if data, err = JSONEncode(body); err != nil {
+			return m.Message.Reply(body)
+		}
+	}
+	if err := m.Message.Reply(data); err != nil {
+		return err
+	}
+	m.replied, m.body = true, data
+	return nil
+}
+
+type cachedReply struct {
+	body    []byte
+	headers map[string]string
+}
+
+// replyCache is an LRU of replies with an expiry each
+type replyCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element // Key -> entry in order
+	order   *list.List               // replyEntry, least recently used first
+}
+
+type replyEntry struct {
+	key     string
+	reply   cachedReply
+	expires time.Time
+}
+
+func newReplyCache(size int) *replyCache {
+	return &replyCache{size: size, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *replyCache) get(key string, now time.Time) (cachedReply, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return cachedReply{}, false
+	}
+	entry := e.Value.(replyEntry)
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		c.order.Remove(e)
+		return cachedReply{}, false
+	}
+	c.order.MoveToBack(e)
+	return entry.reply, true
+}
+
+func (c *replyCache) put(key string, reply cachedReply, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+	}
+	c.entries[key] = c.order.PushBack(replyEntry{key: key, reply: reply, expires: expires})
+	for c.order.Len() > c.size {
+		oldest := c.order.Front()
+		delete(c.entries, oldest.Value.(replyEntry).key)
+		c.order.Remove(oldest)
+	}
+}
+
+func (c *replyCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		delete(c.entries, key)
+		c.order.Remove(e)
+	}
+}
+
+func (c *replyCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
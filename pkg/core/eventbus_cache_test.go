@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedConsumer(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var calls int32
+	getProduct := func(ctx FluxorContext, msg Message) error {
+		var req struct {
+			ID string `json:"id"`
+		}
+		if err := msg.DecodeBody(&req); err != nil {
+			return err
+		}
+		if req.ID == "missing" {
+			atomic.AddInt32(&calls, 1)
+			return msg.Fail(404, "not found")
+		}
+		n := atomic.AddInt32(&calls, 1)
+		msg.SetHeader("X-Version", "v1")
+		return msg.Reply(map[string]interface{}{"id": req.ID, "call": n})
+	}
+	c := eb.Consumer("cache.product.get").Handler(CachedConsumer(getProduct, CacheConfig{
+		TTL:               100 * time.Millisecond,
+		InvalidateAddress: "cache.product.changed",
+		EventBus:          eb,
+	}))
+	waitReady(t, c)
+
+	request := func(id string) (int, string) {
+		t.Helper()
+		reply, err := eb.Request("cache.product.get", map[string]string{"id": id}, time.Second)
+		if err != nil {
+			t.Fatalf("Request(%s) error = %v", id, err)
+		}
+		var body struct {
+			Call int `json:"call"`
+		}
+		if err := reply.DecodeBody(&body); err != nil {
+			t.Fatalf("DecodeBody() error = %v", err)
+		}
+		return body.Call, reply.Header("X-Version")
+	}
+
+	if call, version := request("1"); call != 1 || version != "v1" {
+		t.Fatalf("first reply = call %d version %q, want call 1 version v1", call, version)
+	}
+	if call, version := request("1"); call != 1 || version != "v1" {
+		t.Errorf("cached reply = call %d version %q, want call 1 version v1", call, version)
+	}
+	if call, _ := request("2"); call != 2 {
+		t.Errorf("other key reply = call %d, want 2", call)
+	}
+
+	// Failures aren't cached
+	for i := 0; i < 2; i++ {
+		reply, err := eb.Request("cache.product.get", map[string]string{"id": "missing"}, time.Second)
+		if err != nil {
+			t.Fatalf("Request(missing) error = %v", err)
+		}
+		if ReplyError(reply) == nil {
+			t.Fatal("Request(missing) should get a failure reply")
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Errorf("calls after two failures = %d, want 4", n)
+	}
+
+	// Publishing the request body drops its entry
+	if err := eb.Publish("cache.product.changed", map[string]string{"id": "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if call, _ := request("1"); call != 5 {
+		t.Errorf("reply after invalidation = call %d, want 5", call)
+	}
+	if call, _ := request("2"); call != 2 {
+		t.Errorf("reply for a key not invalidated = call %d, want 2", call)
+	}
+
+	// CacheInvalidateAll drops everything; entries also expire after the TTL
+	if err := eb.Publish("cache.product.changed", CacheInvalidateAll); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if call, _ := request("2"); call != 6 {
+		t.Errorf("reply after invalidating all = call %d, want 6", call)
+	}
+	time.Sleep(120 * time.Millisecond)
+	if call, _ := request("2"); call != 7 {
+		t.Errorf("reply after TTL = call %d, want 7", call)
+	}
+}
+
+func TestCachedConsumer_Size(t *testing.T) {
+	var calls int
+	h := CachedConsumer(func(ctx FluxorContext, msg Message) error {
+		calls++
+		return nil // No reply: nothing to cache
+	}, CacheConfig{Size: 1})
+
+	for i := 0; i < 2; i++ {
+		if err := h(nil, newMessage([]byte(`"a"`), nil, "", nil)); err != nil {
+			t.Fatalf("handler error = %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("calls without reply address = %d, want 2", calls)
+	}
+
+	cache := newReplyCache(1)
+	now := time.Now()
+	cache.put("a", cachedReply{body: []byte("1")}, now.Add(time.Minute))
+	cache.put("b", cachedReply{body: []byte("2")}, now.Add(time.Minute))
+	if _, ok := cache.get("a", now); ok {
+		t.Error("a should be evicted past Size")
+	}
+	if _, ok := cache.get("b", now); !ok {
+		t.Error("b should be cached")
+	}
+}
+
+func TestCachedConsumer_RequiresEventBusForInvalidation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CachedConsumer() should panic on InvalidateAddress without EventBus")
+		}
+	}()
+	CachedConsumer(func(ctx FluxorContext, msg Message) error { return nil }, CacheConfig{InvalidateAddress: "x.changed"})
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClusterEventBusJetStream_PublishBatch(t *testing.T) {
+	s := runTestNATSJetStreamServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+	bus, err := NewClusterEventBusJetStream(ctx, gocmd, ClusterJetStreamConfig{
+		URL:     s.ClientURL(),
+		Prefix:  "fluxor.js.batch",
+		Service: "importer",
+	})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusJetStream: %v", err)
+	}
+	defer bus.Close()
+
+	var got int64
+	waitReady(t, bus.Consumer("import.rows").Handler(func(_ FluxorContext, msg Message) error {
+		atomic.AddInt64(&got, 1)
+		return nil
+	}))
+
+	bodies := make([]interface{}, 500)
+	for i := range bodies {
+		bodies[i] = map[string]int{"row": i}
+	}
+	bodies[7] = nil
+	err = bus.PublishBatch("import.rows", bodies)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 7 {
+		t.Fatalf("PublishBatch() error = %v, want one failure at index 7", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt64(&got) < 499 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&got); n != 499 {
+		t.Errorf("delivered %d messages, want 499", n)
+	}
+}
+
+func TestClusterEventBusNATS_PublishBatch(t *testing.T) {
+	s := runTestNATSServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+	bus, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.batch"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	defer bus.Close()
+
+	var got int64
+	waitReady(t, bus.Consumer("import.rows").Handler(func(_ FluxorContext, msg Message) error {
+		atomic.AddInt64(&got, 1)
+		return nil
+	}))
+
+	if err := bus.PublishBatch("import.rows", []interface{}{1, 2, 3}); err != nil {
+		t.Fatalf("PublishBatch() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(&got) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&got); n != 3 {
+		t.Errorf("delivered %d messages, want 3", n)
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClusterEventBusNATS_Ping(t *testing.T) {
+	s := runTestNATSServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+	bus, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{
+		URL:    s.ClientURL(),
+		Prefix: "fluxor.health",
+	})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	defer bus.Close()
+
+	pinger, ok := bus.(Pinger)
+	if !ok {
+		t.Fatal("cluster bus should implement Pinger")
+	}
+	if err := pinger.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	// Tracing keeps the check reachable
+	if err := NewTracingEventBus(bus, TraceOptions{}).Ping(ctx); err != nil {
+		t.Fatalf("TracingEventBus.Ping() error = %v", err)
+	}
+
+	// Pings time out until the client notices the server is gone and reports reconnecting
+	s.Shutdown()
+	var ebErr *EventBusError
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		pingCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		err = pinger.Ping(pingCtx)
+		cancel()
+		if errors.As(err, &ebErr) && ebErr.Code == "NOT_CONNECTED" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Ping() after shutdown = %v, want NOT_CONNECTED", err)
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestHybridPair(t *testing.T) (*HybridEventBus, EventBus) {
+	t.Helper()
+	s := runTestNATSServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	t.Cleanup(func() { gocmd.Close() })
+
+	cluster, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.hybrid"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	hybrid := NewHybridEventBus(ctx, gocmd, cluster, HybridConfig{RemoteAddresses: []string{"orders.created"}})
+	t.Cleanup(func() { hybrid.Close() })
+
+	// Another process on the same cluster
+	other, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.hybrid"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	t.Cleanup(func() { other.Close() })
+	return hybrid, other
+}
+
+func waitCount(n *int64, want int64) int64 {
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(n) < want && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond) // Catch duplicates
+	return atomic.LoadInt64(n)
+}
+
+func TestHybridEventBus_LocalFastPath(t *testing.T) {
+	hybrid, other := newTestHybridPair(t)
+
+	var mu sync.Mutex
+	var decisions []RouteDecision
+	hybrid.onRoute = func(d RouteDecision) {
+		mu.Lock()
+		decisions = append(decisions, d)
+		mu.Unlock()
+	}
+
+	var local, remote int64
+	c1 := hybrid.Consumer("payments.charge").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		atomic.AddInt64(&local, 1)
+		return map[string]string{"status": "ok"}, nil
+	})
+	c2 := other.Consumer("payments.charge").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&remote, 1)
+		return nil
+	})
+	waitReady(t, c1, c2)
+
+	if _, err := hybrid.Request("payments.charge", map[string]int{"amount": 5}, time.Second); err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	// Not a known remote address: the publish stays in-process
+	if err := hybrid.Publish("payments.charge", map[string]int{"amount": 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := waitCount(&local, 2); n != 2 {
+		t.Errorf("local deliveries = %d, want 2", n)
+	}
+	if n := atomic.LoadInt64(&remote); n != 0 {
+		t.Errorf("remote deliveries = %d, want 0", n)
+	}
+	if counts := hybrid.RouteCounts(); counts.Local != 2 || counts.Remote != 0 {
+		t.Errorf("RouteCounts() = %+v, want 2 local", counts)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(decisions) != 2 || decisions[0].Op != "request" || !decisions[0].Local || decisions[0].Remote {
+		t.Errorf("decisions = %+v", decisions)
+	}
+}
+
+func TestHybridEventBus_RemoteAddresses(t *testing.T) {
+	hybrid, other := newTestHybridPair(t)
+
+	var local, remote, inbound int64
+	waitReady(t,
+		hybrid.Consumer("orders.created").Handler(func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt64(&local, 1)
+			return nil
+		}),
+		other.Consumer("orders.created").Handler(func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt64(&remote, 1)
+			return nil
+		}),
+		hybrid.Consumer("inventory.reserve").Handler(func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt64(&inbound, 1)
+			return nil
+		}),
+		other.Consumer("audit.log").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+			return "logged", nil
+		}),
+	)
+
+	// Known remote address: delivered once in-process and once remotely, not twice locally
+	if err := hybrid.Publish("orders.created", map[string]int{"id": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if n := waitCount(&local, 1); n != 1 {
+		t.Errorf("local deliveries = %d, want 1", n)
+	}
+	if n := waitCount(&remote, 1); n != 1 {
+		t.Errorf("remote deliveries = %d, want 1", n)
+	}
+
+	// No local consumer: goes through the cluster
+	if _, err := hybrid.Request("audit.log", "x", time.Second); err != nil {
+		t.Errorf("Request(audit.log) error = %v", err)
+	}
+
+	// Other processes still reach hybrid consumers
+	if err := other.Send("inventory.reserve", map[string]int{"sku": 7}); err != nil {
+		t.Fatal(err)
+	}
+	if n := waitCount(&inbound, 1); n != 1 {
+		t.Errorf("inbound deliveries = %d, want 1", n)
+	}
+
+	if counts := hybrid.RouteCounts(); counts.Both != 1 || counts.Remote != 1 {
+		t.Errorf("RouteCounts() = %+v, want 1 both and 1 remote", counts)
+	}
+}
@@ -2,13 +2,16 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core/concurrency"
 	"github.com/fluxorio/fluxor/pkg/core/failfast"
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 )
 
@@ -32,7 +35,17 @@ type ClusterJetStreamConfig struct {
 	// Name is an optional NATS connection name.
 	Name string
 
-	// RequestTimeout is the default timeout used by Request when timeout==0.
+	// Region, if set, makes Send and Request prefer consumers in the same region,
+	// crossing regions only when the local one has none (see RegionPolicy).
+	// Publish stays global. Must be a single subject token, e.g. "eu-west".
+	Region string
+
+	// RegionPolicies overrides the routing of individual addresses when Region is
+	// set. Default: RegionPreferLocal.
+	RegionPolicies map[string]RegionPolicy
+
+	// RequestTimeout is the default timeout used by Request when timeout <= 0.
+	// Default: DefaultRequestTimeout.
 	RequestTimeout time.Duration
 
 	// StreamMaxAge configures how long published messages are retained in JetStream streams.
@@ -52,6 +65,23 @@ type ClusterJetStreamConfig struct {
 	// MaxAckPending bounds in-flight, unacked messages per consumer. Default: 1024.
 	MaxAckPending int
 
+	// RejectBackoff is how long a subscription pauses deliveries after the executor
+	// refuses a message, doubling with consecutive refusals up to 1s; the refused
+	// message is redelivered after the pause. Default: DefaultRejectBackoff.
+	RejectBackoff time.Duration
+
+	// OnReject, if set, is called for each message the executor refused because it
+	// was saturated; rejections are also counted in Stats().Rejected.
+	OnReject RejectHandler
+
+	// MaxMessageSize bounds encoded message bodies in bytes; larger ones are rejected
+	// with a MESSAGE_TOO_LARGE error. Default (and upper bound): the server's max_payload.
+	MaxMessageSize int
+
+	// ScheduleMaxPending bounds scheduled (PublishAfter/PublishAt) messages JetStream
+	// holds for redelivery at their due time; beyond it, later ones wait. Default: 65536.
+	ScheduleMaxPending int
+
 	// ExecutorConfig controls bounded handler execution.
 	// If zero, defaults are used.
 	ExecutorConfig concurrency.ExecutorConfig
@@ -77,9 +107,15 @@ func NewClusterEventBusJetStream(ctx context.Context, gocmd GoCMD, cfg ClusterJe
 	if strings.TrimSpace(cfg.Service) == "" {
 		return nil, fmt.Errorf("service is required for JetStream EventBus")
 	}
+	if cfg.MaxMessageSize < 0 {
+		return nil, fmt.Errorf("max message size cannot be negative")
+	}
+	if err := validateRegion(cfg.Region); err != nil {
+		return nil, err
+	}
 	reqTimeout := cfg.RequestTimeout
 	if reqTimeout <= 0 {
-		reqTimeout = 5 * time.Second
+		reqTimeout = DefaultRequestTimeout
 	}
 
 	maxAge := cfg.StreamMaxAge
@@ -102,6 +138,15 @@ func NewClusterEventBusJetStream(ctx context.Context, gocmd GoCMD, cfg ClusterJe
 	if maxAckPending <= 0 {
 		maxAckPending = 1024
 	}
+	rejectBackoff := cfg.RejectBackoff
+	if rejectBackoff <= 0 {
+		rejectBackoff = DefaultRejectBackoff
+	}
+
+	scheduleMaxPending := cfg.ScheduleMaxPending
+	if scheduleMaxPending <= 0 {
+		scheduleMaxPending = 65536
+	}
 
 	execCfg := cfg.ExecutorConfig
 	if execCfg.Workers == 0 && execCfg.QueueSize == 0 {
@@ -133,9 +178,13 @@ func NewClusterEventBusJetStream(ctx context.Context, gocmd GoCMD, cfg ClusterJe
 		js:             js,
 		prefix:         prefix,
 		service:        cfg.Service,
+		regions:        regionRouting{region: cfg.Region, policies: cfg.RegionPolicies},
 		requestTimeout: reqTimeout,
+		maxMessageSize: clusterMaxMessageSize(cfg.MaxMessageSize, nc),
 		ackWait:        ackWait,
 		maxAckPending:  maxAckPending,
+		rejectBackoff:  rejectBackoff,
+		onReject:       cfg.OnReject,
 		executor:       concurrency.NewExecutor(ctx, execCfg),
 		logger:         NewDefaultLogger(),
 	}
@@ -145,6 +194,10 @@ func NewClusterEventBusJetStream(ctx context.Context, gocmd GoCMD, cfg ClusterJe
 		_ = eb.Close()
 		return nil, err
 	}
+	if err := eb.startScheduler(scheduleMaxPending); err != nil {
+		_ = eb.Close()
+		return nil, err
+	}
 
 	return eb, nil
 }
@@ -158,17 +211,24 @@ type clusterJSEventBus struct {
 
 	prefix  string
 	service string
+	regions regionRouting // Region-aware Send/Request
 
 	requestTimeout time.Duration
+	maxMessageSize int // Limit on encoded message bodies
 
 	ackWait       time.Duration
 	maxAckPending int
+	rejectBackoff time.Duration
+	onReject      RejectHandler
 
 	executor concurrency.Executor
 	logger   Logger
 
+	counters busCounters // Message totals for Stats()
+
 	mu        sync.Mutex
 	consumers []*clusterJSConsumer
+	schedSub  *nats.Subscription // Shared scheduler consumer for PublishAfter/PublishAt
 }
 
 func (eb *clusterJSEventBus) ensureStreams(maxAge time.Duration, storage nats.StorageType, replicas int) error {
@@ -189,6 +249,20 @@ func (eb *clusterJSEventBus) ensureStreams(maxAge time.Duration, storage nats.St
 		}
 	}
 
+	// Schedule stream holds PublishAfter/PublishAt messages until due (no MaxAge: delays can be long).
+	// Work-queue retention removes each one once it has been published.
+	if _, err := eb.js.StreamInfo(eb.streamSched()); err != nil {
+		if _, err := eb.js.AddStream(&nats.StreamConfig{
+			Name:      eb.streamSched(),
+			Subjects:  []string{eb.prefix + ".sched.>"},
+			Storage:   storage,
+			Retention: nats.WorkQueuePolicy,
+			Replicas:  replicas,
+		}); err != nil {
+			return err
+		}
+	}
+
 	// Send stream retains work-queue messages.
 	if _, err := eb.js.StreamInfo(sendStream); err != nil {
 		if _, err := eb.js.AddStream(&nats.StreamConfig{
@@ -203,10 +277,38 @@ func (eb *clusterJSEventBus) ensureStreams(maxAge time.Duration, storage nats.St
 		}
 	}
 
+	// Region stream holds Sends routed to this region's consumers.
+	if eb.regions.region != "" {
+		if _, err := eb.js.StreamInfo(eb.streamRegion()); err != nil {
+			if _, err := eb.js.AddStream(&nats.StreamConfig{
+				Name:      eb.streamRegion(),
+				Subjects:  []string{eb.prefix + ".rsend." + eb.regions.region + ".>"},
+				Storage:   storage,
+				MaxAge:    maxAge,
+				Retention: nats.LimitsPolicy,
+				Replicas:  replicas,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
 func (eb *clusterJSEventBus) Publish(address string, body interface{}) error {
+	return eb.publishWithHeaders(address, body, nil)
+}
+
+// PublishWithHeaders implements EventBus.
+func (eb *clusterJSEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.publishWithHeaders(address, body, headers)
+}
+
+func (eb *clusterJSEventBus) publishWithHeaders(address string, body interface{}, extra map[string]string) error {
 	if err := ValidateAddress(address); err != nil {
 		return err
 	}
@@ -214,11 +316,13 @@ func (eb *clusterJSEventBus) Publish(address string, body interface{}) error {
 		return err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return err
 	}
 
+	atomic.AddInt64(&eb.counters.published, 1)
+
 	msg := &nats.Msg{
 		Subject: eb.subjectPub(address),
 		Data:    data,
@@ -227,12 +331,28 @@ func (eb *clusterJSEventBus) Publish(address string, body interface{}) error {
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
+	stampMessageID(msg, extra)
 
 	_, err = eb.js.PublishMsg(msg)
 	return err
 }
 
 func (eb *clusterJSEventBus) Send(address string, body interface{}) error {
+	return eb.sendWithHeaders(address, body, nil)
+}
+
+// SendWithHeaders implements EventBus.
+func (eb *clusterJSEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.sendWithHeaders(address, body, headers)
+}
+
+func (eb *clusterJSEventBus) sendWithHeaders(address string, body interface{}, extra map[string]string) error {
 	if err := ValidateAddress(address); err != nil {
 		return err
 	}
@@ -240,25 +360,43 @@ func (eb *clusterJSEventBus) Send(address string, body interface{}) error {
 		return err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return err
 	}
 
+	atomic.AddInt64(&eb.counters.sent, 1)
+
 	msg := &nats.Msg{
-		Subject: eb.subjectSend(address),
+		Subject: eb.sendSubject(address),
 		Data:    data,
 		Header:  nats.Header{},
 	}
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
+	stampMessageID(msg, extra)
 
 	_, err = eb.js.PublishMsg(msg)
 	return err
 }
 
+// stampMessageID gives msg a unique HeaderMessageID unless the producer set one,
+// so consumers can recognize redeliveries (see DedupHandler)
+func stampMessageID(msg *nats.Msg, extra map[string]string) {
+	if headerValue(extra, HeaderMessageID) == "" {
+		msg.Header.Set(HeaderMessageID, uuid.New().String())
+	}
+}
+
 func (eb *clusterJSEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	return eb.requestWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *clusterJSEventBus) requestWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (Message, error) {
 	// Keep Request/Reply as core NATS for low-latency synchronous calls.
 	if err := ValidateAddress(address); err != nil {
 		return nil, err
@@ -266,28 +404,32 @@ func (eb *clusterJSEventBus) Request(address string, body interface{}, timeout t
 	if err := ValidateBody(body); err != nil {
 		return nil, err
 	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
 	if err := ValidateTimeout(timeout); err != nil {
 		return nil, err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return nil, err
 	}
-	if timeout <= 0 {
-		timeout = eb.requestTimeout
-	}
+
+	atomic.AddInt64(&eb.counters.requested, 1)
 
 	msg := &nats.Msg{
-		Subject: eb.subjectReq(address),
-		Data:    data,
-		Header:  nats.Header{},
+		Data:   data,
+		Header: nats.Header{},
 	}
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
 
-	resp, err := eb.nc.RequestMsg(msg, timeout)
+	resp, err := eb.requestRegional(address, msg, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -303,25 +445,38 @@ func (eb *clusterJSEventBus) Request(address string, body interface{}, timeout t
 		body:         resp.Data,
 		headers:      h,
 		replySubject: "",
-		eb: &clusterNATSEventBus{
-			ctx:            eb.ctx,
-			gocmd:          eb.gocmd,
-			nc:             eb.nc,
-			prefix:         eb.prefix,
-			requestTimeout: eb.requestTimeout,
-			executor:       eb.executor,
-			logger:         eb.logger,
-		},
+		eb:           eb.coreBus(),
 	}, nil
 }
 
+// coreBus returns a core NATS view of the bus for messages exchanged outside JetStream
+func (eb *clusterJSEventBus) coreBus() *clusterNATSEventBus {
+	return &clusterNATSEventBus{
+		ctx:            eb.ctx,
+		gocmd:          eb.gocmd,
+		nc:             eb.nc,
+		prefix:         eb.prefix,
+		requestTimeout: eb.requestTimeout,
+		executor:       eb.executor,
+		logger:         eb.logger,
+	}
+}
+
 func (eb *clusterJSEventBus) Consumer(address string) Consumer {
 	// Fail-fast: keep contract consistent with in-memory EventBus.
 	// Invalid address is a programmer error and should be caught in dev.
 	if err := ValidateAddress(address); err != nil {
 		failfast.Err(err)
 	}
-	return newClusterJSConsumer(address, eb)
+	return newClusterJSConsumer(address, eb, eb.executor)
+}
+
+// consumerOn creates a consumer whose handlers run on executor
+func (eb *clusterJSEventBus) consumerOn(address string, executor concurrency.Executor) Consumer {
+	if err := ValidateAddress(address); err != nil {
+		failfast.Err(err)
+	}
+	return newClusterJSConsumer(address, eb, executor)
 }
 
 func (eb *clusterJSEventBus) Close() error {
@@ -331,8 +486,14 @@ func (eb *clusterJSEventBus) Close() error {
 	eb.mu.Lock()
 	cons := eb.consumers
 	eb.consumers = nil
+	schedSub := eb.schedSub
+	eb.schedSub = nil
 	eb.mu.Unlock()
 
+	if schedSub != nil {
+		_ = schedSub.Unsubscribe()
+	}
+
 	for _, c := range cons {
 		_ = c.Unregister()
 	}
@@ -353,21 +514,29 @@ func (eb *clusterJSEventBus) subjectSend(address string) string {
 func (eb *clusterJSEventBus) subjectReq(address string) string { return eb.prefix + ".req." + address }
 
 type clusterJSConsumer struct {
-	address string
-	eb      *clusterJSEventBus
+	address  string
+	eb       *clusterJSEventBus
+	executor concurrency.Executor
 
 	mu         sync.Mutex
 	handler    MessageHandler
+	onError    ErrorHandler
+	filter     MessageFilter
 	subs       []*nats.Subscription
+	backoff    rejectBackoff // Pacing while the executor refuses messages
 	completion chan struct{}
+	ready      chan struct{} // Closed once the subscriptions reached the server
 	registered bool
 }
 
-func newClusterJSConsumer(address string, eb *clusterJSEventBus) *clusterJSConsumer {
+func newClusterJSConsumer(address string, eb *clusterJSEventBus, executor concurrency.Executor) *clusterJSConsumer {
 	c := &clusterJSConsumer{
 		address:    address,
 		eb:         eb,
+		executor:   executor,
+		backoff:    rejectBackoff{base: eb.rejectBackoff},
 		completion: make(chan struct{}),
+		ready:      make(chan struct{}),
 	}
 	eb.mu.Lock()
 	eb.consumers = append(eb.consumers, c)
@@ -375,13 +544,79 @@ func newClusterJSConsumer(address string, eb *clusterJSEventBus) *clusterJSConsu
 	return c
 }
 
+func (c *clusterJSConsumer) HandlerReply(handler ReplyHandler) Consumer {
+	return c.Handler(replyingHandler(handler))
+}
+
+func (c *clusterJSConsumer) OnError(handler ErrorHandler) Consumer {
+	c.mu.Lock()
+	c.onError = handler
+	c.mu.Unlock()
+	return c
+}
+
+func (c *clusterJSConsumer) setFilter(filter MessageFilter) {
+	c.mu.Lock()
+	c.filter = filter
+	c.mu.Unlock()
+}
+
+// HandlerN implements Consumer. Cluster messages already run concurrently on the
+// bus executor (without ordering), so n only needs to be valid.
+func (c *clusterJSConsumer) HandlerN(n int, handler MessageHandler) Consumer {
+	failfast.If(n > 0, "consumer concurrency must be positive")
+	return c.Handler(handler)
+}
+
 func (c *clusterJSConsumer) Handler(handler MessageHandler) Consumer {
+	// Flushed outside c.mu, so deliveries and Unregister don't wait on the round trip
+	c.markReady(c.register(handler))
+	return c
+}
+
+// queueSubscribe joins group on durable, a durable push consumer of stream
+// filtered to subject. The bus creates the durable itself: nats.go deletes
+// durables it created when their subscription is removed, which would drop the
+// queued messages of every other member of the group.
+func (eb *clusterJSEventBus) queueSubscribe(stream, subject, group, durable string, cb nats.MsgHandler) (*nats.Subscription, error) {
+	if _, err := eb.js.ConsumerInfo(stream, durable); errors.Is(err, nats.ErrConsumerNotFound) {
+		_, err = eb.js.AddConsumer(stream, &nats.ConsumerConfig{
+			Durable:        durable,
+			DeliverSubject: nats.NewInbox(),
+			DeliverGroup:   group,
+			FilterSubject:  subject,
+			AckPolicy:      nats.AckExplicitPolicy,
+			AckWait:        eb.ackWait,
+			MaxAckPending:  eb.maxAckPending,
+		})
+		if err != nil {
+			// Another member may have created it first
+			if _, infoErr := eb.js.ConsumerInfo(stream, durable); infoErr != nil {
+				return nil, err
+			}
+		}
+	}
+	return eb.js.QueueSubscribe(
+		subject,
+		group,
+		cb,
+		nats.BindStream(stream),
+		nats.Durable(durable),
+		nats.ManualAck(),
+		nats.AckWait(eb.ackWait),
+		nats.MaxAckPending(eb.maxAckPending),
+	)
+}
+
+// register sets handler and subscribes on first use; reports whether this call
+// added every subscription
+func (c *clusterJSConsumer) register(handler MessageHandler) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.handler = handler
 	if c.registered {
-		return c
+		return false
 	}
 	c.registered = true
 
@@ -389,16 +624,7 @@ func (c *clusterJSConsumer) Handler(handler MessageHandler) Consumer {
 	pubSubject := c.eb.subjectPub(c.address)
 	pubDurable := "pub_" + sanitizeConsumerName(c.eb.service) + "_" + sanitizeConsumerName(c.address)
 	pubGroup := sanitizeConsumerName(c.eb.service)
-	pubSub, err := c.eb.js.QueueSubscribe(
-		pubSubject,
-		pubGroup,
-		c.onJSMsg(),
-		nats.BindStream(c.eb.streamPub()),
-		nats.Durable(pubDurable),
-		nats.ManualAck(),
-		nats.AckWait(c.eb.ackWait),
-		nats.MaxAckPending(c.eb.maxAckPending),
-	)
+	pubSub, err := c.eb.queueSubscribe(c.eb.streamPub(), pubSubject, pubGroup, pubDurable, c.onJSMsg())
 	if err == nil {
 		c.subs = append(c.subs, pubSub)
 	} else {
@@ -409,16 +635,7 @@ func (c *clusterJSConsumer) Handler(handler MessageHandler) Consumer {
 	sendSubject := c.eb.subjectSend(c.address)
 	sendDurable := "send_" + sanitizeConsumerName(c.address)
 	sendGroup := sanitizeConsumerName(c.address)
-	sendSub, err := c.eb.js.QueueSubscribe(
-		sendSubject,
-		sendGroup,
-		c.onJSMsg(),
-		nats.BindStream(c.eb.streamSend()),
-		nats.Durable(sendDurable),
-		nats.ManualAck(),
-		nats.AckWait(c.eb.ackWait),
-		nats.MaxAckPending(c.eb.maxAckPending),
-	)
+	sendSub, err := c.eb.queueSubscribe(c.eb.streamSend(), sendSubject, sendGroup, sendDurable, c.onJSMsg())
 	if err == nil {
 		c.subs = append(c.subs, sendSub)
 	} else {
@@ -432,11 +649,31 @@ func (c *clusterJSConsumer) Handler(handler MessageHandler) Consumer {
 		c.subs = append(c.subs, reqSub)
 	}
 
-	return c
+	// Region: a second queue group per region, preferred by senders in the region.
+	subscribed := len(c.subs) == 3
+	if !c.subscribeRegion() {
+		subscribed = false
+	}
+
+	return subscribed
+}
+
+// markReady closes ready once the server has processed the subscriptions
+func (c *clusterJSConsumer) markReady(subscribed bool) {
+	if !subscribed {
+		return
+	}
+	if err := c.eb.nc.FlushTimeout(readyFlushTimeout); err != nil {
+		c.eb.logger.Error(fmt.Sprintf("jetstream consumer %s not confirmed ready: %v", c.address, err))
+		return
+	}
+	close(c.ready)
 }
 
 func (c *clusterJSConsumer) Completion() <-chan struct{} { return c.completion }
 
+func (c *clusterJSConsumer) Ready() <-chan struct{} { return c.ready }
+
 func (c *clusterJSConsumer) Unregister() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -459,7 +696,8 @@ func (c *clusterJSConsumer) onJSMsg() nats.MsgHandler {
 		task := concurrency.NewNamedTask(
 			"cluster-jetstream-consumer."+c.address,
 			func(ctx context.Context) error {
-				err := c.handleMsg(nm)
+				// The delivery's reply subject is JetStream's ack, not a requester
+				err := c.handleMsg(nm, "")
 				if err != nil {
 					_ = nm.Nak()
 					return err
@@ -468,10 +706,16 @@ func (c *clusterJSConsumer) onJSMsg() nats.MsgHandler {
 				return nil
 			},
 		)
-		if err := c.eb.executor.Submit(task); err != nil {
-			// Backpressure: keep message unacked to be redelivered.
-			c.eb.logger.Info(fmt.Sprintf("cluster consumer overloaded for %s: %v", c.address, err))
+		if err := c.executor.Submit(task); err != nil {
+			// Backpressure: redeliver the message after a pause, and hold this
+			// subscription's deliveries meanwhile instead of spinning on refusals.
+			reportRejected(c.eb.logger, &c.eb.counters, c.eb.onReject, c.address, err)
+			delay := c.backoff.next()
+			_ = nm.NakWithDelay(delay)
+			time.Sleep(delay)
+			return
 		}
+		c.backoff.reset()
 	}
 }
 
@@ -480,23 +724,26 @@ func (c *clusterJSConsumer) onCoreMsg() nats.MsgHandler {
 		task := concurrency.NewNamedTask(
 			"cluster-core-consumer."+c.address,
 			func(ctx context.Context) error {
-				return c.handleMsg(nm)
+				return c.handleMsg(nm, nm.Reply)
 			},
 		)
-		if err := c.eb.executor.Submit(task); err != nil {
-			c.eb.logger.Info(fmt.Sprintf("cluster consumer overloaded for %s: %v", c.address, err))
+		if err := c.executor.Submit(task); err != nil {
+			reportRejected(c.eb.logger, &c.eb.counters, c.eb.onReject, c.address, err)
 		}
 	}
 }
 
-func (c *clusterJSConsumer) handleMsg(nm *nats.Msg) error {
+// handleMsg runs the handler for nm; replySubject is what Message.ReplyAddress
+// returns, empty for sends and publishes so handlers can tell them from requests
+func (c *clusterJSConsumer) handleMsg(nm *nats.Msg, replySubject string) error {
 	c.mu.Lock()
 	h := c.handler
+	onError := c.onError
+	filter := c.filter
 	c.mu.Unlock()
 	if h == nil {
 		return nil
 	}
-
 	base := c.eb.ctx
 	if rid := nm.Header.Get("X-Request-ID"); rid != "" {
 		base = WithRequestID(base, rid)
@@ -513,7 +760,7 @@ func (c *clusterJSConsumer) handleMsg(nm *nats.Msg) error {
 	msg := &clusterNATSMessage{
 		body:         nm.Data,
 		headers:      headers,
-		replySubject: nm.Reply,
+		replySubject: replySubject,
 		eb: &clusterNATSEventBus{
 			ctx:            c.eb.ctx,
 			gocmd:          c.eb.gocmd,
@@ -525,7 +772,7 @@ func (c *clusterJSConsumer) handleMsg(nm *nats.Msg) error {
 		},
 	}
 
-	return h(fctx, msg)
+	return callHandler(c.eb.logger, &c.eb.counters, c.address, filter, h, onError, fctx, msg)
 }
 
 func sanitizeStreamName(prefix string) string {
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -67,16 +68,15 @@ func TestClusterEventBusJetStream_PublishAndSend(t *testing.T) {
 	var gotA int64
 	var gotB int64
 
-	busA.Consumer("topic").Handler(func(_ FluxorContext, msg Message) error {
+	cA := busA.Consumer("topic").Handler(func(_ FluxorContext, msg Message) error {
 		atomic.AddInt64(&gotA, 1)
 		return nil
 	})
-	busB.Consumer("topic").Handler(func(_ FluxorContext, msg Message) error {
+	cB := busB.Consumer("topic").Handler(func(_ FluxorContext, msg Message) error {
 		atomic.AddInt64(&gotB, 1)
 		return nil
 	})
-
-	time.Sleep(50 * time.Millisecond)
+	waitReady(t, cA, cB)
 
 	if err := busA.Publish("topic", map[string]any{"k": "v"}); err != nil {
 		t.Fatalf("Publish: %v", err)
@@ -126,16 +126,15 @@ func TestClusterEventBusJetStream_PublishAndSend(t *testing.T) {
 	var w1Count int64
 	var w2Count int64
 
-	w1.Consumer("work").Handler(func(_ FluxorContext, msg Message) error {
+	c1 := w1.Consumer("work").Handler(func(_ FluxorContext, msg Message) error {
 		atomic.AddInt64(&w1Count, 1)
 		return nil
 	})
-	w2.Consumer("work").Handler(func(_ FluxorContext, msg Message) error {
+	c2 := w2.Consumer("work").Handler(func(_ FluxorContext, msg Message) error {
 		atomic.AddInt64(&w2Count, 1)
 		return nil
 	})
-
-	time.Sleep(50 * time.Millisecond)
+	waitReady(t, c1, c2)
 
 	for i := 0; i < 50; i++ {
 		if err := busA.Send("work", map[string]any{"n": i}); err != nil {
@@ -201,3 +200,198 @@ func TestNewClusterEventBusJetStream_FailFast_InvalidInputs(t *testing.T) {
 		}
 	})
 }
+
+func TestClusterEventBusJetStream_PublishAfter(t *testing.T) {
+	s := runTestNATSJetStreamServer(t)
+	url := s.ClientURL()
+	ctx := context.Background()
+	cfg := ClusterJetStreamConfig{URL: url, Prefix: "fluxor.js.sched", Service: "reminders"}
+
+	// Schedule from a node that then goes away; a later node must still deliver
+	v1 := NewGoCMD(ctx)
+	defer func() { _ = v1.Close() }()
+	bus1, err := NewClusterEventBusJetStream(ctx, v1, cfg)
+	if err != nil {
+		t.Fatalf("NewClusterEventBusJetStream 1: %v", err)
+	}
+	if _, err := bus1.PublishAfter("remind", map[string]any{"n": 1}, 300*time.Millisecond); err != nil {
+		t.Fatalf("PublishAfter: %v", err)
+	}
+	cancelled, err := bus1.PublishAfter("remind", map[string]any{"n": 2}, 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("PublishAfter: %v", err)
+	}
+	if !bus1.CancelScheduled(cancelled) {
+		t.Fatalf("CancelScheduled = false, want true for a pending message")
+	}
+	_ = bus1.Close()
+
+	v2 := NewGoCMD(ctx)
+	defer func() { _ = v2.Close() }()
+	bus2, err := NewClusterEventBusJetStream(ctx, v2, cfg)
+	if err != nil {
+		t.Fatalf("NewClusterEventBusJetStream 2: %v", err)
+	}
+	defer func() { _ = bus2.Close() }()
+
+	got := make(chan int, 4)
+	bus2.Consumer("remind").Handler(func(_ FluxorContext, msg Message) error {
+		var body map[string]int
+		_ = msg.DecodeBody(&body)
+		got <- body["n"]
+		return nil
+	})
+
+	select {
+	case n := <-got:
+		if n != 1 {
+			t.Fatalf("received n=%d, want 1", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduled message not delivered after restart")
+	}
+	select {
+	case n := <-got:
+		t.Fatalf("unexpected extra delivery n=%d", n)
+	case <-time.After(500 * time.Millisecond):
+	}
+	if bus2.CancelScheduled(cancelled) {
+		t.Errorf("CancelScheduled twice = true, want false")
+	}
+}
+
+func TestClusterEventBusJetStream_MessageIDAndDedup(t *testing.T) {
+	s := runTestNATSJetStreamServer(t)
+	ctx := context.Background()
+
+	gocmd := NewGoCMD(ctx)
+	defer func() { _ = gocmd.Close() }()
+	bus, err := NewClusterEventBusJetStream(ctx, gocmd, ClusterJetStreamConfig{
+		URL:     s.ClientURL(),
+		Prefix:  "fluxor.js.dedup",
+		Service: "payment-service",
+	})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusJetStream: %v", err)
+	}
+	defer func() { _ = bus.Close() }()
+
+	// The first delivery fails and is redelivered with the same ID; the handler
+	// then succeeds, and a duplicate publish with that ID is dropped
+	var attempts, handled int64
+	ids := make(chan string, 4)
+	c := bus.Consumer("payments").Handler(DedupHandler(DedupConfig{}, func(_ FluxorContext, msg Message) error {
+		ids <- MessageID(msg)
+		if atomic.AddInt64(&attempts, 1) == 1 {
+			return errors.New("transient failure")
+		}
+		atomic.AddInt64(&handled, 1)
+		return nil
+	}))
+	waitReady(t, c)
+
+	if err := bus.Send("payments", map[string]any{"amount": 10}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	first, second := <-ids, <-ids
+	if first == "" || first != second {
+		t.Fatalf("message IDs = %q, %q; want the same non-empty ID", first, second)
+	}
+	if err := bus.SendWithHeaders("payments", map[string]any{"amount": 10}, map[string]string{HeaderMessageID: first}); err != nil {
+		t.Fatalf("SendWithHeaders: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := atomic.LoadInt64(&handled); got != 1 {
+		t.Errorf("handled = %d, want 1", got)
+	}
+}
+
+func TestClusterEventBusJetStream_Regions(t *testing.T) {
+	s := runTestNATSJetStreamServer(t)
+	url := s.ClientURL()
+	ctx := context.Background()
+
+	newRegionBus := func(region string) EventBus {
+		v := NewGoCMD(ctx)
+		t.Cleanup(func() { _ = v.Close() })
+		bus, err := NewClusterEventBusJetStream(ctx, v, ClusterJetStreamConfig{
+			URL:     url,
+			Prefix:  "fluxor.js.region",
+			Service: "svc-" + region,
+			Region:  region,
+		})
+		if err != nil {
+			t.Fatalf("NewClusterEventBusJetStream %s: %v", region, err)
+		}
+		t.Cleanup(func() { _ = bus.Close() })
+		return bus
+	}
+	eu, us := newRegionBus("eu"), newRegionBus("us")
+
+	got := make(chan string, 100)
+	consume := func(bus EventBus, region string) Consumer {
+		return bus.Consumer("geo.work").Handler(func(_ FluxorContext, msg Message) error {
+			if msg.ReplyAddress() != "" {
+				return msg.Reply(region)
+			}
+			got <- region
+			return nil
+		})
+	}
+	cEU, cUS := consume(eu, "eu"), consume(us, "us")
+	waitReady(t, cEU, cUS)
+
+	expect := func(want string, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			select {
+			case region := <-got:
+				if region != want {
+					t.Fatalf("send %d delivered in %s, want %s", i, region, want)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatalf("send %d not delivered", i)
+			}
+		}
+	}
+	reply := func(bus EventBus) string {
+		t.Helper()
+		msg, err := bus.Request("geo.work", "ping", time.Second)
+		if err != nil {
+			t.Fatalf("Request: %v", err)
+		}
+		var region string
+		_ = msg.DecodeBody(&region)
+		return region
+	}
+
+	// Local consumers are preferred
+	for i := 0; i < 20; i++ {
+		if err := eu.Send("geo.work", i); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	expect("eu", 20)
+	if region := reply(us); region != "us" {
+		t.Errorf("request from us answered in %s", region)
+	}
+
+	// Without a local consumer, another region takes over
+	_ = cEU.Unregister()
+	// Sends use the cached answer until the background refresh sees the change
+	time.Sleep(regionHealthTTL + 100*time.Millisecond)
+	deadline := time.Now().Add(3 * time.Second)
+	for eu.(*clusterJSEventBus).regionHealthy("geo.work") {
+		if time.Now().After(deadline) {
+			t.Fatal("eu still reported healthy after its consumer left")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err := eu.Send("geo.work", "failover"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	expect("us", 1)
+	if region := reply(eu); region != "us" {
+		t.Errorf("request from eu after failover answered in %s", region)
+	}
+}
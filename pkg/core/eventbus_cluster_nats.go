@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core/concurrency"
@@ -22,9 +23,18 @@ type ClusterNATSConfig struct {
 	// Name is an optional NATS connection name.
 	Name string
 
-	// RequestTimeout is the default timeout used by Request when timeout==0.
+	// RequestTimeout is the default timeout used by Request when timeout <= 0.
+	// Default: DefaultRequestTimeout.
 	RequestTimeout time.Duration
 
+	// MaxMessageSize bounds encoded message bodies in bytes; larger ones are rejected
+	// with a MESSAGE_TOO_LARGE error. Default (and upper bound): the server's max_payload.
+	MaxMessageSize int
+
+	// OnReject, if set, is called for each message dropped because the executor
+	// was saturated; rejections are also counted in Stats().Rejected.
+	OnReject RejectHandler
+
 	// ExecutorConfig controls bounded handler execution.
 	// If zero, defaults are used.
 	ExecutorConfig concurrency.ExecutorConfig
@@ -52,9 +62,12 @@ func NewClusterEventBusNATS(ctx context.Context, gocmd GoCMD, cfg ClusterNATSCon
 	if prefix == "" {
 		prefix = "fluxor"
 	}
+	if cfg.MaxMessageSize < 0 {
+		return nil, fmt.Errorf("max message size cannot be negative")
+	}
 	reqTimeout := cfg.RequestTimeout
 	if reqTimeout <= 0 {
-		reqTimeout = 5 * time.Second
+		reqTimeout = DefaultRequestTimeout
 	}
 
 	execCfg := cfg.ExecutorConfig
@@ -82,6 +95,8 @@ func NewClusterEventBusNATS(ctx context.Context, gocmd GoCMD, cfg ClusterNATSCon
 		nc:             nc,
 		prefix:         prefix,
 		requestTimeout: reqTimeout,
+		maxMessageSize: clusterMaxMessageSize(cfg.MaxMessageSize, nc),
+		onReject:       cfg.OnReject,
 		executor:       executor,
 		logger:         NewDefaultLogger(),
 	}, nil
@@ -95,12 +110,32 @@ type clusterNATSEventBus struct {
 
 	prefix         string
 	requestTimeout time.Duration
+	maxMessageSize int // Limit on encoded message bodies; 0 for none
+	onReject       RejectHandler
 
 	executor concurrency.Executor
 	logger   Logger
+
+	counters busCounters // Message totals for Stats()
+
+	mu        sync.Mutex
+	consumers []*clusterNATSConsumer // Local consumers, for publishLocal
+	scheduled scheduledTimers        // Pending PublishAfter/PublishAt timers
 }
 
 func (eb *clusterNATSEventBus) Publish(address string, body interface{}) error {
+	return eb.publishWithHeaders(address, body, nil)
+}
+
+// PublishWithHeaders implements EventBus.
+func (eb *clusterNATSEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.publishWithHeaders(address, body, headers)
+}
+
+func (eb *clusterNATSEventBus) publishWithHeaders(address string, body interface{}, extra map[string]string) error {
 	if err := ValidateAddress(address); err != nil {
 		return err
 	}
@@ -108,11 +143,13 @@ func (eb *clusterNATSEventBus) Publish(address string, body interface{}) error {
 		return err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return err
 	}
 
+	atomic.AddInt64(&eb.counters.published, 1)
+
 	msg := &nats.Msg{
 		Subject: eb.subjectPub(address),
 		Data:    data,
@@ -121,11 +158,26 @@ func (eb *clusterNATSEventBus) Publish(address string, body interface{}) error {
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
 
 	return eb.nc.PublishMsg(msg)
 }
 
 func (eb *clusterNATSEventBus) Send(address string, body interface{}) error {
+	return eb.sendWithHeaders(address, body, nil)
+}
+
+// SendWithHeaders implements EventBus.
+func (eb *clusterNATSEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.sendWithHeaders(address, body, headers)
+}
+
+func (eb *clusterNATSEventBus) sendWithHeaders(address string, body interface{}, extra map[string]string) error {
 	if err := ValidateAddress(address); err != nil {
 		return err
 	}
@@ -133,11 +185,13 @@ func (eb *clusterNATSEventBus) Send(address string, body interface{}) error {
 		return err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return err
 	}
 
+	atomic.AddInt64(&eb.counters.sent, 1)
+
 	msg := &nats.Msg{
 		Subject: eb.subjectSend(address),
 		Data:    data,
@@ -146,29 +200,37 @@ func (eb *clusterNATSEventBus) Send(address string, body interface{}) error {
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
 
 	return eb.nc.PublishMsg(msg)
 }
 
 func (eb *clusterNATSEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	return eb.requestWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *clusterNATSEventBus) requestWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (Message, error) {
 	if err := ValidateAddress(address); err != nil {
 		return nil, err
 	}
 	if err := ValidateBody(body); err != nil {
 		return nil, err
 	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
 	if err := ValidateTimeout(timeout); err != nil {
 		return nil, err
 	}
 
-	data, err := encodeBody(body)
+	data, err := eb.encodeMessage(address, body)
 	if err != nil {
 		return nil, err
 	}
 
-	if timeout <= 0 {
-		timeout = eb.requestTimeout
-	}
+	atomic.AddInt64(&eb.counters.requested, 1)
 
 	msg := &nats.Msg{
 		Subject: eb.subjectReq(address),
@@ -178,6 +240,9 @@ func (eb *clusterNATSEventBus) Request(address string, body interface{}, timeout
 	if rid := GetRequestID(eb.ctx); rid != "" {
 		msg.Header.Set("X-Request-ID", rid)
 	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
 
 	resp, err := eb.nc.RequestMsg(msg, timeout)
 	if err != nil {
@@ -206,10 +271,20 @@ func (eb *clusterNATSEventBus) Consumer(address string) Consumer {
 		failfast.Err(err)
 	}
 	// Create consumer object. Handler() will create subscriptions.
-	return newClusterNATSConsumer(address, eb)
+	return newClusterNATSConsumer(address, eb, eb.executor)
+}
+
+// consumerOn creates a consumer whose handlers run on executor
+func (eb *clusterNATSEventBus) consumerOn(address string, executor concurrency.Executor) Consumer {
+	if err := ValidateAddress(address); err != nil {
+		failfast.Err(err)
+	}
+	return newClusterNATSConsumer(address, eb, executor)
 }
 
 func (eb *clusterNATSEventBus) Close() error {
+	eb.scheduled.stop()
+
 	// Drain executor and NATS.
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -231,36 +306,85 @@ func (eb *clusterNATSEventBus) subjectReq(address string) string {
 }
 
 type clusterNATSConsumer struct {
-	address string
-	eb      *clusterNATSEventBus
+	address  string
+	group    string // Queue group for Publish; "" receives every publish
+	eb       *clusterNATSEventBus
+	executor concurrency.Executor
 
 	mu         sync.Mutex
 	handler    MessageHandler
+	onError    ErrorHandler
+	filter     MessageFilter
 	subs       []*nats.Subscription
 	completion chan struct{}
+	ready      chan struct{} // Closed once the subscriptions reached the server
 	registered bool
 }
 
-func newClusterNATSConsumer(address string, eb *clusterNATSEventBus) *clusterNATSConsumer {
-	return &clusterNATSConsumer{
+func newClusterNATSConsumer(address string, eb *clusterNATSEventBus, executor concurrency.Executor) *clusterNATSConsumer {
+	c := &clusterNATSConsumer{
 		address:    address,
 		eb:         eb,
+		executor:   executor,
 		completion: make(chan struct{}),
+		ready:      make(chan struct{}),
 	}
+	eb.mu.Lock()
+	eb.consumers = append(eb.consumers, c)
+	eb.mu.Unlock()
+	return c
+}
+
+func (c *clusterNATSConsumer) HandlerReply(handler ReplyHandler) Consumer {
+	return c.Handler(replyingHandler(handler))
+}
+
+func (c *clusterNATSConsumer) OnError(handler ErrorHandler) Consumer {
+	c.mu.Lock()
+	c.onError = handler
+	c.mu.Unlock()
+	return c
+}
+
+func (c *clusterNATSConsumer) setFilter(filter MessageFilter) {
+	c.mu.Lock()
+	c.filter = filter
+	c.mu.Unlock()
+}
+
+// HandlerN implements Consumer. Cluster messages already run concurrently on the
+// bus executor (without ordering), so n only needs to be valid.
+func (c *clusterNATSConsumer) HandlerN(n int, handler MessageHandler) Consumer {
+	failfast.If(n > 0, "consumer concurrency must be positive")
+	return c.Handler(handler)
 }
 
 func (c *clusterNATSConsumer) Handler(handler MessageHandler) Consumer {
+	// Flushed outside c.mu, so deliveries and Unregister don't wait on the round trip
+	c.markReady(c.register(handler))
+	return c
+}
+
+// register sets handler and subscribes on first use; reports whether this call
+// added every subscription
+func (c *clusterNATSConsumer) register(handler MessageHandler) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.handler = handler
 	if c.registered {
-		return c
+		return false
 	}
 	c.registered = true
 
-	// Subscribe to publish subject (fanout)
-	pubSub, err := c.eb.nc.Subscribe(c.eb.subjectPub(c.address), c.onMsg(""))
+	// Subscribe to publish subject (fanout; one member per consumer group)
+	var pubSub *nats.Subscription
+	var err error
+	if c.group != "" {
+		pubSub, err = c.eb.nc.QueueSubscribe(c.eb.subjectPub(c.address), c.group, c.onMsg(""))
+	} else {
+		pubSub, err = c.eb.nc.Subscribe(c.eb.subjectPub(c.address), c.onMsg(""))
+	}
 	if err == nil {
 		c.subs = append(c.subs, pubSub)
 	}
@@ -279,11 +403,28 @@ func (c *clusterNATSConsumer) Handler(handler MessageHandler) Consumer {
 		c.subs = append(c.subs, reqSub)
 	}
 
-	return c
+	return len(c.subs) == 3
+}
+
+// readyFlushTimeout bounds the round trip confirming a consumer's subscriptions
+const readyFlushTimeout = 5 * time.Second
+
+// markReady closes ready once the server has processed the subscriptions
+func (c *clusterNATSConsumer) markReady(subscribed bool) {
+	if !subscribed {
+		return
+	}
+	if err := c.eb.nc.FlushTimeout(readyFlushTimeout); err != nil {
+		c.eb.logger.Error(fmt.Sprintf("cluster consumer %s not confirmed ready: %v", c.address, err))
+		return
+	}
+	close(c.ready)
 }
 
 func (c *clusterNATSConsumer) Completion() <-chan struct{} { return c.completion }
 
+func (c *clusterNATSConsumer) Ready() <-chan struct{} { return c.ready }
+
 func (c *clusterNATSConsumer) Unregister() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -310,8 +451,8 @@ func (c *clusterNATSConsumer) onMsg(_ string) nats.MsgHandler {
 				return c.handleMsg(nm)
 			},
 		)
-		if err := c.eb.executor.Submit(task); err != nil {
-			c.eb.logger.Info(fmt.Sprintf("cluster consumer overloaded for %s: %v", c.address, err))
+		if err := c.executor.Submit(task); err != nil {
+			reportRejected(c.eb.logger, &c.eb.counters, c.eb.onReject, c.address, err)
 		}
 	}
 }
@@ -319,11 +460,12 @@ func (c *clusterNATSConsumer) onMsg(_ string) nats.MsgHandler {
 func (c *clusterNATSConsumer) handleMsg(nm *nats.Msg) error {
 	c.mu.Lock()
 	h := c.handler
+	onError := c.onError
+	filter := c.filter
 	c.mu.Unlock()
 	if h == nil {
 		return nil
 	}
-
 	// Build context and propagate request ID if present.
 	base := c.eb.ctx
 	if rid := nm.Header.Get("X-Request-ID"); rid != "" {
@@ -345,13 +487,14 @@ func (c *clusterNATSConsumer) handleMsg(nm *nats.Msg) error {
 		eb:           c.eb,
 	}
 
-	return h(fctx, msg)
+	return callHandler(c.eb.logger, &c.eb.counters, c.address, filter, h, onError, fctx, msg)
 }
 
 type clusterNATSMessage struct {
 	mu           sync.RWMutex
 	body         interface{}
 	headers      map[string]string
+	replyHeaders map[string]string // Set by SetHeader, sent with replies
 	replySubject string
 	eb           *clusterNATSEventBus
 }
@@ -372,6 +515,24 @@ func (m *clusterNATSMessage) Headers() map[string]string {
 	return out
 }
 
+func (m *clusterNATSMessage) Header(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return headerValue(m.headers, key)
+}
+
+func (m *clusterNATSMessage) SetHeader(key, value string) {
+	if key == "" || IsReservedHeader(key) {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.replyHeaders == nil {
+		m.replyHeaders = make(map[string]string)
+	}
+	m.replyHeaders[key] = value
+}
+
 func (m *clusterNATSMessage) ReplyAddress() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -379,6 +540,18 @@ func (m *clusterNATSMessage) ReplyAddress() string {
 }
 
 func (m *clusterNATSMessage) Reply(body interface{}) error {
+	return m.reply(body, nil)
+}
+
+func (m *clusterNATSMessage) Stream(chunk interface{}) error {
+	return m.reply(chunk, map[string]string{StreamHeader: StreamChunk})
+}
+
+func (m *clusterNATSMessage) EndStream() error {
+	return m.reply([]byte("null"), map[string]string{StreamHeader: StreamEnd})
+}
+
+func (m *clusterNATSMessage) reply(body interface{}, extra map[string]string) error {
 	if m.replySubject == "" {
 		return ErrNoReplyAddress
 	}
@@ -393,8 +566,21 @@ func (m *clusterNATSMessage) Reply(body interface{}) error {
 		Data:    data,
 		Header:  nats.Header{},
 	}
-	if rid := GetRequestID(m.eb.ctx); rid != "" {
-		reply.Header.Set("X-Request-ID", rid)
+	// Echo the request's ID so the requester can correlate the reply
+	rid := m.Header(HeaderRequestID)
+	if rid == "" {
+		rid = GetRequestID(m.eb.ctx)
+	}
+	if rid != "" {
+		reply.Header.Set(HeaderRequestID, rid)
+	}
+	m.mu.RLock()
+	for k, v := range m.replyHeaders {
+		reply.Header.Set(k, v)
+	}
+	m.mu.RUnlock()
+	for k, v := range extra {
+		reply.Header.Set(k, v)
 	}
 
 	return m.eb.nc.PublishMsg(reply)
@@ -87,15 +87,13 @@ func TestClusterEventBusNATS_PublishSendRequest(t *testing.T) {
 		}
 	}
 
-	bus.Consumer("work").Handler(func(ctx FluxorContext, msg Message) error {
+	c1 := bus.Consumer("work").Handler(func(ctx FluxorContext, msg Message) error {
 		return handler(&pubCount1)(ctx, msg)
 	})
-	bus.Consumer("work").Handler(func(ctx FluxorContext, msg Message) error {
+	c2 := bus.Consumer("work").Handler(func(ctx FluxorContext, msg Message) error {
 		return handler(&pubCount2)(ctx, msg)
 	})
-
-	// NATS subscriptions are async; give them a moment to become active.
-	time.Sleep(50 * time.Millisecond)
+	waitReady(t, c1, c2)
 
 	// Publish should hit both consumers.
 	for i := 0; i < 10; i++ {
@@ -179,3 +177,50 @@ func TestNewClusterEventBusNATS_FailFast_InvalidInputs(t *testing.T) {
 		}
 	})
 }
+
+func TestClusterEventBusNATS_ConsumerOnError(t *testing.T) {
+	s := runTestNATSServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+	bus, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.onerror"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	defer bus.Close()
+
+	failures := make(chan error, 2)
+	waitReady(t, bus.Consumer("jobs").Handler(func(ctx FluxorContext, msg Message) error {
+		panic("kaboom")
+	}).OnError(func(ctx FluxorContext, msg Message, err error) {
+		failures <- err
+	}))
+
+	if err := bus.Send("jobs", "x"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case err := <-failures:
+		if err.Error() != "handler panic: kaboom" {
+			t.Errorf("OnError err = %v, want the recovered panic", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnError not called")
+	}
+}
+
+func TestClusterEventBusNATS_Headers(t *testing.T) {
+	s := runTestNATSServer(t)
+	v, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{
+		EventBusFactory: func(ctx context.Context, gocmd GoCMD) (EventBus, error) {
+			return NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.test"})
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions: %v", err)
+	}
+	t.Cleanup(func() { _ = v.Close() })
+
+	testHeaderRoundTrip(t, v.EventBus(), 50*time.Millisecond)
+}
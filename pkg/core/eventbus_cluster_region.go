@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// RegionPolicy controls how a region-aware JetStream bus (ClusterJetStreamConfig.Region)
+// routes Send and Request for an address. Publish is always global.
+type RegionPolicy int
+
+const (
+	// RegionPreferLocal delivers to a consumer in the sender's region, and to any
+	// region while the local one has no active consumer (the default)
+	RegionPreferLocal RegionPolicy = iota
+
+	// RegionLocalOnly never leaves the sender's region: Sends wait in the region's
+	// stream for a local consumer, Requests fail with no responders
+	RegionLocalOnly
+
+	// RegionGlobal ignores regions: any consumer in any region
+	RegionGlobal
+)
+
+func (p RegionPolicy) String() string {
+	switch p {
+	case RegionPreferLocal:
+		return "prefer-local"
+	case RegionLocalOnly:
+		return "local-only"
+	case RegionGlobal:
+		return "global"
+	default:
+		return fmt.Sprintf("RegionPolicy(%d)", int(p))
+	}
+}
+
+// regionHealthTTL is how long a check for an active local consumer is reused by
+// Send before it is refreshed in the background
+const regionHealthTTL = time.Second
+
+// regionCheck caches whether an address has an active consumer in the local region
+type regionCheck struct {
+	done       chan struct{} // Closed once the first answer is in
+	healthy    atomic.Bool
+	at         atomic.Int64 // Unix nanoseconds of the last answer
+	refreshing atomic.Bool
+}
+
+// regionRouting is the region-aware part of the JetStream bus
+type regionRouting struct {
+	region   string                  // Empty: region-unaware
+	policies map[string]RegionPolicy // Per address; missing ones prefer local
+	checks   sync.Map                // Address -> *regionCheck
+}
+
+// validateRegion checks that region can be used as a subject token
+func validateRegion(region string) error {
+	if region == "" {
+		return nil
+	}
+	if strings.ContainsAny(region, ".*> \t\r\n") {
+		return fmt.Errorf("region %q cannot contain '.', '*', '>' or whitespace", region)
+	}
+	return nil
+}
+
+// regionPolicy returns address's policy; RegionGlobal when the bus has no region
+func (eb *clusterJSEventBus) regionPolicy(address string) RegionPolicy {
+	if eb.regions.region == "" {
+		return RegionGlobal
+	}
+	if p, ok := eb.regions.policies[address]; ok {
+		return p
+	}
+	return RegionPreferLocal
+}
+
+func (eb *clusterJSEventBus) streamRegion() string {
+	return eb.streamSend() + "_" + sanitizeStreamName(eb.regions.region)
+}
+
+func (eb *clusterJSEventBus) subjectRegionSend(address string) string {
+	return eb.prefix + ".rsend." + eb.regions.region + "." + address
+}
+
+func (eb *clusterJSEventBus) subjectRegionReq(address string) string {
+	return eb.prefix + ".rreq." + eb.regions.region + "." + address
+}
+
+func (eb *clusterJSEventBus) regionSendDurable(address string) string {
+	return "send_" + sanitizeConsumerName(eb.regions.region) + "_" + sanitizeConsumerName(address)
+}
+
+// sendSubject picks the subject a Send to address is stored under: the region's
+// while it has an active consumer (or the policy is local-only), the global one otherwise
+func (eb *clusterJSEventBus) sendSubject(address string) string {
+	switch eb.regionPolicy(address) {
+	case RegionLocalOnly:
+		return eb.subjectRegionSend(address)
+	case RegionPreferLocal:
+		if eb.regionHealthy(address) {
+			return eb.subjectRegionSend(address)
+		}
+	}
+	return eb.subjectSend(address)
+}
+
+// regionHealthy reports whether address's regional durable has a bound subscriber.
+// Only the first Send to address waits for the server, together with any
+// concurrent ones; later Sends use the last answer and refresh it in the
+// background once it is regionHealthTTL old.
+func (eb *clusterJSEventBus) regionHealthy(address string) bool {
+	v, ok := eb.regions.checks.Load(address)
+	if !ok {
+		if v, ok = eb.regions.checks.LoadOrStore(address, &regionCheck{done: make(chan struct{})}); !ok {
+			check := v.(*regionCheck)
+			eb.checkRegion(address, check)
+			close(check.done)
+		}
+	}
+	check := v.(*regionCheck)
+	<-check.done
+	if time.Since(time.Unix(0, check.at.Load())) >= regionHealthTTL && check.refreshing.CompareAndSwap(false, true) {
+		// Hidden: goroutine creation
+		go func() {
+			defer check.refreshing.Store(false)
+			eb.checkRegion(address, check)
+		}()
+	}
+	return check.healthy.Load()
+}
+
+// checkRegion asks the server whether address's regional durable has a bound
+// subscriber and records the answer in check
+func (eb *clusterJSEventBus) checkRegion(address string, check *regionCheck) {
+	info, err := eb.js.ConsumerInfo(eb.streamRegion(), eb.regionSendDurable(address))
+	check.healthy.Store(err == nil && info.PushBound)
+	check.at.Store(time.Now().UnixNano())
+}
+
+// requestRegional sends a request to msg's address in the local region first, as
+// the policy allows, then to any region when nobody answers locally
+func (eb *clusterJSEventBus) requestRegional(address string, msg *nats.Msg, timeout time.Duration) (*nats.Msg, error) {
+	policy := eb.regionPolicy(address)
+	if policy == RegionGlobal {
+		msg.Subject = eb.subjectReq(address)
+		return eb.nc.RequestMsg(msg, timeout)
+	}
+
+	start := time.Now()
+	msg.Subject = eb.subjectRegionReq(address)
+	resp, err := eb.nc.RequestMsg(msg, timeout)
+	if policy == RegionLocalOnly || !errors.Is(err, nats.ErrNoResponders) {
+		return resp, err
+	}
+	remaining := timeout - time.Since(start)
+	if remaining <= 0 {
+		return nil, nats.ErrTimeout
+	}
+	msg.Subject = eb.subjectReq(address)
+	return eb.nc.RequestMsg(msg, remaining)
+}
+
+// subscribeRegion adds the consumer's regional Send and Request subscriptions;
+// returns whether all it needed were added
+func (c *clusterJSConsumer) subscribeRegion() bool {
+	eb := c.eb
+	if eb.regionPolicy(c.address) == RegionGlobal {
+		return true
+	}
+
+	// Send: the region's own queue group, bound to the region's stream
+	durable := eb.regionSendDurable(c.address)
+	sendSub, err := eb.queueSubscribe(eb.streamRegion(), eb.subjectRegionSend(c.address), durable, durable, c.onJSMsg())
+	subscribed := err == nil
+	if subscribed {
+		c.subs = append(c.subs, sendSub)
+	} else {
+		eb.logger.Error(fmt.Sprintf("jetstream subscribe (region send) failed for %s: %v", c.address, err))
+	}
+
+	reqSubject := eb.subjectRegionReq(c.address)
+	reqSub, err := eb.nc.QueueSubscribe(reqSubject, reqSubject, c.onCoreMsg())
+	if err == nil {
+		c.subs = append(c.subs, reqSub)
+	} else {
+		subscribed = false
+		eb.logger.Error(fmt.Sprintf("nats subscribe (region request) failed for %s: %v", c.address, err))
+	}
+	return subscribed
+}
@@ -0,0 +1,33 @@
+package core
+
+import "testing"
+
+func TestRegionRouting(t *testing.T) {
+	for _, region := range []string{"eu.west", "eu west", "us-*", "ap>"} {
+		if err := validateRegion(region); err == nil {
+			t.Errorf("validateRegion(%q) = nil, want an error", region)
+		}
+	}
+	if err := validateRegion("eu-west"); err != nil {
+		t.Errorf("validateRegion(eu-west) error = %v", err)
+	}
+
+	eb := &clusterJSEventBus{prefix: "fluxor"}
+	if p := eb.regionPolicy("orders"); p != RegionGlobal {
+		t.Errorf("policy without a region = %v, want global", p)
+	}
+
+	eb.regions = regionRouting{region: "eu-west", policies: map[string]RegionPolicy{"audit": RegionLocalOnly}}
+	if p := eb.regionPolicy("orders"); p != RegionPreferLocal {
+		t.Errorf("default policy = %v, want prefer-local", p)
+	}
+	if p := eb.regionPolicy("audit"); p != RegionLocalOnly {
+		t.Errorf("audit policy = %v, want local-only", p)
+	}
+	if got := eb.sendSubject("audit"); got != "fluxor.rsend.eu-west.audit" {
+		t.Errorf("local-only send subject = %q", got)
+	}
+	if got := eb.streamRegion(); got != "FLUXOR_SEND_EU_WEST" {
+		t.Errorf("region stream = %q", got)
+	}
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClusterEventBusNATS_RequestStream(t *testing.T) {
+	s := runTestNATSServer(t)
+
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+	responder, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.stream"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	defer responder.Close()
+	requester, err := NewClusterEventBusNATS(ctx, gocmd, ClusterNATSConfig{URL: s.ClientURL(), Prefix: "fluxor.stream"})
+	if err != nil {
+		t.Fatalf("NewClusterEventBusNATS: %v", err)
+	}
+	defer requester.Close()
+
+	// The subscription must reach the server before requesting from another connection
+	waitReady(t, registerCountdown(responder))
+
+	replies, err := requester.RequestStream("jobs.countdown", 3, time.Second)
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	assertCountdown(t, replies)
+}
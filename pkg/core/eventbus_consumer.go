@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// errReplyHandlerPanic marks a ReplyHandler panic that replyingHandler turned into an error
+var errReplyHandlerPanic = errors.New("reply handler panic")
+
+// callHandler runs filter and h with panic isolation; a panic becomes the
+// returned error. Failures are counted in counters and passed to onError.
+func callHandler(logger Logger, counters *busCounters, address string, filter MessageFilter, h MessageHandler, onError ErrorHandler, ctx FluxorContext, msg Message) (err error) {
+	ctx = withMessageRequestID(ctx, msg)
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(fmt.Sprintf("handler panic for address %s (isolated): %v", address, r))
+			err = fmt.Errorf("handler panic: %v", r)
+			counters.handlerFailed(address, true)
+		} else if err != nil {
+			counters.handlerFailed(address, errors.Is(err, errReplyHandlerPanic))
+		}
+		if err != nil {
+			runErrorHandler(logger, address, onError, ctx, msg, err)
+		}
+	}()
+	if filterRejects(counters, address, filter, msg) {
+		return nil
+	}
+	return h(ctx, msg)
+}
+
+// FilteredFailureCode is the failure code a requester gets when the consumer
+// its Send or Request reached filtered the message out
+const FilteredFailureCode = 412
+
+// filterableConsumer is implemented by consumers that support message filters
+type filterableConsumer interface {
+	setFilter(filter MessageFilter)
+}
+
+// FilterConsumer sets a predicate messages must match to reach c's handler,
+// e.g. only orders over $1000, and returns c:
+//
+//	core.FilterConsumer(eb.Consumer("orders.created"), isLargeOrder).Handler(review)
+//
+// Other messages are dropped (acked on the cluster bus) without calling the
+// handler, and counted in EventBusStats.Filtered. Filtering happens per consumer
+// after delivery: a Send or Request it rejects is not passed to another
+// consumer; a Request is failed with FilteredFailureCode instead.
+// Fail-fast: panics if c was not created by a Fluxor bus
+func FilterConsumer(c Consumer, filter MessageFilter) Consumer {
+	fc, ok := c.(filterableConsumer)
+	failfast.If(ok, "consumer %T does not support filters", c)
+	fc.setFilter(filter)
+	return c
+}
+
+// filterRejects reports whether filter rejects msg. A rejection is counted for
+// address and failed back to a requester, so it doesn't wait for its timeout.
+func filterRejects(counters *busCounters, address string, filter MessageFilter, msg Message) bool {
+	if filter == nil || filter(msg) {
+		return false
+	}
+	counters.messageFiltered(address)
+	if msg.ReplyAddress() != "" {
+		_ = msg.Fail(FilteredFailureCode, "message filtered out by consumer on "+address)
+	}
+	return true
+}
+
+// runErrorHandler calls onError, if set, isolating its panics
+func runErrorHandler(logger Logger, address string, onError ErrorHandler, ctx FluxorContext, msg Message, err error) {
+	if onError == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(fmt.Sprintf("error handler panic for address %s (isolated): %v", address, r))
+		}
+	}()
+	onError(ctx, msg, err)
+}
@@ -2,6 +2,9 @@ package core
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -133,3 +136,191 @@ func TestConsumer_RequestIDPropagation(t *testing.T) {
 		t.Error("Timeout waiting for message with request ID")
 	}
 }
+
+func TestConsumer_OnError(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	failures := make(chan string, 3)
+	eb.Consumer("orders").Handler(func(ctx FluxorContext, msg Message) error {
+		var kind string
+		_ = msg.DecodeBody(&kind)
+		switch kind {
+		case "fail":
+			return errors.New("boom")
+		case "panic":
+			panic("kaboom")
+		}
+		return nil
+	}).OnError(func(ctx FluxorContext, msg Message, err error) {
+		failures <- err.Error()
+		if err.Error() == "boom" {
+			panic("error handler panic") // Isolated: must not stop the consumer
+		}
+	})
+
+	for _, kind := range []string{"ok", "fail", "panic"} {
+		if err := eb.Send("orders", kind); err != nil {
+			t.Fatalf("Send(%q) error = %v", kind, err)
+		}
+	}
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case f := <-failures:
+			got = append(got, f)
+		case <-time.After(time.Second):
+			t.Fatalf("OnError calls = %v, want 2", got)
+		}
+	}
+	if got[0] != "boom" || got[1] != "handler panic: kaboom" {
+		t.Errorf("OnError errors = %v, want [boom, handler panic: kaboom]", got)
+	}
+}
+
+func TestConsumer_Filter(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	type order struct {
+		Total float64 `json:"total"`
+	}
+	handled := make(chan float64, 4)
+	failures := make(chan error, 1)
+	c := FilterConsumer(eb.Consumer("orders.filtered"), func(msg Message) bool {
+		var o order
+		if err := msg.DecodeBody(&o); err != nil {
+			panic("undecodable order")
+		}
+		return o.Total > 1000
+	}).Handler(func(ctx FluxorContext, msg Message) error {
+		var o order
+		_ = msg.DecodeBody(&o)
+		handled <- o.Total
+		return nil
+	}).OnError(func(ctx FluxorContext, msg Message, err error) {
+		failures <- err
+	})
+	waitReady(t, c)
+
+	for _, total := range []float64{50, 2500, 999, 1200} {
+		if err := eb.Publish("orders.filtered", order{Total: total}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	for _, want := range []float64{2500, 1200} {
+		select {
+		case got := <-handled:
+			if got != want {
+				t.Errorf("handled order total %v, want %v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("order %v not handled", want)
+		}
+	}
+
+	// A panicking filter is a handler panic
+	if err := eb.Publish("orders.filtered", "not an order"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case err := <-failures:
+		if !strings.Contains(err.Error(), "undecodable order") {
+			t.Errorf("OnError error = %v, want the filter panic", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("filter panic not reported to OnError")
+	}
+
+	stats := eb.(*eventBus).Stats()
+	if got := stats.Filtered["orders.filtered"]; got != 2 {
+		t.Errorf("Stats().Filtered = %v, want 2 for orders.filtered", stats.Filtered)
+	}
+	if got := stats.HandlerFailures["orders.filtered"].Panics; got != 1 {
+		t.Errorf("handler panics = %d, want 1", got)
+	}
+
+	// A rejected request fails right away instead of timing out
+	reply, err := eb.Request("orders.filtered", order{Total: 10}, 5*time.Second)
+	var out map[string]interface{}
+	if err != nil || reply.DecodeBody(&out) != nil || out["failureCode"] != float64(FilteredFailureCode) {
+		t.Errorf("Request() of a filtered order = %v, %v; want a %d failure", out, err, FilteredFailureCode)
+	}
+	select {
+	case got := <-handled:
+		t.Errorf("handler got filtered order %v", got)
+	default:
+	}
+}
+
+func TestConsumer_HandlerN(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	const n = 4
+	var active, peak, handled int64
+	release := make(chan struct{})
+	consumer := eb.Consumer("test.concurrent").HandlerN(n, func(ctx FluxorContext, msg Message) error {
+		now := atomic.AddInt64(&active, 1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if now <= p || atomic.CompareAndSwapInt64(&peak, p, now) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt64(&active, -1)
+		if atomic.AddInt64(&handled, 1) == 1 {
+			panic("isolated to one message")
+		}
+		return nil
+	})
+
+	for i := 0; i < 2*n; i++ {
+		if err := eb.Send("test.concurrent", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt64(&peak) < n && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if p := atomic.LoadInt64(&peak); p != n {
+		t.Errorf("peak concurrency = %d, want %d", p, n)
+	}
+	close(release)
+
+	// A panic stops neither its goroutine nor the others
+	for atomic.LoadInt64(&handled) < 2*n && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if h := atomic.LoadInt64(&handled); h != 2*n {
+		t.Errorf("handled = %d, want %d", h, 2*n)
+	}
+
+	if err := consumer.Unregister(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-consumer.Completion():
+	case <-time.After(2 * time.Second):
+		t.Error("Completion channel not closed after all handler goroutines stopped")
+	}
+}
+
+func TestConsumer_HandlerN_FailFast(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("HandlerN(0) should panic")
+		}
+	}()
+	gocmd.EventBus().Consumer("test.invalid").HandlerN(0, func(ctx FluxorContext, msg Message) error { return nil })
+}
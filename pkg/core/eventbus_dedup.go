@@ -0,0 +1,163 @@
+package core
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// Default DedupConfig values
+const (
+	DefaultDedupSize   = 10000
+	DefaultDedupWindow = 10 * time.Minute
+)
+
+// ErrDuplicateInFlight is returned by a DedupHandler for a redelivery of a message
+// that is still being handled; the JetStream bus naks it, so it comes back later
+// and is skipped once the first delivery succeeded
+var ErrDuplicateInFlight = errors.New("duplicate of a message still being handled")
+
+// MessageID returns the ID of msg (HeaderMessageID), or "" if it has none.
+// The JetStream bus gives every Publish and Send one; on other buses producers can
+// set it with SendWithHeaders/PublishWithHeaders.
+func MessageID(msg Message) string {
+	return msg.Header(HeaderMessageID)
+}
+
+// DedupConfig bounds the recently-seen cache of a DedupHandler
+type DedupConfig struct {
+	// Size is how many message IDs are remembered (default: DefaultDedupSize)
+	Size int
+
+	// Window is how long a handled message ID is remembered (default: DefaultDedupWindow);
+	// keep it above the JetStream AckWait so redeliveries fall inside it
+	Window time.Duration
+}
+
+// DedupHandler returns a handler that skips messages whose MessageID it handled
+// successfully within the window, for at-least-once delivery such as JetStream
+// redeliveries. Skipped duplicates return nil, so the bus acks them. A message only
+// counts as seen once handler succeeds: after an error its redelivery runs again.
+// Messages without an ID are always handled.
+//
+//	eb.Consumer("payments.captured").Handler(core.DedupHandler(core.DedupConfig{}, capture))
+//
+// The cache is per handler and in memory; replicas of a service each keep their own.
+// Fail-fast: panics on a nil handler or negative config values
+func DedupHandler(cfg DedupConfig, handler MessageHandler) MessageHandler {
+	failfast.NotNil(handler, "handler")
+	failfast.If(cfg.Size >= 0, "dedup size cannot be negative")
+	failfast.If(cfg.Window >= 0, "dedup window cannot be negative")
+	if cfg.Size == 0 {
+		cfg.Size = DefaultDedupSize
+	}
+	if cfg.Window == 0 {
+		cfg.Window = DefaultDedupWindow
+	}
+
+	cache := newDedupCache(cfg.Size, cfg.Window)
+	return func(ctx FluxorContext, msg Message) error {
+		id := MessageID(msg)
+		if id == "" {
+			return handler(ctx, msg)
+		}
+		switch cache.begin(id, time.Now()) {
+		case dedupSeen:
+			return nil
+		case dedupInFlight:
+			return fmt.Errorf("message %s: %w", id, ErrDuplicateInFlight)
+		}
+
+		return handleDeduped(cache, id, handler, ctx, msg)
+	}
+}
+
+// handleDeduped runs handler for a new id and records the outcome; a panic clears
+// the in-flight mark before propagating, so the redelivery isn't refused forever
+func handleDeduped(cache *dedupCache, id string, handler MessageHandler, ctx FluxorContext, msg Message) (err error) {
+	handled := false
+	defer func() {
+		cache.finish(id, handled, time.Now())
+	}()
+	err = handler(ctx, msg)
+	handled = err == nil
+	return err
+}
+
+type dedupState int
+
+const (
+	dedupNew dedupState = iota
+	dedupInFlight
+	dedupSeen
+)
+
+// dedupCache remembers handled message IDs for a window, evicting the oldest beyond size
+type dedupCache struct {
+	mu       sync.Mutex
+	size     int
+	window   time.Duration
+	seen     map[string]*list.Element // ID -> entry in order
+	order    *list.List               // dedupEntry, oldest first
+	inFlight map[string]bool
+}
+
+type dedupEntry struct {
+	id string
+	at time.Time
+}
+
+func newDedupCache(size int, window time.Duration) *dedupCache {
+	return &dedupCache{
+		size:     size,
+		window:   window,
+		seen:     make(map[string]*list.Element),
+		order:    list.New(),
+		inFlight: make(map[string]bool),
+	}
+}
+
+// begin reports whether id was seen or is in flight, and otherwise marks it in flight
+func (c *dedupCache) begin(id string, now time.Time) dedupState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(now)
+	if _, ok := c.seen[id]; ok {
+		return dedupSeen
+	}
+	if c.inFlight[id] {
+		return dedupInFlight
+	}
+	c.inFlight[id] = true
+	return dedupNew
+}
+
+// finish clears id's in-flight mark and remembers it if it was handled
+func (c *dedupCache) finish(id string, handled bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inFlight, id)
+	if !handled {
+		return
+	}
+	c.seen[id] = c.order.PushBack(dedupEntry{id: id, at: now})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Front())
+	}
+}
+
+// expire drops entries older than the window
+func (c *dedupCache) expire(now time.Time) {
+	for e := c.order.Front(); e != nil && now.Sub(e.Value.(dedupEntry).at) > c.window; e = c.order.Front() {
+		c.remove(e)
+	}
+}
+
+func (c *dedupCache) remove(e *list.Element) {
+	delete(c.seen, e.Value.(dedupEntry).id)
+	c.order.Remove(e)
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// dedupMessage is a message carrying id as its HeaderMessageID
+func dedupMessage(id string) Message {
+	headers := map[string]string{}
+	if id != "" {
+		headers[HeaderMessageID] = id
+	}
+	return newMessage([]byte(`{}`), headers, "", nil)
+}
+
+func TestDedupHandler(t *testing.T) {
+	var calls int
+	fail := true
+	h := DedupHandler(DedupConfig{Size: 2, Window: time.Minute}, func(ctx FluxorContext, msg Message) error {
+		calls++
+		if fail {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	// A failed delivery doesn't count as seen: its redelivery runs again
+	if err := h(nil, dedupMessage("a")); err == nil {
+		t.Fatal("handler error should be returned")
+	}
+	fail = false
+	for i := 0; i < 3; i++ {
+		if err := h(nil, dedupMessage("a")); err != nil {
+			t.Fatalf("handler error = %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("calls after redeliveries of a = %d, want 2", calls)
+	}
+
+	// Messages without an ID are always handled
+	_ = h(nil, dedupMessage(""))
+	_ = h(nil, dedupMessage(""))
+	if calls != 4 {
+		t.Errorf("calls after messages without ID = %d, want 4", calls)
+	}
+
+	// Size bounds the cache: the oldest ID is forgotten
+	_ = h(nil, dedupMessage("b"))
+	_ = h(nil, dedupMessage("c"))
+	_ = h(nil, dedupMessage("a"))
+	if calls != 7 {
+		t.Errorf("calls after evicting a = %d, want 7", calls)
+	}
+}
+
+func TestDedupHandler_InFlightAndWindow(t *testing.T) {
+	release := make(chan struct{})
+	h := DedupHandler(DedupConfig{Window: 50 * time.Millisecond}, func(ctx FluxorContext, msg Message) error {
+		if release != nil {
+			<-release
+		}
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- h(nil, dedupMessage("a")) }()
+	time.Sleep(20 * time.Millisecond)
+	if err := h(nil, dedupMessage("a")); !errors.Is(err, ErrDuplicateInFlight) {
+		t.Errorf("duplicate while in flight error = %v, want ErrDuplicateInFlight", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	// Past the window the ID is handled again
+	release = nil
+	time.Sleep(60 * time.Millisecond)
+	if err := h(nil, dedupMessage("a")); err != nil {
+		t.Errorf("handler error after window = %v", err)
+	}
+}
+
+func TestDedupHandler_Panic(t *testing.T) {
+	panicking := true
+	var calls int
+	h := DedupHandler(DedupConfig{}, func(ctx FluxorContext, msg Message) error {
+		calls++
+		if panicking {
+			panic("boom")
+		}
+		return nil
+	})
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the handler's panic", r)
+			}
+		}()
+		_ = h(nil, dedupMessage("a"))
+	}()
+
+	// The panicked delivery is neither in flight nor seen: its redelivery runs
+	panicking = false
+	if err := h(nil, dedupMessage("a")); err != nil {
+		t.Fatalf("redelivery error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestDedupHandler_OnBus(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	handled := make(chan string, 4)
+	c := eb.Consumer("dedup.payments").Handler(DedupHandler(DedupConfig{}, func(ctx FluxorContext, msg Message) error {
+		handled <- MessageID(msg)
+		return nil
+	}))
+	waitReady(t, c)
+
+	// A producer retrying with the same ID is handled once
+	for i := 0; i < 2; i++ {
+		if err := eb.SendWithHeaders("dedup.payments", "capture", map[string]string{HeaderMessageID: "pay-1"}); err != nil {
+			t.Fatalf("SendWithHeaders() error = %v", err)
+		}
+	}
+	if err := eb.SendWithHeaders("dedup.payments", "capture", map[string]string{HeaderMessageID: "pay-2"}); err != nil {
+		t.Fatalf("SendWithHeaders() error = %v", err)
+	}
+	for _, want := range []string{"pay-1", "pay-2"} {
+		select {
+		case got := <-handled:
+			if got != want {
+				t.Errorf("handled %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s not handled", want)
+		}
+	}
+}
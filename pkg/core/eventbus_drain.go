@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// GracefulCloser is implemented by event buses that can finish queued work before
+// closing (the in-memory bus, and the hybrid bus for its in-process side).
+//
+// Close is abrupt: it stops consumers right away and queued messages are dropped.
+// CloseGracefully first rejects new messages with ErrBusClosing (replies to
+// requests already in flight still go through), waits until every consumer's
+// mailbox is empty and no handler is running, then closes. If ctx ends first,
+// the bus is closed anyway and ctx's error is returned.
+type GracefulCloser interface {
+	CloseGracefully(ctx context.Context) error
+}
+
+// drainer lets GoCMD.Close drain the bus before cancelling the root context,
+// which would stop consumers, and close it afterwards
+type drainer interface {
+	drain(ctx context.Context) error
+}
+
+// drainPollInterval is how often drain checks consumers for remaining work
+const drainPollInterval = 10 * time.Millisecond
+
+// CloseGracefully implements GracefulCloser.
+func (eb *eventBus) CloseGracefully(ctx context.Context) error {
+	drainErr := eb.drain(ctx)
+	if err := eb.Close(); err != nil {
+		return err
+	}
+	return drainErr
+}
+
+// drain rejects new messages and waits for consumers to finish queued ones
+func (eb *eventBus) drain(ctx context.Context) error {
+	atomic.StoreInt32(&eb.draining, 1)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	// A message is briefly neither queued nor counted as busy while a consumer
+	// picks it up, so require two idle checks in a row
+	idle := 0
+	for idle < 2 {
+		if eb.idle() {
+			idle++
+		} else {
+			idle = 0
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("eventbus drain: %w", ctx.Err())
+		case <-eb.ctx.Done():
+			return nil // Consumers are already stopped
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// idle reports whether no consumer has queued or running messages
+func (eb *eventBus) idle() bool {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+	for _, consumers := range eb.consumers {
+		for _, c := range consumers {
+			if c.mailbox.Size() > 0 || atomic.LoadInt32(&c.busy) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// accepting rejects new messages while draining; replies are still delivered
+// so in-flight requests complete. Once closed, the bus behaves as after Close.
+func (eb *eventBus) accepting(address string) error {
+	if atomic.LoadInt32(&eb.draining) == 1 && eb.ctx.Err() == nil && !strings.HasPrefix(address, replyAddressPrefix) {
+		return ErrBusClosing
+	}
+	return nil
+}
+
+// CloseGracefully implements GracefulCloser when the wrapped bus does,
+// and is Close otherwise.
+func (t *TracingEventBus) CloseGracefully(ctx context.Context) error {
+	if gc, ok := t.EventBus.(GracefulCloser); ok {
+		return gc.CloseGracefully(ctx)
+	}
+	return t.EventBus.Close()
+}
+
+func (t *TracingEventBus) drain(ctx context.Context) error {
+	if d, ok := t.EventBus.(drainer); ok {
+		return d.drain(ctx)
+	}
+	return nil
+}
+
+// CloseGracefully implements GracefulCloser when the wrapped bus does,
+// and is Close otherwise.
+func (r *RequestIDEventBus) CloseGracefully(ctx context.Context) error {
+	if gc, ok := r.EventBus.(GracefulCloser); ok {
+		return gc.CloseGracefully(ctx)
+	}
+	return r.EventBus.Close()
+}
+
+func (r *RequestIDEventBus) drain(ctx context.Context) error {
+	if d, ok := r.EventBus.(drainer); ok {
+		return d.drain(ctx)
+	}
+	return nil
+}
+
+// CloseGracefully implements GracefulCloser: the in-process bus is drained,
+// then both buses are closed. The cluster bus already drains its connection on Close.
+func (h *HybridEventBus) CloseGracefully(ctx context.Context) error {
+	drainErr := h.drain(ctx)
+	if err := h.Close(); err != nil {
+		return err
+	}
+	return drainErr
+}
+
+func (h *HybridEventBus) drain(ctx context.Context) error {
+	return h.local.drain(ctx)
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_CloseGracefully(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var handled int32
+	started := make(chan struct{}, 1)
+	eb.Consumer("drain.work").Handler(func(ctx FluxorContext, msg Message) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+	for i := 0; i < 20; i++ {
+		if err := eb.Send("drain.work", i); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	<-started
+
+	gc, ok := eb.(GracefulCloser)
+	if !ok {
+		t.Fatalf("%T does not implement GracefulCloser", eb)
+	}
+	done := make(chan error, 1)
+	go func() { done <- gc.CloseGracefully(context.Background()) }()
+
+	// New messages are rejected once the drain has started
+	sent := int32(20)
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := eb.Send("drain.work", "late")
+		if errors.Is(err, ErrBusClosing) {
+			break
+		}
+		if err != nil || time.Now().After(deadline) {
+			t.Fatalf("Send() during drain error = %v, want ErrBusClosing", err)
+		}
+		sent++
+		time.Sleep(time.Millisecond)
+	}
+	if err := eb.Publish("drain.work", "late"); !errors.Is(err, ErrBusClosing) {
+		t.Errorf("Publish() during drain error = %v, want ErrBusClosing", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("CloseGracefully() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&handled); got != sent {
+		t.Errorf("handled %d messages, want %d", got, sent)
+	}
+}
+
+func TestEventBus_CloseGracefully_Deadline(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	release := make(chan struct{})
+	defer close(release)
+	eb.Consumer("drain.stuck").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	})
+	if err := eb.Send("drain.stuck", "x"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := eb.(GracefulCloser).CloseGracefully(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CloseGracefully() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestGoCMD_Close_DrainsEventBus(t *testing.T) {
+	gx, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{EventBusDrainTimeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+
+	var handled int32
+	gx.EventBus().Consumer("drain.gocmd").Handler(func(ctx FluxorContext, msg Message) error {
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+	for i := 0; i < 10; i++ {
+		if err := gx.EventBus().Send("drain.gocmd", i); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&handled); got != 10 {
+		t.Errorf("handled %d messages before Close() returned, want 10", got)
+	}
+}
+
+func TestNewGoCMDWithOptions_NegativeEventBusDrainTimeout(t *testing.T) {
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{EventBusDrainTimeout: -time.Second}); err == nil {
+		t.Fatal("NewGoCMDWithOptions() should reject a negative EventBusDrainTimeout")
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+)
+
+// fanOutPublisher is implemented by buses that can enqueue a publish concurrently
+type fanOutPublisher interface {
+	publishTo(address string, body interface{}, extra map[string]string, parallelism int) (int, error)
+}
+
+// PublishFanOut publishes body to address like Publish, enqueueing on up to
+// parallelism consumer mailboxes at once, for addresses with many consumers.
+// Delivery stays non-blocking: a full mailbox is counted as a drop.
+//
+// Returns how many consumers received the message. Buses that can't count
+// deliveries (cluster buses, wrappers other than RequestIDEventBus) fall back
+// to Publish and return -1.
+func PublishFanOut(eb EventBus, address string, body interface{}, parallelism int) (int, error) {
+	if parallelism < 1 {
+		return 0, fmt.Errorf("parallelism must be positive")
+	}
+	if fp, ok := eb.(fanOutPublisher); ok {
+		return fp.publishTo(address, body, nil, parallelism)
+	}
+	return -1, eb.Publish(address, body)
+}
+
+// publishTo keeps PublishFanOut's concurrent delivery and count through the wrapper
+func (r *RequestIDEventBus) publishTo(address string, body interface{}, extra map[string]string, parallelism int) (int, error) {
+	if fp, ok := r.EventBus.(fanOutPublisher); ok {
+		return fp.publishTo(address, body, r.headers(extra), parallelism)
+	}
+	return -1, r.PublishWithHeaders(address, body, extra)
+}
+
+// deliverConcurrent splits consumers into up to parallelism chunks, each delivered
+// on its own goroutine (the last on the caller's), since the bus executor's
+// workers are held by consumer loops. Delivery never blocks, so the goroutines
+// are short-lived.
+func (eb *eventBus) deliverConcurrent(msg Message, consumers []*consumer, parallelism int) (int, error) {
+	if parallelism > len(consumers) {
+		parallelism = len(consumers)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+		firstErr  error
+	)
+	deliverPart := func(part []*consumer) {
+		n, err := eb.deliver(msg, part)
+		mu.Lock()
+		delivered += n
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+	}
+	chunk := (len(consumers) + parallelism - 1) / parallelism
+	for start := 0; start < len(consumers); start += chunk {
+		end := start + chunk
+		if end >= len(consumers) {
+			deliverPart(consumers[start:])
+			break
+		}
+		part := consumers[start:end]
+		wg.Add(1)
+		go func() { // Hidden: goroutine creation
+			defer wg.Done()
+			deliverPart(part)
+		}()
+	}
+	wg.Wait()
+	return delivered, firstErr
+}
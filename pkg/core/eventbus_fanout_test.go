@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublishFanOut(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// HandlerN gives each consumer its own workers; Handler loops share the bus
+	// executor's 10, so more than 10 of them would not all run
+	const consumers = 50
+	var received int32
+	all := make([]Consumer, consumers)
+	for i := range all {
+		all[i] = eb.Consumer("fanout.test").HandlerN(2, func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt32(&received, 1)
+			return nil
+		})
+	}
+	waitReady(t, all...)
+
+	delivered, err := PublishFanOut(eb, "fanout.test", "hello", 8)
+	if err != nil {
+		t.Fatalf("PublishFanOut() error = %v", err)
+	}
+	if delivered != consumers {
+		t.Errorf("delivered = %d, want %d", delivered, consumers)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&received) < consumers && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&received); got != consumers {
+		t.Errorf("received = %d, want %d", got, consumers)
+	}
+
+	if n, err := PublishFanOut(eb, "fanout.none", "hello", 8); err != nil || n != 0 {
+		t.Errorf("PublishFanOut() without consumers = %d, %v; want 0, nil", n, err)
+	}
+	if _, err := PublishFanOut(eb, "fanout.test", "hello", 0); err == nil {
+		t.Error("PublishFanOut() should reject parallelism < 1")
+	}
+	// The request ID wrapper keeps counting; others fall back to Publish
+	if n, err := PublishFanOut(NewRequestIDEventBus(eb, "req-1"), "fanout.test", "hello", 8); err != nil || n != consumers {
+		t.Errorf("PublishFanOut() through a request ID bus = %d, %v; want %d, nil", n, err, consumers)
+	}
+	if n, err := PublishFanOut(NewTracingEventBus(eb, TraceOptions{}), "fanout.test", "hello", 8); err != nil || n != -1 {
+		t.Errorf("PublishFanOut() through a wrapper = %d, %v; want -1, nil", n, err)
+	}
+}
+
+// BenchmarkPublishFanOut compares sequential Publish with PublishFanOut to 1000 consumers
+func BenchmarkPublishFanOut(b *testing.B) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	all := make([]Consumer, 1000)
+	for i := range all {
+		all[i] = eb.Consumer("fanout.bench").HandlerN(2, func(ctx FluxorContext, msg Message) error {
+			return nil
+		})
+	}
+	for _, c := range all {
+		<-c.Ready()
+	}
+
+	for _, parallelism := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := PublishFanOut(eb, "fanout.bench", "hello", parallelism); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// groupConsumers is implemented by buses that support consumer groups
+type groupConsumers interface {
+	groupConsumer(address, group string) Consumer
+}
+
+// executorGroupConsumers is implemented by buses that can run a group's consumer on a given executor
+type executorGroupConsumers interface {
+	groupConsumerOn(address, group string, executor concurrency.Executor) Consumer
+}
+
+// GroupConsumer creates a consumer on address that joins the named consumer group.
+// Publish delivers one copy per group, to its members in round-robin order, and one
+// copy to every ungrouped consumer. Send and Request go to one consumer: groups and
+// ungrouped consumers take turns, and a group's turn goes to its next member. Use it to scale out a subscriber without duplicating work:
+//
+//	GroupConsumer(eb, "orders.created", "billing").Handler(bill)  // replica 1
+//	GroupConsumer(eb, "orders.created", "billing").Handler(bill)  // replica 2
+//	eb.Consumer("orders.created").Handler(audit)                  // gets every event
+//
+// On the NATS cluster bus the group is a NATS queue group for Publish. Buses
+// without group support (JetStream already groups Publish by service name)
+// return a plain Consumer.
+// Fail-fast: panics on an empty group
+func GroupConsumer(eb EventBus, address, group string) Consumer {
+	failfast.If(group != "", "consumer group cannot be empty")
+	if gc, ok := eb.(groupConsumers); ok {
+		return gc.groupConsumer(address, group)
+	}
+	return eb.Consumer(address)
+}
+
+func (eb *eventBus) groupConsumer(address, group string) Consumer {
+	return eb.groupConsumerOn(address, group, eb.executor)
+}
+
+// groupKey identifies a consumer group's round-robin cursor
+func groupKey(address, group string) string {
+	return address + "\x00" + group
+}
+
+// hasGroups reports whether any of consumers joined a group
+func hasGroups(consumers []*consumer) bool {
+	for _, c := range consumers {
+		if c.group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// publishTargets returns the consumers a publish to address reaches: every
+// ungrouped consumer plus the next member of each group. Caller holds eb.mu.
+func (eb *eventBus) publishTargets(address string) []*consumer {
+	consumers := eb.consumers[address]
+	if !hasGroups(consumers) {
+		return consumers
+	}
+
+	targets := make([]*consumer, 0, len(consumers))
+	var groups []string
+	members := make(map[string][]*consumer)
+	for _, c := range consumers {
+		if c.group == "" {
+			targets = append(targets, c)
+			continue
+		}
+		if _, ok := members[c.group]; !ok {
+			groups = append(groups, c.group)
+		}
+		members[c.group] = append(members[c.group], c)
+	}
+	for _, group := range groups {
+		next := eb.groupNext[groupKey(address, group)]
+		n := atomic.AddUint64(next, 1) - 1
+		targets = append(targets, members[group][n%uint64(len(members[group]))])
+	}
+	return targets
+}
+
+// sendTarget returns the consumer of address a Send or Request goes to. The
+// ungrouped consumers and the groups take turns, and a group's turn goes to its
+// next member, so a group shares Send traffic the way it shares Publish copies.
+func (eb *eventBus) sendTarget(address string, consumers []*consumer) *consumer {
+	n := atomic.AddUint64(&eb.next, 1) - 1
+	if !hasGroups(consumers) {
+		return consumers[n%uint64(len(consumers))]
+	}
+
+	// Ungrouped consumers and the first member of each group
+	turns := make([]*consumer, 0, len(consumers))
+	seen := make(map[string]bool)
+	for _, c := range consumers {
+		if c.group == "" || !seen[c.group] {
+			seen[c.group] = true
+			turns = append(turns, c)
+		}
+	}
+	turn := turns[n%uint64(len(turns))]
+	if turn.group == "" {
+		return turn
+	}
+	var members []*consumer
+	for _, c := range consumers {
+		if c.group == turn.group {
+			members = append(members, c)
+		}
+	}
+	eb.mu.RLock()
+	next := eb.groupNext[groupKey(address, turn.group)]
+	eb.mu.RUnlock()
+	if next == nil {
+		// The group just left
+		return turn
+	}
+	m := atomic.AddUint64(next, 1) - 1
+	return members[m%uint64(len(members))]
+}
+
+// groupConsumer runs the group's consumers on the deployment's executor when the bus allows it
+func (b *isolatedEventBus) groupConsumer(address, group string) Consumer {
+	gc, ok := b.EventBus.(executorGroupConsumers)
+	if !ok {
+		return GroupConsumer(b.EventBus, address, group)
+	}
+	c := gc.groupConsumerOn(address, group, b.executor)
+	b.mu.Lock()
+	b.consumers = append(b.consumers, c)
+	b.mu.Unlock()
+	return c
+}
+
+func (t *TenantEventBus) groupConsumer(address, group string) Consumer {
+	return &tenantConsumer{Consumer: GroupConsumer(t.EventBus, t.address(address), group), bus: t}
+}
+
+func (t *TracingEventBus) groupConsumer(address, group string) Consumer {
+	return &tracingConsumer{Consumer: GroupConsumer(t.EventBus, address, group), bus: t, address: address}
+}
+
+func (r *RequestIDEventBus) groupConsumer(address, group string) Consumer {
+	return GroupConsumer(r.EventBus, address, group)
+}
+
+func (h *HybridEventBus) groupConsumer(address, group string) Consumer {
+	return &hybridConsumer{
+		local:  GroupConsumer(h.local, address, group),
+		remote: GroupConsumer(h.EventBus, address, group),
+		node:   h.node,
+	}
+}
+
+func (eb *clusterNATSEventBus) groupConsumer(address, group string) Consumer {
+	c := eb.Consumer(address).(*clusterNATSConsumer)
+	c.group = group
+	return c
+}
+
+// hasGroup reports whether group still has consumers on address. Caller holds eb.mu.
+func (eb *eventBus) hasGroup(address, group string) bool {
+	for _, c := range eb.consumers[address] {
+		if c.group == group {
+			return true
+		}
+	}
+	return false
+}
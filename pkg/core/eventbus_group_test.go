@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupConsumer(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var billing [2]int32
+	var audit int32
+	counter := func(n *int32) MessageHandler {
+		return func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt32(n, 1)
+			return nil
+		}
+	}
+	b0 := GroupConsumer(eb, "group.orders", "billing").Handler(counter(&billing[0]))
+	b1 := GroupConsumer(eb, "group.orders", "billing").Handler(counter(&billing[1]))
+	a := eb.Consumer("group.orders").Handler(counter(&audit))
+	waitReady(t, b0, b1, a)
+
+	const published = 10
+	for i := 0; i < published; i++ {
+		if err := eb.Publish("group.orders", i); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) &&
+		(atomic.LoadInt32(&audit) < published || atomic.LoadInt32(&billing[0])+atomic.LoadInt32(&billing[1]) < published) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The ungrouped consumer sees every event; the group sees each once, split round-robin
+	if got := atomic.LoadInt32(&audit); got != published {
+		t.Errorf("ungrouped consumer received %d, want %d", got, published)
+	}
+	if b0, b1 := atomic.LoadInt32(&billing[0]), atomic.LoadInt32(&billing[1]); b0 != published/2 || b1 != published/2 {
+		t.Errorf("group members received %d and %d, want %d each", b0, b1, published/2)
+	}
+
+	// Once one member leaves, the other gets all of the group's copies
+	if err := b0.Unregister(); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+	if err := eb.Publish("group.orders", "last"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	deadline = time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&billing[1]) < published/2+1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&billing[1]); got != published/2+1 {
+		t.Errorf("remaining member received %d, want %d", got, published/2+1)
+	}
+}
+
+func TestEventBus_SendRoundRobin(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var a, b int64
+	eb.Consumer("rr.address").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&a, 1)
+		return nil
+	})
+	eb.Consumer("rr.address").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&b, 1)
+		return nil
+	})
+
+	for i := 0; i < 4; i++ {
+		if err := eb.Send("rr.address", i); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&a)+atomic.LoadInt64(&b) < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt64(&a) != 2 || atomic.LoadInt64(&b) != 2 {
+		t.Errorf("deliveries = %d/%d, want 2/2 across consumers", atomic.LoadInt64(&a), atomic.LoadInt64(&b))
+	}
+}
+
+func TestGroupConsumer_SharesSend(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var billing [2]int32
+	var audit int32
+	counter := func(n *int32) MessageHandler {
+		return func(ctx FluxorContext, msg Message) error {
+			atomic.AddInt32(n, 1)
+			return nil
+		}
+	}
+	b0 := GroupConsumer(eb, "group.send", "billing").Handler(counter(&billing[0]))
+	b1 := GroupConsumer(eb, "group.send", "billing").Handler(counter(&billing[1]))
+	a := eb.Consumer("group.send").Handler(counter(&audit))
+	waitReady(t, b0, b1, a)
+
+	// The group takes one turn like the ungrouped consumer, split between its members
+	const sent = 8
+	for i := 0; i < sent; i++ {
+		if err := eb.Send("group.send", i); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&audit)+atomic.LoadInt32(&billing[0])+atomic.LoadInt32(&billing[1]) < sent && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&audit); got != sent/2 {
+		t.Errorf("ungrouped consumer received %d, want %d", got, sent/2)
+	}
+	if b0, b1 := atomic.LoadInt32(&billing[0]), atomic.LoadInt32(&billing[1]); b0 != sent/4 || b1 != sent/4 {
+		t.Errorf("group members received %d and %d, want %d each", b0, b1, sent/4)
+	}
+}
+
+func TestGroupConsumer_ThroughWrapper(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	tenant := NewTenantEventBus(gocmd.EventBus(), "acme")
+
+	var received int32
+	counter := func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt32(&received, 1)
+		return nil
+	}
+	c0 := GroupConsumer(tenant, "group.jobs", "workers").Handler(counter)
+	c1 := GroupConsumer(tenant, "group.jobs", "workers").Handler(counter)
+	waitReady(t, c0, c1)
+
+	if err := tenant.Publish("group.jobs", "job"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&received); got != 1 {
+		t.Errorf("group received %d copies, want 1", got)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import "strings"
+
+// Message headers set by the bus. HeaderRequestID may also be set by producers;
+// the other bus headers are reserved (see ValidateHeaders).
+const (
+	// HeaderRequestID carries the request ID for tracing and log correlation
+	HeaderRequestID = "X-Request-ID"
+
+	// HeaderReplyAddress carries the reply address of a Request
+	HeaderReplyAddress = "replyAddress"
+
+	// HeaderMessageID carries a message's unique ID (see MessageID); the JetStream
+	// bus sets it on Publish and Send unless the producer did, and drops duplicate
+	// publishes with the same ID
+	HeaderMessageID = "Nats-Msg-Id"
+)
+
+// reservedHeaders are headers the bus relies on; producers can't set them
+var reservedHeaders = map[string]bool{
+	HeaderReplyAddress:    true,
+	StreamHeader:          true,
+	scheduleAddressHeader: true,
+	hybridOriginHeader:    true,
+	traceParentHeader:     true,
+}
+
+// IsReservedHeader reports whether key is set by the bus and can't be used
+// with SendWithHeaders/PublishWithHeaders (case-insensitive)
+func IsReservedHeader(key string) bool {
+	for reserved := range reservedHeaders {
+		if strings.EqualFold(key, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerValue looks key up exactly, then case-insensitively
+func headerValue(headers map[string]string, key string) string {
+	if v, ok := headers[key]; ok {
+		return v
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// mergeHeaders returns a copy of base with override applied on top
+func mergeHeaders(base, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Pinger is implemented by event buses backed by a broker connection (the cluster buses).
+// Ping returns an error unless the connection is up and a message round-trips through it.
+// Buses that do not implement it (the in-memory bus) have nothing to check.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping implements Pinger.
+func (eb *clusterNATSEventBus) Ping(ctx context.Context) error {
+	return pingNATS(ctx, eb.nc, eb.prefix)
+}
+
+// Ping implements Pinger.
+func (eb *clusterJSEventBus) Ping(ctx context.Context) error {
+	return pingNATS(ctx, eb.nc, eb.prefix)
+}
+
+// Ping implements Pinger when the wrapped bus does.
+func (t *TracingEventBus) Ping(ctx context.Context) error {
+	if p, ok := t.EventBus.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// Ping implements Pinger when the wrapped bus does.
+func (t *TenantEventBus) Ping(ctx context.Context) error {
+	if p, ok := t.EventBus.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// Ping implements Pinger when the wrapped bus does.
+func (r *RequestIDEventBus) Ping(ctx context.Context) error {
+	if p, ok := r.EventBus.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// pingNATS checks the connection status, then round-trips a message on a
+// reserved subject no address can map to
+func pingNATS(ctx context.Context, nc *nats.Conn, prefix string) error {
+	if status := nc.Status(); status != nats.CONNECTED {
+		return &EventBusError{Code: "NOT_CONNECTED", Message: "nats connection is " + strings.ToLower(status.String())}
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+	}
+
+	subject := prefix + "._health." + generateUUID()
+	sub, err := nc.SubscribeSync(subject)
+	if err != nil {
+		return &EventBusError{Code: "PING_FAILED", Message: "nats ping failed: " + err.Error()}
+	}
+	defer func() {
+		if err := sub.Unsubscribe(); err != nil {
+			// Best-effort; ignore on error.
+		}
+	}()
+
+	if err := nc.Publish(subject, []byte("ping")); err != nil {
+		return &EventBusError{Code: "PING_FAILED", Message: "nats ping failed: " + err.Error()}
+	}
+	if _, err := sub.NextMsgWithContext(ctx); err != nil {
+		return &EventBusError{Code: "PING_FAILED", Message: "nats ping failed: " + err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// hybridOriginHeader names the node that published a message it also delivered locally,
+// so that node's own remote subscription skips the copy coming back from NATS
+const hybridOriginHeader = "X-Hybrid-Origin"
+
+// HybridConfig configures NewHybridEventBus.
+type HybridConfig struct {
+	// RemoteAddresses are addresses known to have subscribers in other processes.
+	// Publishes to them also go to the cluster; see HybridEventBus.AddRemote.
+	RemoteAddresses []string
+
+	// OnRoute, if set, is called with every routing decision (for debugging)
+	OnRoute func(RouteDecision)
+}
+
+// RouteDecision records where a HybridEventBus sent one message
+type RouteDecision struct {
+	Op      string // publish, send or request
+	Address string
+	Local   bool // Delivered to in-process consumers
+	Remote  bool // Sent through the cluster bus
+}
+
+// HybridRouteCounts counts HybridEventBus routing decisions
+type HybridRouteCounts struct {
+	Local  int64 `json:"local"`  // In-process only
+	Remote int64 `json:"remote"` // Cluster only
+	Both   int64 `json:"both"`   // Publishes delivered in-process and to the cluster
+}
+
+// HybridEventBus delivers messages to consumers in this process directly and only
+// uses the cluster bus when the message has to leave the process, for deployments
+// that co-locate services (e.g. all-in-one) on a cluster bus.
+//
+// Routing:
+//   - Send, Request and RequestStream go to an in-process consumer if there is one,
+//     otherwise through the cluster bus
+//   - Publish goes to in-process consumers, and also through the cluster bus when
+//     the address is a known remote address (or has no in-process consumers)
+//   - Scheduled publishes always go through the cluster bus
+//
+// Consumers are registered on both buses, so other processes still reach them.
+// A publish that reached a remote address without being registered there is not
+// seen by those subscribers: keep the registry in sync with the deployment.
+type HybridEventBus struct {
+	EventBus // Cluster bus
+	local    *eventBus
+	node     string
+	onRoute  func(RouteDecision)
+
+	mu     sync.RWMutex
+	remote map[string]bool
+
+	routedLocal  int64
+	routedRemote int64
+	routedBoth   int64
+}
+
+// NewHybridEventBus wraps a cluster bus (NewClusterEventBus or NewClusterEventBusJetStream)
+// with an in-process fast path - fail-fast on nil or non-cluster buses
+func NewHybridEventBus(ctx context.Context, gocmd GoCMD, cluster EventBus, config HybridConfig) *HybridEventBus {
+	if cluster == nil {
+		panic("cluster eventBus cannot be nil")
+	}
+	if _, ok := cluster.(headerSender); !ok {
+		panic(fmt.Sprintf("hybrid event bus needs a cluster event bus, got %T", cluster))
+	}
+
+	h := &HybridEventBus{
+		EventBus: cluster,
+		local:    NewEventBus(ctx, gocmd).(*eventBus),
+		node:     generateUUID(),
+		onRoute:  config.OnRoute,
+		remote:   make(map[string]bool),
+	}
+	for _, address := range config.RemoteAddresses {
+		h.AddRemote(address)
+	}
+	return h
+}
+
+// AddRemote marks address as having subscribers in other processes
+func (h *HybridEventBus) AddRemote(address string) {
+	if err := ValidateAddress(address); err != nil {
+		panic(err)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remote[address] = true
+}
+
+// RemoveRemote unmarks address; publishes to it stay in-process while it has local consumers
+func (h *HybridEventBus) RemoveRemote(address string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.remote, address)
+}
+
+// RouteCounts returns how many messages took each route so far
+func (h *HybridEventBus) RouteCounts() HybridRouteCounts {
+	return HybridRouteCounts{
+		Local:  atomic.LoadInt64(&h.routedLocal),
+		Remote: atomic.LoadInt64(&h.routedRemote),
+		Both:   atomic.LoadInt64(&h.routedBoth),
+	}
+}
+
+// hasLocal reports whether address has in-process consumers
+func (h *HybridEventBus) hasLocal(address string) bool {
+	h.local.mu.RLock()
+	defer h.local.mu.RUnlock()
+	return len(h.local.consumers[address]) > 0
+}
+
+func (h *HybridEventBus) isRemote(address string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.remote[address]
+}
+
+// route decides where a message goes and records the decision
+func (h *HybridEventBus) route(op, address string) RouteDecision {
+	d := RouteDecision{Op: op, Address: address, Local: h.hasLocal(address)}
+	if op == "publish" {
+		d.Remote = !d.Local || h.isRemote(address)
+	} else {
+		d.Remote = !d.Local
+	}
+
+	switch {
+	case d.Local && d.Remote:
+		atomic.AddInt64(&h.routedBoth, 1)
+	case d.Local:
+		atomic.AddInt64(&h.routedLocal, 1)
+	default:
+		atomic.AddInt64(&h.routedRemote, 1)
+	}
+	if h.onRoute != nil {
+		h.onRoute(d)
+	}
+	return d
+}
+
+// Publish implements EventBus.
+func (h *HybridEventBus) Publish(address string, body interface{}) error {
+	d := h.route("publish", address)
+	if !d.Local {
+		return h.EventBus.Publish(address, body)
+	}
+	if err := h.local.Publish(address, body); err != nil {
+		return err
+	}
+	if d.Remote {
+		return h.EventBus.(headerSender).publishWithHeaders(address, body, map[string]string{hybridOriginHeader: h.node})
+	}
+	return nil
+}
+
+// PublishWithHeaders implements EventBus.
+func (h *HybridEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	d := h.route("publish", address)
+	if !d.Local {
+		return h.EventBus.PublishWithHeaders(address, body, headers)
+	}
+	if err := h.local.PublishWithHeaders(address, body, headers); err != nil {
+		return err
+	}
+	if d.Remote {
+		return h.EventBus.(headerSender).publishWithHeaders(address, body, mergeHeaders(headers, map[string]string{hybridOriginHeader: h.node}))
+	}
+	return nil
+}
+
+// PublishBatch implements EventBus.
+func (h *HybridEventBus) PublishBatch(address string, bodies []interface{}) error {
+	d := h.route("publish", address)
+	if !d.Local {
+		return h.EventBus.PublishBatch(address, bodies)
+	}
+	if err := h.local.PublishBatch(address, bodies); err != nil {
+		return err
+	}
+	if d.Remote {
+		return h.EventBus.(headerSender).publishBatchWithHeaders(address, bodies, map[string]string{hybridOriginHeader: h.node})
+	}
+	return nil
+}
+
+// Send implements EventBus.
+func (h *HybridEventBus) Send(address string, body interface{}) error {
+	if h.route("send", address).Local {
+		return h.local.Send(address, body)
+	}
+	return h.EventBus.Send(address, body)
+}
+
+// SendWithHeaders implements EventBus.
+func (h *HybridEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if h.route("send", address).Local {
+		return h.local.SendWithHeaders(address, body, headers)
+	}
+	return h.EventBus.SendWithHeaders(address, body, headers)
+}
+
+// Request implements EventBus.
+func (h *HybridEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	if h.route("request", address).Local {
+		return h.local.Request(address, body, timeout)
+	}
+	return h.EventBus.Request(address, body, timeout)
+}
+
+// RequestStream implements EventBus.
+func (h *HybridEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	if h.route("request", address).Local {
+		return h.local.RequestStream(address, body, timeout)
+	}
+	return h.EventBus.RequestStream(address, body, timeout)
+}
+
+// Consumer implements EventBus. The consumer receives in-process messages directly
+// and cluster messages through the cluster bus.
+func (h *HybridEventBus) Consumer(address string) Consumer {
+	return &hybridConsumer{
+		local:  h.local.Consumer(address),
+		remote: h.EventBus.Consumer(address),
+		node:   h.node,
+	}
+}
+
+// Stats implements EventBus: per-address state from in-process consumers, totals from both buses.
+func (h *HybridEventBus) Stats() EventBusStats {
+	stats := h.local.Stats()
+	remote := h.EventBus.Stats()
+	stats.Published += remote.Published
+	stats.Sent += remote.Sent
+	stats.Requested += remote.Requested
+	stats.Dropped += remote.Dropped
+	return stats
+}
+
+// Close implements EventBus, closing both buses.
+func (h *HybridEventBus) Close() error {
+	if err := h.local.Close(); err != nil {
+		// Best-effort; ignore on error.
+	}
+	return h.EventBus.Close()
+}
+
+// Ping implements Pinger when the cluster bus does.
+func (h *HybridEventBus) Ping(ctx context.Context) error {
+	if p, ok := h.EventBus.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// publishLocal keeps GoCMD's local shutdown broadcast in-process
+func (h *HybridEventBus) publishLocal(address string, body interface{}) error {
+	return h.local.Publish(address, body)
+}
+
+// hybridConsumer is registered on both buses; its cluster side skips messages
+// this node already delivered in-process
+type hybridConsumer struct {
+	local  Consumer
+	remote Consumer
+	node   string
+
+	completionOnce sync.Once
+	completion     <-chan struct{}
+	readyOnce      sync.Once
+	ready          <-chan struct{}
+}
+
+func (c *hybridConsumer) Handler(handler MessageHandler) Consumer {
+	return c.HandlerN(1, handler)
+}
+
+func (c *hybridConsumer) HandlerN(n int, handler MessageHandler) Consumer {
+	c.local.HandlerN(n, handler)
+	c.remote.HandlerN(n, func(ctx FluxorContext, msg Message) error {
+		if msg.Headers()[hybridOriginHeader] == c.node {
+			return nil
+		}
+		return handler(ctx, msg)
+	})
+	return c
+}
+
+func (c *hybridConsumer) HandlerReply(handler ReplyHandler) Consumer {
+	return c.Handler(replyingHandler(handler))
+}
+
+func (c *hybridConsumer) OnError(handler ErrorHandler) Consumer {
+	c.local.OnError(handler)
+	c.remote.OnError(handler)
+	return c
+}
+
+func (c *hybridConsumer) setFilter(filter MessageFilter) {
+	FilterConsumer(c.local, filter)
+	FilterConsumer(c.remote, filter)
+}
+
+// Completion is closed once both sides are closed
+func (c *hybridConsumer) Completion() <-chan struct{} {
+	c.completionOnce.Do(func() { c.completion = bothClosed(c.local.Completion(), c.remote.Completion()) })
+	return c.completion
+}
+
+// Ready is closed once both sides are ready
+func (c *hybridConsumer) Ready() <-chan struct{} {
+	c.readyOnce.Do(func() { c.ready = bothClosed(c.local.Ready(), c.remote.Ready()) })
+	return c.ready
+}
+
+// bothClosed returns a channel closed once a and b are; callers build it once
+// per consumer, so repeated Ready/Completion calls don't each start a goroutine
+func bothClosed(a, b <-chan struct{}) <-chan struct{} {
+	done := make(chan struct{})
+	// Hidden: goroutine creation
+	go func() {
+		<-a
+		<-b
+		close(done)
+	}()
+	return done
+}
+
+func (c *hybridConsumer) Unregister() error {
+	localErr := c.local.Unregister()
+	if err := c.remote.Unregister(); err != nil {
+		return err
+	}
+	return localErr
+}
@@ -2,8 +2,11 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core/concurrency"
@@ -25,17 +28,35 @@ import (
 //   - Publish/Send/Request use RLock (concurrent reads)
 //   - Consumer registration uses Lock (exclusive writes)
 type eventBus struct {
-	consumers map[string][]*consumer
-	mu        sync.RWMutex
-	ctx       context.Context      // derived from gocmd.rootCtx via WithCancel
-	cancel    context.CancelFunc   // cancels ctx; called in Close() (redundant but defense-in-depth)
-	gocmd     GoCMD                // back-reference to GoCMD for creating FluxorContext (circular ref)
-	executor  concurrency.Executor // Executor for processing messages (hides goroutines)
-	logger    Logger               // Logger for error and debug messages
+	consumers      map[string][]*consumer
+	groupNext      map[string]*uint64 // Round-robin cursor per consumer group, by groupKey
+	mu             sync.RWMutex
+	ctx            context.Context      // derived from gocmd.rootCtx via WithCancel
+	cancel         context.CancelFunc   // cancels ctx; called in Close() (redundant but defense-in-depth)
+	gocmd          GoCMD                // back-reference to GoCMD for creating FluxorContext (circular ref)
+	executor       concurrency.Executor // Executor for processing messages (hides goroutines)
+	logger         Logger               // Logger for error and debug messages
+	counters       busCounters          // Message totals for Stats()
+	next           uint64               // Round-robin cursor for Send/Request
+	scheduled      scheduledTimers      // Pending PublishAfter/PublishAt timers
+	maxMessageSize int                  // Limit on encoded message bodies (see GoCMDOptions.MaxMessageSize)
+	requestTimeout time.Duration        // Used by Request when called with timeout <= 0
+	draining       int32                // Atomic: set by drain; new messages are rejected
+	consumerAdded  chan struct{}        // Closed and replaced when a consumer registers (see WaitForConsumer)
+	taps           map[string][]*busTap // Observers by address (see Tap), guarded by mu
+	tapCount       int32                // Atomic: number of taps, so untapped buses skip the lookup
+
+	reportBackpressure bool // Full mailboxes fail Publish with ErrConsumerBackpressure (see GoCMDOptions.ReportBackpressure)
 }
 
 // NewEventBus creates a new event bus
 func NewEventBus(ctx context.Context, gocmd GoCMD) EventBus {
+	return newEventBus(ctx, gocmd, DefaultMaxMessageSize, DefaultRequestTimeout)
+}
+
+// newEventBus creates an event bus that rejects encoded bodies over maxMessageSize
+// bytes and gives requests without a timeout requestTimeout
+func newEventBus(ctx context.Context, gocmd GoCMD, maxMessageSize int, requestTimeout time.Duration) *eventBus {
 	ctx, cancel := context.WithCancel(ctx)
 
 	// Create logger
@@ -49,32 +70,58 @@ func NewEventBus(ctx context.Context, gocmd GoCMD) EventBus {
 	executor := concurrency.NewExecutor(ctx, executorConfig)
 
 	return &eventBus{
-		consumers: make(map[string][]*consumer),
-		ctx:       ctx,
-		cancel:    cancel,
-		gocmd:     gocmd,
-		executor:  executor,
-		logger:    logger,
+		consumers:      make(map[string][]*consumer),
+		groupNext:      make(map[string]*uint64),
+		consumerAdded:  make(chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+		gocmd:          gocmd,
+		executor:       executor,
+		logger:         logger,
+		maxMessageSize: maxMessageSize,
+		requestTimeout: requestTimeout,
 	}
 }
 
 func (eb *eventBus) Publish(address string, body interface{}) error {
+	return eb.publishWithHeaders(address, body, nil)
+}
+
+// PublishWithHeaders implements EventBus.
+func (eb *eventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.publishWithHeaders(address, body, headers)
+}
+
+func (eb *eventBus) publishWithHeaders(address string, body interface{}, extra map[string]string) error {
+	_, err := eb.publishTo(address, body, extra, 1)
+	return err
+}
+
+// publishTo publishes to address's consumers (one per group), enqueueing on up to parallelism
+// mailboxes at once, and returns how many accepted the message
+func (eb *eventBus) publishTo(address string, body interface{}, extra map[string]string, parallelism int) (int, error) {
 	// Fail-fast: validate inputs immediately
 	if err := ValidateAddress(address); err != nil {
-		return err
+		return 0, err
+	}
+	if err := eb.accepting(address); err != nil {
+		return 0, err
 	}
 	if err := ValidateBody(body); err != nil {
-		return err
+		return 0, err
 	}
 
 	// Auto-encode to JSON if not already []byte
-	jsonBody, err := eb.encodeBody(body)
+	jsonBody, err := eb.encodeBody(address, body)
 	if err != nil {
-		return fmt.Errorf("encode body failed: %w", err)
+		return 0, fmt.Errorf("encode body failed: %w", err)
 	}
 
 	eb.mu.RLock()
-	consumers := eb.consumers[address]
+	consumers := eb.publishTargets(address)
 	eb.mu.RUnlock()
 
 	// Extract request ID from context if available
@@ -82,40 +129,91 @@ func (eb *eventBus) Publish(address string, body interface{}) error {
 	if requestID := GetRequestID(eb.ctx); requestID != "" {
 		headers["X-Request-ID"] = requestID
 	}
+	for k, v := range extra {
+		headers[k] = v
+	}
 	msg := newMessage(jsonBody, headers, "", eb)
+	if !strings.HasPrefix(address, frameworkAddressPrefix) {
+		atomic.AddInt64(&eb.counters.published, 1)
+	}
+	eb.observe(address, msg)
+
+	var delivered int
+	if parallelism > 1 && len(consumers) > 1 {
+		delivered, err = eb.deliverConcurrent(msg, consumers, parallelism)
+	} else {
+		delivered, err = eb.deliver(msg, consumers)
+	}
+	if err != nil {
+		return delivered, err
+	}
+	return delivered, eb.deliveryError(address, len(consumers)-delivered, delivered)
+}
 
+// deliver enqueues msg on each consumer's mailbox without blocking and returns
+// how many accepted it; a full mailbox is a drop, not an error (publishTo reports
+// drops with ReportBackpressure)
+func (eb *eventBus) deliver(msg Message, consumers []*consumer) (int, error) {
+	delivered := 0
 	for _, c := range consumers {
 		// Use Mailbox abstraction (hides channel operations)
 		if err := c.mailbox.Send(msg); err != nil {
 			if err == concurrency.ErrMailboxFull {
 				// Non-blocking: if handler is busy, skip
+				c.recordDrop()
 				continue
 			}
 			if err == concurrency.ErrMailboxClosed {
-				return eb.ctx.Err()
+				return delivered, eb.ctx.Err()
 			}
-			return err
+			return delivered, err
 		}
+		delivered++
 	}
-
-	return nil
+	return delivered, nil
 }
 
 func (eb *eventBus) Send(address string, body interface{}) error {
+	return eb.sendWithHeaders(address, body, nil)
+}
+
+// SendWithHeaders implements EventBus.
+func (eb *eventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	return eb.sendWithHeaders(address, body, headers)
+}
+
+func (eb *eventBus) sendWithHeaders(address string, body interface{}, extra map[string]string) error {
 	// Fail-fast: validate inputs immediately
 	if err := ValidateAddress(address); err != nil {
 		return err
 	}
+	if err := eb.accepting(address); err != nil {
+		return err
+	}
 	if err := ValidateBody(body); err != nil {
 		return err
 	}
 
 	// Auto-encode to JSON if not already []byte
-	jsonBody, err := eb.encodeBody(body)
+	jsonBody, err := eb.encodeBody(address, body)
 	if err != nil {
 		return fmt.Errorf("encode body failed: %w", err)
 	}
 
+	// Extract request ID from context if available
+	headers := make(map[string]string)
+	if requestID := GetRequestID(eb.ctx); requestID != "" {
+		headers["X-Request-ID"] = requestID
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+	msg := newMessage(jsonBody, headers, "", eb)
+	eb.observe(address, msg)
+
 	eb.mu.RLock()
 	consumers := eb.consumers[address]
 	eb.mu.RUnlock()
@@ -126,20 +224,15 @@ func (eb *eventBus) Send(address string, body interface{}) error {
 	}
 
 	// Round-robin to one consumer
-	consumer := consumers[0]
-
-	// Extract request ID from context if available
-	headers := make(map[string]string)
-	if requestID := GetRequestID(eb.ctx); requestID != "" {
-		headers["X-Request-ID"] = requestID
-	}
-	msg := newMessage(jsonBody, headers, "", eb)
+	consumer := eb.sendTarget(address, consumers)
+	atomic.AddInt64(&eb.counters.sent, 1)
 
 	// Use Mailbox abstraction (hides select statement)
 	// Note: Mailbox.Send() is non-blocking, so timeout is handled by backpressure
 	if err := consumer.mailbox.Send(msg); err != nil {
 		if err == concurrency.ErrMailboxFull {
-			return ErrTimeout
+			consumer.recordDrop()
+			return eb.mailboxFullError(address)
 		}
 		if err == concurrency.ErrMailboxClosed {
 			return eb.ctx.Err()
@@ -150,19 +243,29 @@ func (eb *eventBus) Send(address string, body interface{}) error {
 }
 
 func (eb *eventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	return eb.requestWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *eventBus) requestWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (Message, error) {
 	// Fail-fast: validate inputs immediately
 	if err := ValidateAddress(address); err != nil {
 		return nil, err
 	}
+	if err := eb.accepting(address); err != nil {
+		return nil, err
+	}
 	if err := ValidateBody(body); err != nil {
 		return nil, err
 	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
 	if err := ValidateTimeout(timeout); err != nil {
 		return nil, err
 	}
 
 	// Auto-encode to JSON if not already []byte
-	jsonBody, err := eb.encodeBody(body)
+	jsonBody, err := eb.encodeBody(address, body)
 	if err != nil {
 		return nil, fmt.Errorf("encode body failed: %w", err)
 	}
@@ -190,7 +293,11 @@ func (eb *eventBus) Request(address string, body interface{}, timeout time.Durat
 	if requestID := GetRequestID(eb.ctx); requestID != "" {
 		headers["X-Request-ID"] = requestID
 	}
+	for k, v := range extra {
+		headers[k] = v
+	}
 	msg := newMessage(jsonBody, headers, replyAddress, eb)
+	eb.observe(address, msg)
 
 	eb.mu.RLock()
 	consumers := eb.consumers[address]
@@ -201,13 +308,15 @@ func (eb *eventBus) Request(address string, body interface{}, timeout time.Durat
 		return nil, &EventBusError{Code: "NO_HANDLERS", Message: "No handlers registered for address: " + address}
 	}
 
-	consumer := consumers[0]
+	consumer := eb.sendTarget(address, consumers)
+	atomic.AddInt64(&eb.counters.requested, 1)
 
 	// Use Mailbox abstraction (hides select statement)
 	// Note: Mailbox.Send() is non-blocking, timeout handled by backpressure
 	if err := consumer.mailbox.Send(msg); err != nil {
 		if err == concurrency.ErrMailboxFull {
-			return nil, ErrTimeout
+			consumer.recordDrop()
+			return nil, eb.mailboxFullError(address)
 		}
 		if err == concurrency.ErrMailboxClosed {
 			return nil, eb.ctx.Err()
@@ -234,6 +343,16 @@ func (eb *eventBus) Request(address string, body interface{}, timeout time.Durat
 }
 
 func (eb *eventBus) Consumer(address string) Consumer {
+	return eb.consumerOn(address, eb.executor)
+}
+
+// consumerOn creates a consumer whose processing runs on executor
+func (eb *eventBus) consumerOn(address string, executor concurrency.Executor) Consumer {
+	return eb.groupConsumerOn(address, "", executor)
+}
+
+// groupConsumerOn creates a consumer in group ("" for none) whose processing runs on executor
+func (eb *eventBus) groupConsumerOn(address, group string, executor concurrency.Executor) Consumer {
 	// Fail-fast: validate address immediately
 	if err := ValidateAddress(address); err != nil {
 		failfast.Err(err)
@@ -251,17 +370,26 @@ func (eb *eventBus) Consumer(address string) Consumer {
 
 	c := &consumer{
 		address:  address,
+		group:    group,
 		mailbox:  concurrency.NewBoundedMailbox(100), // Hidden: channel creation
 		eventBus: eb,
+		executor: executor,
 		ctx:      fluxorCtx,           // Initialize ctx to prevent nil pointer
 		done:     make(chan struct{}), // Channel for Completion() notification (closed when mailbox processing stops)
+		ready:    make(chan struct{}),
 	}
 
 	eb.consumers[address] = append(eb.consumers[address], c)
+	close(eb.consumerAdded)
+	eb.consumerAdded = make(chan struct{})
+	if key := groupKey(address, group); group != "" && eb.groupNext[key] == nil {
+		eb.groupNext[key] = new(uint64)
+	}
 	return c
 }
 
 func (eb *eventBus) Close() error {
+	eb.scheduled.stop()
 	eb.cancel()
 
 	// Shutdown executor gracefully
@@ -281,45 +409,118 @@ func (eb *eventBus) Close() error {
 		}
 	}
 	eb.consumers = make(map[string][]*consumer)
+	eb.groupNext = make(map[string]*uint64)
 	return nil
 }
 
 // consumer implements Consumer
 // Uses Mailbox abstraction to hide channel operations
 type consumer struct {
-	address  string
-	mailbox  concurrency.Mailbox // Abstracted: hides chan Message
-	handler  MessageHandler
-	eventBus *eventBus
-	ctx      FluxorContext
-	mu       sync.RWMutex
-	done     chan struct{} // Channel for Completion() notification (closed when mailbox closes)
+	address      string
+	group        string              // Consumer group; "" when ungrouped
+	mailbox      concurrency.Mailbox // Abstracted: hides chan Message
+	handler      MessageHandler
+	onError      ErrorHandler
+	filter       MessageFilter
+	eventBus     *eventBus
+	executor     concurrency.Executor // Runs processMessages; the bus's, or a deployment's dedicated one
+	ctx          FluxorContext
+	mu           sync.RWMutex
+	done         chan struct{} // Channel for Completion() notification (closed when mailbox closes)
+	ready        chan struct{} // Closed by the first processMessages loop; see Ready()
+	readyOnce    sync.Once
+	started      sync.Once // Processing starts once: one goroutine per mailbox keeps send order
+	dropped      int64     // Atomic count of messages rejected by a full mailbox
+	lastOverload int64     // Unix nanos of the last ConsumerOverloadedAddress event
+	running      int32     // Atomic count of processMessages loops still draining the mailbox
+	busy         int32     // Atomic count of messages being handled right now
+	// Cancels the dedicated executor of a HandlerN consumer
+	stopWorkers context.CancelFunc
+}
+
+// recordDrop counts a message rejected by this consumer's full mailbox
+func (c *consumer) recordDrop() {
+	dropped := atomic.AddInt64(&c.dropped, 1)
+	atomic.AddInt64(&c.eventBus.counters.dropped, 1)
+	c.reportOverload(dropped)
+}
+
+func (c *consumer) HandlerReply(handler ReplyHandler) Consumer {
+	return c.Handler(replyingHandler(handler))
+}
+
+func (c *consumer) OnError(handler ErrorHandler) Consumer {
+	c.mu.Lock()
+	c.onError = handler
+	c.mu.Unlock()
+	return c
+}
+
+func (c *consumer) setFilter(filter MessageFilter) {
+	c.mu.Lock()
+	c.filter = filter
+	c.mu.Unlock()
 }
 
 func (c *consumer) Handler(handler MessageHandler) Consumer {
+	return c.HandlerN(1, handler)
+}
+
+func (c *consumer) HandlerN(n int, handler MessageHandler) Consumer {
 	// Fail-fast: handler cannot be nil
 	failfast.NotNil(handler, "handler")
+	failfast.If(n > 0, "consumer concurrency must be positive")
 
 	c.mu.Lock()
 	c.handler = handler
 	c.mu.Unlock()
 
 	// Start processing messages using Executor (hides go func() call)
-	task := concurrency.NewNamedTask(
-		fmt.Sprintf("eventbus-consumer-%s", c.address),
-		func(ctx context.Context) error {
-			return c.processMessages(ctx)
-		},
-	)
-	if err := c.eventBus.executor.Submit(task); err != nil {
-		c.eventBus.logger.Error(fmt.Sprintf("Failed to submit consumer task for address %s: %v", c.address, err))
-		// Close done channel since processing won't start
-		close(c.done)
-		// Still return consumer - handler can be set later
-	}
+	// Only once: replacing the handler must not add readers, which would
+	// break per-consumer ordering
+	c.started.Do(func() {
+		c.start(n)
+	})
 	return c
 }
 
+// start runs n processMessages loops. Concurrent loops get a dedicated executor,
+// since each loop holds an executor worker for the consumer's lifetime.
+func (c *consumer) start(n int) {
+	executor := c.executor
+	if n > 1 {
+		ctx, cancel := context.WithCancel(c.eventBus.ctx)
+		executor = concurrency.NewExecutor(ctx, concurrency.ExecutorConfig{Workers: n, QueueSize: n})
+		c.stopWorkers = cancel
+	}
+
+	atomic.StoreInt32(&c.running, int32(n))
+	for i := 0; i < n; i++ {
+		task := concurrency.NewNamedTask(
+			fmt.Sprintf("eventbus-consumer-%s", c.address),
+			func(ctx context.Context) error {
+				return c.processMessages(ctx)
+			},
+		)
+		if err := executor.Submit(task); err != nil {
+			c.eventBus.logger.Error(fmt.Sprintf("Failed to submit consumer task for address %s: %v", c.address, err))
+			// This loop won't run
+			c.loopDone()
+		}
+	}
+}
+
+// loopDone closes the done channel once the last processMessages loop stops
+func (c *consumer) loopDone() {
+	if atomic.AddInt32(&c.running, -1) > 0 {
+		return
+	}
+	close(c.done)
+	if c.stopWorkers != nil {
+		c.stopWorkers()
+	}
+}
+
 func (c *consumer) processMessages(ctx context.Context) error {
 	// Panic isolation - recover from panics without re-panicking
 	// This allows the message processing loop to continue even if one message handler panics
@@ -327,11 +528,14 @@ func (c *consumer) processMessages(ctx context.Context) error {
 		if r := recover(); r != nil {
 			// Log panic but don't re-panic - maintain panic isolation
 			c.eventBus.logger.Error(fmt.Sprintf("panic in message processing loop for address %s (isolated): %v", c.address, r))
+			c.eventBus.counters.handlerFailed(c.address, true)
 		}
 		// Close done channel to notify Completion() when mailbox processing stops
-		close(c.done)
+		c.loopDone()
 	}()
 
+	c.readyOnce.Do(func() { close(c.ready) })
+
 	// Use Mailbox abstraction (hides select statement and channel operations)
 	for {
 		// Receive message using Mailbox (hides channel receive and select)
@@ -349,7 +553,14 @@ func (c *consumer) processMessages(ctx context.Context) error {
 			continue
 		}
 
-		if c.handler != nil {
+		c.mu.RLock()
+		handler := c.handler
+		onError := c.onError
+		filter := c.filter
+		c.mu.RUnlock()
+
+		atomic.AddInt32(&c.busy, 1)
+		if handler != nil {
 			// Use the consumer's context (now properly initialized)
 			fluxorCtx := c.ctx
 			if fluxorCtx == nil {
@@ -358,6 +569,8 @@ func (c *consumer) processMessages(ctx context.Context) error {
 					fluxorCtx = newFluxorContext(c.eventBus.ctx, c.eventBus.gocmd)
 				}
 			}
+			// Thread the sender's request ID into the handler's context and bus
+			fluxorCtx = withMessageRequestID(fluxorCtx, message)
 
 			// Wrap handler call in panic recovery for individual messages (panic isolation)
 			func() {
@@ -365,11 +578,17 @@ func (c *consumer) processMessages(ctx context.Context) error {
 					if r := recover(); r != nil {
 						// Log handler panic but don't crash - maintain panic isolation
 						c.eventBus.logger.Error(fmt.Sprintf("handler panic for address %s (isolated): %v", c.address, r))
+						c.eventBus.counters.handlerFailed(c.address, true)
+						runErrorHandler(c.eventBus.logger, c.address, onError, fluxorCtx, message, fmt.Errorf("handler panic: %v", r))
 					}
 				}()
 
+				if filterRejects(&c.eventBus.counters, c.address, filter, message) {
+					return
+				}
 				// Call handler - errors are logged but don't crash
-				if err := c.handler(fluxorCtx, message); err != nil {
+				if err := handler(fluxorCtx, message); err != nil {
+					c.eventBus.counters.handlerFailed(c.address, errors.Is(err, errReplyHandlerPanic))
 					// Log handler error but don't panic - maintain system stability
 					// Try to extract request ID from message headers for better tracing
 					requestID := ""
@@ -383,15 +602,21 @@ func (c *consumer) processMessages(ctx context.Context) error {
 					} else {
 						c.eventBus.logger.Error(fmt.Sprintf("handler error for address %s: %v", c.address, err))
 					}
+					runErrorHandler(c.eventBus.logger, c.address, onError, fluxorCtx, message, err)
 				}
 			}()
 		} else {
 			// Handler is nil - log but don't panic (shouldn't happen in normal flow)
 			c.eventBus.logger.Info(fmt.Sprintf("handler is nil for address %s", c.address))
 		}
+		atomic.AddInt32(&c.busy, -1)
 	}
 }
 
+func (c *consumer) Ready() <-chan struct{} {
+	return c.ready
+}
+
 func (c *consumer) Completion() <-chan struct{} {
 	// Return the done channel that will be closed when mailbox processing stops
 	// This is efficient - no polling, just channel notification
@@ -409,28 +634,45 @@ func (c *consumer) Unregister() error {
 			break
 		}
 	}
+	if c.group != "" && !c.eventBus.hasGroup(c.address, c.group) {
+		delete(c.eventBus.groupNext, groupKey(c.address, c.group))
+	}
 
 	// Close mailbox (hides channel close operation)
 	c.mailbox.Close()
 	return nil
 }
 
+// replyAddressPrefix starts the temporary addresses requests receive replies on
+const replyAddressPrefix = "reply."
+
+// IsReplyAddress reports whether address is a temporary address a Request awaits
+// its reply on, e.g. to keep them out of per-address metrics
+func IsReplyAddress(address string) bool {
+	return strings.HasPrefix(address, replyAddressPrefix)
+}
+
 func generateReplyAddress() string {
-	return "reply." + uuid.New().String()
+	return replyAddressPrefix + uuid.New().String()
 }
 
-// encodeBody encodes body to JSON if needed - fail-fast
-func (eb *eventBus) encodeBody(body interface{}) (interface{}, error) {
+// encodeBody encodes body to JSON if needed and checks it against the size limit - fail-fast
+func (eb *eventBus) encodeBody(address string, body interface{}) (interface{}, error) {
 	// Fail-fast: validate body
 	if err := ValidateBody(body); err != nil {
 		return nil, err
 	}
 
-	// If already []byte, return as-is
-	if data, ok := body.([]byte); ok {
-		return data, nil
+	// If already []byte, use as-is; otherwise encode to JSON - errors are propagated immediately
+	data, ok := body.([]byte)
+	if !ok {
+		var err error
+		if data, err = JSONEncode(body); err != nil {
+			return nil, err
+		}
 	}
-
-	// Encode to JSON - errors are propagated immediately
-	return JSONEncode(body)
+	if err := checkMessageSize(address, data, eb.maxMessageSize, &eb.counters); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
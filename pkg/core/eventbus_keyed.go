@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+)
+
+// KeyFunc extracts the ordering key from a message (e.g. an aggregate ID)
+type KeyFunc func(msg Message) string
+
+// KeyedHandler returns a handler that processes messages with the same key in
+// order on one of workers goroutines, while different keys run in parallel
+//
+// Usage:
+//
+//	eb.Consumer("orders.events").Handler(core.KeyedHandler(4, orderID, handleOrderEvent))
+//
+// Dispatch blocks when a worker is busy (backpressure flows to the consumer
+// mailbox) rather than dropping, so per-key order is preserved.
+// Workers run on an executor started on the first message and stop when GoCMD
+// closes; each message is handled with the context it was delivered on.
+//
+// The returned handler succeeds once a message is queued, so the consumer sees
+// it as handled: errors and panics of handler on the workers are only logged.
+// They don't reach Consumer.OnError, aren't counted in EventBusStats.HandlerFailures,
+// and don't nak cluster deliveries. Report failures from handler itself where
+// they matter (e.g. send them to a dead-letter address).
+func KeyedHandler(workers int, key KeyFunc, handler MessageHandler) MessageHandler {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+	if key == nil {
+		panic("key function cannot be nil")
+	}
+	if handler == nil {
+		panic("handler cannot be nil")
+	}
+
+	k := &keyedDispatcher{
+		queues:  make([]chan keyedMessage, workers),
+		handler: handler,
+	}
+	for i := range k.queues {
+		k.queues[i] = make(chan keyedMessage, 100)
+	}
+
+	return func(ctx FluxorContext, msg Message) error {
+		k.start.Do(func() { k.run(lifetime(ctx)) })
+
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(key(msg)))
+		queue := k.queues[h.Sum32()%uint32(len(k.queues))]
+
+		select {
+		case queue <- keyedMessage{ctx: ctx, msg: msg}:
+			return nil
+		case <-ctx.Context().Done():
+			return ctx.Context().Err()
+		}
+	}
+}
+
+// keyedMessage pairs a message with the context it was delivered on
+type keyedMessage struct {
+	ctx FluxorContext
+	msg Message
+}
+
+// keyedDispatcher owns the per-worker queues for KeyedHandler
+type keyedDispatcher struct {
+	queues  []chan keyedMessage
+	handler MessageHandler
+	start   sync.Once
+}
+
+// lifetime returns the context KeyedHandler workers live for: GoCMD's, so
+// they outlive any one message
+func lifetime(ctx FluxorContext) context.Context {
+	if gocmd := ctx.GoCMD(); gocmd != nil {
+		return gocmd.Context()
+	}
+	return ctx.Context()
+}
+
+// run submits one task per queue to an executor sized to the queues
+func (k *keyedDispatcher) run(ctx context.Context) {
+	executor := concurrency.NewExecutor(ctx, concurrency.ExecutorConfig{Workers: len(k.queues), QueueSize: len(k.queues)})
+	for i := range k.queues {
+		queue := k.queues[i]
+		task := concurrency.NewNamedTask(fmt.Sprintf("keyed-handler-%d", i), func(ctx context.Context) error {
+			k.work(ctx, queue)
+			return nil
+		})
+		if err := executor.Submit(task); err != nil {
+			Error(fmt.Sprintf("keyed handler: failed to start worker %d: %v", i, err))
+		}
+	}
+}
+
+// work handles queued messages sequentially, isolating handler panics. A
+// message whose own context is done by the time it is dequeued is skipped.
+func (k *keyedDispatcher) work(ctx context.Context, queue chan keyedMessage) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case km := <-queue:
+			if err := km.ctx.Context().Err(); err != nil {
+				Error(fmt.Sprintf("keyed handler: message dropped: %v", err))
+				continue
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						Error(fmt.Sprintf("keyed handler panic (isolated): %v", r))
+					}
+				}()
+				if err := k.handler(km.ctx, km.msg); err != nil {
+					Error(fmt.Sprintf("keyed handler error: %v", err))
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+type orderedEvent struct {
+	Key string `json:"key"`
+	Seq int    `json:"seq"`
+}
+
+func decodeOrderedEvent(t *testing.T, msg Message) orderedEvent {
+	var ev orderedEvent
+	if err := json.Unmarshal(msg.Body().([]byte), &ev); err != nil {
+		t.Errorf("decode body: %v", err)
+	}
+	return ev
+}
+
+func TestEventBus_Send_PreservesOrder(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	eb := gocmd.EventBus()
+	defer eb.Close()
+
+	const total = 50
+	received := make(chan int, total)
+	consumer := eb.Consumer("ordered.address")
+	handler := func(ctx FluxorContext, msg Message) error {
+		received <- decodeOrderedEvent(t, msg).Seq
+		return nil
+	}
+	consumer.Handler(handler)
+	// Replacing the handler must not start a second reader
+	consumer.Handler(handler)
+
+	for i := 0; i < total; i++ {
+		if err := eb.Send("ordered.address", orderedEvent{Seq: i}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	for want := 0; want < total; want++ {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Fatalf("received seq %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for seq %d", want)
+		}
+	}
+}
+
+func TestKeyedHandler_PreservesPerKeyOrder(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	eb := gocmd.EventBus()
+	defer eb.Close()
+
+	const perKey = 20
+	keys := []string{"a", "b", "c", "d"}
+
+	var mu sync.Mutex
+	seen := make(map[string][]int)
+	var wg sync.WaitGroup
+	wg.Add(perKey * len(keys))
+
+	key := func(msg Message) string { return decodeOrderedEvent(t, msg).Key }
+	eb.Consumer("keyed.address").Handler(KeyedHandler(3, key, func(ctx FluxorContext, msg Message) error {
+		defer wg.Done()
+		ev := decodeOrderedEvent(t, msg)
+		mu.Lock()
+		seen[ev.Key] = append(seen[ev.Key], ev.Seq)
+		mu.Unlock()
+		return nil
+	}))
+
+	for i := 0; i < perKey; i++ {
+		for _, k := range keys {
+			if err := eb.Send("keyed.address", orderedEvent{Key: k, Seq: i}); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for keyed messages")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, k := range keys {
+		for i, seq := range seen[k] {
+			if seq != i {
+				t.Fatalf("key %s: position %d has seq %d (got %v)", k, i, seq, seen[k])
+			}
+		}
+	}
+}
+
+func TestKeyedHandler_UsesEachMessageContext(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	handled := make(chan context.Context, 2)
+	handler := KeyedHandler(1, func(Message) string { return "k" }, func(ctx FluxorContext, msg Message) error {
+		handled <- ctx.Context()
+		return nil
+	})
+
+	// Cancelling the first message's context must not stop the workers
+	first, cancel := context.WithCancel(context.Background())
+	if err := handler(newFluxorContext(first, gocmd), newMessage("a", nil, "", nil)); err != nil {
+		t.Fatalf("first message error = %v", err)
+	}
+	select {
+	case got := <-handled:
+		if got != first {
+			t.Error("first message handled with another context")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the first message")
+	}
+	cancel()
+
+	second := context.WithValue(context.Background(), orderedEvent{}, "second")
+	if err := handler(newFluxorContext(second, gocmd), newMessage("b", nil, "", nil)); err != nil {
+		t.Fatalf("second message error = %v", err)
+	}
+	select {
+	case got := <-handled:
+		if got != second {
+			t.Error("second message handled with another context")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the second message: workers stopped with the first context")
+	}
+}
+
+func TestKeyedHandler_FailFast(t *testing.T) {
+	noop := func(ctx FluxorContext, msg Message) error { return nil }
+	key := func(msg Message) string { return "" }
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"zero workers", func() { KeyedHandler(0, key, noop) }},
+		{"nil key", func() { KeyedHandler(1, nil, noop) }},
+		{"nil handler", func() { KeyedHandler(1, key, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("KeyedHandler() with %s should panic", tt.name)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRejectBackoff is how long a JetStream subscription pauses after its
+// executor refuses a message; consecutive refusals double it up to maxRejectBackoff
+const DefaultRejectBackoff = 50 * time.Millisecond
+
+const maxRejectBackoff = time.Second
+
+// RejectHandler is called for each message a cluster consumer's executor refused
+// because it was saturated, with the executor's error. It runs on the NATS delivery
+// goroutine, so keep it quick (e.g. bump a metric or sample a log line).
+type RejectHandler func(address string, err error)
+
+// rejectBackoff paces a consumer's subscriptions under sustained rejections
+type rejectBackoff struct {
+	base        time.Duration
+	consecutive int32
+}
+
+// next counts a rejection and returns how long to pause
+func (b *rejectBackoff) next() time.Duration {
+	n := atomic.AddInt32(&b.consecutive, 1)
+	d := b.base
+	for i := int32(1); i < n && d < maxRejectBackoff; i++ {
+		d *= 2
+	}
+	if d > maxRejectBackoff {
+		d = maxRejectBackoff
+	}
+	return d
+}
+
+// reset is called once the executor accepts again
+func (b *rejectBackoff) reset() {
+	if atomic.LoadInt32(&b.consecutive) != 0 {
+		atomic.StoreInt32(&b.consecutive, 0)
+	}
+}
+
+// messageRejected counts a message the consumer executor for address refused
+func (c *busCounters) messageRejected(address string) {
+	n, ok := c.rejected.Load(address)
+	if !ok {
+		n, _ = c.rejected.LoadOrStore(address, new(int64))
+	}
+	atomic.AddInt64(n.(*int64), 1)
+}
+
+// reportRejected counts, logs and hands a refused message to onReject, isolating its panics
+func reportRejected(logger Logger, counters *busCounters, onReject RejectHandler, address string, err error) {
+	counters.messageRejected(address)
+	logger.Info(fmt.Sprintf("cluster consumer overloaded for %s: %v", address, err))
+	if onReject == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(fmt.Sprintf("reject handler panic for %s (isolated): %v", address, r))
+		}
+	}()
+	onReject(address, err)
+}
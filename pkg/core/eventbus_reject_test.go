@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRejectBackoff(t *testing.T) {
+	b := rejectBackoff{base: 100 * time.Millisecond}
+	for _, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if got := b.next(); got != want {
+			t.Fatalf("next() = %v, want %v", got, want)
+		}
+	}
+	b.reset()
+	if got := b.next(); got != 100*time.Millisecond {
+		t.Errorf("next() after reset = %v, want the base", got)
+	}
+}
+
+func TestReportRejected(t *testing.T) {
+	var counters busCounters
+	errFull := errors.New("queue full")
+	var got []string
+	onReject := func(address string, err error) {
+		got = append(got, address)
+		if !errors.Is(err, errFull) {
+			t.Errorf("OnReject error = %v, want the executor's", err)
+		}
+		panic("handler bug")
+	}
+
+	reportRejected(NewDefaultLogger(), &counters, onReject, "orders", errFull)
+	reportRejected(NewDefaultLogger(), &counters, onReject, "orders", errFull)
+	reportRejected(NewDefaultLogger(), &counters, nil, "payments", errFull)
+
+	stats := counters.snapshot()
+	if stats.Rejected["orders"] != 2 || stats.Rejected["payments"] != 1 {
+		t.Errorf("Rejected = %v, want orders:2 payments:1", stats.Rejected)
+	}
+	if len(got) != 2 {
+		t.Errorf("OnReject calls = %v, want 2 despite panics", got)
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReplyHandler handles a request and returns its reply.
+// Registered with Consumer.HandlerReply; the result is sent via Message.Reply and
+// an error via Message.Fail, so request/reply handlers always respond.
+type ReplyHandler func(ctx FluxorContext, msg Message) (interface{}, error)
+
+// ReplyFailure is an error that sets the failure code sent by HandlerReply.
+// Other errors are sent with code 500.
+type ReplyFailure struct {
+	Code    int
+	Message string
+}
+
+func (e *ReplyFailure) Error() string {
+	return e.Message
+}
+
+// As lets errors.As convert a ReplyFailure to a *FluxorError with code
+// ErrorCodeFailed and the failure code in Details["failureCode"]
+func (e *ReplyFailure) As(target interface{}) bool {
+	t, ok := target.(**FluxorError)
+	if ok {
+		*t = &FluxorError{Code: ErrorCodeFailed, Message: e.Message, Details: map[string]interface{}{"failureCode": e.Code}}
+	}
+	return ok
+}
+
+// FailWith replies to msg with a failure like msg.Fail(failureCode, ...), adding
+// the Code and Details of a *FluxorError in err so the requester can rebuild it
+// with ReplyError. Only the error's Message is sent, not its Cause, which may
+// hold internal details.
+func FailWith(msg Message, failureCode int, err error) error {
+	var fe *FluxorError
+	if !errors.As(err, &fe) {
+		return msg.Fail(failureCode, err.Error())
+	}
+	body := map[string]interface{}{
+		"failureCode": failureCode,
+		"code":        fe.Code,
+		"message":     fe.Message,
+	}
+	if len(fe.Details) > 0 {
+		body["details"] = fe.Details
+	}
+	return msg.Reply(body)
+}
+
+// ReplyError returns the failure in a reply as a *FluxorError, or nil if the
+// reply isn't a failure (Message.Fail, FailWith, a HandlerReply error):
+//
+//	reply, err := eb.Request("orders.get", id, 0)
+//	if err == nil {
+//	    err = core.ReplyError(reply)
+//	}
+//
+// Failures sent with Message.Fail get code ErrorCodeFailed; the failure code is
+// in Details["failureCode"].
+func ReplyError(reply Message) error {
+	var failure struct {
+		FailureCode *int                   `json:"failureCode"`
+		Code        string                 `json:"code"`
+		Message     string                 `json:"message"`
+		Details     map[string]interface{} `json:"details"`
+	}
+	if reply == nil || reply.DecodeBody(&failure) != nil || failure.FailureCode == nil {
+		return nil
+	}
+	fe := &FluxorError{Code: failure.Code, Message: failure.Message, Details: failure.Details}
+	if fe.Code == "" {
+		fe.Code = ErrorCodeFailed
+	}
+	if fe.Details == nil {
+		fe.Details = make(map[string]interface{}, 1)
+	}
+	fe.Details["failureCode"] = *failure.FailureCode
+	return fe
+}
+
+// replyingHandler adapts a ReplyHandler to a MessageHandler
+// Messages without a reply address (Publish/Send) get no reply; errors are still returned for logging
+func replyingHandler(handler ReplyHandler) MessageHandler {
+	if handler == nil {
+		panic("reply handler cannot be nil")
+	}
+	return func(ctx FluxorContext, msg Message) (err error) {
+		var result interface{}
+		func() {
+			// Answer the requester even if the handler panics
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: %v", errReplyHandlerPanic, r)
+				}
+			}()
+			result, err = handler(ctx, msg)
+		}()
+
+		if msg.ReplyAddress() == "" {
+			return err
+		}
+
+		if err != nil {
+			code := 500
+			var failure *ReplyFailure
+			if errors.As(err, &failure) {
+				code = failure.Code
+			}
+			if failErr := FailWith(msg, code, err); failErr != nil {
+				return fmt.Errorf("%w (reply failed: %v)", err, failErr)
+			}
+			return err
+		}
+
+		if result == nil {
+			result = []byte("null") // Bodies cannot be nil; reply with JSON null
+		}
+		return msg.Reply(result)
+	}
+}
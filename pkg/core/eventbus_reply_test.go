@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConsumer_HandlerReply(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	eb.Consumer("math.double").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		var n int
+		if err := msg.DecodeBody(&n); err != nil {
+			return nil, &ReplyFailure{Code: 400, Message: "expected a number"}
+		}
+		if n < 0 {
+			return nil, errors.New("negative")
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		return n * 2, nil
+	})
+
+	decode := func(body interface{}) map[string]interface{} {
+		t.Helper()
+		reply, err := eb.Request("math.double", body, time.Second)
+		if err != nil {
+			t.Fatalf("Request(%v) error = %v", body, err)
+		}
+		var out map[string]interface{}
+		_ = reply.DecodeBody(&out)
+		return out
+	}
+
+	reply, err := eb.Request("math.double", 21, time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var n int
+	if err := reply.DecodeBody(&n); err != nil || n != 42 {
+		t.Errorf("reply = %d, %v; want 42", n, err)
+	}
+
+	if out := decode("x"); out["failureCode"] != float64(400) {
+		t.Errorf("failure = %v, want code 400 from ReplyFailure", out)
+	}
+	if out := decode(-1); out["failureCode"] != float64(500) || out["message"] != "negative" {
+		t.Errorf("failure = %v, want code 500 for plain errors", out)
+	}
+
+	// FluxorErrors keep their code and details across the bus
+	eb.Consumer("math.sqrt").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return nil, NewError("INVALID_INPUT", "negative").WithDetail("min", 0)
+	})
+	reply, err = eb.Request("math.sqrt", -1, time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var fe *FluxorError
+	if !errors.As(ReplyError(reply), &fe) || fe.Code != "INVALID_INPUT" || fe.Details["min"] != float64(0) || fe.Details["failureCode"] != 500 {
+		t.Errorf("ReplyError() = %+v", fe)
+	}
+
+	// The cause stays with the replier
+	eb.Consumer("db.get").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return nil, WrapError("UNAVAILABLE", "lookup failed", errors.New("dial tcp 10.0.0.5:5432: refused"))
+	})
+	reply, err = eb.Request("db.get", 1, time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if err := ReplyError(reply); err == nil || err.Error() != "lookup failed" {
+		t.Errorf("ReplyError() = %v, want only the message", err)
+	}
+
+	failed, _ := eb.Request("math.double", "x", time.Second)
+	if err := ReplyError(failed); !errors.Is(err, NewError(ErrorCodeFailed, "")) {
+		t.Errorf("ReplyError() of Fail = %v, want code FAILED", err)
+	}
+	if err := ReplyError(reply2(t, eb)); err != nil {
+		t.Errorf("ReplyError() of a plain reply = %v, want nil", err)
+	}
+
+	// A nil result still answers the request
+	if _, err := eb.Request("math.double", 0, time.Second); err != nil {
+		t.Errorf("Request() with nil result error = %v, want a reply", err)
+	}
+}
+
+func TestConsumer_HandlerReply_PanicFails(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	eb.Consumer("boom").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		panic("boom")
+	})
+
+	reply, err := eb.Request("boom", "x", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v, panicking handler should still reply", err)
+	}
+	var out map[string]interface{}
+	if err := reply.DecodeBody(&out); err != nil || out["failureCode"] != float64(500) {
+		t.Errorf("reply = %v, %v; want failure code 500", out, err)
+	}
+}
+
+// reply2 returns a successful reply from math.double
+func reply2(t *testing.T, eb EventBus) Message {
+	t.Helper()
+	reply, err := eb.Request("math.double", 1, time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	return reply
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// RequestIDEventBus scopes an EventBus to one request ID: every message it
+// sends carries HeaderRequestID, so the consumer's FluxorContext.Context()
+// returns the same ID (GetRequestID) and its replies echo it back. Producer
+// headers passed to SendWithHeaders/PublishWithHeaders take precedence.
+//
+// web.FastHTTPServer hands one to each HTTP handler as ctx.EventBus, so a single
+// request ID threads HTTP -> bus -> handler -> reply.
+type RequestIDEventBus struct {
+	EventBus
+	requestID string
+}
+
+// NewRequestIDEventBus wraps eventBus for requestID
+// Fail-fast: panics on a nil bus or an empty request ID
+func NewRequestIDEventBus(eventBus EventBus, requestID string) *RequestIDEventBus {
+	if eventBus == nil {
+		panic("eventBus cannot be nil")
+	}
+	if requestID == "" {
+		panic("requestID cannot be empty")
+	}
+	if r, ok := eventBus.(*RequestIDEventBus); ok {
+		// Re-scoping replaces the request ID rather than nesting wrappers
+		eventBus = r.EventBus
+	}
+	return &RequestIDEventBus{EventBus: eventBus, requestID: requestID}
+}
+
+// RequestIDBus returns eventBus scoped to ctx's request ID, or eventBus itself
+// when ctx has none
+func RequestIDBus(ctx context.Context, eventBus EventBus) EventBus {
+	requestID := GetRequestID(ctx)
+	if requestID == "" {
+		return eventBus
+	}
+	return NewRequestIDEventBus(eventBus, requestID)
+}
+
+// RequestID returns the request ID the bus is scoped to
+func (r *RequestIDEventBus) RequestID() string {
+	return r.requestID
+}
+
+func (r *RequestIDEventBus) headers(headers map[string]string) map[string]string {
+	return mergeHeaders(map[string]string{HeaderRequestID: r.requestID}, headers)
+}
+
+// Publish implements EventBus.
+func (r *RequestIDEventBus) Publish(address string, body interface{}) error {
+	return r.EventBus.PublishWithHeaders(address, body, r.headers(nil))
+}
+
+// PublishWithHeaders implements EventBus.
+func (r *RequestIDEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	return r.EventBus.PublishWithHeaders(address, body, r.headers(headers))
+}
+
+// Send implements EventBus.
+func (r *RequestIDEventBus) Send(address string, body interface{}) error {
+	return r.EventBus.SendWithHeaders(address, body, r.headers(nil))
+}
+
+// SendWithHeaders implements EventBus.
+func (r *RequestIDEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	return r.EventBus.SendWithHeaders(address, body, r.headers(headers))
+}
+
+// Request implements EventBus; buses that can't attach headers send it unscoped.
+func (r *RequestIDEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	return r.requestWithHeaders(address, body, timeout, nil)
+}
+
+// RequestStream implements EventBus; buses that can't attach headers send it unscoped.
+func (r *RequestIDEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	return r.requestStreamWithHeaders(address, body, timeout, nil)
+}
+
+// PublishBatch implements EventBus; buses that can't attach headers send it unscoped.
+func (r *RequestIDEventBus) PublishBatch(address string, bodies []interface{}) error {
+	return r.publishBatchWithHeaders(address, bodies, nil)
+}
+
+// The headerSender methods add the request ID too, for wrappers stacked on top
+
+func (r *RequestIDEventBus) publishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if hs, ok := r.EventBus.(headerSender); ok {
+		return hs.publishWithHeaders(address, body, r.headers(headers))
+	}
+	return r.EventBus.PublishWithHeaders(address, body, r.headers(headers))
+}
+
+func (r *RequestIDEventBus) publishBatchWithHeaders(address string, bodies []interface{}, headers map[string]string) error {
+	if hs, ok := r.EventBus.(headerSender); ok {
+		return hs.publishBatchWithHeaders(address, bodies, r.headers(headers))
+	}
+	return r.EventBus.PublishBatch(address, bodies)
+}
+
+func (r *RequestIDEventBus) sendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if hs, ok := r.EventBus.(headerSender); ok {
+		return hs.sendWithHeaders(address, body, r.headers(headers))
+	}
+	return r.EventBus.SendWithHeaders(address, body, r.headers(headers))
+}
+
+func (r *RequestIDEventBus) requestWithHeaders(address string, body interface{}, timeout time.Duration, headers map[string]string) (Message, error) {
+	if hs, ok := r.EventBus.(headerSender); ok {
+		return hs.requestWithHeaders(address, body, timeout, r.headers(headers))
+	}
+	return r.EventBus.Request(address, body, timeout)
+}
+
+func (r *RequestIDEventBus) requestStreamWithHeaders(address string, body interface{}, timeout time.Duration, headers map[string]string) (<-chan Message, error) {
+	if hs, ok := r.EventBus.(headerSender); ok {
+		return hs.requestStreamWithHeaders(address, body, timeout, r.headers(headers))
+	}
+	return r.EventBus.RequestStream(address, body, timeout)
+}
+
+// publishLocal keeps GoCMD's local framework events working through the wrapper
+func (r *RequestIDEventBus) publishLocal(address string, body interface{}) error {
+	return PublishLocal(r.EventBus, address, body)
+}
+
+// requestIDContext hands handlers of a message carrying HeaderRequestID a
+// context and bus scoped to that ID
+type requestIDContext struct {
+	FluxorContext
+	requestID string
+}
+
+// withMessageRequestID scopes ctx to msg's request ID, if it has one
+func withMessageRequestID(ctx FluxorContext, msg Message) FluxorContext {
+	if ctx == nil {
+		return nil
+	}
+	requestID := msg.Header(HeaderRequestID)
+	if requestID == "" {
+		return ctx
+	}
+	return &requestIDContext{FluxorContext: ctx, requestID: requestID}
+}
+
+func (c *requestIDContext) Context() context.Context {
+	return WithRequestID(c.FluxorContext.Context(), c.requestID)
+}
+
+func (c *requestIDContext) EventBus() EventBus {
+	return NewRequestIDEventBus(c.FluxorContext.EventBus(), c.requestID)
+}
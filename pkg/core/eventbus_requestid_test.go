@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRequestIDEventBus(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// The first hop forwards through its context's bus; the ID must survive both hops
+	got := make(chan string, 2)
+	front := eb.Consumer("rid.front").Handler(func(ctx FluxorContext, msg Message) error {
+		got <- GetRequestID(ctx.Context())
+		return ctx.EventBus().Send("rid.back", "x")
+	})
+	back := eb.Consumer("rid.back").Handler(func(ctx FluxorContext, msg Message) error {
+		got <- GetRequestID(ctx.Context())
+		return nil
+	})
+	replier := eb.Consumer("rid.reply").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return "pong", nil
+	})
+	waitReady(t, front, back, replier)
+
+	bus := RequestIDBus(WithRequestID(context.Background(), "req-1"), eb)
+	if err := bus.Send("rid.front", "x"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-got:
+			if id != "req-1" {
+				t.Errorf("hop %d request ID = %q, want req-1", i, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("hop %d not delivered", i)
+		}
+	}
+
+	reply, err := bus.Request("rid.reply", "ping", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if id := reply.Header(HeaderRequestID); id != "req-1" {
+		t.Errorf("reply request ID = %q, want req-1", id)
+	}
+
+	if RequestIDBus(context.Background(), eb) != eb {
+		t.Error("RequestIDBus() without a request ID should return the bus unchanged")
+	}
+}
+
+func TestRequestIDEventBus_ForwardsCapabilities(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	bus := NewRequestIDEventBus(eb, "req-2")
+
+	got := make(chan string, 4)
+	waitReady(t, eb.Consumer("rid.fanout").Handler(func(ctx FluxorContext, msg Message) error {
+		got <- msg.Header(HeaderRequestID)
+		return nil
+	}))
+
+	// Fan-out still counts deliveries and batches still carry the ID
+	if n, err := PublishFanOut(bus, "rid.fanout", "x", 2); err != nil || n != 1 {
+		t.Errorf("PublishFanOut() = %d, %v; want 1 delivery", n, err)
+	}
+	if err := bus.PublishBatch("rid.fanout", []interface{}{"a"}); err != nil {
+		t.Fatalf("PublishBatch() error = %v", err)
+	}
+	if _, err := bus.RequestStream("rid.fanout", "x", time.Second); err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if id := <-got; id != "req-2" {
+			t.Errorf("message %d request ID = %q, want req-2", i, id)
+		}
+	}
+
+	if err := bus.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() over the in-memory bus error = %v, want nil", err)
+	}
+	if _, ok := EventBus(bus).(GracefulCloser); !ok {
+		t.Error("RequestIDEventBus should implement GracefulCloser")
+	}
+}
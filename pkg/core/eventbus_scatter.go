@@ -0,0 +1,95 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// GatherFailure is one address of a ScatterGather that did not reply
+type GatherFailure struct {
+	Address string
+	Err     error
+}
+
+// GatherError is returned by ScatterGather when some addresses did not reply;
+// the replies of the others are still returned
+type GatherError struct {
+	Failures []GatherFailure
+}
+
+func (e *GatherError) Error() string {
+	first := e.Failures[0]
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("scatter-gather: %s failed: %v", first.Address, first.Err)
+	}
+	return fmt.Sprintf("scatter-gather: %d addresses failed (first: %s: %v)", len(e.Failures), first.Address, first.Err)
+}
+
+// Unwrap returns the per-address errors, so errors.Is/As see through the gather
+func (e *GatherError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// TimedOut returns the addresses that did not reply within the timeout
+func (e *GatherError) TimedOut() []string {
+	var addrs []string
+	for _, f := range e.Failures {
+		if errors.Is(f.Err, ErrTimeout) {
+			addrs = append(addrs, f.Address)
+		}
+	}
+	return addrs
+}
+
+// ScatterGather sends body to every address concurrently with eb.Request and
+// collects the replies, e.g. to query all workers for their status.
+// replies[i] is the reply from addresses[i], or nil if that address failed; the
+// failures (timeouts, no handlers, ...) are reported together as a *GatherError,
+// so callers can use the partial results. All requests share the one timeout
+// (<= 0 uses the bus's default request timeout).
+//
+// Each address gets its own request; nothing is deduplicated (see SingleFlight).
+func ScatterGather(eb EventBus, addresses []string, body interface{}, timeout time.Duration) ([]Message, error) {
+	// Fail-fast: validate inputs shared by every request
+	if eb == nil {
+		return nil, &EventBusError{Code: "INVALID_INPUT", Message: "eventBus cannot be nil"}
+	}
+	if err := ValidateBody(body); err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		if err := ValidateTimeout(timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	replies := make([]Message, len(addresses))
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			replies[i], errs[i] = eb.Request(address, body, timeout)
+		}(i, address)
+	}
+	wg.Wait()
+
+	var failures []GatherFailure
+	for i, err := range errs {
+		if err != nil {
+			replies[i] = nil
+			failures = append(failures, GatherFailure{Address: addresses[i], Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return replies, &GatherError{Failures: failures}
+	}
+	return replies, nil
+}
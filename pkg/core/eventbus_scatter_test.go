@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScatterGather(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	for _, name := range []string{"worker.1", "worker.2"} {
+		name := name
+		eb.Consumer(name + ".status").Handler(func(ctx FluxorContext, msg Message) error {
+			return msg.Reply(map[string]string{"worker": name})
+		})
+	}
+	eb.Consumer("worker.3.status").Handler(func(ctx FluxorContext, msg Message) error {
+		time.Sleep(500 * time.Millisecond)
+		return msg.Reply(map[string]string{"worker": "worker.3"})
+	})
+
+	addresses := []string{"worker.1.status", "worker.3.status", "worker.2.status", "worker.4.status"}
+	start := time.Now()
+	replies, err := ScatterGather(eb, addresses, map[string]string{}, 200*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 450*time.Millisecond {
+		t.Errorf("ScatterGather took %v; requests should run concurrently", elapsed)
+	}
+
+	var gatherErr *GatherError
+	if !errors.As(err, &gatherErr) {
+		t.Fatalf("ScatterGather() error = %v, want *GatherError", err)
+	}
+	if len(gatherErr.Failures) != 2 {
+		t.Fatalf("failures = %+v, want worker.3 and worker.4", gatherErr.Failures)
+	}
+	if timedOut := gatherErr.TimedOut(); len(timedOut) != 1 || timedOut[0] != "worker.3.status" {
+		t.Errorf("TimedOut() = %v, want [worker.3.status]", timedOut)
+	}
+
+	if len(replies) != len(addresses) || replies[1] != nil || replies[3] != nil {
+		t.Fatalf("replies = %v, want nil for failed addresses", replies)
+	}
+	for i, want := range map[int]string{0: "worker.1", 2: "worker.2"} {
+		var body map[string]string
+		if err := replies[i].DecodeBody(&body); err != nil || body["worker"] != want {
+			t.Errorf("replies[%d] = %v (%v), want %s", i, body, err, want)
+		}
+	}
+}
+
+func TestScatterGather_AllReply(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	eb.Consumer("ping").Handler(func(ctx FluxorContext, msg Message) error {
+		return msg.Reply("pong")
+	})
+
+	replies, err := ScatterGather(eb, []string{"ping", "ping"}, "hi", time.Second)
+	if err != nil || len(replies) != 2 || replies[0] == nil || replies[1] == nil {
+		t.Errorf("ScatterGather() = %v, %v", replies, err)
+	}
+
+	// Zero uses the bus's default request timeout
+	if replies, err := ScatterGather(eb, []string{"ping"}, "hi", 0); err != nil || replies[0] == nil {
+		t.Errorf("ScatterGather() with zero timeout = %v, %v", replies, err)
+	}
+	if _, err := ScatterGather(eb, []string{"ping"}, "hi", 10*time.Minute); err == nil {
+		t.Error("expected error for a timeout over the maximum")
+	}
+}
@@ -0,0 +1,291 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Headers carried by scheduled JetStream messages
+const (
+	scheduleAddressHeader = "Fluxor-Schedule-Address"
+	scheduleDueHeader     = "Fluxor-Schedule-Due" // Unix nanoseconds
+)
+
+// scheduleDelay validates a scheduled publish and returns its delay (never negative)
+func scheduleDelay(address string, body interface{}, at time.Time) (time.Duration, error) {
+	if err := ValidateAddress(address); err != nil {
+		return 0, err
+	}
+	if err := ValidateBody(body); err != nil {
+		return 0, err
+	}
+	if at.IsZero() {
+		return 0, &EventBusError{Code: "INVALID_SCHEDULE", Message: "due time cannot be zero"}
+	}
+	delay := time.Until(at)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, nil
+}
+
+func validateScheduleAfter(delay time.Duration) error {
+	if delay < 0 {
+		return &EventBusError{Code: "INVALID_SCHEDULE", Message: "delay cannot be negative"}
+	}
+	return nil
+}
+
+// scheduledTimers holds in-process timers for PublishAfter/PublishAt.
+// The zero value is ready to use.
+type scheduledTimers struct {
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	closed bool
+}
+
+// schedule runs fire after delay unless cancelled or stopped first
+func (s *scheduledTimers) schedule(delay time.Duration, fire func()) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return "", &EventBusError{Code: "EVENTBUS_CLOSED", Message: "event bus is closed"}
+	}
+	if s.timers == nil {
+		s.timers = make(map[string]*time.Timer)
+	}
+	id := generateUUID()
+	s.timers[id] = time.AfterFunc(delay, func() {
+		// Whoever removes the entry first wins, so a fire and a cancel never both succeed
+		if s.take(id) {
+			fire()
+		}
+	})
+	return id, nil
+}
+
+func (s *scheduledTimers) take(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.timers[id]; !ok {
+		return false
+	}
+	delete(s.timers, id)
+	return true
+}
+
+func (s *scheduledTimers) cancel(id string) bool {
+	s.mu.Lock()
+	t, ok := s.timers[id]
+	delete(s.timers, id)
+	s.mu.Unlock()
+	if ok {
+		t.Stop()
+	}
+	return ok
+}
+
+// stop drops all pending timers and rejects new ones
+func (s *scheduledTimers) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for id, t := range s.timers {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// PublishAfter implements EventBus.
+func (eb *eventBus) PublishAfter(address string, body interface{}, delay time.Duration) (string, error) {
+	if err := validateScheduleAfter(delay); err != nil {
+		return "", err
+	}
+	return eb.PublishAt(address, body, time.Now().Add(delay))
+}
+
+// PublishAt implements EventBus.
+func (eb *eventBus) PublishAt(address string, body interface{}, at time.Time) (string, error) {
+	delay, err := scheduleDelay(address, body, at)
+	if err != nil {
+		return "", err
+	}
+	data, err := eb.encodeBody(address, body)
+	if err != nil {
+		return "", err
+	}
+	return eb.scheduled.schedule(delay, func() {
+		if err := ignoreBackpressure(eb.Publish(address, data)); err != nil {
+			eb.logger.Error(fmt.Sprintf("scheduled publish to %s failed: %v", address, err))
+		}
+	})
+}
+
+// CancelScheduled implements EventBus.
+func (eb *eventBus) CancelScheduled(id string) bool {
+	return eb.scheduled.cancel(id)
+}
+
+// PublishAfter implements EventBus. Timers are in-process; use JetStream for durability.
+func (eb *clusterNATSEventBus) PublishAfter(address string, body interface{}, delay time.Duration) (string, error) {
+	if err := validateScheduleAfter(delay); err != nil {
+		return "", err
+	}
+	return eb.PublishAt(address, body, time.Now().Add(delay))
+}
+
+// PublishAt implements EventBus.
+func (eb *clusterNATSEventBus) PublishAt(address string, body interface{}, at time.Time) (string, error) {
+	delay, err := scheduleDelay(address, body, at)
+	if err != nil {
+		return "", err
+	}
+	data, err := eb.encodeMessage(address, body)
+	if err != nil {
+		return "", err
+	}
+	return eb.scheduled.schedule(delay, func() {
+		if err := ignoreBackpressure(eb.Publish(address, data)); err != nil {
+			eb.logger.Error(fmt.Sprintf("scheduled publish to %s failed: %v", address, err))
+		}
+	})
+}
+
+// CancelScheduled implements EventBus.
+func (eb *clusterNATSEventBus) CancelScheduled(id string) bool {
+	return eb.scheduled.cancel(id)
+}
+
+// PublishAfter implements EventBus.
+// The message is stored in the schedule stream and published by whichever node's
+// scheduler consumer receives it at the due time, so it survives restarts.
+func (eb *clusterJSEventBus) PublishAfter(address string, body interface{}, delay time.Duration) (string, error) {
+	if err := validateScheduleAfter(delay); err != nil {
+		return "", err
+	}
+	return eb.PublishAt(address, body, time.Now().Add(delay))
+}
+
+// PublishAt implements EventBus.
+func (eb *clusterJSEventBus) PublishAt(address string, body interface{}, at time.Time) (string, error) {
+	if _, err := scheduleDelay(address, body, at); err != nil {
+		return "", err
+	}
+	data, err := eb.encodeMessage(address, body)
+	if err != nil {
+		return "", err
+	}
+
+	id := generateUUID()
+	msg := &nats.Msg{
+		Subject: eb.subjectSched(id),
+		Data:    data,
+		Header:  nats.Header{},
+	}
+	msg.Header.Set(scheduleAddressHeader, address)
+	msg.Header.Set(scheduleDueHeader, strconv.FormatInt(at.UnixNano(), 10))
+	if rid := GetRequestID(eb.ctx); rid != "" {
+		msg.Header.Set("X-Request-ID", rid)
+	}
+	if _, err := eb.js.PublishMsg(msg); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// CancelScheduled implements EventBus by purging the message from the schedule stream.
+func (eb *clusterJSEventBus) CancelScheduled(id string) bool {
+	if id == "" {
+		return false
+	}
+	subject := eb.subjectSched(id)
+	if _, err := eb.js.GetLastMsg(eb.streamSched(), subject); err != nil {
+		return false // Unknown, cancelled, or already published (acked away)
+	}
+	if err := eb.js.PurgeStream(eb.streamSched(), &nats.StreamPurgeRequest{Subject: subject}); err != nil {
+		eb.logger.Error(fmt.Sprintf("cancel scheduled %s failed: %v", id, err))
+		return false
+	}
+	return true
+}
+
+// startScheduler binds this node to the shared scheduler consumer.
+// The consumer is created explicitly so closing one node does not delete it for the others.
+// Messages not yet due are NAKed with the remaining delay, so JetStream holds them.
+func (eb *clusterJSEventBus) startScheduler(maxPending int) error {
+	const durable = "scheduler"
+	stream := eb.streamSched()
+	if _, err := eb.js.ConsumerInfo(stream, durable); err != nil {
+		if _, err := eb.js.AddConsumer(stream, &nats.ConsumerConfig{
+			Durable:        durable,
+			DeliverSubject: eb.prefix + ".schedule.deliver",
+			DeliverGroup:   durable,
+			AckPolicy:      nats.AckExplicitPolicy,
+			AckWait:        eb.ackWait,
+			MaxAckPending:  maxPending,
+		}); err != nil {
+			return err
+		}
+	}
+
+	sub, err := eb.js.QueueSubscribe("", durable, eb.onScheduled, nats.Bind(stream, durable), nats.ManualAck())
+	if err != nil {
+		return err
+	}
+	eb.mu.Lock()
+	eb.schedSub = sub
+	eb.mu.Unlock()
+	return nil
+}
+
+func (eb *clusterJSEventBus) onScheduled(m *nats.Msg) {
+	address := m.Header.Get(scheduleAddressHeader)
+	due, err := strconv.ParseInt(m.Header.Get(scheduleDueHeader), 10, 64)
+	if err != nil || ValidateAddress(address) != nil {
+		eb.logger.Error(fmt.Sprintf("dropping malformed scheduled message %s", m.Subject))
+		if err := m.Term(); err != nil {
+			// Best-effort; ignore on error.
+		}
+		return
+	}
+
+	if remaining := time.Until(time.Unix(0, due)); remaining > 0 {
+		if err := m.NakWithDelay(remaining); err != nil {
+			// Redelivered after AckWait; ignore on error.
+		}
+		return
+	}
+
+	msg := &nats.Msg{
+		Subject: eb.subjectPub(address),
+		Data:    m.Data,
+		Header:  nats.Header{},
+	}
+	if rid := m.Header.Get("X-Request-ID"); rid != "" {
+		msg.Header.Set("X-Request-ID", rid)
+	}
+	// The schedule ID dedupes a re-publish if the ack below is lost (within the stream's duplicate window)
+	id := m.Subject[len(eb.prefix+".sched."):]
+	if _, err := eb.js.PublishMsg(msg, nats.MsgId("sched-"+id)); err != nil {
+		eb.logger.Error(fmt.Sprintf("scheduled publish to %s failed: %v", address, err))
+		if err := m.Nak(); err != nil {
+			// Redelivered after AckWait; ignore on error.
+		}
+		return
+	}
+	if err := m.Ack(); err != nil {
+		// Redelivery is deduped by MsgId; ignore on error.
+	}
+}
+
+func (eb *clusterJSEventBus) streamSched() string {
+	return sanitizeStreamName(eb.prefix) + "_SCHED"
+}
+
+func (eb *clusterJSEventBus) subjectSched(id string) string {
+	return eb.prefix + ".sched." + id
+}
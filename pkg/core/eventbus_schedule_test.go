@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_PublishAfter(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	got := make(chan string, 2)
+	eb.Consumer("reminders").Handler(func(ctx FluxorContext, msg Message) error {
+		var s string
+		_ = msg.DecodeBody(&s)
+		got <- s
+		return nil
+	})
+
+	start := time.Now()
+	if _, err := eb.PublishAfter("reminders", "later", 50*time.Millisecond); err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+	cancelled, err := eb.PublishAfter("reminders", "cancelled", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+	if !eb.CancelScheduled(cancelled) {
+		t.Fatalf("CancelScheduled() = false, want true for a pending message")
+	}
+	if eb.CancelScheduled(cancelled) {
+		t.Errorf("CancelScheduled() twice = true, want false")
+	}
+
+	select {
+	case s := <-got:
+		if s != "later" {
+			t.Errorf("received %q, want %q", s, "later")
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("delivered after %v, want at least 50ms", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scheduled message not delivered")
+	}
+
+	select {
+	case s := <-got:
+		t.Errorf("received cancelled message %q", s)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEventBus_PublishAt_PastTimePublishesNow(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var n int64
+	eb.Consumer("past").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&n, 1)
+		return nil
+	})
+
+	id, err := eb.PublishAt("past", "x", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&n) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt64(&n) != 1 {
+		t.Fatalf("delivered %d times, want 1", atomic.LoadInt64(&n))
+	}
+	if eb.CancelScheduled(id) {
+		t.Errorf("CancelScheduled() after delivery = true, want false")
+	}
+}
+
+func TestEventBus_PublishAfter_FailFast(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	if _, err := eb.PublishAfter("", "x", time.Second); err == nil {
+		t.Error("expected error for empty address")
+	}
+	if _, err := eb.PublishAfter("a", nil, time.Second); err == nil {
+		t.Error("expected error for nil body")
+	}
+	if _, err := eb.PublishAfter("a", "x", -time.Second); err == nil {
+		t.Error("expected error for negative delay")
+	}
+	if _, err := eb.PublishAt("a", "x", time.Time{}); err == nil {
+		t.Error("expected error for zero time")
+	}
+	if eb.CancelScheduled("unknown") {
+		t.Error("CancelScheduled(unknown) = true, want false")
+	}
+}
+
+func TestEventBus_CloseDropsScheduled(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	eb := gocmd.EventBus()
+
+	id, err := eb.PublishAfter("later", "x", time.Hour)
+	if err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+	_ = gocmd.Close()
+
+	if eb.CancelScheduled(id) {
+		t.Error("CancelScheduled() after Close = true, want false")
+	}
+	if _, err := eb.PublishAfter("later", "x", time.Second); err == nil {
+		t.Error("expected error scheduling on a closed bus")
+	}
+}
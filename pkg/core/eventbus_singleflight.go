@@ -0,0 +1,73 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// SingleFlight dedupes concurrent EventBus requests by key.
+// While a request for a key is in flight, later callers with the same key wait for
+// it and share its reply or error instead of sending their own (cache-stampede guard).
+//
+// The reply Message is shared between callers; treat it as read-only.
+type SingleFlight struct {
+	eventBus EventBus
+	mu       sync.Mutex
+	calls    map[string]*flight
+}
+
+var errFlightAborted = &EventBusError{Code: "SINGLE_FLIGHT_ABORTED", Message: "in-flight request aborted"}
+
+type flight struct {
+	done  chan struct{}
+	reply Message
+	err   error
+	dups  int
+}
+
+// NewSingleFlight creates a SingleFlight over the given bus
+func NewSingleFlight(eventBus EventBus) *SingleFlight {
+	if eventBus == nil {
+		panic("eventBus cannot be nil")
+	}
+	return &SingleFlight{
+		eventBus: eventBus,
+		calls:    make(map[string]*flight),
+	}
+}
+
+// Request sends eventBus.Request(address, body, timeout) unless a request with the
+// same key is already in flight, in which case it waits for that one's result.
+// shared reports whether the result was delivered to more than one caller.
+func (s *SingleFlight) Request(key, address string, body interface{}, timeout time.Duration) (reply Message, shared bool, err error) {
+	s.mu.Lock()
+	if f, ok := s.calls[key]; ok {
+		f.dups++
+		s.mu.Unlock()
+		<-f.done
+		return f.reply, true, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	s.calls[key] = f
+	s.mu.Unlock()
+
+	// Always release waiters, even if Request panics
+	defer func() {
+		s.mu.Lock()
+		delete(s.calls, key)
+		shared = f.dups > 0
+		s.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.err = errFlightAborted // Seen by waiters only if Request panics
+	f.reply, f.err = s.eventBus.Request(address, body, timeout)
+	return f.reply, false, f.err
+}
+
+// InFlight returns the number of keys with a request in flight
+func (s *SingleFlight) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.calls)
+}
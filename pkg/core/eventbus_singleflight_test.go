@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlight_DedupesConcurrentRequests(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	var calls int64
+	release := make(chan struct{})
+	eb.Consumer("product.get").Handler(func(ctx FluxorContext, msg Message) error {
+		atomic.AddInt64(&calls, 1)
+		<-release
+		return msg.Reply(map[string]interface{}{"id": "p1"})
+	})
+
+	sf := NewSingleFlight(eb)
+	const callers = 10
+	var wg sync.WaitGroup
+	var sharedCount int64
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reply, shared, err := sf.Request("product:p1", "product.get", "p1", time.Second)
+			if err != nil || reply == nil {
+				errs <- err
+				return
+			}
+			if shared {
+				atomic.AddInt64(&sharedCount, 1)
+			}
+		}()
+	}
+
+	// Let all callers join the flight before the backend answers
+	deadline := time.Now().Add(time.Second)
+	for sf.InFlight() != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Request() error = %v", err)
+	}
+	if got := atomic.LoadInt64(&calls); got != 1 {
+		t.Errorf("backend calls = %d, want 1", got)
+	}
+	if sharedCount != callers {
+		t.Errorf("shared = %d callers, want %d", sharedCount, callers)
+	}
+	if sf.InFlight() != 0 {
+		t.Errorf("InFlight() = %d after completion, want 0", sf.InFlight())
+	}
+}
+
+func TestSingleFlight_PropagatesErrorToWaiters(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	sf := NewSingleFlight(eb)
+
+	// No consumer: the request fails and every caller sees the error
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := sf.Request("k", "missing.address", "x", time.Second); err == nil {
+				t.Error("Request() should propagate the error")
+			}
+		}()
+	}
+	wg.Wait()
+}
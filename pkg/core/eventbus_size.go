@@ -0,0 +1,53 @@
+package core
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/nats-io/nats.go"
+)
+
+// DefaultMaxMessageSize is the default limit on an encoded message body in bytes,
+// the NATS server's default max_payload, so the same messages fit every bus
+const DefaultMaxMessageSize = 1 << 20
+
+// checkMessageSize rejects data over max bytes (0: no limit) before it is enqueued,
+// counting the rejection in EventBusStats.Oversized
+func checkMessageSize(address string, data []byte, max int, counters *busCounters) error {
+	if max <= 0 || len(data) <= max {
+		return nil
+	}
+	atomic.AddInt64(&counters.oversized, 1)
+	return &EventBusError{
+		Code:    "MESSAGE_TOO_LARGE",
+		Message: fmt.Sprintf("message to %s is %d bytes, over the %d byte limit", address, len(data), max),
+	}
+}
+
+// clusterMaxMessageSize returns the limit for a cluster bus: configured, capped at
+// the server's max_payload (which also applies when configured is 0)
+func clusterMaxMessageSize(configured int, nc *nats.Conn) int {
+	serverMax := int(nc.MaxPayload())
+	if configured == 0 || (serverMax > 0 && configured > serverMax) {
+		return serverMax
+	}
+	return configured
+}
+
+// encodeMessage encodes body and checks it against the bus's size limit
+func (eb *clusterNATSEventBus) encodeMessage(address string, body interface{}) ([]byte, error) {
+	data, err := encodeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	return data, checkMessageSize(address, data, eb.maxMessageSize, &eb.counters)
+}
+
+// encodeMessage encodes body and checks it against the bus's size limit
+func (eb *clusterJSEventBus) encodeMessage(address string, body interface{}) ([]byte, error) {
+	data, err := encodeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	return data, checkMessageSize(address, data, eb.maxMessageSize, &eb.counters)
+}
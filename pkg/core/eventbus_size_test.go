@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBus_MaxMessageSize(t *testing.T) {
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{MaxMessageSize: 64})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	received := make(chan struct{}, 4)
+	c := eb.Consumer("size.test").Handler(func(ctx FluxorContext, msg Message) error {
+		received <- struct{}{}
+		return msg.Reply("ok")
+	})
+	waitReady(t, c)
+
+	big := strings.Repeat("x", 100)
+	wantTooLarge := func(op string, err error) {
+		t.Helper()
+		var busErr *EventBusError
+		if !errors.As(err, &busErr) || busErr.Code != "MESSAGE_TOO_LARGE" {
+			t.Errorf("%s() error = %v, want MESSAGE_TOO_LARGE", op, err)
+		}
+	}
+	wantTooLarge("Publish", eb.Publish("size.test", big))
+	wantTooLarge("Send", eb.Send("size.test", []byte(big)))
+	_, err = eb.Request("size.test", big, time.Second)
+	wantTooLarge("Request", err)
+	_, err = eb.PublishAfter("size.test", big, time.Millisecond)
+	wantTooLarge("PublishAfter", err)
+
+	// Oversized messages never reach the consumer; small ones still do
+	if err := eb.Send("size.test", "small"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-received
+	select {
+	case <-received:
+		t.Error("an oversized message was delivered")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := eb.Stats().Oversized; got != 4 {
+		t.Errorf("Stats().Oversized = %d, want 4", got)
+	}
+
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{MaxMessageSize: -1}); err == nil {
+		t.Error("NewGoCMDWithOptions() should reject a negative MaxMessageSize")
+	}
+}
+
+func TestEventBus_DefaultMaxMessageSize(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	err := gocmd.EventBus().Publish("size.default", make([]byte, DefaultMaxMessageSize+1))
+	if err == nil {
+		t.Error("Publish() over DefaultMaxMessageSize should fail")
+	}
+	if err := gocmd.EventBus().Publish("size.default", make([]byte, DefaultMaxMessageSize)); err != nil {
+		t.Errorf("Publish() at DefaultMaxMessageSize error = %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// EventBusStats is a point-in-time snapshot of event bus activity
+// Intended for health checks, admin endpoints and tests; use Prometheus for time series
+type EventBusStats struct {
+	// Addresses holds per-address consumer state (local bus only; nil for cluster buses)
+	Addresses map[string]AddressStats `json:"addresses,omitempty"`
+
+	Published int64 `json:"published"` // Accepted Publish calls
+	Sent      int64 `json:"sent"`      // Accepted Send calls (including replies)
+	Requested int64 `json:"requested"` // Accepted Request calls
+	Dropped   int64 `json:"dropped"`   // Deliveries lost to full mailboxes
+	Oversized int64 `json:"oversized"` // Messages rejected for exceeding the size limit
+
+	// HandlerFailures counts failed handler calls per address since the bus was created
+	// (only addresses that have failed); exported by prometheus.EventBusCollector
+	HandlerFailures map[string]HandlerFailureStats `json:"handler_failures,omitempty"`
+
+	// Filtered counts messages dropped by consumer filters (Consumer.Filter) per
+	// address (only addresses that have filtered one)
+	Filtered map[string]int64 `json:"filtered,omitempty"`
+
+	// Rejected counts messages a cluster consumer's executor refused because it
+	// was saturated, per address (only addresses that have rejected one)
+	Rejected map[string]int64 `json:"rejected,omitempty"`
+}
+
+// HandlerFailureStats counts the failed handler calls for one address
+type HandlerFailureStats struct {
+	Errors int64 `json:"errors"` // Handlers that returned an error
+	Panics int64 `json:"panics"` // Handlers that panicked (recovered by the bus)
+}
+
+// AddressStats describes the consumers registered for one address
+type AddressStats struct {
+	Consumers       int   `json:"consumers"`        // Registered consumers
+	MailboxDepth    int   `json:"mailbox_depth"`    // Messages queued across all consumer mailboxes
+	MailboxCapacity int   `json:"mailbox_capacity"` // Total mailbox capacity across consumers
+	Dropped         int64 `json:"dropped"`          // Deliveries dropped for currently registered consumers
+}
+
+// busCounters holds the message totals shared by all EventBus implementations
+// Fields are updated atomically
+type busCounters struct {
+	published int64
+	sent      int64
+	requested int64
+	dropped   int64
+	oversized int64
+
+	failures sync.Map // address -> *handlerFailures
+	filtered sync.Map // address -> *int64
+	rejected sync.Map // address -> *int64
+}
+
+type handlerFailures struct {
+	errors int64
+	panics int64
+}
+
+// handlerFailed counts a failed handler call for address
+func (c *busCounters) handlerFailed(address string, panicked bool) {
+	f, ok := c.failures.Load(address)
+	if !ok {
+		f, _ = c.failures.LoadOrStore(address, &handlerFailures{})
+	}
+	if panicked {
+		atomic.AddInt64(&f.(*handlerFailures).panics, 1)
+	} else {
+		atomic.AddInt64(&f.(*handlerFailures).errors, 1)
+	}
+}
+
+// messageFiltered counts a message a consumer filter dropped for address
+func (c *busCounters) messageFiltered(address string) {
+	n, ok := c.filtered.Load(address)
+	if !ok {
+		n, _ = c.filtered.LoadOrStore(address, new(int64))
+	}
+	atomic.AddInt64(n.(*int64), 1)
+}
+
+// snapshot returns the totals as an EventBusStats without per-address data
+func (c *busCounters) snapshot() EventBusStats {
+	stats := EventBusStats{
+		Published: atomic.LoadInt64(&c.published),
+		Sent:      atomic.LoadInt64(&c.sent),
+		Requested: atomic.LoadInt64(&c.requested),
+		Dropped:   atomic.LoadInt64(&c.dropped),
+		Oversized: atomic.LoadInt64(&c.oversized),
+	}
+	c.failures.Range(func(address, f interface{}) bool {
+		if stats.HandlerFailures == nil {
+			stats.HandlerFailures = make(map[string]HandlerFailureStats)
+		}
+		stats.HandlerFailures[address.(string)] = HandlerFailureStats{
+			Errors: atomic.LoadInt64(&f.(*handlerFailures).errors),
+			Panics: atomic.LoadInt64(&f.(*handlerFailures).panics),
+		}
+		return true
+	})
+	c.filtered.Range(func(address, n interface{}) bool {
+		if stats.Filtered == nil {
+			stats.Filtered = make(map[string]int64)
+		}
+		stats.Filtered[address.(string)] = atomic.LoadInt64(n.(*int64))
+		return true
+	})
+	c.rejected.Range(func(address, n interface{}) bool {
+		if stats.Rejected == nil {
+			stats.Rejected = make(map[string]int64)
+		}
+		stats.Rejected[address.(string)] = atomic.LoadInt64(n.(*int64))
+		return true
+	})
+	return stats
+}
+
+// Stats returns a snapshot of message totals and per-address consumer state
+func (eb *eventBus) Stats() EventBusStats {
+	stats := eb.counters.snapshot()
+
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	stats.Addresses = make(map[string]AddressStats, len(eb.consumers))
+	for address, consumers := range eb.consumers {
+		if len(consumers) == 0 {
+			continue
+		}
+		var as AddressStats
+		for _, c := range consumers {
+			as.Consumers++
+			as.MailboxDepth += c.mailbox.Size()
+			as.MailboxCapacity += c.mailbox.Capacity()
+			as.Dropped += atomic.LoadInt64(&c.dropped)
+		}
+		stats.Addresses[address] = as
+	}
+	return stats
+}
+
+// Stats returns message totals; per-address state lives in the NATS server
+func (eb *clusterNATSEventBus) Stats() EventBusStats {
+	return eb.counters.snapshot()
+}
+
+// Stats returns message totals; per-address state lives in the NATS server
+func (eb *clusterJSEventBus) Stats() EventBusStats {
+	return eb.counters.snapshot()
+}
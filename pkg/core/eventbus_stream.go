@@ -0,0 +1,273 @@
+package core
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+	"github.com/nats-io/nats.go"
+)
+
+// StreamHeader marks the messages of a streamed reply (see EventBus.RequestStream).
+// Replies without it are plain replies and end the stream.
+const (
+	StreamHeader = "Fluxor-Stream"
+	StreamChunk  = "chunk" // One chunk, delivered to the requester
+	StreamEnd    = "end"   // End of stream, not delivered
+)
+
+// replyStreamBuffer is the number of chunks buffered between the bus and the requester
+const replyStreamBuffer = 64
+
+// replyStream forwards the replies of one RequestStream to its channel
+type replyStream struct {
+	in      chan Message
+	out     chan Message
+	done    chan struct{}
+	timeout time.Duration
+}
+
+func newReplyStream(timeout time.Duration) *replyStream {
+	return &replyStream{
+		in:      make(chan Message, replyStreamBuffer),
+		out:     make(chan Message, replyStreamBuffer),
+		done:    make(chan struct{}),
+		timeout: timeout,
+	}
+}
+
+// deliver queues a reply; it blocks while the buffer is full, so a slow requester
+// slows the responder down instead of losing chunks
+func (s *replyStream) deliver(msg Message) {
+	select {
+	case s.in <- msg:
+	case <-s.done:
+	}
+}
+
+// run forwards replies until the stream ends, a plain reply arrives, or no reply
+// arrives within the timeout, then calls cleanup and closes the channel
+func (s *replyStream) run(cleanup func()) {
+	defer close(s.out)
+	defer cleanup()
+	defer close(s.done)
+
+	idle := time.NewTimer(s.timeout)
+	defer idle.Stop()
+	for {
+		select {
+		case msg := <-s.in:
+			switch msg.Headers()[StreamHeader] {
+			case StreamEnd:
+				return
+			case StreamChunk:
+				if !s.forward(msg) {
+					return
+				}
+			default:
+				s.forward(msg)
+				return
+			}
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(s.timeout)
+		case <-idle.C:
+			s.forward(newMessage([]byte(`{"failureCode":504,"code":"TIMEOUT","message":"stream timeout"}`), nil, "", nil))
+			return
+		}
+	}
+}
+
+// forward hands msg to the requester; false if it stopped reading
+func (s *replyStream) forward(msg Message) bool {
+	wait := time.NewTimer(s.timeout)
+	defer wait.Stop()
+	select {
+	case s.out <- msg:
+		return true
+	case <-wait.C:
+		return false
+	}
+}
+
+// RequestStream implements EventBus.
+func (eb *eventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	return eb.requestStreamWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *eventBus) requestStreamWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (<-chan Message, error) {
+	if err := ValidateAddress(address); err != nil {
+		return nil, err
+	}
+	if err := eb.accepting(address); err != nil {
+		return nil, err
+	}
+	if err := ValidateBody(body); err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
+	if err := ValidateTimeout(timeout); err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := eb.encodeBody(address, body)
+	if err != nil {
+		return nil, fmt.Errorf("encode body failed: %w", err)
+	}
+
+	eb.mu.RLock()
+	consumers := eb.consumers[address]
+	eb.mu.RUnlock()
+	if len(consumers) == 0 {
+		return nil, &EventBusError{Code: "NO_HANDLERS", Message: "No handlers registered for address: " + address}
+	}
+
+	replyAddress := generateReplyAddress()
+	stream := newReplyStream(timeout)
+	replyConsumer := eb.Consumer(replyAddress)
+	replyConsumer.Handler(func(ctx FluxorContext, msg Message) error {
+		stream.deliver(msg)
+		return nil
+	})
+
+	headers := map[string]string{"replyAddress": replyAddress}
+	if requestID := GetRequestID(eb.ctx); requestID != "" {
+		headers["X-Request-ID"] = requestID
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+	msg := newMessage(jsonBody, headers, replyAddress, eb)
+	eb.observe(address, msg)
+
+	consumer := eb.sendTarget(address, consumers)
+	atomic.AddInt64(&eb.counters.requested, 1)
+	if err := consumer.mailbox.Send(msg); err != nil {
+		_ = replyConsumer.Unregister()
+		if err == concurrency.ErrMailboxFull {
+			consumer.recordDrop()
+			return nil, ErrTimeout
+		}
+		if err == concurrency.ErrMailboxClosed {
+			return nil, eb.ctx.Err()
+		}
+		return nil, err
+	}
+
+	go stream.run(func() { _ = replyConsumer.Unregister() })
+	return stream.out, nil
+}
+
+// RequestStream implements EventBus.
+func (eb *clusterNATSEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	return eb.requestStreamWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *clusterNATSEventBus) requestStreamWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (<-chan Message, error) {
+	if err := ValidateAddress(address); err != nil {
+		return nil, err
+	}
+	if err := ValidateBody(body); err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
+	if err := ValidateTimeout(timeout); err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&eb.counters.requested, 1)
+	return natsRequestStream(eb, eb.subjectReq(address), body, timeout, extra)
+}
+
+// RequestStream implements EventBus. Like Request, it uses core NATS rather than JetStream.
+func (eb *clusterJSEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	return eb.requestStreamWithHeaders(address, body, timeout, nil)
+}
+
+func (eb *clusterJSEventBus) requestStreamWithHeaders(address string, body interface{}, timeout time.Duration, extra map[string]string) (<-chan Message, error) {
+	if err := ValidateAddress(address); err != nil {
+		return nil, err
+	}
+	if err := ValidateBody(body); err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = eb.requestTimeout
+	}
+	if err := ValidateTimeout(timeout); err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&eb.counters.requested, 1)
+	return natsRequestStream(eb.coreBus(), eb.subjectReq(address), body, timeout, extra)
+}
+
+// natsRequestStream publishes a request whose replies go to a fresh inbox subscription
+func natsRequestStream(eb *clusterNATSEventBus, subject string, body interface{}, timeout time.Duration, extra map[string]string) (<-chan Message, error) {
+	data, err := eb.encodeMessage(subject, body)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newReplyStream(timeout)
+	inbox := nats.NewInbox()
+	sub, err := eb.nc.Subscribe(inbox, func(nm *nats.Msg) {
+		stream.deliver(&clusterNATSMessage{
+			body:    nm.Data,
+			headers: natsHeaders(nm.Header),
+			eb:      eb,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &nats.Msg{
+		Subject: subject,
+		Reply:   inbox,
+		Data:    data,
+		Header:  nats.Header{},
+	}
+	if rid := GetRequestID(eb.ctx); rid != "" {
+		msg.Header.Set("X-Request-ID", rid)
+	}
+	for k, v := range extra {
+		msg.Header.Set(k, v)
+	}
+	if err := eb.nc.PublishMsg(msg); err != nil {
+		_ = sub.Unsubscribe()
+		return nil, err
+	}
+
+	go stream.run(func() { _ = sub.Unsubscribe() })
+	return stream.out, nil
+}
+
+// natsHeaders flattens NATS headers to their first values
+func natsHeaders(header nats.Header) map[string]string {
+	h := make(map[string]string, len(header))
+	for k, v := range header {
+		if len(v) > 0 {
+			h[k] = v[0]
+		}
+	}
+	return h
+}
+
+// RequestStream implements EventBus.
+func (t *TracingEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	entry, headers := t.begin("request", address)
+	var replies <-chan Message
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		replies, err = hs.requestStreamWithHeaders(address, body, timeout, headers)
+	} else {
+		replies, err = t.EventBus.RequestStream(address, body, timeout)
+	}
+	t.finish(entry, err)
+	return replies, err
+}
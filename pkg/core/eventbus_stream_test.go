@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// collectStream reads a stream until it closes
+func collectStream(t *testing.T, replies <-chan Message) []Message {
+	t.Helper()
+	var got []Message
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case msg, ok := <-replies:
+			if !ok {
+				return got
+			}
+			got = append(got, msg)
+		case <-deadline:
+			t.Fatalf("stream did not close; got %d messages", len(got))
+		}
+	}
+}
+
+func registerCountdown(eb EventBus) Consumer {
+	return eb.Consumer("jobs.countdown").Handler(func(ctx FluxorContext, msg Message) error {
+		var n int
+		if err := msg.DecodeBody(&n); err != nil {
+			return msg.Fail(400, "expected a number")
+		}
+		for i := n; i > 0; i-- {
+			if err := msg.Stream(i); err != nil {
+				return err
+			}
+		}
+		return msg.EndStream()
+	})
+}
+
+func assertCountdown(t *testing.T, replies <-chan Message) {
+	t.Helper()
+	got := collectStream(t, replies)
+	if len(got) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(got))
+	}
+	for i, msg := range got {
+		var n int
+		if err := msg.DecodeBody(&n); err != nil || n != 3-i {
+			t.Errorf("chunk %d = %d, %v; want %d", i, n, err, 3-i)
+		}
+		if msg.Headers()[StreamHeader] != StreamChunk {
+			t.Errorf("chunk %d headers = %v, want %s", i, msg.Headers(), StreamChunk)
+		}
+	}
+}
+
+func TestEventBus_RequestStream(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	registerCountdown(eb)
+
+	replies, err := eb.RequestStream("jobs.countdown", 3, time.Second)
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	assertCountdown(t, replies)
+
+	// A plain reply ends the stream
+	replies, err = eb.RequestStream("jobs.countdown", "x", time.Second)
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	got := collectStream(t, replies)
+	var failure map[string]interface{}
+	if len(got) != 1 || got[0].DecodeBody(&failure) != nil || failure["failureCode"] != float64(400) {
+		t.Errorf("got %d messages (%v), want one failure with code 400", len(got), failure)
+	}
+
+	if _, err := eb.RequestStream("jobs.missing", 1, time.Second); err == nil {
+		t.Error("RequestStream() without handlers should fail")
+	}
+}
+
+func TestEventBus_RequestStreamTimeout(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// Streams one chunk and never ends
+	eb.Consumer("jobs.stuck").Handler(func(ctx FluxorContext, msg Message) error {
+		return msg.Stream("started")
+	})
+
+	replies, err := eb.RequestStream("jobs.stuck", 1, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	got := collectStream(t, replies)
+	if len(got) != 2 {
+		t.Fatalf("got %d messages, want a chunk and a timeout failure", len(got))
+	}
+	var failure map[string]interface{}
+	if err := got[1].DecodeBody(&failure); err != nil || failure["failureCode"] != float64(504) {
+		t.Errorf("last message = %v, %v; want failure code 504", failure, err)
+	}
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// tapBuffer is how many messages a tap queues for a slow observer before dropping
+const tapBuffer = 1000
+
+// ErrTapReadOnly is returned when an observer replies to a tapped message
+var ErrTapReadOnly = &EventBusError{Code: "TAP_READ_ONLY", Message: "Tapped messages cannot be replied to"}
+
+// tappers is implemented by buses that can observe the messages sent to an address
+type tappers interface {
+	tap(address string, observer func(Message)) (func(), error)
+}
+
+// Tap calls observer with a copy of every message published, sent or requested
+// to address, without affecting delivery, e.g. to see whether an event that
+// "never got processed" was ever sent:
+//
+//	untap, err := core.Tap(eb, "orders.created", func(msg core.Message) {
+//		log.Printf("orders.created %s: %s", msg.Header(core.HeaderRequestID), msg.Body())
+//	})
+//	defer untap()
+//
+// Messages are seen even when the address has no handler (the Send then fails
+// with ErrNoHandlers), and a tap is not a consumer: it doesn't count for Send,
+// Request or WaitForConsumer. The copy can't be replied to (ErrTapReadOnly).
+// The observer runs on its own goroutine, in message order; when it falls more
+// than 1000 messages behind, the rest are dropped rather than slowing the bus.
+//
+// Only the in-memory bus and its wrappers support taps; cluster and hybrid
+// buses return an errors.ErrUnsupported error.
+// Fail-fast: panics on a nil eventBus or observer
+func Tap(eb EventBus, address string, observer func(Message)) (untap func(), err error) {
+	failfast.NotNil(eb, "eventBus")
+	failfast.NotNil(observer, "observer")
+	if err := ValidateAddress(address); err != nil {
+		return nil, err
+	}
+	if t, ok := eb.(tappers); ok {
+		return t.tap(address, observer)
+	}
+	return nil, fmt.Errorf("tap %s: %w", address, errors.ErrUnsupported)
+}
+
+// busTap is one observer registered with Tap
+type busTap struct {
+	mailbox concurrency.Mailbox
+	dropped int64 // Atomic: messages the observer was too slow for
+}
+
+func (eb *eventBus) tap(address string, observer func(Message)) (func(), error) {
+	t := &busTap{mailbox: concurrency.NewBoundedMailbox(tapBuffer)}
+	eb.mu.Lock()
+	if eb.taps == nil {
+		eb.taps = make(map[string][]*busTap)
+	}
+	eb.taps[address] = append(eb.taps[address], t)
+	atomic.AddInt32(&eb.tapCount, 1)
+	eb.mu.Unlock()
+
+	go func() {
+		for {
+			item, err := t.mailbox.Receive(eb.ctx)
+			if err != nil {
+				return // Untapped or bus closed
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						eb.logger.Error(fmt.Sprintf("tap observer panic on %s (isolated): %v", address, r))
+					}
+				}()
+				observer(item.(Message))
+			}()
+		}
+	}()
+
+	var once int32
+	untap := func() {
+		if !atomic.CompareAndSwapInt32(&once, 0, 1) {
+			return
+		}
+		eb.mu.Lock()
+		taps := eb.taps[address]
+		for i, other := range taps {
+			if other == t {
+				eb.taps[address] = append(taps[:i:i], taps[i+1:]...)
+				break
+			}
+		}
+		if len(eb.taps[address]) == 0 {
+			delete(eb.taps, address)
+		}
+		atomic.AddInt32(&eb.tapCount, -1)
+		eb.mu.Unlock()
+		t.mailbox.Close()
+		if n := atomic.LoadInt64(&t.dropped); n > 0 {
+			eb.logger.Info(fmt.Sprintf("tap on %s dropped %d messages", address, n))
+		}
+	}
+	return untap, nil
+}
+
+// observe hands a read-only copy of msg to address's taps
+func (eb *eventBus) observe(address string, msg Message) {
+	if atomic.LoadInt32(&eb.tapCount) == 0 {
+		return
+	}
+	eb.mu.RLock()
+	taps := eb.taps[address]
+	eb.mu.RUnlock()
+	if len(taps) == 0 {
+		return
+	}
+
+	body := msg.Body()
+	if data, ok := body.([]byte); ok {
+		body = append([]byte(nil), data...) // The observer can't change what consumers get
+	}
+	copied := tapMessage{newMessage(body, msg.Headers(), msg.ReplyAddress(), nil)}
+	for _, t := range taps {
+		if err := t.mailbox.Send(copied); err != nil {
+			atomic.AddInt64(&t.dropped, 1)
+		}
+	}
+}
+
+// tapMessage is the copy a tap observer gets; replying is refused so taps
+// stay invisible to requesters
+type tapMessage struct {
+	Message
+}
+
+func (tapMessage) Reply(body interface{}) error               { return ErrTapReadOnly }
+func (tapMessage) Fail(failureCode int, message string) error { return ErrTapReadOnly }
+func (tapMessage) Stream(chunk interface{}) error             { return ErrTapReadOnly }
+func (tapMessage) EndStream() error                           { return ErrTapReadOnly }
+
+func (t *TenantEventBus) tap(address string, observer func(Message)) (func(), error) {
+	return Tap(t.EventBus, t.address(address), observer)
+}
+
+func (t *TracingEventBus) tap(address string, observer func(Message)) (func(), error) {
+	return Tap(t.EventBus, address, observer)
+}
+
+func (r *RequestIDEventBus) tap(address string, observer func(Message)) (func(), error) {
+	return Tap(r.EventBus, address, observer)
+}
+
+func (b *isolatedEventBus) tap(address string, observer func(Message)) (func(), error) {
+	return Tap(b.EventBus, address, observer)
+}
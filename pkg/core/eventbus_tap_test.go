@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTap(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	tapped := make(chan Message, 10)
+	untap, err := Tap(eb, "tap.orders", func(msg Message) { tapped <- msg })
+	if err != nil {
+		t.Fatalf("Tap() error = %v", err)
+	}
+	next := func() Message {
+		t.Helper()
+		select {
+		case msg := <-tapped:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("tap saw nothing")
+			return nil
+		}
+	}
+
+	// A tap is not a consumer: the send still fails, but the tap saw it
+	if err := eb.Send("tap.orders", "lost"); !errors.Is(err, ErrNoHandlers) {
+		t.Errorf("Send() with only a tap error = %v, want ErrNoHandlers", err)
+	}
+	var body string
+	if _ = next().DecodeBody(&body); body != "lost" {
+		t.Errorf("tapped body = %q, want lost", body)
+	}
+
+	received := make(chan string, 10)
+	c := eb.Consumer("tap.orders").Handler(func(ctx FluxorContext, msg Message) error {
+		var s string
+		_ = msg.DecodeBody(&s)
+		received <- s
+		if msg.ReplyAddress() != "" {
+			return msg.Reply("handled")
+		}
+		return nil
+	})
+	waitReady(t, c)
+
+	if err := eb.PublishWithHeaders("tap.orders", "published", map[string]string{"X-Source": "test"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if msg := next(); msg.Header("X-Source") != "test" {
+		t.Errorf("tapped headers = %v, want X-Source", msg.Headers())
+	}
+	reply, err := eb.Request("tap.orders", "requested", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var got string
+	if _ = reply.DecodeBody(&got); got != "handled" {
+		t.Errorf("reply = %q, want the consumer's, not the tap's", got)
+	}
+	msg := next()
+	if msg.ReplyAddress() == "" {
+		t.Error("tapped request has no reply address")
+	}
+	if err := msg.Reply("from tap"); !errors.Is(err, ErrTapReadOnly) {
+		t.Errorf("Reply() on a tapped message error = %v, want ErrTapReadOnly", err)
+	}
+	for _, want := range []string{"published", "requested"} {
+		if got := <-received; got != want {
+			t.Errorf("consumer got %q, want %q", got, want)
+		}
+	}
+
+	untap()
+	untap() // Idempotent
+	if err := eb.Send("tap.orders", "after"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-received
+	select {
+	case msg := <-tapped:
+		t.Errorf("tap saw %v after untap", msg.Body())
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestTap_Wrappers(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	// A tenant tap sees the tenant's address only
+	tenant := NewTenantEventBus(eb, "acme")
+	tapped := make(chan string, 2)
+	untap, err := Tap(tenant, "tap.events", func(msg Message) { tapped <- msg.Header(TenantHeader) })
+	if err != nil {
+		t.Fatalf("Tap() on a tenant bus error = %v", err)
+	}
+	defer untap()
+	_ = eb.Publish("tap.events", "untenanted")
+	_ = tenant.Publish("tap.events", "tenanted")
+	select {
+	case got := <-tapped:
+		if got != "acme" {
+			t.Errorf("tenant tap saw a message from tenant %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("tenant tap saw nothing")
+	}
+
+	// A panicking observer doesn't stop the tap
+	seen := make(chan struct{}, 2)
+	untap2, _ := Tap(eb, "tap.panics", func(msg Message) {
+		seen <- struct{}{}
+		panic("observer bug")
+	})
+	defer untap2()
+	_ = eb.Publish("tap.panics", 1)
+	_ = eb.Publish("tap.panics", 2)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-seen:
+		case <-time.After(time.Second):
+			t.Fatalf("observer ran %d times, want 2", i)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// TenantHeader carries the sending tenant on messages sent through a TenantEventBus
+const TenantHeader = "X-Tenant-ID"
+
+// GlobalAddressPrefix marks cross-tenant addresses: a TenantEventBus leaves them
+// (and framework "fluxor." addresses) unprefixed, so every tenant shares them
+const GlobalAddressPrefix = "global."
+
+// tenantAddressPrefix namespaces tenant addresses: "tenant.<id>.<address>"
+const tenantAddressPrefix = "tenant."
+
+type tenantKey struct{}
+
+// WithTenant returns a context scoped to tenantID (see TenantBus)
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// GetTenant returns the tenant ID stored by WithTenant, or ""
+func GetTenant(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if tenantID, ok := ctx.Value(tenantKey{}).(string); ok {
+		return tenantID
+	}
+	return ""
+}
+
+// TenantAddress returns the bus address tenantID's address maps to, e.g.
+// "tenant.acme.order.created"; global, framework and reply addresses are unchanged
+func TenantAddress(tenantID, address string) string {
+	if isSharedAddress(address) {
+		return address
+	}
+	return tenantAddressPrefix + tenantID + "." + address
+}
+
+func isSharedAddress(address string) bool {
+	return strings.HasPrefix(address, GlobalAddressPrefix) ||
+		strings.HasPrefix(address, frameworkAddressPrefix) ||
+		strings.HasPrefix(address, replyAddressPrefix)
+}
+
+// TenantEventBus scopes an EventBus to one tenant: every address is prefixed
+// (see TenantAddress), so tenant A's "order.created" never reaches tenant B's
+// consumers. On cluster buses the prefix becomes part of the NATS subject.
+//
+// Handlers registered through it see a FluxorContext whose EventBus() is scoped
+// to the same tenant and whose Context() carries it (GetTenant).
+// Messages it sends carry TenantHeader, and the IDs of publishes it schedules
+// can only be cancelled through the same tenant.
+type TenantEventBus struct {
+	EventBus
+	tenantID string
+}
+
+// NewTenantEventBus wraps eventBus for tenantID
+// Fail-fast: panics on a nil bus or an empty tenant ID, or one containing
+// '.', '*', '>' or whitespace (it must stay a single subject token)
+func NewTenantEventBus(eventBus EventBus, tenantID string) *TenantEventBus {
+	if eventBus == nil {
+		panic("eventBus cannot be nil")
+	}
+	if tenantID == "" || strings.ContainsAny(tenantID, ".*> \t\r\n") {
+		panic("invalid tenant ID: " + tenantID)
+	}
+	if t, ok := eventBus.(*TenantEventBus); ok {
+		// Re-scoping replaces the tenant rather than nesting prefixes
+		eventBus = t.EventBus
+	}
+	return &TenantEventBus{EventBus: eventBus, tenantID: tenantID}
+}
+
+// TenantBus returns eventBus scoped to ctx's tenant, or eventBus itself when
+// ctx has none
+func TenantBus(ctx context.Context, eventBus EventBus) EventBus {
+	tenantID := GetTenant(ctx)
+	if tenantID == "" {
+		return eventBus
+	}
+	return NewTenantEventBus(eventBus, tenantID)
+}
+
+// Tenant returns the tenant ID the bus is scoped to
+func (t *TenantEventBus) Tenant() string {
+	return t.tenantID
+}
+
+func (t *TenantEventBus) address(address string) string {
+	return TenantAddress(t.tenantID, address)
+}
+
+func (t *TenantEventBus) headers(headers map[string]string) map[string]string {
+	return mergeHeaders(headers, map[string]string{TenantHeader: t.tenantID})
+}
+
+// Publish implements EventBus.
+func (t *TenantEventBus) Publish(address string, body interface{}) error {
+	return t.EventBus.PublishWithHeaders(t.address(address), body, t.headers(nil))
+}
+
+// PublishBatch implements EventBus; buses that can't attach headers send it
+// without TenantHeader.
+func (t *TenantEventBus) PublishBatch(address string, bodies []interface{}) error {
+	if hs, ok := t.EventBus.(headerSender); ok {
+		return hs.publishBatchWithHeaders(t.address(address), bodies, t.headers(nil))
+	}
+	return t.EventBus.PublishBatch(t.address(address), bodies)
+}
+
+// PublishWithHeaders implements EventBus.
+func (t *TenantEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	return t.EventBus.PublishWithHeaders(t.address(address), body, t.headers(headers))
+}
+
+// PublishAfter implements EventBus; the ID is scoped to the tenant (see CancelScheduled).
+func (t *TenantEventBus) PublishAfter(address string, body interface{}, delay time.Duration) (string, error) {
+	return t.scheduleID(t.EventBus.PublishAfter(t.address(address), body, delay))
+}
+
+// PublishAt implements EventBus; the ID is scoped to the tenant (see CancelScheduled).
+func (t *TenantEventBus) PublishAt(address string, body interface{}, at time.Time) (string, error) {
+	return t.scheduleID(t.EventBus.PublishAt(t.address(address), body, at))
+}
+
+// CancelScheduled implements EventBus. It only cancels publishes scheduled
+// through a TenantEventBus for the same tenant.
+func (t *TenantEventBus) CancelScheduled(id string) bool {
+	inner, ok := strings.CutPrefix(id, tenantAddressPrefix+t.tenantID+".")
+	if !ok {
+		return false
+	}
+	return t.EventBus.CancelScheduled(inner)
+}
+
+// scheduleID prefixes a scheduled publish's ID the way addresses are, so other
+// tenants can't cancel it
+func (t *TenantEventBus) scheduleID(id string, err error) (string, error) {
+	if err != nil {
+		return id, err
+	}
+	return tenantAddressPrefix + t.tenantID + "." + id, nil
+}
+
+// Send implements EventBus.
+func (t *TenantEventBus) Send(address string, body interface{}) error {
+	return t.EventBus.SendWithHeaders(t.address(address), body, t.headers(nil))
+}
+
+// SendWithHeaders implements EventBus.
+func (t *TenantEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	return t.EventBus.SendWithHeaders(t.address(address), body, t.headers(headers))
+}
+
+// Request implements EventBus; buses that can't attach headers send it without
+// TenantHeader.
+func (t *TenantEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	if hs, ok := t.EventBus.(headerSender); ok {
+		return hs.requestWithHeaders(t.address(address), body, timeout, t.headers(nil))
+	}
+	return t.EventBus.Request(t.address(address), body, timeout)
+}
+
+// RequestStream implements EventBus; buses that can't attach headers send it
+// without TenantHeader.
+func (t *TenantEventBus) RequestStream(address string, body interface{}, timeout time.Duration) (<-chan Message, error) {
+	if hs, ok := t.EventBus.(headerSender); ok {
+		return hs.requestStreamWithHeaders(t.address(address), body, timeout, t.headers(nil))
+	}
+	return t.EventBus.RequestStream(t.address(address), body, timeout)
+}
+
+// Consumer implements EventBus; handlers run scoped to the tenant.
+func (t *TenantEventBus) Consumer(address string) Consumer {
+	return &tenantConsumer{Consumer: t.EventBus.Consumer(t.address(address)), bus: t}
+}
+
+// publishLocal keeps GoCMD's local framework events working through the wrapper
+func (t *TenantEventBus) publishLocal(address string, body interface{}) error {
+	return PublishLocal(t.EventBus, address, body)
+}
+
+// tenantConsumer hands handlers a FluxorContext scoped to the tenant
+type tenantConsumer struct {
+	Consumer
+	bus *TenantEventBus
+}
+
+func (c *tenantConsumer) Handler(handler MessageHandler) Consumer {
+	c.Consumer.Handler(func(ctx FluxorContext, msg Message) error {
+		return handler(c.scope(ctx), msg)
+	})
+	return c
+}
+
+func (c *tenantConsumer) HandlerN(n int, handler MessageHandler) Consumer {
+	c.Consumer.HandlerN(n, func(ctx FluxorContext, msg Message) error {
+		return handler(c.scope(ctx), msg)
+	})
+	return c
+}
+
+func (c *tenantConsumer) HandlerReply(handler ReplyHandler) Consumer {
+	c.Consumer.HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return handler(c.scope(ctx), msg)
+	})
+	return c
+}
+
+func (c *tenantConsumer) OnError(handler ErrorHandler) Consumer {
+	c.Consumer.OnError(func(ctx FluxorContext, msg Message, err error) {
+		handler(c.scope(ctx), msg, err)
+	})
+	return c
+}
+
+func (c *tenantConsumer) setFilter(filter MessageFilter) {
+	FilterConsumer(c.Consumer, filter)
+}
+
+func (c *tenantConsumer) scope(ctx FluxorContext) FluxorContext {
+	return &tenantContext{FluxorContext: ctx, bus: c.bus}
+}
+
+// tenantContext hands the tenant-scoped bus and context to handlers
+type tenantContext struct {
+	FluxorContext
+	bus *TenantEventBus
+}
+
+func (c *tenantContext) EventBus() EventBus { return c.bus }
+
+func (c *tenantContext) Context() context.Context {
+	return WithTenant(c.FluxorContext.Context(), c.bus.tenantID)
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTenantEventBus_Isolation(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	acme, globex := NewTenantEventBus(eb, "acme"), NewTenantEventBus(eb, "globex")
+
+	received := make(chan string, 4)
+	headers := make(chan string, 4)
+	for _, bus := range []*TenantEventBus{acme, globex} {
+		bus := bus
+		waitReady(t, bus.Consumer("order.created").Handler(func(ctx FluxorContext, msg Message) error {
+			// Messages sent through a TenantEventBus carry the sender's tenant
+			header := msg.Header(TenantHeader)
+			if GetTenant(ctx.Context()) != bus.Tenant() || (header != "" && header != bus.Tenant()) {
+				t.Errorf("handler for %s saw tenant %q, header %q", bus.Tenant(), GetTenant(ctx.Context()), header)
+			}
+			headers <- header
+			received <- bus.Tenant()
+			return nil
+		}))
+	}
+
+	if err := acme.Publish("order.created", "o-1"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	select {
+	case got := <-received:
+		if got != "acme" {
+			t.Errorf("delivered to %s, want acme", got)
+		}
+		if h := <-headers; h != "acme" {
+			t.Errorf("%s = %q, want acme", TenantHeader, h)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not delivered")
+	}
+	select {
+	case got := <-received:
+		t.Errorf("message leaked to %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The unscoped bus sees the namespaced address
+	if err := eb.Send(TenantAddress("globex", "order.created"), "o-2"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := <-received; got != "globex" {
+		t.Errorf("delivered to %s, want globex", got)
+	}
+	if err := eb.Send("order.created", "o-3"); err == nil {
+		t.Error("Send() to the unprefixed address should find no handlers")
+	}
+}
+
+func TestTenantEventBus_SharedAddressesAndReplies(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	acme := TenantBus(WithTenant(context.Background(), "acme"), eb)
+
+	// Global addresses are shared by every tenant and the unscoped bus
+	waitReady(t, eb.Consumer("global.audit").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return msg.Header(TenantHeader), nil
+	}))
+	reply, err := acme.Request("global.audit", "x", time.Second)
+	if err != nil {
+		t.Fatalf("Request(global) error = %v", err)
+	}
+	var tenant string
+	if err := reply.DecodeBody(&tenant); err != nil || tenant != "acme" {
+		t.Errorf("global reply = %q, %v; want the request to carry %s acme", tenant, err, TenantHeader)
+	}
+
+	// Handlers reply and fan out within their tenant
+	waitReady(t, acme.Consumer("price").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		reply, err := ctx.EventBus().Request("tax", "x", time.Second)
+		if err != nil {
+			return nil, err
+		}
+		var rate float64
+		return rate, reply.DecodeBody(&rate)
+	}))
+	waitReady(t, acme.Consumer("tax").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return 0.2, nil
+	}))
+	reply, err = acme.Request("price", "x", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var rate float64
+	if err := reply.DecodeBody(&rate); err != nil || rate != 0.2 {
+		t.Errorf("rate = %v, %v", rate, err)
+	}
+
+	if TenantBus(context.Background(), eb) != eb {
+		t.Error("TenantBus() without a tenant should return the bus unchanged")
+	}
+	if NewTenantEventBus(acme, "globex").address("x") != "tenant.globex.x" {
+		t.Error("re-scoping should replace the tenant, not nest it")
+	}
+}
+
+func TestTenantEventBus_HeadersOnEveryOperation(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	acme := NewTenantEventBus(gocmd.EventBus(), "acme")
+
+	headers := make(chan string, 4)
+	waitReady(t, acme.Consumer("batch").Handler(func(ctx FluxorContext, msg Message) error {
+		headers <- msg.Header(TenantHeader)
+		return nil
+	}), acme.Consumer("stream").Handler(func(ctx FluxorContext, msg Message) error {
+		if err := msg.Stream(msg.Header(TenantHeader)); err != nil {
+			return err
+		}
+		return msg.EndStream()
+	}))
+
+	if err := acme.PublishBatch("batch", []interface{}{"a", "b"}); err != nil {
+		t.Fatalf("PublishBatch() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if h := <-headers; h != "acme" {
+			t.Errorf("PublishBatch %s = %q, want acme", TenantHeader, h)
+		}
+	}
+
+	replies, err := acme.RequestStream("stream", "x", time.Second)
+	if err != nil {
+		t.Fatalf("RequestStream() error = %v", err)
+	}
+	var tenant string
+	if err := (<-replies).DecodeBody(&tenant); err != nil || tenant != "acme" {
+		t.Errorf("RequestStream %s = %q, %v; want acme", TenantHeader, tenant, err)
+	}
+
+	if err := acme.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() over the in-memory bus error = %v, want nil", err)
+	}
+}
+
+func TestTenantEventBus_CancelScheduledIsTenantScoped(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	acme, globex := NewTenantEventBus(eb, "acme"), NewTenantEventBus(eb, "globex")
+
+	id, err := acme.PublishAfter("reminder", "x", time.Hour)
+	if err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+	if globex.CancelScheduled(id) {
+		t.Error("another tenant cancelled acme's scheduled publish")
+	}
+	if eb.CancelScheduled(id) {
+		t.Error("the unscoped bus cancelled a tenant-scoped ID")
+	}
+	if !NewTenantEventBus(eb, "acme").CancelScheduled(id) {
+		t.Error("acme could not cancel its own scheduled publish")
+	}
+}
+
+func TestNewTenantEventBus_InvalidTenantPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewTenantEventBus() should panic on a tenant ID containing '.'")
+		}
+	}()
+	NewTenantEventBus(NewGoCMD(context.Background()).EventBus(), "a.b")
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -89,9 +90,9 @@ func TestEventBus_Request(t *testing.T) {
 	defer eb.Close()
 
 	// Test fail-fast: invalid timeout
-	_, err := eb.Request("test.address", "test", 0)
+	_, err := eb.Request("test.address", "test", 10*time.Minute)
 	if err == nil {
-		t.Error("Request() with zero timeout should fail")
+		t.Error("Request() with a timeout over the maximum should fail")
 	}
 
 	// Test fail-fast: empty address
@@ -163,3 +164,237 @@ func TestConsumer_Handler_FailFast_NilHandlerPanics(t *testing.T) {
 	}()
 	c.Handler(nil)
 }
+
+func TestEventBus_Stats(t *testing.T) {
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	eb := gocmd.EventBus()
+	defer eb.Close()
+
+	// Handler blocks so the mailbox fills up and further deliveries are dropped
+	release := make(chan struct{})
+	defer close(release)
+	eb.Consumer("stats.address").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	})
+	eb.Consumer("stats.address")
+
+	for i := 0; i < 150; i++ {
+		if err := eb.Publish("stats.address", i); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if err := eb.Send("stats.address", "one"); err != nil && err != ErrTimeout {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	stats := eb.Stats()
+	if stats.Published != 150 {
+		t.Errorf("Published = %d, want 150", stats.Published)
+	}
+	if stats.Sent != 1 {
+		t.Errorf("Sent = %d, want 1", stats.Sent)
+	}
+	if stats.Dropped == 0 {
+		t.Error("Dropped should count deliveries rejected by full mailboxes")
+	}
+
+	as, ok := stats.Addresses["stats.address"]
+	if !ok {
+		t.Fatal("Stats() missing stats.address")
+	}
+	if as.Consumers != 2 {
+		t.Errorf("Consumers = %d, want 2", as.Consumers)
+	}
+	if as.MailboxCapacity != 200 {
+		t.Errorf("MailboxCapacity = %d, want 200", as.MailboxCapacity)
+	}
+	if as.MailboxDepth == 0 || as.MailboxDepth > as.MailboxCapacity {
+		t.Errorf("MailboxDepth = %d, want within (0, %d]", as.MailboxDepth, as.MailboxCapacity)
+	}
+	if as.Dropped != stats.Dropped {
+		t.Errorf("address Dropped = %d, want %d", as.Dropped, stats.Dropped)
+	}
+}
+
+func TestEventBus_StatsHandlerFailures(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	handled := make(chan struct{}, 10)
+	c := eb.Consumer("failing.address").Handler(func(ctx FluxorContext, msg Message) error {
+		defer func() { handled <- struct{}{} }()
+		var body string
+		msg.DecodeBody(&body)
+		switch body {
+		case "panic":
+			panic("boom")
+		case "error":
+			return errors.New("failed")
+		}
+		return nil
+	})
+	r := eb.Consumer("failing.reply").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		panic("reply boom")
+	})
+	waitReady(t, c, r)
+
+	for _, body := range []string{"ok", "error", "error", "panic"} {
+		if err := eb.Send("failing.address", body); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		<-handled
+	}
+	eb.Request("failing.reply", "x", time.Second)
+
+	// Counters are updated after the handler returns
+	deadline := time.Now().Add(time.Second)
+	for {
+		failures := eb.Stats().HandlerFailures
+		got, want := failures["failing.address"], HandlerFailureStats{Errors: 2, Panics: 1}
+		gotReply, wantReply := failures["failing.reply"], HandlerFailureStats{Panics: 1}
+		if got == want && gotReply == wantReply {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("HandlerFailures = %+v, want failing.address %+v and failing.reply %+v", failures, want, wantReply)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// testHeaderRoundTrip checks custom headers reach handlers and replies on bus
+func testHeaderRoundTrip(t *testing.T, bus EventBus, settle time.Duration) {
+	t.Helper()
+	received := make(chan Message, 2)
+	bus.Consumer("headers.in").Handler(func(_ FluxorContext, msg Message) error {
+		received <- msg
+		return nil
+	})
+	bus.Consumer("headers.req").Handler(func(_ FluxorContext, msg Message) error {
+		msg.SetHeader("X-Result", "done")
+		msg.SetHeader(HeaderReplyAddress, "ignored")
+		return msg.Reply("ok")
+	})
+	time.Sleep(settle)
+
+	headers := map[string]string{"X-Tenant": "acme", "Priority": "high"}
+	if err := bus.PublishWithHeaders("headers.in", "published", headers); err != nil {
+		t.Fatalf("PublishWithHeaders() error = %v", err)
+	}
+	if err := bus.SendWithHeaders("headers.in", "sent", headers); err != nil {
+		t.Fatalf("SendWithHeaders() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-received:
+			if msg.Header("X-Tenant") != "acme" || msg.Header("priority") != "high" || msg.Headers()["Priority"] != "high" {
+				t.Errorf("headers = %v", msg.Headers())
+			}
+			if msg.Header("missing") != "" {
+				t.Error("Header() of a missing key should be empty")
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("message not received")
+		}
+	}
+
+	reply, err := bus.Request("headers.req", "hi", 2*time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if reply.Header("X-Result") != "done" || reply.Header(HeaderReplyAddress) != "" {
+		t.Errorf("reply headers = %v", reply.Headers())
+	}
+
+	for _, bad := range []map[string]string{{HeaderReplyAddress: "x"}, {"fluxor-stream": "x"}, {"": "x"}} {
+		if err := bus.PublishWithHeaders("headers.in", "x", bad); err == nil {
+			t.Errorf("PublishWithHeaders(%v) should fail", bad)
+		}
+		if err := bus.SendWithHeaders("headers.in", "x", bad); err == nil {
+			t.Errorf("SendWithHeaders(%v) should fail", bad)
+		}
+	}
+}
+
+func TestEventBus_Headers(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	testHeaderRoundTrip(t, gocmd.EventBus(), 0)
+}
+
+// waitReady waits until every consumer is ready to receive
+func waitReady(t *testing.T, consumers ...Consumer) {
+	t.Helper()
+	for _, c := range consumers {
+		select {
+		case <-c.Ready():
+		case <-time.After(5 * time.Second):
+			t.Fatal("consumer did not become ready")
+		}
+	}
+}
+
+func TestConsumer_Ready(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	c := eb.Consumer("ready.test")
+	select {
+	case <-c.Ready():
+		t.Fatal("consumer without a handler should not be ready")
+	default:
+	}
+
+	c.HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return "pong", nil
+	})
+	waitReady(t, c)
+
+	reply, err := eb.Request("ready.test", "ping", time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var body string
+	if err := reply.DecodeBody(&body); err != nil || body != "pong" {
+		t.Errorf("reply = %q, %v", body, err)
+	}
+}
+
+func TestEventBus_RequestDefaultTimeout(t *testing.T) {
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{RequestTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	c := eb.Consumer("timeout.slow").Handler(func(ctx FluxorContext, msg Message) error {
+		time.Sleep(150 * time.Millisecond)
+		return msg.Reply("late")
+	})
+	waitReady(t, c)
+
+	// timeout <= 0 uses the bus default
+	start := time.Now()
+	if _, err := eb.Request("timeout.slow", "ping", 0); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Request() with zero timeout error = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 140*time.Millisecond {
+		t.Errorf("Request() with zero timeout took %v, want about 50ms", elapsed)
+	}
+
+	// A per-call timeout wins over the default
+	if _, err := eb.Request("timeout.slow", "ping", time.Second); err != nil {
+		t.Errorf("Request() with per-call timeout error = %v", err)
+	}
+
+	for _, timeout := range []time.Duration{-time.Second, 10 * time.Minute} {
+		if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{RequestTimeout: timeout}); err == nil {
+			t.Errorf("NewGoCMDWithOptions() should reject RequestTimeout %v", timeout)
+		}
+	}
+}
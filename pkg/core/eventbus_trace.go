@@ -0,0 +1,373 @@
+package core
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// traceParentHeader carries the ID of the TraceEntry that sent a message
+const traceParentHeader = "X-Trace-Parent"
+
+// TraceEntry is one step of a correlation chain recorded by TracingEventBus.
+// Entries of one request form a tree through ParentID.
+type TraceEntry struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"` // Entry that caused this one; empty for a root
+	RequestID string    `json:"request_id"`
+	Kind      string    `json:"kind"` // publish, send, request, schedule or receive
+	Address   string    `json:"address"`
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// TraceOptions bounds what a TracingEventBus retains.
+type TraceOptions struct {
+	// MaxTraces is the number of request IDs kept; the oldest is evicted first (default: 1000)
+	MaxTraces int
+
+	// MaxAge drops traces started longer ago than this (default: 10m)
+	MaxAge time.Duration
+
+	// MaxEntries caps entries per trace; later ones are not recorded (default: 1000)
+	MaxEntries int
+}
+
+// TracingEventBus wraps an EventBus and records in-process correlation chains
+// (request X triggered send Y triggered publish Z) keyed by request ID, without
+// an external tracing backend. Query them with Trace.
+//
+// Handlers registered through it see a FluxorContext whose EventBus() and
+// Context() carry the incoming message's request ID, so messages they send are
+// recorded as children of the delivery. Trace headers travel on the wire, so a
+// chain continues across cluster nodes that also trace; each node records its own part.
+// Scheduled publishes are recorded but not propagated.
+//
+// To trace a whole app, return it from GoCMDOptions.EventBusFactory.
+type TracingEventBus struct {
+	EventBus
+	store     *traceStore
+	requestID string // Scope: empty means every send starts a new trace
+	parentID  string
+}
+
+// NewTracingEventBus wraps eventBus - fail-fast on nil bus or negative options
+func NewTracingEventBus(eventBus EventBus, opts TraceOptions) *TracingEventBus {
+	if eventBus == nil {
+		panic("eventBus cannot be nil")
+	}
+	if opts.MaxTraces < 0 || opts.MaxAge < 0 || opts.MaxEntries < 0 {
+		panic("trace options cannot be negative")
+	}
+	if opts.MaxTraces == 0 {
+		opts.MaxTraces = 1000
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = 10 * time.Minute
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = 1000
+	}
+	return &TracingEventBus{
+		EventBus: eventBus,
+		store: &traceStore{
+			opts:   opts,
+			traces: make(map[string]*list.Element),
+			order:  list.New(),
+		},
+	}
+}
+
+// WithRequestID returns a view of the bus whose sends belong to requestID,
+// e.g. an HTTP handler passing ctx.RequestID()
+func (t *TracingEventBus) WithRequestID(requestID string) *TracingEventBus {
+	scoped := *t
+	scoped.requestID = requestID
+	scoped.parentID = ""
+	return &scoped
+}
+
+// Trace returns the recorded entries for requestID, oldest first
+func (t *TracingEventBus) Trace(requestID string) []TraceEntry {
+	return t.store.get(requestID)
+}
+
+// Publish implements EventBus.
+func (t *TracingEventBus) Publish(address string, body interface{}) error {
+	entry, headers := t.begin("publish", address)
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		err = hs.publishWithHeaders(address, body, headers)
+	} else {
+		err = t.EventBus.Publish(address, body)
+	}
+	t.finish(entry, err)
+	return err
+}
+
+// PublishWithHeaders implements EventBus; the trace headers take precedence.
+func (t *TracingEventBus) PublishWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	entry, traceHeaders := t.begin("publish", address)
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		err = hs.publishWithHeaders(address, body, mergeHeaders(headers, traceHeaders))
+	} else {
+		err = t.EventBus.PublishWithHeaders(address, body, headers)
+	}
+	t.finish(entry, err)
+	return err
+}
+
+// Send implements EventBus.
+func (t *TracingEventBus) Send(address string, body interface{}) error {
+	entry, headers := t.begin("send", address)
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		err = hs.sendWithHeaders(address, body, headers)
+	} else {
+		err = t.EventBus.Send(address, body)
+	}
+	t.finish(entry, err)
+	return err
+}
+
+// SendWithHeaders implements EventBus; the trace headers take precedence.
+func (t *TracingEventBus) SendWithHeaders(address string, body interface{}, headers map[string]string) error {
+	if err := ValidateHeaders(headers); err != nil {
+		return err
+	}
+	entry, traceHeaders := t.begin("send", address)
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		err = hs.sendWithHeaders(address, body, mergeHeaders(headers, traceHeaders))
+	} else {
+		err = t.EventBus.SendWithHeaders(address, body, headers)
+	}
+	t.finish(entry, err)
+	return err
+}
+
+// Request implements EventBus.
+func (t *TracingEventBus) Request(address string, body interface{}, timeout time.Duration) (Message, error) {
+	entry, headers := t.begin("request", address)
+	var reply Message
+	var err error
+	if hs, ok := t.EventBus.(headerSender); ok {
+		reply, err = hs.requestWithHeaders(address, body, timeout, headers)
+	} else {
+		reply, err = t.EventBus.Request(address, body, timeout)
+	}
+	t.finish(entry, err)
+	return reply, err
+}
+
+// PublishAfter implements EventBus.
+func (t *TracingEventBus) PublishAfter(address string, body interface{}, delay time.Duration) (string, error) {
+	entry, _ := t.begin("schedule", address)
+	id, err := t.EventBus.PublishAfter(address, body, delay)
+	t.finish(entry, err)
+	return id, err
+}
+
+// PublishAt implements EventBus.
+func (t *TracingEventBus) PublishAt(address string, body interface{}, at time.Time) (string, error) {
+	entry, _ := t.begin("schedule", address)
+	id, err := t.EventBus.PublishAt(address, body, at)
+	t.finish(entry, err)
+	return id, err
+}
+
+// Consumer implements EventBus; handlers record a receive entry and run in its scope.
+func (t *TracingEventBus) Consumer(address string) Consumer {
+	return &tracingConsumer{Consumer: t.EventBus.Consumer(address), bus: t, address: address}
+}
+
+// publishLocal keeps GoCMD's local shutdown broadcast working through the wrapper
+func (t *TracingEventBus) publishLocal(address string, body interface{}) error {
+	if lp, ok := t.EventBus.(localPublisher); ok {
+		return lp.publishLocal(address, body)
+	}
+	return t.EventBus.Publish(address, body)
+}
+
+func (t *TracingEventBus) begin(kind, address string) (TraceEntry, map[string]string) {
+	requestID := t.requestID
+	if requestID == "" {
+		requestID = GenerateRequestID()
+	}
+	entry := TraceEntry{
+		ID:        generateUUID(),
+		ParentID:  t.parentID,
+		RequestID: requestID,
+		Kind:      kind,
+		Address:   address,
+		Timestamp: time.Now(),
+	}
+	return entry, map[string]string{"X-Request-ID": requestID, traceParentHeader: entry.ID}
+}
+
+func (t *TracingEventBus) finish(entry TraceEntry, err error) {
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	t.store.add(entry)
+}
+
+// receive records a delivery and returns the bus scoped to it
+func (t *TracingEventBus) receive(address string, msg Message) *TracingEventBus {
+	headers := msg.Headers()
+	requestID := headers["X-Request-ID"]
+	if requestID == "" {
+		requestID = GenerateRequestID()
+	}
+	entry := TraceEntry{
+		ID:        generateUUID(),
+		ParentID:  headers[traceParentHeader],
+		RequestID: requestID,
+		Kind:      "receive",
+		Address:   address,
+		Timestamp: time.Now(),
+	}
+	t.store.add(entry)
+
+	scoped := *t
+	scoped.requestID = requestID
+	scoped.parentID = entry.ID
+	return &scoped
+}
+
+// tracingConsumer wraps handlers so they run in the scope of the delivery
+type tracingConsumer struct {
+	Consumer
+	bus     *TracingEventBus
+	address string
+}
+
+func (c *tracingConsumer) Handler(handler MessageHandler) Consumer {
+	c.Consumer.Handler(func(ctx FluxorContext, msg Message) error {
+		return handler(c.scope(ctx, msg), msg)
+	})
+	return c
+}
+
+func (c *tracingConsumer) HandlerN(n int, handler MessageHandler) Consumer {
+	c.Consumer.HandlerN(n, func(ctx FluxorContext, msg Message) error {
+		return handler(c.scope(ctx, msg), msg)
+	})
+	return c
+}
+
+func (c *tracingConsumer) HandlerReply(handler ReplyHandler) Consumer {
+	c.Consumer.HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return handler(c.scope(ctx, msg), msg)
+	})
+	return c
+}
+
+func (c *tracingConsumer) OnError(handler ErrorHandler) Consumer {
+	c.Consumer.OnError(func(ctx FluxorContext, msg Message, err error) {
+		// The handler's receive entry is already recorded; continue its trace
+		bus := *c.bus
+		bus.requestID = msg.Headers()["X-Request-ID"]
+		bus.parentID = msg.Headers()[traceParentHeader]
+		handler(&tracingContext{FluxorContext: ctx, bus: &bus}, msg, err)
+	})
+	return c
+}
+
+func (c *tracingConsumer) setFilter(filter MessageFilter) {
+	FilterConsumer(c.Consumer, filter)
+}
+
+func (c *tracingConsumer) scope(ctx FluxorContext, msg Message) FluxorContext {
+	bus := c.bus.receive(c.address, msg)
+	return &tracingContext{FluxorContext: ctx, bus: bus}
+}
+
+// tracingContext hands the scoped bus and request ID to handlers
+type tracingContext struct {
+	FluxorContext
+	bus *TracingEventBus
+}
+
+func (c *tracingContext) EventBus() EventBus { return c.bus }
+
+func (c *tracingContext) Context() context.Context {
+	return WithRequestID(c.FluxorContext.Context(), c.bus.requestID)
+}
+
+// headerSender is implemented by buses that can attach extra headers to a message
+type headerSender interface {
+	publishWithHeaders(address string, body interface{}, headers map[string]string) error
+	publishBatchWithHeaders(address string, bodies []interface{}, headers map[string]string) error
+	sendWithHeaders(address string, body interface{}, headers map[string]string) error
+	requestWithHeaders(address string, body interface{}, timeout time.Duration, headers map[string]string) (Message, error)
+	requestStreamWithHeaders(address string, body interface{}, timeout time.Duration, headers map[string]string) (<-chan Message, error)
+}
+
+// traceStore keeps traces by request ID, bounded by count, age and entries per trace
+type traceStore struct {
+	mu     sync.Mutex
+	opts   TraceOptions
+	traces map[string]*list.Element
+	order  *list.List // Oldest trace first
+}
+
+type traceRecord struct {
+	requestID string
+	started   time.Time
+	entries   []TraceEntry
+}
+
+func (s *traceStore) add(entry TraceEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(time.Now())
+
+	el, ok := s.traces[entry.RequestID]
+	if !ok {
+		el = s.order.PushBack(&traceRecord{requestID: entry.RequestID, started: entry.Timestamp})
+		s.traces[entry.RequestID] = el
+		if s.order.Len() > s.opts.MaxTraces {
+			s.removeLocked(s.order.Front())
+		}
+	}
+	record := el.Value.(*traceRecord)
+	if len(record.entries) < s.opts.MaxEntries {
+		record.entries = append(record.entries, entry)
+	}
+}
+
+func (s *traceStore) get(requestID string) []TraceEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(time.Now())
+
+	el, ok := s.traces[requestID]
+	if !ok {
+		return nil
+	}
+	entries := append([]TraceEntry(nil), el.Value.(*traceRecord).entries...)
+	// Sends are recorded once they return, so a fast receive can be appended first
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+	return entries
+}
+
+func (s *traceStore) pruneLocked(now time.Time) {
+	for el := s.order.Front(); el != nil; el = s.order.Front() {
+		if now.Sub(el.Value.(*traceRecord).started) <= s.opts.MaxAge {
+			return
+		}
+		s.removeLocked(el)
+	}
+}
+
+func (s *traceStore) removeLocked(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.traces, el.Value.(*traceRecord).requestID)
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTracingGoCMD(t *testing.T, opts TraceOptions) (GoCMD, *TracingEventBus) {
+	t.Helper()
+	gocmd, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{
+		EventBusFactory: func(ctx context.Context, g GoCMD) (EventBus, error) {
+			return NewTracingEventBus(NewEventBus(ctx, g), opts), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	return gocmd, gocmd.EventBus().(*TracingEventBus)
+}
+
+func TestTracingEventBus_RecordsCorrelationChain(t *testing.T) {
+	gocmd, bus := newTracingGoCMD(t, TraceOptions{})
+	defer gocmd.Close()
+
+	published := make(chan string, 1)
+	bus.Consumer("orders.create").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		if err := ctx.EventBus().Send("inventory.reserve", "sku-1"); err != nil {
+			return nil, err
+		}
+		return "created", nil
+	})
+	bus.Consumer("inventory.reserve").Handler(func(ctx FluxorContext, msg Message) error {
+		return ctx.EventBus().Publish("orders.reserved", "sku-1")
+	})
+	bus.Consumer("orders.reserved").Handler(func(ctx FluxorContext, msg Message) error {
+		published <- GetRequestID(ctx.Context())
+		return nil
+	})
+
+	if _, err := bus.WithRequestID("req-1").Request("orders.create", "order", time.Second); err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	select {
+	case rid := <-published:
+		if rid != "req-1" {
+			t.Errorf("handler request ID = %q, want req-1", rid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("chain did not reach orders.reserved")
+	}
+
+	entries := bus.Trace("req-1")
+	want := []struct{ kind, address string }{
+		{"request", "orders.create"},
+		{"receive", "orders.create"},
+		{"send", "inventory.reserve"},
+		{"receive", "inventory.reserve"},
+		{"publish", "orders.reserved"},
+		{"receive", "orders.reserved"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Trace() = %d entries %+v, want %d", len(entries), entries, len(want))
+	}
+	byID := make(map[string]TraceEntry)
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+	// Walk from the leaf up to the root; each step must be the expected parent
+	leaf := entries[0]
+	for _, e := range entries {
+		if e.Kind == "receive" && e.Address == "orders.reserved" {
+			leaf = e
+		}
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		if leaf.Kind != want[i].kind || leaf.Address != want[i].address {
+			t.Fatalf("chain step %d = %s %s, want %s %s", i, leaf.Kind, leaf.Address, want[i].kind, want[i].address)
+		}
+		if i > 0 {
+			parent, ok := byID[leaf.ParentID]
+			if !ok {
+				t.Fatalf("chain step %d (%s %s) has unknown parent %q", i, leaf.Kind, leaf.Address, leaf.ParentID)
+			}
+			leaf = parent
+		}
+	}
+	if leaf.ParentID != "" {
+		t.Errorf("root ParentID = %q, want empty", leaf.ParentID)
+	}
+}
+
+func TestTracingEventBus_OnErrorRunsInTrace(t *testing.T) {
+	gocmd, bus := newTracingGoCMD(t, TraceOptions{})
+	defer gocmd.Close()
+
+	failed := make(chan string, 1)
+	consumer := bus.Consumer("orders.charge")
+	if got := consumer.OnError(func(ctx FluxorContext, msg Message, err error) {
+		failed <- GetRequestID(ctx.Context())
+	}); got != consumer {
+		t.Fatal("OnError() should return the tracing consumer")
+	}
+	waitReady(t, consumer.Handler(func(ctx FluxorContext, msg Message) error {
+		return errors.New("card declined")
+	}))
+
+	if err := bus.WithRequestID("req-fail").Send("orders.charge", "order"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case rid := <-failed:
+		if rid != "req-fail" {
+			t.Errorf("OnError request ID = %q, want req-fail", rid)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnError was not called")
+	}
+}
+
+func TestTracingEventBus_RootSendsStartNewTraces(t *testing.T) {
+	gocmd, bus := newTracingGoCMD(t, TraceOptions{})
+	defer gocmd.Close()
+
+	if err := bus.Send("nobody.home", "x"); err == nil {
+		t.Fatal("expected NO_HANDLERS error")
+	}
+	if err := bus.WithRequestID("req-err").Send("nobody.home", "x"); err == nil {
+		t.Fatal("expected NO_HANDLERS error")
+	}
+	entries := bus.Trace("req-err")
+	if len(entries) != 1 || entries[0].Error == "" || entries[0].Kind != "send" {
+		t.Errorf("Trace() = %+v, want one failed send", entries)
+	}
+	if bus.Trace("unknown") != nil {
+		t.Errorf("Trace(unknown) should be nil")
+	}
+}
+
+func TestTracingEventBus_Bounds(t *testing.T) {
+	bus := NewTracingEventBus(NewEventBus(context.Background(), nil), TraceOptions{MaxTraces: 2, MaxEntries: 2, MaxAge: 50 * time.Millisecond})
+	defer bus.Close()
+
+	for _, rid := range []string{"a", "b", "c"} {
+		_ = bus.WithRequestID(rid).Publish("topic", "x")
+	}
+	if bus.Trace("a") != nil {
+		t.Error("oldest trace should be evicted by MaxTraces")
+	}
+	for i := 0; i < 3; i++ {
+		_ = bus.WithRequestID("c").Publish("topic", "x")
+	}
+	if got := len(bus.Trace("c")); got != 2 {
+		t.Errorf("Trace(c) = %d entries, want MaxEntries 2", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if bus.Trace("c") != nil {
+		t.Error("trace should expire after MaxAge")
+	}
+}
+
+func TestTracingEventBus_ShutdownHooksStillRun(t *testing.T) {
+	gocmd, _ := newTracingGoCMD(t, TraceOptions{})
+	ran := make(chan struct{}, 1)
+	gocmd.OnShutdown(func(ctx FluxorContext) error {
+		ran <- struct{}{}
+		return nil
+	})
+	_ = gocmd.Close()
+	select {
+	case <-ran:
+	default:
+		t.Error("shutdown hook did not run through the tracing bus")
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import "fmt"
+
+// TypedHandler handles a message whose body has been decoded into T.
+type TypedHandler[T any] func(ctx FluxorContext, body T) error
+
+// DecodeErrorHandler is called when a message body cannot be decoded for a typed handler.
+// Its return value is what the consumer returns for the message (nil to swallow it).
+type DecodeErrorHandler func(ctx FluxorContext, msg Message, err error) error
+
+// TypedConsumer registers handler on address, decoding each body into T.
+// Decode failures fail requests with code 400 and are returned for logging;
+// use TypedConsumerWithErrorHandler to handle them yourself.
+func TypedConsumer[T any](eb EventBus, address string, handler TypedHandler[T]) Consumer {
+	return TypedConsumerWithErrorHandler(eb, address, handler, nil)
+}
+
+// TypedConsumerWithErrorHandler is TypedConsumer with decode failures routed to onError.
+// A nil onError uses the TypedConsumer default - fail-fast on nil bus or handler.
+func TypedConsumerWithErrorHandler[T any](eb EventBus, address string, handler TypedHandler[T], onError DecodeErrorHandler) Consumer {
+	if eb == nil {
+		panic("eventBus cannot be nil")
+	}
+	if handler == nil {
+		panic("typed handler cannot be nil")
+	}
+	if onError == nil {
+		onError = failDecode
+	}
+
+	consumer := eb.Consumer(address)
+	consumer.Handler(func(ctx FluxorContext, msg Message) error {
+		body, err := decodeTyped[T](msg.Body())
+		if err != nil {
+			return onError(ctx, msg, err)
+		}
+		return handler(ctx, body)
+	})
+	return consumer
+}
+
+// TypedSend sends body to one handler on address.
+func TypedSend[T any](eb EventBus, address string, body T) error {
+	return eb.Send(address, body)
+}
+
+// TypedPublish publishes body to all handlers on address.
+func TypedPublish[T any](eb EventBus, address string, body T) error {
+	return eb.Publish(address, body)
+}
+
+// decodeTyped returns body as T, JSON-decoding it when it arrives as bytes
+func decodeTyped[T any](body interface{}) (T, error) {
+	var v T
+	switch b := body.(type) {
+	case T:
+		return b, nil // Already T, e.g. T is []byte
+	case []byte:
+		if err := JSONDecode(b, &v); err != nil {
+			return v, &EventBusError{Code: "DECODE_FAILED", Message: err.Error()}
+		}
+		return v, nil
+	}
+	return v, &EventBusError{Code: "DECODE_FAILED", Message: fmt.Sprintf("cannot decode %T into %T", body, v)}
+}
+
+// failDecode answers requests with code 400 and returns err for logging
+func failDecode(ctx FluxorContext, msg Message, err error) error {
+	if msg.ReplyAddress() == "" {
+		return err
+	}
+	if failErr := msg.Fail(400, err.Error()); failErr != nil {
+		return fmt.Errorf("%w (reply failed: %v)", err, failErr)
+	}
+	return err
+}
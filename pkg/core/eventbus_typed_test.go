@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type typedOrder struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestTypedConsumer_DecodesBody(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	got := make(chan typedOrder, 1)
+	TypedConsumer(eb, "orders.created", func(ctx FluxorContext, order typedOrder) error {
+		got <- order
+		return nil
+	})
+
+	if err := TypedSend(eb, "orders.created", typedOrder{ID: "o-1", Total: 42}); err != nil {
+		t.Fatalf("TypedSend() error = %v", err)
+	}
+	select {
+	case order := <-got:
+		if order.ID != "o-1" || order.Total != 42 {
+			t.Errorf("order = %+v, want o-1/42", order)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("typed handler not called")
+	}
+
+	if err := TypedPublish(eb, "orders.created", typedOrder{ID: "o-2"}); err != nil {
+		t.Fatalf("TypedPublish() error = %v", err)
+	}
+	select {
+	case order := <-got:
+		if order.ID != "o-2" {
+			t.Errorf("order = %+v, want o-2", order)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("typed handler not called for publish")
+	}
+}
+
+func TestTypedConsumer_DecodeErrorHandler(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	decodeErrs := make(chan error, 1)
+	TypedConsumerWithErrorHandler(eb, "orders.created", func(ctx FluxorContext, order typedOrder) error {
+		t.Error("handler should not be called for an undecodable body")
+		return nil
+	}, func(ctx FluxorContext, msg Message, err error) error {
+		decodeErrs <- err
+		return nil
+	})
+
+	if err := eb.Send("orders.created", "not an order"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case err := <-decodeErrs:
+		var ebErr *EventBusError
+		if !errors.As(err, &ebErr) || ebErr.Code != "DECODE_FAILED" {
+			t.Errorf("err = %v, want DECODE_FAILED", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("decode error handler not called")
+	}
+}
+
+func TestTypedConsumer_DefaultFailsRequest(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	TypedConsumer(eb, "math.square", func(ctx FluxorContext, n int) error {
+		t.Error("handler should not be called for an undecodable body")
+		return nil
+	})
+
+	reply, err := eb.Request("math.square", map[string]string{"n": "x"}, time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v, want a failure reply", err)
+	}
+	var out map[string]interface{}
+	if err := reply.DecodeBody(&out); err != nil || out["failureCode"] != float64(400) {
+		t.Errorf("reply = %v, %v; want failure code 400", out, err)
+	}
+}
+
+func TestTypedConsumer_RawBytes(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	got := make(chan []byte, 1)
+	TypedConsumer(eb, "raw", func(ctx FluxorContext, body []byte) error {
+		got <- body
+		return nil
+	})
+
+	if err := TypedSend(eb, "raw", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("TypedSend() error = %v", err)
+	}
+	select {
+	case body := <-got:
+		if string(body) != `{"a":1}` {
+			t.Errorf("body = %s, want raw JSON", body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("typed handler not called")
+	}
+}
+
+func TestTypedConsumer_FailFast(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("nil handler should panic")
+		}
+	}()
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	TypedConsumer[int](gocmd.EventBus(), "x", nil)
+}
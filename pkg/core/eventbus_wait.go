@@ -0,0 +1,186 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// DefaultConsumerWait is how long SendWithRetry waits for a consumer by default
+const DefaultConsumerWait = 5 * time.Second
+
+// consumerWaiter is implemented by buses that know when a local consumer registers
+type consumerWaiter interface {
+	waitConsumer(address string, timeout time.Duration) error
+}
+
+// listenerChecker is implemented by buses that know which addresses have listeners
+type listenerChecker interface {
+	hasListeners(address string) bool
+}
+
+// SendRetryOptions configures SendWithRetry
+type SendRetryOptions struct {
+	// MaxWait bounds how long to wait for a consumer (default: DefaultConsumerWait)
+	MaxWait time.Duration
+
+	// Headers are sent with the message, as with SendWithHeaders
+	Headers map[string]string
+}
+
+// WaitForConsumer waits until address has a consumer on eb, so a producer that
+// starts before the verticle consuming its messages doesn't lose the race.
+// Returns an ErrTimeout error after timeout, and ErrBusClosing if the bus closes.
+//
+// Only the in-memory bus, and the tenant, request ID and tracing wrappers around
+// it, can tell: cluster buses return an errors.ErrUnsupported error; use
+// SendWithRetry, which works with any bus.
+// Fail-fast: panics on a nil eventBus or a non-positive timeout
+func WaitForConsumer(eb EventBus, address string, timeout time.Duration) error {
+	failfast.NotNil(eb, "eventBus")
+	failfast.If(timeout > 0, "timeout must be positive")
+	if err := ValidateAddress(address); err != nil {
+		return err
+	}
+	return waitConsumer(eb, address, timeout)
+}
+
+// HasListeners reports whether a publish to address would reach a consumer or a
+// tap, so producers of optional events (progress updates, debug output) can skip
+// encoding them when nobody listens. Buses that can't tell (cluster buses, whose
+// consumers may be on other nodes) report true.
+// Fail-fast: panics on a nil eventBus
+func HasListeners(eb EventBus, address string) bool {
+	failfast.NotNil(eb, "eventBus")
+	return hasListeners(eb, address)
+}
+
+func hasListeners(eb EventBus, address string) bool {
+	if lc, ok := eb.(listenerChecker); ok {
+		return lc.hasListeners(address)
+	}
+	return true
+}
+
+func (eb *eventBus) hasListeners(address string) bool {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+	return len(eb.consumers[address]) > 0 || len(eb.taps[address]) > 0
+}
+
+// waitConsumer waits on eb when it can signal new consumers; wrappers call it
+// with the bus they wrap
+func waitConsumer(eb EventBus, address string, timeout time.Duration) error {
+	if w, ok := eb.(consumerWaiter); ok {
+		return w.waitConsumer(address, timeout)
+	}
+	return fmt.Errorf("wait for consumer on %s: %w", address, errors.ErrUnsupported)
+}
+
+// SendWithRetry sends body to address like Send, but when no handler is
+// registered yet (ErrNoHandlers) it waits up to opts.MaxWait for one and sends
+// again. Other errors are returned at once. Once MaxWait has passed, the last
+// ErrNoHandlers error is returned.
+//
+// On buses that can't signal a new consumer (see WaitForConsumer) it retries
+// with a short backoff instead.
+// Fail-fast: panics on a nil eventBus or a negative MaxWait
+func SendWithRetry(eb EventBus, address string, body interface{}, opts SendRetryOptions) error {
+	failfast.NotNil(eb, "eventBus")
+	failfast.If(opts.MaxWait >= 0, "max wait cannot be negative")
+	if opts.MaxWait == 0 {
+		opts.MaxWait = DefaultConsumerWait
+	}
+
+	deadline := time.Now().Add(opts.MaxWait)
+	backoff := 10 * time.Millisecond
+	for {
+		var err error
+		if opts.Headers != nil {
+			err = eb.SendWithHeaders(address, body, opts.Headers)
+		} else {
+			err = eb.Send(address, body)
+		}
+		if !errors.Is(err, ErrNoHandlers) {
+			return err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return err
+		}
+
+		werr := waitConsumer(eb, address, remaining)
+		if werr == nil {
+			continue // The consumer may be gone again by the time we send
+		}
+		if errors.Is(werr, ErrTimeout) {
+			return err
+		}
+		if !errors.Is(werr, errors.ErrUnsupported) {
+			return werr
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > 200*time.Millisecond {
+			backoff = 200 * time.Millisecond
+		}
+	}
+}
+
+func (eb *eventBus) waitConsumer(address string, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		eb.mu.RLock()
+		found := len(eb.consumers[address]) > 0
+		added := eb.consumerAdded
+		eb.mu.RUnlock()
+		if found {
+			return nil
+		}
+
+		select {
+		case <-added:
+		case <-timer.C:
+			return &EventBusError{Code: ErrTimeout.Code, Message: fmt.Sprintf("No consumer on %s after %v", address, timeout)}
+		case <-eb.ctx.Done():
+			return ErrBusClosing
+		}
+	}
+}
+
+func (t *TenantEventBus) waitConsumer(address string, timeout time.Duration) error {
+	return waitConsumer(t.EventBus, t.address(address), timeout)
+}
+
+func (r *RequestIDEventBus) waitConsumer(address string, timeout time.Duration) error {
+	return waitConsumer(r.EventBus, address, timeout)
+}
+
+func (t *TracingEventBus) waitConsumer(address string, timeout time.Duration) error {
+	return waitConsumer(t.EventBus, address, timeout)
+}
+
+func (b *isolatedEventBus) waitConsumer(address string, timeout time.Duration) error {
+	return waitConsumer(b.EventBus, address, timeout)
+}
+
+func (t *TenantEventBus) hasListeners(address string) bool {
+	return hasListeners(t.EventBus, t.address(address))
+}
+
+func (r *RequestIDEventBus) hasListeners(address string) bool {
+	return hasListeners(r.EventBus, address)
+}
+
+func (t *TracingEventBus) hasListeners(address string) bool {
+	return hasListeners(t.EventBus, address)
+}
+
+func (b *isolatedEventBus) hasListeners(address string) bool {
+	return hasListeners(b.EventBus, address)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitForConsumer(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	if err := WaitForConsumer(eb, "wait.none", 30*time.Millisecond); !errors.Is(err, ErrTimeout) {
+		t.Errorf("WaitForConsumer() without consumer error = %v, want ErrTimeout", err)
+	}
+
+	deployed := make(chan Consumer)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		deployed <- eb.Consumer("wait.late").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+	}()
+	if err := WaitForConsumer(eb, "wait.late", time.Second); err != nil {
+		t.Errorf("WaitForConsumer() error = %v", err)
+	}
+	waitReady(t, <-deployed)
+
+	// Wrappers wait on the bus they wrap, at the address they map to
+	if err := WaitForConsumer(NewRequestIDEventBus(eb, "req-1"), "wait.late", time.Second); err != nil {
+		t.Errorf("WaitForConsumer() through a request ID bus error = %v", err)
+	}
+	if err := WaitForConsumer(NewTracingEventBus(eb, TraceOptions{}), "wait.late", time.Second); err != nil {
+		t.Errorf("WaitForConsumer() through a tracing bus error = %v", err)
+	}
+	acme := NewTenantEventBus(eb, "acme")
+	if err := WaitForConsumer(acme, "wait.late", 30*time.Millisecond); !errors.Is(err, ErrTimeout) {
+		t.Errorf("WaitForConsumer() for another tenant's address error = %v, want ErrTimeout", err)
+	}
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		deployed <- acme.Consumer("wait.late").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+	}()
+	if err := WaitForConsumer(acme, "wait.late", time.Second); err != nil {
+		t.Errorf("WaitForConsumer() through a tenant bus error = %v", err)
+	}
+	waitReady(t, <-deployed)
+
+	// Buses that can't tell, and wrappers around them
+	opaque := struct{ EventBus }{eb}
+	if err := WaitForConsumer(NewTenantEventBus(opaque, "acme"), "wait.late", time.Second); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("WaitForConsumer() on an unsupported bus error = %v, want errors.ErrUnsupported", err)
+	}
+}
+
+func TestSendWithRetry(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	if err := eb.Send("retry.orders", "early"); !errors.Is(err, ErrNoHandlers) {
+		t.Fatalf("Send() before the consumer error = %v, want ErrNoHandlers", err)
+	}
+	if err := SendWithRetry(eb, "retry.none", "lost", SendRetryOptions{MaxWait: 30 * time.Millisecond}); !errors.Is(err, ErrNoHandlers) {
+		t.Errorf("SendWithRetry() without consumer error = %v, want ErrNoHandlers", err)
+	}
+
+	// The consumer deploys after the producer started, on the bus and through a wrapper
+	received := make(chan string, 2)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		eb.Consumer("retry.orders").Handler(func(ctx FluxorContext, msg Message) error {
+			received <- msg.Header("X-Source")
+			return nil
+		})
+		eb.Consumer("tenant.acme.retry.orders").Handler(func(ctx FluxorContext, msg Message) error {
+			received <- "tenant"
+			return nil
+		})
+	}()
+	if err := SendWithRetry(eb, "retry.orders", "order", SendRetryOptions{MaxWait: time.Second, Headers: map[string]string{"X-Source": "checkout"}}); err != nil {
+		t.Fatalf("SendWithRetry() error = %v", err)
+	}
+	if err := SendWithRetry(NewTenantEventBus(eb, "acme"), "retry.orders", "order", SendRetryOptions{MaxWait: time.Second}); err != nil {
+		t.Fatalf("SendWithRetry() through a wrapper error = %v", err)
+	}
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case source := <-received:
+			got[source] = true
+		case <-time.After(time.Second):
+			t.Fatalf("messages not delivered, got %v", got)
+		}
+	}
+	if !got["checkout"] || !got["tenant"] {
+		t.Errorf("received %v, want checkout and tenant", got)
+	}
+
+	// Buses that can't signal a new consumer are polled instead
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		eb.Consumer("retry.polled").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+	}()
+	opaque := struct{ EventBus }{eb}
+	if err := SendWithRetry(NewRequestIDEventBus(opaque, "req-1"), "retry.polled", "order", SendRetryOptions{MaxWait: time.Second}); err != nil {
+		t.Errorf("SendWithRetry() on an unsupported bus error = %v", err)
+	}
+}
+
+func TestHasListeners(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	if HasListeners(eb, "listen.none") {
+		t.Error("HasListeners() without consumers or taps = true")
+	}
+	untap, err := Tap(eb, "listen.tapped", func(Message) {})
+	if err != nil {
+		t.Fatalf("Tap() error = %v", err)
+	}
+	if !HasListeners(eb, "listen.tapped") {
+		t.Error("HasListeners() with a tap = false")
+	}
+	untap()
+	if HasListeners(eb, "listen.tapped") {
+		t.Error("HasListeners() after untap = true")
+	}
+
+	acme := NewTenantEventBus(eb, "acme")
+	consumer := acme.Consumer("listen.orders").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+	if !HasListeners(acme, "listen.orders") || HasListeners(eb, "listen.orders") {
+		t.Error("HasListeners() should see the consumer only at the tenant's address")
+	}
+	_ = consumer.Unregister()
+
+	// Buses that can't tell always report listeners
+	if !HasListeners(struct{ EventBus }{eb}, "listen.none") {
+		t.Error("HasListeners() on an unsupported bus = false, want true")
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -10,6 +11,19 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+const (
+	// DefaultWSRequestTimeout is how long a bridged request waits for a reply
+	// when the client gives no timeout
+	DefaultWSRequestTimeout = 5 * time.Second
+
+	// MaxWSRequestTimeout caps the timeout a client may ask for
+	MaxWSRequestTimeout = 30 * time.Second
+
+	// MaxWSPendingRequests is how many requests one connection may have in
+	// flight; further request frames wait until one completes
+	MaxWSPendingRequests = 64
+)
+
 // WebSocketEventBusBridge bridges WebSocket connections to EventBus
 type WebSocketEventBusBridge struct {
 	eventBus EventBus
@@ -25,20 +39,31 @@ type wsClient struct {
 	bridge         *WebSocketEventBusBridge
 	subscriptions  map[string]Consumer // address -> consumer
 	mu             sync.RWMutex
+	writeMu        sync.Mutex // gorilla connections allow one concurrent writer
 	requestID      int64
 	requestMu      sync.Mutex
 	pendingReplies map[string]chan *wsMessage // requestID -> reply channel
 	replyMu        sync.Mutex
+	requests       chan struct{} // in-flight request slots
 }
 
-// wsMessage represents a WebSocket message
+// wsMessage represents a WebSocket message.
+//
+// Request/reply frames:
+//
+//	-> {"op":"request","id":"req-1","address":"user.get","body":{...},"timeout":5000}
+//	<- {"op":"reply","id":"req-1","address":"user.get","result":{...},"headers":{...}}
+//	<- {"op":"reply","id":"req-1","address":"user.get","error":"Request timeout","code":"TIMEOUT"}
+//
+// The id is chosen by the client and echoed back, so replies may arrive in any order.
 type wsMessage struct {
-	Op      string            `json:"op"`      // publish, send, request, subscribe, unsubscribe
+	Op      string            `json:"op"`      // publish, send, request, subscribe, unsubscribe (client); reply, message (server)
 	Address string            `json:"address"` // EventBus address
 	Body    interface{}       `json:"body"`    // Message body
 	ID      string            `json:"id"`      // Request ID for request/reply
 	Timeout int64             `json:"timeout"` // Timeout in milliseconds
 	Error   string            `json:"error,omitempty"`
+	Code    string            `json:"code,omitempty"` // EventBusError code of a failed request, e.g. TIMEOUT
 	Result  interface{}       `json:"result,omitempty"`
 	Headers map[string]string `json:"headers,omitempty"`
 }
@@ -72,6 +97,7 @@ func (b *WebSocketEventBusBridge) HandleWebSocket(w http.ResponseWriter, r *http
 		bridge:         b,
 		subscriptions:  make(map[string]Consumer),
 		pendingReplies: make(map[string]chan *wsMessage),
+		requests:       make(chan struct{}, MaxWSPendingRequests),
 	}
 
 	b.mu.Lock()
@@ -157,31 +183,72 @@ func (c *wsClient) handleSend(msg *wsMessage) {
 	c.sendResult(msg, map[string]interface{}{"status": "ok"})
 }
 
-// handleRequest handles request operation
+// handleRequest handles request operation. The request runs in its own
+// goroutine so a slow handler does not hold up the client's other frames;
+// the EventBus unregisters the temporary reply consumer on reply or timeout.
+// At most MaxWSPendingRequests run per connection: past that, reading the
+// client's frames waits for a free slot.
 func (c *wsClient) handleRequest(msg *wsMessage) {
-	// Fail-fast: validate address
+	// Fail-fast: validate address and correlation ID
 	if err := ValidateAddress(msg.Address); err != nil {
-		c.sendError(msg, err.Error())
+		c.sendReply(msg, nil, err)
 		return
 	}
-	timeout := 5 * time.Second
-	if msg.Timeout > 0 {
-		timeout = time.Duration(msg.Timeout) * time.Millisecond
+	if msg.ID == "" {
+		c.sendReply(msg, nil, &EventBusError{Code: "INVALID_INPUT", Message: "request id cannot be empty"})
+		return
 	}
+	timeout := wsRequestTimeout(msg.Timeout)
+
+	c.requests <- struct{}{}
+	// Hidden: goroutine creation
+	go func() {
+		defer func() { <-c.requests }()
+		reply, err := c.bridge.eventBus.Request(msg.Address, msg.Body, timeout)
+		c.sendReply(msg, reply, err)
+	}()
+}
 
-	reply, err := c.bridge.eventBus.Request(msg.Address, msg.Body, timeout)
-	if err != nil {
-		c.sendError(msg, err.Error())
-		return
+// wsRequestTimeout converts a client's timeout in milliseconds, applying
+// DefaultWSRequestTimeout and MaxWSRequestTimeout
+func wsRequestTimeout(ms int64) time.Duration {
+	if ms <= 0 {
+		return DefaultWSRequestTimeout
+	}
+	if ms >= int64(MaxWSRequestTimeout/time.Millisecond) {
+		return MaxWSRequestTimeout
 	}
+	return time.Duration(ms) * time.Millisecond
+}
 
-	var replyBody interface{}
-	if err := reply.DecodeBody(&replyBody); err != nil {
-		c.sendError(msg, fmt.Sprintf("failed to decode reply: %v", err))
-		return
+// sendReply sends the reply frame for a request
+func (c *wsClient) sendReply(msg *wsMessage, reply Message, err error) {
+	response := &wsMessage{
+		Op:      "reply",
+		ID:      msg.ID,
+		Address: msg.Address,
+	}
+	if err == nil {
+		var replyBody interface{}
+		if decodeErr := reply.DecodeBody(&replyBody); decodeErr != nil {
+			err = fmt.Errorf("failed to decode reply: %w", decodeErr)
+		} else {
+			response.Result = replyBody
+			response.Headers = reply.Headers()
+		}
+	}
+	if err != nil {
+		response.Error = err.Error()
+		var ebErr *EventBusError
+		if errors.As(err, &ebErr) {
+			response.Code = ebErr.Code
+		}
 	}
 
-	c.sendResult(msg, replyBody)
+	if err := c.writeJSON(response); err != nil {
+		// Client went away while the request was in flight; nothing to deliver to
+		c.bridge.logger.Debug("failed to send reply to client", "error", err)
+	}
 }
 
 // handleSubscribe handles subscribe operation
@@ -214,11 +281,7 @@ func (c *wsClient) handleSubscribe(msg *wsMessage) {
 			Headers: eventMsg.Headers(),
 		}
 
-		c.mu.RLock()
-		err := c.conn.WriteJSON(wsMsg)
-		c.mu.RUnlock()
-
-		if err != nil {
+		if err := c.writeJSON(wsMsg); err != nil {
 			c.bridge.logger.Error("failed to send message to client", "error", err)
 		}
 
@@ -262,9 +325,9 @@ func (c *wsClient) sendError(msg *wsMessage, errorMsg string) {
 		Error: errorMsg,
 	}
 
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	c.conn.WriteJSON(response)
+	if err := c.writeJSON(response); err != nil {
+		// Best-effort; ignore on error.
+	}
 }
 
 // sendResult sends a success response
@@ -275,9 +338,16 @@ func (c *wsClient) sendResult(msg *wsMessage, result interface{}) {
 		Result: result,
 	}
 
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	c.conn.WriteJSON(response)
+	if err := c.writeJSON(response); err != nil {
+		// Best-effort; ignore on error.
+	}
+}
+
+// writeJSON serializes writes to the connection
+func (c *wsClient) writeJSON(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(v)
 }
 
 // cleanup cleans up client resources
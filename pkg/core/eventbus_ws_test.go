@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dialTestBridge(t *testing.T, eb EventBus) *websocket.Conn {
+	t.Helper()
+	bridge := NewWebSocketEventBusBridge(eb)
+	server := httptest.NewServer(http.HandlerFunc(bridge.HandleWebSocket))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWebSocketBridge_RequestReply(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	eb.Consumer("ws.slow").Handler(func(ctx FluxorContext, msg Message) error {
+		time.Sleep(300 * time.Millisecond)
+		return msg.Reply(map[string]string{"late": "true"})
+	})
+	eb.Consumer("ws.echo").Handler(func(ctx FluxorContext, msg Message) error {
+		var body map[string]interface{}
+		if err := msg.DecodeBody(&body); err != nil {
+			return err
+		}
+		return msg.Reply(body)
+	})
+
+	conn := dialTestBridge(t, eb)
+
+	// The slow request times out; the echo sent after it must not wait for it
+	if err := conn.WriteJSON(wsMessage{Op: "request", ID: "slow", Address: "ws.slow", Body: map[string]string{}, Timeout: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteJSON(wsMessage{Op: "request", ID: "echo", Address: "ws.echo", Body: map[string]string{"name": "ada"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	replies := make(map[string]wsMessage)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for len(replies) < 2 {
+		var frame wsMessage
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if frame.Op != "reply" {
+			t.Fatalf("op = %q, want reply", frame.Op)
+		}
+		if len(replies) == 0 && frame.ID != "echo" {
+			t.Errorf("first reply = %q, want echo", frame.ID)
+		}
+		replies[frame.ID] = frame
+	}
+
+	echo := replies["echo"]
+	if result, _ := echo.Result.(map[string]interface{}); echo.Error != "" || result["name"] != "ada" {
+		t.Errorf("echo reply = %+v", echo)
+	}
+	if slow := replies["slow"]; slow.Code != "TIMEOUT" || slow.Error == "" {
+		t.Errorf("slow reply = %+v, want TIMEOUT error", slow)
+	}
+}
+
+func TestWebSocketBridge_RequestRequiresID(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	conn := dialTestBridge(t, gocmd.EventBus())
+	if err := conn.WriteJSON(wsMessage{Op: "request", Address: "ws.echo", Body: map[string]string{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var frame wsMessage
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := conn.ReadJSON(&frame); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if frame.Op != "reply" || frame.Code != "INVALID_INPUT" {
+		t.Errorf("reply = %+v, want INVALID_INPUT", frame)
+	}
+}
+
+func TestWSRequestTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		ms   int64
+		want time.Duration
+	}{
+		{0, DefaultWSRequestTimeout},
+		{-1, DefaultWSRequestTimeout},
+		{100, 100 * time.Millisecond},
+		{int64(MaxWSRequestTimeout/time.Millisecond) + 1, MaxWSRequestTimeout},
+		{1 << 62, MaxWSRequestTimeout},
+	} {
+		if got := wsRequestTimeout(tc.ms); got != tc.want {
+			t.Errorf("wsRequestTimeout(%d) = %v, want %v", tc.ms, got, tc.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -17,6 +18,14 @@ type GoCMD interface {
 	// DeployVerticle deploys a verticle
 	DeployVerticle(verticle Verticle) (string, error)
 
+	// DeployVerticleWithOptions deploys a verticle with options, e.g. a dedicated
+	// executor for its consumers
+	DeployVerticleWithOptions(verticle Verticle, opts DeploymentOptions) (string, error)
+
+	// DeployVerticleH deploys a verticle like DeployVerticle and returns a handle
+	// to it instead of the raw deployment ID
+	DeployVerticleH(verticle Verticle) (Deployment, error)
+
 	// UndeployVerticle undeploys a verticle
 	UndeployVerticle(deploymentID string) error
 
@@ -28,6 +37,18 @@ type GoCMD interface {
 
 	// Context returns the root context
 	Context() context.Context
+
+	// OnShutdown subscribes hook to ShutdownAddress. Close publishes the event
+	// before stopping verticles and waits up to ShutdownGracePeriod for hooks
+	OnShutdown(hook func(ctx FluxorContext) error)
+
+	// OnShutdownPhase registers hook to run in phase of Close (see ShutdownPhase).
+	// Hooks of a phase run concurrently, bounded together by ShutdownGracePeriod
+	OnShutdownPhase(phase ShutdownPhase, hook func(ctx context.Context) error)
+
+	// ExecuteBlocking runs fn on the blocking pool and returns its pending result,
+	// so slow I/O does not hold up a consumer's message loop
+	ExecuteBlocking(fn func() (interface{}, error)) Future
 }
 
 // gocmd implements GoCMD
@@ -47,7 +68,17 @@ type gocmd struct {
 	rootCtx     context.Context    // renamed from 'ctx' for clarity: this is the root context.Context
 	rootCancel  context.CancelFunc // renamed from 'cancel' for clarity
 	logger      Logger
-	closed      bool // tracks if Close() has been called
+	closed      bool          // tracks if Close() has been called
+	stopTimeout time.Duration // bound on each verticle's Stop()
+
+	shutdownGrace time.Duration // bound on OnShutdown hooks in Close()
+	drainTimeout  time.Duration // bound on draining the EventBus in Close(); 0 skips it
+	shutdownHooks int           // hooks registered via OnShutdown
+	shutdownAcks  chan struct{} // set by Close(); hooks signal completion on it
+
+	phaseHooks [shutdownPhaseCount][]func(ctx context.Context) error // hooks registered via OnShutdownPhase
+
+	blocking *blockingPool // runs ExecuteBlocking calls; stops accepting when rootCtx is cancelled
 }
 
 // GoCMDOptions configures GoCMD construction.
@@ -57,8 +88,52 @@ type GoCMDOptions struct {
 	//
 	// The factory is called after the GoCMD struct is created so implementations can reference GoCMD.
 	EventBusFactory func(ctx context.Context, gocmd GoCMD) (EventBus, error)
+
+	// StopTimeout bounds how long UndeployVerticle and Close wait for each verticle's Stop().
+	// A verticle that exceeds it is forced to STOPPED and shutdown continues. Default: 5s.
+	StopTimeout time.Duration
+
+	// ShutdownGracePeriod bounds how long Close waits for OnShutdown hooks after
+	// publishing ShutdownAddress. Default: 2s.
+	ShutdownGracePeriod time.Duration
+
+	// EventBusDrainTimeout, if set, makes Close drain the EventBus before stopping
+	// verticles: new messages are rejected and consumers get up to this long to
+	// handle what is already queued (see GracefulCloser). Default: 0, queued
+	// messages are dropped.
+	EventBusDrainTimeout time.Duration
+
+	// BlockingPoolSize bounds how many ExecuteBlocking calls run at once; further
+	// calls wait for a free slot, up to BlockingQueueSize. Default: DefaultBlockingPoolSize.
+	BlockingPoolSize int
+
+	// BlockingQueueSize bounds how many ExecuteBlocking calls wait for a slot;
+	// further calls fail their Future with ErrBlockingQueueFull.
+	// Default: DefaultBlockingQueueSize.
+	BlockingQueueSize int
+
+	// MaxMessageSize bounds encoded message bodies on the in-memory EventBus, in bytes;
+	// larger ones are rejected with a MESSAGE_TOO_LARGE error before they are enqueued.
+	// Default: DefaultMaxMessageSize. Cluster buses take theirs from their config.
+	MaxMessageSize int
+
+	// RequestTimeout is the timeout the in-memory EventBus gives Request and
+	// RequestStream calls made with timeout <= 0; a positive per-call timeout
+	// always wins. Default: DefaultRequestTimeout. Cluster buses take theirs from
+	// their config.
+	RequestTimeout time.Duration
+
+	// ReportBackpressure makes the in-memory EventBus report full consumer mailboxes
+	// instead of dropping silently: Publish and PublishBatch still deliver to the
+	// consumers with room but return ErrConsumerBackpressure naming the address, and
+	// Send and Request return it in place of ErrTimeout (it still matches ErrTimeout).
+	// Delivery never blocks either way. Default: false, drops only show in Stats.
+	ReportBackpressure bool
 }
 
+// DefaultStopTimeout is the default bound on a verticle's Stop()
+const DefaultStopTimeout = 5 * time.Second
+
 // DeploymentState represents the lifecycle state of a deployed verticle.
 //
 // This acts as a state machine with the following states and valid transitions:
@@ -118,12 +193,57 @@ func NewGoCMD(ctx context.Context) GoCMD {
 // The provided ctx becomes the parent of the root context. When the parent is
 // cancelled, the GoCMD instance will also be cancelled.
 func NewGoCMDWithOptions(ctx context.Context, opts GoCMDOptions) (GoCMD, error) {
+	if opts.StopTimeout < 0 {
+		return nil, fmt.Errorf("stop timeout cannot be negative")
+	}
+	if opts.StopTimeout == 0 {
+		opts.StopTimeout = DefaultStopTimeout
+	}
+	if opts.ShutdownGracePeriod < 0 {
+		return nil, fmt.Errorf("shutdown grace period cannot be negative")
+	}
+	if opts.ShutdownGracePeriod == 0 {
+		opts.ShutdownGracePeriod = DefaultShutdownGracePeriod
+	}
+	if opts.EventBusDrainTimeout < 0 {
+		return nil, fmt.Errorf("event bus drain timeout cannot be negative")
+	}
+	if opts.BlockingPoolSize < 0 {
+		return nil, fmt.Errorf("blocking pool size cannot be negative")
+	}
+	if opts.BlockingPoolSize == 0 {
+		opts.BlockingPoolSize = DefaultBlockingPoolSize
+	}
+	if opts.BlockingQueueSize < 0 {
+		return nil, fmt.Errorf("blocking queue size cannot be negative")
+	}
+	if opts.BlockingQueueSize == 0 {
+		opts.BlockingQueueSize = DefaultBlockingQueueSize
+	}
+	if opts.MaxMessageSize < 0 {
+		return nil, fmt.Errorf("max message size cannot be negative")
+	}
+	if opts.RequestTimeout < 0 {
+		return nil, fmt.Errorf("request timeout cannot be negative")
+	}
+	if opts.RequestTimeout > 0 {
+		if err := ValidateTimeout(opts.RequestTimeout); err != nil {
+			return nil, fmt.Errorf("request timeout: %w", err)
+		}
+	}
+
 	rootCtx, rootCancel := context.WithCancel(ctx)
 	g := &gocmd{
 		deployments: make(map[string]*deployment),
 		rootCtx:     rootCtx,
 		rootCancel:  rootCancel,
 		logger:      NewDefaultLogger(),
+		stopTimeout: opts.StopTimeout,
+
+		shutdownGrace: opts.ShutdownGracePeriod,
+		drainTimeout:  opts.EventBusDrainTimeout,
+
+		blocking: newBlockingPool(rootCtx, opts.BlockingPoolSize, opts.BlockingQueueSize),
 	}
 
 	if opts.EventBusFactory != nil {
@@ -137,7 +257,15 @@ func NewGoCMDWithOptions(ctx context.Context, opts GoCMDOptions) (GoCMD, error)
 	}
 
 	// Default: in-memory EventBus.
-	g.eventBus = NewEventBus(rootCtx, g)
+	if opts.MaxMessageSize == 0 {
+		opts.MaxMessageSize = DefaultMaxMessageSize
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = DefaultRequestTimeout
+	}
+	bus := newEventBus(rootCtx, g, opts.MaxMessageSize, opts.RequestTimeout)
+	bus.reportBackpressure = opts.ReportBackpressure
+	g.eventBus = bus
 	return g, nil
 }
 
@@ -146,18 +274,34 @@ func (g *gocmd) EventBus() EventBus {
 }
 
 func (g *gocmd) DeployVerticle(verticle Verticle) (string, error) {
+	return g.DeployVerticleWithOptions(verticle, DeploymentOptions{})
+}
+
+func (g *gocmd) DeployVerticleWithOptions(verticle Verticle, opts DeploymentOptions) (string, error) {
 	// Fail-fast: validate verticle immediately
 	if err := ValidateVerticle(verticle); err != nil {
 		return "", err
 	}
 
 	deploymentID := generateDeploymentID()
-	fluxorCtx := newFluxorContext(g.rootCtx, g)
+	// Each deployment gets its own child of rootCtx, cancelled on undeploy or Close
+	depCtx, depCancel := context.WithCancel(g.rootCtx)
+	bus, err := g.newIsolatedEventBus(depCtx, opts)
+	if err != nil {
+		depCancel()
+		return "", err
+	}
+	fluxorCtx := newFluxorContext(depCtx, g)
+	if bus != nil {
+		fluxorCtx.(*gocmdContext).eventBus = bus
+	}
 
 	dep := &deployment{
 		id:        deploymentID,
 		verticle:  verticle,
 		fluxorCtx: fluxorCtx,
+		cancel:    depCancel,
+		bus:       bus,
 		state:     DeploymentStatePending,
 	}
 
@@ -171,14 +315,17 @@ func (g *gocmd) DeployVerticle(verticle Verticle) (string, error) {
 	// Start verticle in goroutine - framework handles blocking operations
 	// Single Start() method - no need for AsyncStart
 	go func() {
-		if err := verticle.Start(fluxorCtx); err != nil {
+		if err := g.startVerticle(deploymentID, verticle, fluxorCtx); err != nil {
 			// State machine transition: PENDING -> FAILED
 			// Remove from map on failure (FAILED is terminal state)
 			g.mu.Lock()
 			dep.state = DeploymentStateFailed
 			delete(g.deployments, deploymentID)
 			g.mu.Unlock()
+			depCancel()
+			g.closeIsolatedEventBus(dep)
 			g.logger.Error(fmt.Sprintf("verticle start failed for deployment %s: %v", deploymentID, err))
+			g.publishDeploymentEvent(DeploymentStoppedAddress, dep, err)
 			return
 		}
 
@@ -186,11 +333,41 @@ func (g *gocmd) DeployVerticle(verticle Verticle) (string, error) {
 		g.mu.Lock()
 		dep.state = DeploymentStateStarted
 		g.mu.Unlock()
+		g.publishDeploymentEvent(DeploymentStartedAddress, dep, nil)
 	}()
 
 	return deploymentID, nil
 }
 
+// startVerticle calls Start, converting a panic into an error (panic isolation)
+// so a misbehaving verticle fails its deployment instead of crashing the process
+func (g *gocmd) startVerticle(deploymentID string, verticle Verticle, ctx FluxorContext) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.logger.WithFields(map[string]interface{}{
+				"deployment_id": deploymentID,
+				"stack":         string(debug.Stack()),
+			}).Error(fmt.Sprintf("verticle start panicked for deployment %s: %v", deploymentID, r))
+			err = fmt.Errorf("verticle start panicked: %v", r)
+		}
+	}()
+	return verticle.Start(ctx)
+}
+
+// callStop calls Stop, converting a panic into an error like startVerticle does
+func (g *gocmd) callStop(dep *deployment) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.logger.WithFields(map[string]interface{}{
+				"deployment_id": dep.id,
+				"stack":         string(debug.Stack()),
+			}).Error(fmt.Sprintf("verticle stop panicked for deployment %s: %v", dep.id, r))
+			err = fmt.Errorf("verticle stop panicked: %v", r)
+		}
+	}()
+	return dep.verticle.Stop(dep.fluxorCtx)
+}
+
 // canTransitionToStopping validates if a deployment can transition to STOPPING state.
 // State machine validation: only PENDING (during shutdown) or STARTED can transition to STOPPING.
 func canTransitionToStopping(state DeploymentState, isShuttingDown bool) bool {
@@ -234,10 +411,10 @@ func (g *gocmd) UndeployVerticle(deploymentID string) error {
 	default:
 	}
 
-	if !canTransitionToStopping(dep.state, isShuttingDown) {
+	if state := dep.state; !canTransitionToStopping(state, isShuttingDown) {
 		g.mu.Unlock()
 		// Return backward-compatible error codes for state machine validation
-		switch dep.state {
+		switch state {
 		case DeploymentStatePending:
 			return &EventBusError{Code: "DEPLOYMENT_PENDING", Message: "Cannot undeploy pending deployment: " + deploymentID}
 		case DeploymentStateStopping, DeploymentStateStopped:
@@ -256,15 +433,52 @@ func (g *gocmd) UndeployVerticle(deploymentID string) error {
 
 	// Stop verticle - framework handles blocking operations
 	// Single Stop() method - no need for AsyncStop
+	go g.stopVerticle(dep)
+
+	return nil
+}
+
+// stopVerticle cancels the deployment's context, calls Stop, waiting at most stopTimeout,
+// then marks the deployment STOPPED. Cancelling first lets goroutines started in Start()
+// exit before Stop() waits for them.
+// A Stop() that overruns keeps running in the background but no longer blocks shutdown.
+func (g *gocmd) stopVerticle(dep *deployment) {
+	dep.cancel()
+
+	done := make(chan error, 1)
 	go func() {
-		if err := dep.verticle.Stop(dep.fluxorCtx); err != nil {
-			g.logger.Error(fmt.Sprintf("verticle stop failed for deployment %s: %v", deploymentID, err))
-		}
-		// State machine transition: STOPPING -> STOPPED (terminal state)
-		dep.state = DeploymentStateStopped
+		done <- g.callStop(dep)
 	}()
 
-	return nil
+	timer := time.NewTimer(g.stopTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			g.logger.Error(fmt.Sprintf("verticle stop failed for deployment %s: %v", dep.id, err))
+		}
+	case <-timer.C:
+		g.logger.Info(fmt.Sprintf("verticle stop for deployment %s did not finish within %v; forcing STOPPED", dep.id, g.stopTimeout))
+	}
+
+	g.closeIsolatedEventBus(dep)
+
+	// State machine transition: STOPPING -> STOPPED (terminal state)
+	g.mu.Lock()
+	dep.state = DeploymentStateStopped
+	g.mu.Unlock()
+	g.publishDeploymentEvent(DeploymentStoppedAddress, dep, nil)
+}
+
+// closeIsolatedEventBus releases a deployment's dedicated executor, if it has one
+func (g *gocmd) closeIsolatedEventBus(dep *deployment) {
+	if dep.bus == nil {
+		return
+	}
+	if err := dep.bus.close(g.stopTimeout); err != nil {
+		g.logger.Info(fmt.Sprintf("executor shutdown for deployment %s: %v", dep.id, err))
+	}
 }
 
 // DeploymentCount returns the number of deployed verticles
@@ -274,13 +488,25 @@ func (g *gocmd) DeploymentCount() int {
 	return len(g.deployments)
 }
 
+// ExecuteBlocking runs fn on the blocking pool; calls still waiting for a slot
+// when the instance is closed fail with context.Canceled
+func (g *gocmd) ExecuteBlocking(fn func() (interface{}, error)) Future {
+	return g.blocking.execute(fn)
+}
+
 // Close gracefully shuts down the GoCMD instance.
 //
 // Shutdown order:
-//  1. Undeploy all verticles (calls Stop on each)
-//  2. Cancel the root context (signals all children to stop)
-//  3. Close the EventBus (which also cancels its internal context - intentionally
-//     redundant as defense-in-depth since EventBus.ctx is a child of rootCtx)
+//  1. Publish ShutdownAddress and wait for OnShutdown hooks
+//  2. PhaseStopAccepting, then PhaseDrainRequests hooks: servers stop accepting
+//     and finish in-flight requests while verticles and the EventBus still work
+//  3. Drain the EventBus, if EventBusDrainTimeout is set
+//  4. Cancel the root context (signals all children to stop)
+//  5. PhaseStopVerticles hooks, then undeploy all verticles (calls Stop on each,
+//     bounded by GoCMDOptions.StopTimeout)
+//  6. PhaseCloseEventBus hooks, then close the EventBus (which also cancels its
+//     internal context - intentionally redundant as defense-in-depth since
+//     EventBus.ctx is a child of rootCtx)
 func (g *gocmd) Close() error {
 	g.mu.Lock()
 	// Check if already closed to avoid double-close deadlock
@@ -295,7 +521,17 @@ func (g *gocmd) Close() error {
 	}
 	g.mu.Unlock()
 
-	// Cancel root context first to signal all goroutines to stop
+	// Announce shutdown while the bus and root context are still usable
+	g.broadcastShutdown()
+
+	// Servers go first, so in-flight requests can still reach verticles over the bus
+	g.runShutdownPhase(PhaseStopAccepting)
+	g.runShutdownPhase(PhaseDrainRequests)
+
+	// Let consumers finish queued messages before the root context stops them
+	g.drainEventBus()
+
+	// Cancel root context to signal all goroutines to stop
 	// This will cause pending Start() calls to fail gracefully
 	g.rootCancel()
 
@@ -303,6 +539,8 @@ func (g *gocmd) Close() error {
 	// This gives goroutines time to finish their Start() and update state
 	time.Sleep(100 * time.Millisecond)
 
+	g.runShutdownPhase(PhaseStopVerticles)
+
 	// Undeploy all verticles (including any that are still PENDING)
 	// Stop all verticles concurrently and wait for completion
 	var stopWg sync.WaitGroup
@@ -327,28 +565,15 @@ func (g *gocmd) Close() error {
 			}
 			g.mu.Unlock()
 
-			// Stop verticle
-			if err := d.verticle.Stop(d.fluxorCtx); err != nil {
-				g.logger.Error(fmt.Sprintf("verticle stop failed for deployment %s: %v", id, err))
-			}
-			// State machine transition: STOPPING -> STOPPED (terminal state)
-			d.state = DeploymentStateStopped
+			// Stop verticle (bounded by stopTimeout, so one stuck verticle can't block exit)
+			g.stopVerticle(d)
 		}(dep, dep.id)
 	}
 
-	// Wait for all stop operations to complete with timeout
-	done := make(chan struct{})
-	go func() {
-		stopWg.Wait()
-		close(done)
-	}()
-	select {
-	case <-done:
-		// All stops completed
-	case <-time.After(5 * time.Second):
-		// Timeout - log but continue with shutdown
-		g.logger.Info("Some verticles did not stop within timeout during Close()")
-	}
+	// Wait for all stop operations; each is bounded by stopTimeout
+	stopWg.Wait()
+
+	g.runShutdownPhase(PhaseCloseEventBus)
 
 	// Close EventBus (its internal cancel is redundant but kept for defense-in-depth)
 	return g.eventBus.Close()
@@ -371,12 +596,15 @@ func (g *gocmd) Context() context.Context {
 // Ownership:
 //   - fluxorCtx is valid for the lifetime of this deployment
 //   - After UndeployVerticle, the fluxorCtx should not be used by the verticle
-//   - The underlying context.Context is cancelled when GoCMD.Close() is called
+//   - The underlying context.Context is cancelled when the deployment is undeployed,
+//     fails to start, or GoCMD.Close() is called
 type deployment struct {
 	id        string
 	verticle  Verticle
-	fluxorCtx FluxorContext   // renamed from 'ctx' for clarity: this is FluxorContext, not context.Context
-	state     DeploymentState // tracks lifecycle state
+	fluxorCtx FluxorContext      // renamed from 'ctx' for clarity: this is FluxorContext, not context.Context
+	cancel    context.CancelFunc // cancels fluxorCtx.Context()
+	bus       *isolatedEventBus  // dedicated executor's bus; nil when using the shared one
+	state     DeploymentState    // tracks lifecycle state
 }
 
 func generateDeploymentID() string {
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+)
+
+// DeploymentOptions configures DeployVerticleWithOptions.
+type DeploymentOptions struct {
+	// Workers > 0 gives the verticle a dedicated executor for the consumers it
+	// creates through ctx.EventBus(), so its slow handlers cannot starve other
+	// verticles (and theirs cannot starve it). 0 uses the shared EventBus executor.
+	// An in-memory consumer holds one worker for its lifetime, so size this to at
+	// least the number of consumers the verticle registers.
+	Workers int
+
+	// QueueSize bounds the dedicated executor's queue. Default: 1000.
+	QueueSize int
+}
+
+// Deployment is a handle to a deployed verticle, returned by DeployVerticleH
+type Deployment interface {
+	// ID returns the deployment ID, as accepted by UndeployVerticle
+	ID() string
+
+	// Verticle returns the deployed verticle
+	Verticle() Verticle
+
+	// State returns the current lifecycle state; Start runs asynchronously,
+	// so it is PENDING right after deployment
+	State() DeploymentState
+
+	// Undeploy undeploys the verticle, like UndeployVerticle(ID())
+	Undeploy() error
+}
+
+// DeployVerticleH implements GoCMD.
+func (g *gocmd) DeployVerticleH(verticle Verticle) (Deployment, error) {
+	id, err := g.DeployVerticle(verticle)
+	if err != nil {
+		return nil, err
+	}
+	g.mu.RLock()
+	dep := g.deployments[id]
+	g.mu.RUnlock()
+	if dep == nil {
+		// Start already failed and removed it; keep the state readable
+		dep = &deployment{id: id, verticle: verticle, state: DeploymentStateFailed}
+	}
+	return &deploymentHandle{gocmd: g, dep: dep}, nil
+}
+
+type deploymentHandle struct {
+	gocmd *gocmd
+	dep   *deployment
+}
+
+func (h *deploymentHandle) ID() string { return h.dep.id }
+
+func (h *deploymentHandle) Verticle() Verticle { return h.dep.verticle }
+
+func (h *deploymentHandle) State() DeploymentState {
+	h.gocmd.mu.RLock()
+	defer h.gocmd.mu.RUnlock()
+	return h.dep.state
+}
+
+func (h *deploymentHandle) Undeploy() error {
+	return h.gocmd.UndeployVerticle(h.dep.id)
+}
+
+// String returns the state name, e.g. "STARTED"
+func (s DeploymentState) String() string {
+	switch s {
+	case DeploymentStatePending:
+		return "PENDING"
+	case DeploymentStateStarted:
+		return "STARTED"
+	case DeploymentStateFailed:
+		return "FAILED"
+	case DeploymentStateStopping:
+		return "STOPPING"
+	case DeploymentStateStopped:
+		return "STOPPED"
+	default:
+		return fmt.Sprintf("DeploymentState(%d)", int(s))
+	}
+}
+
+// executorConsumers is implemented by buses that can run a consumer on a given executor
+type executorConsumers interface {
+	consumerOn(address string, executor concurrency.Executor) Consumer
+}
+
+// isolatedEventBus is the EventBus seen by a verticle deployed with a dedicated
+// executor: consumers it creates run on that executor and are unregistered on undeploy
+type isolatedEventBus struct {
+	EventBus
+	executor concurrency.Executor
+
+	mu        sync.Mutex
+	consumers []Consumer
+}
+
+func (b *isolatedEventBus) Consumer(address string) Consumer {
+	c := b.EventBus.(executorConsumers).consumerOn(address, b.executor)
+	b.mu.Lock()
+	b.consumers = append(b.consumers, c)
+	b.mu.Unlock()
+	return c
+}
+
+// close unregisters the deployment's consumers and shuts its executor down, waiting at most timeout
+func (b *isolatedEventBus) close(timeout time.Duration) error {
+	b.mu.Lock()
+	consumers := b.consumers
+	b.consumers = nil
+	b.mu.Unlock()
+
+	for _, c := range consumers {
+		if err := c.Unregister(); err != nil {
+			// Best-effort; ignore on error.
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return b.executor.Shutdown(ctx)
+}
+
+// newIsolatedEventBus validates opts and creates the deployment's executor, bound to ctx.
+// Returns nil when opts asks for the shared executor.
+func (g *gocmd) newIsolatedEventBus(ctx context.Context, opts DeploymentOptions) (*isolatedEventBus, error) {
+	if opts.Workers < 0 {
+		return nil, &EventBusError{Code: "INVALID_DEPLOYMENT_OPTIONS", Message: "workers cannot be negative"}
+	}
+	if opts.QueueSize < 0 {
+		return nil, &EventBusError{Code: "INVALID_DEPLOYMENT_OPTIONS", Message: "queue size cannot be negative"}
+	}
+	if opts.Workers == 0 {
+		return nil, nil
+	}
+	if _, ok := g.eventBus.(executorConsumers); !ok {
+		return nil, &EventBusError{Code: "INVALID_DEPLOYMENT_OPTIONS", Message: fmt.Sprintf("event bus %T does not support dedicated executors", g.eventBus)}
+	}
+
+	cfg := concurrency.DefaultExecutorConfig()
+	cfg.Workers = opts.Workers
+	if opts.QueueSize > 0 {
+		cfg.QueueSize = opts.QueueSize
+	}
+	return &isolatedEventBus{
+		EventBus: g.eventBus,
+		executor: concurrency.NewExecutor(ctx, cfg),
+	}, nil
+}
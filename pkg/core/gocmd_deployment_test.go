@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// signalVerticle signals on received for every message sent to address
+type signalVerticle struct {
+	address  string
+	received chan struct{}
+}
+
+func (v *signalVerticle) Start(ctx FluxorContext) error {
+	ctx.EventBus().Consumer(v.address).Handler(func(ctx FluxorContext, msg Message) error {
+		v.received <- struct{}{}
+		return nil
+	})
+	return nil
+}
+
+func (v *signalVerticle) Stop(ctx FluxorContext) error {
+	return nil
+}
+
+func TestGoCMD_DeployVerticleWithOptions_DedicatedExecutor(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+	defer gx.Close()
+	eb := gx.EventBus()
+
+	// Each in-memory consumer holds a shared worker; take all 10 so that any
+	// further consumer on the shared executor never runs
+	for i := 0; i < 10; i++ {
+		eb.Consumer("noisy").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+	}
+	starved := &signalVerticle{address: "starved", received: make(chan struct{}, 1)}
+	if _, err := gx.DeployVerticle(starved); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	isolated := &signalVerticle{address: "isolated", received: make(chan struct{}, 1)}
+	id, err := gx.DeployVerticleWithOptions(isolated, DeploymentOptions{Workers: 1})
+	if err != nil {
+		t.Fatalf("DeployVerticleWithOptions() error = %v", err)
+	}
+
+	sendUntil := func(address string) error {
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			err := eb.Send(address, "ping")
+			if err == nil || time.Now().After(deadline) {
+				return err
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if err := sendUntil("isolated"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case <-isolated.received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("verticle with a dedicated executor should not be starved")
+	}
+
+	if err := sendUntil("starved"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case <-starved.received:
+		t.Error("expected the shared executor to be saturated")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Undeploy unregisters the verticle's consumers
+	if err := gx.UndeployVerticle(id); err != nil {
+		t.Fatalf("UndeployVerticle() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if err = eb.Send("isolated", "ping"); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	var ebErr *EventBusError
+	if !errors.As(err, &ebErr) || ebErr.Code != "NO_HANDLERS" {
+		t.Errorf("Send() after undeploy error = %v, want NO_HANDLERS", err)
+	}
+}
+
+func TestGoCMD_DeployVerticleWithOptions_InvalidOptions(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+	defer gx.Close()
+
+	for _, opts := range []DeploymentOptions{{Workers: -1}, {Workers: 1, QueueSize: -1}} {
+		if _, err := gx.DeployVerticleWithOptions(&testVerticle{}, opts); err == nil {
+			t.Errorf("DeployVerticleWithOptions(%+v) should fail", opts)
+		}
+	}
+	if n := gx.DeploymentCount(); n != 0 {
+		t.Errorf("DeploymentCount() = %d, want 0", n)
+	}
+}
+
+// waitState polls dep until it reaches want or a second passes
+func waitState(dep Deployment, want DeploymentState) DeploymentState {
+	deadline := time.Now().Add(time.Second)
+	for dep.State() != want && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	return dep.State()
+}
+
+func TestGoCMD_DeployVerticleH(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	verticle := &testVerticle{}
+	dep, err := gocmd.DeployVerticleH(verticle)
+	if err != nil {
+		t.Fatalf("DeployVerticleH() error = %v", err)
+	}
+	if dep.ID() == "" || dep.Verticle() != verticle {
+		t.Errorf("handle = %q %v", dep.ID(), dep.Verticle())
+	}
+	if state := waitState(dep, DeploymentStateStarted); state != DeploymentStateStarted {
+		t.Fatalf("State() = %v, want STARTED", state)
+	}
+
+	if err := dep.Undeploy(); err != nil {
+		t.Fatalf("Undeploy() error = %v", err)
+	}
+	if state := waitState(dep, DeploymentStateStopped); state != DeploymentStateStopped {
+		t.Errorf("State() = %v, want STOPPED", state)
+	}
+	if gocmd.DeploymentCount() != 0 {
+		t.Errorf("DeploymentCount() = %d, want 0", gocmd.DeploymentCount())
+	}
+	if err := dep.Undeploy(); err == nil {
+		t.Error("second Undeploy() should fail")
+	}
+}
+
+func TestGoCMD_DeployVerticleH_FailedStart(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	dep, err := gocmd.DeployVerticleH(&failingStartVerticle{})
+	if err != nil {
+		t.Fatalf("DeployVerticleH() error = %v", err)
+	}
+	if state := waitState(dep, DeploymentStateFailed); state != DeploymentStateFailed {
+		t.Errorf("State() = %v, want FAILED", state)
+	}
+	if _, err := gocmd.DeployVerticleH(nil); err == nil {
+		t.Error("expected error for nil verticle")
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Framework events, published so operators can monitor by subscribing to the bus
+// instead of polling metrics. Like ShutdownAddress, delivery is local to the
+// publishing process, also on clustered buses. Bodies are JSON objects.
+// Framework events are not counted in EventBusStats.Published.
+const (
+	// DeploymentStartedAddress: a verticle's Start returned successfully
+	// {"deploymentId", "verticle"}
+	DeploymentStartedAddress = "fluxor.deployment.started"
+	// DeploymentStoppedAddress: a deployment reached STOPPED, or FAILED when Start failed
+	// {"deploymentId", "verticle", "state", "error" (FAILED only)}
+	DeploymentStoppedAddress = "fluxor.deployment.stopped"
+	// ConsumerOverloadedAddress: an in-memory consumer's mailbox was full and a
+	// message was dropped; at most once per second per consumer
+	// {"address", "dropped" (total for the consumer), "queued", "capacity"}
+	ConsumerOverloadedAddress = "fluxor.eventbus.consumer.overloaded"
+)
+
+// frameworkAddressPrefix marks framework events; they never raise overload events
+// themselves, so a slow monitoring consumer can't feed back into its own address
+const frameworkAddressPrefix = "fluxor."
+
+// overloadEventInterval throttles ConsumerOverloadedAddress events per consumer
+const overloadEventInterval = time.Second
+
+// PublishLocal publishes body to this process's consumers of address only, falling
+// back to Publish on buses that can't restrict delivery. Used for framework events.
+func PublishLocal(eb EventBus, address string, body interface{}) error {
+	if lp, ok := eb.(localPublisher); ok {
+		return lp.publishLocal(address, body)
+	}
+	return eb.Publish(address, body)
+}
+
+// publishDeploymentEvent is best-effort: the bus may already be closing
+func (g *gocmd) publishDeploymentEvent(address string, dep *deployment, startErr error) {
+	body := map[string]interface{}{
+		"deploymentId": dep.id,
+		"verticle":     fmt.Sprintf("%T", dep.verticle),
+	}
+	if address == DeploymentStoppedAddress {
+		body["state"] = DeploymentStateStopped.String()
+		if startErr != nil {
+			body["state"] = DeploymentStateFailed.String()
+			body["error"] = startErr.Error()
+		}
+	}
+	_ = PublishLocal(g.eventBus, address, body)
+}
+
+// reportOverload publishes ConsumerOverloadedAddress, throttled per consumer
+func (c *consumer) reportOverload(dropped int64) {
+	if strings.HasPrefix(c.address, frameworkAddressPrefix) {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := atomic.LoadInt64(&c.lastOverload)
+	if last != 0 && now-last < int64(overloadEventInterval) {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&c.lastOverload, last, now) {
+		return
+	}
+	_ = c.eventBus.Publish(ConsumerOverloadedAddress, map[string]interface{}{
+		"address":  c.address,
+		"dropped":  dropped,
+		"queued":   c.mailbox.Size(),
+		"capacity": c.mailbox.Capacity(),
+	})
+}
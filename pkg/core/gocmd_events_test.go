@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// subscribeEvents collects the decoded bodies published to address
+func subscribeEvents(t *testing.T, eb EventBus, address string) <-chan map[string]interface{} {
+	t.Helper()
+	events := make(chan map[string]interface{}, 16)
+	waitReady(t, eb.Consumer(address).Handler(func(ctx FluxorContext, msg Message) error {
+		var body map[string]interface{}
+		if err := msg.DecodeBody(&body); err != nil {
+			return err
+		}
+		events <- body
+		return nil
+	}))
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	select {
+	case body := <-events:
+		return body
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestGoCMD_DeploymentEvents(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	started := subscribeEvents(t, gocmd.EventBus(), DeploymentStartedAddress)
+	stopped := subscribeEvents(t, gocmd.EventBus(), DeploymentStoppedAddress)
+
+	id, err := gocmd.DeployVerticle(&testVerticle{})
+	if err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	if body := nextEvent(t, started); body["deploymentId"] != id || body["verticle"] != "*core.testVerticle" {
+		t.Errorf("started event = %v", body)
+	}
+	if err := gocmd.UndeployVerticle(id); err != nil {
+		t.Fatalf("UndeployVerticle() error = %v", err)
+	}
+	if body := nextEvent(t, stopped); body["deploymentId"] != id || body["state"] != "STOPPED" {
+		t.Errorf("stopped event = %v", body)
+	}
+
+	failedID, err := gocmd.DeployVerticle(&failingStartVerticle{})
+	if err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	body := nextEvent(t, stopped)
+	if body["deploymentId"] != failedID || body["state"] != "FAILED" || body["error"] != "start failed" {
+		t.Errorf("failed event = %v", body)
+	}
+}
+
+func TestConsumer_OverloadedEvent(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	overloaded := subscribeEvents(t, eb, ConsumerOverloadedAddress)
+
+	release := make(chan struct{})
+	defer close(release)
+	waitReady(t, eb.Consumer("events.slow").Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	}))
+	for i := 0; i < 200; i++ {
+		if err := eb.Publish("events.slow", i); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	body := nextEvent(t, overloaded)
+	if body["address"] != "events.slow" || body["dropped"].(float64) < 1 {
+		t.Errorf("overloaded event = %v", body)
+	}
+	// Throttled: one event per consumer per interval
+	select {
+	case body := <-overloaded:
+		t.Errorf("unexpected second event: %v", body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,243 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/concurrency"
+	"github.com/nats-io/nats.go"
+)
+
+// ShutdownAddress is published to by GoCMD.Close before verticles are stopped.
+// Delivery is local to the closing process, also on clustered buses.
+const ShutdownAddress = "fluxor.lifecycle.shutdown"
+
+// DefaultShutdownGracePeriod is the default bound on OnShutdown hooks
+const DefaultShutdownGracePeriod = 2 * time.Second
+
+// ShutdownPhase is a step of GoCMD.Close; components register their teardown in
+// the phase it belongs to with OnShutdownPhase. Phases run in this order.
+type ShutdownPhase int
+
+const (
+	// PhaseStopAccepting: servers refuse new connections and requests
+	PhaseStopAccepting ShutdownPhase = iota
+	// PhaseDrainRequests: servers finish in-flight requests and stop; verticles
+	// and the EventBus still work (core.BaseServer registers Stop here)
+	PhaseDrainRequests
+	// PhaseStopVerticles: runs just before verticles are stopped; the root
+	// context is already cancelled
+	PhaseStopVerticles
+	// PhaseCloseEventBus: runs after verticles stopped, just before the EventBus closes
+	PhaseCloseEventBus
+
+	shutdownPhaseCount
+)
+
+func (p ShutdownPhase) String() string {
+	switch p {
+	case PhaseStopAccepting:
+		return "stop-accepting"
+	case PhaseDrainRequests:
+		return "drain-requests"
+	case PhaseStopVerticles:
+		return "stop-verticles"
+	case PhaseCloseEventBus:
+		return "close-eventbus"
+	default:
+		return fmt.Sprintf("ShutdownPhase(%d)", int(p))
+	}
+}
+
+// localPublisher delivers a message to this process's consumers only
+// Cluster buses must not fan a shutdown out to other nodes
+type localPublisher interface {
+	publishLocal(address string, body interface{}) error
+}
+
+// OnShutdown implements GoCMD.
+func (g *gocmd) OnShutdown(hook func(ctx FluxorContext) error) {
+	if hook == nil {
+		panic("shutdown hook cannot be nil")
+	}
+
+	g.mu.Lock()
+	g.shutdownHooks++
+	g.mu.Unlock()
+
+	g.eventBus.Consumer(ShutdownAddress).Handler(func(ctx FluxorContext, msg Message) error {
+		// Run off the consumer goroutine so a stuck hook can't hold up closing the bus
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					g.logger.Error(fmt.Sprintf("shutdown hook panicked: %v", r))
+				}
+				g.mu.RLock()
+				acks := g.shutdownAcks
+				g.mu.RUnlock()
+				if acks != nil {
+					select {
+					case acks <- struct{}{}:
+					default:
+					}
+				}
+			}()
+			if err := hook(ctx); err != nil {
+				g.logger.Error(fmt.Sprintf("shutdown hook failed: %v", err))
+			}
+		}()
+		return nil
+	})
+}
+
+// OnShutdownPhase implements GoCMD.
+func (g *gocmd) OnShutdownPhase(phase ShutdownPhase, hook func(ctx context.Context) error) {
+	if hook == nil {
+		panic("shutdown hook cannot be nil")
+	}
+	if phase < 0 || phase >= shutdownPhaseCount {
+		panic(fmt.Sprintf("unknown shutdown phase: %d", int(phase)))
+	}
+
+	g.mu.Lock()
+	g.phaseHooks[phase] = append(g.phaseHooks[phase], hook)
+	g.mu.Unlock()
+}
+
+// runShutdownPhase runs phase's hooks concurrently and waits for them, bounded by
+// the grace period; hooks still running then get a cancelled context
+func (g *gocmd) runShutdownPhase(phase ShutdownPhase) {
+	g.mu.RLock()
+	hooks := append([]func(ctx context.Context) error(nil), g.phaseHooks[phase]...)
+	g.mu.RUnlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.shutdownGrace)
+	defer cancel()
+	done := make(chan struct{}, len(hooks))
+	for _, hook := range hooks {
+		go func(hook func(ctx context.Context) error) {
+			defer func() {
+				if r := recover(); r != nil {
+					g.logger.Error(fmt.Sprintf("%s shutdown hook panicked: %v", phase, r))
+				}
+				done <- struct{}{}
+			}()
+			if err := hook(ctx); err != nil {
+				g.logger.Error(fmt.Sprintf("%s shutdown hook failed: %v", phase, err))
+			}
+		}(hook)
+	}
+	for i := range hooks {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			g.logger.Info(fmt.Sprintf("%s shutdown hooks did not finish within %v (%d of %d done); continuing shutdown", phase, g.shutdownGrace, i, len(hooks)))
+			return
+		}
+	}
+}
+
+// broadcastShutdown publishes the shutdown event and waits, bounded by the grace
+// period, for OnShutdown hooks to return. The bus and root context are still live.
+func (g *gocmd) broadcastShutdown() {
+	g.mu.Lock()
+	hooks := g.shutdownHooks
+	acks := make(chan struct{}, hooks)
+	g.shutdownAcks = acks
+	g.mu.Unlock()
+
+	body := map[string]interface{}{"gracePeriodMs": g.shutdownGrace.Milliseconds()}
+	if err := PublishLocal(g.eventBus, ShutdownAddress, body); ignoreBackpressure(err) != nil {
+		g.logger.Error(fmt.Sprintf("failed to publish shutdown event: %v", err))
+		return
+	} else if err != nil {
+		// Hooks with room still run; the others count against the grace period
+		g.logger.Info(fmt.Sprintf("shutdown event not delivered to every hook: %v", err))
+	}
+
+	if hooks == 0 {
+		return
+	}
+	timer := time.NewTimer(g.shutdownGrace)
+	defer timer.Stop()
+	for i := 0; i < hooks; i++ {
+		select {
+		case <-acks:
+		case <-timer.C:
+			g.logger.Info(fmt.Sprintf("shutdown hooks did not finish within %v (%d of %d done); continuing shutdown", g.shutdownGrace, i, hooks))
+			return
+		}
+	}
+}
+
+// publishLocal implements localPublisher; the in-memory bus is already local
+func (eb *eventBus) publishLocal(address string, body interface{}) error {
+	return eb.Publish(address, body)
+}
+
+// publishLocal implements localPublisher by handing the message to this process's consumers
+func (eb *clusterNATSEventBus) publishLocal(address string, body interface{}) error {
+	data, err := encodeBody(body)
+	if err != nil {
+		return err
+	}
+	eb.mu.Lock()
+	consumers := append([]*clusterNATSConsumer(nil), eb.consumers...)
+	eb.mu.Unlock()
+
+	for _, c := range consumers {
+		if c.address != address {
+			continue
+		}
+		c := c
+		nm := &nats.Msg{Subject: eb.subjectPub(address), Data: data, Header: nats.Header{}}
+		if err := eb.executor.Submit(concurrency.NewNamedTask("cluster-nats-local."+address, func(ctx context.Context) error {
+			return c.handleMsg(nm)
+		})); err != nil {
+			eb.logger.Info(fmt.Sprintf("local delivery to %s dropped: %v", address, err))
+		}
+	}
+	return nil
+}
+
+// publishLocal implements localPublisher by handing the message to this process's consumers
+func (eb *clusterJSEventBus) publishLocal(address string, body interface{}) error {
+	data, err := encodeBody(body)
+	if err != nil {
+		return err
+	}
+	eb.mu.Lock()
+	consumers := append([]*clusterJSConsumer(nil), eb.consumers...)
+	eb.mu.Unlock()
+
+	for _, c := range consumers {
+		if c.address != address {
+			continue
+		}
+		c := c
+		nm := &nats.Msg{Subject: eb.subjectPub(address), Data: data, Header: nats.Header{}}
+		if err := eb.executor.Submit(concurrency.NewNamedTask("cluster-jetstream-local."+address, func(ctx context.Context) error {
+			return c.handleMsg(nm, "")
+		})); err != nil {
+			eb.logger.Info(fmt.Sprintf("local delivery to %s dropped: %v", address, err))
+		}
+	}
+	return nil
+}
+
+// drainEventBus drains the bus within EventBusDrainTimeout, if configured and supported
+func (g *gocmd) drainEventBus() {
+	d, ok := g.eventBus.(drainer)
+	if g.drainTimeout <= 0 || !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), g.drainTimeout)
+	defer cancel()
+	if err := d.drain(ctx); err != nil {
+		g.logger.Info(fmt.Sprintf("event bus did not drain within %v; dropping queued messages", g.drainTimeout))
+	}
+}
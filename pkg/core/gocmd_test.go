@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -171,6 +173,39 @@ type failingStartVerticle struct{}
 func (v *failingStartVerticle) Start(ctx FluxorContext) error { return errors.New("start failed") }
 func (v *failingStartVerticle) Stop(ctx FluxorContext) error  { return nil }
 
+type panickingStartVerticle struct{}
+
+func (v *panickingStartVerticle) Start(ctx FluxorContext) error { panic("boom in start") }
+func (v *panickingStartVerticle) Stop(ctx FluxorContext) error  { return nil }
+
+func TestGoCMD_DeployVerticle_StartPanicFailsDeployment(t *testing.T) {
+	ctx := context.Background()
+	gocmd := NewGoCMD(ctx)
+	defer gocmd.Close()
+
+	// A panic in Start must not crash the process; the deployment fails instead
+	if _, err := gocmd.DeployVerticle(&panickingStartVerticle{}); err != nil {
+		t.Fatalf("DeployVerticle() should not return error (start is async), got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if gocmd.DeploymentCount() == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if gocmd.DeploymentCount() != 0 {
+		t.Fatalf("expected 0 deployments after start panic, got %d", gocmd.DeploymentCount())
+	}
+
+	// Runtime still works after the panic
+	id, err := gocmd.DeployVerticle(&testVerticle{})
+	if err != nil || id == "" {
+		t.Fatalf("DeployVerticle() after panic = %q, %v", id, err)
+	}
+}
+
 func TestGoCMD_DeployVerticle_FailFast_StartError(t *testing.T) {
 	ctx := context.Background()
 	gocmd := NewGoCMD(ctx)
@@ -203,3 +238,382 @@ func TestGoCMD_DeployVerticle_FailFast_StartError(t *testing.T) {
 		t.Errorf("expected 0 deployments after start failure, got %d", gocmd.DeploymentCount())
 	}
 }
+
+type stuckStopVerticle struct {
+	release chan struct{}
+}
+
+func (v *stuckStopVerticle) Start(ctx FluxorContext) error { return nil }
+func (v *stuckStopVerticle) Stop(ctx FluxorContext) error {
+	<-v.release
+	return nil
+}
+
+func TestGoCMD_UndeployVerticle_StopTimeout(t *testing.T) {
+	gx, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{StopTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	vx := gx.(*gocmd)
+	defer vx.Close()
+
+	verticle := &stuckStopVerticle{release: make(chan struct{})}
+	defer close(verticle.release)
+	id, _ := vx.DeployVerticle(verticle)
+
+	deadline := time.Now().Add(2 * time.Second)
+	var dep *deployment
+	for time.Now().Before(deadline) {
+		vx.mu.RLock()
+		if d, ok := vx.deployments[id]; ok && d.state == DeploymentStateStarted {
+			dep = d
+		}
+		vx.mu.RUnlock()
+		if dep != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if dep == nil {
+		t.Fatal("verticle did not start")
+	}
+
+	if err := vx.UndeployVerticle(id); err != nil {
+		t.Fatalf("UndeployVerticle() error = %v", err)
+	}
+
+	// Stop never returns, but the deployment is forced to STOPPED after the timeout
+	deadline = time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		vx.mu.RLock()
+		state := dep.state
+		vx.mu.RUnlock()
+		if state == DeploymentStateStopped {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("deployment should be forced to STOPPED after the stop timeout")
+}
+
+type panickingStopVerticle struct{}
+
+func (v *panickingStopVerticle) Start(ctx FluxorContext) error { return nil }
+func (v *panickingStopVerticle) Stop(ctx FluxorContext) error  { panic("boom in stop") }
+
+func TestGoCMD_UndeployVerticle_StopPanicIsolated(t *testing.T) {
+	gocmd := NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	id, _ := gocmd.DeployVerticle(&panickingStopVerticle{})
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := gocmd.UndeployVerticle(id); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A panic in Stop must not crash the process; the deployment still stops
+	for time.Now().Before(deadline) {
+		if gocmd.DeploymentCount() == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected 0 deployments after stop panic, got %d", gocmd.DeploymentCount())
+}
+
+func TestGoCMD_Close_StopTimeout(t *testing.T) {
+	gx, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{StopTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+
+	verticle := &stuckStopVerticle{release: make(chan struct{})}
+	defer close(verticle.release)
+	if _, err := gx.DeployVerticle(verticle); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close() took %v; a stuck Stop() should be bounded by StopTimeout", elapsed)
+	}
+}
+
+func TestNewGoCMDWithOptions_NegativeStopTimeout(t *testing.T) {
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{StopTimeout: -time.Second}); err == nil {
+		t.Fatal("NewGoCMDWithOptions() should reject a negative StopTimeout")
+	}
+}
+
+type recordingStopVerticle struct {
+	stopped chan struct{}
+}
+
+func (v *recordingStopVerticle) Start(ctx FluxorContext) error { return nil }
+func (v *recordingStopVerticle) Stop(ctx FluxorContext) error {
+	close(v.stopped)
+	return nil
+}
+
+func TestGoCMD_OnShutdown_RunsBeforeVerticlesStop(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+
+	verticle := &recordingStopVerticle{stopped: make(chan struct{})}
+	if _, err := gx.DeployVerticle(verticle); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	gx.EventBus().Consumer("registry.deregister").Handler(func(ctx FluxorContext, msg Message) error { return nil })
+
+	var hookErr error
+	ran := false
+	gx.OnShutdown(func(ctx FluxorContext) error {
+		ran = true
+		select {
+		case <-verticle.stopped:
+			t.Error("shutdown hook should run before verticles are stopped")
+		default:
+		}
+		// The bus is still usable during the grace window
+		hookErr = ctx.EventBus().Send("registry.deregister", "node-1")
+		return nil
+	})
+
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !ran {
+		t.Fatal("shutdown hook did not run before Close() returned")
+	}
+	if hookErr != nil {
+		t.Errorf("Send() during shutdown hook error = %v", hookErr)
+	}
+}
+
+func TestGoCMD_OnShutdown_BoundedByGracePeriod(t *testing.T) {
+	gx, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{ShutdownGracePeriod: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	gx.OnShutdown(func(ctx FluxorContext) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close() took %v; a stuck hook should be bounded by ShutdownGracePeriod", elapsed)
+	}
+}
+
+func TestGoCMD_OnShutdown_RunsDespiteBackpressure(t *testing.T) {
+	gx, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{ReportBackpressure: true})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+
+	// A stuck listener on the shutdown address fills its mailbox
+	release := make(chan struct{})
+	stuck := gx.EventBus().Consumer(ShutdownAddress).Handler(func(ctx FluxorContext, msg Message) error {
+		<-release
+		return nil
+	})
+	waitReady(t, stuck)
+	for i := 0; i < 200; i++ {
+		if err := gx.EventBus().Publish(ShutdownAddress, i); err != nil {
+			break
+		}
+	}
+
+	var ran atomic.Bool
+	gx.OnShutdown(func(ctx FluxorContext) error {
+		time.Sleep(50 * time.Millisecond)
+		ran.Store(true)
+		return nil
+	})
+	// The stuck listener holds up Close, so let it go once shutdown is under way
+	time.AfterFunc(10*time.Millisecond, func() { close(release) })
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !ran.Load() {
+		t.Error("Close() returned before the shutdown hook finished")
+	}
+}
+
+// phaseVerticle records its Stop in the shutdown order
+type phaseVerticle struct {
+	record func(string)
+}
+
+func (v *phaseVerticle) Start(ctx FluxorContext) error { return nil }
+func (v *phaseVerticle) Stop(ctx FluxorContext) error {
+	v.record("verticles")
+	return nil
+}
+
+func TestGoCMD_ShutdownPhases(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+
+	var mu sync.Mutex
+	var order []string
+	record := func(step string) {
+		mu.Lock()
+		order = append(order, step)
+		mu.Unlock()
+	}
+	if _, err := gx.DeployVerticle(&phaseVerticle{record: record}); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	c := gx.EventBus().Consumer("phase.echo").HandlerReply(func(ctx FluxorContext, msg Message) (interface{}, error) {
+		return "pong", nil
+	})
+	waitReady(t, c)
+
+	var drainErr error
+	for _, phase := range []ShutdownPhase{PhaseCloseEventBus, PhaseStopVerticles, PhaseDrainRequests, PhaseStopAccepting} {
+		phase := phase
+		gx.OnShutdownPhase(phase, func(ctx context.Context) error {
+			if phase == PhaseDrainRequests {
+				// In-flight requests can still use the bus and the root context
+				_, drainErr = gx.EventBus().Request("phase.echo", "ping", time.Second)
+				if drainErr == nil {
+					drainErr = gx.Context().Err()
+				}
+			}
+			record(phase.String())
+			return nil
+		})
+	}
+	gx.OnShutdownPhase(PhaseStopAccepting, func(ctx context.Context) error { panic("hook bug") })
+
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	want := []string{"stop-accepting", "drain-requests", "stop-verticles", "verticles", "close-eventbus"}
+	if fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("shutdown order = %v, want %v", order, want)
+	}
+	if drainErr != nil {
+		t.Errorf("bus during PhaseDrainRequests error = %v", drainErr)
+	}
+}
+
+func TestNewGoCMDWithOptions_NegativeShutdownGracePeriod(t *testing.T) {
+	if _, err := NewGoCMDWithOptions(context.Background(), GoCMDOptions{ShutdownGracePeriod: -time.Second}); err == nil {
+		t.Fatal("NewGoCMDWithOptions() should reject a negative ShutdownGracePeriod")
+	}
+}
+
+// tickerVerticle runs a ticker goroutine until its context is cancelled
+type tickerVerticle struct {
+	exited chan struct{}
+}
+
+func (v *tickerVerticle) Start(ctx FluxorContext) error {
+	go func() {
+		defer close(v.exited)
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (v *tickerVerticle) Stop(ctx FluxorContext) error {
+	return nil
+}
+
+func TestGoCMD_UndeployVerticle_CancelsContext(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+	defer gx.Close()
+
+	verticle := &tickerVerticle{exited: make(chan struct{})}
+	id, err := gx.DeployVerticle(verticle)
+	if err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+
+	// Undeploy is rejected while the deployment is still PENDING
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err = gx.UndeployVerticle(id)
+		if err == nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("UndeployVerticle() error = %v", err)
+	}
+
+	select {
+	case <-verticle.exited:
+	case <-time.After(time.Second):
+		t.Fatal("ticker goroutine did not exit after undeploy")
+	}
+	if gx.Context().Err() != nil {
+		t.Error("undeploy should not cancel the GoCMD root context")
+	}
+}
+
+// failingTickerVerticle starts a ticker goroutine, then fails Start
+type failingTickerVerticle struct {
+	tickerVerticle
+}
+
+func (v *failingTickerVerticle) Start(ctx FluxorContext) error {
+	_ = v.tickerVerticle.Start(ctx)
+	return errors.New("start failed")
+}
+
+func TestGoCMD_FailedStart_CancelsContext(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+	defer gx.Close()
+
+	verticle := &failingTickerVerticle{tickerVerticle{exited: make(chan struct{})}}
+	if _, err := gx.DeployVerticle(verticle); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	select {
+	case <-verticle.exited:
+	case <-time.After(time.Second):
+		t.Fatal("goroutine started by a failed Start did not exit")
+	}
+}
+
+func TestGoCMD_Close_CancelsVerticleContext(t *testing.T) {
+	gx := NewGoCMD(context.Background())
+
+	verticle := &tickerVerticle{exited: make(chan struct{})}
+	if _, err := gx.DeployVerticle(verticle); err != nil {
+		t.Fatalf("DeployVerticle() error = %v", err)
+	}
+	if err := gx.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	select {
+	case <-verticle.exited:
+	case <-time.After(time.Second):
+		t.Fatal("ticker goroutine did not exit after Close")
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// AsInt64 reads a decoded JSON number as an int64. It accepts json.Number
+// (JSONDecodeOptions.UseNumber), float64 (the default decoding) and Go integer
+// types. ok is false for non-numbers, fractions and values out of range.
+func AsInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt64(f)
+	case float64:
+		return floatToInt64(n)
+	case float32:
+		return floatToInt64(float64(n))
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return uintToInt64(uint64(n))
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return uintToInt64(n)
+	default:
+		return 0, false
+	}
+}
+
+// AsFloat64 reads a decoded JSON number as a float64; see AsInt64 for the
+// accepted types. ok is false for non-numbers.
+func AsFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(string(n), 64)
+		return f, err == nil
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+func uintToInt64(u uint64) (int64, bool) {
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
@@ -0,0 +1,383 @@
+package core
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// JSONOptions configures JSONEncodeWith. The zero value encodes like JSONEncode.
+type JSONOptions struct {
+	// DisableHTMLEscape keeps <, > and & as-is; by default encoding/json writes
+	// them as \u003c, \u003e and \u0026, which mangles URLs in responses
+	DisableHTMLEscape bool
+
+	// Indent pretty-prints the output with this indent per level (e.g. "  ")
+	Indent string
+
+	// FieldNaming renames struct fields that have no name in their json tag,
+	// e.g. SnakeCase. Tagged fields and map keys are left unchanged.
+	FieldNaming func(string) string
+}
+
+// JSONEncodeWith encodes a value to JSON bytes using opts (fail-fast).
+func JSONEncodeWith(v interface{}, opts JSONOptions) ([]byte, error) {
+	// Fail-fast: validate input
+	if v == nil {
+		return nil, &EventBusError{Code: "INVALID_INPUT", Message: "cannot encode nil value"}
+	}
+
+	if opts.FieldNaming != nil {
+		renamed, err := renameFields(reflect.ValueOf(v), opts.FieldNaming)
+		if err != nil {
+			return nil, fmt.Errorf("json encode failed: %w", err)
+		}
+		v = renamed
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(!opts.DisableHTMLEscape)
+	if opts.Indent != "" {
+		enc.SetIndent("", opts.Indent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("json encode failed: %w", err)
+	}
+
+	// Encoder terminates each value with a newline; JSONEncode does not
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// JSONDecodeOptions configures JSONDecodeWith. The zero value decodes like JSONDecode.
+type JSONDecodeOptions struct {
+	// UseNumber decodes numbers in interface{} values (e.g. map[string]interface{})
+	// as json.Number instead of float64, so integers above 2^53 keep their exact
+	// value. Read them with AsInt64/AsFloat64.
+	UseNumber bool
+
+	// DisallowUnknownFields fails on object keys with no matching struct field
+	DisallowUnknownFields bool
+}
+
+// JSONDecodeWith decodes JSON bytes to a value using opts (fail-fast).
+// Trailing data after the first JSON value is an error, as with JSONDecode.
+func JSONDecodeWith(data []byte, v interface{}, opts JSONDecodeOptions) error {
+	// Fail-fast: validate inputs
+	if len(data) == 0 {
+		return &EventBusError{Code: "INVALID_INPUT", Message: "cannot decode empty data"}
+	}
+	if v == nil {
+		return &EventBusError{Code: "INVALID_INPUT", Message: "cannot decode into nil value"}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if opts.UseNumber {
+		dec.UseNumber()
+	}
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("json decode failed: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("json decode failed: unexpected data after top-level value")
+	}
+	return nil
+}
+
+// SnakeCase converts a Go identifier to snake_case ("UserID" -> "user_id",
+// "HTTPServer" -> "http_server"). Use it as JSONOptions.FieldNaming.
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at lower->Upper, and at the last capital of an
+			// acronym followed by lowercase ("HTTPServer" -> "http_server")
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// renamer rebuilds values with struct fields renamed by naming, tracking the
+// pointers, maps and slices being walked to catch self-referential values
+type renamer struct {
+	naming   func(string) string
+	visiting map[visit]bool
+}
+
+// visit identifies a value being walked; len tells a slice from its sub-slices
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// renameFields rebuilds v with struct fields renamed by naming. Values that
+// marshal themselves (time.Time, json.RawMessage, ...) are left alone. Like
+// encoding/json it fails on cyclic values instead of recursing forever.
+func renameFields(v reflect.Value, naming func(string) string) (interface{}, error) {
+	r := &renamer{naming: naming, visiting: make(map[visit]bool)}
+	return r.rename(v)
+}
+
+func (r *renamer) rename(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return v.Interface(), nil
+	}
+	if v.CanAddr() {
+		if pt := reflect.PointerTo(t); pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType) {
+			return v.Addr().Interface(), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		key := visit{ptr: v.Pointer(), typ: t}
+		if v.Kind() == reflect.Slice {
+			key.len = v.Len()
+		}
+		if r.visiting[key] {
+			return nil, &json.UnsupportedValueError{Value: v, Str: fmt.Sprintf("encountered a cycle via %s", t)}
+		}
+		r.visiting[key] = true
+		defer delete(r.visiting, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return r.rename(v.Elem())
+	case reflect.Struct:
+		obj := namedObject{}
+		if err := r.appendFields(&obj, v, map[string]bool{}); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, ok := mapKeyString(iter.Key())
+			if !ok {
+				return v.Interface(), nil
+			}
+			value, err := r.rename(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), nil // []byte encodes as base64
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item, err := r.rename(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+// appendFields adds v's exported fields to obj, flattening untagged embedded
+// structs like encoding/json does; the shallowest field wins a name clash
+func (r *renamer) appendFields(obj *namedObject, v reflect.Value, seen map[string]bool) error {
+	t := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Ptr {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if hasJSONOption(opts, "omitempty") && isEmptyJSONValue(fv) ||
+			hasJSONOption(opts, "omitzero") && fv.IsZero() {
+			continue
+		}
+		if name == "" {
+			name = r.naming(f.Name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		var value interface{}
+		if quoted, ok := quotedJSONValue(fv, opts); ok {
+			value = quoted
+		} else {
+			var err error
+			if value, err = r.rename(fv); err != nil {
+				return err
+			}
+		}
+		*obj = append(*obj, namedField{key: name, value: value})
+	}
+	for _, ev := range embedded {
+		if err := r.appendFields(obj, ev, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quotedJSONValue applies the ",string" tag option: a string, number or bool
+// field (or a non-nil pointer to one) is encoded inside a JSON string
+func quotedJSONValue(fv reflect.Value, opts string) (interface{}, bool) {
+	if !hasJSONOption(opts, "string") {
+		return nil, false
+	}
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil, false
+		}
+		fv = fv.Elem()
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		data, err := json.Marshal(fv.String())
+		if err != nil {
+			return nil, false
+		}
+		return string(data), true
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(fv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		data, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return nil, false
+		}
+		return string(data), true
+	default:
+		return nil, false
+	}
+}
+
+func hasJSONOption(opts, name string) bool {
+	return strings.Contains(","+opts+",", ","+name+",")
+}
+
+// isEmptyJSONValue reports whether omitempty drops v (encoding/json's rules)
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// mapKeyString formats a map key the way encoding/json does
+func mapKeyString(k reflect.Value) (string, bool) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), true
+	default:
+		return "", false
+	}
+}
+
+// namedObject is a renamed struct; unlike a map it keeps the struct's field order
+type namedObject []namedField
+
+type namedField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler. It never escapes HTML; the outer
+// Encoder re-applies its own escaping and indentation to the result.
+func (o namedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(f.key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1) // drop Encode's newline
+		buf.WriteByte(':')
+		if err := enc.Encode(f.value); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
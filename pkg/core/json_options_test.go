@@ -0,0 +1,226 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONEncodeWith_ZeroOptionsMatchesJSONEncode(t *testing.T) {
+	v := map[string]interface{}{"url": "https://example.com/?a=1&b=<2>", "n": 1}
+	want, _ := JSONEncode(v)
+	got, err := JSONEncodeWith(v, JSONOptions{})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("JSONEncodeWith() = %s, want %s", got, want)
+	}
+
+	if _, err := JSONEncodeWith(nil, JSONOptions{}); err == nil {
+		t.Error("JSONEncodeWith(nil) should fail")
+	}
+}
+
+func TestJSONEncodeWith_DisableHTMLEscape(t *testing.T) {
+	got, err := JSONEncodeWith(map[string]string{"url": "/a?x=1&y=<2>"}, JSONOptions{DisableHTMLEscape: true})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	if want := `{"url":"/a?x=1&y=<2>"}`; string(got) != want {
+		t.Errorf("JSONEncodeWith() = %s, want %s", got, want)
+	}
+}
+
+func TestJSONEncodeWith_Indent(t *testing.T) {
+	got, err := JSONEncodeWith(map[string]int{"a": 1}, JSONOptions{Indent: "  "})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	if want := "{\n  \"a\": 1\n}"; string(got) != want {
+		t.Errorf("JSONEncodeWith() = %q, want %q", got, want)
+	}
+}
+
+type jsonAudit struct {
+	CreatedBy string
+}
+
+type jsonUser struct {
+	jsonAudit
+	UserID    string
+	HTTPProxy string `json:",omitempty"`
+	Email     string `json:"mail"`
+	Password  string `json:"-"`
+	Tags      []string
+	Profile   *jsonProfile
+	Joined    time.Time
+	internal  string
+}
+
+type jsonProfile struct {
+	DisplayName string
+	Links       map[string]string
+}
+
+func TestJSONEncodeWith_FieldNaming(t *testing.T) {
+	u := jsonUser{
+		jsonAudit: jsonAudit{CreatedBy: "admin"},
+		UserID:    "u-1",
+		Email:     "ada@example.com",
+		Password:  "secret",
+		Tags:      []string{"a"},
+		Profile:   &jsonProfile{DisplayName: "Ada", Links: map[string]string{"HomePage": "/ada"}},
+		Joined:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		internal:  "x",
+	}
+	got, err := JSONEncodeWith(u, JSONOptions{FieldNaming: SnakeCase, DisableHTMLEscape: true})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	want := `{"user_id":"u-1","mail":"ada@example.com","tags":["a"],` +
+		`"profile":{"display_name":"Ada","links":{"HomePage":"/ada"}},` +
+		`"joined":"2024-01-02T03:04:05Z","created_by":"admin"}`
+	if string(got) != want {
+		t.Errorf("JSONEncodeWith() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Slices of structs and pointers are renamed too, and the result is valid JSON
+	got, err = JSONEncodeWith([]*jsonProfile{{DisplayName: "A"}, nil}, JSONOptions{FieldNaming: SnakeCase, Indent: "  "})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	var out []map[string]interface{}
+	if err := json.Unmarshal(got, &out); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, got)
+	}
+	if len(out) != 2 || out[0]["display_name"] != "A" || out[1] != nil {
+		t.Errorf("decoded = %v, want a renamed profile and null", out)
+	}
+}
+
+type jsonNode struct {
+	Name string
+	Next *jsonNode
+}
+
+func TestJSONEncodeWith_FieldNamingCycle(t *testing.T) {
+	n := &jsonNode{Name: "a"}
+	n.Next = n
+	var unsupported *json.UnsupportedValueError
+	if _, err := JSONEncodeWith(n, JSONOptions{FieldNaming: SnakeCase}); !errors.As(err, &unsupported) {
+		t.Errorf("JSONEncodeWith() of a cycle error = %v, want *json.UnsupportedValueError", err)
+	}
+
+	// The same pointer twice is not a cycle
+	shared := &jsonNode{Name: "b"}
+	got, err := JSONEncodeWith([]*jsonNode{shared, shared}, JSONOptions{FieldNaming: SnakeCase})
+	if err != nil || string(got) != `[{"name":"b","next":null},{"name":"b","next":null}]` {
+		t.Errorf("JSONEncodeWith() = %s, %v", got, err)
+	}
+}
+
+func TestJSONEncodeWith_FieldNamingStringOption(t *testing.T) {
+	type quoted struct {
+		Count   int64   `json:",string"`
+		Ratio   float64 `json:"ratio,string"`
+		Enabled bool    `json:",string"`
+		Label   string  `json:",string"`
+		Ptr     *int    `json:",string"`
+		Tags    []int   `json:",string"`
+	}
+	n := 7
+	v := quoted{Count: 42, Ratio: 0.5, Enabled: true, Label: "x", Ptr: &n, Tags: []int{1}}
+	got, err := JSONEncodeWith(v, JSONOptions{FieldNaming: SnakeCase})
+	if err != nil {
+		t.Fatalf("JSONEncodeWith() error = %v", err)
+	}
+	want := `{"count":"42","ratio":"0.5","enabled":"true","label":"\"x\"","ptr":"7","tags":[1]}`
+	if string(got) != want {
+		t.Errorf("JSONEncodeWith() =\n%s\nwant\n%s", got, want)
+	}
+	// Same values as encoding/json, only renamed
+	plain, _ := json.Marshal(v)
+	if !strings.Contains(string(plain), `"Count":"42"`) || !strings.Contains(string(plain), `"Label":"\"x\""`) {
+		t.Errorf("encoding/json = %s", plain)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":       "name",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"CreatedAt":  "created_at",
+		"Address2":   "address2",
+		"V2Config":   "v2_config",
+		"already_ok": "already_ok",
+	}
+	for in, want := range tests {
+		if got := SnakeCase(in); got != want {
+			t.Errorf("SnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJSONDecodeWith_UseNumber(t *testing.T) {
+	data := []byte(`{"id": 9007199254740993, "amount": 12.5}`)
+
+	var plain map[string]interface{}
+	if err := JSONDecodeWith(data, &plain, JSONDecodeOptions{}); err != nil {
+		t.Fatalf("JSONDecodeWith() error = %v", err)
+	}
+	if _, ok := plain["id"].(float64); !ok {
+		t.Errorf("id = %T, want float64 without UseNumber", plain["id"])
+	}
+
+	var got map[string]interface{}
+	if err := JSONDecodeWith(data, &got, JSONDecodeOptions{UseNumber: true}); err != nil {
+		t.Fatalf("JSONDecodeWith() error = %v", err)
+	}
+	if id, ok := AsInt64(got["id"]); !ok || id != 9007199254740993 {
+		t.Errorf("AsInt64(id) = %d, %v; want exact 9007199254740993", id, ok)
+	}
+	if amount, ok := AsFloat64(got["amount"]); !ok || amount != 12.5 {
+		t.Errorf("AsFloat64(amount) = %v, %v", amount, ok)
+	}
+	if _, ok := AsInt64(got["amount"]); ok {
+		t.Error("AsInt64 of a fraction should fail")
+	}
+
+	var strict struct{ ID int64 }
+	if err := JSONDecodeWith(data, &strict, JSONDecodeOptions{DisallowUnknownFields: true}); err == nil {
+		t.Error("DisallowUnknownFields should reject the amount field")
+	}
+	for _, bad := range []string{`{"a":1} {"b":2}`, `{"a":1}]`, ``} {
+		if err := JSONDecodeWith([]byte(bad), &got, JSONDecodeOptions{}); err == nil {
+			t.Errorf("JSONDecodeWith(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAsInt64(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want int64
+		ok   bool
+	}{
+		{json.Number("42"), 42, true},
+		{json.Number("1e3"), 1000, true},
+		{json.Number("1.5"), 0, false},
+		{float64(7), 7, true},
+		{float64(7.25), 0, false},
+		{int32(-3), -3, true},
+		{uint64(1 << 63), 0, false},
+		{"42", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := AsInt64(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("AsInt64(%#v) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
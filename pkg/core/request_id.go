@@ -2,6 +2,13 @@ package core
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -21,9 +28,81 @@ func GetRequestID(ctx context.Context) string {
 	return ""
 }
 
-// GenerateRequestID generates a new request ID
+// RequestIDFormat selects what GenerateRequestID produces
+type RequestIDFormat int32
+
+const (
+	// RequestIDUUID is a random UUID (default): 6ba7b810-9dad-41d1-80b4-00c04fd430c8
+	RequestIDUUID RequestIDFormat = iota
+
+	// RequestIDULID is a ULID: 26 Crockford base32 characters that sort by creation time (ms)
+	RequestIDULID
+
+	// RequestIDTraceID is 32 lowercase hex characters (128 bits), valid as a W3C/OTel
+	// trace ID, so logs can be joined with traces on the same value
+	RequestIDTraceID
+)
+
+var requestIDFormat atomic.Int32
+
+// SetRequestIDFormat sets the format of IDs from GenerateRequestID, process-wide
+// Fail-fast: panics on an unknown format
+func SetRequestIDFormat(format RequestIDFormat) {
+	if format < RequestIDUUID || format > RequestIDTraceID {
+		panic(fmt.Sprintf("unknown request ID format: %d", format))
+	}
+	requestIDFormat.Store(int32(format))
+}
+
+// GetRequestIDFormat returns the format set by SetRequestIDFormat
+func GetRequestIDFormat() RequestIDFormat {
+	return RequestIDFormat(requestIDFormat.Load())
+}
+
+// GenerateRequestID generates a new request ID in the format set by SetRequestIDFormat
 func GenerateRequestID() string {
-	return uuid.New().String()
+	switch GetRequestIDFormat() {
+	case RequestIDULID:
+		return newULID(time.Now())
+	case RequestIDTraceID:
+		return newTraceID()
+	default:
+		return uuid.New().String()
+	}
+}
+
+// crockford is the ULID alphabet (Crockford's base32)
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID encodes a 48-bit millisecond timestamp and 80 random bits
+func newULID(t time.Time) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(t.UnixMilli())<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+
+	// 128 bits as 26 base32 digits, most significant first (the first digit holds 3 bits)
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// newTraceID returns 16 random bytes as hex, never all zeros (invalid as a trace ID)
+func newTraceID() string {
+	var b [16]byte
+	for b == [16]byte{} {
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(fmt.Sprintf("crypto/rand failed: %v", err))
+		}
+	}
+	return hex.EncodeToString(b[:])
 }
 
 // WithNewRequestID adds a new request ID to the context
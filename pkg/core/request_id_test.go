@@ -2,7 +2,9 @@ package core
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestWithRequestID(t *testing.T) {
@@ -53,3 +55,31 @@ func TestWithNewRequestID(t *testing.T) {
 		t.Error("WithNewRequestID() should generate a request ID")
 	}
 }
+
+func TestGenerateRequestID_Formats(t *testing.T) {
+	defer SetRequestIDFormat(GetRequestIDFormat())
+
+	SetRequestIDFormat(RequestIDTraceID)
+	id := GenerateRequestID()
+	if len(id) != 32 || strings.Trim(id, "0123456789abcdef") != "" {
+		t.Errorf("trace ID format = %q, want 32 lowercase hex digits", id)
+	}
+
+	SetRequestIDFormat(RequestIDULID)
+	first := GenerateRequestID()
+	if len(first) != 26 || strings.Trim(first, crockford) != "" {
+		t.Errorf("ULID format = %q, want 26 Crockford base32 characters", first)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if second := GenerateRequestID(); second <= first {
+		t.Errorf("ULIDs should sort by creation time: %q <= %q", second, first)
+	}
+	if got := newULID(time.UnixMilli(0)); got[:10] != "0000000000" {
+		t.Errorf("ULID at epoch = %q, want a zero timestamp prefix", got)
+	}
+
+	SetRequestIDFormat(RequestIDUUID)
+	if id := GenerateRequestID(); len(id) != 36 {
+		t.Errorf("UUID format = %q", id)
+	}
+}
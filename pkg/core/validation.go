@@ -17,6 +17,11 @@ func ValidateAddress(address string) error {
 	return nil
 }
 
+// DefaultRequestTimeout is the timeout Request uses when called with timeout <= 0,
+// unless the bus is configured with another (GoCMDOptions.RequestTimeout,
+// ClusterNATSConfig.RequestTimeout, ClusterJetStreamConfig.RequestTimeout)
+const DefaultRequestTimeout = 5 * time.Second
+
 // ValidateTimeout validates a timeout duration
 func ValidateTimeout(timeout time.Duration) error {
 	if timeout <= 0 {
@@ -44,6 +49,20 @@ func ValidateBody(body interface{}) error {
 	return nil
 }
 
+// ValidateHeaders validates producer-supplied message headers: keys must be
+// non-empty and not reserved by the bus (see IsReservedHeader)
+func ValidateHeaders(headers map[string]string) error {
+	for key := range headers {
+		if key == "" {
+			return &EventBusError{Code: "INVALID_HEADER", Message: "header key cannot be empty"}
+		}
+		if IsReservedHeader(key) {
+			return &EventBusError{Code: "INVALID_HEADER", Message: "header is reserved: " + key}
+		}
+	}
+	return nil
+}
+
 // FailFast panics with an error (fail-fast principle)
 // Deprecated: Use failfast.Err instead
 func FailFast(err error) {
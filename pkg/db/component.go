@@ -108,7 +108,7 @@ func (c *DatabaseComponent) DB() *sql.DB {
 
 // Query executes a query that returns rows
 // Fail-fast: Validates state and inputs before querying
-func (c *DatabaseComponent) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+func (c *DatabaseComponent) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
 	if c == nil {
 		return nil, &core.EventBusError{Code: "INVALID_STATE", Message: "DatabaseComponent cannot be nil"}
 	}
@@ -126,7 +126,7 @@ func (c *DatabaseComponent) Query(ctx context.Context, query string, args ...int
 
 // QueryRow executes a query that returns a single row
 // Fail-fast: Validates state and inputs before querying
-func (c *DatabaseComponent) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+func (c *DatabaseComponent) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
 	if c == nil {
 		panic("DatabaseComponent cannot be nil")
 	}
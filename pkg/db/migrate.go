@@ -0,0 +1,305 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	DownSQL string // Empty if the migration cannot be rolled back
+}
+
+// MigratorConfig configures a Migrator
+type MigratorConfig struct {
+	// TableName tracks applied versions (default: "schema_migrations")
+	TableName string
+}
+
+// Migrator applies ordered .sql migrations and tracks them in a table
+//
+// Files are named "<version>_<name>.sql" (up only) or
+// "<version>_<name>.up.sql" / "<version>_<name>.down.sql", e.g.:
+//
+//	001_init.up.sql
+//	001_init.down.sql
+//	002_add_index.sql
+//
+// Each migration runs in its own transaction together with its bookkeeping row.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	table      string
+}
+
+// migrationFilePattern matches "<version>_<name>[.up|.down].sql"
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+?)(\.up|\.down)?\.sql$`)
+
+// tableNamePattern restricts the tracking table name to a safe identifier
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewMigrator loads migrations from fsys (e.g. an embed.FS) - fail-fast
+func NewMigrator(db *sql.DB, fsys fs.FS, config MigratorConfig) (*Migrator, error) {
+	if db == nil {
+		return nil, &Error{Code: "INVALID_INPUT", Message: "db cannot be nil"}
+	}
+	if fsys == nil {
+		return nil, &Error{Code: "INVALID_INPUT", Message: "migration filesystem cannot be nil"}
+	}
+
+	table := config.TableName
+	if table == "" {
+		table = "schema_migrations"
+	}
+	if !tableNamePattern.MatchString(table) {
+		return nil, &Error{Code: "INVALID_CONFIG", Message: fmt.Sprintf("invalid migration table name: %q", table)}
+	}
+
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		table:      table,
+	}, nil
+}
+
+// NewMigratorFromDir loads migrations from a directory on disk
+func NewMigratorFromDir(db *sql.DB, dir string, config MigratorConfig) (*Migrator, error) {
+	return NewMigrator(db, os.DirFS(dir), config)
+}
+
+// loadMigrations reads and orders migrations from the root of fsys
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, &Error{Code: "INVALID_MIGRATION", Message: fmt.Sprintf("invalid migration version in %s", entry.Name())}
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, &Error{Code: "INVALID_MIGRATION", Message: fmt.Sprintf("duplicate migration version %d (%s, %s)", version, m.Name, match[2])}
+		}
+
+		if match[3] == ".down" {
+			m.DownSQL = string(content)
+		} else {
+			if m.UpSQL != "" {
+				return nil, &Error{Code: "INVALID_MIGRATION", Message: fmt.Sprintf("duplicate up migration for version %d", version)}
+			}
+			m.UpSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.UpSQL) == "" {
+			return nil, &Error{Code: "INVALID_MIGRATION", Message: fmt.Sprintf("migration %d_%s has no up SQL", m.Version, m.Name)}
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrations returns all known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return append([]Migration(nil), m.migrations...)
+}
+
+// ensureTable creates the tracking table if needed
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`, m.table))
+	return err
+}
+
+// Applied returns the applied versions in ascending order
+func (m *Migrator) Applied(ctx context.Context) ([]int64, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf("SELECT version FROM %s ORDER BY version", m.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []int64
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// Pending returns migrations that have not been applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int64]bool, len(applied))
+	for _, v := range applied {
+		done[v] = true
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if !done[mig.Version] {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies all pending migrations in order and returns the ones applied
+// Stops at the first failure; earlier migrations stay applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, mig := range pending {
+		// Version is an int64 and name is formatted as a quoted literal, so the
+		// bookkeeping statement stays placeholder-free across drivers
+		record := fmt.Sprintf("INSERT INTO %s (version, name) VALUES (%d, '%s')",
+			m.table, mig.Version, strings.ReplaceAll(mig.Name, "'", "''"))
+		if err := m.runInTx(ctx, mig.UpSQL, record); err != nil {
+			return applied, fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		applied = append(applied, mig)
+	}
+	return applied, nil
+}
+
+// Down rolls back the most recently applied migrations, up to steps
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, &Error{Code: "INVALID_INPUT", Message: "steps must be positive"}
+	}
+
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+
+	var rolledBack []Migration
+	for i := len(applied) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		mig, ok := known[applied[i]]
+		if !ok {
+			return rolledBack, &Error{Code: "INVALID_MIGRATION", Message: fmt.Sprintf("applied migration %d has no source file", applied[i])}
+		}
+		if strings.TrimSpace(mig.DownSQL) == "" {
+			return rolledBack, &Error{Code: "IRREVERSIBLE_MIGRATION", Message: fmt.Sprintf("migration %d_%s has no down SQL", mig.Version, mig.Name)}
+		}
+		record := fmt.Sprintf("DELETE FROM %s WHERE version = %d", m.table, mig.Version)
+		if err := m.runInTx(ctx, mig.DownSQL, record); err != nil {
+			return rolledBack, fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		rolledBack = append(rolledBack, mig)
+	}
+	return rolledBack, nil
+}
+
+// runInTx executes statements in a single transaction
+func (m *Migrator) runInTx(ctx context.Context, statements ...string) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				// Best-effort rollback; the original error is more useful.
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// MigrationVerticle applies pending migrations when deployed
+// Deploy it before verticles that depend on the schema
+type MigrationVerticle struct {
+	*core.BaseVerticle
+	migrator *Migrator
+}
+
+// NewMigrationVerticle creates a verticle that runs migrator.Up on start
+func NewMigrationVerticle(migrator *Migrator) *MigrationVerticle {
+	if migrator == nil {
+		panic("migrator cannot be nil")
+	}
+	return &MigrationVerticle{
+		BaseVerticle: core.NewBaseVerticle("migration-verticle"),
+		migrator:     migrator,
+	}
+}
+
+// Start applies pending migrations - fail-fast: a failed migration fails the deployment
+func (v *MigrationVerticle) Start(ctx core.FluxorContext) error {
+	if err := v.BaseVerticle.Start(ctx); err != nil {
+		return err
+	}
+
+	applied, err := v.migrator.Up(ctx.Context())
+	if err != nil {
+		return err
+	}
+	for _, mig := range applied {
+		core.Info(fmt.Sprintf("applied migration %d_%s", mig.Version, mig.Name))
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newMigrationTestPool(t *testing.T) *Pool {
+	t.Helper()
+	pool, err := NewPool(DefaultPoolConfig(filepath.Join(t.TempDir(), "migrate.db"), "sqlite3"))
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return pool
+}
+
+func tableExists(t *testing.T, pool *Pool, name string) bool {
+	t.Helper()
+	var count int
+	if err := pool.QueryRow(context.Background(),
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatalf("query sqlite_master: %v", err)
+	}
+	return count > 0
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	pool := newMigrationTestPool(t)
+	fsys := fstest.MapFS{
+		"001_users.up.sql":   {Data: []byte("CREATE TABLE users (id INTEGER PRIMARY KEY)")},
+		"001_users.down.sql": {Data: []byte("DROP TABLE users")},
+		"002_posts.up.sql":   {Data: []byte("CREATE TABLE posts (id INTEGER PRIMARY KEY)")},
+		"002_posts.down.sql": {Data: []byte("DROP TABLE posts")},
+		"README.md":          {Data: []byte("ignored")},
+	}
+
+	m, err := NewMigrator(pool.DB(), fsys, MigratorConfig{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if got := len(m.Migrations()); got != 2 {
+		t.Fatalf("Migrations() len = %d, want 2", got)
+	}
+
+	ctx := context.Background()
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(applied) != 2 || applied[0].Version != 1 || applied[1].Version != 2 {
+		t.Fatalf("Up() applied = %+v, want versions 1, 2", applied)
+	}
+	if !tableExists(t, pool, "users") || !tableExists(t, pool, "posts") {
+		t.Fatal("Up() did not create tables")
+	}
+
+	// Second run is a no-op
+	applied, err = m.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("second Up() = %d applied, err %v; want 0, nil", len(applied), err)
+	}
+
+	rolledBack, err := m.Down(ctx, 1)
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(rolledBack) != 1 || rolledBack[0].Version != 2 {
+		t.Fatalf("Down() rolled back %+v, want version 2", rolledBack)
+	}
+	if tableExists(t, pool, "posts") {
+		t.Error("Down() did not drop posts")
+	}
+
+	versions, err := m.Applied(ctx)
+	if err != nil {
+		t.Fatalf("Applied() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0] != 1 {
+		t.Errorf("Applied() = %v, want [1]", versions)
+	}
+}
+
+func TestMigrator_FailedMigrationRollsBack(t *testing.T) {
+	pool := newMigrationTestPool(t)
+	fsys := fstest.MapFS{
+		"001_ok.sql":     {Data: []byte("CREATE TABLE ok (id INTEGER)")},
+		"002_broken.sql": {Data: []byte("CREATE TABLE broken (id INTEGER); NOT VALID SQL")},
+	}
+
+	m, err := NewMigrator(pool.DB(), fsys, MigratorConfig{TableName: "my_migrations"})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	applied, err := m.Up(context.Background())
+	if err == nil {
+		t.Fatal("Up() should fail on invalid SQL")
+	}
+	if len(applied) != 1 {
+		t.Errorf("Up() applied %d migrations before failure, want 1", len(applied))
+	}
+
+	versions, err := m.Applied(context.Background())
+	if err != nil {
+		t.Fatalf("Applied() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0] != 1 {
+		t.Errorf("Applied() = %v, want [1]", versions)
+	}
+	if !tableExists(t, pool, "my_migrations") {
+		t.Error("custom tracking table was not created")
+	}
+}
+
+func TestMigrator_DownWithoutDownSQL(t *testing.T) {
+	pool := newMigrationTestPool(t)
+	m, err := NewMigrator(pool.DB(), fstest.MapFS{
+		"001_init.sql": {Data: []byte("CREATE TABLE t (id INTEGER)")},
+	}, MigratorConfig{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Error("Down() should fail for a migration without down SQL")
+	}
+}
+
+func TestNewMigrator_Validation(t *testing.T) {
+	pool := newMigrationTestPool(t)
+
+	if _, err := NewMigrator(nil, fstest.MapFS{}, MigratorConfig{}); err == nil {
+		t.Error("NewMigrator(nil db) should fail")
+	}
+	if _, err := NewMigrator(pool.DB(), fstest.MapFS{}, MigratorConfig{TableName: "bad; DROP"}); err == nil {
+		t.Error("NewMigrator() should reject an unsafe table name")
+	}
+	if _, err := NewMigrator(pool.DB(), fstest.MapFS{
+		"001_a.sql": {Data: []byte("SELECT 1")},
+		"001_b.sql": {Data: []byte("SELECT 1")},
+	}, MigratorConfig{}); err == nil {
+		t.Error("NewMigrator() should reject duplicate versions")
+	}
+	if _, err := NewMigrator(pool.DB(), fstest.MapFS{
+		"001_a.down.sql": {Data: []byte("SELECT 1")},
+	}, MigratorConfig{}); err == nil {
+		t.Error("NewMigrator() should reject a migration without up SQL")
+	}
+}
@@ -3,7 +3,12 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // PoolConfig configures database connection pool (similar to HikariConfig)
@@ -25,6 +30,17 @@ type PoolConfig struct {
 
 	// DriverName is the database driver name (e.g., "postgres", "mysql")
 	DriverName string
+
+	// DefaultQueryTimeout bounds Query/QueryRow/Exec when the caller's context
+	// has no earlier deadline (0 = no timeout)
+	DefaultQueryTimeout time.Duration
+
+	// SlowQueryThreshold logs queries that take longer than this at Info, with
+	// SQL and duration (0 = disabled)
+	SlowQueryThreshold time.Duration
+
+	// Logger is used for slow-query logging (default: core.NewDefaultLogger())
+	Logger core.Logger
 }
 
 // DefaultPoolConfig returns HikariCP-like default configuration
@@ -43,6 +59,7 @@ func DefaultPoolConfig(dsn string, driverName string) PoolConfig {
 type Pool struct {
 	db     *sql.DB
 	config PoolConfig
+	logger core.Logger
 }
 
 // NewPool creates a new database connection pool (similar to HikariDataSource)
@@ -70,6 +87,12 @@ func NewPool(config PoolConfig) (*Pool, error) {
 	if config.ConnMaxIdleTime < 0 {
 		return nil, &Error{Code: "INVALID_CONFIG", Message: "ConnMaxIdleTime cannot be negative"}
 	}
+	if config.DefaultQueryTimeout < 0 {
+		return nil, &Error{Code: "INVALID_CONFIG", Message: "DefaultQueryTimeout cannot be negative"}
+	}
+	if config.SlowQueryThreshold < 0 {
+		return nil, &Error{Code: "INVALID_CONFIG", Message: "SlowQueryThreshold cannot be negative"}
+	}
 
 	// Open database (creates pool)
 	db, err := sql.Open(config.DriverName, config.DSN)
@@ -94,9 +117,15 @@ func NewPool(config PoolConfig) (*Pool, error) {
 		return nil, err
 	}
 
+	logger := config.Logger
+	if logger == nil {
+		logger = core.NewDefaultLogger()
+	}
+
 	return &Pool{
 		db:     db,
 		config: config,
+		logger: logger,
 	}, nil
 }
 
@@ -160,7 +189,7 @@ func (p *Pool) Stats() sql.DBStats {
 
 // Query executes a query that returns rows
 // Fail-fast: Validates inputs before querying
-func (p *Pool) Query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+func (p *Pool) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
 	if p == nil {
 		return nil, &Error{Code: "INVALID_STATE", Message: "pool cannot be nil"}
 	}
@@ -173,12 +202,20 @@ func (p *Pool) Query(ctx context.Context, query string, args ...interface{}) (*s
 	if query == "" {
 		return nil, &Error{Code: "INVALID_INPUT", Message: "query cannot be empty"}
 	}
-	return p.db.QueryContext(ctx, query, args...)
+	ctx, cancel := p.withQueryTimeout(ctx)
+	start := time.Now()
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	p.observe(ctx, query, start)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Rows{Rows: rows, cancel: cancel}, nil
 }
 
 // QueryRow executes a query that returns a single row
 // Fail-fast: Validates inputs before querying
-func (p *Pool) QueryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+func (p *Pool) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
 	if p == nil {
 		panic("pool cannot be nil")
 	}
@@ -191,7 +228,11 @@ func (p *Pool) QueryRow(ctx context.Context, query string, args ...interface{})
 	if query == "" {
 		panic("query cannot be empty")
 	}
-	return p.db.QueryRowContext(ctx, query, args...)
+	ctx, cancel := p.withQueryTimeout(ctx)
+	start := time.Now()
+	row := p.db.QueryRowContext(ctx, query, args...)
+	p.observe(ctx, query, start)
+	return &Row{Row: row, cancel: cancel}
 }
 
 // Exec executes a command
@@ -209,7 +250,86 @@ func (p *Pool) Exec(ctx context.Context, query string, args ...interface{}) (sql
 	if query == "" {
 		return nil, &Error{Code: "INVALID_INPUT", Message: "query cannot be empty"}
 	}
-	return p.db.ExecContext(ctx, query, args...)
+	ctx, cancel := p.withQueryTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	result, err := p.db.ExecContext(ctx, query, args...)
+	p.observe(ctx, query, start)
+	return result, err
+}
+
+// withQueryTimeout applies DefaultQueryTimeout to ctx. Results read after the
+// call returns hand cancel to their Rows or Row, which call it once read.
+func (p *Pool) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := p.config.DefaultQueryTimeout
+	if timeout <= 0 {
+		return ctx, noCancel
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// noCancel is the cancel func of a query without DefaultQueryTimeout
+func noCancel() {}
+
+// Rows is the result of Query. It releases the query's DefaultQueryTimeout
+// when closed or fully read; always Close it, as with *sql.Rows.
+type Rows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+// Next prepares the next row, releasing the query context after the last one
+func (r *Rows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+// Close closes the rows and releases the query context
+func (r *Rows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// Row is the result of QueryRow. Scan releases the query's DefaultQueryTimeout.
+type Row struct {
+	*sql.Row
+	cancel context.CancelFunc
+}
+
+// Scan copies the row into dest and releases the query context
+func (r *Row) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	return r.Row.Scan(dest...)
+}
+
+// observe logs the query when it exceeded SlowQueryThreshold and marks the
+// active span (if any) as slow
+func (p *Pool) observe(ctx context.Context, query string, start time.Time) {
+	threshold := p.config.SlowQueryThreshold
+	if threshold <= 0 {
+		return
+	}
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	p.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"sql":          query,
+		"duration_ms":  duration.Milliseconds(),
+		"threshold_ms": threshold.Milliseconds(),
+	}).Info(fmt.Sprintf("slow query (%v): %s", duration, query))
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(
+			attribute.Bool("db.slow_query", true),
+			attribute.Int64("db.duration_ms", duration.Milliseconds()),
+		)
+		span.AddEvent("slow_query")
+	}
 }
 
 // Begin starts a transaction
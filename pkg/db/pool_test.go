@@ -1,8 +1,16 @@
 package db
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
 )
 
 func TestDefaultPoolConfig(t *testing.T) {
@@ -48,3 +56,129 @@ func TestPoolConfig(t *testing.T) {
 
 // Note: Actual pool tests would require a real database connection
 // These are unit tests for configuration only
+
+// recordingLogger captures error and info messages, prefixed by level, for assertions
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(level string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, level+" "+fmt.Sprint(args...))
+}
+
+func (l *recordingLogger) Error(args ...interface{})                            { l.record("ERROR", args...) }
+func (l *recordingLogger) Info(args ...interface{})                             { l.record("INFO", args...) }
+func (l *recordingLogger) Debug(args ...interface{})                            {}
+func (l *recordingLogger) WithFields(fields map[string]interface{}) core.Logger { return l }
+func (l *recordingLogger) WithContext(ctx context.Context) core.Logger          { return l }
+
+func TestPool_SlowQueryLogging(t *testing.T) {
+	logger := &recordingLogger{}
+	config := DefaultPoolConfig(filepath.Join(t.TempDir(), "slow.db"), "sqlite3")
+	config.SlowQueryThreshold = time.Nanosecond // every query is "slow"
+	config.Logger = logger
+
+	pool, err := NewPool(config)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	if _, err := pool.Exec(context.Background(), "CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.messages) != 1 || !strings.HasPrefix(logger.messages[0], "INFO ") || !strings.Contains(logger.messages[0], "CREATE TABLE t") {
+		t.Errorf("slow query log = %v, want one Info entry containing the SQL", logger.messages)
+	}
+}
+
+func TestPool_DefaultQueryTimeout(t *testing.T) {
+	config := DefaultPoolConfig(filepath.Join(t.TempDir(), "timeout.db"), "sqlite3")
+	if config.DefaultQueryTimeout != 0 {
+		t.Errorf("DefaultPoolConfig().DefaultQueryTimeout = %v, want 0 (opt-in)", config.DefaultQueryTimeout)
+	}
+	pool, err := NewPool(config)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	// An already-expired timeout must be applied to the query context
+	pool.config.DefaultQueryTimeout = time.Nanosecond
+	_, err = pool.Exec(context.Background(), "CREATE TABLE t (id INTEGER)")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Exec() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestPool_QueryReleasesTimeout(t *testing.T) {
+	config := DefaultPoolConfig(filepath.Join(t.TempDir(), "release.db"), "sqlite3")
+	config.DefaultQueryTimeout = time.Hour
+	pool, err := NewPool(config)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	if _, err := pool.Exec(ctx, "CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if _, err := pool.Exec(ctx, "INSERT INTO t (id) VALUES (1), (2)"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	countCancel := func(cancel *context.CancelFunc) *int {
+		calls := new(int)
+		inner := *cancel
+		*cancel = func() { *calls++; inner() }
+		return calls
+	}
+
+	rows, err := pool.Query(ctx, "SELECT id FROM t")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	calls := countCancel(&rows.cancel)
+	var n int
+	for rows.Next() {
+		n++
+	}
+	if n != 2 || *calls == 0 {
+		t.Errorf("read %d rows, cancel calls = %d; want 2 rows and the timeout released", n, *calls)
+	}
+	_ = rows.Close()
+
+	rows, err = pool.Query(ctx, "SELECT id FROM t")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	calls = countCancel(&rows.cancel)
+	_ = rows.Close()
+	if *calls == 0 {
+		t.Error("closing rows early should release the timeout")
+	}
+
+	row := pool.QueryRow(ctx, "SELECT id FROM t WHERE id = 2")
+	calls = countCancel(&row.cancel)
+	var id int
+	if err := row.Scan(&id); err != nil || id != 2 {
+		t.Fatalf("Scan() = %d, %v; want 2", id, err)
+	}
+	if *calls == 0 {
+		t.Error("Scan should release the timeout")
+	}
+}
+
+func TestNewPool_FailFast_NegativeSlowQueryThreshold(t *testing.T) {
+	config := DefaultPoolConfig("test-dsn", "sqlite3")
+	config.SlowQueryThreshold = -time.Second
+	if _, err := NewPool(config); err == nil {
+		t.Error("NewPool() should reject negative SlowQueryThreshold")
+	}
+}
@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+// Querier is the query surface shared by Pool and ReadWritePool
+type Querier interface {
+	Query(ctx context.Context, query string, args ...interface{}) (*Rows, error)
+	QueryRow(ctx context.Context, query string, args ...interface{}) *Row
+	Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	Begin(ctx context.Context) (*sql.Tx, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+var (
+	_ Querier = (*Pool)(nil)
+	_ Querier = (*ReadWritePool)(nil)
+)
+
+// RWConfig configures a read/write split pool
+type RWConfig struct {
+	// WriteDSN is the primary database (receives Exec and transactions)
+	WriteDSN string
+
+	// ReadDSNs are the replicas (receive Query/QueryRow, round-robin)
+	// When empty, reads go to the primary
+	ReadDSNs []string
+
+	// Pool holds the pool settings applied to every endpoint (DSN is ignored)
+	// Use DefaultPoolConfig("", driver) for HikariCP-like defaults
+	Pool PoolConfig
+}
+
+// ReadWritePool routes reads to replicas and writes to the primary
+type ReadWritePool struct {
+	primary  *Pool
+	replicas []*Pool
+	next     uint64 // Round-robin cursor (atomic)
+}
+
+// primaryKey marks a context as requiring the primary for reads
+type primaryKey struct{}
+
+// WithPrimary returns a context that forces reads on a ReadWritePool to the primary
+// Use it for read-after-write consistency when replicas may lag
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// usePrimary reports whether the context forces the primary
+func usePrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// NewReadWritePool creates pools for the primary and every replica
+// Fail-fast: any endpoint that fails to connect aborts creation and closes the others
+func NewReadWritePool(config RWConfig) (*ReadWritePool, error) {
+	if config.WriteDSN == "" {
+		return nil, &Error{Code: "INVALID_CONFIG", Message: "WriteDSN cannot be empty"}
+	}
+
+	primaryConfig := config.Pool
+	primaryConfig.DSN = config.WriteDSN
+	primary, err := NewPool(primaryConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	rw := &ReadWritePool{
+		primary:  primary,
+		replicas: make([]*Pool, 0, len(config.ReadDSNs)),
+	}
+
+	for _, dsn := range config.ReadDSNs {
+		replicaConfig := config.Pool
+		replicaConfig.DSN = dsn
+		replica, err := NewPool(replicaConfig)
+		if err != nil {
+			if cerr := rw.Close(); cerr != nil {
+				// Best-effort cleanup; ignore on error.
+			}
+			return nil, err
+		}
+		rw.replicas = append(rw.replicas, replica)
+	}
+
+	return rw, nil
+}
+
+// Primary returns the primary pool
+func (rw *ReadWritePool) Primary() *Pool {
+	return rw.primary
+}
+
+// Replicas returns the replica pools
+func (rw *ReadWritePool) Replicas() []*Pool {
+	return rw.replicas
+}
+
+// reader picks the pool for a read
+func (rw *ReadWritePool) reader(ctx context.Context) *Pool {
+	if len(rw.replicas) == 0 || (ctx != nil && usePrimary(ctx)) {
+		return rw.primary
+	}
+	n := atomic.AddUint64(&rw.next, 1)
+	return rw.replicas[(n-1)%uint64(len(rw.replicas))]
+}
+
+// Query executes a read on a replica (or the primary when forced via WithPrimary)
+func (rw *ReadWritePool) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	if rw == nil || rw.primary == nil {
+		return nil, &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	return rw.reader(ctx).Query(ctx, query, args...)
+}
+
+// QueryRow executes a single-row read on a replica (or the primary when forced)
+func (rw *ReadWritePool) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
+	if rw == nil || rw.primary == nil {
+		panic("pool not initialized")
+	}
+	return rw.reader(ctx).QueryRow(ctx, query, args...)
+}
+
+// Exec executes a command on the primary
+func (rw *ReadWritePool) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if rw == nil || rw.primary == nil {
+		return nil, &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	return rw.primary.Exec(ctx, query, args...)
+}
+
+// Begin starts a transaction on the primary
+func (rw *ReadWritePool) Begin(ctx context.Context) (*sql.Tx, error) {
+	if rw == nil || rw.primary == nil {
+		return nil, &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	return rw.primary.Begin(ctx)
+}
+
+// BeginTx starts a transaction with options on the primary
+func (rw *ReadWritePool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if rw == nil || rw.primary == nil {
+		return nil, &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	return rw.primary.BeginTx(ctx, opts)
+}
+
+// Ping tests the primary and every replica
+func (rw *ReadWritePool) Ping(ctx context.Context) error {
+	if rw == nil || rw.primary == nil {
+		return &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	if err := rw.primary.Ping(ctx); err != nil {
+		return err
+	}
+	for _, replica := range rw.replicas {
+		if err := replica.Ping(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes every endpoint, returning the first error encountered
+func (rw *ReadWritePool) Close() error {
+	if rw == nil || rw.primary == nil {
+		return &Error{Code: "INVALID_STATE", Message: "pool not initialized"}
+	}
+	firstErr := rw.primary.Close()
+	for _, replica := range rw.replicas {
+		if err := replica.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// EndpointStats holds pool statistics for one endpoint
+type EndpointStats struct {
+	Role  string // "primary" or "replica"
+	Index int    // Replica index (0 for the primary)
+	Stats sql.DBStats
+}
+
+// Stats returns pool statistics per endpoint, primary first
+func (rw *ReadWritePool) Stats() []EndpointStats {
+	if rw == nil || rw.primary == nil {
+		return nil
+	}
+	stats := make([]EndpointStats, 0, len(rw.replicas)+1)
+	stats = append(stats, EndpointStats{Role: "primary", Stats: rw.primary.Stats()})
+	for i, replica := range rw.replicas {
+		stats = append(stats, EndpointStats{Role: "replica", Index: i, Stats: replica.Stats()})
+	}
+	return stats
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newEndpoint creates a sqlite database file whose "endpoint" table names it
+func newEndpoint(t *testing.T, name string) string {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), name+".db")
+
+	pool, err := NewPool(DefaultPoolConfig(dsn, "sqlite3"))
+	if err != nil {
+		t.Fatalf("NewPool(%s) error = %v", name, err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	if _, err := pool.Exec(ctx, "CREATE TABLE endpoint (name TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := pool.Exec(ctx, "INSERT INTO endpoint (name) VALUES (?)", name); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	return dsn
+}
+
+func TestReadWritePool_Routing(t *testing.T) {
+	rw, err := NewReadWritePool(RWConfig{
+		WriteDSN: newEndpoint(t, "primary"),
+		ReadDSNs: []string{newEndpoint(t, "replica-0"), newEndpoint(t, "replica-1")},
+		Pool:     DefaultPoolConfig("", "sqlite3"),
+	})
+	if err != nil {
+		t.Fatalf("NewReadWritePool() error = %v", err)
+	}
+	defer rw.Close()
+
+	ctx := context.Background()
+	readName := func(ctx context.Context) string {
+		var name string
+		if err := rw.QueryRow(ctx, "SELECT name FROM endpoint").Scan(&name); err != nil {
+			t.Fatalf("QueryRow() error = %v", err)
+		}
+		return name
+	}
+
+	// Reads rotate across replicas
+	if got := readName(ctx); got != "replica-0" {
+		t.Errorf("first read went to %s, want replica-0", got)
+	}
+	if got := readName(ctx); got != "replica-1" {
+		t.Errorf("second read went to %s, want replica-1", got)
+	}
+
+	// Forced primary read
+	if got := readName(WithPrimary(ctx)); got != "primary" {
+		t.Errorf("WithPrimary read went to %s, want primary", got)
+	}
+
+	// Writes go to the primary
+	if _, err := rw.Exec(ctx, "UPDATE endpoint SET name = 'primary-written'"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if got := readName(WithPrimary(ctx)); got != "primary-written" {
+		t.Errorf("primary after Exec = %s, want primary-written", got)
+	}
+
+	stats := rw.Stats()
+	if len(stats) != 3 || stats[0].Role != "primary" || stats[2].Role != "replica" || stats[2].Index != 1 {
+		t.Errorf("Stats() = %+v, want primary followed by two replicas", stats)
+	}
+}
+
+func TestNewReadWritePool_FailFast_EmptyWriteDSN(t *testing.T) {
+	_, err := NewReadWritePool(RWConfig{Pool: DefaultPoolConfig("", "sqlite3")})
+	if err == nil {
+		t.Fatal("NewReadWritePool() should fail with empty WriteDSN")
+	}
+	if dbErr, ok := err.(*Error); !ok || dbErr.Code != "INVALID_CONFIG" {
+		t.Errorf("error = %v, want INVALID_CONFIG", err)
+	}
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// tracerName identifies spans created by this package
+const tracerName = "github.com/fluxorio/fluxor/pkg/db"
+
+// TxOption configures a transaction started by WithTransaction
+type TxOption func(*sql.TxOptions)
+
+// WithIsolation sets the transaction isolation level
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+// WithReadOnly marks the transaction read-only
+func WithReadOnly() TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.ReadOnly = true
+	}
+}
+
+// WithTransaction runs fn inside a transaction
+// Commits when fn returns nil; rolls back when fn returns an error or panics
+// (the panic is re-raised after rollback)
+func (p *Pool) WithTransaction(ctx context.Context, fn func(tx *sql.Tx) error, opts ...TxOption) (err error) {
+	if ctx == nil {
+		return &Error{Code: "INVALID_INPUT", Message: "context cannot be nil"}
+	}
+	if fn == nil {
+		return &Error{Code: "INVALID_INPUT", Message: "transaction function cannot be nil"}
+	}
+
+	txOpts := &sql.TxOptions{}
+	for _, opt := range opts {
+		opt(txOpts)
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "db.transaction")
+	span.SetAttributes(
+		attribute.String("db.system", p.driverName()),
+		attribute.String("db.transaction.isolation", txOpts.Isolation.String()),
+		attribute.Bool("db.transaction.read_only", txOpts.ReadOnly),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	tx, err := p.BeginTx(ctx, txOpts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				// Best-effort rollback; the panic is more useful.
+			}
+			span.SetAttributes(attribute.String("db.transaction.outcome", "panic"))
+			span.SetStatus(codes.Error, fmt.Sprint(r))
+			panic(r)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		span.SetAttributes(attribute.String("db.transaction.outcome", "rollback"))
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.String("db.transaction.outcome", "commit"))
+	return nil
+}
+
+// driverName returns the configured driver name for span attributes
+func (p *Pool) driverName() string {
+	if p == nil {
+		return ""
+	}
+	return p.config.DriverName
+}
+
+// WithTransaction runs fn inside a transaction (see Pool.WithTransaction)
+// Fail-fast: Validates state before beginning the transaction
+func (c *DatabaseComponent) WithTransaction(ctx context.Context, fn func(tx *sql.Tx) error, opts ...TxOption) error {
+	if c == nil {
+		return &core.EventBusError{Code: "INVALID_STATE", Message: "DatabaseComponent cannot be nil"}
+	}
+	if c.pool == nil {
+		return &core.EventBusError{Code: "NOT_STARTED", Message: "database component not started - call Start() first"}
+	}
+	return c.pool.WithTransaction(ctx, fn, opts...)
+}
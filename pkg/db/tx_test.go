@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTxTestPool(t *testing.T) *Pool {
+	t.Helper()
+	pool, err := NewPool(DefaultPoolConfig(filepath.Join(t.TempDir(), "tx.db"), "sqlite3"))
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	if _, err := pool.Exec(context.Background(), "CREATE TABLE items (name TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return pool
+}
+
+func countItems(t *testing.T, pool *Pool) int {
+	t.Helper()
+	var n int
+	if err := pool.QueryRow(context.Background(), "SELECT COUNT(*) FROM items").Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return n
+}
+
+func TestPool_WithTransaction_Commit(t *testing.T) {
+	pool := newTxTestPool(t)
+
+	err := pool.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO items (name) VALUES ('a')")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+	if got := countItems(t, pool); got != 1 {
+		t.Errorf("items = %d, want 1", got)
+	}
+}
+
+func TestPool_WithTransaction_RollbackOnError(t *testing.T) {
+	pool := newTxTestPool(t)
+	wantErr := errors.New("boom")
+
+	err := pool.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		if _, err := tx.Exec("INSERT INTO items (name) VALUES ('a')"); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, wantErr)
+	}
+	if got := countItems(t, pool); got != 0 {
+		t.Errorf("items = %d, want 0 after rollback", got)
+	}
+}
+
+func TestPool_WithTransaction_RollbackOnPanic(t *testing.T) {
+	pool := newTxTestPool(t)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recover() = %v, want re-panic with boom", r)
+			}
+		}()
+		_ = pool.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+			if _, err := tx.Exec("INSERT INTO items (name) VALUES ('a')"); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	if got := countItems(t, pool); got != 0 {
+		t.Errorf("items = %d, want 0 after rollback", got)
+	}
+}
+
+func TestPool_WithTransaction_Options(t *testing.T) {
+	pool := newTxTestPool(t)
+
+	// sqlite rejects isolation levels it doesn't support; serializable is accepted
+	err := pool.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		return nil
+	}, WithIsolation(sql.LevelSerializable), WithReadOnly())
+	if err != nil {
+		t.Fatalf("WithTransaction() with options error = %v", err)
+	}
+
+	if err := pool.WithTransaction(context.Background(), nil); err == nil {
+		t.Error("WithTransaction(nil fn) should fail")
+	}
+}
+
+func TestDatabaseComponent_WithTransaction_NotStarted(t *testing.T) {
+	component := NewDatabaseComponent(DefaultPoolConfig("test-dsn", "postgres"))
+
+	err := component.WithTransaction(context.Background(), func(tx *sql.Tx) error { return nil })
+	if err == nil {
+		t.Error("WithTransaction() should return error when not started")
+	}
+}
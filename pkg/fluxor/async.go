@@ -2,6 +2,7 @@ package fluxor
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core"
@@ -336,3 +337,58 @@ func Race[T any](ctx context.Context, futures ...interface {
 
 	return &promise.FutureT
 }
+
+// Any returns the first future that succeeds (Promise.any() style)
+// Fails with all errors joined if every future fails
+// Accepts both FutureT and PromiseT
+func Any[T any](ctx context.Context, futures ...interface {
+	Await(context.Context) (T, error)
+}) *FutureT[T] {
+	promise := NewPromiseT[T]()
+
+	go func() {
+		if len(futures) == 0 {
+			promise.Fail(&Error{Message: "no futures provided"})
+			return
+		}
+
+		resultChan := make(chan T, 1)
+		errChan := make(chan error, len(futures))
+
+		for _, f := range futures {
+			go func(future interface {
+				Await(context.Context) (T, error)
+			}) {
+				result, err := future.Await(ctx)
+				if err != nil {
+					errChan <- err
+					return
+				}
+				select {
+				case resultChan <- result:
+				default:
+				}
+			}(f)
+		}
+
+		errs := make([]error, 0, len(futures))
+		for {
+			select {
+			case result := <-resultChan:
+				promise.Complete(result)
+				return
+			case err := <-errChan:
+				errs = append(errs, err)
+				if len(errs) == len(futures) {
+					promise.Fail(errors.Join(errs...))
+					return
+				}
+			case <-ctx.Done():
+				promise.Fail(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	return &promise.FutureT
+}
@@ -199,3 +199,40 @@ func TestRace(t *testing.T) {
 		t.Errorf("Race() = %v, want second", result)
 	}
 }
+
+func TestAny(t *testing.T) {
+	p1 := NewPromiseT[string]()
+	p2 := NewPromiseT[string]()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p1.Fail(errors.New("first failed"))
+	}()
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		p2.Complete("second")
+	}()
+
+	ctx := context.Background()
+	result, err := Any(ctx, p1, p2).Await(ctx)
+	if err != nil {
+		t.Fatalf("Any() error = %v, want nil", err)
+	}
+	if result != "second" {
+		t.Errorf("Any() = %v, want second", result)
+	}
+}
+
+func TestAny_AllFail(t *testing.T) {
+	p1 := NewPromiseT[int]()
+	p2 := NewPromiseT[int]()
+	err1 := errors.New("error 1")
+	p1.Fail(err1)
+	p2.Fail(errors.New("error 2"))
+
+	ctx := context.Background()
+	_, err := Any(ctx, p1, p2).Await(ctx)
+	if !errors.Is(err, err1) {
+		t.Errorf("Any() error = %v, want joined errors including %v", err, err1)
+	}
+}
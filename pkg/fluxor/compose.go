@@ -0,0 +1,97 @@
+package fluxor
+
+import (
+	"errors"
+	"sync"
+)
+
+// AllFutures returns a Future that completes with every result ([]interface{}, in
+// argument order) once all futures succeed, or fails fast with the first error
+// (Vert.x Future.all / Promise.all() style)
+func AllFutures(futures ...Future) Future {
+	combined := NewFuture()
+	if len(futures) == 0 {
+		combined.Complete([]interface{}{})
+		return combined
+	}
+
+	var mu sync.Mutex
+	results := make([]interface{}, len(futures))
+	remaining := len(futures)
+
+	for i, f := range futures {
+		i := i
+		f.OnSuccess(func(result interface{}) {
+			mu.Lock()
+			results[i] = result
+			remaining--
+			done := remaining == 0
+			mu.Unlock()
+
+			if done {
+				combined.Complete(results)
+			}
+		})
+		f.OnFailure(func(err error) {
+			combined.Fail(err)
+		})
+	}
+
+	return combined
+}
+
+// AnyFuture returns a Future that completes with the first successful result,
+// or fails with all errors joined once every future has failed
+// (Vert.x Future.any / Promise.any() style)
+func AnyFuture(futures ...Future) Future {
+	combined := NewFuture()
+	if len(futures) == 0 {
+		combined.Fail(&Error{Message: "no futures provided"})
+		return combined
+	}
+
+	var mu sync.Mutex
+	errs := make([]error, len(futures))
+	remaining := len(futures)
+
+	for i, f := range futures {
+		i := i
+		f.OnSuccess(func(result interface{}) {
+			combined.Complete(result)
+		})
+		f.OnFailure(func(err error) {
+			mu.Lock()
+			errs[i] = err
+			remaining--
+			done := remaining == 0
+			mu.Unlock()
+
+			if done {
+				combined.Fail(errors.Join(errs...))
+			}
+		})
+	}
+
+	return combined
+}
+
+// RaceFutures returns a Future that settles like the first future to complete,
+// whether it succeeded or failed (Promise.race() style)
+func RaceFutures(futures ...Future) Future {
+	combined := NewFuture()
+	if len(futures) == 0 {
+		combined.Fail(&Error{Message: "no futures provided"})
+		return combined
+	}
+
+	for _, f := range futures {
+		f.OnSuccess(func(result interface{}) {
+			combined.Complete(result)
+		})
+		f.OnFailure(func(err error) {
+			combined.Fail(err)
+		})
+	}
+
+	return combined
+}
@@ -0,0 +1,90 @@
+package fluxor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func completeAfter(f Future, d time.Duration, value interface{}, err error) {
+	go func() {
+		time.Sleep(d)
+		if err != nil {
+			f.Fail(err)
+			return
+		}
+		f.Complete(value)
+	}()
+}
+
+func TestAllFutures(t *testing.T) {
+	f1, f2 := NewFuture(), NewFuture()
+	completeAfter(f1, 20*time.Millisecond, "a", nil)
+	completeAfter(f2, 10*time.Millisecond, "b", nil)
+
+	result, err := AllFutures(f1, f2).Await(context.Background())
+	if err != nil {
+		t.Fatalf("AllFutures() error = %v", err)
+	}
+	results := result.([]interface{})
+	if len(results) != 2 || results[0] != "a" || results[1] != "b" {
+		t.Errorf("AllFutures() = %v, want [a b] in argument order", results)
+	}
+}
+
+func TestAllFutures_FailFast(t *testing.T) {
+	f1, f2 := NewFuture(), NewFuture()
+	wantErr := errors.New("boom")
+	completeAfter(f1, 10*time.Millisecond, nil, wantErr)
+
+	// f2 never completes: the combined future must still fail
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := AllFutures(f1, f2).Await(ctx); !errors.Is(err, wantErr) {
+		t.Errorf("AllFutures() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestAnyFuture(t *testing.T) {
+	f1, f2 := NewFuture(), NewFuture()
+	completeAfter(f1, 10*time.Millisecond, nil, errors.New("f1 failed"))
+	completeAfter(f2, 20*time.Millisecond, "ok", nil)
+
+	result, err := AnyFuture(f1, f2).Await(context.Background())
+	if err != nil || result != "ok" {
+		t.Errorf("AnyFuture() = %v, %v; want ok, nil", result, err)
+	}
+
+	f3, f4 := NewFuture(), NewFuture()
+	err3 := errors.New("f3 failed")
+	f3.Fail(err3)
+	f4.Fail(errors.New("f4 failed"))
+	if _, err := AnyFuture(f3, f4).Await(context.Background()); !errors.Is(err, err3) {
+		t.Errorf("AnyFuture() error = %v, want joined errors including %v", err, err3)
+	}
+}
+
+func TestRaceFutures(t *testing.T) {
+	f1, f2 := NewFuture(), NewFuture()
+	wantErr := errors.New("fast failure")
+	completeAfter(f1, 50*time.Millisecond, "slow", nil)
+	completeAfter(f2, 10*time.Millisecond, nil, wantErr)
+
+	if _, err := RaceFutures(f1, f2).Await(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("RaceFutures() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFutureCombinators_Empty(t *testing.T) {
+	ctx := context.Background()
+	if result, err := AllFutures().Await(ctx); err != nil || len(result.([]interface{})) != 0 {
+		t.Errorf("AllFutures() = %v, %v; want empty, nil", result, err)
+	}
+	if _, err := AnyFuture().Await(ctx); err == nil {
+		t.Error("AnyFuture() with no futures should fail")
+	}
+	if _, err := RaceFutures().Await(ctx); err == nil {
+		t.Error("RaceFutures() with no futures should fail")
+	}
+}
@@ -0,0 +1,23 @@
+package fluxor
+
+import "github.com/fluxorio/fluxor/pkg/core"
+
+// Error is the framework's structured error (see core.FluxorError): Code, Message,
+// optional Cause and Details; errors.Is matches Errors by Code. Futures fail with
+// it too, e.g. &Error{Message: "type assertion failed"}.
+type Error = core.FluxorError
+
+// NewError creates an Error with code and message
+func NewError(code, message string) *Error {
+	return core.NewError(code, message)
+}
+
+// WrapError creates an Error with code and message caused by cause
+func WrapError(code, message string, cause error) *Error {
+	return core.WrapError(code, message, cause)
+}
+
+// AsError returns the *Error in err's chain, or wraps err as a core.ErrorCodeInternal Error
+func AsError(err error) *Error {
+	return core.AsError(err)
+}
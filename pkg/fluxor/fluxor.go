@@ -149,3 +149,7 @@ func (c *fluxorContextWrapper) Deploy(verticle core.Verticle) (string, error) {
 func (c *fluxorContextWrapper) Undeploy(deploymentID string) error {
 	return c.gocmd.UndeployVerticle(deploymentID)
 }
+
+func (c *fluxorContextWrapper) ExecuteBlocking(fn func() (interface{}, error)) core.Future {
+	return c.gocmd.ExecuteBlocking(fn)
+}
@@ -2,10 +2,7 @@ package fluxor
 
 import (
 	"context"
-	"os"
-	"os/signal"
 	"sync"
-	"syscall"
 
 	"github.com/fluxorio/fluxor/pkg/config"
 	"github.com/fluxorio/fluxor/pkg/core"
@@ -19,7 +16,8 @@ type MainVerticle struct {
 
 	gocmd core.GoCMD
 
-	cfg map[string]any
+	cfg        map[string]any
+	configPath string
 
 	mu            sync.Mutex
 	deploymentIDs []string
@@ -70,10 +68,11 @@ func NewMainVerticleWithOptions(configPath string, opts MainVerticleOptions) (*M
 	}
 
 	return &MainVerticle{
-		ctx:    rootCtx,
-		cancel: cancel,
-		gocmd:  vx,
-		cfg:    cfg,
+		ctx:        rootCtx,
+		cancel:     cancel,
+		gocmd:      vx,
+		cfg:        cfg,
+		configPath: configPath,
 	}, nil
 }
 
@@ -109,13 +108,21 @@ func (m *MainVerticle) DeployVerticle(v core.Verticle) (string, error) {
 }
 
 // Start blocks until SIGINT/SIGTERM then stops the app.
+// Use RunWithSignals(m, handler) to also react to SIGHUP.
 func (m *MainVerticle) Start() error {
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
-	defer signal.Stop(sig)
+	return RunWithSignals(m, nil)
+}
 
-	<-sig
-	return m.Stop()
+// ReloadConfig loads the config file again and returns it (implements ConfigReloader).
+// Already-deployed verticles and Config() keep the config they started with.
+func (m *MainVerticle) ReloadConfig() (map[string]any, error) {
+	cfg := make(map[string]any)
+	if m.configPath != "" {
+		if err := config.Load(m.configPath, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
 }
 
 // Stop gracefully shuts down: cancels root context and closes GoCMD (undeploys verticles).
@@ -46,6 +46,14 @@ type Future interface {
 	// Blocks until the future completes or context is cancelled
 	Await(ctx context.Context) (interface{}, error)
 
+	// AwaitWithTimeout waits up to d for the future to complete
+	// Returns context.DeadlineExceeded if it is still pending after d
+	AwaitWithTimeout(d time.Duration) (interface{}, error)
+
+	// WithContext fails the future with ctx.Err() if ctx is done before it completes
+	// Returns the same future so cancellation propagates to chained handlers
+	WithContext(ctx context.Context) Future
+
 	// Then chains a success handler (Node.js Promise style)
 	// Returns a new Future that completes with the result of the handler
 	Then(fn func(interface{}) (interface{}, error)) Future
@@ -72,18 +80,10 @@ type FutureResult struct {
 	Error error
 }
 
-// Error represents a reactive error
-type Error struct {
-	Message string
-}
-
-func (e *Error) Error() string {
-	return e.Message
-}
-
 // future implements Future
 type future struct {
 	resultChan      chan FutureResult
+	done            chan struct{} // Closed on completion
 	once            sync.Once
 	mu              sync.RWMutex
 	completed       bool
@@ -96,6 +96,7 @@ type future struct {
 func NewFuture() Future {
 	return &future{
 		resultChan:      make(chan FutureResult, 1),
+		done:            make(chan struct{}),
 		successHandlers: make([]func(interface{}), 0),
 		failureHandlers: make([]func(error), 0),
 	}
@@ -107,6 +108,7 @@ func (f *future) Complete(result interface{}) {
 		f.completed = true
 		f.result = FutureResult{Value: result}
 		f.mu.Unlock()
+		close(f.done)
 
 		select {
 		case f.resultChan <- f.result:
@@ -126,6 +128,7 @@ func (f *future) Fail(err error) {
 		f.completed = true
 		f.result = FutureResult{Error: err}
 		f.mu.Unlock()
+		close(f.done)
 
 		select {
 		case f.resultChan <- f.result:
@@ -199,8 +202,12 @@ func (f *future) Await(ctx context.Context) (interface{}, error) {
 	f.mu.RUnlock()
 
 	// Wait for completion or context cancellation
+	// Waits on done (not resultChan) so concurrent Awaits all observe the result
 	select {
-	case result := <-f.resultChan:
+	case <-f.done:
+		f.mu.RLock()
+		result := f.result
+		f.mu.RUnlock()
 		if result.Error != nil {
 			return nil, result.Error
 		}
@@ -210,6 +217,26 @@ func (f *future) Await(ctx context.Context) (interface{}, error) {
 	}
 }
 
+// AwaitWithTimeout waits up to d for the future to complete
+func (f *future) AwaitWithTimeout(d time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return f.Await(ctx)
+}
+
+// WithContext fails the future with ctx.Err() if ctx is done first
+// No-op once the future has completed
+func (f *future) WithContext(ctx context.Context) Future {
+	go func() {
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			f.Fail(ctx.Err())
+		}
+	}()
+	return f
+}
+
 // Then chains a success handler (Node.js Promise.then() style)
 // Returns a new Future that completes with the result of the handler
 func (f *future) Then(fn func(interface{}) (interface{}, error)) Future {
@@ -292,7 +319,7 @@ func (rv *ReactiveVerticle) ExecuteReactive(ctx context.Context, address string,
 	promise := NewPromise()
 
 	// Send request via event bus
-	msg, err := rv.gocmd.EventBus().Request(address, data, 5*time.Second)
+	msg, err := rv.gocmd.EventBus().Request(address, data, 0) // The bus's default request timeout
 	if err != nil {
 		promise.Fail(err)
 		return promise
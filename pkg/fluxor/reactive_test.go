@@ -0,0 +1,108 @@
+package fluxor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFuture_RegisterAfterCompletion(t *testing.T) {
+	f := NewFuture()
+	f.Complete("done")
+
+	// Handlers registered after completion fire synchronously
+	var got interface{}
+	f.OnSuccess(func(result interface{}) { got = result })
+	if got != "done" {
+		t.Errorf("OnSuccess() after completion got %v, want done", got)
+	}
+
+	failed := NewPromise()
+	failed.Fail(errors.New("boom"))
+	var gotErr error
+	failed.OnFailure(func(err error) { gotErr = err })
+	if gotErr == nil || gotErr.Error() != "boom" {
+		t.Errorf("OnFailure() after failure got %v, want boom", gotErr)
+	}
+}
+
+func TestFuture_AwaitWithTimeout(t *testing.T) {
+	f := NewFuture()
+
+	start := time.Now()
+	_, err := f.AwaitWithTimeout(20 * time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AwaitWithTimeout() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("AwaitWithTimeout() took %v", elapsed)
+	}
+
+	// Completing later still works
+	f.Complete(42)
+	result, err := f.AwaitWithTimeout(time.Second)
+	if err != nil || result != 42 {
+		t.Errorf("AwaitWithTimeout() = %v, %v; want 42, nil", result, err)
+	}
+}
+
+func TestFuture_DoubleCompleteIsNoOp(t *testing.T) {
+	f := NewFuture()
+	calls := 0
+	f.OnSuccess(func(interface{}) { calls++ })
+
+	f.Complete("first")
+	f.Complete("second")
+	f.Fail(errors.New("late failure"))
+
+	result, err := f.Await(context.Background())
+	if err != nil || result != "first" {
+		t.Errorf("Await() = %v, %v; want first, nil", result, err)
+	}
+	if calls != 1 {
+		t.Errorf("success handler called %d times, want 1", calls)
+	}
+}
+
+func TestFuture_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := NewFuture().WithContext(ctx)
+
+	chained := f.Then(func(v interface{}) (interface{}, error) { return v, nil })
+	cancel()
+
+	if _, err := chained.AwaitWithTimeout(time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("chained Await() error = %v, want context.Canceled", err)
+	}
+
+	// A completed future ignores later cancellation
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	done := NewFuture()
+	done.Complete("ok")
+	done.WithContext(ctx2)
+	cancel2()
+	time.Sleep(10 * time.Millisecond)
+	if result, err := done.Await(context.Background()); err != nil || result != "ok" {
+		t.Errorf("Await() = %v, %v; want ok, nil", result, err)
+	}
+}
+
+func TestFuture_ConcurrentAwait(t *testing.T) {
+	f := NewFuture()
+	results := make(chan interface{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			v, _ := f.AwaitWithTimeout(time.Second)
+			results <- v
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	f.Complete("shared")
+	for i := 0; i < 2; i++ {
+		if v := <-results; v != "shared" {
+			t.Errorf("Await() = %v, want shared", v)
+		}
+	}
+}
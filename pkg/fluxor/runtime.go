@@ -2,6 +2,7 @@ package fluxor
 
 import (
 	"context"
+	goruntime "runtime"
 	"sync"
 
 	"github.com/fluxorio/fluxor/pkg/core"
@@ -19,6 +20,12 @@ type Runtime interface {
 	// Execute executes a task/workflow
 	Execute(task Task) error
 
+	// ExecuteAll runs tasks concurrently and waits for all of them
+	ExecuteAll(tasks ...Task) error
+
+	// ExecuteGraph runs tasks concurrently in DependentTask order
+	ExecuteGraph(tasks ...Task) error
+
 	// Deploy deploys a verticle
 	Deploy(verticle core.Verticle) (string, error)
 
@@ -43,6 +50,23 @@ type runtime struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	stack  StackManager
+
+	// parallelism bounds concurrent tasks in ExecuteAll/ExecuteGraph
+	parallelism int
+}
+
+// RuntimeOption configures a runtime
+type RuntimeOption func(*runtime)
+
+// WithParallelism bounds how many tasks ExecuteAll/ExecuteGraph run at once
+// (default: runtime.NumCPU())
+func WithParallelism(n int) RuntimeOption {
+	if n <= 0 {
+		panic("parallelism must be positive")
+	}
+	return func(r *runtime) {
+		r.parallelism = n
+	}
 }
 
 // StackManager manages execution stacks (abstraction over gostacks)
@@ -61,17 +85,22 @@ type StackManager interface {
 }
 
 // NewRuntime creates a new runtime instance
-func NewRuntime(ctx context.Context) Runtime {
+func NewRuntime(ctx context.Context, opts ...RuntimeOption) Runtime {
 	ctx, cancel := context.WithCancel(ctx)
 	gocmd := core.NewGoCMD(ctx)
 
-	return &runtime{
-		gocmd:  gocmd,
-		tasks:  make([]Task, 0),
-		ctx:    ctx,
-		cancel: cancel,
-		stack:  newStackManager(),
+	r := &runtime{
+		gocmd:       gocmd,
+		tasks:       make([]Task, 0),
+		ctx:         ctx,
+		cancel:      cancel,
+		stack:       newStackManager(),
+		parallelism: goruntime.NumCPU(),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 func (r *runtime) Start(ctx context.Context) error {
@@ -0,0 +1,255 @@
+package fluxor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DependentTask is a Task that must run after the named tasks complete successfully
+type DependentTask interface {
+	Task
+
+	// DependsOn returns the names of tasks this task waits for
+	DependsOn() []string
+}
+
+// TaskError reports the failure (or skip) of a single task in ExecuteAll/ExecuteGraph
+type TaskError struct {
+	Task string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %s: %v", e.Task, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// ErrDependencyFailed is wrapped by the TaskError of tasks skipped because a dependency failed
+var ErrDependencyFailed = &Error{Message: "dependency failed"}
+
+// FuncTask is a task implemented as a function, with optional dependencies
+type FuncTask struct {
+	name      string
+	dependsOn []string
+	fn        func(ctx context.Context) error
+}
+
+// NewTask creates a function-based task that runs after dependsOn (used by ExecuteGraph)
+func NewTask(name string, fn func(ctx context.Context) error, dependsOn ...string) *FuncTask {
+	if fn == nil {
+		panic("task function cannot be nil")
+	}
+	return &FuncTask{
+		name:      name,
+		dependsOn: dependsOn,
+		fn:        fn,
+	}
+}
+
+func (t *FuncTask) Name() string {
+	return t.name
+}
+
+func (t *FuncTask) DependsOn() []string {
+	return t.dependsOn
+}
+
+func (t *FuncTask) Execute(ctx context.Context) error {
+	return t.fn(ctx)
+}
+
+// taskNode tracks a task's scheduling state
+type taskNode struct {
+	task       Task
+	remaining  int   // Dependencies not yet completed
+	dependents []int // Indices of tasks waiting on this one
+	skipped    bool
+}
+
+// taskResult is sent by a worker when a task finishes
+type taskResult struct {
+	index int
+	err   error
+}
+
+// ExecuteAll runs tasks concurrently (up to the runtime's parallelism) and waits
+// for all of them; dependencies are ignored
+// Returns nil, or every failure joined as *TaskError values
+func (r *runtime) ExecuteAll(tasks ...Task) error {
+	nodes := make([]*taskNode, len(tasks))
+	for i, task := range tasks {
+		if task == nil {
+			return &Error{Message: fmt.Sprintf("task %d cannot be nil", i)}
+		}
+		nodes[i] = &taskNode{task: task}
+	}
+	return r.runNodes(nodes)
+}
+
+// ExecuteGraph runs tasks respecting DependentTask ordering: independent tasks run
+// concurrently up to the runtime's parallelism, and a failed task skips all tasks
+// that (transitively) depend on it
+// Fail-fast: duplicate names, unknown dependencies and cycles are rejected before anything runs
+func (r *runtime) ExecuteGraph(tasks ...Task) error {
+	nodes := make([]*taskNode, len(tasks))
+	byName := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		if task == nil {
+			return &Error{Message: fmt.Sprintf("task %d cannot be nil", i)}
+		}
+		if _, exists := byName[task.Name()]; exists {
+			return &Error{Message: fmt.Sprintf("duplicate task name: %s", task.Name())}
+		}
+		byName[task.Name()] = i
+		nodes[i] = &taskNode{task: task}
+	}
+
+	for i, node := range nodes {
+		dt, ok := node.task.(DependentTask)
+		if !ok {
+			continue
+		}
+		for _, dep := range dt.DependsOn() {
+			j, ok := byName[dep]
+			if !ok {
+				return &Error{Message: fmt.Sprintf("task %s depends on unknown task %s", node.task.Name(), dep)}
+			}
+			if j == i {
+				return &Error{Message: fmt.Sprintf("task %s depends on itself", dep)}
+			}
+			node.remaining++
+			nodes[j].dependents = append(nodes[j].dependents, i)
+		}
+	}
+
+	if err := checkAcyclic(nodes); err != nil {
+		return err
+	}
+	return r.runNodes(nodes)
+}
+
+// checkAcyclic verifies the dependency graph has no cycles (Kahn's algorithm)
+func checkAcyclic(nodes []*taskNode) error {
+	remaining := make([]int, len(nodes))
+	queue := make([]int, 0, len(nodes))
+	for i, node := range nodes {
+		remaining[i] = node.remaining
+		if remaining[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	visited := 0
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, d := range nodes[i].dependents {
+			remaining[d]--
+			if remaining[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	if visited != len(nodes) {
+		for i := range nodes {
+			if remaining[i] > 0 {
+				return &Error{Message: fmt.Sprintf("dependency cycle detected involving task %s", nodes[i].task.Name())}
+			}
+		}
+	}
+	return nil
+}
+
+// runNodes schedules ready nodes onto at most r.parallelism goroutines
+func (r *runtime) runNodes(nodes []*taskNode) error {
+	ctx := r.ctx
+	results := make(chan taskResult, len(nodes))
+	ready := make([]int, 0, len(nodes))
+	for i, node := range nodes {
+		if node.remaining == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	var errs []error
+	done, running := 0, 0
+
+	// fail records err for node i and skips everything downstream of it
+	fail := func(i int, err error) {
+		errs = append(errs, &TaskError{Task: nodes[i].task.Name(), Err: err})
+		done++
+		queue := append([]int(nil), nodes[i].dependents...)
+		for len(queue) > 0 {
+			d := queue[0]
+			queue = queue[1:]
+			if nodes[d].skipped {
+				continue
+			}
+			nodes[d].skipped = true
+			errs = append(errs, &TaskError{
+				Task: nodes[d].task.Name(),
+				Err:  fmt.Errorf("%w: %s", ErrDependencyFailed, nodes[i].task.Name()),
+			})
+			done++
+			queue = append(queue, nodes[d].dependents...)
+		}
+	}
+
+	for done < len(nodes) {
+		for len(ready) > 0 && running < r.parallelism {
+			i := ready[0]
+			ready = ready[1:]
+			if nodes[i].skipped {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				fail(i, err)
+				continue
+			}
+
+			running++
+			go func(i int) {
+				results <- taskResult{index: i, err: runTask(ctx, nodes[i].task)}
+			}(i)
+		}
+
+		if running == 0 {
+			// Nothing in flight and nothing ready: every remaining node was skipped
+			break
+		}
+
+		res := <-results
+		running--
+		if res.err != nil {
+			fail(res.index, res.err)
+			continue
+		}
+
+		done++
+		for _, d := range nodes[res.index].dependents {
+			nodes[d].remaining--
+			if nodes[d].remaining == 0 && !nodes[d].skipped {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// runTask executes a task, converting a panic into an error
+func runTask(ctx context.Context, task Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return task.Execute(ctx)
+}
@@ -0,0 +1,151 @@
+package fluxor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRuntime_ExecuteAll_Parallelism(t *testing.T) {
+	rt := NewRuntime(context.Background(), WithParallelism(2))
+	defer rt.Stop()
+
+	var current, peak int32
+	task := func(ctx context.Context) error {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		return nil
+	}
+
+	tasks := make([]Task, 6)
+	for i := range tasks {
+		tasks[i] = NewTask("t", task)
+	}
+	if err := rt.ExecuteAll(tasks...); err != nil {
+		t.Fatalf("ExecuteAll() error = %v", err)
+	}
+	if peak != 2 {
+		t.Errorf("peak concurrency = %d, want 2", peak)
+	}
+}
+
+func TestRuntime_ExecuteAll_JoinsErrors(t *testing.T) {
+	rt := NewRuntime(context.Background())
+	defer rt.Stop()
+
+	errA := errors.New("a failed")
+	err := rt.ExecuteAll(
+		NewTask("a", func(ctx context.Context) error { return errA }),
+		NewTask("b", func(ctx context.Context) error { return nil }),
+		NewTask("c", func(ctx context.Context) error { panic("boom") }),
+	)
+	if !errors.Is(err, errA) {
+		t.Errorf("ExecuteAll() error = %v, want to wrap %v", err, errA)
+	}
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Errorf("ExecuteAll() error should contain *TaskError")
+	}
+}
+
+func TestRuntime_ExecuteGraph_Ordering(t *testing.T) {
+	rt := NewRuntime(context.Background(), WithParallelism(4))
+	defer rt.Stop()
+
+	var mu sync.Mutex
+	finished := make(map[string]bool)
+	step := func(name string, deps ...string) Task {
+		return NewTask(name, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, d := range deps {
+				if !finished[d] {
+					t.Errorf("%s ran before dependency %s", name, d)
+				}
+			}
+			finished[name] = true
+			return nil
+		}, deps...)
+	}
+
+	err := rt.ExecuteGraph(
+		step("deploy", "build", "test"),
+		step("test", "build"),
+		step("build", "fetch"),
+		step("fetch"),
+		step("lint", "fetch"),
+	)
+	if err != nil {
+		t.Fatalf("ExecuteGraph() error = %v", err)
+	}
+	if len(finished) != 5 {
+		t.Errorf("finished %d tasks, want 5", len(finished))
+	}
+}
+
+func TestRuntime_ExecuteGraph_FailureSkipsDependents(t *testing.T) {
+	rt := NewRuntime(context.Background())
+	defer rt.Stop()
+
+	var ran sync.Map
+	mark := func(name string, err error, deps ...string) Task {
+		return NewTask(name, func(ctx context.Context) error {
+			ran.Store(name, true)
+			return err
+		}, deps...)
+	}
+
+	err := rt.ExecuteGraph(
+		mark("a", errors.New("a failed")),
+		mark("b", nil, "a"),
+		mark("c", nil, "b"),
+		mark("d", nil),
+	)
+	if err == nil {
+		t.Fatal("ExecuteGraph() should fail")
+	}
+	if !errors.Is(err, ErrDependencyFailed) {
+		t.Errorf("ExecuteGraph() error = %v, want ErrDependencyFailed for dependents", err)
+	}
+	for _, name := range []string{"b", "c"} {
+		if _, ok := ran.Load(name); ok {
+			t.Errorf("task %s should have been skipped", name)
+		}
+	}
+	if _, ok := ran.Load("d"); !ok {
+		t.Error("independent task d should still run")
+	}
+}
+
+func TestRuntime_ExecuteGraph_Validation(t *testing.T) {
+	rt := NewRuntime(context.Background())
+	defer rt.Stop()
+
+	noop := func(ctx context.Context) error { return nil }
+	tests := []struct {
+		name  string
+		tasks []Task
+	}{
+		{"duplicate", []Task{NewTask("a", noop), NewTask("a", noop)}},
+		{"unknown dependency", []Task{NewTask("a", noop, "missing")}},
+		{"cycle", []Task{NewTask("a", noop, "b"), NewTask("b", noop, "a")}},
+		{"self dependency", []Task{NewTask("a", noop, "a")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rt.ExecuteGraph(tt.tasks...); err == nil {
+				t.Error("ExecuteGraph() should fail")
+			}
+		})
+	}
+}
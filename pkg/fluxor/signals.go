@@ -0,0 +1,76 @@
+package fluxor
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// Stopper is an application RunWithSignals can shut down (MainVerticle, fx.Fluxor, Runtime, ...).
+type Stopper interface {
+	Stop() error
+}
+
+// StopFunc adapts a plain function to Stopper.
+type StopFunc func() error
+
+// Stop implements Stopper.
+func (f StopFunc) Stop() error { return f() }
+
+// ConfigReloader is implemented by apps that can load their config again (e.g. MainVerticle).
+type ConfigReloader interface {
+	ReloadConfig() (map[string]any, error)
+}
+
+// ReloadHandler is called on SIGHUP. cfg is freshly loaded when the app implements
+// ConfigReloader, nil otherwise (the handler loads its own config).
+type ReloadHandler func(cfg map[string]any) error
+
+// RunWithSignals blocks until SIGINT/SIGTERM, then stops app and returns its error.
+// If onReload is non-nil, SIGHUP calls it instead of terminating the process;
+// a failed reload is logged and the app keeps running on its current config.
+func RunWithSignals(app Stopper, onReload ReloadHandler) error {
+	if app == nil {
+		return &core.EventBusError{Code: "INVALID_INPUT", Message: "app cannot be nil"}
+	}
+
+	signals := []os.Signal{os.Interrupt, syscall.SIGTERM}
+	if onReload != nil {
+		signals = append(signals, syscall.SIGHUP)
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, signals...)
+	defer signal.Stop(sig)
+
+	return runWithSignals(app, onReload, sig, core.NewDefaultLogger())
+}
+
+func runWithSignals(app Stopper, onReload ReloadHandler, sig <-chan os.Signal, logger core.Logger) error {
+	for s := range sig {
+		if s == syscall.SIGHUP && onReload != nil {
+			if err := reload(app, onReload); err != nil {
+				logger.Error(fmt.Sprintf("config reload failed: %v", err))
+			} else {
+				logger.Info("config reloaded")
+			}
+			continue
+		}
+		logger.Info(fmt.Sprintf("received %v, shutting down", s))
+		return app.Stop()
+	}
+	return app.Stop()
+}
+
+func reload(app Stopper, onReload ReloadHandler) error {
+	var cfg map[string]any
+	if r, ok := app.(ConfigReloader); ok {
+		var err error
+		if cfg, err = r.ReloadConfig(); err != nil {
+			return err
+		}
+	}
+	return onReload(cfg)
+}
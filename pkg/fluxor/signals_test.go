@@ -0,0 +1,90 @@
+package fluxor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestRunWithSignals_ReloadThenStop(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(cfgPath, []byte(`{"level":"info"}`), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	app, err := NewMainVerticle(cfgPath)
+	if err != nil {
+		t.Fatalf("NewMainVerticle: %v", err)
+	}
+
+	// Config changes on disk before SIGHUP
+	if err := os.WriteFile(cfgPath, []byte(`{"level":"debug"}`), 0600); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+
+	var reloaded []map[string]any
+	sig := make(chan os.Signal, 2)
+	sig <- syscall.SIGHUP
+	sig <- syscall.SIGTERM
+	err = runWithSignals(app, func(cfg map[string]any) error {
+		reloaded = append(reloaded, cfg)
+		return nil
+	}, sig, core.NewDefaultLogger())
+	if err != nil {
+		t.Fatalf("runWithSignals: %v", err)
+	}
+
+	if len(reloaded) != 1 || reloaded[0]["level"] != "debug" {
+		t.Fatalf("expected one reload with level=debug, got %v", reloaded)
+	}
+	if app.Config()["level"] != "info" {
+		t.Fatalf("expected running config to be unchanged, got %v", app.Config())
+	}
+	if app.GoCMD().Context().Err() == nil {
+		t.Fatalf("expected app to be stopped")
+	}
+}
+
+func TestRunWithSignals_FailedReloadKeepsRunning(t *testing.T) {
+	stops := 0
+	app := StopFunc(func() error { stops++; return nil })
+
+	sig := make(chan os.Signal, 3)
+	sig <- syscall.SIGHUP
+	sig <- syscall.SIGHUP
+	sig <- os.Interrupt
+	calls := 0
+	err := runWithSignals(app, func(cfg map[string]any) error {
+		calls++
+		if cfg != nil {
+			t.Errorf("expected nil cfg for app without ConfigReloader, got %v", cfg)
+		}
+		return errors.New("bad config")
+	}, sig, core.NewDefaultLogger())
+	if err != nil {
+		t.Fatalf("runWithSignals: %v", err)
+	}
+	if calls != 2 || stops != 1 {
+		t.Fatalf("expected 2 reloads and 1 stop, got %d and %d", calls, stops)
+	}
+}
+
+func TestRunWithSignals_ReturnsStopError(t *testing.T) {
+	stopErr := errors.New("stop failed")
+	sig := make(chan os.Signal, 1)
+	sig <- syscall.SIGTERM
+	err := runWithSignals(StopFunc(func() error { return stopErr }), nil, sig, core.NewDefaultLogger())
+	if !errors.Is(err, stopErr) {
+		t.Fatalf("expected stop error, got %v", err)
+	}
+}
+
+func TestRunWithSignals_FailFast_NilApp(t *testing.T) {
+	if err := RunWithSignals(nil, nil); err == nil {
+		t.Fatalf("expected error for nil app")
+	}
+}
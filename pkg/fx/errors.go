@@ -0,0 +1,80 @@
+package fx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ComponentError reports which provider or invoker failed during Start
+type ComponentError struct {
+	Kind  string // "provider" or "invoker"
+	Index int    // Registration order (0-based)
+	Name  string // Function name, or value type for value providers
+	Err   error
+}
+
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("%s #%d (%s) failed: %v", e.Kind, e.Index, e.Name, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// StartupError aggregates every failure collected under ContinueOnError
+type StartupError struct {
+	Errors []error
+}
+
+func (e *StartupError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "fx startup failed with %d error(s):", len(e.Errors))
+	for _, err := range e.Errors {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the collected errors (supports errors.Is / errors.As)
+func (e *StartupError) Unwrap() []error {
+	return e.Errors
+}
+
+// ContinueOnError makes Start run every provider and invoker even after a
+// failure, returning all failures as a *StartupError
+func ContinueOnError() Option {
+	return func(fx *Fluxor) error {
+		fx.continueOnError = true
+		return nil
+	}
+}
+
+// componentName describes a provider or invoker for error messages
+func componentName(component interface{}) string {
+	switch c := component.(type) {
+	case *FuncProvider:
+		return funcName(c.fn)
+	case *FuncInvoker:
+		return funcName(c.fn)
+	case *ValueProvider:
+		return fmt.Sprintf("value %T", c.value)
+	default:
+		return fmt.Sprintf("%T", component)
+	}
+}
+
+// funcName returns the fully qualified name of fn, or its type if it isn't a function
+func funcName(fn interface{}) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", fn)
+	}
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		return f.Name()
+	}
+	return v.Type().String()
+}
@@ -2,7 +2,6 @@ package fx
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 	"sync"
 
@@ -29,6 +28,9 @@ type Fluxor struct {
 	invokers  []Invoker
 	lifecycle *lifecycle
 	mu        sync.RWMutex
+
+	// continueOnError collects every provider/invoker failure instead of stopping at the first
+	continueOnError bool
 }
 
 // Provider provides a value to the dependency injection container
@@ -87,18 +89,26 @@ func Invoke(invoker Invoker) Option {
 }
 
 // Start starts the Fluxor application
+// Provider and invoker failures are wrapped in *ComponentError naming the function;
+// with ContinueOnError all failures are returned together as *StartupError
 func (fx *Fluxor) Start() error {
 	fx.mu.Lock()
 	defer fx.mu.Unlock()
 
 	// Build dependency map
 	deps := make(map[reflect.Type]interface{})
+	var errs []error
 
 	// Provide all dependencies
-	for _, provider := range fx.providers {
+	for i, provider := range fx.providers {
 		value, err := provider.Provide()
 		if err != nil {
-			return fmt.Errorf("provider error: %w", err)
+			cerr := &ComponentError{Kind: "provider", Index: i, Name: componentName(provider), Err: err}
+			if !fx.continueOnError {
+				return cerr
+			}
+			errs = append(errs, cerr)
+			continue
 		}
 
 		valueType := reflect.TypeOf(value)
@@ -112,12 +122,20 @@ func (fx *Fluxor) Start() error {
 	deps[reflect.TypeOf((*core.EventBus)(nil)).Elem()] = fx.gocmd.EventBus()
 
 	// Invoke all invokers
-	for _, invoker := range fx.invokers {
+	for i, invoker := range fx.invokers {
 		if err := invoker.Invoke(deps); err != nil {
-			return fmt.Errorf("invoker error: %w", err)
+			cerr := &ComponentError{Kind: "invoker", Index: i, Name: componentName(invoker), Err: err}
+			if !fx.continueOnError {
+				return cerr
+			}
+			errs = append(errs, cerr)
 		}
 	}
 
+	if len(errs) > 0 {
+		return &StartupError{Errors: errs}
+	}
+
 	fx.lifecycle.start()
 	return nil
 }
@@ -0,0 +1,146 @@
+package fx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// depsMapType is the map[reflect.Type]interface{} parameter that receives all dependencies
+var depsMapType = reflect.TypeOf(map[reflect.Type]interface{}{})
+
+// DependencyGraph returns the container wiring in Graphviz DOT format
+// Nodes are provided types (with their provider), built-ins and invokers;
+// edges show which type resolves each invoker parameter. Parameters that
+// cannot be resolved point at a red "missing" node. Constructors that take
+// arguments, which Start rejects, are red and marked "(takes arguments)";
+// constructors returning an interface are "(unknown type)", as Start keys
+// them by the type of the value they return.
+// Providers are not called, so the graph is available before Start.
+func (fx *Fluxor) DependencyGraph() string {
+	fx.mu.RLock()
+	defer fx.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("digraph fx {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	// Provided types, keyed by type so parameters can be matched
+	provided := make(map[reflect.Type]string)
+	var nodes []string // Provided node IDs in listing order
+	addType := func(t reflect.Type, label string, attrs string) {
+		id := fmt.Sprintf("type_%d", len(nodes))
+		provided[t] = id
+		nodes = append(nodes, id)
+		fmt.Fprintf(&b, "  %s [label=%q%s];\n", id, label, attrs)
+	}
+	resolve := func(param reflect.Type) (string, bool) {
+		if id, ok := provided[param]; ok {
+			return id, true
+		}
+		id, ok := provided[reflect.PointerTo(param)]
+		return id, ok
+	}
+	missing := make(map[reflect.Type]string)
+	edge := func(param reflect.Type, to string) {
+		if from, ok := resolve(param); ok {
+			fmt.Fprintf(&b, "  %s -> %s;\n", from, to)
+			return
+		}
+		from, ok := missing[param]
+		if !ok {
+			from = fmt.Sprintf("missing_%d", len(missing))
+			missing[param] = from
+			fmt.Fprintf(&b, "  %s [label=%q, color=red, fontcolor=red];\n", from, param.String()+"\n(missing)")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [color=red];\n", from, to)
+	}
+
+	addType(reflect.TypeOf((*core.GoCMD)(nil)).Elem(), "core.GoCMD\n(built-in)", ", style=dashed")
+	addType(reflect.TypeOf((*core.EventBus)(nil)).Elem(), "core.EventBus\n(built-in)", ", style=dashed")
+
+	for i, provider := range fx.providers {
+		name := "provider #" + fmt.Sprint(i) + "\n" + componentName(provider)
+		if takesArguments(provider) {
+			fmt.Fprintf(&b, "  provider_%d [label=%q, color=red, fontcolor=red];\n", i, name+"\n(takes arguments)")
+			continue
+		}
+		t := providedType(provider)
+		if t == nil {
+			fmt.Fprintf(&b, "  provider_%d [label=%q, color=gray];\n", i, name+"\n(unknown type)")
+			continue
+		}
+		label := t.String()
+		if _, ok := provider.(*ValueProvider); !ok {
+			label += "\n" + componentName(provider)
+		}
+		addType(t, label, "")
+	}
+
+	for i, invoker := range fx.invokers {
+		id := fmt.Sprintf("invoker_%d", i)
+		fmt.Fprintf(&b, "  %s [label=%q, shape=ellipse];\n", id, "invoke #"+fmt.Sprint(i)+"\n"+componentName(invoker))
+
+		for _, param := range invokerParams(invoker) {
+			if param == depsMapType {
+				for _, from := range nodes {
+					fmt.Fprintf(&b, "  %s -> %s [style=dotted];\n", from, id)
+				}
+				continue
+			}
+			edge(param, id)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// providedType returns the type Start registers a provider's value under, without
+// calling it, or nil if that is only known from the value (interface results)
+func providedType(provider Provider) reflect.Type {
+	switch p := provider.(type) {
+	case *ValueProvider:
+		return reflect.TypeOf(p.value)
+	case *FuncProvider:
+		t := reflect.TypeOf(p.fn)
+		if t == nil || t.Kind() != reflect.Func || t.NumOut() == 0 || t.Out(0).Kind() == reflect.Interface {
+			return nil
+		}
+		return t.Out(0)
+	default:
+		return nil
+	}
+}
+
+// takesArguments reports whether provider is a constructor with parameters,
+// which FuncProvider.Provide rejects
+func takesArguments(provider Provider) bool {
+	fp, ok := provider.(*FuncProvider)
+	return ok && len(funcParams(fp.fn)) > 0
+}
+
+// invokerParams returns the parameter types an invoker resolves from the container
+func invokerParams(invoker Invoker) []reflect.Type {
+	fi, ok := invoker.(*FuncInvoker)
+	if !ok {
+		return nil
+	}
+	return funcParams(fi.fn)
+}
+
+// funcParams returns the parameter types of fn, or nil if it isn't a function
+func funcParams(fn interface{}) []reflect.Type {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil
+	}
+	params := make([]reflect.Type, t.NumIn())
+	for i := range params {
+		params[i] = t.In(i)
+	}
+	return params
+}
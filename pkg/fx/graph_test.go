@@ -0,0 +1,137 @@
+package fx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+type graphDB struct{}
+type graphRepo struct{}
+type graphCache struct{}
+
+// graphNamed is a provided interface; Start keys it by the returned value's type
+type graphNamed interface{ Name() string }
+
+func (*graphRepo) Name() string { return "repo" }
+
+func newGraphRepo() *graphRepo                { return &graphRepo{} }
+func newGraphRepoFrom(db *graphDB) *graphRepo { return &graphRepo{} }
+func newGraphNamed() graphNamed               { return &graphRepo{} }
+
+// dependencyGraph builds an app from options and returns its DOT graph
+func dependencyGraph(t *testing.T, options ...Option) string {
+	t.Helper()
+	app, err := New(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = app.GoCMD().Close() })
+	return app.DependencyGraph()
+}
+
+// nodeLine returns the line declaring the node whose label starts with label
+func nodeLine(t *testing.T, graph, label string) string {
+	t.Helper()
+	for _, line := range strings.Split(graph, "\n") {
+		if strings.Contains(line, `[label="`+label) {
+			return line
+		}
+	}
+	t.Fatalf("no node labelled %q in\n%s", label, graph)
+	return ""
+}
+
+// nodeID returns the ID of the node whose label starts with label
+func nodeID(t *testing.T, graph, label string) string {
+	t.Helper()
+	return strings.Fields(nodeLine(t, graph, label))[0]
+}
+
+func TestDependencyGraph_Wiring(t *testing.T) {
+	graph := dependencyGraph(t,
+		Provide(NewValueProvider(&graphDB{})),
+		Provide(NewProvider(newGraphRepo)),
+		Invoke(NewInvoker(func(repo *graphRepo, db *graphDB, bus core.EventBus) error { return nil })),
+	)
+
+	if line := nodeLine(t, graph, "*fx.graphRepo"); !strings.Contains(line, "newGraphRepo") {
+		t.Errorf("constructor node = %q, want it to name the constructor", line)
+	}
+	for _, edge := range []string{
+		nodeID(t, graph, "*fx.graphRepo") + " -> invoker_0",
+		nodeID(t, graph, "*fx.graphDB") + " -> invoker_0",
+		nodeID(t, graph, "core.EventBus") + " -> invoker_0",
+	} {
+		if !strings.Contains(graph, "  "+edge+";\n") {
+			t.Errorf("graph has no edge %q:\n%s", edge, graph)
+		}
+	}
+	if strings.Contains(graph, "missing") || strings.Contains(graph, "color=red") {
+		t.Errorf("fully wired graph reports problems:\n%s", graph)
+	}
+}
+
+func TestDependencyGraph_MatchesStart(t *testing.T) {
+	options := []Option{
+		Provide(NewValueProvider(&graphDB{})),
+		Provide(NewProvider(newGraphRepoFrom)),
+		Provide(NewProvider(newGraphNamed)),
+		Invoke(NewInvoker(func(repo *graphRepo) error { return nil })),
+	}
+	graph := dependencyGraph(t, options...)
+
+	// Start rejects constructors with arguments, so their type isn't provided
+	if line := nodeLine(t, graph, "provider #1"); !strings.Contains(line, `(takes arguments)"`) || !strings.Contains(line, "color=red") {
+		t.Errorf("constructor with arguments = %q, want it marked as rejected", line)
+	}
+	if !strings.Contains(graph, "  "+nodeID(t, graph, "*fx.graphRepo")+" -> invoker_0 [color=red];\n") {
+		t.Errorf("*graphRepo should be missing for the invoker:\n%s", graph)
+	}
+	// An interface result is registered under its dynamic type, unknown before Start
+	if line := nodeLine(t, graph, "provider #2"); !strings.Contains(line, `(unknown type)"`) {
+		t.Errorf("interface constructor = %q, want an unknown type", line)
+	}
+	if strings.Contains(graph, "fx.graphNamed") {
+		t.Errorf("graph lists the static interface type:\n%s", graph)
+	}
+
+	app, err := New(context.Background(), options...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer app.GoCMD().Close()
+	if err := app.Start(); err == nil {
+		t.Error("Start() should fail like the graph shows")
+	}
+}
+
+func TestDependencyGraph_MissingDependency(t *testing.T) {
+	graph := dependencyGraph(t,
+		Provide(NewProvider(newGraphRepo)),
+		Invoke(NewInvoker(func(repo *graphRepo, cache *graphCache) error { return nil })),
+		Invoke(NewInvoker(func(cache *graphCache) error { return nil })),
+	)
+
+	missing := nodeLine(t, graph, "*fx.graphCache")
+	if !strings.Contains(missing, `(missing)"`) || !strings.Contains(missing, "color=red") {
+		t.Errorf("graphCache node = %q, want a red missing node", missing)
+	}
+	cache := nodeID(t, graph, "*fx.graphCache")
+	if strings.Count(graph, "[label=\"*fx.graphCache") != 1 {
+		t.Errorf("a type missing for two invokers should have one node:\n%s", graph)
+	}
+	for _, edge := range []string{
+		cache + " -> invoker_0",
+		cache + " -> invoker_1",
+	} {
+		if !strings.Contains(graph, "  "+edge+" [color=red];\n") {
+			t.Errorf("graph has no red edge %q:\n%s", edge, graph)
+		}
+	}
+	if !strings.Contains(graph, "  "+nodeID(t, graph, "*fx.graphRepo")+" -> invoker_0;\n") {
+		t.Errorf("graph has no edge from *graphRepo:\n%s", graph)
+	}
+}
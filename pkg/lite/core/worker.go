@@ -1,34 +1,163 @@
 package core
 
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// RejectionPolicy decides what Submit does when the queue is full.
+type RejectionPolicy int
+
+const (
+	// PolicyBlock waits for queue space (default).
+	PolicyBlock RejectionPolicy = iota
+	// PolicyDrop rejects the task and returns ErrPoolFull.
+	PolicyDrop
+	// PolicyCallerRuns runs the task on the submitting goroutine.
+	PolicyCallerRuns
+)
+
+var (
+	ErrPoolFull   = errors.New("worker pool full")
+	ErrPoolClosed = errors.New("worker pool closed")
+)
+
+// WorkerPoolConfig configures a bounded WorkerPool.
+type WorkerPoolConfig struct {
+	Workers   int             // Goroutines running tasks (default: 1)
+	QueueSize int             // Tasks waiting for a worker (default: 1024)
+	Policy    RejectionPolicy // What to do when the queue is full (default: PolicyBlock)
+}
+
+// WorkerPoolStats is a snapshot of pool activity.
+type WorkerPoolStats struct {
+	Workers    int
+	QueueSize  int
+	Queued     int    // Tasks currently waiting
+	Submitted  uint64 // Tasks accepted into the queue
+	Completed  uint64 // Tasks finished by workers
+	Rejected   uint64 // Tasks dropped by PolicyDrop
+	CallerRuns uint64 // Tasks run on the caller by PolicyCallerRuns
+}
+
+// WorkerPool runs tasks on a fixed number of goroutines with a bounded queue.
 type WorkerPool struct {
-	tasks chan func()
+	tasks   chan func()
+	quit    chan struct{}
+	workers int
+	policy  RejectionPolicy
+	closed  atomic.Bool
+
+	submitted  atomic.Uint64
+	completed  atomic.Uint64
+	rejected   atomic.Uint64
+	callerRuns atomic.Uint64
 }
 
+// NewWorkerPool creates a pool that blocks Submit when the queue is full.
 func NewWorkerPool(workerCount int, queueSize int) *WorkerPool {
-	if workerCount <= 0 {
-		workerCount = 1
+	return NewWorkerPoolWithConfig(WorkerPoolConfig{Workers: workerCount, QueueSize: queueSize})
+}
+
+// NewWorkerPoolWithConfig creates a pool with the given size, queue and rejection policy.
+func NewWorkerPoolWithConfig(cfg WorkerPoolConfig) *WorkerPool {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
 	}
-	if queueSize <= 0 {
-		queueSize = 1024
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1024
 	}
 
-	wp := &WorkerPool{tasks: make(chan func(), queueSize)}
-	for i := 0; i < workerCount; i++ {
-		go func() {
-			for task := range wp.tasks {
-				task()
-			}
-		}()
+	wp := &WorkerPool{
+		tasks:   make(chan func(), cfg.QueueSize),
+		quit:    make(chan struct{}),
+		workers: cfg.Workers,
+		policy:  cfg.Policy,
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		go wp.work()
 	}
 	return wp
 }
 
+func (wp *WorkerPool) work() {
+	for {
+		select {
+		case task := <-wp.tasks:
+			wp.run(task)
+		case <-wp.quit:
+			// Drain what was queued before Shutdown
+			for {
+				select {
+				case task := <-wp.tasks:
+					wp.run(task)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (wp *WorkerPool) run(task func()) {
+	defer wp.completed.Add(1)
+	task()
+}
+
 // Submit enqueues a unit of work. Keep tasks short; if you need cancellation,
 // close over a context in your task.
-func (wp *WorkerPool) Submit(task func()) {
-	wp.tasks <- task
+//
+// When the queue is full the pool's policy applies: PolicyBlock waits,
+// PolicyDrop returns ErrPoolFull, PolicyCallerRuns runs task before returning.
+// Returns ErrPoolClosed after Shutdown.
+func (wp *WorkerPool) Submit(task func()) error {
+	if wp.closed.Load() {
+		return ErrPoolClosed
+	}
+
+	select {
+	case wp.tasks <- task:
+		wp.submitted.Add(1)
+		return nil
+	default:
+	}
+
+	switch wp.policy {
+	case PolicyDrop:
+		wp.rejected.Add(1)
+		return ErrPoolFull
+	case PolicyCallerRuns:
+		wp.callerRuns.Add(1)
+		task()
+		return nil
+	default:
+		select {
+		case wp.tasks <- task:
+			wp.submitted.Add(1)
+			return nil
+		case <-wp.quit:
+			return ErrPoolClosed
+		}
+	}
 }
 
+// Stats returns a snapshot of pool activity.
+func (wp *WorkerPool) Stats() WorkerPoolStats {
+	return WorkerPoolStats{
+		Workers:    wp.workers,
+		QueueSize:  cap(wp.tasks),
+		Queued:     len(wp.tasks),
+		Submitted:  wp.submitted.Load(),
+		Completed:  wp.completed.Load(),
+		Rejected:   wp.rejected.Load(),
+		CallerRuns: wp.callerRuns.Load(),
+	}
+}
+
+// Shutdown stops accepting tasks; workers finish what is already queued.
+// Tasks submitted concurrently with Shutdown may not run.
 func (wp *WorkerPool) Shutdown() {
-	close(wp.tasks)
+	if wp.closed.CompareAndSwap(false, true) {
+		close(wp.quit)
+	}
 }
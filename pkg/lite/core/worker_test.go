@@ -22,3 +22,59 @@ func TestWorkerPool_Submit(t *testing.T) {
 		t.Fatalf("timed out waiting for task")
 	}
 }
+
+func TestWorkerPool_PolicyDrop(t *testing.T) {
+	wp := core.NewWorkerPoolWithConfig(core.WorkerPoolConfig{Workers: 1, QueueSize: 1, Policy: core.PolicyDrop})
+	defer wp.Shutdown()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := wp.Submit(func() { close(started); <-release }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	if err := wp.Submit(func() {}); err != nil {
+		t.Fatalf("Submit into free queue slot: %v", err)
+	}
+	if err := wp.Submit(func() {}); err != core.ErrPoolFull {
+		t.Fatalf("Submit on full queue = %v, want ErrPoolFull", err)
+	}
+	close(release)
+
+	if stats := wp.Stats(); stats.Rejected != 1 || stats.Submitted != 2 {
+		t.Fatalf("stats = %+v, want 1 rejected and 2 submitted", stats)
+	}
+}
+
+func TestWorkerPool_PolicyCallerRuns(t *testing.T) {
+	wp := core.NewWorkerPoolWithConfig(core.WorkerPoolConfig{Workers: 1, QueueSize: 1, Policy: core.PolicyCallerRuns})
+	defer wp.Shutdown()
+
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	_ = wp.Submit(func() { close(started); <-release })
+	<-started
+	_ = wp.Submit(func() {})
+
+	ran := false
+	if err := wp.Submit(func() { ran = true }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if !ran {
+		t.Fatal("task should run on the caller when the queue is full")
+	}
+	if stats := wp.Stats(); stats.CallerRuns != 1 {
+		t.Fatalf("CallerRuns = %d, want 1", stats.CallerRuns)
+	}
+}
+
+func TestWorkerPool_SubmitAfterShutdown(t *testing.T) {
+	wp := core.NewWorkerPool(1, 1)
+	wp.Shutdown()
+	wp.Shutdown() // idempotent
+
+	if err := wp.Submit(func() {}); err != core.ErrPoolClosed {
+		t.Fatalf("Submit after Shutdown = %v, want ErrPoolClosed", err)
+	}
+}
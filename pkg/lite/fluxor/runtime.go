@@ -3,11 +3,9 @@ package fluxor
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"sync"
-	"syscall"
 
+	fluxorapp "github.com/fluxorio/fluxor/pkg/fluxor"
 	"github.com/fluxorio/fluxor/pkg/lite/core"
 	"github.com/google/uuid"
 )
@@ -23,15 +21,31 @@ type App struct {
 	deployments []core.Component
 }
 
-func New() *App {
+// Option configures an App.
+type Option func(*App)
+
+// WithWorkerPool replaces the default worker pool (10 workers, 1024 queue, blocking).
+func WithWorkerPool(cfg core.WorkerPoolConfig) Option {
+	return func(a *App) {
+		a.worker = core.NewWorkerPoolWithConfig(cfg)
+	}
+}
+
+func New(opts ...Option) *App {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &App{
+	a := &App{
 		bus:         core.NewBus(),
-		worker:      core.NewWorkerPool(10, 1024),
 		ctx:         ctx,
 		cancel:      cancel,
 		deployments: make([]core.Component, 0),
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.worker == nil {
+		a.worker = core.NewWorkerPool(10, 1024)
+	}
+	return a
 }
 
 func (a *App) Bus() *core.Bus {
@@ -59,10 +73,12 @@ func (a *App) Deploy(c core.Component) {
 func (a *App) Run() {
 	fmt.Println("🚀 Fluxor (lite) running... (Ctrl+C to stop)")
 
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-	<-sig
+	_ = fluxorapp.RunWithSignals(a, nil)
+}
 
+// Stop cancels the app's context, stops deployed components and shuts down the
+// worker pool (implements fluxor.Stopper).
+func (a *App) Stop() error {
 	fmt.Println("\n🛑 Fluxor shutdown")
 	a.cancel()
 
@@ -75,4 +91,5 @@ func (a *App) Run() {
 	}
 
 	a.worker.Shutdown()
+	return nil
 }
@@ -13,11 +13,23 @@ import (
 // JSON is a convenience alias for JSON objects.
 type JSON map[string]any
 
+// ErrorBody is the standard error envelope: {"error":{"code":"...","message":"..."}}.
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an error in an ErrorBody.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 // Context is a thin wrapper around http request/response plus the core context.
 type Context struct {
 	W       http.ResponseWriter
 	R       *http.Request
 	Params  map[string]string
+	Route   string // Matched route pattern ("/users/:id"), set by the router
 	coreCtx *core.FluxorContext
 }
 
@@ -33,6 +45,23 @@ func (c *Context) Error(code int, msg string) error {
 	return c.JSON(code, JSON{"error": msg})
 }
 
+func (c *Context) Created(data any) error {
+	return c.JSON(http.StatusCreated, data)
+}
+
+func (c *Context) NoContent() error {
+	c.W.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (c *Context) BadRequest(msg string) error {
+	return c.Fail(http.StatusBadRequest, "bad_request", msg)
+}
+
+func (c *Context) Fail(status int, code, msg string) error {
+	return c.JSON(status, ErrorBody{Error: ErrorDetail{Code: code, Message: msg}})
+}
+
 func (c *Context) JSON(code int, data any) error {
 	c.W.Header().Set("Content-Type", "application/json")
 	c.W.WriteHeader(code)
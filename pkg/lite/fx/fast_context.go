@@ -23,6 +23,7 @@ type Param struct {
 type FastContext struct {
 	RC      *fasthttp.RequestCtx
 	Params  []Param
+	Route   string // Matched route pattern ("/users/:id"), set by the router
 	coreCtx *core.FluxorContext
 }
 
@@ -57,6 +58,23 @@ func (c *FastContext) Error(code int, msg string) error {
 	return c.JSON(code, JSON{"error": msg})
 }
 
+func (c *FastContext) Created(data any) error {
+	return c.JSON(fasthttp.StatusCreated, data)
+}
+
+func (c *FastContext) NoContent() error {
+	c.RC.SetStatusCode(fasthttp.StatusNoContent)
+	return nil
+}
+
+func (c *FastContext) BadRequest(msg string) error {
+	return c.Fail(fasthttp.StatusBadRequest, "bad_request", msg)
+}
+
+func (c *FastContext) Fail(status int, code, msg string) error {
+	return c.JSON(status, ErrorBody{Error: ErrorDetail{Code: code, Message: msg}})
+}
+
 func (c *FastContext) Text(code int, text string) error {
 	c.RC.SetStatusCode(code)
 	c.RC.SetContentType("text/plain; charset=utf-8")
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/lite/fx"
+	"github.com/fluxorio/fluxor/pkg/lite/web"
+	"github.com/fluxorio/fluxor/pkg/lite/webfast"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Mount records every request on router and serves the metrics at GET /metrics.
+func (r *Registry) Mount(router *web.Router) {
+	router.Use(r.Middleware())
+	router.GET(Path, r.Handler())
+}
+
+// MountFast is Mount for the fasthttp router.
+func (r *Registry) MountFast(router *webfast.Router) {
+	router.Use(r.FastMiddleware())
+	router.GET(Path, r.FastHandler())
+}
+
+// Middleware records requests handled by a web.Router.
+func (r *Registry) Middleware() web.Middleware {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *fx.Context) error {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: c.W, status: http.StatusOK}
+			c.W = rec
+			err := next(c)
+			c.W = rec.ResponseWriter
+
+			status := rec.status
+			if err != nil && !rec.wrote {
+				status = http.StatusInternalServerError // written by the router's error handler
+			}
+			r.Observe(c.R.Method, c.Route, status, err != nil, time.Since(start))
+			return err
+		}
+	}
+}
+
+// FastMiddleware records requests handled by a webfast.Router.
+func (r *Registry) FastMiddleware() webfast.Middleware {
+	return func(next webfast.HandlerFunc) webfast.HandlerFunc {
+		return func(c *fx.FastContext) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.RC.Response.StatusCode()
+			if err != nil {
+				status = http.StatusInternalServerError // written by the router's error handler
+			}
+			r.Observe(string(c.RC.Method()), c.Route, status, err != nil, time.Since(start))
+			return err
+		}
+	}
+}
+
+// Handler serves the metrics on a web.Router.
+func (r *Registry) Handler() web.HandlerFunc {
+	return func(c *fx.Context) error {
+		c.W.Header().Set("Content-Type", contentType)
+		c.W.WriteHeader(http.StatusOK)
+		_, err := r.WriteTo(c.W)
+		return err
+	}
+}
+
+// FastHandler serves the metrics on a webfast.Router.
+func (r *Registry) FastHandler() webfast.HandlerFunc {
+	return func(c *fx.FastContext) error {
+		var buf bytes.Buffer
+		if _, err := r.WriteTo(&buf); err != nil {
+			return err
+		}
+		c.RC.SetStatusCode(http.StatusOK)
+		c.RC.SetContentType(contentType)
+		c.RC.SetBody(buf.Bytes())
+		return nil
+	}
+}
+
+// statusRecorder captures the status code written by the handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	wrote  bool
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if !w.wrote {
+		w.status = code
+		w.wrote = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
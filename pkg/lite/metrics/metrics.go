@@ -0,0 +1,210 @@
+// Package metrics exposes request and worker pool metrics for the lite runtime
+// in the Prometheus text format, without depending on the Prometheus client.
+//
+//	m := metrics.New()
+//	m.WatchPool("default", pool)
+//	m.Mount(router) // records every route and serves GET /metrics
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/lite/core"
+)
+
+// Path is where Mount and MountFast serve the metrics.
+const Path = "/metrics"
+
+// DefaultBuckets are the request duration histogram buckets, in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry collects request metrics per method and route pattern.
+type Registry struct {
+	buckets []float64
+
+	mu     sync.RWMutex
+	routes map[routeKey]*routeStats
+	pools  map[string]*core.WorkerPool
+}
+
+type routeKey struct {
+	method, route string
+}
+
+type routeStats struct {
+	mu       sync.Mutex
+	statuses map[int]uint64
+	errors   uint64
+	buckets  []uint64 // per-bucket (non-cumulative) counts
+	sum      float64
+	count    uint64
+}
+
+// New creates a Registry using DefaultBuckets.
+func New() *Registry {
+	return NewWithBuckets(DefaultBuckets)
+}
+
+// NewWithBuckets creates a Registry with custom duration buckets (seconds, ascending).
+func NewWithBuckets(buckets []float64) *Registry {
+	return &Registry{
+		buckets: slices.Sorted(slices.Values(buckets)),
+		routes:  make(map[routeKey]*routeStats),
+		pools:   make(map[string]*core.WorkerPool),
+	}
+}
+
+// WatchPool reports the pool's depth and counters under the given name.
+func (r *Registry) WatchPool(name string, pool *core.WorkerPool) {
+	r.mu.Lock()
+	r.pools[name] = pool
+	r.mu.Unlock()
+}
+
+// Observe records one request. failed marks requests whose handler returned
+// an error; responses with a 5xx status count as errors too.
+func (r *Registry) Observe(method, route string, status int, failed bool, d time.Duration) {
+	key := routeKey{method: method, route: route}
+	r.mu.RLock()
+	stats := r.routes[key]
+	r.mu.RUnlock()
+	if stats == nil {
+		r.mu.Lock()
+		if stats = r.routes[key]; stats == nil {
+			stats = &routeStats{statuses: make(map[int]uint64), buckets: make([]uint64, len(r.buckets))}
+			r.routes[key] = stats
+		}
+		r.mu.Unlock()
+	}
+
+	seconds := d.Seconds()
+	stats.mu.Lock()
+	stats.statuses[status]++
+	if failed || status >= 500 {
+		stats.errors++
+	}
+	if i, _ := slices.BinarySearch(r.buckets, seconds); i < len(stats.buckets) {
+		stats.buckets[i]++
+	}
+	stats.sum += seconds
+	stats.count++
+	stats.mu.Unlock()
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	r.mu.RLock()
+	keys := make([]routeKey, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	routes := make([]*routeStats, 0, len(keys))
+	slices.SortFunc(keys, func(a, b routeKey) int {
+		if c := strings.Compare(a.route, b.route); c != 0 {
+			return c
+		}
+		return strings.Compare(a.method, b.method)
+	})
+	for _, key := range keys {
+		routes = append(routes, r.routes[key])
+	}
+	names := make([]string, 0, len(r.pools))
+	for name := range r.pools {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	pools := make([]*core.WorkerPool, 0, len(names))
+	for _, name := range names {
+		pools = append(pools, r.pools[name])
+	}
+	r.mu.RUnlock()
+
+	buf.WriteString("# HELP fluxor_lite_http_requests_total Total HTTP requests by route and status.\n")
+	buf.WriteString("# TYPE fluxor_lite_http_requests_total counter\n")
+	for i, stats := range routes {
+		labels := routeLabels(keys[i])
+		stats.mu.Lock()
+		statuses := make([]int, 0, len(stats.statuses))
+		for status := range stats.statuses {
+			statuses = append(statuses, status)
+		}
+		slices.Sort(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&buf, "fluxor_lite_http_requests_total{%s,status=\"%d\"} %d\n", labels, status, stats.statuses[status])
+		}
+		stats.mu.Unlock()
+	}
+
+	buf.WriteString("# HELP fluxor_lite_http_request_errors_total HTTP requests that failed (handler error or 5xx).\n")
+	buf.WriteString("# TYPE fluxor_lite_http_request_errors_total counter\n")
+	for i, stats := range routes {
+		stats.mu.Lock()
+		fmt.Fprintf(&buf, "fluxor_lite_http_request_errors_total{%s} %d\n", routeLabels(keys[i]), stats.errors)
+		stats.mu.Unlock()
+	}
+
+	buf.WriteString("# HELP fluxor_lite_http_request_duration_seconds HTTP request latency.\n")
+	buf.WriteString("# TYPE fluxor_lite_http_request_duration_seconds histogram\n")
+	for i, stats := range routes {
+		labels := routeLabels(keys[i])
+		stats.mu.Lock()
+		var cumulative uint64
+		for j, le := range r.buckets {
+			cumulative += stats.buckets[j]
+			fmt.Fprintf(&buf, "fluxor_lite_http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(&buf, "fluxor_lite_http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, stats.count)
+		fmt.Fprintf(&buf, "fluxor_lite_http_request_duration_seconds_sum{%s} %s\n", labels, formatFloat(stats.sum))
+		fmt.Fprintf(&buf, "fluxor_lite_http_request_duration_seconds_count{%s} %d\n", labels, stats.count)
+		stats.mu.Unlock()
+	}
+
+	if len(pools) > 0 {
+		stats := make([]core.WorkerPoolStats, len(pools))
+		for i, pool := range pools {
+			stats[i] = pool.Stats()
+		}
+		writePoolGauge(&buf, "fluxor_lite_worker_pool_queued", "gauge", "Tasks waiting for a worker.", names, stats,
+			func(s core.WorkerPoolStats) uint64 { return uint64(s.Queued) })
+		writePoolGauge(&buf, "fluxor_lite_worker_pool_queue_size", "gauge", "Worker pool queue capacity.", names, stats,
+			func(s core.WorkerPoolStats) uint64 { return uint64(s.QueueSize) })
+		writePoolGauge(&buf, "fluxor_lite_worker_pool_workers", "gauge", "Worker goroutines.", names, stats,
+			func(s core.WorkerPoolStats) uint64 { return uint64(s.Workers) })
+		writePoolGauge(&buf, "fluxor_lite_worker_pool_completed_total", "counter", "Tasks finished by workers.", names, stats,
+			func(s core.WorkerPoolStats) uint64 { return s.Completed })
+		writePoolGauge(&buf, "fluxor_lite_worker_pool_rejected_total", "counter", "Tasks rejected because the queue was full.", names, stats,
+			func(s core.WorkerPoolStats) uint64 { return s.Rejected })
+	}
+
+	return buf.WriteTo(w)
+}
+
+func writePoolGauge(buf *bytes.Buffer, name, kind, help string, names []string, stats []core.WorkerPoolStats, value func(core.WorkerPoolStats) uint64) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	for i, s := range stats {
+		fmt.Fprintf(buf, "%s{pool=\"%s\"} %d\n", name, escapeLabel(names[i]), value(s))
+	}
+}
+
+func routeLabels(key routeKey) string {
+	return "method=\"" + escapeLabel(key.method) + "\",route=\"" + escapeLabel(key.route) + "\""
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
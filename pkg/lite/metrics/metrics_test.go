@@ -0,0 +1,110 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/lite/core"
+	"github.com/fluxorio/fluxor/pkg/lite/fx"
+	"github.com/fluxorio/fluxor/pkg/lite/metrics"
+	"github.com/fluxorio/fluxor/pkg/lite/web"
+	"github.com/fluxorio/fluxor/pkg/lite/webfast"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestMount_Web(t *testing.T) {
+	pool := core.NewWorkerPool(2, 16)
+	defer pool.Shutdown()
+	coreCtx := core.NewFluxorContext(context.Background(), core.NewBus(), pool, "test")
+
+	m := metrics.New()
+	m.WatchPool("default", pool)
+	r := web.NewRouter()
+	m.Mount(r)
+	r.GET("/users/:id", func(c *fx.Context) error { return c.Text(200, c.Param("id")) })
+	r.GET("/boom", func(c *fx.Context) error { return errors.New("boom") })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		rec := httptest.NewRecorder()
+		if err := r.Handle(fx.NewContext(rec, req, coreCtx)); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+		return rec
+	}
+	serve("/users/1")
+	serve("/users/2")
+	serve("/boom")
+
+	rec := serve("/metrics")
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`fluxor_lite_http_requests_total{method="GET",route="/users/:id",status="200"} 2`,
+		`fluxor_lite_http_requests_total{method="GET",route="/boom",status="500"} 1`,
+		`fluxor_lite_http_request_errors_total{method="GET",route="/boom"} 1`,
+		`fluxor_lite_http_request_errors_total{method="GET",route="/users/:id"} 0`,
+		`fluxor_lite_http_request_duration_seconds_bucket{method="GET",route="/users/:id",le="+Inf"} 2`,
+		`fluxor_lite_http_request_duration_seconds_count{method="GET",route="/users/:id"} 2`,
+		`fluxor_lite_worker_pool_queue_size{pool="default"} 16`,
+		`fluxor_lite_worker_pool_workers{pool="default"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+}
+
+func TestMountFast(t *testing.T) {
+	coreCtx := core.NewFluxorContext(context.Background(), core.NewBus(), core.NewWorkerPool(1, 1024), "test")
+
+	m := metrics.New()
+	r := webfast.NewRouter()
+	r.Bind(coreCtx)
+	m.MountFast(r)
+	r.GET("/items/:id", func(c *fx.FastContext) error { return c.Text(200, c.Param("id")) })
+	r.POST("/fail", func(c *fx.FastContext) error { return c.Error(503, "unavailable") })
+
+	ln := fasthttputil.NewInmemoryListener()
+	srv := &fasthttp.Server{Handler: r.Handler()}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() {
+		_ = ln.Close()
+		_ = srv.Shutdown()
+	}()
+	client := &fasthttp.Client{Dial: func(addr string) (net.Conn, error) { return ln.Dial() }}
+
+	do := func(method, path string) string {
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseRequest(req)
+		defer fasthttp.ReleaseResponse(resp)
+		req.Header.SetMethod(method)
+		req.SetRequestURI("http://test" + path)
+		if err := client.Do(req, resp); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return string(resp.Body())
+	}
+	do("GET", "/items/7")
+	do("POST", "/fail")
+
+	body := do("GET", "/metrics")
+	for _, want := range []string{
+		`fluxor_lite_http_requests_total{method="GET",route="/items/:id",status="200"} 1`,
+		`fluxor_lite_http_requests_total{method="POST",route="/fail",status="503"} 1`,
+		`fluxor_lite_http_request_errors_total{method="POST",route="/fail"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+}
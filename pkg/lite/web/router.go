@@ -111,6 +111,7 @@ func (r *Router) Handle(c *fx.Context) error {
 		for k, v := range params {
 			c.Params[k] = v
 		}
+		c.Route = rt.pattern
 
 		// Build handler: route middleware then global middleware (global outermost).
 		h := rt.handler
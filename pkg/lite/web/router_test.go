@@ -89,3 +89,37 @@ func TestRouter_MiddlewareOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestContext_ResponseHelpers(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func(c *fx.Context) error
+		status int
+		body   string
+	}{
+		{"created", func(c *fx.Context) error { return c.Created(fx.JSON{"id": 1}) }, 201, `{"id":1}` + "\n"},
+		{"no content", func(c *fx.Context) error { return c.NoContent() }, 204, ""},
+		{"bad request", func(c *fx.Context) error { return c.BadRequest("name is required") }, 400,
+			`{"error":{"code":"bad_request","message":"name is required"}}` + "\n"},
+		{"fail", func(c *fx.Context) error { return c.Fail(409, "conflict", "already exists") }, 409,
+			`{"error":{"code":"conflict","message":"already exists"}}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://example.com/items", nil)
+			rec := httptest.NewRecorder()
+			ctx := fx.NewContext(rec, req, core.NewFluxorContext(req.Context(), core.NewBus(), core.NewWorkerPool(1, 10), "test"))
+
+			if err := tt.handle(ctx); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.status {
+				t.Fatalf("status=%d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Body.String(); got != tt.body {
+				t.Fatalf("body=%q, want %q", got, tt.body)
+			}
+		})
+	}
+}
@@ -121,6 +121,7 @@ func (r *Router) Handler() fasthttp.RequestHandler {
 			if ok := matchAndFill(rt, path, c, &r.paramPool); !ok {
 				continue
 			}
+			c.Route = rt.pattern
 
 			h := rt.handler
 			for i := len(rt.middleware) - 1; i >= 0; i-- {
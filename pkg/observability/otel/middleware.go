@@ -30,13 +30,22 @@ func HTTPMiddleware() web.FastMiddleware {
 			carrier := newHeaderCarrier(&ctx.RequestCtx.Request.Header)
 			parentCtx := propagator.Extract(ctx.Context(), carrier)
 
+			// Use the matched route template for the span name and http.route
+			// to keep cardinality low; the raw path is kept as http.target
+			rawPath := string(ctx.Path())
+			route := ctx.RoutePattern()
+			if route == "" {
+				route = web.UnmatchedRoute
+			}
+
 			// Start span
-			spanCtx, span := StartSpan(parentCtx, "http.request",
+			spanCtx, span := StartSpan(parentCtx, route,
 				trace.WithSpanKind(trace.SpanKindServer),
 				trace.WithAttributes(
 					semconv.HTTPMethodKey.String(string(ctx.Method())),
-					semconv.HTTPURLKey.String(string(ctx.Path())),
-					semconv.HTTPRouteKey.String(string(ctx.Path())),
+					semconv.HTTPURLKey.String(rawPath),
+					semconv.HTTPTargetKey.String(rawPath),
+					semconv.HTTPRouteKey.String(route),
 					attribute.String("http.request_id", ctx.RequestID()),
 				),
 			)
@@ -0,0 +1,95 @@
+package prometheus
+
+import (
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	eventBusHandlerErrorsDesc = prometheus.NewDesc(
+		"fluxor_eventbus_handler_errors_total",
+		"Total number of EventBus handler calls that returned an error",
+		[]string{"address"}, nil,
+	)
+	eventBusHandlerPanicsDesc = prometheus.NewDesc(
+		"fluxor_eventbus_handler_panics_total",
+		"Total number of EventBus handler calls that panicked",
+		[]string{"address"}, nil,
+	)
+	eventBusFilteredDesc = prometheus.NewDesc(
+		"fluxor_eventbus_filtered_total",
+		"Total number of EventBus messages dropped by consumer filters",
+		[]string{"address"}, nil,
+	)
+	eventBusRejectedDesc = prometheus.NewDesc(
+		"fluxor_eventbus_rejected_total",
+		"Total number of EventBus messages refused by a saturated cluster consumer executor",
+		[]string{"address"}, nil,
+	)
+	eventBusMailboxDepthDesc = prometheus.NewDesc(
+		"fluxor_eventbus_mailbox_depth",
+		"Messages queued across an address's consumer mailboxes (in-memory EventBus)",
+		[]string{"address"}, nil,
+	)
+	eventBusMailboxCapacityDesc = prometheus.NewDesc(
+		"fluxor_eventbus_mailbox_capacity",
+		"Total mailbox capacity of an address's consumers (in-memory EventBus)",
+		[]string{"address"}, nil,
+	)
+	eventBusOversizedDesc = prometheus.NewDesc(
+		"fluxor_eventbus_oversized_total",
+		"Total number of messages rejected for exceeding the EventBus message size limit",
+		nil, nil,
+	)
+)
+
+// EventBusCollector exports per-address handler failure, filter and rejection counters,
+// mailbox depth and capacity, and the oversized message count from EventBus.Stats
+// Values are read at scrape time, so handlers need no instrumentation
+type EventBusCollector struct {
+	eventBus core.EventBus
+}
+
+// NewEventBusCollector creates a collector for eventBus
+func NewEventBusCollector(eventBus core.EventBus) *EventBusCollector {
+	return &EventBusCollector{eventBus: eventBus}
+}
+
+// Describe implements prometheus.Collector
+func (c *EventBusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- eventBusHandlerErrorsDesc
+	ch <- eventBusHandlerPanicsDesc
+	ch <- eventBusFilteredDesc
+	ch <- eventBusRejectedDesc
+	ch <- eventBusMailboxDepthDesc
+	ch <- eventBusMailboxCapacityDesc
+	ch <- eventBusOversizedDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *EventBusCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.eventBus.Stats()
+	for address, f := range stats.HandlerFailures {
+		ch <- prometheus.MustNewConstMetric(eventBusHandlerErrorsDesc, prometheus.CounterValue, float64(f.Errors), address)
+		ch <- prometheus.MustNewConstMetric(eventBusHandlerPanicsDesc, prometheus.CounterValue, float64(f.Panics), address)
+	}
+	for address, n := range stats.Filtered {
+		ch <- prometheus.MustNewConstMetric(eventBusFilteredDesc, prometheus.CounterValue, float64(n), address)
+	}
+	for address, n := range stats.Rejected {
+		ch <- prometheus.MustNewConstMetric(eventBusRejectedDesc, prometheus.CounterValue, float64(n), address)
+	}
+	for address, as := range stats.Addresses {
+		if core.IsReplyAddress(address) {
+			continue // One per in-flight request; would explode the label set
+		}
+		ch <- prometheus.MustNewConstMetric(eventBusMailboxDepthDesc, prometheus.GaugeValue, float64(as.MailboxDepth), address)
+		ch <- prometheus.MustNewConstMetric(eventBusMailboxCapacityDesc, prometheus.GaugeValue, float64(as.MailboxCapacity), address)
+	}
+	ch <- prometheus.MustNewConstMetric(eventBusOversizedDesc, prometheus.CounterValue, float64(stats.Oversized))
+}
+
+// RegisterEventBus registers an EventBusCollector for eventBus on the default registry
+func RegisterEventBus(eventBus core.EventBus) error {
+	return DefaultRegisterer.Register(NewEventBusCollector(eventBus))
+}
@@ -8,12 +8,21 @@ import (
 
 // FastHTTPMetricsMiddleware creates middleware that records HTTP metrics
 func FastHTTPMetricsMiddleware() web.FastMiddleware {
-	metrics := GetMetrics()
+	return FastHTTPMetricsMiddlewareFor(GetMetrics())
+}
+
+// FastHTTPMetricsMiddlewareFor creates middleware that records HTTP metrics on
+// metrics, including the labels handlers set with web.AddMetricLabel
+func FastHTTPMetricsMiddlewareFor(metrics *Metrics) web.FastMiddleware {
 	return func(next web.FastRequestHandler) web.FastRequestHandler {
 		return func(ctx *web.FastRequestContext) error {
 			start := time.Now()
 			method := string(ctx.Method())
-			path := string(ctx.Path())
+			// Prefer the matched route template to keep the path label low-cardinality
+			path := ctx.RoutePattern()
+			if path == "" {
+				path = web.UnmatchedRoute
+			}
 
 			// Get request size
 			requestSize := int64(len(ctx.RequestCtx.PostBody()))
@@ -33,7 +42,7 @@ func FastHTTPMetricsMiddleware() web.FastMiddleware {
 			}
 
 			// Record metrics
-			metrics.RecordHTTPRequest(method, path, statusStr, duration, requestSize, responseSize)
+			metrics.RecordHTTPRequestWithLabels(method, path, statusStr, web.MetricLabels(ctx), duration, requestSize, responseSize)
 
 			return err
 		}
@@ -42,9 +51,19 @@ func FastHTTPMetricsMiddleware() web.FastMiddleware {
 
 // UpdateServerMetrics updates server metrics from FastHTTPServer
 func UpdateServerMetrics(server *web.FastHTTPServer) {
-	metrics := GetMetrics()
-	serverMetrics := server.Metrics()
+	recordServerMetrics(server, server.Metrics())
+}
+
+// ExportServerMetrics updates the server metrics every interval for as long as
+// the server runs (see FastHTTPServer.OnMetrics); call cancel to stop earlier
+func ExportServerMetrics(server *web.FastHTTPServer, interval time.Duration) (cancel func()) {
+	return server.OnMetrics(interval, func(m web.ServerMetrics) {
+		recordServerMetrics(server, m)
+	})
+}
 
+func recordServerMetrics(server *web.FastHTTPServer, serverMetrics web.ServerMetrics) {
+	metrics := GetMetrics()
 	metrics.UpdateServerMetrics(
 		serverMetrics.QueuedRequests,
 		0, // Rejected requests are already counted
@@ -53,6 +72,7 @@ func UpdateServerMetrics(server *web.FastHTTPServer) {
 		serverMetrics.CCUUtilization,
 		server.GoCMD().DeploymentCount(),
 	)
+	metrics.UpdateServerRejections(serverMetrics.RejectedLastInterval)
 }
 
 // statusCodeString converts status code to string
@@ -1,9 +1,11 @@
 package prometheus
 
 import (
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -42,6 +44,7 @@ type Metrics struct {
 	// Server metrics
 	ServerQueuedRequests          prometheus.Gauge
 	ServerRejectedRequests        prometheus.Counter
+	ServerRejectedLastInterval    prometheus.Gauge
 	ServerCurrentCCU              prometheus.Gauge
 	ServerNormalCCU               prometheus.Gauge
 	ServerCCUUtilization          prometheus.Gauge
@@ -56,6 +59,12 @@ type Metrics struct {
 	CustomGauges     map[string]*prometheus.GaugeVec
 	CustomHistograms map[string]*prometheus.HistogramVec
 	customMu         sync.RWMutex
+
+	// registerer receives custom metrics (same registry as the built-in ones)
+	registerer prometheus.Registerer
+
+	// Extra HTTP label keys (MetricsConfig.HTTPLabels)
+	httpLabels []string
 }
 
 // GetMetrics returns the global metrics instance
@@ -66,11 +75,56 @@ func GetMetrics() *Metrics {
 	return metrics
 }
 
+// MetricsConfig configures a metrics collection
+type MetricsConfig struct {
+	// Registerer receives the metrics (default: DefaultRegisterer)
+	Registerer prometheus.Registerer
+
+	// HTTPLabels are extra label keys on the HTTP request metrics, for business
+	// dimensions such as tenant or plan. Handlers set the values per request with
+	// web.AddMetricLabel; a value a handler doesn't set is recorded as "".
+	// Prometheus needs a fixed label set, so keys must be declared here; keep
+	// their values low-cardinality.
+	HTTPLabels []string
+}
+
 // NewMetrics creates a new metrics collection
 func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	return NewMetricsWithConfig(MetricsConfig{Registerer: registerer})
+}
+
+// InitMetrics creates the global metrics instance (see GetMetrics) from cfg.
+// Call it before anything uses GetMetrics; afterwards it returns an error.
+func InitMetrics(cfg MetricsConfig) (*Metrics, error) {
+	created := false
+	metricsOnce.Do(func() {
+		if cfg.Registerer == nil {
+			cfg.Registerer = DefaultRegisterer
+		}
+		metrics = NewMetricsWithConfig(cfg)
+		created = true
+	})
+	if !created {
+		return nil, errors.New("metrics already initialized")
+	}
+	return metrics, nil
+}
+
+// NewMetricsWithConfig creates a new metrics collection from cfg
+// Fail-fast: panics if an HTTP label repeats or clashes with a built-in label
+func NewMetricsWithConfig(cfg MetricsConfig) *Metrics {
+	registerer := cfg.Registerer
 	if registerer == nil {
 		registerer = DefaultRegisterer
 	}
+	seen := map[string]bool{"method": true, "path": true, "status": true, "service": true}
+	for _, key := range cfg.HTTPLabels {
+		failfast.If(!seen[key], "HTTP metric label "+key+" repeats or clashes with a built-in label")
+		seen[key] = true
+	}
+	httpLabels := func(base ...string) []string {
+		return append(base, cfg.HTTPLabels...)
+	}
 
 	m := &Metrics{
 		// HTTP request metrics
@@ -79,7 +133,7 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 				Name: "fluxor_http_requests_total",
 				Help: "Total number of HTTP requests",
 			},
-			[]string{"method", "path", "status"},
+			httpLabels("method", "path", "status"),
 		),
 		HTTPRequestDuration: promauto.With(registerer).NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -87,7 +141,7 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 				Help:    "HTTP request duration in seconds",
 				Buckets: prometheus.DefBuckets,
 			},
-			[]string{"method", "path", "status"},
+			httpLabels("method", "path", "status"),
 		),
 		HTTPRequestSize: promauto.With(registerer).NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -95,7 +149,7 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 				Help:    "HTTP request size in bytes",
 				Buckets: prometheus.ExponentialBuckets(100, 10, 7), // 100B to 100MB
 			},
-			[]string{"method", "path"},
+			httpLabels("method", "path"),
 		),
 		HTTPResponseSize: promauto.With(registerer).NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -103,7 +157,7 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 				Help:    "HTTP response size in bytes",
 				Buckets: prometheus.ExponentialBuckets(100, 10, 7), // 100B to 100MB
 			},
-			[]string{"method", "path", "status"},
+			httpLabels("method", "path", "status"),
 		),
 
 		// EventBus metrics
@@ -170,6 +224,12 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 				Help: "Total number of rejected HTTP requests (503)",
 			},
 		),
+		ServerRejectedLastInterval: promauto.With(registerer).NewGauge(
+			prometheus.GaugeOpts{
+				Name: "fluxor_server_rejected_requests_last_interval",
+				Help: "Requests rejected by backpressure in the last completed reset interval",
+			},
+		),
 		ServerCurrentCCU: promauto.With(registerer).NewGauge(
 			prometheus.GaugeOpts{
 				Name: "fluxor_server_current_ccu",
@@ -213,6 +273,8 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 		CustomCounters:   make(map[string]*prometheus.CounterVec),
 		CustomGauges:     make(map[string]*prometheus.GaugeVec),
 		CustomHistograms: make(map[string]*prometheus.HistogramVec),
+		registerer:       registerer,
+		httpLabels:       cfg.HTTPLabels,
 	}
 
 	return m
@@ -220,10 +282,21 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 
 // RecordHTTPRequest records an HTTP request metric
 func (m *Metrics) RecordHTTPRequest(method, path, status string, duration time.Duration, requestSize, responseSize int64) {
-	m.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
-	m.HTTPRequestDuration.WithLabelValues(method, path, status).Observe(duration.Seconds())
-	m.HTTPRequestSize.WithLabelValues(method, path).Observe(float64(requestSize))
-	m.HTTPResponseSize.WithLabelValues(method, path, status).Observe(float64(responseSize))
+	m.RecordHTTPRequestWithLabels(method, path, status, nil, duration, requestSize, responseSize)
+}
+
+// RecordHTTPRequestWithLabels records an HTTP request metric with values for
+// the configured HTTPLabels; missing ones are "" and undeclared keys are ignored
+func (m *Metrics) RecordHTTPRequestWithLabels(method, path, status string, labels map[string]string, duration time.Duration, requestSize, responseSize int64) {
+	extra := make([]string, len(m.httpLabels))
+	for i, key := range m.httpLabels {
+		extra[i] = labels[key]
+	}
+	withStatus := append([]string{method, path, status}, extra...)
+	m.HTTPRequestsTotal.WithLabelValues(withStatus...).Inc()
+	m.HTTPRequestDuration.WithLabelValues(withStatus...).Observe(duration.Seconds())
+	m.HTTPRequestSize.WithLabelValues(append([]string{method, path}, extra...)...).Observe(float64(requestSize))
+	m.HTTPResponseSize.WithLabelValues(withStatus...).Observe(float64(responseSize))
 }
 
 // RecordEventBusMessage records an EventBus message metric
@@ -261,12 +334,18 @@ func (m *Metrics) UpdateServerMetrics(queued int64, rejected int64, currentCCU i
 	m.VerticleCount.Set(float64(verticleCount))
 }
 
+// UpdateServerRejections updates the windowed rejection gauge
+func (m *Metrics) UpdateServerRejections(lastInterval int64) {
+	m.ServerRejectedLastInterval.Set(float64(lastInterval))
+}
+
 // UpdateVerticleCount updates the verticle count metric
 func (m *Metrics) UpdateVerticleCount(count int) {
 	m.VerticleCount.Set(float64(count))
 }
 
 // Counter creates or returns a custom counter metric
+// Idempotent and safe for concurrent use; registered on the registry passed to NewMetrics
 func (m *Metrics) Counter(name, help string, labels ...string) *prometheus.CounterVec {
 	m.customMu.RLock()
 	if counter, exists := m.CustomCounters[name]; exists {
@@ -283,18 +362,18 @@ func (m *Metrics) Counter(name, help string, labels ...string) *prometheus.Count
 		return counter
 	}
 
-	counter := promauto.With(DefaultRegisterer).NewCounterVec(
+	counter := registerOrExisting(m.registerer, prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: name,
 			Help: help,
 		},
 		labels,
-	)
+	))
 	m.CustomCounters[name] = counter
 	return counter
 }
 
-// Gauge creates or returns a custom gauge metric
+// Gauge creates or returns a custom gauge metric (see Counter)
 func (m *Metrics) Gauge(name, help string, labels ...string) *prometheus.GaugeVec {
 	m.customMu.RLock()
 	if gauge, exists := m.CustomGauges[name]; exists {
@@ -311,18 +390,18 @@ func (m *Metrics) Gauge(name, help string, labels ...string) *prometheus.GaugeVe
 		return gauge
 	}
 
-	gauge := promauto.With(DefaultRegisterer).NewGaugeVec(
+	gauge := registerOrExisting(m.registerer, prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: name,
 			Help: help,
 		},
 		labels,
-	)
+	))
 	m.CustomGauges[name] = gauge
 	return gauge
 }
 
-// Histogram creates or returns a custom histogram metric
+// Histogram creates or returns a custom histogram metric (see Counter); nil buckets use prometheus.DefBuckets
 func (m *Metrics) Histogram(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
 	m.customMu.RLock()
 	if histogram, exists := m.CustomHistograms[name]; exists {
@@ -348,11 +427,27 @@ func (m *Metrics) Histogram(name, help string, buckets []float64, labels ...stri
 		opts.Buckets = prometheus.DefBuckets
 	}
 
-	histogram := promauto.With(DefaultRegisterer).NewHistogramVec(opts, labels)
+	histogram := registerOrExisting(m.registerer, prometheus.NewHistogramVec(opts, labels))
 	m.CustomHistograms[name] = histogram
 	return histogram
 }
 
+// registerOrExisting registers c, or returns the collector already registered under
+// the same descriptor (e.g. by another Metrics sharing the registry)
+// Panics on conflicting registrations (same name, different type or labels), like promauto
+func registerOrExisting[T prometheus.Collector](registerer prometheus.Registerer, c T) T {
+	if err := registerer.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
+
 // Convenience functions for global metrics
 
 // Counter returns a custom counter metric (creates if doesn't exist)
@@ -1,10 +1,16 @@
 package prometheus_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/fluxorio/fluxor/pkg/core"
 	"github.com/fluxorio/fluxor/pkg/observability/prometheus"
+	"github.com/fluxorio/fluxor/pkg/web"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/valyala/fasthttp"
 )
 
 func TestPrometheusMetrics(t *testing.T) {
@@ -33,3 +39,261 @@ func TestPrometheusMetrics(t *testing.T) {
 
 	// If we get here without panic, metrics are working
 }
+
+func TestMetrics_CustomMetricsUseOwnRegistry(t *testing.T) {
+	registry := promclient.NewRegistry()
+	metrics := prometheus.NewMetrics(registry)
+
+	counter := metrics.Counter("orders_total", "Orders placed", "status")
+	if again := metrics.Counter("orders_total", "Orders placed", "status"); again != counter {
+		t.Error("Counter() should return the existing counter")
+	}
+	counter.WithLabelValues("paid").Inc()
+	metrics.Gauge("cart_items", "Items in carts").WithLabelValues().Set(3)
+	metrics.Histogram("checkout_seconds", "Checkout latency", nil).WithLabelValues().Observe(0.2)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	found := map[string]bool{}
+	for _, f := range families {
+		found[f.GetName()] = true
+	}
+	for _, name := range []string{"orders_total", "cart_items", "checkout_seconds"} {
+		if !found[name] {
+			t.Errorf("%s not registered on the Metrics registry", name)
+		}
+	}
+}
+
+func TestMetrics_CounterReusesRegisteredCollector(t *testing.T) {
+	registry := promclient.NewRegistry()
+	metrics := prometheus.NewMetrics(registry)
+
+	// Registered directly on the registry, outside Metrics
+	existing := promclient.NewCounterVec(promclient.CounterOpts{Name: "jobs_total", Help: "Jobs run"}, []string{"queue"})
+	registry.MustRegister(existing)
+
+	if counter := metrics.Counter("jobs_total", "Jobs run", "queue"); counter != existing {
+		t.Error("Counter() should return the collector already registered under the same name")
+	}
+}
+
+func TestEventBusCollector_HandlerFailures(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	handled := make(chan struct{}, 2)
+	c := eb.Consumer("orders.failing").Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		defer func() { handled <- struct{}{} }()
+		return errors.New("failed")
+	})
+	<-c.Ready()
+	for i := 0; i < 2; i++ {
+		if err := eb.Send("orders.failing", "x"); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		<-handled
+	}
+
+	registry := promclient.NewRegistry()
+	registry.MustRegister(prometheus.NewEventBusCollector(eb))
+
+	// Counters are updated after the handler returns
+	deadline := time.Now().Add(time.Second)
+	for {
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		var errorsTotal float64
+		for _, f := range families {
+			if f.GetName() == "fluxor_eventbus_handler_errors_total" {
+				errorsTotal = f.GetMetric()[0].GetCounter().GetValue()
+			}
+		}
+		if errorsTotal == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("fluxor_eventbus_handler_errors_total = %v, want 2", errorsTotal)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEventBusCollector_Oversized(t *testing.T) {
+	gocmd, err := core.NewGoCMDWithOptions(context.Background(), core.GoCMDOptions{MaxMessageSize: 16})
+	if err != nil {
+		t.Fatalf("NewGoCMDWithOptions() error = %v", err)
+	}
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	if err := eb.Publish("orders.created", "a body well over sixteen bytes"); err == nil {
+		t.Fatal("Publish() of an oversized body should fail")
+	}
+
+	registry := promclient.NewRegistry()
+	registry.MustRegister(prometheus.NewEventBusCollector(eb))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() == "fluxor_eventbus_oversized_total" {
+			if got := f.GetMetric()[0].GetCounter().GetValue(); got != 1 {
+				t.Errorf("fluxor_eventbus_oversized_total = %v, want 1", got)
+			}
+			return
+		}
+	}
+	t.Error("fluxor_eventbus_oversized_total not exported")
+}
+
+func TestEventBusCollector_Filtered(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+
+	handled := make(chan struct{}, 1)
+	c := core.FilterConsumer(eb.Consumer("orders.created"), func(msg core.Message) bool { return msg.Header("X-Large") == "true" }).
+		Handler(func(ctx core.FluxorContext, msg core.Message) error {
+			handled <- struct{}{}
+			return nil
+		})
+	<-c.Ready()
+	_ = eb.Publish("orders.created", "small")
+	_ = eb.PublishWithHeaders("orders.created", "large", map[string]string{"X-Large": "true"})
+	<-handled // Messages are handled in order, so the small one was filtered already
+
+	registry := promclient.NewRegistry()
+	registry.MustRegister(prometheus.NewEventBusCollector(eb))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() == "fluxor_eventbus_filtered_total" {
+			m := f.GetMetric()[0]
+			if got := m.GetCounter().GetValue(); got != 1 || m.GetLabel()[0].GetValue() != "orders.created" {
+				t.Errorf("fluxor_eventbus_filtered_total = %v %v, want 1 for orders.created", m.GetLabel(), got)
+			}
+			return
+		}
+	}
+	t.Error("fluxor_eventbus_filtered_total not exported")
+}
+
+func TestFastHTTPMetricsMiddleware_HTTPLabels(t *testing.T) {
+	registry := promclient.NewRegistry()
+	metrics := prometheus.NewMetricsWithConfig(prometheus.MetricsConfig{Registerer: registry, HTTPLabels: []string{"tenant", "plan"}})
+	handler := prometheus.FastHTTPMetricsMiddlewareFor(metrics)(func(ctx *web.FastRequestContext) error {
+		web.AddMetricLabel(ctx, "tenant", "acme")
+		web.AddMetricLabel(ctx, "region", "eu") // Not declared: ignored
+		return ctx.JSON(200, map[string]string{"status": "ok"})
+	})
+
+	rc := &fasthttp.RequestCtx{}
+	rc.Request.Header.SetMethod("GET")
+	rc.Request.SetRequestURI("/orders")
+	if err := handler(&web.FastRequestContext{RequestCtx: rc}); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != "fluxor_http_requests_total" {
+			continue
+		}
+		labels := map[string]string{}
+		for _, l := range f.GetMetric()[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["tenant"] != "acme" || labels["plan"] != "" || labels["path"] != web.UnmatchedRoute {
+			// No router matched the request, so the raw path isn't used as a label
+			t.Errorf("labels = %v, want tenant=acme, plan empty, path=%s", labels, web.UnmatchedRoute)
+		}
+		if _, ok := labels["region"]; ok {
+			t.Error("undeclared label region should not be recorded")
+		}
+		return
+	}
+	t.Fatal("fluxor_http_requests_total not recorded")
+}
+
+func TestNewMetricsWithConfig_RejectsBuiltInLabel(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewMetricsWithConfig() should panic on an HTTP label named status")
+		}
+	}()
+	prometheus.NewMetricsWithConfig(prometheus.MetricsConfig{Registerer: promclient.NewRegistry(), HTTPLabels: []string{"status"}})
+}
+
+// statsBus reports fixed Stats
+type statsBus struct {
+	core.EventBus
+	stats core.EventBusStats
+}
+
+func (b statsBus) Stats() core.EventBusStats { return b.stats }
+
+func TestEventBusCollector_Rejected(t *testing.T) {
+	registry := promclient.NewRegistry()
+	registry.MustRegister(prometheus.NewEventBusCollector(statsBus{stats: core.EventBusStats{
+		Rejected: map[string]int64{"orders.created": 3},
+	}}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() == "fluxor_eventbus_rejected_total" {
+			m := f.GetMetric()[0]
+			if got := m.GetCounter().GetValue(); got != 3 || m.GetLabel()[0].GetValue() != "orders.created" {
+				t.Errorf("fluxor_eventbus_rejected_total = %v %v, want 3 for orders.created", m.GetLabel(), got)
+			}
+			return
+		}
+	}
+	t.Error("fluxor_eventbus_rejected_total not exported")
+}
+
+func TestEventBusCollector_MailboxDepth(t *testing.T) {
+	registry := promclient.NewRegistry()
+	registry.MustRegister(prometheus.NewEventBusCollector(statsBus{stats: core.EventBusStats{
+		Addresses: map[string]core.AddressStats{
+			"orders.created": {Consumers: 2, MailboxDepth: 150, MailboxCapacity: 200},
+			"reply.1234":     {Consumers: 1, MailboxCapacity: 100},
+		},
+	}}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	want := map[string]float64{"fluxor_eventbus_mailbox_depth": 150, "fluxor_eventbus_mailbox_capacity": 200}
+	for _, f := range families {
+		value, ok := want[f.GetName()]
+		if !ok {
+			continue
+		}
+		delete(want, f.GetName())
+		if len(f.GetMetric()) != 1 {
+			t.Errorf("%s has %d series, want only orders.created (no reply addresses)", f.GetName(), len(f.GetMetric()))
+			continue
+		}
+		m := f.GetMetric()[0]
+		if got := m.GetGauge().GetValue(); got != value || m.GetLabel()[0].GetValue() != "orders.created" {
+			t.Errorf("%s = %v %v, want %v for orders.created", f.GetName(), m.GetLabel(), got, value)
+		}
+	}
+	for name := range want {
+		t.Errorf("%s not exported", name)
+	}
+}
@@ -0,0 +1,106 @@
+package web
+
+import (
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+// Adapters between net/http and the fast router, so existing http.Handler code
+// (handlers, middleware-wrapped handlers, promhttp, pprof) can run on FastHTTPServer
+// and fast handlers can be mounted on an http.ServeMux.
+//
+// Each call copies the request and response between the two representations
+// (headers, and the whole body, which is buffered rather than streamed), so an
+// adapted route is noticeably slower and allocates more than a native one. Use
+// them for migration and for endpoints off the hot path.
+
+// WrapHTTPHandler runs a net/http handler as a fast route:
+//
+//	router.GETFast("/metrics", web.WrapHTTPHandler(promhttp.Handler()))
+//	router.GETFast("/legacy/:page", web.WrapHTTPHandler(legacyMux))
+//
+// The handler sees the request with its original path; route params aren't set
+// as http.Request path values. The router has no catch-all wildcard, so a
+// legacy mux serving deeper paths needs a route per path depth.
+// Fail-fast: panics on a nil handler
+func WrapHTTPHandler(h http.Handler) FastRequestHandler {
+	failfast.NotNil(h, "handler")
+	adaptor := fasthttpadaptor.NewFastHTTPHandler(h)
+	return func(ctx *FastRequestContext) error {
+		adaptor(ctx.RequestCtx)
+		return nil
+	}
+}
+
+// ToHTTPHandler serves a fast handler from net/http. The context it gets has no
+// GoCMD or EventBus and no route params; its request ID comes from X-Request-ID
+// or is generated. Errors the handler returns are answered by DefaultErrorHandler.
+// Fail-fast: panics on a nil handler
+func ToHTTPHandler(handler FastRequestHandler) http.Handler {
+	failfast.NotNil(handler, "handler")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveAsFastHTTP(w, r, fasthttp.DefaultMaxRequestBodySize, func(reqCtx *fasthttp.RequestCtx) {
+			requestID := string(reqCtx.Request.Header.Peek("X-Request-ID"))
+			if requestID == "" {
+				requestID = core.GenerateRequestID()
+			}
+			reqCtx.Response.Header.Set("X-Request-ID", requestID)
+			ctx := &FastRequestContext{
+				BaseRequestContext: core.NewBaseRequestContext(),
+				RequestCtx:         reqCtx,
+				Params:             make(map[string]string),
+				requestID:          requestID,
+			}
+			if err := handler(ctx); err != nil {
+				DefaultErrorHandler(ctx, err)
+			}
+		})
+	})
+}
+
+// serveAsFastHTTP copies a net/http request into a fasthttp.RequestCtx, runs
+// handler on it and writes its response to w. Bodies over maxBody are answered
+// with 413 without calling handler.
+func serveAsFastHTTP(w http.ResponseWriter, r *http.Request, maxBody int, handler fasthttp.RequestHandler) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(maxBody)))
+	if err != nil {
+		http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+		return nil
+	}
+
+	var req fasthttp.Request
+	req.Header.SetMethod(r.Method)
+	req.SetRequestURI(r.URL.RequestURI())
+	req.Header.SetHost(r.Host)
+	for key, values := range r.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.SetBody(body)
+
+	var remoteAddr net.Addr
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		remoteAddr = addr
+	}
+	var ctx fasthttp.RequestCtx
+	ctx.Init(&req, remoteAddr, nil)
+
+	handler(&ctx)
+
+	resp := &ctx.Response
+	for key, value := range resp.Header.All() {
+		if isHopByHopHeader(string(key)) {
+			continue
+		}
+		w.Header().Add(string(key), string(value))
+	}
+	w.WriteHeader(resp.StatusCode())
+	return resp.BodyWriteTo(w)
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestWrapHTTPHandler(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	handler := WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Legacy", r.URL.Query().Get("q"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(r.Method + " " + r.URL.Path + " " + string(body)))
+	}))
+
+	ctx := newTestFastContext(gocmd, "POST", "/legacy/items?q=1")
+	ctx.RequestCtx.Request.SetBodyString("payload")
+	if err := handler(ctx); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	resp := &ctx.RequestCtx.Response
+	if resp.StatusCode() != http.StatusCreated {
+		t.Errorf("status = %d, want 201", resp.StatusCode())
+	}
+	if got := string(resp.Header.Peek("X-Legacy")); got != "1" {
+		t.Errorf("X-Legacy = %q, want 1", got)
+	}
+	if got := string(resp.Body()); got != "POST /legacy/items payload" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestToHTTPHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/echo", ToHTTPHandler(func(ctx *FastRequestContext) error {
+		return ctx.JSON(200, map[string]interface{}{
+			"method": string(ctx.Method()),
+			"body":   string(ctx.RequestCtx.PostBody()),
+		})
+	}))
+	mux.Handle("/fail", ToHTTPHandler(func(ctx *FastRequestContext) error {
+		return errors.New("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/echo", strings.NewReader("hi"))
+	req.Header.Set("X-Request-ID", "req-1")
+	mux.ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Body.String(); !strings.Contains(got, `"method":"PUT"`) || !strings.Contains(got, `"body":"hi"`) {
+		t.Errorf("body = %s", got)
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != "req-1" {
+		t.Errorf("X-Request-ID = %q, want req-1", got)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	// Handler errors go through DefaultErrorHandler
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/fail", nil))
+	if rec.Code != 500 {
+		t.Errorf("error status = %d, want 500", rec.Code)
+	}
+}
@@ -11,21 +11,109 @@ import (
 type BackpressureController struct {
 	normalCapacity int64 // Normal capacity (target utilization, e.g., 67% of max)
 	currentLoad    int64 // Current load (atomic)
-	rejectedCount  int64 // Rejected requests count
-	lastReset      int64 // Last reset time (unix timestamp)
-	resetInterval  int64 // Reset interval in seconds
+	rejectedCount  int64 // Rejected requests count (lifetime)
+	// Rejection window: see NewBackpressureController
+	windowRejected     int64 // Rejections in the current interval
+	lastWindowRejected int64 // Rejections in the last completed interval
+	lastReset          int64 // Start of the current interval (unix timestamp)
+	resetInterval      int64 // Reset interval in seconds
+	// Utilization thresholds (percent of normal capacity) for graceful degradation
+	degradedThreshold float64
+	criticalThreshold float64
 }
 
+// LoadLevel describes how loaded the server is, so handlers can shed optional work
+// before the server has to reject requests outright
+type LoadLevel int
+
+const (
+	// LoadLevelNormal - serve requests fully
+	LoadLevelNormal LoadLevel = iota
+	// LoadLevelDegraded - skip optional/expensive work (enrichment, fan-out)
+	LoadLevelDegraded
+	// LoadLevelCritical - serve only the cheapest possible response (cached data)
+	LoadLevelCritical
+)
+
+// String returns the string representation of the load level
+func (l LoadLevel) String() string {
+	switch l {
+	case LoadLevelNormal:
+		return "normal"
+	case LoadLevelDegraded:
+		return "degraded"
+	case LoadLevelCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// BackpressureAddress receives an event when a FastHTTPServer's load level changes,
+// e.g. when it goes critical and starts rejecting requests. Published locally
+// (see core.PublishLocal); transitions are noticed on the next request.
+// {"server", "level", "previousLevel", "utilization", "rejected"}
+const BackpressureAddress = "fluxor.server.backpressure"
+
+// Default utilization thresholds (percent of normal capacity)
+const (
+	DefaultDegradedUtilization = 70.0
+	DefaultCriticalUtilization = 90.0
+)
+
+// DefaultBackpressureResetInterval is the rejection window used when none is given (seconds)
+const DefaultBackpressureResetInterval = 60
+
 // NewBackpressureController creates a new backpressure controller
 // normalCapacity: Target capacity for normal operations (e.g., 67% of max)
 // This ensures system operates at target utilization under normal load
+//
+// resetIntervalSeconds is the length of the rejection window (default: 60).
+// It only affects the windowed metrics (RejectedThisInterval, RejectedLastInterval,
+// RejectionRate): at the end of each interval the window counter moves to
+// RejectedLastInterval and starts again from zero. Current load and the lifetime
+// RejectedCount are never reset.
 func NewBackpressureController(normalCapacity int, resetIntervalSeconds int64) *BackpressureController {
+	if resetIntervalSeconds <= 0 {
+		resetIntervalSeconds = DefaultBackpressureResetInterval
+	}
 	return &BackpressureController{
 		normalCapacity: int64(normalCapacity),
 		currentLoad:    0,
 		rejectedCount:  0,
 		lastReset:      time.Now().Unix(),
 		resetInterval:  resetIntervalSeconds,
+
+		degradedThreshold: DefaultDegradedUtilization,
+		criticalThreshold: DefaultCriticalUtilization,
+	}
+}
+
+// SetDegradationThresholds configures the utilization percentages at which
+// Level() reports Degraded and Critical. Must be called before serving requests.
+// Non-positive values keep the current threshold.
+func (bc *BackpressureController) SetDegradationThresholds(degraded, critical float64) {
+	if degraded > 0 {
+		bc.degradedThreshold = degraded
+	}
+	if critical > 0 {
+		bc.criticalThreshold = critical
+	}
+}
+
+// Level returns the current load level based on utilization of normal capacity
+func (bc *BackpressureController) Level() LoadLevel {
+	if bc.normalCapacity <= 0 {
+		return LoadLevelNormal
+	}
+	utilization := float64(atomic.LoadInt64(&bc.currentLoad)) / float64(bc.normalCapacity) * 100
+	switch {
+	case utilization >= bc.criticalThreshold:
+		return LoadLevelCritical
+	case utilization >= bc.degradedThreshold:
+		return LoadLevelDegraded
+	default:
+		return LoadLevelNormal
 	}
 }
 
@@ -33,12 +121,7 @@ func NewBackpressureController(normalCapacity int, resetIntervalSeconds int64) *
 // Returns true if normal capacity available, false if should reject (503)
 // Normal capacity = target utilization (e.g., 67% of max capacity)
 func (bc *BackpressureController) TryAcquire() bool {
-	// Reset counters periodically
-	now := time.Now().Unix()
-	if now-bc.lastReset > bc.resetInterval {
-		atomic.StoreInt64(&bc.currentLoad, 0)
-		atomic.StoreInt64(&bc.lastReset, now)
-	}
+	bc.rotate(time.Now().Unix())
 
 	// Check current load against normal capacity (target utilization)
 	current := atomic.LoadInt64(&bc.currentLoad)
@@ -46,6 +129,7 @@ func (bc *BackpressureController) TryAcquire() bool {
 		// Fail-fast: normal capacity exceeded, reject immediately
 		// This maintains target utilization (e.g., 67%) under normal conditions
 		atomic.AddInt64(&bc.rejectedCount, 1)
+		atomic.AddInt64(&bc.windowRejected, 1)
 		return false
 	}
 
@@ -59,21 +143,66 @@ func (bc *BackpressureController) Release() {
 	atomic.AddInt64(&bc.currentLoad, -1)
 }
 
+// rotate closes the rejection window once resetInterval has elapsed.
+// Only one caller wins the CAS; concurrent callers keep counting into the new window.
+func (bc *BackpressureController) rotate(now int64) {
+	start := atomic.LoadInt64(&bc.lastReset)
+	if now-start < bc.resetInterval {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&bc.lastReset, start, now) {
+		return
+	}
+	rejected := atomic.SwapInt64(&bc.windowRejected, 0)
+	if now-start >= 2*bc.resetInterval {
+		// Idle for a whole interval since the window closed: nothing was rejected in it
+		rejected = 0
+	}
+	atomic.StoreInt64(&bc.lastWindowRejected, rejected)
+}
+
 // GetMetrics returns current backpressure metrics
 func (bc *BackpressureController) GetMetrics() BackpressureMetrics {
+	bc.rotate(time.Now().Unix())
 	currentLoad := atomic.LoadInt64(&bc.currentLoad)
+	lastRejected := atomic.LoadInt64(&bc.lastWindowRejected)
 	return BackpressureMetrics{
-		NormalCapacity: bc.normalCapacity,
-		CurrentLoad:    currentLoad,
-		RejectedCount:  atomic.LoadInt64(&bc.rejectedCount),
-		Utilization:    float64(currentLoad) / float64(bc.normalCapacity) * 100,
+		NormalCapacity:       bc.normalCapacity,
+		CurrentLoad:          currentLoad,
+		RejectedCount:        atomic.LoadInt64(&bc.rejectedCount),
+		RejectedThisInterval: atomic.LoadInt64(&bc.windowRejected),
+		RejectedLastInterval: lastRejected,
+		RejectionRate:        float64(lastRejected) / float64(bc.resetInterval),
+		ResetInterval:        time.Duration(bc.resetInterval) * time.Second,
+		Utilization:          float64(currentLoad) / float64(bc.normalCapacity) * 100,
 	}
 }
 
 // BackpressureMetrics provides backpressure statistics
 type BackpressureMetrics struct {
-	NormalCapacity int64   // Normal capacity (target utilization)
-	CurrentLoad    int64   // Current load
-	RejectedCount  int64   // Total rejected requests
-	Utilization    float64 // Utilization percentage (relative to normal capacity)
+	NormalCapacity       int64         // Normal capacity (target utilization)
+	CurrentLoad          int64         // Current load
+	RejectedCount        int64         // Total rejected requests
+	RejectedThisInterval int64         // Rejected requests in the current (incomplete) interval
+	RejectedLastInterval int64         // Rejected requests in the last completed interval
+	RejectionRate        float64       // Rejections per second over the last completed interval
+	ResetInterval        time.Duration // Length of the rejection window
+	Utilization          float64       // Utilization percentage (relative to normal capacity)
+}
+
+// LoadShed creates middleware that switches to an alternative handler when the
+// server is under load. levelHandlers maps a load level to the handler to use;
+// when the current level has no entry, the closest lower level's handler is used,
+// falling back to the wrapped handler (Normal).
+func LoadShed(levelHandlers map[LoadLevel]FastRequestHandler) FastMiddleware {
+	return func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			for level := ctx.LoadLevel(); level > LoadLevelNormal; level-- {
+				if handler, ok := levelHandlers[level]; ok && handler != nil {
+					return handler(ctx)
+				}
+			}
+			return next(ctx)
+		}
+	}
 }
@@ -2,6 +2,8 @@ package web
 
 import (
 	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
 )
 
 func TestCCUBasedConfig(t *testing.T) {
@@ -93,3 +95,96 @@ func TestBackpressureController(t *testing.T) {
 		t.Error("Should acquire capacity after release")
 	}
 }
+
+func TestBackpressureController_Level(t *testing.T) {
+	bc := NewBackpressureController(10, 60)
+
+	if bc.Level() != LoadLevelNormal {
+		t.Errorf("Level() = %v, want normal", bc.Level())
+	}
+
+	for i := 0; i < 7; i++ {
+		bc.TryAcquire()
+	}
+	if bc.Level() != LoadLevelDegraded {
+		t.Errorf("Level() at 70%% = %v, want degraded", bc.Level())
+	}
+
+	for i := 0; i < 2; i++ {
+		bc.TryAcquire()
+	}
+	if bc.Level() != LoadLevelCritical {
+		t.Errorf("Level() at 90%% = %v, want critical", bc.Level())
+	}
+
+	bc.SetDegradationThresholds(50, 95)
+	if bc.Level() != LoadLevelDegraded {
+		t.Errorf("Level() with custom thresholds = %v, want degraded", bc.Level())
+	}
+}
+
+func TestLoadShed(t *testing.T) {
+	full := func(ctx *FastRequestContext) error { ctx.Set("served", "full"); return nil }
+	cached := func(ctx *FastRequestContext) error { ctx.Set("served", "cached"); return nil }
+
+	handler := LoadShed(map[LoadLevel]FastRequestHandler{
+		LoadLevelDegraded: cached,
+	})(full)
+
+	tests := []struct {
+		level LoadLevel
+		want  string
+	}{
+		{LoadLevelNormal, "full"},
+		{LoadLevelDegraded, "cached"},
+		{LoadLevelCritical, "cached"}, // falls back to closest lower level
+	}
+
+	for _, tt := range tests {
+		ctx := &FastRequestContext{BaseRequestContext: core.NewBaseRequestContext(), loadLevel: tt.level}
+		if err := handler(ctx); err != nil {
+			t.Fatalf("handler() error = %v", err)
+		}
+		if got := ctx.Get("served"); got != tt.want {
+			t.Errorf("level %v served %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestBackpressureController_RejectionWindow(t *testing.T) {
+	bc := NewBackpressureController(2, 10)
+	start := bc.lastReset
+
+	bc.TryAcquire()
+	bc.TryAcquire()
+	for i := 0; i < 5; i++ {
+		if bc.TryAcquire() {
+			t.Fatal("Should reject beyond normal capacity")
+		}
+	}
+	if m := bc.GetMetrics(); m.RejectedThisInterval != 5 || m.RejectedLastInterval != 0 {
+		t.Errorf("before rotation: this = %d, last = %d", m.RejectedThisInterval, m.RejectedLastInterval)
+	}
+
+	bc.rotate(start + 10)
+	m := bc.GetMetrics()
+	if m.RejectedLastInterval != 5 || m.RejectedThisInterval != 0 {
+		t.Errorf("after rotation: this = %d, last = %d, want 0 and 5", m.RejectedThisInterval, m.RejectedLastInterval)
+	}
+	if m.RejectionRate != 0.5 {
+		t.Errorf("RejectionRate = %v, want 0.5/s", m.RejectionRate)
+	}
+	if m.RejectedCount != 5 {
+		t.Errorf("RejectedCount = %d, want lifetime total 5", m.RejectedCount)
+	}
+	// The window does not touch in-flight load
+	if m.CurrentLoad != 2 {
+		t.Errorf("CurrentLoad = %d, want 2", m.CurrentLoad)
+	}
+
+	// A whole idle interval: nothing was rejected in the last one
+	bc.rotate(start + 30)
+	if m := bc.GetMetrics(); m.RejectedLastInterval != 0 {
+		t.Errorf("after idle interval: last = %d, want 0", m.RejectedLastInterval)
+	}
+}
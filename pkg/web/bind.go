@@ -0,0 +1,104 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultBindMaxBytes is a suggested BindOptions.MaxBytes for endpoints taking
+// small JSON documents (1 MiB)
+const DefaultBindMaxBytes = 1 << 20
+
+// BindJSON errors, distinguishable with errors.Is; BindErrorStatus maps them to a status code
+var (
+	ErrEmptyBody              = errors.New("empty request body")
+	ErrUnsupportedContentType = errors.New("content type is not JSON")
+	ErrBodyTooLarge           = errors.New("request body too large")
+	ErrMalformedJSON          = errors.New("malformed JSON body")
+)
+
+// BindOptions tunes BindJSON. The zero value binds like BindJSON without options.
+type BindOptions struct {
+	// MaxBytes rejects larger bodies before decoding (0 = no limit beyond the
+	// server's MaxRequestBodySize)
+	MaxBytes int
+
+	// CheckContentType rejects a Content-Type that is not JSON (application/json
+	// or application/*+json); requests without one are still accepted
+	CheckContentType bool
+
+	// StrictContentType also rejects requests without a Content-Type header
+	// (implies CheckContentType)
+	StrictContentType bool
+
+	// DisallowUnknownFields rejects fields the target struct doesn't have, to catch typos
+	DisallowUnknownFields bool
+}
+
+// BindJSON binds the JSON request body to v with core.JSONDecodeWith - fail-fast.
+// It returns an error wrapping ErrEmptyBody or ErrMalformedJSON, and with the
+// matching options ErrUnsupportedContentType or ErrBodyTooLarge, checked before decoding.
+func (c *FastRequestContext) BindJSON(v interface{}, opts ...BindOptions) error {
+	// Fail-fast: validate target
+	if v == nil {
+		return fmt.Errorf("cannot bind to nil value")
+	}
+	var options BindOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if options.CheckContentType || options.StrictContentType {
+		contentType := string(c.RequestCtx.Request.Header.ContentType())
+		if (contentType != "" || options.StrictContentType) && !isJSONContentType(contentType) {
+			return fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+		}
+	}
+
+	if options.MaxBytes > 0 {
+		if n := c.RequestCtx.Request.Header.ContentLength(); n > options.MaxBytes {
+			return fmt.Errorf("%w: %d bytes exceeds limit of %d", ErrBodyTooLarge, n, options.MaxBytes)
+		}
+	}
+	body := c.RequestCtx.PostBody()
+	if options.MaxBytes > 0 && len(body) > options.MaxBytes {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d", ErrBodyTooLarge, len(body), options.MaxBytes)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ErrEmptyBody
+	}
+
+	if err := core.JSONDecodeWith(body, v, core.JSONDecodeOptions{DisallowUnknownFields: options.DisallowUnknownFields}); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedJSON, err)
+	}
+	return nil
+}
+
+// BindErrorStatus returns the HTTP status for a BindJSON error:
+// 415 for the content type, 413 for the size, 400 otherwise
+func BindErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUnsupportedContentType):
+		return fasthttp.StatusUnsupportedMediaType
+	case errors.Is(err, ErrBodyTooLarge):
+		return fasthttp.StatusRequestEntityTooLarge
+	default:
+		return fasthttp.StatusBadRequest
+	}
+}
+
+// isJSONContentType accepts application/json and application/*+json, with parameters
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" ||
+		(strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestFastRequestContext_BindJSONChecks(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		opts        []BindOptions
+		wantErr     error
+		wantStatus  int
+	}{
+		{name: "valid", contentType: "application/json; charset=utf-8", body: `{"name":"ada"}`},
+		{name: "vendor json", contentType: "application/vnd.api+json", body: `{"name":"ada"}`},
+		{name: "missing content type allowed", body: `{"name":"ada"}`},
+		{name: "missing content type strict", body: `{"name":"ada"}`, opts: []BindOptions{{StrictContentType: true}},
+			wantErr: ErrUnsupportedContentType, wantStatus: 415},
+		{name: "form content type allowed", contentType: "application/x-www-form-urlencoded", body: `{"name":"ada"}`},
+		{name: "wrong content type", contentType: "text/plain", body: `{"name":"ada"}`, opts: []BindOptions{{CheckContentType: true}},
+			wantErr: ErrUnsupportedContentType, wantStatus: 415},
+		{name: "missing content type checked", body: `{"name":"ada"}`, opts: []BindOptions{{CheckContentType: true}}},
+		{name: "large body allowed", contentType: "application/json", body: `{"name":"` + strings.Repeat("a", 2<<20) + `"}`},
+		{name: "too large", contentType: "application/json", body: `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			opts: []BindOptions{{MaxBytes: 32}}, wantErr: ErrBodyTooLarge, wantStatus: 413},
+		{name: "empty", contentType: "application/json", body: "  ", wantErr: ErrEmptyBody, wantStatus: 400},
+		{name: "malformed", contentType: "application/json", body: `{"name":`, wantErr: ErrMalformedJSON, wantStatus: 400},
+		{name: "trailing data", contentType: "application/json", body: `{"name":"ada"} {}`, wantErr: ErrMalformedJSON, wantStatus: 400},
+		{name: "unknown field allowed", contentType: "application/json", body: `{"nmae":"ada"}`},
+		{name: "unknown field rejected", contentType: "application/json", body: `{"nmae":"ada"}`,
+			opts: []BindOptions{{DisallowUnknownFields: true}}, wantErr: ErrMalformedJSON, wantStatus: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestFastContext(gocmd, "POST", "/users")
+			if tt.contentType != "" {
+				ctx.RequestCtx.Request.Header.SetContentType(tt.contentType)
+			}
+			ctx.RequestCtx.Request.SetBodyString(tt.body)
+
+			var u user
+			err := ctx.BindJSON(&u, tt.opts...)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("BindJSON() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BindJSON() error = %v, want %v", err, tt.wantErr)
+			}
+			if status := BindErrorStatus(err); status != tt.wantStatus {
+				t.Errorf("BindErrorStatus() = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// AutoCCUOptions tunes how AutoCCUConfig sizes the server
+type AutoCCUOptions struct {
+	// PerConnMemory is the memory budget per concurrent connection in bytes (default: 64 KiB)
+	// Covers read/write buffers, goroutine stack and request state
+	PerConnMemory int64
+
+	// MemoryFraction is the share of available memory given to connections (default: 0.5)
+	MemoryFraction float64
+
+	// CCUPerCPU caps concurrent users per CPU core (default: 1000)
+	CCUPerCPU int
+
+	// CPUs overrides runtime.NumCPU() (0 = detect)
+	CPUs int
+
+	// AvailableMemory overrides memory detection in bytes (0 = detect)
+	AvailableMemory int64
+
+	// Logger receives the derived values (default: core.NewDefaultLogger())
+	Logger core.Logger
+}
+
+// DefaultAutoCCUOptions returns the default sizing options
+func DefaultAutoCCUOptions() AutoCCUOptions {
+	return AutoCCUOptions{
+		PerConnMemory:  64 * 1024,
+		MemoryFraction: 0.5,
+		CCUPerCPU:      1000,
+	}
+}
+
+// AutoCCUConfig derives maxCCU from CPU count and available memory, then builds
+// the config with CCUBasedConfigWithUtilization
+// Use when you don't know a good maxCCU; the derived values are logged for sanity-checking
+func AutoCCUConfig(addr string, utilizationPercent int) *FastHTTPServerConfig {
+	return AutoCCUConfigWithOptions(addr, utilizationPercent, DefaultAutoCCUOptions())
+}
+
+// AutoCCUConfigWithOptions is AutoCCUConfig with explicit sizing options - fail-fast on invalid options
+func AutoCCUConfigWithOptions(addr string, utilizationPercent int, opts AutoCCUOptions) *FastHTTPServerConfig {
+	if opts.PerConnMemory < 0 || opts.CCUPerCPU < 0 || opts.CPUs < 0 || opts.AvailableMemory < 0 {
+		panic("auto CCU options cannot be negative")
+	}
+	if opts.MemoryFraction < 0 || opts.MemoryFraction > 1 {
+		panic("MemoryFraction must be between 0 and 1")
+	}
+	defaults := DefaultAutoCCUOptions()
+	if opts.PerConnMemory == 0 {
+		opts.PerConnMemory = defaults.PerConnMemory
+	}
+	if opts.MemoryFraction == 0 {
+		opts.MemoryFraction = defaults.MemoryFraction
+	}
+	if opts.CCUPerCPU == 0 {
+		opts.CCUPerCPU = defaults.CCUPerCPU
+	}
+	if opts.CPUs == 0 {
+		opts.CPUs = runtime.NumCPU()
+	}
+	memorySource := "override"
+	if opts.AvailableMemory == 0 {
+		opts.AvailableMemory, memorySource = availableMemory()
+	}
+	if opts.Logger == nil {
+		opts.Logger = core.NewDefaultLogger()
+	}
+
+	cpuCCU := opts.CPUs * opts.CCUPerCPU
+	memCCU := int(float64(opts.AvailableMemory) * opts.MemoryFraction / float64(opts.PerConnMemory))
+	maxCCU := cpuCCU
+	if memCCU < maxCCU {
+		maxCCU = memCCU
+	}
+	if maxCCU < 100 {
+		maxCCU = 100 // Minimum so tiny containers still serve traffic
+	}
+
+	config := CCUBasedConfigWithUtilization(addr, maxCCU, utilizationPercent)
+
+	opts.Logger.WithFields(map[string]interface{}{
+		"cpus":             opts.CPUs,
+		"available_memory": opts.AvailableMemory,
+		"memory_source":    memorySource,
+		"per_conn_memory":  opts.PerConnMemory,
+		"cpu_ccu_limit":    cpuCCU,
+		"memory_ccu_limit": memCCU,
+		"max_ccu":          maxCCU,
+		"workers":          config.Workers,
+		"max_queue":        config.MaxQueue,
+		"max_conns":        config.MaxConns,
+	}).Info(fmt.Sprintf("auto CCU config: maxCCU=%d (cpu limit %d, memory limit %d), workers=%d, queue=%d",
+		maxCCU, cpuCCU, memCCU, config.Workers, config.MaxQueue))
+
+	return config
+}
+
+// availableMemory returns usable memory in bytes and where it came from:
+// GOMEMLIMIT, cgroup v2 limit, /proc/meminfo MemAvailable, or a 1 GiB fallback
+func availableMemory() (int64, string) {
+	if limit := debug.SetMemoryLimit(-1); limit > 0 && limit < math.MaxInt64 {
+		return limit, "GOMEMLIMIT"
+	}
+	if data, err := os.ReadFile("/sys/fs/cgroup/memory.max"); err == nil {
+		if v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil && v > 0 {
+			return v, "cgroup"
+		}
+	}
+	if f, err := os.Open("/proc/meminfo"); err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 2 && fields[0] == "MemAvailable:" {
+				if kb, err := strconv.ParseInt(fields[1], 10, 64); err == nil && kb > 0 {
+					return kb * 1024, "meminfo"
+				}
+			}
+		}
+	}
+	return 1 << 30, "default"
+}
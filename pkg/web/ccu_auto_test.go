@@ -0,0 +1,50 @@
+package web
+
+import "testing"
+
+func TestAutoCCUConfigWithOptions_CPUBound(t *testing.T) {
+	config := AutoCCUConfigWithOptions(":8080", 67, AutoCCUOptions{
+		CPUs:            4,
+		AvailableMemory: 8 << 30, // 8 GiB * 0.5 / 64 KiB = 65536 by memory
+	})
+
+	// 4 CPUs * 1000 = 4000 is the tighter limit
+	if config.MaxConns != 4000 {
+		t.Errorf("MaxConns = %d, want 4000", config.MaxConns)
+	}
+	if config.Workers < 50 || config.MaxQueue < 100 {
+		t.Errorf("Workers = %d, MaxQueue = %d; should respect CCUBasedConfigWithUtilization minimums", config.Workers, config.MaxQueue)
+	}
+}
+
+func TestAutoCCUConfigWithOptions_MemoryBound(t *testing.T) {
+	config := AutoCCUConfigWithOptions(":8080", 67, AutoCCUOptions{
+		CPUs:            16,
+		AvailableMemory: 128 << 20, // 128 MiB * 0.5 / 64 KiB = 1024
+	})
+
+	if config.MaxConns != 1024 {
+		t.Errorf("MaxConns = %d, want 1024", config.MaxConns)
+	}
+}
+
+func TestAutoCCUConfigWithOptions_Minimum(t *testing.T) {
+	config := AutoCCUConfigWithOptions(":8080", 67, AutoCCUOptions{
+		CPUs:            1,
+		AvailableMemory: 1 << 20,
+	})
+
+	if config.MaxConns != 100 {
+		t.Errorf("MaxConns = %d, want minimum 100", config.MaxConns)
+	}
+}
+
+func TestAutoCCUConfig_Detects(t *testing.T) {
+	config := AutoCCUConfig(":8080", 67)
+	if config.MaxConns < 100 {
+		t.Errorf("MaxConns = %d, want at least 100", config.MaxConns)
+	}
+	if mem, source := availableMemory(); mem <= 0 || source == "" {
+		t.Errorf("availableMemory() = %d, %q", mem, source)
+	}
+}
@@ -0,0 +1,39 @@
+package web
+
+// ContextKey is a typed key for values stored on a FastRequestContext
+// Values are stored under Name(), so untyped ctx.Get(name) keeps working
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey creates a typed context key - fail-fast on empty name
+func NewContextKey[T any](name string) ContextKey[T] {
+	if name == "" {
+		panic("context key name cannot be empty")
+	}
+	return ContextKey[T]{name: name}
+}
+
+// Name returns the underlying storage key
+func (k ContextKey[T]) Name() string {
+	return k.name
+}
+
+// SetValue stores a typed value on the request context
+func SetValue[T any](ctx *FastRequestContext, key ContextKey[T], value T) {
+	ctx.Set(key.name, value)
+}
+
+// GetValue retrieves a typed value from the request context
+// Returns false if the key is missing or holds a value of another type
+func GetValue[T any](ctx *FastRequestContext, key ContextKey[T]) (T, bool) {
+	var zero T
+	if ctx == nil || ctx.BaseRequestContext == nil {
+		return zero, false
+	}
+	value, ok := ctx.Get(key.name).(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+type testPrincipal struct {
+	ID string
+}
+
+func TestContextValues(t *testing.T) {
+	ctx := &FastRequestContext{BaseRequestContext: core.NewBaseRequestContext()}
+	userKey := NewContextKey[*testPrincipal]("user")
+
+	if _, ok := GetValue(ctx, userKey); ok {
+		t.Error("GetValue() on empty context should return false")
+	}
+
+	SetValue(ctx, userKey, &testPrincipal{ID: "42"})
+	user, ok := GetValue(ctx, userKey)
+	if !ok || user.ID != "42" {
+		t.Errorf("GetValue() = %v, %v; want user 42", user, ok)
+	}
+
+	// Untyped access still works
+	if _, ok := ctx.Get("user").(*testPrincipal); !ok {
+		t.Error("Get(\"user\") should return the stored value")
+	}
+
+	// Mismatched type is reported instead of panicking
+	countKey := NewContextKey[int]("user")
+	if _, ok := GetValue(ctx, countKey); ok {
+		t.Error("GetValue() with mismatched type should return false")
+	}
+
+	if _, ok := GetValue(&FastRequestContext{}, userKey); ok {
+		t.Error("GetValue() without storage should return false")
+	}
+}
@@ -0,0 +1,170 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrorHandler writes the response for an error returned by a handler or middleware
+// (see FastRouter.SetErrorHandler)
+type ErrorHandler func(ctx *FastRequestContext, err error)
+
+// PanicHandler writes the response for a request whose handler panicked with
+// recovered (see FastHTTPServerConfig.PanicHandler). The panic is already logged.
+type PanicHandler func(ctx *FastRequestContext, recovered interface{})
+
+// HTTPError is an error that carries the response status, so handlers can
+// `return web.NewHTTPError(404, "user not found")` instead of writing the response
+type HTTPError struct {
+	Status  int
+	Code    string                 // Machine-readable code, e.g. "user_not_found" (default: derived from Status)
+	Message string                 // Sent to the client
+	Details map[string]interface{} // Sent to the client, e.g. invalid fields
+	Err     error                  // Underlying cause; logged, never sent
+}
+
+// NewHTTPError creates an HTTPError with status and a client-facing message
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// As lets errors.As convert an HTTPError to a *core.FluxorError
+func (e *HTTPError) As(target interface{}) bool {
+	t, ok := target.(**core.FluxorError)
+	if ok {
+		code := e.Code
+		if code == "" {
+			code = statusCode(e.Status)
+		}
+		*t = &core.FluxorError{Code: code, Message: e.Message, Cause: e.Err, Details: e.Details}
+	}
+	return ok
+}
+
+// ErrorStatus maps an error to the status DefaultErrorHandler answers with:
+//   - *HTTPError: its Status
+//   - BindJSON errors: BindErrorStatus
+//   - core.FluxorError (e.g. EventBus errors): NO_HANDLERS 503, TIMEOUT 504,
+//     INVALID_INPUT 400, MESSAGE_TOO_LARGE 413
+//   - context.DeadlineExceeded: 504
+//   - anything else: 500
+func ErrorStatus(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status != 0 {
+		return httpErr.Status
+	}
+	if errors.Is(err, ErrUnsupportedContentType) || errors.Is(err, ErrBodyTooLarge) ||
+		errors.Is(err, ErrEmptyBody) || errors.Is(err, ErrMalformedJSON) {
+		return BindErrorStatus(err)
+	}
+	var fluxErr *core.FluxorError
+	if errors.As(err, &fluxErr) {
+		switch fluxErr.Code {
+		case "NO_HANDLERS":
+			return fasthttp.StatusServiceUnavailable
+		case "TIMEOUT":
+			return fasthttp.StatusGatewayTimeout
+		case "INVALID_INPUT":
+			return fasthttp.StatusBadRequest
+		case "MESSAGE_TOO_LARGE":
+			return fasthttp.StatusRequestEntityTooLarge
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fasthttp.StatusGatewayTimeout
+	}
+	return fasthttp.StatusInternalServerError
+}
+
+// DefaultErrorHandler answers with ErrorStatus(err) and a JSON body:
+//
+//	{"error":"service_unavailable","message":"Service Unavailable","request_id":"..."}
+//
+// A core.FluxorError is rendered with core.ErrorBody, so its Details are sent as
+// "details". Client errors (4xx) and HTTPError messages are sent as is; other 5xx
+// errors are logged with the request ID and answered with the generic status text
+// and no details, so internal details don't leak.
+func DefaultErrorHandler(ctx *FastRequestContext, err error) {
+	status := ErrorStatus(err)
+	body := map[string]interface{}{
+		"error":   statusCode(status),
+		"message": fasthttp.StatusMessage(status),
+	}
+
+	var httpErr *HTTPError
+	var fluxErr *core.FluxorError
+	switch {
+	case errors.As(err, &httpErr):
+		if httpErr.Code != "" {
+			body["error"] = httpErr.Code
+		}
+		if httpErr.Message != "" {
+			body["message"] = httpErr.Message
+		}
+		if len(httpErr.Details) > 0 {
+			body["details"] = httpErr.Details
+		}
+	case errors.As(err, &fluxErr):
+		fields := core.ErrorBody(fluxErr)
+		body["error"] = fields["error"]
+		if details, ok := fields["details"]; ok && status < 500 {
+			body["details"] = details
+		}
+		if status < 500 {
+			body["message"] = err.Error()
+		}
+	case status < 500:
+		body["message"] = err.Error()
+	}
+	if status >= 500 {
+		errorLogger.Error(fmt.Sprintf("handler error (request_id=%s, status=%d): %v", ctx.RequestID(), status, err))
+	}
+	body["request_id"] = ctx.RequestID()
+
+	ctx.RequestCtx.Response.ResetBody()
+	if jsonErr := ctx.JSON(status, body); jsonErr != nil {
+		ctx.Error(body["message"].(string), status)
+	}
+}
+
+// errorLogger logs the server errors DefaultErrorHandler answers
+var errorLogger = core.NewDefaultLogger()
+
+// statusCode turns a status into a snake_case code, e.g. 404 -> "not_found"
+func statusCode(status int) string {
+	return strings.ToLower(strings.ReplaceAll(fasthttp.StatusMessage(status), " ", "_"))
+}
+
+// DefaultPanicHandler answers 500 with a JSON body naming the request and route,
+// but not the panic value, so internal details don't leak:
+//
+//	{"error":"handler_panic","message":"Request handler failed","request_id":"...","route":"/api/users/:id"}
+func DefaultPanicHandler(ctx *FastRequestContext, recovered interface{}) {
+	body := map[string]interface{}{
+		"error":      "handler_panic",
+		"message":    "Request handler failed",
+		"request_id": ctx.RequestID(),
+	}
+	if route := ctx.RoutePattern(); route != "" {
+		body["route"] = route
+	}
+	if err := ctx.JSON(fasthttp.StatusInternalServerError, body); err != nil {
+		ctx.Error("Internal Server Error", fasthttp.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestFastRouter_DefaultErrorHandler(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{"http error", NewHTTPError(404, "user not found"), 404, "not_found", "user not found"},
+		{"http error code", &HTTPError{Status: 409, Code: "email_taken", Message: "email taken"}, 409, "email_taken", "email taken"},
+		{"wrapped http error", fmt.Errorf("lookup: %w", NewHTTPError(403, "forbidden")), 403, "forbidden", "forbidden"},
+		{"bind error", fmt.Errorf("%w: %q", ErrUnsupportedContentType, "text/plain"), 415, "unsupported_media_type", `content type is not JSON: "text/plain"`},
+		{"no handlers", &core.EventBusError{Code: "NO_HANDLERS", Message: "no handlers for users.get"}, 503, "no_handlers", "Service Unavailable"},
+		{"message too large", &core.EventBusError{Code: "MESSAGE_TOO_LARGE", Message: "message to uploads is 2048 bytes, over the 1024 byte limit"}, 413, "message_too_large", "message to uploads is 2048 bytes, over the 1024 byte limit"},
+		{"bus timeout", core.ErrTimeout, 504, "timeout", "Gateway Timeout"},
+		{"internal", errors.New("db password rejected"), 500, "internal_server_error", "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewFastRouter()
+			router.GETFast("/users/:id", func(ctx *FastRequestContext) error {
+				ctx.RequestCtx.WriteString("partial")
+				return tt.err
+			})
+			ctx := newTestFastContext(gocmd, "GET", "/users/1")
+			ctx.requestID = "req-1"
+			router.ServeFastHTTP(ctx)
+
+			if got := ctx.RequestCtx.Response.StatusCode(); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(ctx.RequestCtx.Response.Body(), &body); err != nil {
+				t.Fatalf("body %q: %v", ctx.RequestCtx.Response.Body(), err)
+			}
+			if body["error"] != tt.wantCode || body["message"] != tt.wantMessage || body["request_id"] != "req-1" {
+				t.Errorf("body = %v, want error=%q message=%q request_id=req-1", body, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDefaultErrorHandler_Details(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantDetails bool
+	}{
+		{"http error", &HTTPError{Status: 422, Message: "invalid user", Details: map[string]interface{}{"field": "email"}}, 422, "unprocessable_entity", true},
+		{"fluxor error", core.NewError("INVALID_INPUT", "invalid user").WithDetail("field", "email"), 400, "invalid_input", true},
+		{"server error", core.NewError("NO_HANDLERS", "no handlers").WithDetail("field", "email"), 503, "no_handlers", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestFastContext(gocmd, "GET", "/users")
+			DefaultErrorHandler(ctx, tt.err)
+
+			if got := ctx.RequestCtx.Response.StatusCode(); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+			var body struct {
+				Error   string                 `json:"error"`
+				Details map[string]interface{} `json:"details"`
+			}
+			if err := json.Unmarshal(ctx.RequestCtx.Response.Body(), &body); err != nil {
+				t.Fatalf("body %q: %v", ctx.RequestCtx.Response.Body(), err)
+			}
+			if body.Error != tt.wantCode {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantCode)
+			}
+			if got := body.Details["field"] == "email"; got != tt.wantDetails {
+				t.Errorf("details = %v, want sent: %v", body.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestHTTPError_AsFluxorError(t *testing.T) {
+	err := fmt.Errorf("lookup: %w", &HTTPError{Status: 404, Message: "user not found", Err: errors.New("no rows")})
+	var fe *core.FluxorError
+	if !errors.As(err, &fe) {
+		t.Fatal("errors.As(HTTPError, *core.FluxorError) = false")
+	}
+	if fe.Code != "not_found" || fe.Message != "user not found" || fe.Cause == nil {
+		t.Errorf("converted = %+v", fe)
+	}
+}
+
+func TestFastRouter_SetErrorHandler(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	errOutOfStock := errors.New("out of stock")
+	router := NewFastRouter()
+	router.SetErrorHandler(func(ctx *FastRequestContext, err error) {
+		if errors.Is(err, errOutOfStock) {
+			ctx.Text(422, "sold out")
+			return
+		}
+		DefaultErrorHandler(ctx, err)
+	})
+	router.POSTFast("/orders", func(ctx *FastRequestContext) error {
+		return fmt.Errorf("reserve: %w", errOutOfStock)
+	})
+
+	ctx := newTestFastContext(gocmd, "POST", "/orders")
+	router.ServeFastHTTP(ctx)
+	if ctx.RequestCtx.Response.StatusCode() != 422 || string(ctx.RequestCtx.Response.Body()) != "sold out" {
+		t.Errorf("response = %d %q, want 422 sold out", ctx.RequestCtx.Response.StatusCode(), ctx.RequestCtx.Response.Body())
+	}
+
+	// Unrouted requests go through middleware, whose errors reach the handler too
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error { return NewHTTPError(401, "login required") }
+	})
+	ctx = newTestFastContext(gocmd, "GET", "/missing")
+	router.ServeFastHTTP(ctx)
+	if ctx.RequestCtx.Response.StatusCode() != 401 || !strings.Contains(string(ctx.RequestCtx.Response.Body()), "login required") {
+		t.Errorf("response = %d %q, want 401", ctx.RequestCtx.Response.StatusCode(), ctx.RequestCtx.Response.Body())
+	}
+
+	router.SetErrorHandler(nil)
+	ctx = newTestFastContext(gocmd, "POST", "/orders")
+	router.ServeFastHTTP(ctx)
+	if ctx.RequestCtx.Response.StatusCode() != 401 {
+		t.Errorf("status = %d, want 401 from the middleware", ctx.RequestCtx.Response.StatusCode())
+	}
+}
@@ -1,6 +1,10 @@
 package web
 
 import (
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 
@@ -11,16 +15,42 @@ import (
 // FastRouter implements Router for fasthttp
 type FastRouter struct {
 	routes     []*fastRoute
+	static     map[string]map[string]*fastRoute // Path -> method -> route, for routes without parameters
 	middleware []FastMiddleware
+	options    FastRouterOptions
 	mu         sync.RWMutex
+
+	errorHandler ErrorHandler // nil: DefaultErrorHandler
+
+	openAPIInfo OpenAPIInfo
+}
+
+// FastRouterOptions configures how unmatched paths are handled. The zero value
+// matches paths exactly, as before.
+//
+// Redirects use 301 for GET/HEAD and 308 otherwise (keeps method and body),
+// and only happen when the canonical path has a route for the request method.
+type FastRouterOptions struct {
+	// RedirectTrailingSlash redirects /users/ to /users (or the reverse) when only the other form is routed
+	RedirectTrailingSlash bool
+
+	// RedirectFixedPath cleans the path (//, ./, ../) and matches it case-insensitively,
+	// then redirects to the registered form, e.g. /API//Users -> /api/users
+	RedirectFixedPath bool
+
+	// CaseInsensitive matches static path segments ignoring case and serves without redirecting
+	CaseInsensitive bool
 }
 
 type fastRoute struct {
 	method  string
 	path    string
 	handler FastRequestHandler
+	name    string // Handler function name, for logs and spans
 	// middleware is applied only for this route (in addition to any global middleware).
 	middleware []FastMiddleware
+	group      *FastRouteGroup // Group the route was registered on, if any
+	doc        *RouteDoc       // Set by Describe, for GenerateOpenAPI
 }
 
 // FastRequestHandler handles fasthttp requests
@@ -31,12 +61,26 @@ type FastMiddleware func(handler FastRequestHandler) FastRequestHandler
 
 // NewFastRouter creates a new fasthttp router
 func NewFastRouter() *FastRouter {
+	return NewFastRouterWithOptions(FastRouterOptions{})
+}
+
+// NewFastRouterWithOptions creates a fasthttp router with path normalization options
+func NewFastRouterWithOptions(options FastRouterOptions) *FastRouter {
 	return &FastRouter{
 		routes:     make([]*fastRoute, 0),
+		static:     make(map[string]map[string]*fastRoute),
 		middleware: make([]FastMiddleware, 0),
+		options:    options,
 	}
 }
 
+// SetOptions replaces the router options, e.g. on the router owned by FastHTTPServer
+func (r *FastRouter) SetOptions(options FastRouterOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.options = options
+}
+
 // ServeFastHTTP implements fasthttp request handler
 func (r *FastRouter) ServeFastHTTP(ctx *FastRequestContext) {
 	r.mu.RLock()
@@ -45,32 +89,132 @@ func (r *FastRouter) ServeFastHTTP(ctx *FastRequestContext) {
 	method := string(ctx.Method())
 	path := string(ctx.Path())
 
+	// Static routes resolve with a map lookup and win over parameterized ones;
+	// everything else (parameters, 405, redirects) goes through the scan below
+	if route := r.static[path][method]; route != nil {
+		r.serveRoute(ctx, route)
+		return
+	}
+
+	// Methods registered for this path, for 405/OPTIONS responses
+	var allowed []string
+
 	for _, route := range r.routes {
-		matched := route.method == method && r.matchPath(route.path, path)
-		if matched {
-			// Extract params
-			r.extractParams(route.path, path, ctx.Params)
-
-			// Apply middleware chain (route-specific then global).
-			// We apply route middleware first so global middleware remains outermost.
-			handler := route.handler
-			for i := len(route.middleware) - 1; i >= 0; i-- {
-				handler = route.middleware[i](handler)
+		if !r.matchPathFold(route.path, path, r.options.CaseInsensitive) {
+			continue
+		}
+		if route.method != method {
+			if !containsString(allowed, route.method) {
+				allowed = append(allowed, route.method)
 			}
-			for i := len(r.middleware) - 1; i >= 0; i-- {
-				handler = r.middleware[i](handler)
+			continue
+		}
+
+		r.extractParams(route.path, path, ctx.Params)
+		r.serveRoute(ctx, route)
+		return
+	}
+
+	// Unrouted requests still pass through global middleware (logging, CORS, request IDs)
+	handler := r.chain(r.unrouted(method, path, allowed), nil)
+	if err := handler(ctx); err != nil {
+		r.handleError(ctx, err)
+	}
+}
+
+// serveRoute runs route's handler chain under r.mu.RLock (see ServeFastHTTP)
+func (r *FastRouter) serveRoute(ctx *FastRequestContext, route *fastRoute) {
+	ctx.routePattern = route.path
+	ctx.handlerName = route.name
+
+	handler := r.chain(route.handler, route)
+	if err := handler(ctx); err != nil {
+		r.handleError(ctx, err)
+	}
+}
+
+// SetErrorHandler sets the handler for errors returned by route handlers and
+// middleware, e.g. to map domain errors to statuses; nil restores DefaultErrorHandler
+func (r *FastRouter) SetErrorHandler(handler ErrorHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errorHandler = handler
+}
+
+// handleError runs under r.mu.RLock (see ServeFastHTTP)
+func (r *FastRouter) handleError(ctx *FastRequestContext, err error) {
+	if r.errorHandler != nil {
+		r.errorHandler(ctx, err)
+		return
+	}
+	DefaultErrorHandler(ctx, err)
+}
+
+// chain wraps handler in global middleware (outermost, in registration order),
+// then the route's group middleware (outer groups first), then the route's own.
+// Composed per request, so middleware registered after a route still applies to it.
+func (r *FastRouter) chain(handler FastRequestHandler, route *fastRoute) FastRequestHandler {
+	if route != nil {
+		for i := len(route.middleware) - 1; i >= 0; i-- {
+			handler = route.middleware[i](handler)
+		}
+		for g := route.group; g != nil; g = g.parent {
+			for i := len(g.middleware) - 1; i >= 0; i-- {
+				handler = g.middleware[i](handler)
 			}
+		}
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	return handler
+}
 
-			// Execute handler
-			if err := handler(ctx); err != nil {
-				ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+// unrouted answers a request no route matched: redirect, 404, 405, or OPTIONS
+func (r *FastRouter) unrouted(method, path string, allowed []string) FastRequestHandler {
+	if len(allowed) == 0 {
+		if location, ok := r.redirectPath(method, path); ok {
+			return func(ctx *FastRequestContext) error {
+				if query := ctx.RequestCtx.URI().QueryString(); len(query) > 0 {
+					location += "?" + string(query)
+				}
+				status := fasthttp.StatusPermanentRedirect
+				if method == fasthttp.MethodGet || method == fasthttp.MethodHead {
+					status = fasthttp.StatusMovedPermanently
+				}
+				ctx.RequestCtx.Response.Header.Set("Location", location)
+				ctx.RequestCtx.SetStatusCode(status)
+				return nil
 			}
-			return
+		}
+
+		// Not found
+		return func(ctx *FastRequestContext) error {
+			ctx.Error("Not Found", fasthttp.StatusNotFound)
+			return nil
+		}
+	}
+
+	if !containsString(allowed, fasthttp.MethodOptions) {
+		allowed = append(allowed, fasthttp.MethodOptions)
+	}
+	allow := strings.Join(allowed, ", ")
+
+	if method == fasthttp.MethodOptions {
+		// Auto-respond to OPTIONS (global middleware such as CORS answers preflights)
+		return func(ctx *FastRequestContext) error {
+			ctx.RequestCtx.Response.Header.Set("Allow", allow)
+			ctx.RequestCtx.SetStatusCode(fasthttp.StatusNoContent)
+			return nil
 		}
 	}
 
-	// Not found
-	ctx.Error("Not Found", fasthttp.StatusNotFound)
+	// Path exists but not for this method (Error resets headers, so set Allow after)
+	return func(ctx *FastRequestContext) error {
+		ctx.Error("Method Not Allowed", fasthttp.StatusMethodNotAllowed)
+		ctx.RequestCtx.Response.Header.Set("Allow", allow)
+		return nil
+	}
 }
 
 func (r *FastRouter) GETFast(path string, handler FastRequestHandler) {
@@ -146,20 +290,94 @@ func (r *FastRouter) RouteFast(method, path string, handler FastRequestHandler)
 
 // RouteFastWith registers a fast handler with per-route middleware.
 func (r *FastRouter) RouteFastWith(method, path string, handler FastRequestHandler, middleware ...FastMiddleware) {
+	r.addRoute(method, path, handler, nil, middleware)
+}
+
+// Override registers a fast handler, replacing any route already registered for
+// the same method and path. Use it for intentional replacement; the other
+// registration methods panic on duplicates.
+func (r *FastRouter) Override(method, path string, handler FastRequestHandler, middleware ...FastMiddleware) {
+	r.registerRoute(method, path, handler, handlerName(handler), nil, middleware, true)
+}
+
+func (r *FastRouter) addRoute(method, path string, handler FastRequestHandler, group *FastRouteGroup, middleware []FastMiddleware) {
+	r.registerRoute(method, path, handler, handlerName(handler), group, middleware, false)
+}
+
+// registerRoute adds a route. A route for the same method and path (parameter
+// names aside, so /users/:id and /users/:uid collide) is replaced if override is
+// set and is a registration error otherwise (fail-fast at startup). name is the
+// handler name reported by FastRequestContext.HandlerName.
+func (r *FastRouter) registerRoute(method, path string, handler FastRequestHandler, name string, group *FastRouteGroup, middleware []FastMiddleware, override bool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.routes = append(r.routes, &fastRoute{
+	route := &fastRoute{
 		method:     method,
 		path:       path,
 		handler:    handler,
+		name:       name,
 		middleware: append([]FastMiddleware(nil), middleware...),
-	})
+		group:      group,
+	}
+
+	shape := routeShape(path)
+	for i, existing := range r.routes {
+		if existing.method != method || routeShape(existing.path) != shape {
+			continue
+		}
+		if !override {
+			panic(fmt.Sprintf("web: duplicate route %s %s conflicts with %s %s (handler %s); use Override to replace it",
+				method, path, existing.method, existing.path, existing.name))
+		}
+		r.routes[i] = route // Keep the original position
+		r.indexStatic(route)
+		return
+	}
+	r.routes = append(r.routes, route)
+	r.indexStatic(route)
+}
+
+// indexStatic adds a route without parameters to the static lookup map
+func (r *FastRouter) indexStatic(route *fastRoute) {
+	if strings.Contains(route.path, "/:") {
+		return
+	}
+	if r.static[route.path] == nil {
+		r.static[route.path] = make(map[string]*fastRoute)
+	}
+	r.static[route.path][route.method] = route
+}
+
+// routeShape blanks parameter names so patterns that match the same paths compare equal
+func routeShape(pattern string) string {
+	parts := strings.Split(pattern, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = ":"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// handlerName returns the handler's function name without its import path,
+// e.g. "main.getUser", or "main.setupRoutes.func1" for a closure. handler is
+// any function type, so adapters can report the handler they wrap.
+func handlerName(handler interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
 }
 
 func (r *FastRouter) Route(method, path string, handler RequestHandler) {
-	// Convert to FastRequestHandler
-	r.RouteFast(method, path, func(ctx *FastRequestContext) error {
+	// Convert to FastRequestHandler, keeping the wrapped handler's name
+	r.registerRoute(method, path, func(ctx *FastRequestContext) error {
 		reqCtx := &RequestContext{
 			BaseRequestContext: core.NewBaseRequestContext(),
 			Request:            nil,
@@ -169,7 +387,7 @@ func (r *FastRouter) Route(method, path string, handler RequestHandler) {
 			Params:             ctx.Params,
 		}
 		return handler(reqCtx)
-	})
+	}, handlerName(handler), nil, nil, false)
 }
 
 func (r *FastRouter) Use(middleware Middleware) {
@@ -194,7 +412,10 @@ func (r *FastRouter) Use(middleware Middleware) {
 	})
 }
 
-// UseFast registers global fasthttp middleware.
+// UseFast registers global fasthttp middleware. It runs for every request
+// routed through ServeFastHTTP, including 404/405/redirect responses, in
+// registration order and before group and per-route middleware. Routes
+// registered before the call are covered too, so handlers need no manual wrapping.
 func (r *FastRouter) UseFast(middleware ...FastMiddleware) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -202,6 +423,11 @@ func (r *FastRouter) UseFast(middleware ...FastMiddleware) {
 }
 
 func (r *FastRouter) matchPath(pattern, path string) bool {
+	return r.matchPathFold(pattern, path, false)
+}
+
+// matchPathFold is matchPath, optionally ignoring case in static segments
+func (r *FastRouter) matchPathFold(pattern, path string, fold bool) bool {
 	patternParts := strings.Split(pattern, "/")
 	pathParts := strings.Split(path, "/")
 
@@ -213,7 +439,7 @@ func (r *FastRouter) matchPath(pattern, path string) bool {
 		if strings.HasPrefix(part, ":") {
 			continue // Parameter
 		}
-		if part != pathParts[i] {
+		if part != pathParts[i] && !(fold && strings.EqualFold(part, pathParts[i])) {
 			return false
 		}
 	}
@@ -221,6 +447,59 @@ func (r *FastRouter) matchPath(pattern, path string) bool {
 	return true
 }
 
+// redirectPath returns the canonical path to redirect an unmatched request to, per options
+func (r *FastRouter) redirectPath(method, reqPath string) (string, bool) {
+	opts := r.options
+	if !opts.RedirectTrailingSlash && !opts.RedirectFixedPath {
+		return "", false
+	}
+
+	candidates := []string{reqPath}
+	if opts.RedirectFixedPath {
+		cleaned := path.Clean("/" + reqPath)
+		if strings.HasSuffix(reqPath, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+		candidates[0] = cleaned
+	}
+	if opts.RedirectTrailingSlash && candidates[0] != "/" {
+		if strings.HasSuffix(candidates[0], "/") {
+			candidates = append(candidates, strings.TrimSuffix(candidates[0], "/"))
+		} else {
+			candidates = append(candidates, candidates[0]+"/")
+		}
+	}
+
+	fold := opts.RedirectFixedPath || opts.CaseInsensitive
+	for _, candidate := range candidates {
+		for _, route := range r.routes {
+			if route.method != method || !r.matchPathFold(route.path, candidate, fold) {
+				continue
+			}
+			location := canonicalPath(route.path, candidate)
+			if opts.CaseInsensitive && !opts.RedirectFixedPath {
+				location = candidate // Case already tolerated; only the slash changes
+			}
+			if location != reqPath {
+				return location, true
+			}
+		}
+	}
+	return "", false
+}
+
+// canonicalPath takes static segments from pattern and parameter values from path
+func canonicalPath(pattern, path string) string {
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(path, "/")
+	for i, part := range patternParts {
+		if !strings.HasPrefix(part, ":") {
+			pathParts[i] = part
+		}
+	}
+	return strings.Join(pathParts, "/")
+}
+
 func (r *FastRouter) extractParams(pattern, path string, params map[string]string) {
 	patternParts := strings.Split(pattern, "/")
 	pathParts := strings.Split(path, "/")
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+// BenchmarkFastRouter_StaticHeavy routes against a table of 100 static routes
+// and 20 parameterized ones: a static route registered last (the worst case for
+// a linear scan), and a parameterized route, which still scans.
+func BenchmarkFastRouter_StaticHeavy(b *testing.B) {
+	router := NewFastRouter()
+	noop := func(ctx *FastRequestContext) error { return nil }
+	for i := 0; i < 20; i++ {
+		router.GETFast(fmt.Sprintf("/api/v1/resource%d/:id", i), noop)
+	}
+	for i := 0; i < 100; i++ {
+		router.GETFast(fmt.Sprintf("/api/v1/static%d", i), noop)
+	}
+	router.GETFast("/health", noop)
+
+	for _, path := range []string{"/health", "/api/v1/resource19/42"} {
+		b.Run(path, func(b *testing.B) {
+			reqCtx := &fasthttp.RequestCtx{}
+			reqCtx.Request.Header.SetMethod("GET")
+			reqCtx.Request.SetRequestURI(path)
+			ctx := &FastRequestContext{RequestCtx: reqCtx, Params: make(map[string]string)}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				router.ServeFastHTTP(ctx)
+			}
+		})
+	}
+}
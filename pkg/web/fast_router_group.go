@@ -0,0 +1,87 @@
+package web
+
+import "strings"
+
+// FastRouteGroup registers routes under a shared path prefix and middleware.
+// Group middleware runs after the router's global middleware and before
+// per-route middleware; nested groups run outer to inner.
+type FastRouteGroup struct {
+	router     *FastRouter
+	parent     *FastRouteGroup
+	prefix     string
+	middleware []FastMiddleware
+}
+
+// Group creates a route group under prefix, e.g. "/api"
+func (r *FastRouter) Group(prefix string, middleware ...FastMiddleware) *FastRouteGroup {
+	return &FastRouteGroup{
+		router:     r,
+		prefix:     strings.TrimSuffix(prefix, "/"),
+		middleware: append([]FastMiddleware(nil), middleware...),
+	}
+}
+
+// Group creates a nested group; its prefix and middleware add to this group's
+func (g *FastRouteGroup) Group(prefix string, middleware ...FastMiddleware) *FastRouteGroup {
+	return &FastRouteGroup{
+		router:     g.router,
+		parent:     g,
+		prefix:     g.prefix + strings.TrimSuffix(prefix, "/"),
+		middleware: append([]FastMiddleware(nil), middleware...),
+	}
+}
+
+// UseFast adds group middleware; like FastRouter.UseFast it also covers routes already registered
+func (g *FastRouteGroup) UseFast(middleware ...FastMiddleware) {
+	g.router.mu.Lock()
+	defer g.router.mu.Unlock()
+	g.middleware = append(g.middleware, middleware...)
+}
+
+func (g *FastRouteGroup) GETFast(path string, handler FastRequestHandler) {
+	g.RouteFast("GET", path, handler)
+}
+
+func (g *FastRouteGroup) POSTFast(path string, handler FastRequestHandler) {
+	g.RouteFast("POST", path, handler)
+}
+
+func (g *FastRouteGroup) PUTFast(path string, handler FastRequestHandler) {
+	g.RouteFast("PUT", path, handler)
+}
+
+func (g *FastRouteGroup) DELETEFast(path string, handler FastRequestHandler) {
+	g.RouteFast("DELETE", path, handler)
+}
+
+func (g *FastRouteGroup) PATCHFast(path string, handler FastRequestHandler) {
+	g.RouteFast("PATCH", path, handler)
+}
+
+// RouteFast registers a fast handler under the group prefix
+func (g *FastRouteGroup) RouteFast(method, path string, handler FastRequestHandler) {
+	g.RouteFastWith(method, path, handler)
+}
+
+// RouteFastWith registers a fast handler under the group prefix with per-route middleware
+func (g *FastRouteGroup) RouteFastWith(method, path string, handler FastRequestHandler, middleware ...FastMiddleware) {
+	g.router.addRoute(method, g.fullPath(path), handler, g, middleware)
+}
+
+// Override registers a fast handler under the group prefix, replacing any route
+// already registered for the same method and path
+func (g *FastRouteGroup) Override(method, path string, handler FastRequestHandler, middleware ...FastMiddleware) {
+	g.router.registerRoute(method, g.fullPath(path), handler, handlerName(handler), g, middleware, true)
+}
+
+// fullPath returns path under the group prefix
+func (g *FastRouteGroup) fullPath(path string) string {
+	full := g.prefix + path
+	if path == "/" || path == "" {
+		full = g.prefix
+	}
+	if full == "" {
+		full = "/"
+	}
+	return full
+}
@@ -0,0 +1,400 @@
+package web
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+)
+
+// newTestFastContext creates a FastRequestContext for the given method and path
+func newTestFastContext(gocmd core.GoCMD, method, path string) *FastRequestContext {
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod(method)
+	reqCtx.Request.SetRequestURI(path)
+
+	return &FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         reqCtx,
+		GoCMD:              gocmd,
+		EventBus:           gocmd.EventBus(),
+		Params:             make(map[string]string),
+	}
+}
+
+func TestFastRouter_RoutePattern(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+
+	var seenByMiddleware string
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			seenByMiddleware = ctx.RoutePattern()
+			return next(ctx)
+		}
+	})
+
+	var seenByHandler string
+	router.GETFast("/api/users/:id", func(ctx *FastRequestContext) error {
+		seenByHandler = ctx.RoutePattern()
+		return ctx.Text(200, ctx.Param("id"))
+	})
+
+	ctx := newTestFastContext(gocmd, "GET", "/api/users/42")
+	router.ServeFastHTTP(ctx)
+
+	if seenByMiddleware != "/api/users/:id" {
+		t.Errorf("middleware RoutePattern() = %q, want /api/users/:id", seenByMiddleware)
+	}
+	if seenByHandler != "/api/users/:id" {
+		t.Errorf("handler RoutePattern() = %q, want /api/users/:id", seenByHandler)
+	}
+	if ctx.Param("id") != "42" {
+		t.Errorf("Param(id) = %q, want 42", ctx.Param("id"))
+	}
+}
+
+func getUserForTest(ctx *FastRequestContext) error {
+	return ctx.Text(200, ctx.HandlerName())
+}
+
+func listOrdersForTest(ctx *RequestContext) error { return nil }
+
+func TestFastRouter_HandlerName(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	var seenByMiddleware string
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			seenByMiddleware = ctx.HandlerName()
+			return next(ctx)
+		}
+	})
+	router.GETFast("/api/users/:id", getUserForTest)
+
+	ctx := newTestFastContext(gocmd, "GET", "/api/users/42")
+	router.ServeFastHTTP(ctx)
+
+	if seenByMiddleware != "web.getUserForTest" {
+		t.Errorf("middleware HandlerName() = %q, want web.getUserForTest", seenByMiddleware)
+	}
+	if body := string(ctx.RequestCtx.Response.Body()); body != "web.getUserForTest" {
+		t.Errorf("handler HandlerName() = %q, want web.getUserForTest", body)
+	}
+
+	// Route reports the RequestHandler it adapts, not the adapter closure
+	router.Route("GET", "/api/orders", listOrdersForTest)
+	ctx = newTestFastContext(gocmd, "GET", "/api/orders")
+	router.ServeFastHTTP(ctx)
+	if seenByMiddleware != "web.listOrdersForTest" {
+		t.Errorf("Route HandlerName() = %q, want web.listOrdersForTest", seenByMiddleware)
+	}
+
+	ctx = newTestFastContext(gocmd, "GET", "/missing")
+	router.ServeFastHTTP(ctx)
+	if ctx.HandlerName() != "" {
+		t.Errorf("HandlerName() = %q, want empty for unmatched request", ctx.HandlerName())
+	}
+}
+
+func TestFastRouter_RoutePatternNotFound(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	router.GETFast("/health", func(ctx *FastRequestContext) error {
+		return ctx.Text(200, "ok")
+	})
+
+	ctx := newTestFastContext(gocmd, "GET", "/missing")
+	router.ServeFastHTTP(ctx)
+
+	if ctx.RoutePattern() != "" {
+		t.Errorf("RoutePattern() = %q, want empty for unmatched request", ctx.RoutePattern())
+	}
+	if ctx.RequestCtx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Errorf("status = %d, want 404", ctx.RequestCtx.Response.StatusCode())
+	}
+}
+
+func TestFastRouter_MethodNotAllowed(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	noop := func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") }
+	router.GETFast("/items/:id", noop)
+	router.PUTFast("/items/:id", noop)
+
+	// Known path, wrong method -> 405 with Allow
+	ctx := newTestFastContext(gocmd, "DELETE", "/items/1")
+	router.ServeFastHTTP(ctx)
+	if got := ctx.RequestCtx.Response.StatusCode(); got != 405 {
+		t.Errorf("DELETE status = %d, want 405", got)
+	}
+	if got := string(ctx.RequestCtx.Response.Header.Peek("Allow")); got != "GET, PUT, OPTIONS" {
+		t.Errorf("Allow = %q, want \"GET, PUT, OPTIONS\"", got)
+	}
+
+	// Unknown path -> 404
+	ctx = newTestFastContext(gocmd, "GET", "/missing")
+	router.ServeFastHTTP(ctx)
+	if got := ctx.RequestCtx.Response.StatusCode(); got != 404 {
+		t.Errorf("unknown path status = %d, want 404", got)
+	}
+}
+
+func TestFastRouter_AutoOptions(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	var middlewareRan bool
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			middlewareRan = true
+			return next(ctx)
+		}
+	})
+	router.POSTFast("/items", func(ctx *FastRequestContext) error { return nil })
+
+	ctx := newTestFastContext(gocmd, "OPTIONS", "/items")
+	router.ServeFastHTTP(ctx)
+
+	if got := ctx.RequestCtx.Response.StatusCode(); got != 204 {
+		t.Errorf("OPTIONS status = %d, want 204", got)
+	}
+	if got := string(ctx.RequestCtx.Response.Header.Peek("Allow")); got != "POST, OPTIONS" {
+		t.Errorf("Allow = %q, want \"POST, OPTIONS\"", got)
+	}
+	if !middlewareRan {
+		t.Error("global middleware should run for OPTIONS (e.g. CORS preflight)")
+	}
+}
+
+func TestFastRouter_PathNormalization(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	handler := func(ctx *FastRequestContext) error { return ctx.Text(200, "id="+ctx.Param("id")) }
+	newRouter := func(options FastRouterOptions) *FastRouter {
+		router := NewFastRouterWithOptions(options)
+		router.GETFast("/api/users", handler)
+		router.GETFast("/api/users/:id/posts", handler)
+		router.POSTFast("/api/items/", handler)
+		return router
+	}
+
+	tests := []struct {
+		name     string
+		options  FastRouterOptions
+		method   string
+		path     string
+		status   int
+		location string
+	}{
+		{"default is exact", FastRouterOptions{}, "GET", "/api/users/", 404, ""},
+		{"default is case sensitive", FastRouterOptions{}, "GET", "/API/Users", 404, ""},
+		{"strip trailing slash", FastRouterOptions{RedirectTrailingSlash: true}, "GET", "/api/users/?page=2", 301, "/api/users?page=2"},
+		{"add trailing slash keeps method", FastRouterOptions{RedirectTrailingSlash: true}, "POST", "/api/items", 308, "/api/items/"},
+		{"no route for method", FastRouterOptions{RedirectTrailingSlash: true}, "DELETE", "/api/users/", 404, ""},
+		{"fixed path", FastRouterOptions{RedirectFixedPath: true}, "GET", "/API//Users/../users/42/posts", 301, "/api/users/42/posts"},
+		{"fixed path and slash", FastRouterOptions{RedirectFixedPath: true, RedirectTrailingSlash: true}, "GET", "/Api/Users/", 301, "/api/users"},
+		{"case insensitive serves", FastRouterOptions{CaseInsensitive: true}, "GET", "/API/Users/Ab/Posts", 200, ""},
+		{"case insensitive with slash", FastRouterOptions{CaseInsensitive: true, RedirectTrailingSlash: true}, "GET", "/API/Users/", 301, "/API/Users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestFastContext(gocmd, tt.method, tt.path)
+			newRouter(tt.options).ServeFastHTTP(ctx)
+
+			if got := ctx.RequestCtx.Response.StatusCode(); got != tt.status {
+				t.Fatalf("status = %d, want %d", got, tt.status)
+			}
+			if got := string(ctx.RequestCtx.Response.Header.Peek("Location")); got != tt.location {
+				t.Errorf("Location = %q, want %q", got, tt.location)
+			}
+			if tt.status == 200 {
+				if body := string(ctx.RequestCtx.Response.Body()); body != "id=Ab" {
+					t.Errorf("body = %q, want param value with its original case", body)
+				}
+			}
+		})
+	}
+}
+
+func TestFastRouter_MiddlewareOrder(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	var calls []string
+	record := func(name string) FastMiddleware {
+		return func(next FastRequestHandler) FastRequestHandler {
+			return func(ctx *FastRequestContext) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+	handler := func(ctx *FastRequestContext) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+
+	router := NewFastRouter()
+	router.UseFast(record("global1"))
+	api := router.Group("/api", record("api"))
+	v1 := api.Group("/v1", record("v1"))
+	v1.RouteFastWith("GET", "/users", handler, record("route"))
+	router.GETFast("/plain", handler)
+
+	// Registered after the routes, still applied to them
+	router.UseFast(record("global2"))
+	api.UseFast(record("api2"))
+
+	cases := []struct {
+		path string
+		want []string
+	}{
+		{"/api/v1/users", []string{"global1", "global2", "api", "api2", "v1", "route", "handler"}},
+		{"/plain", []string{"global1", "global2", "handler"}},
+		{"/missing", []string{"global1", "global2"}},
+	}
+	for _, tc := range cases {
+		calls = nil
+		router.ServeFastHTTP(newTestFastContext(gocmd, "GET", tc.path))
+		if strings.Join(calls, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%s calls = %v, want %v", tc.path, calls, tc.want)
+		}
+	}
+}
+
+func TestFastRouter_UnroutedThroughGlobalMiddleware(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouterWithOptions(FastRouterOptions{RedirectTrailingSlash: true})
+	seen := 0
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			seen++
+			return next(ctx)
+		}
+	})
+	router.GETFast("/users", func(ctx *FastRequestContext) error { return nil })
+
+	cases := []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/missing", fasthttp.StatusNotFound},
+		{"POST", "/users", fasthttp.StatusMethodNotAllowed},
+		{"GET", "/users/", fasthttp.StatusMovedPermanently},
+	}
+	for _, tc := range cases {
+		seen = 0
+		ctx := newTestFastContext(gocmd, tc.method, tc.path)
+		router.ServeFastHTTP(ctx)
+		if got := ctx.RequestCtx.Response.StatusCode(); got != tc.status {
+			t.Errorf("%s %s status = %d, want %d", tc.method, tc.path, got, tc.status)
+		}
+		if seen != 1 {
+			t.Errorf("%s %s did not run global middleware", tc.method, tc.path)
+		}
+	}
+}
+
+func TestFastRouteGroup_Paths(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	api := router.Group("/api/")
+	api.GETFast("/", func(ctx *FastRequestContext) error { return ctx.JSON(200, "root") })
+	api.Group("/todos").GETFast("/:id", func(ctx *FastRequestContext) error { return ctx.JSON(200, ctx.Param("id")) })
+
+	for path, want := range map[string]string{"/api": `"root"`, "/api/todos/7": `"7"`} {
+		ctx := newTestFastContext(gocmd, "GET", path)
+		router.ServeFastHTTP(ctx)
+		if body := string(ctx.RequestCtx.Response.Body()); body != want {
+			t.Errorf("%s body = %s, want %s", path, body, want)
+		}
+	}
+}
+
+func TestFastRouter_DuplicateRoutePanics(t *testing.T) {
+	router := NewFastRouter()
+	noop := func(ctx *FastRequestContext) error { return nil }
+	router.GETFast("/api/users/:id", noop)
+	router.POSTFast("/api/users/:id", noop) // Other method is fine
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "GET /api/users/:uid") || !strings.Contains(msg, "GET /api/users/:id") {
+			t.Errorf("panic = %q, want both conflicting routes named", msg)
+		}
+	}()
+	// Registered on a group, with a different parameter name: still the same route
+	router.Group("/api").GETFast("/users/:uid", noop)
+}
+
+func TestFastRouter_Override(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	router.GETFast("/health", func(ctx *FastRequestContext) error { return ctx.Text(200, "old") })
+	router.Override("GET", "/health", func(ctx *FastRequestContext) error { return ctx.Text(200, "new") })
+	router.Group("/api").Override("GET", "/ping", func(ctx *FastRequestContext) error { return ctx.Text(200, "pong") })
+
+	ctx := newTestFastContext(gocmd, "GET", "/health")
+	router.ServeFastHTTP(ctx)
+	if body := string(ctx.RequestCtx.Response.Body()); body != "new" {
+		t.Errorf("body = %q, want new", body)
+	}
+
+	ctx = newTestFastContext(gocmd, "GET", "/api/ping")
+	router.ServeFastHTTP(ctx)
+	if body := string(ctx.RequestCtx.Response.Body()); body != "pong" {
+		t.Errorf("body = %q, want pong", body)
+	}
+}
+
+func TestFastRouter_StaticRoutesFirst(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	router.GETFast("/users/:id", func(ctx *FastRequestContext) error { return ctx.Text(200, "user "+ctx.Param("id")) })
+	router.GETFast("/users/me", func(ctx *FastRequestContext) error { return ctx.Text(200, "me") })
+
+	tests := []struct {
+		method, path, body string
+		pattern            string
+		status             int
+	}{
+		// The static route wins even though the parameterized one was registered first
+		{"GET", "/users/me", "me", "/users/me", 200},
+		{"GET", "/users/42", "user 42", "/users/:id", 200},
+		{"POST", "/users/me", "Method Not Allowed", "", 405},
+	}
+	for _, tt := range tests {
+		ctx := newTestFastContext(gocmd, tt.method, tt.path)
+		router.ServeFastHTTP(ctx)
+		if status := ctx.RequestCtx.Response.StatusCode(); status != tt.status {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, status, tt.status)
+		}
+		if body := string(ctx.RequestCtx.Response.Body()); body != tt.body {
+			t.Errorf("%s %s body = %q, want %q", tt.method, tt.path, body, tt.body)
+		}
+		if ctx.RoutePattern() != tt.pattern {
+			t.Errorf("%s %s RoutePattern() = %q, want %q", tt.method, tt.path, ctx.RoutePattern(), tt.pattern)
+		}
+	}
+}
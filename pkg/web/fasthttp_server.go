@@ -2,7 +2,12 @@ package web
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,10 +35,30 @@ type FastHTTPServer struct {
 	totalRequests      int64 // Atomic counter for total requests
 	successfulRequests int64 // Atomic counter for successful requests (200-299)
 	errorRequests      int64 // Atomic counter for error requests (500-599)
+	inFlightRequests   int64 // Atomic counter for requests being handled (not queued)
+	stopping           int32 // Set atomically once the GoCMD's shutdown reached PhaseStopAccepting
 	// Backpressure controller for CCU-based limiting
 	backpressure *BackpressureController
+	loadLevel    int32 // Atomic LoadLevel last announced on BackpressureAddress
+	// Paths that bypass backpressure (health checks, metrics)
+	exemptPaths    map[string]struct{}
+	exemptPrefixes []string
+
+	requestIDHeaders []string     // inbound headers an existing request ID is adopted from
+	panicHandler     PanicHandler // nil: DefaultPanicHandler
 	// Start workers once (constructor and Start() might both call it).
 	startWorkersOnce sync.Once
+	// Optional HTTPS (see tls.go)
+	tlsSettings   *TLSConfig
+	tlsConfig     *tls.Config
+	certs         *certReloader
+	stopCertWatch context.CancelFunc
+	// OnMetrics callbacks run until stopMetrics (see metrics_watch.go)
+	metricsCtx  context.Context
+	stopMetrics context.CancelFunc
+	// Optional HTTP/2 listener (see http2.go)
+	http2         *http.Server
+	http2Listener net.Listener
 }
 
 // FastHTTPServerConfig configures the fasthttp server
@@ -46,6 +71,26 @@ type FastHTTPServerConfig struct {
 	MaxConns        int
 	ReadBufferSize  int
 	WriteBufferSize int
+	// Graceful degradation thresholds (percent of normal capacity).
+	// Zero uses DefaultDegradedUtilization / DefaultCriticalUtilization.
+	DegradedUtilization float64
+	CriticalUtilization float64
+	// BackpressureExemptPaths skip CCU backpressure so probes still answer while shedding load.
+	// Exact paths ("/health") or prefixes ending in "*" ("/metrics/*").
+	BackpressureExemptPaths []string
+	// HTTP2Addr, if set, serves HTTP/2 on a second address through the same router
+	// and backpressure (fasthttp itself only speaks HTTP/1.1). Cleartext h2c with
+	// prior knowledge unless HTTP2TLS is set; HTTP/1.1 is also accepted there.
+	HTTP2Addr string
+	HTTP2TLS  *tls.Config // Serves HTTP/2 over TLS (ALPN "h2") on HTTP2Addr; defaults to TLS when set
+	// TLS, if set, serves HTTPS instead of HTTP on Addr
+	TLS *TLSConfig
+	// RequestIDHeaders are checked in order for an inbound request ID to adopt
+	// (default: X-Request-ID). "traceparent" contributes its trace ID. Without one,
+	// core.GenerateRequestID is used. The ID is always returned in X-Request-ID.
+	RequestIDHeaders []string
+	// PanicHandler writes the response when a handler panics (default: DefaultPanicHandler)
+	PanicHandler PanicHandler
 }
 
 // DefaultFastHTTPServerConfig returns default configuration for 100k RPS
@@ -191,6 +236,28 @@ func NewFastHTTPServer(gocmd core.GoCMD, config *FastHTTPServerConfig) *FastHTTP
 		},
 	}
 
+	s.backpressure.SetDegradationThresholds(config.DegradedUtilization, config.CriticalUtilization)
+	s.exemptPaths, s.exemptPrefixes = compileExemptPaths(config.BackpressureExemptPaths)
+	s.requestIDHeaders = config.RequestIDHeaders
+	s.panicHandler = config.PanicHandler
+	s.metricsCtx, s.stopMetrics = context.WithCancel(gocmdCtx)
+	gocmd.OnShutdownPhase(core.PhaseStopAccepting, s.stopAccepting)
+	if len(s.requestIDHeaders) == 0 {
+		s.requestIDHeaders = []string{"X-Request-ID"}
+	}
+	if config.TLS != nil {
+		s.tlsSettings = config.TLS
+		s.tlsConfig, s.certs = newServerTLS(config.TLS)
+		s.server.TLSConfig = s.tlsConfig
+	}
+	if config.HTTP2Addr != "" {
+		http2TLS := config.HTTP2TLS
+		if http2TLS == nil {
+			http2TLS = s.tlsConfig
+		}
+		s.http2 = s.newHTTP2Server(config.HTTP2Addr, http2TLS)
+	}
+
 	// Wire BaseServer hooks (template method pattern).
 	s.BaseServer.SetHooks(s.doStart, s.doStop)
 
@@ -211,20 +278,47 @@ func (s *FastHTTPServer) doStart() error {
 	}
 	// Start request processing workers using Executor (hides goroutine creation)
 	s.startRequestWorkers()
+	if s.tlsConfig != nil {
+		if err := s.startTLS(); err != nil {
+			s.Logger().Error(err.Error())
+			return err
+		}
+	}
+	if s.http2 != nil {
+		if err := s.startHTTP2(); err != nil {
+			s.Logger().Error(err.Error())
+			return err
+		}
+	}
 	s.Logger().Info(fmt.Sprintf("Starting FastHTTP server on %s", s.addr))
 	// Start listening (blocking call)
-	err := s.server.ListenAndServe(s.addr)
+	var err error
+	if s.tlsConfig != nil {
+		// Certificates come from TLSConfig.GetCertificate
+		err = s.server.ListenAndServeTLS(s.addr, "", "")
+	} else {
+		err = s.server.ListenAndServe(s.addr)
+	}
 	if err != nil {
 		s.Logger().Error(fmt.Sprintf("FastHTTP server error: %v", err))
 	}
 	return err
 }
 
+// stopAccepting makes new requests fail with 503 and close their connection;
+// the BaseServer stops the server once in-flight requests are done
+func (s *FastHTTPServer) stopAccepting(ctx context.Context) error {
+	atomic.StoreInt32(&s.stopping, 1)
+	return nil
+}
+
 // doStop is called by BaseServer.Stop() - implements hook method
 func (s *FastHTTPServer) doStop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	s.stopMetrics()
+
 	// Close request mailbox (hides channel close)
 	s.requestMailbox.Close()
 
@@ -233,6 +327,15 @@ func (s *FastHTTPServer) doStop() error {
 		return err
 	}
 
+	if s.stopCertWatch != nil {
+		s.stopCertWatch()
+	}
+	if s.http2 != nil {
+		if err := s.http2.Shutdown(ctx); err != nil {
+			// Best-effort; ignore on error.
+		}
+	}
+
 	// Shutdown server
 	return s.server.ShutdownWithContext(ctx)
 }
@@ -247,6 +350,11 @@ func (s *FastHTTPServer) FastRouter() *FastRouter {
 	return s.router
 }
 
+// Backpressure returns the server's backpressure controller
+func (s *FastHTTPServer) Backpressure() *BackpressureController {
+	return s.backpressure
+}
+
 // Metrics returns current server metrics
 func (s *FastHTTPServer) Metrics() ServerMetrics {
 	bpMetrics := s.backpressure.GetMetrics()
@@ -257,33 +365,61 @@ func (s *FastHTTPServer) Metrics() ServerMetrics {
 		queueUtil = 100.0
 	}
 	return ServerMetrics{
-		QueuedRequests:     queued,
-		RejectedRequests:   atomic.LoadInt64(&s.rejectedRequests),
-		QueueCapacity:      s.maxQueue,
-		Workers:            s.workers,
-		QueueUtilization:   queueUtil,
-		NormalCCU:          normalCapacity, // Normal capacity (target utilization, e.g., 67%)
-		CurrentCCU:         int(bpMetrics.CurrentLoad),
-		CCUUtilization:     bpMetrics.Utilization, // Utilization relative to normal capacity
-		TotalRequests:      atomic.LoadInt64(&s.totalRequests),
-		SuccessfulRequests: atomic.LoadInt64(&s.successfulRequests),
-		ErrorRequests:      atomic.LoadInt64(&s.errorRequests),
+		QueuedRequests:       queued,
+		RejectedRequests:     atomic.LoadInt64(&s.rejectedRequests),
+		RejectedLastInterval: bpMetrics.RejectedLastInterval,
+		RejectionRate:        bpMetrics.RejectionRate,
+		QueueCapacity:        s.maxQueue,
+		Workers:              s.workers,
+		QueueUtilization:     queueUtil,
+		NormalCCU:            normalCapacity, // Normal capacity (target utilization, e.g., 67%)
+		CurrentCCU:           int(bpMetrics.CurrentLoad),
+		CCUUtilization:       bpMetrics.Utilization, // Utilization relative to normal capacity
+		TotalRequests:        atomic.LoadInt64(&s.totalRequests),
+		SuccessfulRequests:   atomic.LoadInt64(&s.successfulRequests),
+		ErrorRequests:        atomic.LoadInt64(&s.errorRequests),
+		InFlightRequests:     atomic.LoadInt64(&s.inFlightRequests),
 	}
 }
 
+// InFlight returns the number of requests currently being handled.
+// Queued requests are not counted until a worker picks them up.
+func (s *FastHTTPServer) InFlight() int64 {
+	return atomic.LoadInt64(&s.inFlightRequests)
+}
+
+// WaitForDrain blocks until no requests are in flight or ctx is done.
+// For rolling deploys: mark the instance not ready, wait for the load balancer
+// to stop routing to it, WaitForDrain, then Stop.
+func (s *FastHTTPServer) WaitForDrain(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.InFlight() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
 // ServerMetrics provides server performance metrics
 type ServerMetrics struct {
-	QueuedRequests     int64   // Current queued requests
-	RejectedRequests   int64   // Total rejected requests (503)
-	QueueCapacity      int     // Maximum queue capacity
-	Workers            int     // Number of worker goroutines
-	QueueUtilization   float64 // Queue utilization percentage
-	NormalCCU          int     // Normal CCU capacity (target utilization, e.g., 67%)
-	CurrentCCU         int     // Current CCU load
-	CCUUtilization     float64 // CCU utilization percentage (relative to normal capacity)
-	TotalRequests      int64   // Total requests processed (successful + rejected)
-	SuccessfulRequests int64   // Total successful requests (200-299)
-	ErrorRequests      int64   // Total error requests (500-599)
+	QueuedRequests       int64   // Current queued requests
+	RejectedRequests     int64   // Total rejected requests (503)
+	RejectedLastInterval int64   // Backpressure rejections in the last completed reset interval
+	RejectionRate        float64 // Backpressure rejections per second over that interval
+	QueueCapacity        int     // Maximum queue capacity
+	Workers              int     // Number of worker goroutines
+	QueueUtilization     float64 // Queue utilization percentage
+	NormalCCU            int     // Normal CCU capacity (target utilization, e.g., 67%)
+	CurrentCCU           int     // Current CCU load
+	CCUUtilization       float64 // CCU utilization percentage (relative to normal capacity)
+	TotalRequests        int64   // Total requests processed (successful + rejected)
+	SuccessfulRequests   int64   // Total successful requests (200-299)
+	ErrorRequests        int64   // Total error requests (500-599)
+	InFlightRequests     int64   // Requests currently being handled (not queued)
 }
 
 // handleRequest is the main request handler - non-blocking, queues to workers
@@ -304,11 +440,23 @@ func (s *FastHTTPServer) handleRequest(ctx *fasthttp.RequestCtx) {
 	default:
 		// Context is still active
 	}
+	if atomic.LoadInt32(&s.stopping) == 1 {
+		// Shutting down: in-flight requests finish, new ones go elsewhere
+		ctx.Error("Service Unavailable", fasthttp.StatusServiceUnavailable)
+		ctx.SetConnectionClose() // After Error, which resets the headers
+		return
+	}
 
 	// Step 1: Check backpressure controller (normal capacity limiting)
 	// Normal capacity = target utilization (e.g., 67% of max)
 	// This ensures system operates at target utilization under normal load
-	if !s.backpressure.TryAcquire() {
+	// Exempt paths (e.g., /health) neither acquire nor count against capacity
+	exempt := s.isBackpressureExempt(path)
+	acquired := exempt || s.backpressure.TryAcquire()
+	if !exempt {
+		s.reportLoadLevel()
+	}
+	if !acquired {
 		// Fail-fast: Normal capacity exceeded, reject immediately
 		// This maintains target utilization (e.g., 67%) under normal conditions
 		s.Logger().Info(fmt.Sprintf("backpressure: capacity exceeded for %s %s", method, path))
@@ -325,7 +473,9 @@ func (s *FastHTTPServer) handleRequest(ctx *fasthttp.RequestCtx) {
 	// fasthttp requires the handler to complete before sending response
 	// We still use backpressure for rate limiting, but process in same goroutine
 	// Use defer to ensure backpressure is always released, even on panic
-	defer s.backpressure.Release()
+	if !exempt {
+		defer s.backpressure.Release()
+	}
 
 	// Process with panic recovery to ensure backpressure is released
 	defer func() {
@@ -333,7 +483,7 @@ func (s *FastHTTPServer) handleRequest(ctx *fasthttp.RequestCtx) {
 			// Handler panic: return 500 error instead of crashing
 			ctx.Error("Internal Server Error", fasthttp.StatusInternalServerError)
 			ctx.SetContentType("application/json")
-			requestID := string(ctx.Request.Header.Peek("X-Request-ID"))
+			requestID := inboundRequestID(&ctx.Request.Header, s.requestIDHeaders)
 			if requestID == "" {
 				requestID = "unknown"
 			}
@@ -347,6 +497,75 @@ func (s *FastHTTPServer) handleRequest(ctx *fasthttp.RequestCtx) {
 	s.processRequest(ctx)
 }
 
+// serveRecovering routes the request and answers a handler panic with the panic
+// handler, which sees the matched route and request ID. The recovers around
+// processRequest remain as a last resort, e.g. for a panicking panic handler.
+func (s *FastHTTPServer) serveRecovering(ctx *FastRequestContext) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		s.Logger().Error(fmt.Sprintf("handler panic (request_id=%s, route=%s, handler=%s): %v\n%s",
+			ctx.RequestID(), ctx.RoutePattern(), ctx.HandlerName(), r, debug.Stack()))
+		handler := s.panicHandler
+		if handler == nil {
+			handler = DefaultPanicHandler
+		}
+		ctx.RequestCtx.Response.ResetBody()
+		handler(ctx, r)
+	}()
+	s.router.ServeFastHTTP(ctx)
+}
+
+// reportLoadLevel publishes BackpressureAddress when the load level has changed
+func (s *FastHTTPServer) reportLoadLevel() {
+	level := s.backpressure.Level()
+	previous := LoadLevel(atomic.LoadInt32(&s.loadLevel))
+	if level == previous || !atomic.CompareAndSwapInt32(&s.loadLevel, int32(previous), int32(level)) {
+		return
+	}
+	bus := s.EventBus()
+	if bus == nil {
+		return
+	}
+	metrics := s.backpressure.GetMetrics()
+	_ = core.PublishLocal(bus, BackpressureAddress, map[string]interface{}{
+		"server":        s.addr,
+		"level":         level.String(),
+		"previousLevel": previous.String(),
+		"utilization":   metrics.Utilization,
+		"rejected":      metrics.RejectedCount,
+	})
+}
+
+// isBackpressureExempt reports whether path bypasses backpressure
+func (s *FastHTTPServer) isBackpressureExempt(path string) bool {
+	if _, ok := s.exemptPaths[path]; ok {
+		return true
+	}
+	for _, prefix := range s.exemptPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileExemptPaths splits BackpressureExemptPaths into exact paths and prefixes
+func compileExemptPaths(paths []string) (map[string]struct{}, []string) {
+	exact := make(map[string]struct{}, len(paths))
+	var prefixes []string
+	for _, p := range paths {
+		if strings.HasSuffix(p, "*") {
+			prefixes = append(prefixes, strings.TrimSuffix(p, "*"))
+			continue
+		}
+		exact[p] = struct{}{}
+	}
+	return exact, prefixes
+}
+
 // SetHandler sets the request handler
 func (s *FastHTTPServer) SetHandler(handler func(*fasthttp.RequestCtx)) {
 	s.server.Handler = handler
@@ -419,7 +638,7 @@ func (s *FastHTTPServer) processRequestFromMailbox(ctx context.Context) error {
 					reqCtx.Error("Internal Server Error", fasthttp.StatusInternalServerError)
 					reqCtx.SetContentType("application/json")
 					// Extract request ID from header if available
-					requestID := string(reqCtx.Request.Header.Peek("X-Request-ID"))
+					requestID := inboundRequestID(&reqCtx.Request.Header, s.requestIDHeaders)
 					if requestID == "" {
 						requestID = "unknown"
 					}
@@ -448,8 +667,12 @@ func (s *FastHTTPServer) processRequest(ctx *fasthttp.RequestCtx) {
 		panic("router cannot be nil")
 	}
 
+	// Counted until the handler returns, including on panic
+	atomic.AddInt64(&s.inFlightRequests, 1)
+	defer atomic.AddInt64(&s.inFlightRequests, -1)
+
 	// Generate or extract request ID from headers
-	requestID := string(ctx.Request.Header.Peek("X-Request-ID"))
+	requestID := inboundRequestID(&ctx.Request.Header, s.requestIDHeaders)
 	if requestID == "" {
 		requestID = core.GenerateRequestID()
 	}
@@ -466,6 +689,12 @@ func (s *FastHTTPServer) processRequest(ctx *fasthttp.RequestCtx) {
 		EventBus:           s.EventBus(),
 		Params:             make(map[string]string),
 		requestID:          requestID,
+		loadLevel:          s.backpressure.Level(),
+	}
+
+	// Messages the handler sends carry the request ID to consumers and back
+	if reqCtx.EventBus != nil {
+		reqCtx.EventBus = core.NewRequestIDEventBus(reqCtx.EventBus, requestID)
 	}
 
 	// Set request ID in response header for tracing
@@ -475,7 +704,7 @@ func (s *FastHTTPServer) processRequest(ctx *fasthttp.RequestCtx) {
 	atomic.AddInt64(&s.totalRequests, 1)
 
 	// Route request - errors are propagated immediately (fail-fast)
-	s.router.ServeFastHTTP(reqCtx)
+	s.serveRecovering(reqCtx)
 
 	// Track response status
 	statusCode := ctx.Response.StatusCode()
@@ -499,13 +728,19 @@ type FastRequestContext struct {
 	*core.BaseRequestContext // Embed base context for data storage
 	RequestCtx               *fasthttp.RequestCtx
 	GoCMD                    core.GoCMD
-	EventBus                 core.EventBus
+	EventBus                 core.EventBus // Scoped to the request ID (core.RequestIDEventBus) when served by FastHTTPServer
 	Params                   map[string]string
 	requestID                string // Request ID for tracing
+	routePattern             string // Matched route template (e.g. /api/users/:id)
+	handlerName              string // Matched route's handler function name
+	loadLevel                LoadLevel
+	tenantID                 string            // Set by TenantScope
+	metricLabels             map[string]string // Set by AddMetricLabel
 }
 
-// JSON writes JSON response (default format) - fail-fast
-func (c *FastRequestContext) JSON(statusCode int, data interface{}) error {
+// JSON writes JSON response (default format) - fail-fast.
+// Pass core.JSONOptions to change the encoding (e.g. no HTML escaping, indentation).
+func (c *FastRequestContext) JSON(statusCode int, data interface{}, opts ...core.JSONOptions) error {
 	// Fail-fast: validate status code
 	if statusCode < 100 || statusCode > 599 {
 		return fmt.Errorf("invalid status code: %d", statusCode)
@@ -519,7 +754,13 @@ func (c *FastRequestContext) JSON(statusCode int, data interface{}) error {
 	c.RequestCtx.SetContentType("application/json")
 
 	// Fail-fast: JSON encoding errors are propagated immediately
-	jsonData, err := core.JSONEncode(data)
+	var jsonData []byte
+	var err error
+	if len(opts) > 0 {
+		jsonData, err = core.JSONEncodeWith(data, opts[0])
+	} else {
+		jsonData, err = core.JSONEncode(data)
+	}
 	if err != nil {
 		return fmt.Errorf("json encode error: %w", err)
 	}
@@ -535,22 +776,6 @@ func (c *FastRequestContext) JSON(statusCode int, data interface{}) error {
 	return nil
 }
 
-// BindJSON binds JSON request body to a struct - fail-fast
-func (c *FastRequestContext) BindJSON(v interface{}) error {
-	// Fail-fast: validate target
-	if v == nil {
-		return fmt.Errorf("cannot bind to nil value")
-	}
-
-	body := c.RequestCtx.PostBody()
-	if len(body) == 0 {
-		return fmt.Errorf("empty request body")
-	}
-
-	// Fail-fast: JSON decoding errors are propagated immediately
-	return core.JSONDecode(body, v)
-}
-
 // Text writes text response
 func (c *FastRequestContext) Text(statusCode int, text string) error {
 	if c.RequestCtx == nil {
@@ -582,6 +807,33 @@ func (c *FastRequestContext) Param(key string) string {
 	return c.Params[key]
 }
 
+// UnmatchedRoute is the route label metrics, spans and rate-limit keys use for
+// requests that matched no route, so unknown paths don't add label values
+const UnmatchedRoute = "unmatched"
+
+// RoutePattern returns the matched route template (e.g. /api/users/:id)
+// Empty until the router has matched the request to a route
+func (c *FastRequestContext) RoutePattern() string {
+	return c.routePattern
+}
+
+// HandlerName returns the matched route's handler function name without its
+// import path (e.g. main.getUser); empty until the router has matched the request
+func (c *FastRequestContext) HandlerName() string {
+	return c.handlerName
+}
+
+// LoadLevel returns the server load level observed when the request started
+// Handlers can use it to skip optional work instead of failing the request
+func (c *FastRequestContext) LoadLevel() LoadLevel {
+	return c.loadLevel
+}
+
+// IsDegraded returns true if the server is above the normal load level
+func (c *FastRequestContext) IsDegraded() bool {
+	return c.loadLevel > LoadLevelNormal
+}
+
 // Method returns HTTP method
 func (c *FastRequestContext) Method() []byte {
 	return c.RequestCtx.Method()
@@ -602,8 +854,23 @@ func (c *FastRequestContext) RequestID() string {
 	return c.requestID
 }
 
-// Context returns a context with request ID
+// Context returns a context with request ID and tenant (see TenantScope)
+// Includes the server span when tracing middleware stored one under "span_context"
 func (c *FastRequestContext) Context() context.Context {
+	ctx := c.requestContext()
+	if c.tenantID != "" {
+		ctx = core.WithTenant(ctx, c.tenantID)
+	}
+	return ctx
+}
+
+func (c *FastRequestContext) requestContext() context.Context {
+	if c.BaseRequestContext != nil {
+		if spanCtx, ok := c.Get("span_context").(context.Context); ok {
+			return spanCtx
+		}
+	}
+
 	ctx := context.Background()
 	if c.requestID != "" {
 		ctx = core.WithRequestID(ctx, c.requestID)
@@ -2,9 +2,16 @@ package web
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
 )
 
 func TestFastHTTPServer_NewServer(t *testing.T) {
@@ -85,3 +92,333 @@ func TestDefaultFastHTTPServerConfig(t *testing.T) {
 		t.Error("Workers should be positive")
 	}
 }
+
+func TestFastHTTPServer_BackpressureExemptPaths(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	config := DefaultFastHTTPServerConfig(":0")
+	config.BackpressureExemptPaths = []string{"/health", "/metrics/*"}
+	server := NewFastHTTPServer(gocmd, config)
+	ok := func(ctx *FastRequestContext) error { return ctx.JSON(200, map[string]string{"status": "ok"}) }
+	server.FastRouter().GETFast("/health", ok)
+	server.FastRouter().GETFast("/metrics/prometheus", ok)
+	server.FastRouter().GETFast("/api", ok)
+
+	// Saturate normal capacity
+	for server.Backpressure().TryAcquire() {
+	}
+
+	request := func(path string) int {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod("GET")
+		ctx.Request.SetRequestURI(path)
+		server.handleRequest(ctx)
+		return ctx.Response.StatusCode()
+	}
+
+	for _, path := range []string{"/health", "/metrics/prometheus"} {
+		if status := request(path); status != 200 {
+			t.Errorf("%s status = %d, exempt path should bypass backpressure", path, status)
+		}
+	}
+	if status := request("/api"); status != fasthttp.StatusServiceUnavailable {
+		t.Errorf("/api status = %d, want 503 while shedding load", status)
+	}
+
+	load := server.Backpressure().GetMetrics().CurrentLoad
+	if int(load) != config.MaxQueue+config.Workers {
+		t.Errorf("CurrentLoad = %d, exempt requests should not change it", load)
+	}
+}
+
+func TestFastHTTPServer_InFlightAndDrain(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	server.FastRouter().GETFast("/slow", func(ctx *FastRequestContext) error {
+		close(entered)
+		<-release
+		return ctx.JSON(200, map[string]string{"status": "ok"})
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod("GET")
+		ctx.Request.SetRequestURI("/slow")
+		server.handleRequest(ctx)
+	}()
+	<-entered
+
+	if n := server.InFlight(); n != 1 {
+		t.Errorf("InFlight() = %d, want 1", n)
+	}
+	if n := server.Metrics().InFlightRequests; n != 1 {
+		t.Errorf("Metrics().InFlightRequests = %d, want 1", n)
+	}
+
+	// Expires while the request is still running
+	shortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := server.WaitForDrain(shortCtx); err != context.DeadlineExceeded {
+		t.Errorf("WaitForDrain() = %v, want DeadlineExceeded", err)
+	}
+
+	close(release)
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), time.Second)
+	defer cancelDrain()
+	if err := server.WaitForDrain(drainCtx); err != nil {
+		t.Errorf("WaitForDrain() = %v, want nil once the request completes", err)
+	}
+	<-done
+	if n := server.InFlight(); n != 0 {
+		t.Errorf("InFlight() = %d after drain, want 0", n)
+	}
+}
+
+func TestFastHTTPServer_BackpressureEvent(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	events := make(chan map[string]interface{}, 4)
+	gocmd.EventBus().Consumer(BackpressureAddress).Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		var body map[string]interface{}
+		if err := msg.DecodeBody(&body); err != nil {
+			return err
+		}
+		events <- body
+		return nil
+	})
+
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	server.FastRouter().GETFast("/api", func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") })
+	request := func() {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod("GET")
+		ctx.Request.SetRequestURI("/api")
+		server.handleRequest(ctx)
+	}
+
+	request() // normal load: no event
+	for server.Backpressure().TryAcquire() {
+	}
+	request()
+	request() // still critical: no second event
+
+	select {
+	case body := <-events:
+		if body["level"] != "critical" || body["previousLevel"] != "normal" || body["server"] != ":0" {
+			t.Errorf("event = %v", body)
+		}
+		if body["rejected"].(float64) < 1 {
+			t.Errorf("rejected = %v, want >= 1", body["rejected"])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no backpressure event published")
+	}
+	select {
+	case body := <-events:
+		t.Errorf("unexpected second event: %v", body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFastHTTPServer_RequestIDThroughEventBus(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	consumerID := make(chan string, 1)
+	consumer := gocmd.EventBus().Consumer("user.service.get").Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		consumerID <- core.GetRequestID(ctx.Context())
+		return msg.Reply(map[string]string{"id": "1"})
+	})
+	select {
+	case <-consumer.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("consumer not ready")
+	}
+
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	var replyID string
+	server.FastRouter().GETFast("/users", func(ctx *FastRequestContext) error {
+		reply, err := ctx.EventBus.Request("user.service.get", map[string]string{"id": "1"}, time.Second)
+		if err != nil {
+			return err
+		}
+		replyID = reply.Header(core.HeaderRequestID)
+		return ctx.Text(200, "ok")
+	})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod("GET")
+	ctx.Request.SetRequestURI("/users")
+	ctx.Request.Header.Set("X-Request-ID", "req-42")
+	server.handleRequest(ctx)
+
+	if ctx.Response.StatusCode() != 200 {
+		t.Fatalf("status = %d, body = %s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+	select {
+	case got := <-consumerID:
+		if got != "req-42" {
+			t.Errorf("consumer request ID = %q, want req-42", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("consumer not called")
+	}
+	if replyID != "req-42" {
+		t.Errorf("reply %s = %q, want req-42", core.HeaderRequestID, replyID)
+	}
+}
+
+func TestFastHTTPServer_PanicResponse(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	request := func(server *FastHTTPServer) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod("GET")
+		ctx.Request.SetRequestURI("/orders/42")
+		ctx.Request.Header.Set("X-Request-ID", "req-1")
+		server.handleRequest(ctx)
+		return ctx
+	}
+	boom := func(ctx *FastRequestContext) error {
+		_ = ctx.Text(200, "partial")
+		panic("boom")
+	}
+
+	// Default: a 500 naming the request and route, without the panic value
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	server.FastRouter().GETFast("/orders/:id", boom)
+	ctx := request(server)
+	if status := ctx.Response.StatusCode(); status != 500 {
+		t.Errorf("status = %d, want 500", status)
+	}
+	want := `{"error":"handler_panic","message":"Request handler failed","request_id":"req-1","route":"/orders/:id"}`
+	if body := string(ctx.Response.Body()); body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+	if m := server.Metrics(); m.ErrorRequests != 1 {
+		t.Errorf("ErrorRequests = %d, want 1", m.ErrorRequests)
+	}
+
+	// Custom panic handler
+	config := DefaultFastHTTPServerConfig(":0")
+	config.PanicHandler = func(ctx *FastRequestContext, recovered interface{}) {
+		_ = ctx.Text(503, fmt.Sprintf("%s %v", ctx.RoutePattern(), recovered))
+	}
+	server = NewFastHTTPServer(gocmd, config)
+	server.FastRouter().GETFast("/orders/:id", boom)
+	ctx = request(server)
+	if status, body := ctx.Response.StatusCode(), string(ctx.Response.Body()); status != 503 || body != "/orders/:id boom" {
+		t.Errorf("custom panic response = %d %q, want 503 \"/orders/:id boom\"", status, body)
+	}
+
+	// A panicking panic handler is still contained by the outer recover
+	config.PanicHandler = func(ctx *FastRequestContext, recovered interface{}) { panic("again") }
+	server = NewFastHTTPServer(gocmd, config)
+	server.FastRouter().GETFast("/orders/:id", boom)
+	if status := request(server).Response.StatusCode(); status != 500 {
+		t.Errorf("status after panicking panic handler = %d, want 500", status)
+	}
+}
+
+func TestFastHTTPServer_StopsBeforeEventBus(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	c := gocmd.EventBus().Consumer("shutdown.echo").HandlerReply(func(ctx core.FluxorContext, msg core.Message) (interface{}, error) {
+		return "pong", nil
+	})
+	<-c.Ready()
+
+	addr := freeAddr(t)
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(addr))
+	release := make(chan struct{})
+	server.FastRouter().GETFast("/slow", func(ctx *FastRequestContext) error {
+		<-release
+		reply, err := ctx.EventBus.Request("shutdown.echo", "ping", time.Second)
+		if err != nil {
+			return ctx.Text(500, err.Error())
+		}
+		var body string
+		_ = reply.DecodeBody(&body)
+		return ctx.Text(200, body)
+	})
+	go server.Start()
+	waitListening(t, addr)
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := client.Get("http://" + addr + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		slow <- result{status: resp.StatusCode, body: string(b)}
+	}()
+	for server.InFlight() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = gocmd.Close()
+		close(closed)
+	}()
+	for atomic.LoadInt32(&server.stopping) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// New requests are refused (503, or no listener) while the in-flight one still runs
+	if resp, err := client.Get("http://" + addr + "/slow"); err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != 503 {
+			t.Errorf("GET during shutdown status = %d, want 503", resp.StatusCode)
+		}
+	}
+
+	// ... which still reaches the EventBus
+	close(release)
+	if r := <-slow; r.err != nil || r.status != 200 || r.body != "pong" {
+		t.Errorf("in-flight request = %d %q %v, want 200 pong", r.status, r.body, r.err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return")
+	}
+	if conn, err := net.Dial("tcp", addr); err == nil {
+		conn.Close()
+		t.Error("server still listening after Close()")
+	}
+}
+
+func TestFastHTTPServer_StopAccepting(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	server.FastRouter().GETFast("/ok", func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") })
+
+	_ = server.stopAccepting(context.Background())
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod("GET")
+	ctx.Request.SetRequestURI("/ok")
+	server.handleRequest(ctx)
+	if code := ctx.Response.StatusCode(); code != 503 || !ctx.Response.ConnectionClose() {
+		t.Errorf("request after stopAccepting = %d (close %v), want 503 closing the connection", code, ctx.Response.ConnectionClose())
+	}
+}
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// FieldsParam is the query parameter JSONFiltered reads, e.g. ?fields=id,name,owner.email
+const FieldsParam = "fields"
+
+// FieldSelection is a parsed field list; a nil child selects the whole value
+type FieldSelection map[string]FieldSelection
+
+// ParseFields parses a comma-separated field list with dot notation for nested
+// fields. Selecting a parent ("owner") wins over its children ("owner.email").
+func ParseFields(raw string) FieldSelection {
+	sel := FieldSelection{}
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		node := sel
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			if part == "" {
+				break
+			}
+			child, seen := node[part]
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if seen && child == nil {
+				break // Parent already selected whole
+			}
+			if child == nil {
+				child = FieldSelection{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return sel
+}
+
+// SelectFields returns data with only the selected fields. Structs are converted
+// through their JSON form, so selection uses JSON names. Arrays are filtered
+// element by element; unknown fields are ignored.
+func SelectFields(data interface{}, sel FieldSelection) (interface{}, error) {
+	if len(sel) == 0 {
+		return data, nil
+	}
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return sel.apply(generic), nil
+}
+
+func (sel FieldSelection) apply(value interface{}) interface{} {
+	if sel == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(sel))
+		for name, child := range sel {
+			if field, ok := v[name]; ok {
+				out[name] = child.apply(field)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = sel.apply(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// toGenericJSON converts data to maps and slices through its JSON form.
+// Numbers are kept as json.Number so large integers survive the round trip.
+func toGenericJSON(data interface{}) (interface{}, error) {
+	encoded, err := core.JSONEncode(data)
+	if err != nil {
+		return nil, fmt.Errorf("json encode error: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("json decode failed: %w", err)
+	}
+	return generic, nil
+}
+
+// JSONFiltered writes data like JSON, keeping only the fields requested by the
+// fields query parameter (partial response). Without the parameter it is JSON.
+// With JSONOptions.FieldNaming, fields are selected by their renamed names.
+func (c *FastRequestContext) JSONFiltered(statusCode int, data interface{}, opts ...core.JSONOptions) error {
+	raw := c.Query(FieldsParam)
+	if raw == "" {
+		return c.JSON(statusCode, data, opts...)
+	}
+	if len(opts) > 0 && opts[0].FieldNaming != nil && data != nil {
+		renamed, err := core.JSONEncodeWith(data, core.JSONOptions{FieldNaming: opts[0].FieldNaming})
+		if err != nil {
+			return err
+		}
+		data = json.RawMessage(renamed)
+	}
+	filtered, err := SelectFields(data, ParseFields(raw))
+	if err != nil {
+		return err
+	}
+	return c.JSON(statusCode, filtered, opts...)
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestParseFields(t *testing.T) {
+	got := ParseFields(" id, owner.email,owner.name,tags , owner.address.city,meta,meta.x,,")
+	want := FieldSelection{
+		"id":   nil,
+		"tags": nil,
+		"meta": nil,
+		"owner": FieldSelection{
+			"email":   nil,
+			"name":    nil,
+			"address": FieldSelection{"city": nil},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFields() = %v, want %v", got, want)
+	}
+
+	// A parent selected after a child still selects the whole value
+	if got := ParseFields("owner.email,owner"); got["owner"] != nil {
+		t.Errorf("owner = %v, want whole value", got["owner"])
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	type owner struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	type todo struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		Owner owner  `json:"owner"`
+	}
+
+	data := map[string]interface{}{
+		"items": []todo{
+			{ID: 1, Title: "a", Owner: owner{Email: "a@x", Name: "A"}},
+			{ID: 2, Title: "b", Owner: owner{Email: "b@x", Name: "B"}},
+		},
+		"total": 2,
+	}
+	got, err := SelectFields(data, ParseFields("items.id,items.owner.email,missing"))
+	if err != nil {
+		t.Fatalf("SelectFields() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": json.Number("1"), "owner": map[string]interface{}{"email": "a@x"}},
+			map[string]interface{}{"id": json.Number("2"), "owner": map[string]interface{}{"email": "b@x"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectFields() = %v, want %v", got, want)
+	}
+
+	if got, _ := SelectFields(data, nil); !reflect.DeepEqual(got, data) {
+		t.Error("empty selection should return data unchanged")
+	}
+}
+
+func TestFastRequestContext_JSONFiltered(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	data := map[string]interface{}{"id": 1, "name": "Ada", "secret": "x"}
+
+	ctx := newTestFastContext(gocmd, "GET", "/users/1?fields=id,name")
+	if err := ctx.JSONFiltered(200, data); err != nil {
+		t.Fatalf("JSONFiltered() error = %v", err)
+	}
+	if body := string(ctx.RequestCtx.Response.Body()); body != `{"id":1,"name":"Ada"}` {
+		t.Errorf("body = %s, want id and name only", body)
+	}
+
+	ctx = newTestFastContext(gocmd, "GET", "/users/1")
+	if err := ctx.JSONFiltered(200, data); err != nil {
+		t.Fatalf("JSONFiltered() error = %v", err)
+	}
+	if body := string(ctx.RequestCtx.Response.Body()); body != `{"id":1,"name":"Ada","secret":"x"}` {
+		t.Errorf("body = %s, want the full response", body)
+	}
+}
+
+func TestFastRequestContext_JSONFiltered_FieldNaming(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	data := struct {
+		UserID   string
+		Name     string
+		HomePage string
+	}{"u-1", "Ada", "/ada?tab=1&x=2"}
+	opts := core.JSONOptions{FieldNaming: core.SnakeCase, DisableHTMLEscape: true}
+
+	ctx := newTestFastContext(gocmd, "GET", "/users/1?fields=user_id,home_page")
+	if err := ctx.JSONFiltered(200, data, opts); err != nil {
+		t.Fatalf("JSONFiltered() error = %v", err)
+	}
+	if body := string(ctx.RequestCtx.Response.Body()); body != `{"home_page":"/ada?tab=1&x=2","user_id":"u-1"}` {
+		t.Errorf("body = %s, want renamed user_id and home_page only", body)
+	}
+}
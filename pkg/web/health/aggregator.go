@@ -37,7 +37,7 @@ func Handler() web.FastRequestHandler {
 }
 
 // ReadyHandler returns a FastRequestHandler for the /ready endpoint
-// Returns 503 if any check fails, 200 if all checks pass
+// Returns 503 if any critical check fails, 200 otherwise (including DEGRADED)
 func ReadyHandler() web.FastRequestHandler {
 	aggregator := NewAggregator(nil)
 	return aggregator.HandleReady
@@ -47,14 +47,8 @@ func ReadyHandler() web.FastRequestHandler {
 func (a *Aggregator) HandleHealth(ctx *web.FastRequestContext) error {
 	results := a.registry.Check(ctx.Context())
 
-	// Determine overall status
-	overallStatus := StatusUp
-	for _, result := range results {
-		if result.Status == StatusDown {
-			overallStatus = StatusDown
-			break
-		}
-	}
+	// Only a failing critical check takes the service down; DEGRADED still serves 200
+	overallStatus := OverallStatus(results)
 
 	response := HealthResponse{
 		Status:    string(overallStatus),
@@ -76,14 +70,8 @@ func (a *Aggregator) HandleHealth(ctx *web.FastRequestContext) error {
 func (a *Aggregator) HandleReady(ctx *web.FastRequestContext) error {
 	results := a.registry.Check(ctx.Context())
 
-	// Determine overall status
-	overallStatus := StatusUp
-	for _, result := range results {
-		if result.Status == StatusDown {
-			overallStatus = StatusDown
-			break
-		}
-	}
+	// Only a failing critical check takes the service down; DEGRADED still serves 200
+	overallStatus := OverallStatus(results)
 
 	response := HealthResponse{
 		Status:    string(overallStatus),
@@ -103,16 +91,7 @@ func (a *Aggregator) HandleReady(ctx *web.FastRequestContext) error {
 // GetHealthStatus returns the current health status without HTTP response
 func (a *Aggregator) GetHealthStatus(ctx context.Context) (Status, map[string]CheckResult) {
 	results := a.registry.Check(ctx)
-
-	overallStatus := StatusUp
-	for _, result := range results {
-		if result.Status == StatusDown {
-			overallStatus = StatusDown
-			break
-		}
-	}
-
-	return overallStatus, results
+	return OverallStatus(results), results
 }
 
 // FormatHealthResponse formats health check results as JSON
@@ -0,0 +1,68 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/fluxorio/fluxor/pkg/web/health"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAggregator_Criticality(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("unreachable") }
+
+	tests := []struct {
+		name       string
+		register   func(r *health.Registry)
+		wantStatus health.Status
+		wantCode   int
+	}{
+		{"all up", func(r *health.Registry) {
+			r.Register("db", up)
+			r.RegisterNonCritical("cache", up)
+		}, health.StatusUp, 200},
+		{"non-critical down", func(r *health.Registry) {
+			r.Register("db", up)
+			r.RegisterNonCritical("cache", down)
+		}, health.StatusDegraded, 200},
+		{"critical down", func(r *health.Registry) {
+			r.Register("db", down)
+			r.RegisterNonCritical("cache", down)
+		}, health.StatusDown, 503},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := health.NewRegistry()
+			tt.register(registry)
+			aggregator := health.NewAggregator(registry)
+
+			if status, _ := aggregator.GetHealthStatus(context.Background()); status != tt.wantStatus {
+				t.Errorf("GetHealthStatus() = %s, want %s", status, tt.wantStatus)
+			}
+
+			ctx := &web.FastRequestContext{RequestCtx: &fasthttp.RequestCtx{}}
+			if err := aggregator.HandleReady(ctx); err != nil {
+				t.Fatalf("HandleReady() error = %v", err)
+			}
+			if code := ctx.RequestCtx.Response.StatusCode(); code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", code, tt.wantCode)
+			}
+
+			var resp health.HealthResponse
+			if err := json.Unmarshal(ctx.RequestCtx.Response.Body(), &resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp.Status != string(tt.wantStatus) {
+				t.Errorf("response status = %s, want %s", resp.Status, tt.wantStatus)
+			}
+			if resp.Checks["db"].Criticality != health.Critical || resp.Checks["cache"].Criticality != health.NonCritical {
+				t.Errorf("checks = %+v, want per-check criticality", resp.Checks)
+			}
+		})
+	}
+}
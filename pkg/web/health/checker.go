@@ -9,11 +9,22 @@ import (
 // Checker is a health check function
 type Checker func(ctx context.Context) error
 
+// Criticality decides how a failing check affects the overall status
+type Criticality string
+
+const (
+	// Critical checks take the service down (503) when they fail. The default.
+	Critical Criticality = "critical"
+	// NonCritical checks only degrade the service when they fail (e.g. a cache)
+	NonCritical Criticality = "non-critical"
+)
+
 // NamedChecker is a health check with a name
 type NamedChecker struct {
-	Name    string
-	Checker Checker
-	Timeout time.Duration
+	Name        string
+	Checker     Checker
+	Timeout     time.Duration
+	Criticality Criticality
 }
 
 // Registry manages health checks
@@ -36,14 +47,23 @@ func (r *Registry) Register(name string, checker Checker) {
 
 // RegisterWithTimeout registers a health check with a timeout
 func (r *Registry) RegisterWithTimeout(name string, checker Checker, timeout time.Duration) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	r.RegisterNamed(NamedChecker{Name: name, Checker: checker, Timeout: timeout})
+}
 
-	r.checkers[name] = &NamedChecker{
-		Name:    name,
-		Checker: checker,
-		Timeout: timeout,
+// RegisterNonCritical registers a health check whose failure only degrades the service
+func (r *Registry) RegisterNonCritical(name string, checker Checker) {
+	r.RegisterNamed(NamedChecker{Name: name, Checker: checker, Timeout: 5 * time.Second, Criticality: NonCritical})
+}
+
+// RegisterNamed registers a fully specified health check. An empty Criticality means Critical.
+func (r *Registry) RegisterNamed(checker NamedChecker) {
+	if checker.Criticality == "" {
+		checker.Criticality = Critical
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers[checker.Name] = &checker
 }
 
 // Unregister removes a health check
@@ -98,24 +118,27 @@ func (r *Registry) runCheck(ctx context.Context, checker *NamedChecker) CheckRes
 
 	if err != nil {
 		return CheckResult{
-			Status:   StatusDown,
-			Message:  err.Error(),
-			Duration: duration,
+			Status:      StatusDown,
+			Message:     err.Error(),
+			Duration:    duration,
+			Criticality: checker.Criticality,
 		}
 	}
 
 	return CheckResult{
-		Status:   StatusUp,
-		Message:  "OK",
-		Duration: duration,
+		Status:      StatusUp,
+		Message:     "OK",
+		Duration:    duration,
+		Criticality: checker.Criticality,
 	}
 }
 
 // CheckResult represents the result of a health check
 type CheckResult struct {
-	Status   Status        `json:"status"`
-	Message  string        `json:"message,omitempty"`
-	Duration time.Duration `json:"duration,omitempty"`
+	Status      Status        `json:"status"`
+	Message     string        `json:"message,omitempty"`
+	Duration    time.Duration `json:"duration,omitempty"`
+	Criticality Criticality   `json:"criticality"`
 }
 
 // Status represents health check status
@@ -124,8 +147,27 @@ type Status string
 const (
 	StatusUp   Status = "UP"
 	StatusDown Status = "DOWN"
+	// StatusDegraded is the overall status when only non-critical checks fail
+	StatusDegraded Status = "DEGRADED"
 )
 
+// OverallStatus is DOWN if a critical check failed, DEGRADED if only
+// non-critical checks failed, and UP otherwise
+func OverallStatus(results map[string]CheckResult) Status {
+	overall := StatusUp
+	for _, result := range results {
+		if result.Status != StatusDown {
+			continue
+		}
+		if result.Criticality == NonCritical {
+			overall = StatusDegraded
+			continue
+		}
+		return StatusDown
+	}
+	return overall
+}
+
 // Global registry
 var globalRegistry = NewRegistry()
 
@@ -139,6 +181,11 @@ func RegisterWithTimeout(name string, checker Checker, timeout time.Duration) {
 	globalRegistry.RegisterWithTimeout(name, checker, timeout)
 }
 
+// RegisterNonCritical registers a non-critical health check in the global registry
+func RegisterNonCritical(name string, checker Checker) {
+	globalRegistry.RegisterNonCritical(name, checker)
+}
+
 // Unregister removes a health check from the global registry
 func Unregister(name string) {
 	globalRegistry.Unregister(name)
@@ -0,0 +1,26 @@
+package health
+
+import (
+	"context"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// EventBusCheck creates a health check for an event bus.
+// Cluster buses report their NATS connection status (reconnecting, closed, ...)
+// and round-trip a ping through the server; the in-memory bus is always healthy.
+func EventBusCheck(eb core.EventBus) Checker {
+	return func(ctx context.Context) error {
+		if eb == nil {
+			return &Error{Message: "event bus is nil"}
+		}
+		pinger, ok := eb.(core.Pinger)
+		if !ok {
+			return nil
+		}
+		if err := pinger.Ping(ctx); err != nil {
+			return &Error{Message: "event bus unhealthy: " + err.Error()}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web/health"
+)
+
+func TestEventBusCheck(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	registry := health.NewRegistry()
+	registry.Register("eventbus", health.EventBusCheck(gocmd.EventBus()))
+	registry.Register("nil-eventbus", health.EventBusCheck(nil))
+
+	results := registry.Check(context.Background())
+	if results["eventbus"].Status != health.StatusUp {
+		t.Errorf("in-memory bus should be UP, got %+v", results["eventbus"])
+	}
+	if results["nil-eventbus"].Status != health.StatusDown {
+		t.Errorf("nil bus should be DOWN, got %+v", results["nil-eventbus"])
+	}
+}
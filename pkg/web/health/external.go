@@ -3,38 +3,16 @@ package health
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"time"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
 )
 
 // HTTPCheck creates a health check for an external HTTP service
+// Requests go through web.SharedHTTPClient
 func HTTPCheck(url string, timeout time.Duration) Checker {
-	if timeout == 0 {
-		timeout = 5 * time.Second
-	}
-
-	return func(ctx context.Context) error {
-		client := &http.Client{
-			Timeout: timeout,
-		}
-
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return &Error{Message: fmt.Sprintf("failed to create request: %v", err)}
-		}
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return &Error{Message: fmt.Sprintf("HTTP request failed: %v", err)}
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return &Error{Message: fmt.Sprintf("HTTP status %d", resp.StatusCode)}
-		}
-
-		return nil
-	}
+	return HTTPCheckWithHeaders(url, timeout, nil)
 }
 
 // HTTPCheckWithHeaders creates a health check for an external HTTP service with custom headers
@@ -44,28 +22,28 @@ func HTTPCheckWithHeaders(url string, timeout time.Duration, headers map[string]
 	}
 
 	return func(ctx context.Context) error {
-		client := &http.Client{
-			Timeout: timeout,
-		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
 
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return &Error{Message: fmt.Sprintf("failed to create request: %v", err)}
-		}
+		req := fasthttp.AcquireRequest()
+		resp := fasthttp.AcquireResponse()
+		defer fasthttp.ReleaseRequest(req)
+		defer fasthttp.ReleaseResponse(resp)
+
+		req.SetRequestURI(url)
+		req.Header.SetMethod(fasthttp.MethodGet)
 
 		// Add custom headers
 		for key, value := range headers {
 			req.Header.Set(key, value)
 		}
 
-		resp, err := client.Do(req)
-		if err != nil {
+		if err := web.SharedHTTPClient().Do(ctx, req, resp); err != nil {
 			return &Error{Message: fmt.Sprintf("HTTP request failed: %v", err)}
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return &Error{Message: fmt.Sprintf("HTTP status %d", resp.StatusCode)}
+		if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+			return &Error{Message: fmt.Sprintf("HTTP status %d", resp.StatusCode())}
 		}
 
 		return nil
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// fasthttp only speaks HTTP/1.1, so HTTP/2 is served by a net/http server on its
+// own address (FastHTTPServerConfig.HTTP2Addr). Each HTTP/2 request is copied into
+// a fasthttp.RequestCtx and goes through the same handler as HTTP/1.1 requests,
+// so backpressure, metrics, panic recovery and the router apply unchanged.
+//
+// Not supported over HTTP/2: connection hijacking (WebSocket upgrades) and
+// streaming request bodies (bodies are read in full, up to MaxRequestBodySize).
+
+// newHTTP2Server builds the net/http server for HTTP2Addr: h2c with prior knowledge
+// when tlsConfig is nil, otherwise HTTP/2 over TLS. HTTP/1.1 is accepted as well.
+func (s *FastHTTPServer) newHTTP2Server(addr string, tlsConfig *tls.Config) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if tlsConfig != nil {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Server{
+		Addr:         addr,
+		Handler:      http.HandlerFunc(s.serveHTTP2),
+		TLSConfig:    tlsConfig,
+		Protocols:    protocols,
+		ReadTimeout:  s.server.ReadTimeout,
+		WriteTimeout: s.server.WriteTimeout,
+	}
+}
+
+// startHTTP2 binds HTTP2Addr and serves it in the background; bind errors fail Start
+func (s *FastHTTPServer) startHTTP2() error {
+	ln, err := net.Listen("tcp", s.http2.Addr)
+	if err != nil {
+		return fmt.Errorf("http2 listen on %s: %w", s.http2.Addr, err)
+	}
+	s.http2Listener = ln
+	s.Logger().Info(fmt.Sprintf("Starting HTTP/2 server on %s", ln.Addr()))
+	go func() {
+		// ServeTLS adds the "h2" ALPN protocol; certificates come from TLSConfig
+		serve := s.http2.Serve
+		if s.http2.TLSConfig != nil {
+			serve = func(ln net.Listener) error { return s.http2.ServeTLS(ln, "", "") }
+		}
+		if err := serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.Logger().Error(fmt.Sprintf("HTTP/2 server error: %v", err))
+		}
+	}()
+	return nil
+}
+
+// serveHTTP2 adapts a net/http request to the fasthttp handler
+func (s *FastHTTPServer) serveHTTP2(w http.ResponseWriter, r *http.Request) {
+	maxBody := s.server.MaxRequestBodySize
+	if maxBody <= 0 {
+		maxBody = fasthttp.DefaultMaxRequestBodySize
+	}
+	if err := serveAsFastHTTP(w, r, maxBody, s.server.Handler); err != nil {
+		s.Logger().Error(fmt.Sprintf("failed to write HTTP/2 response: %v", err))
+	}
+}
+
+// isHopByHopHeader reports headers HTTP/2 forbids or net/http sets itself
+func isHopByHopHeader(key string) bool {
+	switch strings.ToLower(key) {
+	case "connection", "keep-alive", "transfer-encoding", "upgrade", "content-length":
+		return true
+	}
+	return false
+}
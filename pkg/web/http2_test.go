@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestFastHTTPServer_H2C(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	config := DefaultFastHTTPServerConfig(":0")
+	config.HTTP2Addr = "127.0.0.1:0"
+	server := NewFastHTTPServer(gocmd, config)
+	server.FastRouter().POSTFast("/echo/:name", func(ctx *FastRequestContext) error {
+		ctx.RequestCtx.Response.Header.Set("X-Name", ctx.Param("name"))
+		return ctx.Text(201, string(ctx.RequestCtx.PostBody()))
+	})
+
+	if err := server.startHTTP2(); err != nil {
+		t.Fatalf("startHTTP2() error = %v", err)
+	}
+	defer server.http2.Close()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+
+	url := "http://" + server.http2Listener.Addr().String() + "/echo/ada"
+	resp, err := client.Post(url, "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.ProtoMajor != 2 {
+		t.Errorf("protocol = %s, want HTTP/2", resp.Proto)
+	}
+	if resp.StatusCode != 201 || string(body) != "hello" {
+		t.Errorf("response = %d %q, want 201 hello", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-Name") != "ada" || resp.Header.Get("X-Request-ID") == "" {
+		t.Errorf("headers = %v", resp.Header)
+	}
+	if server.Metrics().TotalRequests != 1 {
+		t.Errorf("TotalRequests = %d, want HTTP/2 requests counted", server.Metrics().TotalRequests)
+	}
+
+	// Backpressure applies to HTTP/2 requests too
+	for server.Backpressure().TryAcquire() {
+	}
+	resp, err = client.Post(url, "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status under load = %d, want 503", resp.StatusCode)
+	}
+}
@@ -0,0 +1,434 @@
+package web
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpproxy"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPClientConfig configures the outbound HTTP client
+type HTTPClientConfig struct {
+	// Timeout bounds each request, redirects included, when the context has no
+	// deadline; a context deadline takes precedence (default: 30s)
+	Timeout time.Duration
+
+	// MaxRedirects is the number of redirects followed per request (default: 10);
+	// -1 returns redirect responses to the caller
+	MaxRedirects int
+
+	// SensitiveHeaders are removed, along with Authorization, Cookie and
+	// Proxy-Authorization, when a redirect leads to another host or scheme, so
+	// credentials aren't handed to a third party (e.g. "X-API-Key")
+	SensitiveHeaders []string
+
+	// RequestIDHeader carries the request ID to downstream services (default: "X-Request-ID")
+	RequestIDHeader string
+
+	// MaxConnsPerHost limits connections per host (0 = fasthttp default)
+	MaxConnsPerHost int
+
+	// MaxIdleConnDuration closes pooled connections idle for longer (0 = fasthttp default, 10s)
+	MaxIdleConnDuration time.Duration
+
+	// MaxRetries retries idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE, TRACE)
+	// on transport errors and 5xx responses (default: 0, no retries)
+	MaxRetries int
+
+	// RetryBackoff is the wait before the first retry, doubled for each further one (default: 100ms)
+	RetryBackoff time.Duration
+
+	// CircuitBreaker, if set, creates a breaker per host; requests to a host whose
+	// breaker is open fail with ErrCircuitOpen without being sent
+	CircuitBreaker func(host string) CircuitBreaker
+}
+
+// CircuitBreaker guards calls to one host; *mesh.CircuitBreaker implements it
+type CircuitBreaker interface {
+	Allow() bool
+	Success()
+	Failure()
+}
+
+// ErrCircuitOpen is returned for requests to a host whose circuit breaker is open
+var ErrCircuitOpen = &core.EventBusError{Code: "CIRCUIT_OPEN", Message: "circuit breaker is open"}
+
+// ErrInsecureRedirect is returned for a redirect from https to http, which is not followed
+var ErrInsecureRedirect = &core.EventBusError{Code: "INSECURE_REDIRECT", Message: "refusing redirect from https to http"}
+
+// redirectStrippedHeaders are always removed on a redirect to another host or scheme
+var redirectStrippedHeaders = []string{fasthttp.HeaderAuthorization, fasthttp.HeaderCookie, fasthttp.HeaderProxyAuthorization}
+
+// DefaultHTTPClientConfig returns the default client configuration
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		Timeout:         30 * time.Second,
+		MaxRedirects:    10,
+		RequestIDHeader: "X-Request-ID",
+		RetryBackoff:    100 * time.Millisecond,
+	}
+}
+
+// HTTPClient is a fasthttp client that forwards the request ID and trace context
+// of the calling handler to downstream services
+type HTTPClient struct {
+	client *fasthttp.Client
+	config HTTPClientConfig
+
+	breakers sync.Map // host -> CircuitBreaker
+
+	// Metrics for monitoring
+	totalRequests  int64 // Atomic counter for requests sent (each retry counts)
+	failedRequests int64 // Atomic counter for transport errors and 5xx responses
+	totalLatencyNs int64 // Atomic sum of request latencies
+	retries        int64 // Atomic counter for retried requests
+	rejected       int64 // Atomic counter for requests refused by an open breaker
+}
+
+// HTTPClientMetrics provides client-side request metrics
+type HTTPClientMetrics struct {
+	TotalRequests  int64         // Total requests sent, including retries
+	FailedRequests int64         // Transport errors and 5xx responses
+	ErrorRate      float64       // FailedRequests / TotalRequests
+	AverageLatency time.Duration // Mean request latency
+	Retries        int64         // Requests sent again after a failure
+	CircuitOpen    int64         // Requests refused by an open circuit breaker
+}
+
+// NewHTTPClient creates an HTTP client with the default configuration
+func NewHTTPClient() *HTTPClient {
+	return NewHTTPClientWithConfig(DefaultHTTPClientConfig())
+}
+
+// NewHTTPClientWithConfig creates an HTTP client - fail-fast on invalid config
+func NewHTTPClientWithConfig(config HTTPClientConfig) *HTTPClient {
+	if config.Timeout < 0 {
+		panic("timeout cannot be negative")
+	}
+	if config.MaxConnsPerHost < 0 {
+		panic("MaxConnsPerHost cannot be negative")
+	}
+	if config.MaxRedirects < -1 {
+		panic("MaxRedirects cannot be less than -1")
+	}
+	if config.MaxRetries < 0 || config.RetryBackoff < 0 {
+		panic("MaxRetries and RetryBackoff cannot be negative")
+	}
+	defaults := DefaultHTTPClientConfig()
+	if config.Timeout == 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.MaxRedirects == 0 {
+		config.MaxRedirects = defaults.MaxRedirects
+	}
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = defaults.RequestIDHeader
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+
+	return &HTTPClient{
+		client: &fasthttp.Client{
+			MaxConnsPerHost:     config.MaxConnsPerHost,
+			MaxIdleConnDuration: config.MaxIdleConnDuration,
+			// Honour HTTP_PROXY, HTTPS_PROXY and NO_PROXY like net/http does
+			Dial: fasthttpproxy.FasthttpProxyHTTPDialerTimeout(fasthttp.DefaultDialTimeout),
+		},
+		config: config,
+	}
+}
+
+var sharedHTTPClient atomic.Pointer[HTTPClient]
+
+// SharedHTTPClient returns the process-wide client used for outbound calls by
+// workflow HTTP nodes and health HTTP checks, so they share one connection pool
+// Created with the default config on first use; replace it with SetSharedHTTPClient
+func SharedHTTPClient() *HTTPClient {
+	if c := sharedHTTPClient.Load(); c != nil {
+		return c
+	}
+	sharedHTTPClient.CompareAndSwap(nil, NewHTTPClient())
+	return sharedHTTPClient.Load()
+}
+
+// SetSharedHTTPClient replaces the shared client - fail-fast on nil
+// Call it at startup, before outbound calls are made
+func SetSharedHTTPClient(c *HTTPClient) {
+	if c == nil {
+		panic("shared HTTP client cannot be nil")
+	}
+	sharedHTTPClient.Store(c)
+}
+
+// Do sends req and fills resp, forwarding the request ID from ctx and
+// injecting the active trace context
+// Pass FastRequestContext.Context() from a handler to link the hop
+func (c *HTTPClient) Do(ctx context.Context, req *fasthttp.Request, resp *fasthttp.Response) error {
+	if ctx == nil {
+		return &core.EventBusError{Code: "INVALID_INPUT", Message: "context cannot be nil"}
+	}
+	if req == nil || resp == nil {
+		return &core.EventBusError{Code: "INVALID_INPUT", Message: "request and response cannot be nil"}
+	}
+
+	method := string(req.Header.Method())
+	ctx, span := otel.Tracer("github.com/fluxorio/fluxor/pkg/web").Start(ctx, "HTTP "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", method),
+			attribute.String("http.url", req.URI().String()),
+		),
+	)
+	defer span.End()
+
+	// Forward request ID; an explicitly set header wins
+	if requestID := core.GetRequestID(ctx); requestID != "" {
+		span.SetAttributes(attribute.String("http.request_id", requestID))
+		if len(req.Header.Peek(c.config.RequestIDHeader)) == 0 {
+			req.Header.Set(c.config.RequestIDHeader, requestID)
+		}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, &requestHeaderCarrier{headers: &req.Header})
+
+	breaker := c.breaker(string(req.URI().Host()))
+	retries := 0
+	if isIdempotent(method) {
+		retries = c.config.MaxRetries
+	}
+	backoff := c.config.RetryBackoff
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		if breaker != nil && !breaker.Allow() {
+			atomic.AddInt64(&c.rejected, 1)
+			err = ErrCircuitOpen
+			break
+		}
+
+		err = c.send(ctx, req, resp)
+		failed := err != nil || resp.StatusCode() >= 500
+		if breaker != nil {
+			if failed {
+				breaker.Failure()
+			} else {
+				breaker.Success()
+			}
+		}
+		if !failed || attempt >= retries || err == context.DeadlineExceeded || err == ErrInsecureRedirect {
+			break
+		}
+
+		// Back off before retrying, within the caller's deadline
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = ctx.Err()
+			}
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		backoff *= 2
+		atomic.AddInt64(&c.retries, 1)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("http.retry_count", attempt+1)))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	statusCode := resp.StatusCode()
+	span.SetAttributes(attribute.Int("http.status_code", statusCode))
+	if statusCode >= 500 {
+		span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(statusCode))
+	}
+	return nil
+}
+
+// send makes one attempt, following redirects, bounded by ctx's deadline or,
+// without one, the client timeout
+func (c *HTTPClient) send(ctx context.Context, req *fasthttp.Request, resp *fasthttp.Response) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.config.Timeout)
+	}
+	if time.Until(deadline) <= 0 {
+		return context.DeadlineExceeded
+	}
+
+	start := time.Now()
+	err := c.doRedirects(req, resp, deadline)
+	atomic.AddInt64(&c.totalLatencyNs, int64(time.Since(start)))
+	atomic.AddInt64(&c.totalRequests, 1)
+	if err != nil || resp.StatusCode() >= 500 {
+		atomic.AddInt64(&c.failedRequests, 1)
+	}
+	return err
+}
+
+// doRedirects sends req and follows up to MaxRedirects redirects before
+// deadline, updating req's URI (and, for 301/302/303, its method and body) as it
+// goes. A redirect to another host or scheme drops credential headers; one from
+// https to http fails with ErrInsecureRedirect. Retries start again from the
+// original request: URI, method, body and headers are restored on return.
+func (c *HTTPClient) doRedirects(req *fasthttp.Request, resp *fasthttp.Response, deadline time.Time) error {
+	uri := req.URI().String()
+	method := string(req.Header.Method())
+	var body []byte
+	bodyReset := false
+	var stripped [][2]string
+	defer func() {
+		req.SetRequestURI(uri)
+		req.Header.SetMethod(method)
+		if bodyReset {
+			req.SetBody(body)
+		}
+		for _, h := range stripped {
+			req.Header.Add(h[0], h[1])
+		}
+	}()
+
+	for redirects := 0; ; redirects++ {
+		if err := c.client.DoDeadline(req, resp, deadline); err != nil {
+			return err
+		}
+		status := resp.StatusCode()
+		if !fasthttp.StatusCodeIsRedirect(status) || c.config.MaxRedirects < 0 {
+			return nil
+		}
+		location := resp.Header.Peek(fasthttp.HeaderLocation)
+		if len(location) == 0 {
+			return fasthttp.ErrMissingLocation
+		}
+		if redirects >= c.config.MaxRedirects {
+			return fasthttp.ErrTooManyRedirects
+		}
+
+		host, scheme := string(req.URI().Host()), string(req.URI().Scheme())
+		req.URI().UpdateBytes(location)
+		newScheme := string(req.URI().Scheme())
+		if scheme == "https" && newScheme == "http" {
+			return ErrInsecureRedirect
+		}
+		if string(req.URI().Host()) != host || newScheme != scheme {
+			stripped = append(stripped, c.stripSensitiveHeaders(req)...)
+		}
+
+		if status != fasthttp.StatusTemporaryRedirect && status != fasthttp.StatusPermanentRedirect &&
+			!req.Header.IsGet() && !req.Header.IsHead() {
+			req.Header.SetMethod(fasthttp.MethodGet)
+			if !bodyReset {
+				body = append([]byte(nil), req.Body()...)
+				bodyReset = true
+			}
+			req.ResetBody()
+			req.Header.SetContentLength(0)
+		}
+	}
+}
+
+// stripSensitiveHeaders removes credential headers from req, returning them so
+// they can be put back
+func (c *HTTPClient) stripSensitiveHeaders(req *fasthttp.Request) [][2]string {
+	var removed [][2]string
+	for _, names := range [][]string{redirectStrippedHeaders, c.config.SensitiveHeaders} {
+		for _, name := range names {
+			for _, value := range req.Header.PeekAll(name) {
+				removed = append(removed, [2]string{name, string(value)})
+			}
+			req.Header.Del(name)
+		}
+	}
+	return removed
+}
+
+// breaker returns host's circuit breaker, or nil if none is configured
+func (c *HTTPClient) breaker(host string) CircuitBreaker {
+	if c.config.CircuitBreaker == nil {
+		return nil
+	}
+	if cb, ok := c.breakers.Load(host); ok {
+		return cb.(CircuitBreaker)
+	}
+	cb, _ := c.breakers.LoadOrStore(host, c.config.CircuitBreaker(host))
+	return cb.(CircuitBreaker)
+}
+
+// isIdempotent reports whether a request with method can safely be retried
+func isIdempotent(method string) bool {
+	switch method {
+	case fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodOptions,
+		fasthttp.MethodPut, fasthttp.MethodDelete, fasthttp.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// Get sends a GET request to url
+// The caller must release resp with fasthttp.ReleaseResponse
+func (c *HTTPClient) Get(ctx context.Context, url string) (*fasthttp.Response, error) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI(url)
+	req.Header.SetMethod(fasthttp.MethodGet)
+
+	resp := fasthttp.AcquireResponse()
+	if err := c.Do(ctx, req, resp); err != nil {
+		fasthttp.ReleaseResponse(resp)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Metrics returns current client metrics
+func (c *HTTPClient) Metrics() HTTPClientMetrics {
+	total := atomic.LoadInt64(&c.totalRequests)
+	failed := atomic.LoadInt64(&c.failedRequests)
+	var avg time.Duration
+	var errorRate float64
+	if total > 0 {
+		avg = time.Duration(atomic.LoadInt64(&c.totalLatencyNs) / total)
+		errorRate = float64(failed) / float64(total)
+	}
+	return HTTPClientMetrics{
+		TotalRequests:  total,
+		FailedRequests: failed,
+		ErrorRate:      errorRate,
+		AverageLatency: avg,
+		Retries:        atomic.LoadInt64(&c.retries),
+		CircuitOpen:    atomic.LoadInt64(&c.rejected),
+	}
+}
+
+// requestHeaderCarrier implements propagation.TextMapCarrier for fasthttp request headers
+type requestHeaderCarrier struct {
+	headers *fasthttp.RequestHeader
+}
+
+func (c *requestHeaderCarrier) Get(key string) string {
+	return string(c.headers.Peek(key))
+}
+
+func (c *requestHeaderCarrier) Set(key, value string) {
+	c.headers.Set(key, value)
+}
+
+func (c *requestHeaderCarrier) Keys() []string {
+	// Not needed for injection
+	return nil
+}
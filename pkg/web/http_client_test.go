@@ -0,0 +1,334 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// newInMemoryHTTPClient returns an HTTPClient wired to an in-memory server
+func newInMemoryHTTPClient(t *testing.T, handler fasthttp.RequestHandler) *HTTPClient {
+	t.Helper()
+
+	ln := fasthttputil.NewInmemoryListener()
+	srv := &fasthttp.Server{Handler: handler}
+	done := make(chan struct{})
+	go func() {
+		_ = srv.Serve(ln)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		_ = ln.Close()
+		_ = srv.Shutdown()
+		<-done
+	})
+
+	client := NewHTTPClient()
+	client.client.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+	return client
+}
+
+func TestHTTPClient_ForwardsRequestID(t *testing.T) {
+	var received string
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		received = string(ctx.Request.Header.Peek("X-Request-ID"))
+		ctx.SetStatusCode(200)
+	})
+
+	ctx := core.WithRequestID(context.Background(), "req-123")
+	resp, err := client.Get(ctx, "http://downstream/api")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer fasthttp.ReleaseResponse(resp)
+
+	if received != "req-123" {
+		t.Errorf("downstream X-Request-ID = %q, want req-123", received)
+	}
+}
+
+func TestHTTPClient_FromHandlerContext(t *testing.T) {
+	var received string
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		received = string(ctx.Request.Header.Peek("X-Request-ID"))
+	})
+
+	reqCtx := &FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         &fasthttp.RequestCtx{},
+		requestID:          "handler-req",
+	}
+	resp, err := client.Get(reqCtx.Context(), "http://downstream/")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	fasthttp.ReleaseResponse(resp)
+
+	if received != "handler-req" {
+		t.Errorf("downstream X-Request-ID = %q, want handler-req", received)
+	}
+}
+
+func TestHTTPClient_Metrics(t *testing.T) {
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Path()) == "/fail" {
+			ctx.SetStatusCode(503)
+		}
+	})
+
+	for _, path := range []string{"/ok", "/fail"} {
+		resp, err := client.Get(context.Background(), "http://downstream"+path)
+		if err != nil {
+			t.Fatalf("Get(%s) error = %v", path, err)
+		}
+		fasthttp.ReleaseResponse(resp)
+	}
+
+	m := client.Metrics()
+	if m.TotalRequests != 2 {
+		t.Errorf("TotalRequests = %d, want 2", m.TotalRequests)
+	}
+	if m.FailedRequests != 1 {
+		t.Errorf("FailedRequests = %d, want 1", m.FailedRequests)
+	}
+}
+
+func TestHTTPClient_RetriesIdempotentRequests(t *testing.T) {
+	var calls int32
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			ctx.SetStatusCode(503)
+		}
+	})
+	client.config.MaxRetries = 2
+	client.config.RetryBackoff = time.Millisecond
+
+	resp, err := client.Get(context.Background(), "http://downstream/flaky")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer fasthttp.ReleaseResponse(resp)
+	if resp.StatusCode() != 200 || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("status = %d after %d calls, want 200 after 3", resp.StatusCode(), calls)
+	}
+	if m := client.Metrics(); m.Retries != 2 || m.FailedRequests != 2 || m.ErrorRate == 0 {
+		t.Errorf("Metrics() = %+v, want 2 retries and 2 failures", m)
+	}
+
+	// POST is not idempotent and is sent once
+	atomic.StoreInt32(&calls, 0)
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI("http://downstream/orders")
+	req.Header.SetMethod(fasthttp.MethodPost)
+	if err := client.Do(context.Background(), req, resp); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode() != 503 || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("POST status = %d after %d calls, want 503 after 1", resp.StatusCode(), calls)
+	}
+}
+
+// countingBreaker opens after the first failure
+type countingBreaker struct{ open atomic.Bool }
+
+func (b *countingBreaker) Allow() bool { return !b.open.Load() }
+func (b *countingBreaker) Success()    {}
+func (b *countingBreaker) Failure()    { b.open.Store(true) }
+
+func TestHTTPClient_CircuitBreakerPerHost(t *testing.T) {
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Host()) == "down" {
+			ctx.SetStatusCode(500)
+		}
+	})
+	client.config.CircuitBreaker = func(host string) CircuitBreaker { return &countingBreaker{} }
+
+	get := func(host string) error {
+		resp, err := client.Get(context.Background(), "http://"+host+"/")
+		if err == nil {
+			fasthttp.ReleaseResponse(resp)
+		}
+		return err
+	}
+	if err := get("down"); err != nil {
+		t.Fatalf("first request error = %v", err)
+	}
+	if err := get("down"); err != ErrCircuitOpen {
+		t.Errorf("request to failing host error = %v, want ErrCircuitOpen", err)
+	}
+	if err := get("up"); err != nil {
+		t.Errorf("other hosts should be unaffected, got %v", err)
+	}
+	if m := client.Metrics(); m.CircuitOpen != 1 {
+		t.Errorf("CircuitOpen = %d, want 1", m.CircuitOpen)
+	}
+}
+
+func TestHTTPClient_FollowsRedirects(t *testing.T) {
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		switch string(ctx.Path()) {
+		case "/old":
+			ctx.Redirect("/new", fasthttp.StatusFound)
+		case "/loop":
+			ctx.Redirect("/loop", fasthttp.StatusFound)
+		default:
+			ctx.SetBodyString(string(ctx.Method()) + " " + string(ctx.Path()))
+		}
+	})
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	req.SetRequestURI("http://downstream/old")
+	req.Header.SetMethod(fasthttp.MethodPost)
+	if err := client.Do(context.Background(), req, resp); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got := string(resp.Body()); got != "GET /new" {
+		t.Errorf("body after a 302 = %q, want GET /new", got)
+	}
+	if got := string(req.URI().Path()); got != "/old" || !req.Header.IsPost() {
+		t.Errorf("request after Do = %s %s, want it restored to POST /old", req.Header.Method(), got)
+	}
+
+	req.SetRequestURI("http://downstream/loop")
+	if err := client.Do(context.Background(), req, resp); err != fasthttp.ErrTooManyRedirects {
+		t.Errorf("Do() on a redirect loop error = %v, want ErrTooManyRedirects", err)
+	}
+
+	client.config.MaxRedirects = -1
+	req.SetRequestURI("http://downstream/old")
+	if err := client.Do(context.Background(), req, resp); err != nil || resp.StatusCode() != fasthttp.StatusFound {
+		t.Errorf("Do() with redirects off = %d, %v; want the 302", resp.StatusCode(), err)
+	}
+}
+
+func TestHTTPClient_RedirectToOtherHostDropsCredentials(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]*fasthttp.RequestHeader)
+	var putBodies []string
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		mu.Lock()
+		h := &fasthttp.RequestHeader{}
+		ctx.Request.Header.CopyTo(h)
+		seen[string(ctx.Host())+string(ctx.Path())] = h
+		if ctx.IsPut() {
+			putBodies = append(putBodies, string(ctx.PostBody()))
+		}
+		mu.Unlock()
+		switch string(ctx.Path()) {
+		case "/away":
+			ctx.Redirect("http://third-party/landing", fasthttp.StatusFound)
+		case "/same":
+			ctx.Redirect("/landing", fasthttp.StatusFound)
+		case "/put":
+			ctx.Redirect("/landing", fasthttp.StatusSeeOther)
+		default:
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		}
+	})
+	client.config.SensitiveHeaders = []string{"X-API-Key"}
+	client.config.MaxRetries = 1
+	client.config.RetryBackoff = time.Millisecond
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-API-Key", "key")
+	req.Header.SetCookie("session", "s1")
+
+	req.SetRequestURI("http://downstream/away")
+	if err := client.Do(context.Background(), req, resp); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	landing := seen["third-party/landing"]
+	if landing == nil {
+		t.Fatal("redirect to another host was not followed")
+	}
+	for _, name := range []string{"Authorization", "X-API-Key", "Cookie"} {
+		if v := landing.Peek(name); len(v) != 0 {
+			t.Errorf("%s = %q sent to another host", name, v)
+		}
+	}
+	// The caller's request keeps its headers for the next call
+	if string(req.Header.Peek("Authorization")) != "Bearer secret" || string(req.Header.Cookie("session")) != "s1" {
+		t.Errorf("request headers after Do = %s, want them restored", req.Header.String())
+	}
+
+	req.SetRequestURI("http://downstream/same")
+	_ = client.Do(context.Background(), req, resp)
+	if landing := seen["downstream/landing"]; landing == nil || string(landing.Peek("Authorization")) != "Bearer secret" {
+		t.Error("a same-host redirect should keep Authorization")
+	}
+
+	// A retried PUT that was redirected with 303 is resent with its body
+	req.SetRequestURI("http://downstream/put")
+	req.Header.SetMethod(fasthttp.MethodPut)
+	req.SetBodyString("payload")
+	_ = client.Do(context.Background(), req, resp)
+	if len(putBodies) != 2 || putBodies[0] != "payload" || putBodies[1] != "payload" {
+		t.Errorf("PUT bodies = %q, want the payload on both attempts", putBodies)
+	}
+}
+
+func TestHTTPClient_RefusesHTTPSToHTTPRedirect(t *testing.T) {
+	cert, key, err := fasthttp.GenerateTestCertificate("downstream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln := fasthttputil.NewInmemoryListener()
+	var plainHits int32
+	srv := &fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsTLS() {
+			atomic.AddInt32(&plainHits, 1)
+			return
+		}
+		ctx.Redirect("http://downstream/plain", fasthttp.StatusFound)
+	}}
+	go func() { _ = srv.ServeTLSEmbed(ln, cert, key) }()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	client := NewHTTPClient()
+	client.client.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+	client.client.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+
+	if _, err := client.Get(context.Background(), "https://downstream/secure"); err != ErrInsecureRedirect {
+		t.Errorf("Get() error = %v, want ErrInsecureRedirect", err)
+	}
+	if atomic.LoadInt32(&plainHits) != 0 {
+		t.Error("the http redirect target was requested")
+	}
+}
+
+func TestHTTPClient_ContextDeadlineOverridesTimeout(t *testing.T) {
+	client := newInMemoryHTTPClient(t, func(ctx *fasthttp.RequestCtx) {
+		time.Sleep(50 * time.Millisecond)
+	})
+	client.config.Timeout = 10 * time.Millisecond
+
+	// A longer caller deadline wins over the client timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := client.Get(ctx, "http://downstream/slow")
+	if err != nil {
+		t.Fatalf("Get() with a 1s deadline error = %v", err)
+	}
+	fasthttp.ReleaseResponse(resp)
+
+	// Without one, the client timeout applies
+	if _, err := client.Get(context.Background(), "http://downstream/slow"); err != fasthttp.ErrTimeout {
+		t.Errorf("Get() without a deadline error = %v, want ErrTimeout", err)
+	}
+}
@@ -0,0 +1,20 @@
+package web
+
+// AddMetricLabel sets a business label (e.g. tenant or plan) on the metrics
+// recorded for the current request. The metrics middleware reads the labels
+// when the handler returns; Prometheus needs a fixed label set, so only keys
+// declared in prometheus.MetricsConfig.HTTPLabels are recorded and others are
+// ignored. Call it from the handler's goroutine.
+//
+//	web.AddMetricLabel(ctx, "plan", account.Plan)
+func AddMetricLabel(ctx *FastRequestContext, key, value string) {
+	if ctx.metricLabels == nil {
+		ctx.metricLabels = make(map[string]string)
+	}
+	ctx.metricLabels[key] = value
+}
+
+// MetricLabels returns the labels set with AddMetricLabel (nil if none)
+func MetricLabels(ctx *FastRequestContext) map[string]string {
+	return ctx.metricLabels
+}
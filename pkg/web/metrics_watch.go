@@ -0,0 +1,53 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// OnMetrics calls fn with a Metrics snapshot every interval until the server
+// stops, the GoCMD closes or the returned cancel is called, so apps can push
+// server metrics to a gauge or an alert without owning a ticker:
+//
+//	server.OnMetrics(time.Second, func(m web.ServerMetrics) {
+//		queueGauge.Set(m.QueueUtilization)
+//	})
+//
+// Callbacks run one at a time on their own goroutine; a tick that comes while
+// fn is still running is skipped. A panicking fn is logged and keeps its schedule.
+// Fail-fast: panics on a non-positive interval or a nil fn
+func (s *FastHTTPServer) OnMetrics(interval time.Duration, fn func(ServerMetrics)) (cancel func()) {
+	failfast.If(interval > 0, "metrics interval must be positive")
+	failfast.NotNil(fn, "fn")
+
+	ctx, cancelFn := context.WithCancel(s.metricsCtx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.emitMetrics(fn)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(cancelFn) }
+}
+
+// emitMetrics calls fn with the current metrics, isolating panics
+func (s *FastHTTPServer) emitMetrics(fn func(ServerMetrics)) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.Logger().Error(fmt.Sprintf("metrics callback panic (isolated): %v", r))
+		}
+	}()
+	fn(s.Metrics())
+}
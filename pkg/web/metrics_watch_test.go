@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+)
+
+func TestFastHTTPServer_OnMetrics(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(":0"))
+	server.FastRouter().GETFast("/ok", func(ctx *FastRequestContext) error {
+		return ctx.JSON(200, map[string]string{"status": "ok"})
+	})
+
+	snapshots := make(chan ServerMetrics, 100)
+	cancel := server.OnMetrics(5*time.Millisecond, func(m ServerMetrics) { snapshots <- m })
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod("GET")
+	ctx.Request.SetRequestURI("/ok")
+	server.handleRequest(ctx)
+
+	deadline := time.After(time.Second)
+	for seen := false; !seen; {
+		select {
+		case m := <-snapshots:
+			seen = m.TotalRequests == 1 && m.Workers == server.workers
+		case <-deadline:
+			t.Fatal("no snapshot with the request counted")
+		}
+	}
+
+	cancel()
+	cancel() // Idempotent
+	time.Sleep(20 * time.Millisecond)
+	for len(snapshots) > 0 {
+		<-snapshots
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := len(snapshots); n != 0 {
+		t.Errorf("%d snapshots after cancel, want 0", n)
+	}
+
+	// A panicking callback keeps running; closing the GoCMD stops it
+	calls := make(chan struct{}, 100)
+	server.OnMetrics(5*time.Millisecond, func(ServerMetrics) {
+		calls <- struct{}{}
+		panic("callback bug")
+	})
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatalf("callback ran %d times, want 2", i)
+		}
+	}
+	gocmd.Close()
+	time.Sleep(20 * time.Millisecond)
+	for len(calls) > 0 {
+		<-calls
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := len(calls); n != 0 {
+		t.Errorf("%d callbacks after GoCMD.Close, want 0", n)
+	}
+}
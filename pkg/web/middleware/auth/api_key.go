@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"strings"
 
@@ -12,6 +13,11 @@ type APIKeyConfig struct {
 	// ValidateKey validates an API key
 	ValidateKey func(key string) (map[string]interface{}, error)
 
+	// Validator resolves an API key to a principal (alternative to ValidateKey)
+	// The principal is stored under ClaimsKey; ok=false rejects the key
+	// Use StaticAPIKeys for a fixed key set with per-key scopes
+	Validator func(key string) (principal interface{}, ok bool)
+
 	// KeyLookup is the key lookup pattern (default: "header:X-API-Key")
 	// Format: "header:<name>", "query:<name>", "cookie:<name>"
 	KeyLookup string
@@ -38,8 +44,13 @@ func DefaultAPIKeyConfig(validateKey func(string) (map[string]interface{}, error
 
 // APIKey middleware validates API keys
 func APIKey(config APIKeyConfig) web.FastMiddleware {
-	if config.ValidateKey == nil {
-		panic("APIKey: ValidateKey function must be provided")
+	if config.ValidateKey == nil && config.Validator == nil {
+		panic("APIKey: ValidateKey or Validator function must be provided")
+	}
+
+	claimsKey := config.ClaimsKey
+	if claimsKey == "" {
+		claimsKey = "user"
 	}
 
 	// Default key lookup
@@ -103,13 +114,23 @@ func APIKey(config APIKeyConfig) web.FastMiddleware {
 			}
 
 			// Validate API key
-			claims, err := config.ValidateKey(apiKey)
-			if err != nil {
-				return onError(ctx, fmt.Errorf("invalid API key: %w", err))
+			var principal interface{}
+			if config.Validator != nil {
+				p, ok := config.Validator(apiKey)
+				if !ok {
+					return onError(ctx, fmt.Errorf("invalid API key"))
+				}
+				principal = p
+			} else {
+				claims, err := config.ValidateKey(apiKey)
+				if err != nil {
+					return onError(ctx, fmt.Errorf("invalid API key: %w", err))
+				}
+				principal = claims
 			}
 
-			// Store claims in context
-			ctx.Set(config.ClaimsKey, claims)
+			// Store principal in context
+			ctx.Set(claimsKey, principal)
 
 			return next(ctx)
 		}
@@ -117,12 +138,60 @@ func APIKey(config APIKeyConfig) web.FastMiddleware {
 }
 
 // SimpleAPIKeyValidator creates a simple API key validator from a map
+// Keys are compared in constant time to avoid timing attacks
 func SimpleAPIKeyValidator(validKeys map[string]map[string]interface{}) func(string) (map[string]interface{}, error) {
 	return func(key string) (map[string]interface{}, error) {
-		claims, ok := validKeys[key]
-		if !ok {
+		var claims map[string]interface{}
+		found := false
+		for validKey, c := range validKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(validKey)) == 1 {
+				claims = c
+				found = true
+			}
+		}
+		if !found {
 			return nil, fmt.Errorf("invalid API key")
 		}
 		return claims, nil
 	}
 }
+
+// APIKeyEntry describes a static API key and the principal it resolves to
+type APIKeyEntry struct {
+	// Key is the secret API key
+	Key string
+
+	// ID identifies the caller (e.g. service name)
+	ID string
+
+	// Scopes granted to the key, exposed as roles so RequireAnyRole and
+	// RequireAllRoles can gate routes on them
+	Scopes []string
+}
+
+// StaticAPIKeys creates a Validator for a fixed set of keys
+// Every entry is compared in constant time so lookup time doesn't leak which key matched
+// The resolved principal is a *User with Roles set to the key's scopes
+func StaticAPIKeys(entries ...APIKeyEntry) func(string) (interface{}, bool) {
+	keys := make([]APIKeyEntry, len(entries))
+	copy(keys, entries)
+
+	return func(key string) (interface{}, bool) {
+		var match *APIKeyEntry
+		for i := range keys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(keys[i].Key)) == 1 {
+				match = &keys[i]
+			}
+		}
+		if match == nil {
+			return nil, false
+		}
+		return &User{
+			ID:    match.ID,
+			Roles: append([]string(nil), match.Scopes...),
+			Data: map[string]interface{}{
+				"auth_method": "api_key",
+			},
+		}, true
+	}
+}
@@ -1,11 +1,16 @@
 package auth_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
 	"github.com/fluxorio/fluxor/pkg/web/middleware/auth"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/valyala/fasthttp"
 )
 
 func TestJWTMiddleware(t *testing.T) {
@@ -77,3 +82,109 @@ func TestAPIKeyMiddleware(t *testing.T) {
 		t.Error("Validator should reject invalid key")
 	}
 }
+
+func TestAPIKeyMiddleware_StaticKeysWithScopes(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	apiKey := auth.APIKey(auth.APIKeyConfig{
+		Validator: auth.StaticAPIKeys(
+			auth.APIKeyEntry{Key: "reader-key", ID: "reporting", Scopes: []string{"todos:read"}},
+			auth.APIKeyEntry{Key: "admin-key", ID: "ops", Scopes: []string{"todos:read", "todos:write"}},
+		),
+	})
+	handler := apiKey(auth.RequireAnyRole("todos:write")(func(ctx *web.FastRequestContext) error {
+		user := ctx.Get("user").(*auth.User)
+		return ctx.Text(200, user.ID)
+	}))
+
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+	}{
+		{"missing key", "", 401},
+		{"unknown key", "nope", 401},
+		{"insufficient scope", "reader-key", 403},
+		{"authorized", "admin-key", 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := &fasthttp.RequestCtx{}
+			if tt.key != "" {
+				reqCtx.Request.Header.Set("X-API-Key", tt.key)
+			}
+			ctx := &web.FastRequestContext{
+				BaseRequestContext: core.NewBaseRequestContext(),
+				RequestCtx:         reqCtx,
+				GoCMD:              gocmd,
+				EventBus:           gocmd.EventBus(),
+				Params:             make(map[string]string),
+			}
+			if err := handler(ctx); err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+			if got := reqCtx.Response.StatusCode(); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	resolver := auth.RolePermissionResolver(auth.RolePermissions{
+		"admin":  {"todos:*"},
+		"member": {"todos:read", "todos:write"},
+	})
+
+	tests := []struct {
+		name        string
+		user        interface{}
+		middleware  web.FastMiddleware
+		wantStatus  int
+		wantMissing string
+	}{
+		{"no user", nil, auth.RequirePermission("todos:delete"), 401, ""},
+		{"direct permission claim", jwt.MapClaims{"permissions": []interface{}{"todos:delete"}}, auth.RequirePermission("todos:delete"), 200, ""},
+		{"missing direct permission", jwt.MapClaims{"permissions": []interface{}{"todos:read"}}, auth.RequirePermission("todos:delete"), 403, "todos:delete"},
+		{"role expanded with wildcard", jwt.MapClaims{"roles": []interface{}{"admin"}}, auth.RequirePermissionWith(resolver, "todos:delete"), 200, ""},
+		{"role lacks permission", &auth.User{ID: "1", Roles: []string{"member"}}, auth.RequirePermissionWith(resolver, "todos:delete"), 403, "todos:delete"},
+		{"permission needing escapes", jwt.MapClaims{"permissions": []interface{}{"todos:read"}}, auth.RequirePermission(`todos:"x"\`), 403, `todos:"x"\`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := &fasthttp.RequestCtx{}
+			ctx := &web.FastRequestContext{
+				BaseRequestContext: core.NewBaseRequestContext(),
+				RequestCtx:         reqCtx,
+				GoCMD:              gocmd,
+				EventBus:           gocmd.EventBus(),
+				Params:             make(map[string]string),
+			}
+			if tt.user != nil {
+				ctx.Set("user", tt.user)
+			}
+
+			handler := tt.middleware(func(ctx *web.FastRequestContext) error {
+				return ctx.Text(200, "ok")
+			})
+			if err := handler(ctx); err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+			if got := reqCtx.Response.StatusCode(); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+			if tt.wantStatus == 403 {
+				var body map[string]string
+				if err := json.Unmarshal(reqCtx.Response.Body(), &body); err != nil || body["missing_permission"] != tt.wantMissing {
+					t.Errorf("403 body should name the missing permission %q, got %s", tt.wantMissing, reqCtx.Response.Body())
+				}
+			}
+		})
+	}
+}
@@ -83,6 +83,11 @@ func JWT(config JWTConfig) web.FastMiddleware {
 		}
 	}
 
+	claimsKey := JWTClaimsKey
+	if config.ClaimsKey != "" {
+		claimsKey = web.NewContextKey[jwt.MapClaims](config.ClaimsKey)
+	}
+
 	// Default token lookup
 	tokenLookup := config.TokenLookup
 	if tokenLookup == "" {
@@ -187,17 +192,25 @@ func JWT(config JWTConfig) web.FastMiddleware {
 				return onError(ctx, fmt.Errorf("invalid token claims"))
 			}
 
-			// Store claims in context
-			ctx.Set(config.ClaimsKey, claims)
+			// Store claims in context (typed; readable via web.GetValue(ctx, JWTClaimsKey))
+			web.SetValue(ctx, claimsKey, claims)
 
 			return next(ctx)
 		}
 	}
 }
 
+// JWTClaimsKey is the typed key for JWT claims stored under the default "user" key
+//
+//	claims, ok := web.GetValue(ctx, auth.JWTClaimsKey)
+var JWTClaimsKey = web.NewContextKey[jwt.MapClaims]("user")
+
 // GetClaims extracts JWT claims from request context
 func GetClaims(ctx *web.FastRequestContext, key string) (jwt.MapClaims, error) {
-	claims, ok := ctx.Get(key).(jwt.MapClaims)
+	if key == "" {
+		return nil, fmt.Errorf("claims not found in context")
+	}
+	claims, ok := web.GetValue(ctx, web.NewContextKey[jwt.MapClaims](key))
 	if !ok {
 		return nil, fmt.Errorf("claims not found in context")
 	}
@@ -55,6 +55,12 @@ func TestJWTAuthMiddleware_Integration(t *testing.T) {
 		if err != nil {
 			return ctx.JSON(500, map[string]any{"error": "missing_user"})
 		}
+		if _, ok := web.GetValue(ctx, auth.JWTClaimsKey); !ok {
+			return ctx.JSON(500, map[string]any{"error": "missing_typed_claims"})
+		}
+		if _, err := auth.GetClaims(ctx, ""); err == nil {
+			return ctx.JSON(500, map[string]any{"error": "claims_under_empty_key"})
+		}
 		return ctx.JSON(200, map[string]any{"user_id": userID})
 	}, auth.JWT(cfg))
 
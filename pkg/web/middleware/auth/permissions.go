@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PermissionResolver maps an authenticated principal to its permission set
+// Permissions use the "resource:action" form (e.g. "todos:delete")
+type PermissionResolver func(principal interface{}) []string
+
+// RolePermissions maps a role to the permissions it grants
+type RolePermissions map[string][]string
+
+// DefaultPermissionResolver reads permissions granted directly to the principal:
+// the "permissions" (or OAuth2 "scope") claim, or User.Data["permissions"]
+func DefaultPermissionResolver(principal interface{}) []string {
+	switch u := principal.(type) {
+	case *User:
+		if u.Data == nil {
+			return nil
+		}
+		return stringSliceClaim(u.Data["permissions"])
+	case jwt.MapClaims:
+		return claimPermissions(u)
+	case map[string]interface{}:
+		return claimPermissions(u)
+	default:
+		return nil
+	}
+}
+
+// RolePermissionResolver expands the principal's roles into permissions using mapping,
+// in addition to any permissions granted directly (see DefaultPermissionResolver)
+func RolePermissionResolver(mapping RolePermissions) PermissionResolver {
+	return func(principal interface{}) []string {
+		permissions := DefaultPermissionResolver(principal)
+		roles, _ := extractRoles(principal)
+		for _, role := range roles {
+			permissions = append(permissions, mapping[role]...)
+		}
+		return permissions
+	}
+}
+
+// RequirePermission creates middleware that requires a permission granted directly
+// to the principal (see DefaultPermissionResolver)
+func RequirePermission(permission string) web.FastMiddleware {
+	return RequirePermissionWith(DefaultPermissionResolver, permission)
+}
+
+// RequirePermissionWith creates middleware that requires all of the specified
+// permissions, resolved from the principal with resolver
+// A granted permission of "*" or "resource:*" matches any action on that resource
+func RequirePermissionWith(resolver PermissionResolver, permissions ...string) web.FastMiddleware {
+	if resolver == nil {
+		panic("RequirePermissionWith: resolver must be provided")
+	}
+
+	return func(next web.FastRequestHandler) web.FastRequestHandler {
+		return func(ctx *web.FastRequestContext) error {
+			principal := ctx.Get("user")
+			if principal == nil {
+				ctx.RequestCtx.SetStatusCode(401)
+				ctx.RequestCtx.SetContentType("application/json")
+				if _, err := ctx.RequestCtx.WriteString(`{"error":"unauthorized","message":"user not found in context"}`); err != nil {
+					// Best-effort response write; ignore on error.
+				}
+				return nil
+			}
+
+			granted := resolver(principal)
+			for _, required := range permissions {
+				if !hasPermission(granted, required) {
+					ctx.RequestCtx.SetStatusCode(403)
+					ctx.RequestCtx.SetContentType("application/json")
+					// Marshal, as permissions may hold characters that need escaping
+					body, _ := json.Marshal(map[string]string{
+						"error":              "forbidden",
+						"message":            "missing required permission: " + required,
+						"missing_permission": required,
+					})
+					if _, err := ctx.RequestCtx.Write(body); err != nil {
+						// Best-effort response write; ignore on error.
+					}
+					return nil
+				}
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// claimPermissions reads the "permissions" claim, falling back to "scope"
+func claimPermissions(claims map[string]interface{}) []string {
+	if permissions := stringSliceClaim(claims["permissions"]); len(permissions) > 0 {
+		return permissions
+	}
+	return stringSliceClaim(claims["scope"])
+}
+
+// hasPermission checks whether required is covered by the granted permissions
+func hasPermission(granted []string, required string) bool {
+	resource := required
+	if i := strings.Index(required, ":"); i >= 0 {
+		resource = required[:i]
+	}
+
+	for _, permission := range granted {
+		if permission == required || permission == "*" || permission == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fluxorio/fluxor/pkg/web"
 	"github.com/golang-jwt/jwt/v5"
@@ -45,29 +46,8 @@ func RequireAnyRole(roles ...string) web.FastMiddleware {
 			}
 
 			// Extract user roles
-			var userRoles []string
-			switch u := userInterface.(type) {
-			case *User:
-				userRoles = u.Roles
-			case jwt.MapClaims:
-				// Extract roles from JWT claims
-				if rolesClaim, ok := u["roles"].([]interface{}); ok {
-					for _, r := range rolesClaim {
-						if roleStr, ok := r.(string); ok {
-							userRoles = append(userRoles, roleStr)
-						}
-					}
-				}
-			case map[string]interface{}:
-				// Extract roles from map
-				if rolesClaim, ok := u["roles"].([]interface{}); ok {
-					for _, r := range rolesClaim {
-						if roleStr, ok := r.(string); ok {
-							userRoles = append(userRoles, roleStr)
-						}
-					}
-				}
-			default:
+			userRoles, ok := extractRoles(userInterface)
+			if !ok {
 				ctx.RequestCtx.SetStatusCode(403)
 				ctx.RequestCtx.SetContentType("application/json")
 				if _, err := ctx.RequestCtx.WriteString(`{"error":"forbidden","message":"invalid user type"}`); err != nil {
@@ -120,27 +100,8 @@ func RequireAllRoles(roles ...string) web.FastMiddleware {
 			}
 
 			// Extract user roles
-			var userRoles []string
-			switch u := userInterface.(type) {
-			case *User:
-				userRoles = u.Roles
-			case jwt.MapClaims:
-				if rolesClaim, ok := u["roles"].([]interface{}); ok {
-					for _, r := range rolesClaim {
-						if roleStr, ok := r.(string); ok {
-							userRoles = append(userRoles, roleStr)
-						}
-					}
-				}
-			case map[string]interface{}:
-				if rolesClaim, ok := u["roles"].([]interface{}); ok {
-					for _, r := range rolesClaim {
-						if roleStr, ok := r.(string); ok {
-							userRoles = append(userRoles, roleStr)
-						}
-					}
-				}
-			default:
+			userRoles, ok := extractRoles(userInterface)
+			if !ok {
 				ctx.RequestCtx.SetStatusCode(403)
 				ctx.RequestCtx.SetContentType("application/json")
 				if _, err := ctx.RequestCtx.WriteString(`{"error":"forbidden","message":"invalid user type"}`); err != nil {
@@ -170,3 +131,39 @@ func RequireAllRoles(roles ...string) web.FastMiddleware {
 		}
 	}
 }
+
+// extractRoles returns the roles of a principal stored in the request context
+// Supports *User, jwt.MapClaims and map[string]interface{} with a "roles" claim
+func extractRoles(principal interface{}) ([]string, bool) {
+	switch u := principal.(type) {
+	case *User:
+		return u.Roles, true
+	case jwt.MapClaims:
+		return stringSliceClaim(u["roles"]), true
+	case map[string]interface{}:
+		return stringSliceClaim(u["roles"]), true
+	default:
+		return nil, false
+	}
+}
+
+// stringSliceClaim converts a claim value ([]interface{} from JSON or []string) to []string
+func stringSliceClaim(claim interface{}) []string {
+	switch v := claim.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	case string:
+		// Space-separated claim (OAuth2 "scope" style)
+		return strings.Fields(v)
+	default:
+		return nil
+	}
+}
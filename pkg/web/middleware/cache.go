@@ -0,0 +1,387 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
+)
+
+// CachedResponse is a response stored by the Cache middleware
+type CachedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers"` // Repeated headers (e.g. Vary) keep every value
+	Body       []byte              `json:"body"`
+	StoredAt   time.Time           `json:"stored_at"`
+	ExpiresAt  time.Time           `json:"expires_at"` // End of freshness; stale-while-revalidate starts here
+}
+
+// CacheStore stores responses for the Cache middleware and must be safe for concurrent use.
+// A Redis store can JSON-encode CachedResponse and use ttl as the key expiry.
+type CacheStore interface {
+	// Get returns the response for key, or nil if there is none
+	Get(key string) (*CachedResponse, error)
+
+	// Set stores resp for key; the store may drop it after ttl
+	Set(key string, resp *CachedResponse, ttl time.Duration) error
+
+	// Delete removes key
+	Delete(key string) error
+}
+
+// CacheConfig configures response caching middleware
+type CacheConfig struct {
+	// TTL is how long a cached response is fresh (default: 1m)
+	TTL time.Duration
+
+	// StaleWhileRevalidate serves a stale response for this long after TTL while
+	// one background request refreshes it (default: 0, disabled)
+	StaleWhileRevalidate time.Duration
+
+	// RevalidateTimeout bounds a background refresh: the handler's Context()
+	// ends after it (default: DefaultRevalidateTimeout)
+	RevalidateTimeout time.Duration
+
+	// Methods are the cacheable request methods (default: GET)
+	Methods []string
+
+	// StatusCodes are the cacheable response status codes (default: 200)
+	StatusCodes []int
+
+	// VaryHeaders are request headers whose values are part of the cache key,
+	// e.g. Accept-Encoding when Compression runs inside Cache
+	VaryHeaders []string
+
+	// SkipPaths is a list of paths (or path prefixes) never cached
+	SkipPaths []string
+
+	// Logger is the logger to use for store errors (default: core.NewDefaultLogger())
+	Logger core.Logger
+}
+
+// DefaultRevalidateTimeout bounds a background stale-while-revalidate refresh by default
+const DefaultRevalidateTimeout = 30 * time.Second
+
+// DefaultCacheConfig returns a default cache configuration
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		TTL:         time.Minute,
+		Methods:     []string{"GET"},
+		StatusCodes: []int{200},
+		SkipPaths:   []string{},
+		Logger:      core.NewDefaultLogger(),
+	}
+}
+
+// Response headers not replayed from cache (set per response by the server)
+var uncachedHeaders = map[string]bool{
+	"Content-Length": true,
+	"Date":           true,
+	"Connection":     true,
+	"Server":         true,
+}
+
+// Cache middleware caches responses keyed by method, URI and VaryHeaders.
+// Requests with Cache-Control: no-cache skip the lookup and refresh the entry;
+// no-store bypasses the cache entirely, and so do requests carrying
+// Authorization or Cookie headers, whose responses may be per user. Responses
+// with Set-Cookie or Cache-Control: no-store/private are never stored.
+// Sets X-Cache: HIT, STALE or MISS on cacheable requests.
+func Cache(store CacheStore, config CacheConfig) web.FastMiddleware {
+	if store == nil {
+		panic("Cache: store cannot be nil")
+	}
+	if config.TTL < 0 || config.StaleWhileRevalidate < 0 || config.RevalidateTimeout < 0 {
+		panic("Cache: TTL, StaleWhileRevalidate and RevalidateTimeout cannot be negative")
+	}
+
+	defaults := DefaultCacheConfig()
+	ttl := config.TTL
+	if ttl == 0 {
+		ttl = defaults.TTL
+	}
+	revalidateTimeout := config.RevalidateTimeout
+	if revalidateTimeout == 0 {
+		revalidateTimeout = DefaultRevalidateTimeout
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = core.NewDefaultLogger()
+	}
+
+	methods := config.Methods
+	if len(methods) == 0 {
+		methods = defaults.Methods
+	}
+	methodMap := make(map[string]bool)
+	for _, m := range methods {
+		methodMap[strings.ToUpper(m)] = true
+	}
+
+	statusCodes := config.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaults.StatusCodes
+	}
+	statusMap := make(map[int]bool)
+	for _, code := range statusCodes {
+		statusMap[code] = true
+	}
+
+	// Keys with a background revalidation in flight
+	var refreshMu sync.Mutex
+	refreshing := make(map[string]bool)
+
+	storeResponse := func(key string, ctx *web.FastRequestContext) {
+		resp, ok := captureResponse(ctx.RequestCtx, statusMap)
+		if !ok {
+			return
+		}
+		resp.ExpiresAt = resp.StoredAt.Add(ttl)
+		if err := store.Set(key, resp, ttl+config.StaleWhileRevalidate); err != nil {
+			logger.Error(fmt.Sprintf("cache store set failed: %v", err))
+		}
+	}
+
+	return func(next web.FastRequestHandler) web.FastRequestHandler {
+		return func(ctx *web.FastRequestContext) error {
+			if !methodMap[string(ctx.Method())] {
+				return next(ctx)
+			}
+			path := string(ctx.Path())
+			for _, skipPath := range config.SkipPaths {
+				if path == skipPath || strings.HasPrefix(path, skipPath) {
+					return next(ctx)
+				}
+			}
+
+			reqCacheControl := strings.ToLower(string(ctx.RequestCtx.Request.Header.Peek("Cache-Control")))
+			if strings.Contains(reqCacheControl, "no-store") || isPersonalized(ctx.RequestCtx) {
+				return next(ctx)
+			}
+
+			key := cacheKey(ctx.RequestCtx, config.VaryHeaders)
+
+			if !strings.Contains(reqCacheControl, "no-cache") {
+				cached, err := store.Get(key)
+				if err != nil {
+					logger.Error(fmt.Sprintf("cache store get failed: %v", err))
+				}
+				now := time.Now()
+				switch {
+				case cached == nil:
+				case now.Before(cached.ExpiresAt):
+					writeCached(ctx.RequestCtx, cached, "HIT", now)
+					return nil
+				case now.Before(cached.ExpiresAt.Add(config.StaleWhileRevalidate)):
+					refreshMu.Lock()
+					start := !refreshing[key]
+					refreshing[key] = true
+					refreshMu.Unlock()
+					if start {
+						bg, cancel := detachedContext(ctx, revalidateTimeout)
+						go func() {
+							defer cancel()
+							defer func() {
+								if r := recover(); r != nil {
+									logger.Error(fmt.Sprintf("cache revalidation panicked: %v", r))
+								}
+								refreshMu.Lock()
+								delete(refreshing, key)
+								refreshMu.Unlock()
+							}()
+							if err := next(bg); err != nil {
+								logger.Error(fmt.Sprintf("cache revalidation failed: %v", err))
+								return
+							}
+							storeResponse(key, bg)
+						}()
+					}
+					writeCached(ctx.RequestCtx, cached, "STALE", now)
+					return nil
+				}
+			}
+
+			err := next(ctx)
+			if err == nil {
+				storeResponse(key, ctx)
+			}
+			ctx.RequestCtx.Response.Header.Set("X-Cache", "MISS")
+			return err
+		}
+	}
+}
+
+// isPersonalized reports whether the request carries credentials, so its response
+// may differ per user and must not be shared (a hit would also skip auth handlers)
+func isPersonalized(rc *fasthttp.RequestCtx) bool {
+	return len(rc.Request.Header.Peek("Authorization")) > 0 || len(rc.Request.Header.Peek("Cookie")) > 0
+}
+
+// cacheKey joins method, request URI and vary header values
+func cacheKey(rc *fasthttp.RequestCtx, vary []string) string {
+	var b strings.Builder
+	b.Write(rc.Method())
+	b.WriteByte(' ')
+	b.Write(rc.RequestURI())
+	for _, h := range vary {
+		b.WriteByte('\n')
+		b.WriteString(h)
+		b.WriteByte(':')
+		b.Write(rc.Request.Header.Peek(h))
+	}
+	return b.String()
+}
+
+// captureResponse copies a cacheable response out of rc
+func captureResponse(rc *fasthttp.RequestCtx, statusMap map[int]bool) (*CachedResponse, bool) {
+	res := &rc.Response
+	if !statusMap[res.StatusCode()] {
+		return nil, false
+	}
+	cc := strings.ToLower(string(res.Header.Peek("Cache-Control")))
+	if strings.Contains(cc, "no-store") || strings.Contains(cc, "private") {
+		return nil, false
+	}
+	hasCookie := false
+	res.Header.VisitAllCookie(func(key, value []byte) { hasCookie = true })
+	if hasCookie {
+		return nil, false
+	}
+
+	headers := make(map[string][]string)
+	res.Header.VisitAll(func(key, value []byte) {
+		name := string(key)
+		if !uncachedHeaders[name] && name != "X-Cache" {
+			headers[name] = append(headers[name], string(value))
+		}
+	})
+	body := append([]byte(nil), res.Body()...)
+	return &CachedResponse{
+		StatusCode: res.StatusCode(),
+		Headers:    headers,
+		Body:       body,
+		StoredAt:   time.Now(),
+	}, true
+}
+
+func writeCached(rc *fasthttp.RequestCtx, cached *CachedResponse, status string, now time.Time) {
+	rc.Response.SetStatusCode(cached.StatusCode)
+	for name, values := range cached.Headers {
+		rc.Response.Header.Del(name)
+		for _, value := range values {
+			rc.Response.Header.Add(name, value)
+		}
+	}
+	rc.Response.Header.Set("Age", strconv.Itoa(int(now.Sub(cached.StoredAt).Seconds())))
+	rc.Response.Header.Set("X-Cache", status)
+	rc.Response.SetBody(cached.Body)
+}
+
+// detachedContext copies the request into a fresh context the handler can run
+// in after the original request has completed; its Context() ends after timeout
+func detachedContext(ctx *web.FastRequestContext, timeout time.Duration) (*web.FastRequestContext, context.CancelFunc) {
+	rc := &fasthttp.RequestCtx{}
+	ctx.RequestCtx.Request.CopyTo(&rc.Request)
+	params := make(map[string]string, len(ctx.Params))
+	for k, v := range ctx.Params {
+		params[k] = v
+	}
+	bg := &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         rc,
+		GoCMD:              ctx.GoCMD,
+		EventBus:           ctx.EventBus,
+		Params:             params,
+	}
+	bounded, cancel := context.WithTimeout(context.Background(), timeout)
+	bg.Set("span_context", bounded) // Context() returns it
+	return bg, cancel
+}
+
+// MemoryCacheStore is an in-memory LRU CacheStore
+type MemoryCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // Front = most recently used
+}
+
+type memoryCacheEntry struct {
+	key       string
+	resp      *CachedResponse
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore creates an LRU store holding at most maxEntries responses - fail-fast on invalid size
+func NewMemoryCacheStore(maxEntries int) *MemoryCacheStore {
+	if maxEntries <= 0 {
+		panic("maxEntries must be positive")
+	}
+	return &MemoryCacheStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements CacheStore
+func (s *MemoryCacheStore) Get(key string) (*CachedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.order.Remove(el)
+		delete(s.entries, key)
+		return nil, nil
+	}
+	s.order.MoveToFront(el)
+	return entry.resp, nil
+}
+
+// Set implements CacheStore, evicting the least recently used entry when full
+func (s *MemoryCacheStore) Set(key string, resp *CachedResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := &memoryCacheEntry{key: key, resp: resp, expiresAt: time.Now().Add(ttl)}
+	if el, ok := s.entries[key]; ok {
+		el.Value = entry
+		s.order.MoveToFront(el)
+		return nil
+	}
+	s.entries[key] = s.order.PushFront(entry)
+	if s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// Delete implements CacheStore
+func (s *MemoryCacheStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted
+func (s *MemoryCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
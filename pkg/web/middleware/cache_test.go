@@ -0,0 +1,253 @@
+package middleware_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/fluxorio/fluxor/pkg/web/middleware"
+	"github.com/valyala/fasthttp"
+)
+
+func newCacheContext(method, uri string, headers ...string) *web.FastRequestContext {
+	rc := &fasthttp.RequestCtx{}
+	rc.Request.Header.SetMethod(method)
+	rc.Request.SetRequestURI(uri)
+	for i := 0; i+1 < len(headers); i += 2 {
+		rc.Request.Header.Set(headers[i], headers[i+1])
+	}
+	return &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         rc,
+		Params:             make(map[string]string),
+	}
+}
+
+// countingHandler answers with the call count so cached responses are recognizable
+func countingHandler(calls *int64, status int) web.FastRequestHandler {
+	return func(ctx *web.FastRequestContext) error {
+		n := atomic.AddInt64(calls, 1)
+		return ctx.JSON(status, map[string]interface{}{"n": n})
+	}
+}
+
+func TestCache_HitMissAndKeying(t *testing.T) {
+	var calls int64
+	config := middleware.DefaultCacheConfig()
+	config.VaryHeaders = []string{"Accept-Language"}
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), config)(countingHandler(&calls, 200))
+
+	run := func(method, uri string, headers ...string) *web.FastRequestContext {
+		t.Helper()
+		ctx := newCacheContext(method, uri, headers...)
+		if err := handler(ctx); err != nil {
+			t.Fatalf("handler error = %v", err)
+		}
+		return ctx
+	}
+
+	first := run("GET", "/stats")
+	if got := string(first.RequestCtx.Response.Header.Peek("X-Cache")); got != "MISS" {
+		t.Errorf("first X-Cache = %q, want MISS", got)
+	}
+	second := run("GET", "/stats")
+	if got := string(second.RequestCtx.Response.Header.Peek("X-Cache")); got != "HIT" {
+		t.Errorf("second X-Cache = %q, want HIT", got)
+	}
+	if string(second.RequestCtx.Response.Body()) != string(first.RequestCtx.Response.Body()) {
+		t.Errorf("cached body = %s, want %s", second.RequestCtx.Response.Body(), first.RequestCtx.Response.Body())
+	}
+	if ct := string(second.RequestCtx.Response.Header.ContentType()); ct != "application/json" {
+		t.Errorf("cached Content-Type = %q, want application/json", ct)
+	}
+
+	// Query, vary headers and method are part of the key; POST is not cacheable by default
+	run("GET", "/stats?page=2")
+	run("GET", "/stats", "Accept-Language", "de")
+	run("POST", "/stats")
+	run("POST", "/stats")
+	if got := atomic.LoadInt64(&calls); got != 5 {
+		t.Errorf("handler calls = %d, want 5", got)
+	}
+}
+
+func TestCache_RequestNoCacheRefreshes(t *testing.T) {
+	var calls int64
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), middleware.DefaultCacheConfig())(countingHandler(&calls, 200))
+
+	_ = handler(newCacheContext("GET", "/stats"))
+	refreshed := newCacheContext("GET", "/stats", "Cache-Control", "no-cache")
+	_ = handler(refreshed)
+	_ = handler(newCacheContext("GET", "/stats", "Cache-Control", "no-store"))
+	hit := newCacheContext("GET", "/stats")
+	_ = handler(hit)
+
+	if got := atomic.LoadInt64(&calls); got != 3 {
+		t.Fatalf("handler calls = %d, want 3", got)
+	}
+	// no-cache stored its fresh response; no-store did not
+	if string(hit.RequestCtx.Response.Body()) != string(refreshed.RequestCtx.Response.Body()) {
+		t.Errorf("body = %s, want refreshed %s", hit.RequestCtx.Response.Body(), refreshed.RequestCtx.Response.Body())
+	}
+}
+
+func TestCache_OnlyConfiguredStatusCodes(t *testing.T) {
+	var calls int64
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), middleware.DefaultCacheConfig())(countingHandler(&calls, 500))
+
+	_ = handler(newCacheContext("GET", "/stats"))
+	_ = handler(newCacheContext("GET", "/stats"))
+	if got := atomic.LoadInt64(&calls); got != 2 {
+		t.Errorf("handler calls = %d, want 2 (500 is not cached)", got)
+	}
+
+	noStore := middleware.Cache(middleware.NewMemoryCacheStore(10), middleware.DefaultCacheConfig())(func(ctx *web.FastRequestContext) error {
+		atomic.AddInt64(&calls, 1)
+		ctx.RequestCtx.Response.Header.Set("Cache-Control", "private")
+		return ctx.JSON(200, map[string]interface{}{"user": "me"})
+	})
+	calls = 0
+	_ = noStore(newCacheContext("GET", "/me"))
+	_ = noStore(newCacheContext("GET", "/me"))
+	if got := atomic.LoadInt64(&calls); got != 2 {
+		t.Errorf("handler calls = %d, want 2 (private responses are not cached)", got)
+	}
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	var calls int64
+	config := middleware.DefaultCacheConfig()
+	config.TTL = 20 * time.Millisecond
+	config.StaleWhileRevalidate = time.Second
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), config)(countingHandler(&calls, 200))
+
+	_ = handler(newCacheContext("GET", "/stats"))
+	time.Sleep(40 * time.Millisecond)
+
+	stale := newCacheContext("GET", "/stats")
+	_ = handler(stale)
+	if got := string(stale.RequestCtx.Response.Header.Peek("X-Cache")); got != "STALE" {
+		t.Fatalf("X-Cache = %q, want STALE", got)
+	}
+	if body := string(stale.RequestCtx.Response.Body()); body != `{"n":1}` {
+		t.Errorf("stale body = %s, want the first response", body)
+	}
+
+	// The background refresh replaces the entry
+	deadline := time.Now().Add(time.Second)
+	for {
+		fresh := newCacheContext("GET", "/stats")
+		_ = handler(fresh)
+		if string(fresh.RequestCtx.Response.Header.Peek("X-Cache")) == "HIT" && string(fresh.RequestCtx.Response.Body()) == `{"n":2}` {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entry not revalidated; last body %s", fresh.RequestCtx.Response.Body())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := atomic.LoadInt64(&calls); got != 2 {
+		t.Errorf("handler calls = %d, want 2 (one background refresh)", got)
+	}
+}
+
+func TestCache_RevalidationContextIsBounded(t *testing.T) {
+	config := middleware.DefaultCacheConfig()
+	config.TTL = 10 * time.Millisecond
+	config.StaleWhileRevalidate = time.Second
+	config.RevalidateTimeout = 50 * time.Millisecond
+	deadlines := make(chan bool, 2)
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), config)(func(ctx *web.FastRequestContext) error {
+		_, ok := ctx.Context().Deadline()
+		deadlines <- ok
+		return ctx.JSON(200, "ok")
+	})
+
+	_ = handler(newCacheContext("GET", "/stats"))
+	<-deadlines
+	time.Sleep(20 * time.Millisecond)
+	_ = handler(newCacheContext("GET", "/stats"))
+	select {
+	case ok := <-deadlines:
+		if !ok {
+			t.Error("background revalidation context has no deadline")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no background revalidation")
+	}
+}
+
+func TestCache_SkipsCredentialedRequests(t *testing.T) {
+	var calls int64
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), middleware.DefaultCacheConfig())(countingHandler(&calls, 200))
+
+	for _, header := range []string{"Authorization", "Cookie"} {
+		for i := 0; i < 2; i++ {
+			ctx := newCacheContext("GET", "/me", header, "alice")
+			_ = handler(ctx)
+			if got := ctx.RequestCtx.Response.Header.Peek("X-Cache"); len(got) != 0 {
+				t.Errorf("%s request X-Cache = %q, want no caching", header, got)
+			}
+		}
+	}
+	// Nor are they answered from an entry an anonymous request stored
+	_ = handler(newCacheContext("GET", "/me"))
+	_ = handler(newCacheContext("GET", "/me", "Authorization", "Bearer bob"))
+	if got := atomic.LoadInt64(&calls); got != 6 {
+		t.Errorf("handler calls = %d, want 6", got)
+	}
+}
+
+func TestCache_KeepsRepeatedHeaders(t *testing.T) {
+	handler := middleware.Cache(middleware.NewMemoryCacheStore(10), middleware.DefaultCacheConfig())(func(ctx *web.FastRequestContext) error {
+		ctx.RequestCtx.Response.Header.Add("Vary", "Accept-Encoding")
+		ctx.RequestCtx.Response.Header.Add("Vary", "Accept-Language")
+		return ctx.JSON(200, "ok")
+	})
+	_ = handler(newCacheContext("GET", "/page"))
+	hit := newCacheContext("GET", "/page")
+	_ = handler(hit)
+
+	var vary []string
+	hit.RequestCtx.Response.Header.VisitAll(func(key, value []byte) {
+		if string(key) == "Vary" {
+			vary = append(vary, string(value))
+		}
+	})
+	if len(vary) != 2 {
+		t.Errorf("cached Vary headers = %v, want both values", vary)
+	}
+}
+
+func TestMemoryCacheStore_LRUAndTTL(t *testing.T) {
+	store := middleware.NewMemoryCacheStore(2)
+	resp := func(i int) *middleware.CachedResponse {
+		return &middleware.CachedResponse{StatusCode: 200, Body: []byte(fmt.Sprint(i))}
+	}
+
+	_ = store.Set("a", resp(1), time.Minute)
+	_ = store.Set("b", resp(2), time.Minute)
+	if got, _ := store.Get("a"); got == nil { // a is now most recently used
+		t.Fatal("Get(a) = nil, want entry")
+	}
+	_ = store.Set("c", resp(3), time.Minute)
+	if got, _ := store.Get("b"); got != nil {
+		t.Errorf("Get(b) = %v, want evicted", got)
+	}
+	if store.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", store.Len())
+	}
+
+	_ = store.Set("short", resp(4), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if got, _ := store.Get("short"); got != nil {
+		t.Errorf("Get(short) = %v, want expired", got)
+	}
+	_ = store.Delete("c")
+	if got, _ := store.Get("c"); got != nil {
+		t.Errorf("Get(c) after Delete = %v, want nil", got)
+	}
+}
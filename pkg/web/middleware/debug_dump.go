@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+)
+
+// debugDumpEnabled switches every DebugDump middleware on or off
+var debugDumpEnabled atomic.Bool
+
+// SetDebugDump turns dumping on or off for every DebugDump middleware in the
+// process, e.g. from a flag or an admin endpoint; it takes effect on the next
+// request. Off by default: bodies may hold credentials and personal data, so
+// only enable it in development or while debugging.
+func SetDebugDump(enabled bool) {
+	debugDumpEnabled.Store(enabled)
+}
+
+// DebugDumpEnabled reports whether SetDebugDump turned dumping on
+func DebugDumpEnabled() bool {
+	return debugDumpEnabled.Load()
+}
+
+// DebugConfig configures the DebugDump middleware
+type DebugConfig struct {
+	// MaxBodyBytes truncates logged request and response bodies (default: 1024)
+	MaxBodyBytes int
+
+	// RedactHeaders are logged as "[REDACTED]", matched case-insensitively
+	// (default: Authorization, Cookie, Set-Cookie)
+	RedactHeaders []string
+
+	// Logger is the logger to use (default: core.NewDefaultLogger())
+	Logger core.Logger
+}
+
+// DefaultDebugConfig returns the default debug dump configuration
+func DefaultDebugConfig() DebugConfig {
+	return DebugConfig{
+		MaxBodyBytes:  1024,
+		RedactHeaders: []string{"Authorization", "Cookie", "Set-Cookie"},
+	}
+}
+
+// DebugDump logs each request's method, path, headers and body, then the
+// response's status, headers and body, with bodies truncated and sensitive
+// headers redacted, at Debug level. It only dumps while SetDebugDump is on.
+//
+// Cost: while on, every request copies and formats both bodies and all headers,
+// and a streamed response (SetBodyStream) is logged as "<stream>" rather than
+// read. While off, a request costs one atomic load.
+func DebugDump(config DebugConfig) web.FastMiddleware {
+	defaults := DefaultDebugConfig()
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	if config.RedactHeaders == nil {
+		config.RedactHeaders = defaults.RedactHeaders
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = core.NewDefaultLogger()
+	}
+
+	redact := make(map[string]bool, len(config.RedactHeaders))
+	for _, h := range config.RedactHeaders {
+		redact[strings.ToLower(h)] = true
+	}
+	dumpHeaders := func(visit func(func(key, value []byte))) map[string]string {
+		headers := make(map[string]string)
+		visit(func(key, value []byte) {
+			k := string(key)
+			if redact[strings.ToLower(k)] {
+				headers[k] = "[REDACTED]"
+				return
+			}
+			headers[k] = string(value)
+		})
+		return headers
+	}
+
+	return func(next web.FastRequestHandler) web.FastRequestHandler {
+		return func(ctx *web.FastRequestContext) error {
+			if !debugDumpEnabled.Load() {
+				return next(ctx)
+			}
+			method := string(ctx.Method())
+			path := string(ctx.Path())
+
+			req := &ctx.RequestCtx.Request
+			logger.WithFields(map[string]interface{}{
+				"request_id": ctx.RequestID(),
+				"method":     method,
+				"path":       path,
+				"headers":    dumpHeaders(req.Header.VisitAll),
+				"body":       truncateBody(req.Body(), config.MaxBodyBytes),
+			}).Debug(fmt.Sprintf("Debug request: %s %s", method, path))
+
+			err := next(ctx)
+
+			resp := &ctx.RequestCtx.Response
+			body := "<stream>"
+			if !resp.IsBodyStream() {
+				body = truncateBody(resp.Body(), config.MaxBodyBytes)
+			}
+			fields := map[string]interface{}{
+				"request_id": ctx.RequestID(),
+				"method":     method,
+				"path":       path,
+				"status":     resp.StatusCode(),
+				"headers":    dumpHeaders(resp.Header.VisitAll),
+				"body":       body,
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			}
+			logger.WithFields(fields).Debug(fmt.Sprintf("Debug response: %s %s - %d", method, path, resp.StatusCode()))
+
+			return err
+		}
+	}
+}
+
+// truncateBody renders body as a string of at most max bytes, noting how much was cut
+func truncateBody(body []byte, max int) string {
+	if len(body) <= max {
+		return string(body)
+	}
+	return fmt.Sprintf("%s...(%d more bytes)", body[:max], len(body)-max)
+}
@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/fluxorio/fluxor/pkg/web/middleware"
+)
+
+// fieldsLogger records the fields of each entry
+type fieldsLogger struct {
+	fields  map[string]interface{}
+	entries *[]map[string]interface{}
+}
+
+func newFieldsLogger() *fieldsLogger {
+	return &fieldsLogger{entries: &[]map[string]interface{}{}}
+}
+
+func (l *fieldsLogger) record(level string) {
+	*l.entries = append(*l.entries, map[string]interface{}{"level": level, "fields": l.fields})
+}
+func (l *fieldsLogger) Error(args ...interface{}) { l.record("error") }
+func (l *fieldsLogger) Info(args ...interface{})  { l.record("info") }
+func (l *fieldsLogger) Debug(args ...interface{}) { l.record("debug") }
+func (l *fieldsLogger) WithFields(fields map[string]interface{}) core.Logger {
+	return &fieldsLogger{fields: fields, entries: l.entries}
+}
+func (l *fieldsLogger) WithContext(ctx context.Context) core.Logger { return l }
+
+func TestDebugDump(t *testing.T) {
+	middleware.SetDebugDump(true)
+	defer middleware.SetDebugDump(false)
+	logger := newFieldsLogger()
+	config := middleware.DefaultDebugConfig()
+	config.MaxBodyBytes = 8
+	config.Logger = logger
+
+	handler := middleware.DebugDump(config)(func(ctx *web.FastRequestContext) error {
+		ctx.RequestCtx.Response.Header.Set("X-Trace", "abc")
+		return ctx.Text(201, "created-resource")
+	})
+
+	ctx := newCacheContext("POST", "/api/users", "Authorization", "Bearer secret", "X-Client", "cli")
+	ctx.RequestCtx.Request.SetBodyString(`{"name":"ada"}`)
+	if err := handler(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := *logger.entries
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want request and response", len(entries))
+	}
+	for _, entry := range entries {
+		if entry["level"] != "debug" {
+			t.Errorf("logged at %v, want debug", entry["level"])
+		}
+	}
+	req := entries[0]["fields"].(map[string]interface{})
+	resp := entries[1]["fields"].(map[string]interface{})
+
+	reqHeaders := req["headers"].(map[string]string)
+	if reqHeaders["Authorization"] != "[REDACTED]" || reqHeaders["X-Client"] != "cli" {
+		t.Errorf("request headers = %v", reqHeaders)
+	}
+	if body := req["body"].(string); !strings.HasPrefix(body, `{"name":`) || !strings.Contains(body, "(6 more bytes)") {
+		t.Errorf("request body = %q, want truncated to 8 bytes", body)
+	}
+
+	if resp["status"] != 201 {
+		t.Errorf("status = %v, want 201", resp["status"])
+	}
+	if resp["body"] != "created-...(8 more bytes)" {
+		t.Errorf("response body = %q", resp["body"])
+	}
+	if resp["headers"].(map[string]string)["X-Trace"] != "abc" {
+		t.Errorf("response headers = %v", resp["headers"])
+	}
+}
+
+func TestDebugDump_DisabledByDefault(t *testing.T) {
+	logger := newFieldsLogger()
+	config := middleware.DefaultDebugConfig()
+	config.Logger = logger
+
+	handler := middleware.DebugDump(config)(func(ctx *web.FastRequestContext) error {
+		return ctx.Text(200, "ok")
+	})
+	if err := handler(newCacheContext("GET", "/")); err != nil {
+		t.Fatal(err)
+	}
+	if len(*logger.entries) != 0 {
+		t.Errorf("logged %d entries while disabled", len(*logger.entries))
+	}
+
+	// The switch is global and read per request, so existing middleware follows it
+	middleware.SetDebugDump(true)
+	defer middleware.SetDebugDump(false)
+	if err := handler(newCacheContext("GET", "/")); err != nil {
+		t.Fatal(err)
+	}
+	if len(*logger.entries) != 2 {
+		t.Errorf("logged %d entries after SetDebugDump(true), want 2", len(*logger.entries))
+	}
+}
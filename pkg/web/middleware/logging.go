@@ -86,6 +86,11 @@ func Logging(config LoggingConfig) web.FastMiddleware {
 				}
 				fields["method"] = method
 				fields["path"] = path
+				// Set by the router once matched; low-cardinality alternative to path
+				if route := ctx.RoutePattern(); route != "" {
+					fields["route"] = route
+					fields["handler"] = ctx.HandlerName()
+				}
 				fields["status"] = statusCode
 				fields["duration_ms"] = duration.Milliseconds()
 				fields["duration"] = duration.String()
@@ -1,12 +1,28 @@
 package middleware_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
 	"github.com/fluxorio/fluxor/pkg/web/middleware"
+	"github.com/valyala/fasthttp"
 )
 
+func newPanicContext() *web.FastRequestContext {
+	return &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         &fasthttp.RequestCtx{},
+		Params:             make(map[string]string),
+	}
+}
+
+func panickingHandler(ctx *web.FastRequestContext) error {
+	panic("boom")
+}
+
 func TestLoggingMiddleware(t *testing.T) {
 	config := middleware.DefaultLoggingConfig()
 	if config.Logger == nil {
@@ -31,6 +47,49 @@ func TestRecoveryMiddleware(t *testing.T) {
 	}
 }
 
+func TestRecoveryMiddleware_DefaultResponse(t *testing.T) {
+	ctx := newPanicContext()
+	if err := middleware.Recovery(middleware.DefaultRecoveryConfig())(panickingHandler)(ctx); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	if ctx.RequestCtx.Response.StatusCode() != 500 {
+		t.Errorf("status = %d, want 500", ctx.RequestCtx.Response.StatusCode())
+	}
+	if body := string(ctx.RequestCtx.Response.Body()); !strings.Contains(body, `"error":"internal_server_error"`) {
+		t.Errorf("body = %s, want internal_server_error JSON", body)
+	}
+}
+
+func TestRecoveryMiddleware_ErrorHandlerAndOnPanic(t *testing.T) {
+	var gotRecovered interface{}
+	var gotStack []byte
+	config := middleware.DefaultRecoveryConfig()
+	config.ErrorHandler = func(ctx *web.FastRequestContext, recovered interface{}) {
+		ctx.RequestCtx.SetStatusCode(503)
+		ctx.RequestCtx.SetBodyString("custom")
+	}
+	config.OnPanic = func(recovered interface{}, stack []byte, requestID string) {
+		gotRecovered = recovered
+		gotStack = stack
+	}
+
+	ctx := newPanicContext()
+	if err := middleware.Recovery(config)(panickingHandler)(ctx); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	if ctx.RequestCtx.Response.StatusCode() != 503 || string(ctx.RequestCtx.Response.Body()) != "custom" {
+		t.Errorf("response = %d %q, want custom ErrorHandler response", ctx.RequestCtx.Response.StatusCode(), ctx.RequestCtx.Response.Body())
+	}
+	if gotRecovered != "boom" {
+		t.Errorf("OnPanic recovered = %v, want boom", gotRecovered)
+	}
+	if !strings.Contains(string(gotStack), "panickingHandler") {
+		t.Error("OnPanic stack should include the panicking frame")
+	}
+}
+
 func TestCompressionMiddleware(t *testing.T) {
 	config := middleware.DefaultCompressionConfig()
 	if len(config.ContentTypes) == 0 {
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"fmt"
+	"runtime/debug"
 
 	"github.com/fluxorio/fluxor/pkg/core"
 	"github.com/fluxorio/fluxor/pkg/web"
@@ -14,6 +15,14 @@ type RecoveryConfig struct {
 
 	// StackTrace includes stack trace in error response (use with caution in production)
 	StackTrace bool
+
+	// ErrorHandler writes a custom response for a recovered panic
+	// (default: JSON 500 with the request ID)
+	ErrorHandler func(ctx *web.FastRequestContext, recovered interface{})
+
+	// OnPanic is called with the panic value, stack trace and request ID,
+	// e.g. to forward to an alerting system such as Sentry
+	OnPanic func(recovered interface{}, stack []byte, requestID string)
 }
 
 // DefaultRecoveryConfig returns a default recovery configuration
@@ -35,15 +44,27 @@ func Recovery(config RecoveryConfig) web.FastMiddleware {
 		return func(ctx *web.FastRequestContext) error {
 			defer func() {
 				if r := recover(); r != nil {
+					stack := debug.Stack()
+
 					// Log panic with request context
 					fields := make(map[string]interface{})
 					fields["request_id"] = ctx.RequestID()
 					fields["method"] = string(ctx.Method())
 					fields["path"] = string(ctx.Path())
 					fields["panic"] = r
+					fields["stack"] = string(stack)
 
 					logger.WithFields(fields).Error(fmt.Sprintf("Panic recovered: %v", r))
 
+					if config.OnPanic != nil {
+						notifyPanic(logger, config.OnPanic, r, stack, ctx.RequestID())
+					}
+
+					if config.ErrorHandler != nil {
+						config.ErrorHandler(ctx, r)
+						return
+					}
+
 					// Return 500 error
 					ctx.RequestCtx.SetStatusCode(500)
 					ctx.RequestCtx.SetContentType("application/json")
@@ -54,7 +75,7 @@ func Recovery(config RecoveryConfig) web.FastMiddleware {
 					}
 
 					// Error intentionally ignored - best effort response for panic recovery
-					_, _ = ctx.RequestCtx.WriteString(fmt.Sprintf(`{"error":"internal_server_error","message":"%s","request_id":"%s"}`, errorMsg, ctx.RequestID()))
+					_, _ = ctx.RequestCtx.WriteString(fmt.Sprintf(`{"error":"internal_server_error","message":%q,"request_id":%q}`, errorMsg, ctx.RequestID()))
 				}
 			}()
 
@@ -62,3 +83,13 @@ func Recovery(config RecoveryConfig) web.FastMiddleware {
 		}
 	}
 }
+
+// notifyPanic calls the OnPanic hook, shielding the recovery path from a panicking hook
+func notifyPanic(logger core.Logger, hook func(interface{}, []byte, string), recovered interface{}, stack []byte, requestID string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(fmt.Sprintf("OnPanic hook panicked: %v", r))
+		}
+	}()
+	hook(recovered, stack, requestID)
+}
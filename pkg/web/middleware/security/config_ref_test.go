@@ -0,0 +1,69 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/fluxorio/fluxor/pkg/web/middleware/security"
+	"github.com/valyala/fasthttp"
+)
+
+func serveWith(mw web.FastMiddleware, method, origin string) *fasthttp.RequestCtx {
+	rc := &fasthttp.RequestCtx{}
+	rc.Request.Header.SetMethod(method)
+	rc.Request.SetRequestURI("/")
+	if origin != "" {
+		rc.Request.Header.Set("Origin", origin)
+	}
+	ctx := &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         rc,
+		Params:             make(map[string]string),
+	}
+	_ = mw(func(ctx *web.FastRequestContext) error {
+		ctx.RequestCtx.SetStatusCode(200)
+		return nil
+	})(ctx)
+	return rc
+}
+
+func TestRateLimitWithRef_StoreTakesEffect(t *testing.T) {
+	ref := security.NewRateLimitConfigRef(security.RateLimitConfig{
+		RequestsPerMinute: 2,
+		KeyFunc:           func(ctx *web.FastRequestContext) string { return "ref-client" },
+	})
+	mw := security.RateLimitWithRef(ref)
+
+	for i := 0; i < 2; i++ {
+		if got := serveWith(mw, "GET", "").Response.StatusCode(); got != 200 {
+			t.Fatalf("request %d status = %d, want 200", i, got)
+		}
+	}
+	if got := serveWith(mw, "GET", "").Response.StatusCode(); got != 429 {
+		t.Fatalf("status = %d, want 429 after limit", got)
+	}
+
+	cfg := ref.Load()
+	cfg.RequestsPerMinute = 7
+	ref.Store(cfg)
+
+	if got := serveWith(mw, "GET", "").Response.StatusCode(); got != 200 {
+		t.Errorf("status = %d, raised limit should apply without rebuilding the middleware", got)
+	}
+}
+
+func TestCORSWithRef_StoreTakesEffect(t *testing.T) {
+	ref := security.NewCORSConfigRef(security.CORSConfig{AllowedOrigins: []string{"https://a.example"}})
+	mw := security.CORSWithRef(ref)
+
+	if got := serveWith(mw, "GET", "https://b.example").Response.Header.Peek("Access-Control-Allow-Origin"); len(got) != 0 {
+		t.Fatalf("Allow-Origin = %q, origin not yet allowed", got)
+	}
+
+	ref.Store(security.CORSConfig{AllowedOrigins: []string{"https://a.example", "https://b.example"}})
+
+	if got := string(serveWith(mw, "GET", "https://b.example").Response.Header.Peek("Access-Control-Allow-Origin")); got != "https://b.example" {
+		t.Errorf("Allow-Origin = %q, want newly allowed origin", got)
+	}
+}
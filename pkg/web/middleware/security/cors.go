@@ -3,6 +3,7 @@ package security
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/fluxorio/fluxor/pkg/web"
 )
@@ -40,8 +41,36 @@ func DefaultCORSConfig() CORSConfig {
 	}
 }
 
-// CORS middleware handles CORS headers
-func CORS(config CORSConfig) web.FastMiddleware {
+// CORSConfigRef holds a CORSConfig that can be swapped at runtime
+// Middleware built with CORSWithRef reads the current config per request
+type CORSConfigRef struct {
+	current atomic.Pointer[corsState]
+}
+
+// corsState is a config with its normalized values, computed once per Store
+type corsState struct {
+	config            CORSConfig
+	allowedOriginsMap map[string]bool
+	allowAllOrigins   bool
+	allowedMethodsStr string
+	allowedHeadersStr string
+	exposedHeadersStr string
+}
+
+// NewCORSConfigRef creates a holder initialized with config
+func NewCORSConfigRef(config CORSConfig) *CORSConfigRef {
+	ref := &CORSConfigRef{}
+	ref.Store(config)
+	return ref
+}
+
+// Load returns the current config
+func (r *CORSConfigRef) Load() CORSConfig {
+	return r.current.Load().config
+}
+
+// Store replaces the config; takes effect on the next request
+func (r *CORSConfigRef) Store(config CORSConfig) {
 	// Normalize allowed origins
 	allowedOriginsMap := make(map[string]bool)
 	allowAllOrigins := false
@@ -53,41 +82,50 @@ func CORS(config CORSConfig) web.FastMiddleware {
 		allowedOriginsMap[origin] = true
 	}
 
-	// Normalize allowed methods
-	allowedMethodsStr := strings.Join(config.AllowedMethods, ", ")
-
-	// Normalize allowed headers
-	allowedHeadersStr := strings.Join(config.AllowedHeaders, ", ")
+	r.current.Store(&corsState{
+		config:            config,
+		allowedOriginsMap: allowedOriginsMap,
+		allowAllOrigins:   allowAllOrigins,
+		allowedMethodsStr: strings.Join(config.AllowedMethods, ", "),
+		allowedHeadersStr: strings.Join(config.AllowedHeaders, ", "),
+		exposedHeadersStr: strings.Join(config.ExposedHeaders, ", "),
+	})
+}
 
-	// Normalize exposed headers
-	exposedHeadersStr := strings.Join(config.ExposedHeaders, ", ")
+// CORS middleware handles CORS headers
+func CORS(config CORSConfig) web.FastMiddleware {
+	return CORSWithRef(NewCORSConfigRef(config))
+}
 
+// CORSWithRef is CORS with a config that can be updated live via ref.Store
+func CORSWithRef(ref *CORSConfigRef) web.FastMiddleware {
 	return func(next web.FastRequestHandler) web.FastRequestHandler {
 		return func(ctx *web.FastRequestContext) error {
+			state := ref.current.Load()
 			origin := string(ctx.RequestCtx.Request.Header.Peek("Origin"))
 
 			// Handle preflight OPTIONS request
 			if string(ctx.Method()) == "OPTIONS" {
 				// Set CORS headers
-				if allowAllOrigins {
+				if state.allowAllOrigins {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Origin", "*")
-				} else if origin != "" && allowedOriginsMap[origin] {
+				} else if origin != "" && state.allowedOriginsMap[origin] {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Origin", origin)
 				}
 
-				ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Methods", allowedMethodsStr)
-				ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Headers", allowedHeadersStr)
+				ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Methods", state.allowedMethodsStr)
+				ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Headers", state.allowedHeadersStr)
 
-				if exposedHeadersStr != "" {
-					ctx.RequestCtx.Response.Header.Set("Access-Control-Expose-Headers", exposedHeadersStr)
+				if state.exposedHeadersStr != "" {
+					ctx.RequestCtx.Response.Header.Set("Access-Control-Expose-Headers", state.exposedHeadersStr)
 				}
 
-				if config.AllowCredentials {
+				if state.config.AllowCredentials {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Credentials", "true")
 				}
 
-				if config.MaxAge > 0 {
-					ctx.RequestCtx.Response.Header.Set("Access-Control-Max-Age", fmt.Sprintf("%d", config.MaxAge))
+				if state.config.MaxAge > 0 {
+					ctx.RequestCtx.Response.Header.Set("Access-Control-Max-Age", fmt.Sprintf("%d", state.config.MaxAge))
 				}
 
 				ctx.RequestCtx.SetStatusCode(204) // No Content
@@ -96,17 +134,17 @@ func CORS(config CORSConfig) web.FastMiddleware {
 
 			// Handle actual request
 			if origin != "" {
-				if allowAllOrigins {
+				if state.allowAllOrigins {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Origin", "*")
-				} else if allowedOriginsMap[origin] {
+				} else if state.allowedOriginsMap[origin] {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Origin", origin)
 				}
 
-				if exposedHeadersStr != "" {
-					ctx.RequestCtx.Response.Header.Set("Access-Control-Expose-Headers", exposedHeadersStr)
+				if state.exposedHeadersStr != "" {
+					ctx.RequestCtx.Response.Header.Set("Access-Control-Expose-Headers", state.exposedHeadersStr)
 				}
 
-				if config.AllowCredentials {
+				if state.config.AllowCredentials {
 					ctx.RequestCtx.Response.Header.Set("Access-Control-Allow-Credentials", "true")
 				}
 			}
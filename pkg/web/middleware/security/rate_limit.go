@@ -2,6 +2,7 @@ package security
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/web"
@@ -16,8 +17,9 @@ type RateLimitConfig struct {
 	RequestsPerSecond int
 
 	// KeyFunc extracts a key from the request to identify the client
-	// Default: uses IP address
-	KeyFunc func(ctx *web.FastRequestContext) string
+	// Default: KeyByIP. Behind a proxy use KeyByHeader; see also KeyByJWTSubject,
+	// KeyByAPIKey, KeyByRoute and CompositeKey
+	KeyFunc KeyFunc
 
 	// OnLimitReached is called when rate limit is exceeded
 	// If nil, returns 429 Too Many Requests
@@ -28,10 +30,7 @@ type RateLimitConfig struct {
 func DefaultRateLimitConfig() RateLimitConfig {
 	return RateLimitConfig{
 		RequestsPerMinute: 100,
-		KeyFunc: func(ctx *web.FastRequestContext) string {
-			// Use IP address as key
-			return ctx.RequestCtx.RemoteIP().String()
-		},
+		KeyFunc:           KeyByIP(),
 	}
 }
 
@@ -169,8 +168,35 @@ func getRateLimiter(requestsPerMinute int) *rateLimiter {
 	return limiter
 }
 
-// RateLimit middleware enforces rate limiting
-func RateLimit(config RateLimitConfig) web.FastMiddleware {
+// RateLimitConfigRef holds a RateLimitConfig that can be swapped at runtime
+// Middleware built with RateLimitWithRef reads the current config per request
+type RateLimitConfigRef struct {
+	current atomic.Pointer[rateLimitState]
+}
+
+// rateLimitState is a config with its derived limiter, computed once per Store
+type rateLimitState struct {
+	config            RateLimitConfig
+	requestsPerMinute int
+	keyFunc           KeyFunc
+	limiter           *rateLimiter
+}
+
+// NewRateLimitConfigRef creates a holder initialized with config
+func NewRateLimitConfigRef(config RateLimitConfig) *RateLimitConfigRef {
+	ref := &RateLimitConfigRef{}
+	ref.Store(config)
+	return ref
+}
+
+// Load returns the current config
+func (r *RateLimitConfigRef) Load() RateLimitConfig {
+	return r.current.Load().config
+}
+
+// Store replaces the config; takes effect on the next request
+// Changing the rate switches to that rate's buckets, so clients start with a full bucket
+func (r *RateLimitConfigRef) Store(config RateLimitConfig) {
 	// Determine requests per minute
 	requestsPerMinute := config.RequestsPerMinute
 	if requestsPerMinute == 0 && config.RequestsPerSecond > 0 {
@@ -183,22 +209,33 @@ func RateLimit(config RateLimitConfig) web.FastMiddleware {
 	// Get key function
 	keyFunc := config.KeyFunc
 	if keyFunc == nil {
-		keyFunc = func(ctx *web.FastRequestContext) string {
-			return ctx.RequestCtx.RemoteIP().String()
-		}
+		keyFunc = KeyByIP()
 	}
 
-	// Get rate limiter
-	limiter := getRateLimiter(requestsPerMinute)
+	r.current.Store(&rateLimitState{
+		config:            config,
+		requestsPerMinute: requestsPerMinute,
+		keyFunc:           keyFunc,
+		limiter:           getRateLimiter(requestsPerMinute),
+	})
+}
+
+// RateLimit middleware enforces rate limiting
+func RateLimit(config RateLimitConfig) web.FastMiddleware {
+	return RateLimitWithRef(NewRateLimitConfigRef(config))
+}
 
+// RateLimitWithRef is RateLimit with a config that can be updated live via ref.Store
+func RateLimitWithRef(ref *RateLimitConfigRef) web.FastMiddleware {
 	return func(next web.FastRequestHandler) web.FastRequestHandler {
 		return func(ctx *web.FastRequestContext) error {
-			key := keyFunc(ctx)
+			state := ref.current.Load()
+			key := state.keyFunc(ctx)
 
-			if !limiter.allow(key, requestsPerMinute) {
+			if !state.limiter.allow(key, state.requestsPerMinute) {
 				// Rate limit exceeded
-				if config.OnLimitReached != nil {
-					return config.OnLimitReached(ctx)
+				if state.config.OnLimitReached != nil {
+					return state.config.OnLimitReached(ctx)
 				}
 
 				// Default: return 429 Too Many Requests
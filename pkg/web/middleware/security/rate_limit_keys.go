@@ -0,0 +1,164 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyFunc identifies the client a request is counted against (see RateLimitConfig.KeyFunc)
+// Keys are prefixed by kind ("ip:", "sub:", "apikey:") so strategies never collide
+type KeyFunc func(ctx *web.FastRequestContext) string
+
+// KeyByIP keys by the connection's remote IP - the default
+// Behind a proxy or load balancer every request shares its IP; use KeyByHeader
+func KeyByIP() KeyFunc {
+	return func(ctx *web.FastRequestContext) string {
+		return "ip:" + ctx.RequestCtx.RemoteIP().String()
+	}
+}
+
+// KeyByHeader keys by the client IP in a forwarding header such as X-Forwarded-For
+// The header is only read when the remote peer is in trustedProxies (CIDRs or IPs);
+// otherwise the remote IP is used, so clients can't spoof their key. The list is
+// walked right to left and the first address that isn't a trusted proxy is the client.
+// With no trustedProxies any peer is trusted and the rightmost address is used, which
+// is only safe when the app is reachable solely through a single proxy.
+// Fail-fast: panics on an invalid CIDR
+func KeyByHeader(header string, trustedProxies ...string) KeyFunc {
+	if header == "" {
+		panic("KeyByHeader: header cannot be empty")
+	}
+	trusted := parseTrustedProxies(trustedProxies)
+
+	return func(ctx *web.FastRequestContext) string {
+		remote := ctx.RequestCtx.RemoteIP()
+		if len(trusted) > 0 && !ipInNets(remote, trusted) {
+			return "ip:" + remote.String()
+		}
+
+		hops := strings.Split(string(ctx.RequestCtx.Request.Header.Peek(header)), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				// Malformed entry; nothing left of it can be trusted
+				break
+			}
+			if !ipInNets(ip, trusted) {
+				return "ip:" + ip.String()
+			}
+		}
+		return "ip:" + remote.String()
+	}
+}
+
+// KeyByJWTSubject keys by the "sub" claim stored by the JWT middleware under claimsKey
+// (default "user"), falling back to the remote IP for unauthenticated requests
+func KeyByJWTSubject(claimsKey string) KeyFunc {
+	if claimsKey == "" {
+		claimsKey = "user"
+	}
+	fallback := KeyByIP()
+
+	return func(ctx *web.FastRequestContext) string {
+		var sub interface{}
+		switch claims := ctx.Get(claimsKey).(type) {
+		case jwt.MapClaims:
+			sub = claims["sub"]
+		case map[string]interface{}:
+			sub = claims["sub"]
+		}
+		if s, ok := sub.(string); ok && s != "" {
+			return "sub:" + s
+		}
+		return fallback(ctx)
+	}
+}
+
+// KeyByAPIKey keys by the API key in header (default "X-API-Key"), falling back to
+// the remote IP when it's missing. Keys are hashed so limiter state holds no secrets.
+func KeyByAPIKey(header string) KeyFunc {
+	if header == "" {
+		header = "X-API-Key"
+	}
+	fallback := KeyByIP()
+
+	return func(ctx *web.FastRequestContext) string {
+		key := ctx.RequestCtx.Request.Header.Peek(header)
+		if len(key) == 0 {
+			return fallback(ctx)
+		}
+		sum := sha256.Sum256(key)
+		return "apikey:" + hex.EncodeToString(sum[:16])
+	}
+}
+
+// KeyByRoute combines the matched route template with principal, giving each client
+// a separate budget per route: "GET /api/users/:id|sub:alice". Requests that
+// matched no route share one web.UnmatchedRoute budget per client, so probing
+// random paths doesn't mint fresh keys.
+func KeyByRoute(principal KeyFunc) KeyFunc {
+	if principal == nil {
+		panic("KeyByRoute: principal cannot be nil")
+	}
+	return func(ctx *web.FastRequestContext) string {
+		route := ctx.RoutePattern()
+		if route == "" {
+			route = web.UnmatchedRoute
+		}
+		return string(ctx.Method()) + " " + route + "|" + principal(ctx)
+	}
+}
+
+// CompositeKey joins the keys of several strategies, e.g. API key and client IP
+func CompositeKey(keys ...KeyFunc) KeyFunc {
+	if len(keys) == 0 {
+		panic("CompositeKey: at least one key function is required")
+	}
+	return func(ctx *web.FastRequestContext) string {
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key(ctx)
+		}
+		return strings.Join(parts, "|")
+	}
+}
+
+// parseTrustedProxies parses CIDRs and bare IPs - fail-fast on invalid entries
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				panic(fmt.Sprintf("invalid trusted proxy: %q", p))
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			panic(fmt.Sprintf("invalid trusted proxy: %q: %v", p, err))
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+func ipInNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package security_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/fluxorio/fluxor/pkg/web/middleware/security"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/valyala/fasthttp"
+)
+
+func keyRequest(remoteIP string, headers map[string]string) *web.FastRequestContext {
+	var req fasthttp.Request
+	req.Header.SetMethod("GET")
+	req.SetRequestURI("/api/users/7")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rc := &fasthttp.RequestCtx{}
+	rc.Init(&req, &net.TCPAddr{IP: net.ParseIP(remoteIP), Port: 4000}, nil)
+	return &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         rc,
+		Params:             make(map[string]string),
+	}
+}
+
+func TestKeyByHeader_TrustedProxies(t *testing.T) {
+	key := security.KeyByHeader("X-Forwarded-For", "10.0.0.0/8", "192.168.1.5")
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    string
+		want   string
+	}{
+		{"trusted proxy", "10.0.0.2", "203.0.113.9", "ip:203.0.113.9"},
+		{"proxy chain", "10.0.0.2", "198.51.100.1, 203.0.113.9, 192.168.1.5", "ip:203.0.113.9"},
+		{"spoofed by untrusted peer", "198.51.100.77", "203.0.113.9", "ip:198.51.100.77"},
+		{"no header", "10.0.0.2", "", "ip:10.0.0.2"},
+		{"malformed entry", "10.0.0.2", "garbage, 10.0.0.3", "ip:10.0.0.2"},
+	}
+	for _, tt := range tests {
+		headers := map[string]string{}
+		if tt.xff != "" {
+			headers["X-Forwarded-For"] = tt.xff
+		}
+		if got := key(keyRequest(tt.remote, headers)); got != tt.want {
+			t.Errorf("%s: key = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Without trusted proxies the rightmost hop is the client
+	key = security.KeyByHeader("X-Forwarded-For")
+	if got := key(keyRequest("10.0.0.2", map[string]string{"X-Forwarded-For": "1.1.1.1, 203.0.113.9"})); got != "ip:203.0.113.9" {
+		t.Errorf("key = %q, want rightmost hop", got)
+	}
+}
+
+func TestKeyByHeader_InvalidCIDRPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("KeyByHeader() should panic on an invalid trusted proxy")
+		}
+	}()
+	security.KeyByHeader("X-Forwarded-For", "10.0.0.0/99")
+}
+
+func TestKeyStrategies(t *testing.T) {
+	ctx := keyRequest("198.51.100.7", map[string]string{"X-API-Key": "secret-key"})
+
+	if got := security.KeyByJWTSubject("")(ctx); got != "ip:198.51.100.7" {
+		t.Errorf("KeyByJWTSubject() without claims = %q, want IP fallback", got)
+	}
+	ctx.Set("user", jwt.MapClaims{"sub": "alice"})
+	if got := security.KeyByJWTSubject("")(ctx); got != "sub:alice" {
+		t.Errorf("KeyByJWTSubject() = %q, want sub:alice", got)
+	}
+
+	apiKey := security.KeyByAPIKey("")(ctx)
+	if !strings.HasPrefix(apiKey, "apikey:") || strings.Contains(apiKey, "secret-key") {
+		t.Errorf("KeyByAPIKey() = %q, want a hashed key", apiKey)
+	}
+
+	byRoute := security.KeyByRoute(security.KeyByJWTSubject(""))
+	if got := byRoute(ctx); got != "GET "+web.UnmatchedRoute+"|sub:alice" {
+		t.Errorf("KeyByRoute() without a matched route = %q, want the unmatched sentinel", got)
+	}
+	router := web.NewFastRouter()
+	var routeKey string
+	router.GETFast("/api/users/:id", func(c *web.FastRequestContext) error {
+		routeKey = byRoute(c)
+		return nil
+	})
+	router.ServeFastHTTP(ctx)
+	if routeKey != "GET /api/users/:id|sub:alice" {
+		t.Errorf("KeyByRoute() = %q, want the route template", routeKey)
+	}
+	if got := security.CompositeKey(security.KeyByJWTSubject(""), security.KeyByIP())(ctx); got != "sub:alice|ip:198.51.100.7" {
+		t.Errorf("CompositeKey() = %q", got)
+	}
+}
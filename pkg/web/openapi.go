@@ -0,0 +1,257 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// RouteDoc annotates a route for GenerateOpenAPI. Request and Response are
+// sample values of the body types (e.g. CreateUserRequest{}); their JSON schema
+// is derived from the struct fields and json tags.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Request     interface{}
+	Response    interface{}
+}
+
+// OpenAPIInfo is the info object of the generated document
+type OpenAPIInfo struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Describe attaches documentation to the route registered for method and path
+// (the full path, for routes registered on a group). Panics if there is no such route.
+func (r *FastRouter) Describe(method, path string, doc RouteDoc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, route := range r.routes {
+		if route.method == method && route.path == path {
+			route.doc = &doc
+			return
+		}
+	}
+	panic(fmt.Sprintf("web: no route registered for %s %s", method, path))
+}
+
+// Describe attaches documentation to a route registered on this group
+func (g *FastRouteGroup) Describe(method, path string, doc RouteDoc) {
+	g.router.Describe(method, g.fullPath(path), doc)
+}
+
+// SetOpenAPIInfo sets the title, version and description of the generated document
+func (r *FastRouter) SetOpenAPIInfo(info OpenAPIInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.openAPIInfo = info
+}
+
+// GenerateOpenAPI returns an OpenAPI 3 document for the registered routes.
+// Every route is listed with its path parameters (from :name segments);
+// routes annotated with Describe also get a summary and body schemas.
+func (r *FastRouter) GenerateOpenAPI() ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.openAPISpec()
+}
+
+// openAPISpec is GenerateOpenAPI for callers already holding r.mu
+func (r *FastRouter) openAPISpec() ([]byte, error) {
+	info := r.openAPIInfo
+	if info.Title == "" {
+		info.Title = "API"
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+	infoObj := map[string]interface{}{"title": info.Title, "version": info.Version}
+	if info.Description != "" {
+		infoObj["description"] = info.Description
+	}
+
+	paths := make(map[string]map[string]interface{})
+	for _, route := range r.routes {
+		apiPath, params := openAPIPath(route.path)
+		if paths[apiPath] == nil {
+			paths[apiPath] = make(map[string]interface{})
+		}
+		paths[apiPath][strings.ToLower(route.method)] = openAPIOperation(route, params)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    infoObj,
+		"paths":   paths,
+	}, "", "  ")
+}
+
+// ServeOpenAPI registers GET /openapi.json serving GenerateOpenAPI. The document
+// is generated per request, so routes registered later are included.
+func (r *FastRouter) ServeOpenAPI() {
+	r.GETFast("/openapi.json", func(ctx *FastRequestContext) error {
+		// Handlers run under ServeFastHTTP's read lock; taking it again would
+		// deadlock behind a queued writer (e.g. a route being registered)
+		spec, err := r.openAPISpec()
+		if err != nil {
+			return err
+		}
+		ctx.RequestCtx.SetContentType("application/json")
+		ctx.RequestCtx.SetStatusCode(fasthttp.StatusOK)
+		ctx.RequestCtx.SetBody(spec)
+		return nil
+	})
+}
+
+// openAPIPath converts /users/:id to /users/{id} and returns the parameter names
+func openAPIPath(pattern string) (string, []string) {
+	parts := strings.Split(pattern, "/")
+	var params []string
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			name := strings.TrimPrefix(part, ":")
+			params = append(params, name)
+			parts[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(parts, "/"), params
+}
+
+func openAPIOperation(route *fastRoute, params []string) map[string]interface{} {
+	op := map[string]interface{}{}
+	if route.name != "" {
+		op["operationId"] = route.name
+	}
+	if len(params) > 0 {
+		parameters := make([]interface{}, len(params))
+		for i, name := range params {
+			parameters[i] = map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			}
+		}
+		op["parameters"] = parameters
+	}
+
+	response := map[string]interface{}{"description": "OK"}
+	if doc := route.doc; doc != nil {
+		if doc.Summary != "" {
+			op["summary"] = doc.Summary
+		}
+		if doc.Description != "" {
+			op["description"] = doc.Description
+		}
+		if len(doc.Tags) > 0 {
+			op["tags"] = doc.Tags
+		}
+		if doc.Request != nil {
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(doc.Request),
+			}
+		}
+		if doc.Response != nil {
+			response["content"] = jsonContent(doc.Response)
+		}
+	}
+	op["responses"] = map[string]interface{}{"200": response}
+	return op
+}
+
+func jsonContent(sample interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": jsonSchema(reflect.TypeOf(sample), map[reflect.Type]bool{}),
+		},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// jsonSchema describes how encoding/json encodes t. Recursive types are cut
+// off with an untyped schema at the second visit.
+func jsonSchema(t reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]interface{}{}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		props := map[string]interface{}{}
+		var required []string
+		addStructProperties(t, visiting, props, &required)
+		schema := map[string]interface{}{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// addStructProperties adds t's JSON fields to props, flattening untagged embedded structs;
+// fields without omitempty are listed as required
+func addStructProperties(t reflect.Type, visiting map[reflect.Type]bool, props map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			addStructProperties(ft, visiting, props, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = jsonSchema(f.Type, visiting)
+		if !strings.Contains(","+opts+",", ",omitempty,") && f.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type userResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+	Manager   *userResponse
+}
+
+func TestFastRouter_GenerateOpenAPI(t *testing.T) {
+	router := NewFastRouter()
+	router.SetOpenAPIInfo(OpenAPIInfo{Title: "Users", Version: "2.0.0"})
+
+	noop := func(ctx *FastRequestContext) error { return nil }
+	api := router.Group("/api")
+	api.GETFast("/users/:id", noop)
+	api.POSTFast("/users", noop)
+	api.Describe("POST", "/users", RouteDoc{
+		Summary:  "Create a user",
+		Tags:     []string{"users"},
+		Request:  createUserRequest{},
+		Response: &userResponse{},
+	})
+	router.GETFast("/orgs/:org/members/:member", noop)
+
+	spec, err := router.GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI() error = %v", err)
+	}
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths map[string]map[string]struct {
+			Summary    string `json:"summary"`
+			Parameters []struct {
+				Name string `json:"name"`
+				In   string `json:"in"`
+			} `json:"parameters"`
+			RequestBody struct {
+				Content map[string]struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.0.3" || doc.Info.Title != "Users" || doc.Info.Version != "2.0.0" {
+		t.Errorf("header = %s %+v", doc.OpenAPI, doc.Info)
+	}
+
+	get, ok := doc.Paths["/api/users/{id}"]["get"]
+	if !ok || len(get.Parameters) != 1 || get.Parameters[0].Name != "id" || get.Parameters[0].In != "path" {
+		t.Errorf("GET /api/users/{id} = %+v", get)
+	}
+	if members := doc.Paths["/orgs/{org}/members/{member}"]["get"]; len(members.Parameters) != 2 {
+		t.Errorf("members parameters = %+v", members.Parameters)
+	}
+
+	post := doc.Paths["/api/users"]["post"]
+	if post.Summary != "Create a user" {
+		t.Errorf("summary = %q", post.Summary)
+	}
+	reqSchema := post.RequestBody.Content["application/json"].Schema
+	if required, _ := reqSchema["required"].([]interface{}); len(required) != 1 || required[0] != "name" {
+		t.Errorf("request required = %v, want [name]", reqSchema["required"])
+	}
+	respProps, _ := post.Responses["200"].Content["application/json"].Schema["properties"].(map[string]interface{})
+	createdAt, _ := respProps["created_at"].(map[string]interface{})
+	if createdAt["format"] != "date-time" {
+		t.Errorf("created_at schema = %v", respProps["created_at"])
+	}
+	if roles, _ := respProps["roles"].(map[string]interface{}); roles["type"] != "array" {
+		t.Errorf("roles schema = %v", respProps["roles"])
+	}
+	if _, ok := respProps["Manager"]; !ok {
+		t.Error("recursive field Manager missing from schema")
+	}
+}
+
+func TestFastRouter_ServeOpenAPI(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	router.ServeOpenAPI()
+	router.GETFast("/later", func(ctx *FastRequestContext) error { return nil })
+
+	ctx := newTestFastContext(gocmd, "GET", "/openapi.json")
+	router.ServeFastHTTP(ctx)
+
+	if ctx.RequestCtx.Response.StatusCode() != 200 {
+		t.Fatalf("status = %d", ctx.RequestCtx.Response.StatusCode())
+	}
+	var doc struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(ctx.RequestCtx.Response.Body(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc.Paths["/later"]; !ok {
+		t.Errorf("paths = %v, want route registered after ServeOpenAPI", doc.Paths)
+	}
+}
+
+func TestFastRouter_ServeOpenAPIWithQueuedWriter(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	router := NewFastRouter()
+	router.ServeOpenAPI()
+	// Queue a route registration behind the request's read lock before the spec is built
+	router.UseFast(func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			go router.GETFast("/queued", func(ctx *FastRequestContext) error { return nil })
+			time.Sleep(20 * time.Millisecond)
+			return next(ctx)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.ServeFastHTTP(newTestFastContext(gocmd, "GET", "/openapi.json"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("serving /openapi.json deadlocked behind a queued writer")
+	}
+}
+
+func TestFastRouter_DescribeUnknownRoutePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unregistered route")
+		}
+	}()
+	NewFastRouter().Describe("GET", "/missing", RouteDoc{})
+}
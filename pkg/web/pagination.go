@@ -0,0 +1,158 @@
+package web
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PaginationConfig configures how pagination query parameters are parsed
+type PaginationConfig struct {
+	PageParam     string // Query parameter for the page number (default: "page")
+	PageSizeParam string // Query parameter for the page size (default: "page_size")
+	SortParam     string // Query parameter for sorting (default: "sort")
+
+	DefaultPageSize int // Page size when none is requested (default: 20)
+	MaxPageSize     int // Upper bound for the requested page size (default: 100)
+
+	// DefaultSort is used when no sort is requested (e.g. "-created_at")
+	DefaultSort string
+	// AllowedSortFields restricts sortable fields; empty allows any field
+	AllowedSortFields []string
+}
+
+// DefaultPaginationConfig returns the default pagination configuration
+func DefaultPaginationConfig() PaginationConfig {
+	return PaginationConfig{
+		PageParam:       "page",
+		PageSizeParam:   "page_size",
+		SortParam:       "sort",
+		DefaultPageSize: 20,
+		MaxPageSize:     100,
+	}
+}
+
+// Pagination holds validated pagination parameters for a list request
+type Pagination struct {
+	Page     int    // 1-based page number
+	PageSize int    // Items per page
+	Offset   int    // Number of items to skip ((Page-1) * PageSize, at most math.MaxInt)
+	SortBy   string // Field to sort by (without direction prefix)
+	SortDesc bool   // True when sort was prefixed with "-"
+}
+
+// ParsePagination reads page, page size and sort from the query string - fail-fast
+// Malformed or out-of-bounds values return an error so the handler can respond 400
+// Sort accepts "field" (ascending) or "-field" (descending)
+func ParsePagination(ctx *FastRequestContext, config PaginationConfig) (Pagination, error) {
+	defaults := DefaultPaginationConfig()
+	if config.PageParam == "" {
+		config.PageParam = defaults.PageParam
+	}
+	if config.PageSizeParam == "" {
+		config.PageSizeParam = defaults.PageSizeParam
+	}
+	if config.SortParam == "" {
+		config.SortParam = defaults.SortParam
+	}
+	if config.MaxPageSize <= 0 {
+		config.MaxPageSize = defaults.MaxPageSize
+	}
+	if config.DefaultPageSize <= 0 {
+		config.DefaultPageSize = defaults.DefaultPageSize
+	}
+	if config.DefaultPageSize > config.MaxPageSize {
+		config.DefaultPageSize = config.MaxPageSize
+	}
+
+	p := Pagination{Page: 1, PageSize: config.DefaultPageSize}
+
+	if pageStr := ctx.Query(config.PageParam); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return Pagination{}, fmt.Errorf("invalid %s: must be a positive integer", config.PageParam)
+		}
+		p.Page = page
+	}
+
+	if sizeStr := ctx.Query(config.PageSizeParam); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil || size < 1 {
+			return Pagination{}, fmt.Errorf("invalid %s: must be a positive integer", config.PageSizeParam)
+		}
+		if size > config.MaxPageSize {
+			return Pagination{}, fmt.Errorf("invalid %s: must not exceed %d", config.PageSizeParam, config.MaxPageSize)
+		}
+		p.PageSize = size
+	}
+
+	sort := ctx.Query(config.SortParam)
+	if sort == "" {
+		sort = config.DefaultSort
+	}
+	if sort != "" {
+		if strings.HasPrefix(sort, "-") {
+			p.SortDesc = true
+			sort = sort[1:]
+		}
+		if len(config.AllowedSortFields) > 0 && !containsString(config.AllowedSortFields, sort) {
+			return Pagination{}, fmt.Errorf("invalid %s: unsupported field %q", config.SortParam, sort)
+		}
+		p.SortBy = sort
+	}
+
+	// Clamp instead of overflowing for huge page numbers; such an offset is past any result set
+	if p.Page-1 > math.MaxInt/p.PageSize {
+		p.Offset = math.MaxInt
+	} else {
+		p.Offset = (p.Page - 1) * p.PageSize
+	}
+	return p, nil
+}
+
+// PageInfo describes the position of a page within a result set
+type PageInfo struct {
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// PagedResponse is the standard envelope for paginated list responses
+type PagedResponse struct {
+	Items      interface{} `json:"items"`
+	Pagination PageInfo    `json:"pagination"`
+}
+
+// NewPagedResponse wraps items with navigation metadata for the given total count
+func NewPagedResponse(items interface{}, p Pagination, total int) PagedResponse {
+	totalPages := 1
+	if p.PageSize > 0 && total > 0 {
+		totalPages = (total-1)/p.PageSize + 1
+	}
+
+	return PagedResponse{
+		Items: items,
+		Pagination: PageInfo{
+			Page:       p.Page,
+			PageSize:   p.PageSize,
+			Total:      total,
+			TotalPages: totalPages,
+			HasNext:    p.Page < totalPages,
+			HasPrev:    p.Page > 1,
+		},
+	}
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
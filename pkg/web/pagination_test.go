@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestParsePagination(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	config := DefaultPaginationConfig()
+	config.AllowedSortFields = []string{"created_at", "title"}
+
+	tests := []struct {
+		name    string
+		uri     string
+		want    Pagination
+		wantErr bool
+	}{
+		{"defaults", "/todos", Pagination{Page: 1, PageSize: 20}, false},
+		{"page and size", "/todos?page=3&page_size=10", Pagination{Page: 3, PageSize: 10, Offset: 20}, false},
+		{"descending sort", "/todos?sort=-created_at", Pagination{Page: 1, PageSize: 20, SortBy: "created_at", SortDesc: true}, false},
+		{"page size above max", "/todos?page_size=1000000", Pagination{}, true},
+		{"non-numeric page", "/todos?page=abc", Pagination{}, true},
+		{"zero page", "/todos?page=0", Pagination{}, true},
+		{"huge page", "/todos?page=" + strconv.Itoa(math.MaxInt) + "&page_size=100", Pagination{Page: math.MaxInt, PageSize: 100, Offset: math.MaxInt}, false},
+		{"unsupported sort field", "/todos?sort=password", Pagination{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestFastContext(gocmd, "GET", tt.uri)
+			got, err := ParsePagination(ctx, config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePagination() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPagedResponse(t *testing.T) {
+	resp := NewPagedResponse([]int{1, 2, 3}, Pagination{Page: 2, PageSize: 3, Offset: 3}, 7)
+
+	want := PageInfo{Page: 2, PageSize: 3, Total: 7, TotalPages: 3, HasNext: true, HasPrev: true}
+	if resp.Pagination != want {
+		t.Errorf("Pagination = %+v, want %+v", resp.Pagination, want)
+	}
+
+	empty := NewPagedResponse([]int{}, Pagination{Page: 1, PageSize: 20}, 0)
+	if empty.Pagination.TotalPages != 1 || empty.Pagination.HasNext {
+		t.Errorf("empty result Pagination = %+v, want single page without next", empty.Pagination)
+	}
+
+	huge := NewPagedResponse([]int{}, Pagination{Page: 1, PageSize: 100}, math.MaxInt)
+	if huge.Pagination.TotalPages != math.MaxInt/100+1 {
+		t.Errorf("TotalPages for a huge total = %d, want %d", huge.Pagination.TotalPages, math.MaxInt/100+1)
+	}
+}
@@ -0,0 +1,83 @@
+package web
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryInt returns query parameter key as an int, or def when it is absent or malformed
+func (c *FastRequestContext) QueryInt(key string, def int) int {
+	v, err := c.QueryIntE(key, def)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// QueryIntE returns query parameter key as an int, def when it is absent,
+// and an error when it isn't an integer (so the handler can respond 400)
+func (c *FastRequestContext) QueryIntE(key string, def int) (int, error) {
+	s := c.Query(key)
+	if s == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s: must be an integer", key)
+	}
+	return v, nil
+}
+
+// QueryBool returns query parameter key as a bool, or def when it is absent or malformed.
+// A bare key (?verbose) is true.
+func (c *FastRequestContext) QueryBool(key string, def bool) bool {
+	v, err := c.QueryBoolE(key, def)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// QueryBoolE returns query parameter key as a bool (1/0, t/f, true/false),
+// def when it is absent, and an error when it isn't a boolean. A bare key (?verbose) is true.
+func (c *FastRequestContext) QueryBoolE(key string, def bool) (bool, error) {
+	args := c.RequestCtx.QueryArgs()
+	if !args.Has(key) {
+		return def, nil
+	}
+	s := string(args.Peek(key))
+	if s == "" {
+		return true, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s: must be true or false", key)
+	}
+	return v, nil
+}
+
+// QueryStringSlice returns the comma-separated values of query parameter key,
+// also across repeated keys: ?tag=a,b&tag=c gives [a b c]. Values are trimmed
+// and empty ones dropped; nil when the key is absent.
+func (c *FastRequestContext) QueryStringSlice(key string) []string {
+	var values []string
+	for _, raw := range c.RequestCtx.QueryArgs().PeekMulti(key) {
+		for _, v := range strings.Split(string(raw), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// Header returns request header key, or "" when it is absent
+func (c *FastRequestContext) Header(key string) string {
+	return string(c.RequestCtx.Request.Header.Peek(key))
+}
+
+// Cookie returns the value of request cookie key, or "" when it is absent
+func (c *FastRequestContext) Cookie(key string) string {
+	return string(c.RequestCtx.Request.Header.Cookie(key))
+}
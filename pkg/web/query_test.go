@@ -0,0 +1,61 @@
+package web
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestFastRequestContext_QueryHelpers(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	ctx := newTestFastContext(gocmd, "GET", "/items?limit=25&bad=x&verbose&archived=false&flag=maybe&tag=a,%20b&tag=c,,&empty=")
+	ctx.RequestCtx.Request.Header.Set("X-Client", "cli")
+	ctx.RequestCtx.Request.Header.SetCookie("session", "abc")
+
+	if got := ctx.QueryInt("limit", 10); got != 25 {
+		t.Errorf("QueryInt(limit) = %d, want 25", got)
+	}
+	if got := ctx.QueryInt("missing", 10); got != 10 {
+		t.Errorf("QueryInt(missing) = %d, want default", got)
+	}
+	if got := ctx.QueryInt("bad", 10); got != 10 {
+		t.Errorf("QueryInt(bad) = %d, want default", got)
+	}
+	if _, err := ctx.QueryIntE("bad", 10); err == nil {
+		t.Error("QueryIntE(bad) should return an error")
+	}
+	if got, err := ctx.QueryIntE("missing", 10); err != nil || got != 10 {
+		t.Errorf("QueryIntE(missing) = %d, %v", got, err)
+	}
+
+	if !ctx.QueryBool("verbose", false) {
+		t.Error("QueryBool(bare key) should be true")
+	}
+	if ctx.QueryBool("archived", true) {
+		t.Error("QueryBool(archived=false) should be false")
+	}
+	if !ctx.QueryBool("flag", true) {
+		t.Error("QueryBool(malformed) should return the default")
+	}
+	if _, err := ctx.QueryBoolE("flag", false); err == nil {
+		t.Error("QueryBoolE(malformed) should return an error")
+	}
+
+	if got := ctx.QueryStringSlice("tag"); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("QueryStringSlice(tag) = %v", got)
+	}
+	if got := ctx.QueryStringSlice("empty"); got != nil {
+		t.Errorf("QueryStringSlice(empty) = %v, want nil", got)
+	}
+
+	if ctx.Header("X-Client") != "cli" || ctx.Header("X-Missing") != "" {
+		t.Errorf("Header() = %q", ctx.Header("X-Client"))
+	}
+	if ctx.Cookie("session") != "abc" || ctx.Cookie("missing") != "" {
+		t.Errorf("Cookie() = %q", ctx.Cookie("session"))
+	}
+}
@@ -0,0 +1,39 @@
+package web
+
+import (
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// inboundRequestID returns the first request ID found in headers, in order
+// traceparent ("00-<trace-id>-<span-id>-<flags>") yields its trace ID
+func inboundRequestID(h *fasthttp.RequestHeader, headers []string) string {
+	for _, name := range headers {
+		value := strings.TrimSpace(string(h.Peek(name)))
+		if value == "" {
+			continue
+		}
+		if strings.EqualFold(name, "traceparent") {
+			if traceID := traceIDFromTraceparent(value); traceID != "" {
+				return traceID
+			}
+			continue
+		}
+		return value
+	}
+	return ""
+}
+
+// traceIDFromTraceparent extracts a valid (32 hex digits, not all zero) trace ID
+func traceIDFromTraceparent(value string) string {
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	traceID := strings.ToLower(parts[1])
+	if strings.Trim(traceID, "0") == "" || strings.Trim(traceID, "0123456789abcdef") != "" {
+		return ""
+	}
+	return traceID
+}
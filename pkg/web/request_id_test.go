@@ -0,0 +1,31 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestInboundRequestID(t *testing.T) {
+	names := []string{"X-Request-ID", "X-Correlation-ID", "traceparent"}
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"request ID first", map[string]string{"X-Request-ID": "req-1", "X-Correlation-ID": "corr-1"}, "req-1"},
+		{"correlation ID", map[string]string{"X-Correlation-ID": "corr-1"}, "corr-1"},
+		{"traceparent", map[string]string{"traceparent": "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"invalid traceparent", map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"}, ""},
+		{"none", nil, ""},
+	}
+	for _, tt := range tests {
+		var h fasthttp.RequestHeader
+		for k, v := range tt.headers {
+			h.Set(k, v)
+		}
+		if got := inboundRequestID(&h, names); got != tt.want {
+			t.Errorf("%s: inboundRequestID() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
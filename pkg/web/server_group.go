@@ -0,0 +1,142 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ServerGroup runs several FastHTTPServers (e.g. a public API on :8080 and an
+// admin API on :8082) as one unit: Start launches them together, a failing server
+// takes the others down with it, and Stop shuts them all down gracefully.
+type ServerGroup struct {
+	mu       sync.Mutex
+	servers  []*FastHTTPServer
+	running  map[*FastHTTPServer]bool // servers Start launched and that haven't failed
+	started  bool
+	stopping bool // set by Stop; servers not yet started are skipped
+	stopOnce sync.Once
+	stopErr  error
+}
+
+// serverGroupFailTimeout bounds the shutdown of the remaining servers after one fails
+const serverGroupFailTimeout = 5 * time.Second
+
+// NewServerGroup creates a group of servers
+// Fail-fast: panics on a nil server
+func NewServerGroup(servers ...*FastHTTPServer) *ServerGroup {
+	g := &ServerGroup{}
+	for _, s := range servers {
+		g.Add(s)
+	}
+	return g
+}
+
+// Add adds a server to the group
+// Fail-fast: panics on a nil server or once the group has started
+func (g *ServerGroup) Add(server *FastHTTPServer) {
+	if server == nil {
+		panic("ServerGroup: server cannot be nil")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.started {
+		panic("ServerGroup: cannot add a server after Start")
+	}
+	g.servers = append(g.servers, server)
+}
+
+// Servers returns the servers in the group
+func (g *ServerGroup) Servers() []*FastHTTPServer {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]*FastHTTPServer(nil), g.servers...)
+}
+
+// Start starts every server and blocks until all of them have stopped, like
+// FastHTTPServer.Start. If any server fails (e.g. its port is in use) the rest
+// are stopped. Returns the servers' errors joined, each prefixed by its address.
+func (g *ServerGroup) Start() error {
+	g.mu.Lock()
+	if g.started {
+		g.mu.Unlock()
+		return errors.New("server group already started")
+	}
+	g.started = true
+	g.running = make(map[*FastHTTPServer]bool, len(g.servers))
+	servers := append([]*FastHTTPServer(nil), g.servers...)
+	g.mu.Unlock()
+
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !g.launch(s) {
+				return
+			}
+			if err := s.Start(); err != nil {
+				g.mu.Lock()
+				delete(g.running, s)
+				g.mu.Unlock()
+				errs[i] = fmt.Errorf("%s: %w", s.addr, err)
+				ctx, cancel := context.WithTimeout(context.Background(), serverGroupFailTimeout)
+				defer cancel()
+				g.Stop(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Stop gracefully stops every running server in parallel: each stops accepting
+// new requests (they get 503), waits for its in-flight requests to drain (see
+// FastHTTPServer.WaitForDrain) until ctx is done, then shuts down. Servers are
+// stopped even when ctx expires first; servers that never started or failed to
+// start are skipped. Safe to call more than once; later calls return the first
+// call's result.
+func (g *ServerGroup) Stop(ctx context.Context) error {
+	g.stopOnce.Do(func() {
+		g.mu.Lock()
+		g.stopping = true
+		var servers []*FastHTTPServer
+		for _, s := range g.servers {
+			if g.running[s] {
+				servers = append(servers, s)
+			}
+		}
+		g.mu.Unlock()
+
+		errs := make([]error, len(servers))
+		var wg sync.WaitGroup
+		for i, s := range servers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				acceptErr := s.stopAccepting(ctx)
+				drainErr := s.WaitForDrain(ctx)
+				if err := errors.Join(acceptErr, drainErr, s.Stop()); err != nil {
+					errs[i] = fmt.Errorf("%s: %w", s.addr, err)
+				}
+			}()
+		}
+		wg.Wait()
+		g.stopErr = errors.Join(errs...)
+	})
+	return g.stopErr
+}
+
+// launch marks s running unless the group is stopping
+func (g *ServerGroup) launch(s *FastHTTPServer) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopping {
+		return false
+	}
+	g.running[s] = true
+	return true
+}
@@ -0,0 +1,192 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// freeAddr returns a local address nothing is listening on
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+func waitListening(t *testing.T, addr string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not listening: %v", addr, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServerGroup_StartStop(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	var addrs []string
+	group := NewServerGroup()
+	for _, name := range []string{"gateway", "admin"} {
+		addr := freeAddr(t)
+		addrs = append(addrs, addr)
+		server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(addr))
+		server.FastRouter().GETFast("/", func(ctx *FastRequestContext) error { return ctx.Text(200, name) })
+		group.Add(server)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- group.Start() }()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	for _, addr := range addrs {
+		waitListening(t, addr)
+		resp, err := client.Get("http://" + addr + "/")
+		if err != nil {
+			t.Fatalf("GET %s error = %v", addr, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 200 {
+			t.Errorf("GET %s status = %d", addr, resp.StatusCode)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := group.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() did not return after Stop()")
+	}
+	for _, addr := range addrs {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			t.Errorf("%s still listening after Stop()", addr)
+		}
+	}
+}
+
+func TestServerGroup_FailureStopsOthers(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+
+	okAddr := freeAddr(t)
+	group := NewServerGroup(
+		NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(okAddr)),
+		NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(busy.Addr().String())),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- group.Start() }()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), busy.Addr().String()) {
+			t.Errorf("Start() error = %v, want failure for %s", err, busy.Addr())
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Start() did not return after a server failed")
+	}
+}
+
+func TestServerGroup_StopRejectsNewRequestsWhileDraining(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	addr := freeAddr(t)
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(addr))
+	release := make(chan struct{})
+	server.FastRouter().GETFast("/slow", func(ctx *FastRequestContext) error {
+		<-release
+		return ctx.Text(200, "done")
+	})
+	server.FastRouter().GETFast("/", func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") })
+	group := NewServerGroup(server)
+	done := make(chan error, 1)
+	go func() { done <- group.Start() }()
+	waitListening(t, addr)
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	slow := make(chan int, 1)
+	go func() {
+		resp, err := client.Get("http://" + addr + "/slow")
+		if err != nil {
+			slow <- 0
+			return
+		}
+		resp.Body.Close()
+		slow <- resp.StatusCode
+	}()
+	for server.InFlight() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopped <- group.Stop(ctx)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// Draining: new requests are turned away, the in-flight one finishes
+	if resp, err := client.Get("http://" + addr + "/"); err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != 503 {
+			t.Errorf("GET while draining status = %d, want 503", resp.StatusCode)
+		}
+	}
+	close(release)
+	if status := <-slow; status != 200 {
+		t.Errorf("in-flight request status = %d, want 200", status)
+	}
+	if err := <-stopped; err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	<-done
+}
+
+func TestServerGroup_StopSkipsServersNeverStarted(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	server := NewFastHTTPServer(gocmd, DefaultFastHTTPServerConfig(freeAddr(t)))
+	group := NewServerGroup(server)
+	if err := group.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if server.IsStopped() {
+		t.Error("Stop() stopped a server the group never started")
+	}
+	if err := group.Start(); err != nil {
+		t.Errorf("Start() after Stop() error = %v, want nil with nothing launched", err)
+	}
+}
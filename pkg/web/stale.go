@@ -0,0 +1,193 @@
+package web
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core/failfast"
+)
+
+// StaleHeader is set to "true" on responses built from a stale value; Age
+// gives the value's age in seconds
+const StaleHeader = "X-Served-Stale"
+
+// DefaultStaleCacheSize is how many keys a StaleCache keeps by default
+const DefaultStaleCacheSize = 10000
+
+// StaleCacheConfig configures a StaleCache
+type StaleCacheConfig struct {
+	// Size is how many keys are kept, with their values and breakers, evicting
+	// the least recently used (default: DefaultStaleCacheSize)
+	Size int
+
+	// Breaker, if set, creates a circuit breaker per key: while it is open the
+	// fresh fetch is skipped and the stale value served at once, instead of
+	// waiting for another timeout
+	Breaker func(key string) CircuitBreaker
+}
+
+// StaleCache keeps the last good value per key for ServeStale
+type StaleCache struct {
+	size    int
+	breaker func(key string) CircuitBreaker
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // Key -> entry in order
+	order   *list.List               // *staleEntry, least recently used first
+}
+
+// staleEntry is a key's last good value, if fetched is set, and its breaker, so
+// both are evicted together
+type staleEntry struct {
+	key     string
+	value   interface{}
+	fetched time.Time
+	breaker CircuitBreaker
+}
+
+// NewStaleCache creates a StaleCache.
+// Fail-fast: panics on a negative Size
+func NewStaleCache(cfg StaleCacheConfig) *StaleCache {
+	failfast.If(cfg.Size >= 0, "stale cache size cannot be negative")
+	if cfg.Size == 0 {
+		cfg.Size = DefaultStaleCacheSize
+	}
+	return &StaleCache{
+		size:    cfg.Size,
+		breaker: cfg.Breaker,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+var sharedStaleCache atomic.Pointer[StaleCache]
+
+// SharedStaleCache returns the process-wide cache used by ServeStale
+// Created with the default config on first use; replace it with SetSharedStaleCache
+func SharedStaleCache() *StaleCache {
+	if c := sharedStaleCache.Load(); c != nil {
+		return c
+	}
+	sharedStaleCache.CompareAndSwap(nil, NewStaleCache(StaleCacheConfig{}))
+	return sharedStaleCache.Load()
+}
+
+// SetSharedStaleCache replaces the shared cache - fail-fast on nil
+// Call it at startup, e.g. to add a circuit breaker
+func SetSharedStaleCache(c *StaleCache) {
+	failfast.NotNil(c, "stale cache")
+	sharedStaleCache.Store(c)
+}
+
+// ServeStale returns fresh's value and remembers it under key. When fresh fails,
+// the last good value is returned instead if it is at most staleTTL old, and the
+// response is marked with StaleHeader and Age; otherwise fresh's error is returned.
+// For read endpoints that should keep answering while a backend is down:
+//
+//	product, err := web.ServeStale(ctx, "product:"+id, func() (interface{}, error) {
+//		reply, err := ctx.EventBus.Request("product.get", id, 2*time.Second)
+//		if err != nil {
+//			return nil, err
+//		}
+//		var p Product
+//		return p, reply.DecodeBody(&p)
+//	}, 10*time.Minute)
+//
+// Uses SharedStaleCache; see StaleCache.Get.
+func ServeStale(ctx *FastRequestContext, key string, fresh func() (interface{}, error), staleTTL time.Duration) (interface{}, error) {
+	return SharedStaleCache().Get(ctx, key, fresh, staleTTL)
+}
+
+// Get is ServeStale on this cache. With a Breaker configured, fresh's outcome is
+// reported to key's breaker, and while it is open fresh isn't called: the stale
+// value is served, or ErrCircuitOpen returned if there is none.
+// Fail-fast: panics on a nil ctx or fresh, or a non-positive staleTTL
+func (c *StaleCache) Get(ctx *FastRequestContext, key string, fresh func() (interface{}, error), staleTTL time.Duration) (interface{}, error) {
+	failfast.NotNil(ctx, "ctx")
+	failfast.NotNil(fresh, "fresh")
+	failfast.If(staleTTL > 0, "stale TTL must be positive")
+
+	breaker := c.breakerFor(key)
+	var err error
+	if breaker != nil && !breaker.Allow() {
+		err = ErrCircuitOpen
+	} else {
+		var value interface{}
+		if value, err = fresh(); err == nil {
+			if breaker != nil {
+				breaker.Success()
+			}
+			c.put(key, value, time.Now())
+			return value, nil
+		}
+		if breaker != nil {
+			breaker.Failure()
+		}
+	}
+
+	entry, ok := c.get(key)
+	if !ok {
+		return nil, err
+	}
+	age := time.Since(entry.fetched)
+	if age > staleTTL {
+		return nil, err
+	}
+	if ctx.RequestCtx != nil {
+		ctx.RequestCtx.Response.Header.Set(StaleHeader, "true")
+		ctx.RequestCtx.Response.Header.Set("Age", strconv.Itoa(int(age.Seconds())))
+	}
+	return entry.value, nil
+}
+
+// breakerFor returns key's circuit breaker, or nil if none is configured
+func (c *StaleCache) breakerFor(key string) CircuitBreaker {
+	if c.breaker == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.entry(key)
+	if e.breaker == nil {
+		e.breaker = c.breaker(key)
+	}
+	return e.breaker
+}
+
+func (c *StaleCache) get(key string) (staleEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || e.Value.(*staleEntry).fetched.IsZero() {
+		return staleEntry{}, false
+	}
+	c.order.MoveToBack(e)
+	return *e.Value.(*staleEntry), true
+}
+
+func (c *StaleCache) put(key string, value interface{}, fetched time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.entry(key)
+	e.value, e.fetched = value, fetched
+}
+
+// entry returns key's entry as the most recently used, adding it and evicting
+// the least recently used keys past size. Caller holds c.mu.
+func (c *StaleCache) entry(key string) *staleEntry {
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToBack(e)
+		return e.Value.(*staleEntry)
+	}
+	entry := &staleEntry{key: key}
+	c.entries[key] = c.order.PushBack(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Front()
+		delete(c.entries, oldest.Value.(*staleEntry).key)
+		c.order.Remove(oldest)
+	}
+	return entry
+}
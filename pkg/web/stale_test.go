@@ -0,0 +1,134 @@
+package web
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/valyala/fasthttp"
+)
+
+func newStaleTestContext() *FastRequestContext {
+	return &FastRequestContext{BaseRequestContext: core.NewBaseRequestContext(), RequestCtx: &fasthttp.RequestCtx{}}
+}
+
+func TestStaleCache_ServesLastGoodValue(t *testing.T) {
+	cache := NewStaleCache(StaleCacheConfig{})
+	errDown := errors.New("backend down")
+	value, fail := "v1", false
+	fresh := func() (interface{}, error) {
+		if fail {
+			return nil, errDown
+		}
+		return value, nil
+	}
+
+	ctx := newStaleTestContext()
+	if got, err := cache.Get(ctx, "k", fresh, time.Minute); err != nil || got != "v1" {
+		t.Fatalf("Get() = %v, %v, want v1", got, err)
+	}
+	if h := ctx.RequestCtx.Response.Header.Peek(StaleHeader); len(h) != 0 {
+		t.Errorf("fresh response has %s = %s", StaleHeader, h)
+	}
+
+	fail = true
+	ctx = newStaleTestContext()
+	if got, err := cache.Get(ctx, "k", fresh, time.Minute); err != nil || got != "v1" {
+		t.Fatalf("Get() while failing = %v, %v, want stale v1", got, err)
+	}
+	if h := string(ctx.RequestCtx.Response.Header.Peek(StaleHeader)); h != "true" {
+		t.Errorf("%s = %q, want true", StaleHeader, h)
+	}
+	if h := string(ctx.RequestCtx.Response.Header.Peek("Age")); h != "0" {
+		t.Errorf("Age = %q, want 0", h)
+	}
+
+	// Nothing cached, or too old: the fetch error
+	if _, err := cache.Get(newStaleTestContext(), "other", fresh, time.Minute); !errors.Is(err, errDown) {
+		t.Errorf("Get() without a stale value error = %v, want the fetch error", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := cache.Get(newStaleTestContext(), "k", fresh, time.Millisecond); !errors.Is(err, errDown) {
+		t.Errorf("Get() past staleTTL error = %v, want the fetch error", err)
+	}
+
+	// Recovery refreshes the value
+	fail, value = false, "v2"
+	if got, _ := cache.Get(newStaleTestContext(), "k", fresh, time.Minute); got != "v2" {
+		t.Errorf("Get() after recovery = %v, want v2", got)
+	}
+}
+
+func TestStaleCache_Breaker(t *testing.T) {
+	breakers := make(map[string]*countingBreaker)
+	cache := NewStaleCache(StaleCacheConfig{Breaker: func(key string) CircuitBreaker {
+		breakers[key] = &countingBreaker{}
+		return breakers[key]
+	}})
+	calls, fail := 0, false
+	fresh := func() (interface{}, error) {
+		calls++
+		if fail {
+			return nil, errors.New("timeout")
+		}
+		return "good", nil
+	}
+
+	_, _ = cache.Get(newStaleTestContext(), "k", fresh, time.Minute)
+	fail = true
+	_, _ = cache.Get(newStaleTestContext(), "k", fresh, time.Minute) // Opens the breaker
+	if !breakers["k"].open.Load() {
+		t.Fatal("breaker not told about the failure")
+	}
+
+	// Open: stale at once, without calling fresh
+	ctx := newStaleTestContext()
+	got, err := cache.Get(ctx, "k", fresh, time.Minute)
+	if err != nil || got != "good" || calls != 2 {
+		t.Errorf("Get() with open breaker = %v, %v after %d calls, want stale without a call", got, err, calls)
+	}
+	if h := string(ctx.RequestCtx.Response.Header.Peek(StaleHeader)); h != "true" {
+		t.Errorf("%s = %q, want true", StaleHeader, h)
+	}
+
+	// Another key has its own breaker; with nothing stale the open breaker is the error
+	fail = true
+	_, _ = cache.Get(newStaleTestContext(), "new", fresh, time.Minute)
+	if _, err := cache.Get(newStaleTestContext(), "new", fresh, time.Minute); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() with open breaker and no value error = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestStaleCache_Evicts(t *testing.T) {
+	cache := NewStaleCache(StaleCacheConfig{Size: 2})
+	for _, key := range []string{"a", "b", "c"} {
+		key := key
+		_, _ = cache.Get(newStaleTestContext(), key, func() (interface{}, error) { return key, nil }, time.Minute)
+	}
+	failing := func() (interface{}, error) { return nil, errors.New("down") }
+	if _, err := cache.Get(newStaleTestContext(), "a", failing, time.Minute); err == nil {
+		t.Error("least recently used key a should have been evicted")
+	}
+	if got, _ := cache.Get(newStaleTestContext(), "c", failing, time.Minute); got != "c" {
+		t.Errorf("Get(c) = %v, want stale c", got)
+	}
+}
+
+func TestStaleCache_EvictsBreakers(t *testing.T) {
+	created := 0
+	cache := NewStaleCache(StaleCacheConfig{Size: 2, Breaker: func(key string) CircuitBreaker {
+		created++
+		return &countingBreaker{}
+	}})
+	failing := func() (interface{}, error) { return nil, errors.New("down") }
+	for _, key := range []string{"a", "b", "c", "a"} {
+		_, _ = cache.Get(newStaleTestContext(), key, failing, time.Minute)
+	}
+	if created != 4 {
+		t.Errorf("breakers created = %d, want 4 (a's evicted with its key)", created)
+	}
+	if len(cache.entries) != 2 {
+		t.Errorf("cache keeps %d keys, want 2", len(cache.entries))
+	}
+}
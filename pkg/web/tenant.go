@@ -0,0 +1,47 @@
+package web
+
+import (
+	"strings"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// TenantScope creates middleware that scopes each request to the tenant returned
+// by tenantOf (e.g. read from a JWT claim or a header): ctx.EventBus becomes a
+// core.TenantEventBus and ctx.Context() carries the tenant (core.GetTenant).
+// Requests without a tenant are served unscoped. A tenant ID that can't be used as an address token is rejected with 400.
+func TenantScope(tenantOf func(ctx *FastRequestContext) string) FastMiddleware {
+	if tenantOf == nil {
+		panic("TenantScope: tenantOf cannot be nil")
+	}
+	return func(next FastRequestHandler) FastRequestHandler {
+		return func(ctx *FastRequestContext) error {
+			tenantID := tenantOf(ctx)
+			if tenantID == "" {
+				return next(ctx)
+			}
+			if strings.ContainsAny(tenantID, ".*> \t\r\n") {
+				return ctx.JSON(400, map[string]string{"error": "invalid_tenant"})
+			}
+			ctx.tenantID = tenantID
+			if ctx.EventBus != nil {
+				ctx.EventBus = core.NewTenantEventBus(ctx.EventBus, tenantID)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// TenantFromHeader returns a TenantScope extractor reading header (e.g. "X-Tenant-ID").
+// Only trust it behind a gateway that sets the header; otherwise derive the tenant
+// from authenticated claims.
+func TenantFromHeader(header string) func(ctx *FastRequestContext) string {
+	return func(ctx *FastRequestContext) string {
+		return string(ctx.RequestCtx.Request.Header.Peek(header))
+	}
+}
+
+// Tenant returns the request's tenant set by TenantScope, or ""
+func (c *FastRequestContext) Tenant() string {
+	return c.tenantID
+}
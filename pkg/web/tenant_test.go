@@ -0,0 +1,58 @@
+package web
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestTenantScope(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	received := make(chan string, 1)
+	consumer := core.NewTenantEventBus(gocmd.EventBus(), "acme").Consumer("order.created").Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		received <- msg.Header(core.TenantHeader)
+		return nil
+	})
+	select {
+	case <-consumer.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("consumer not ready")
+	}
+
+	router := NewFastRouter()
+	router.UseFast(TenantScope(TenantFromHeader("X-Tenant-ID")))
+	var tenant string
+	router.POSTFast("/orders", func(ctx *FastRequestContext) error {
+		tenant = core.GetTenant(ctx.Context())
+		if err := ctx.EventBus.Publish("order.created", "o-1"); err != nil {
+			return err
+		}
+		return ctx.Text(202, "accepted")
+	})
+
+	ctx := newTestFastContext(gocmd, "POST", "/orders")
+	ctx.RequestCtx.Request.Header.Set("X-Tenant-ID", "acme")
+	router.ServeFastHTTP(ctx)
+	if ctx.RequestCtx.Response.StatusCode() != 202 || tenant != "acme" || ctx.Tenant() != "acme" {
+		t.Fatalf("status = %d, tenant = %q", ctx.RequestCtx.Response.StatusCode(), tenant)
+	}
+	select {
+	case got := <-received:
+		if got != "acme" {
+			t.Errorf("%s = %q, want acme", core.TenantHeader, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("tenant message not delivered")
+	}
+
+	ctx = newTestFastContext(gocmd, "POST", "/orders")
+	ctx.RequestCtx.Request.Header.Set("X-Tenant-ID", "acme.admin")
+	router.ServeFastHTTP(ctx)
+	if ctx.RequestCtx.Response.StatusCode() != 400 {
+		t.Errorf("invalid tenant status = %d, want 400", ctx.RequestCtx.Response.StatusCode())
+	}
+}
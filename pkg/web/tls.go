@@ -0,0 +1,172 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// DefaultTLSReloadInterval is how often certificate files are checked for changes
+const DefaultTLSReloadInterval = time.Minute
+
+// TLSConfig configures HTTPS for FastHTTPServer (FastHTTPServerConfig.TLS)
+type TLSConfig struct {
+	// CertFile and KeyFile are PEM files, reloaded when they change on disk
+	// (e.g. after a Let's Encrypt renewal) without restarting the server
+	CertFile string
+	KeyFile  string
+
+	// GetCertificate, if set, supplies certificates instead of CertFile/KeyFile
+	GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+	// ReloadInterval is how often CertFile/KeyFile are checked for changes
+	// (default: DefaultTLSReloadInterval; negative disables reloading)
+	ReloadInterval time.Duration
+
+	// mTLS: ClientCAFile is a PEM bundle of CAs trusted for client certificates.
+	// ClientAuth defaults to tls.RequireAndVerifyClientCert when ClientCAFile is set.
+	ClientCAFile string
+	ClientAuth   tls.ClientAuthType
+
+	// MinVersion defaults to TLS 1.2
+	MinVersion uint16
+}
+
+// certReloader serves the certificate from CertFile/KeyFile, reloading it when
+// either file's modification time changes
+type certReloader struct {
+	certFile, keyFile string
+
+	mu              sync.RWMutex
+	cert            *tls.Certificate
+	certMod, keyMod time.Time
+}
+
+// newServerTLS builds the server's tls.Config - fail-fast without a certificate source.
+// Files are read by loadServerTLS, at Start.
+func newServerTLS(config *TLSConfig) (*tls.Config, *certReloader) {
+	if config.GetCertificate == nil && (config.CertFile == "" || config.KeyFile == "") {
+		panic("TLS config needs CertFile and KeyFile, or GetCertificate")
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     config.MinVersion,
+		ClientAuth:     config.ClientAuth,
+		GetCertificate: config.GetCertificate,
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+	if config.ClientCAFile != "" && tlsConfig.ClientAuth == tls.NoClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	var reloader *certReloader
+	if config.GetCertificate == nil {
+		reloader = &certReloader{certFile: config.CertFile, keyFile: config.KeyFile}
+		tlsConfig.GetCertificate = reloader.getCertificate
+	}
+	return tlsConfig, reloader
+}
+
+// loadServerTLS reads the client CA bundle and the initial certificate
+func loadServerTLS(tlsConfig *tls.Config, config *TLSConfig, reloader *certReloader) error {
+	if config.ClientCAFile != "" {
+		pem, err := os.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", config.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+	}
+	if reloader != nil {
+		if _, err := reloader.reload(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startTLS loads certificates and starts watching the files for changes
+func (s *FastHTTPServer) startTLS() error {
+	if err := loadServerTLS(s.tlsConfig, s.tlsSettings, s.certs); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	interval := s.tlsSettings.ReloadInterval
+	if interval == 0 {
+		interval = DefaultTLSReloadInterval
+	}
+	if s.certs != nil && interval > 0 {
+		ctx, cancel := context.WithCancel(s.GoCMD().Context())
+		s.stopCertWatch = cancel
+		go s.certs.watch(ctx, interval, s.Logger())
+	}
+	return nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reload loads the key pair if either file changed since the last load.
+// On error the current certificate stays in use.
+func (r *certReloader) reload() (bool, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return false, fmt.Errorf("stat certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("stat key: %w", err)
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("load key pair: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.certMod = certInfo.ModTime()
+	r.keyMod = keyInfo.ModTime()
+	r.mu.Unlock()
+	return true, nil
+}
+
+// watch reloads the certificate every interval until ctx is done
+func (r *certReloader) watch(ctx context.Context, interval time.Duration, logger core.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				logger.Error(fmt.Sprintf("TLS certificate reload failed, keeping current certificate: %v", err))
+				continue
+			}
+			if reloaded {
+				logger.Info(fmt.Sprintf("TLS certificate reloaded from %s", r.certFile))
+			}
+		}
+	}
+}
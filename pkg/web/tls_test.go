@@ -0,0 +1,184 @@
+package web
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 (usable as server,
+// client and CA certificate) and returns it
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "fluxor-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+// serveTestTLS starts server's HTTPS listener on a random port and returns its address
+func serveTestTLS(t *testing.T, server *FastHTTPServer) string {
+	t.Helper()
+	if err := server.startTLS(); err != nil {
+		t.Fatalf("startTLS() error = %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.server.ServeTLS(ln, "", "")
+	t.Cleanup(func() { server.server.Shutdown() })
+	return ln.Addr().String()
+}
+
+func TestFastHTTPServer_TLSCertReload(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	first := writeTestCert(t, certFile, keyFile, 1)
+
+	config := DefaultFastHTTPServerConfig(":0")
+	config.TLS = &TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: -1}
+	server := NewFastHTTPServer(gocmd, config)
+	server.FastRouter().GETFast("/", func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") })
+	addr := serveTestTLS(t, server)
+
+	get := func(trusted *x509.Certificate) *http.Response {
+		t.Helper()
+		roots := x509.NewCertPool()
+		roots.AddCert(trusted)
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots},
+			DisableKeepAlives: true,
+		}}
+		resp, err := client.Get("https://" + addr + "/")
+		if err != nil {
+			t.Fatalf("GET error = %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := get(first); resp.StatusCode != 200 || resp.TLS.PeerCertificates[0].SerialNumber.Int64() != 1 {
+		t.Errorf("status = %d, serial = %v", resp.StatusCode, resp.TLS.PeerCertificates[0].SerialNumber)
+	}
+
+	// Rotate the files; the next handshake uses the new certificate
+	second := writeTestCert(t, certFile, keyFile, 2)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+	if reloaded, err := server.certs.reload(); err != nil || !reloaded {
+		t.Fatalf("reload() = %v, %v", reloaded, err)
+	}
+	if resp := get(second); resp.TLS.PeerCertificates[0].SerialNumber.Int64() != 2 {
+		t.Errorf("serial after reload = %v, want 2", resp.TLS.PeerCertificates[0].SerialNumber)
+	}
+
+	// A broken file keeps the current certificate
+	os.WriteFile(keyFile, []byte("garbage"), 0o600)
+	os.Chtimes(keyFile, later.Add(time.Minute), later.Add(time.Minute))
+	if _, err := server.certs.reload(); err == nil {
+		t.Error("reload() of a broken key should fail")
+	}
+	if resp := get(second); resp.StatusCode != 200 {
+		t.Errorf("status after failed reload = %d", resp.StatusCode)
+	}
+}
+
+func TestFastHTTPServer_MutualTLS(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	ca := writeTestCert(t, certFile, keyFile, 1)
+
+	config := DefaultFastHTTPServerConfig(":0")
+	config.TLS = &TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile, ReloadInterval: -1}
+	server := NewFastHTTPServer(gocmd, config)
+	server.FastRouter().GETFast("/", func(ctx *FastRequestContext) error { return ctx.Text(200, "ok") })
+	addr := serveTestTLS(t, server)
+
+	if server.tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("ClientAuth = %v, want RequireAndVerifyClientCert by default", server.tlsConfig.ClientAuth)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}, DisableKeepAlives: true}}
+	if resp, err := anonymous.Get("https://" + addr + "/"); err == nil {
+		resp.Body.Close()
+		t.Error("request without a client certificate should fail")
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticated := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{clientCert},
+	}, DisableKeepAlives: true}}
+	resp, err := authenticated.Get("https://" + addr + "/")
+	if err != nil {
+		t.Fatalf("GET with client certificate error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestFastHTTPServer_TLSMissingCertFailsStart(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+
+	config := DefaultFastHTTPServerConfig(":0")
+	config.TLS = &TLSConfig{CertFile: "/nonexistent/cert.pem", KeyFile: "/nonexistent/key.pem"}
+	if err := NewFastHTTPServer(gocmd, config).startTLS(); err == nil {
+		t.Error("startTLS() should fail when the certificate files are missing")
+	}
+}
@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+)
+
+// DefaultAdminCleanupAge is the age POST /cleanup removes finished executions
+// past when no maxAge is given
+const DefaultAdminCleanupAge = 24 * time.Hour
+
+// AdminRoutes registers endpoints to inspect and control engine's executions
+// under routes, for operators. They change executions, so protect the group
+// with auth middleware:
+//
+//	admin := router.Group("/admin/workflows", auth.JWT(jwtConfig), auth.RequireRole("admin"))
+//	workflow.AdminRoutes(admin, engine)
+//
+// Endpoints, relative to the group:
+//   - GET  /definitions: registered workflows
+//   - GET  /executions: execution summaries, newest first; filter with
+//     ?workflowId=, ?status=running,paused and ?limit=
+//   - GET  /executions/:id: full execution state
+//   - POST /executions/:id/cancel, /pause, /resume
+//   - POST /executions/:id/signal: resumes the execution's waitevent nodes with
+//     the JSON body (up to web.DefaultBindMaxBytes) as their event, ignoring
+//     address and correlation
+//   - POST /cleanup: removes executions finished more than ?maxAge= ago
+//     (default: DefaultAdminCleanupAge)
+//
+// Unknown executions get 404; actions an execution's status doesn't allow, 409.
+func AdminRoutes(routes *web.FastRouteGroup, engine *Engine) {
+	routes.GETFast("/definitions", func(c *web.FastRequestContext) error {
+		return c.JSON(200, map[string]interface{}{"workflows": engine.ListWorkflows()})
+	})
+
+	routes.GETFast("/executions", func(c *web.FastRequestContext) error {
+		limit, err := c.QueryIntE("limit", 0)
+		if err != nil || limit < 0 {
+			return c.JSON(400, map[string]interface{}{"error": "invalid limit"})
+		}
+		filter := ExecutionFilter{WorkflowID: c.Query("workflowId"), Limit: limit}
+		for _, status := range c.QueryStringSlice("status") {
+			filter.Statuses = append(filter.Statuses, ExecutionStatus(status))
+		}
+		return c.JSON(200, map[string]interface{}{"executions": engine.ListExecutions(filter)})
+	})
+
+	routes.GETFast("/executions/:id", func(c *web.FastRequestContext) error {
+		// A snapshot, so encoding doesn't race with the running execution
+		state, err := engine.GetExecutionState(c.Param("id"))
+		if err != nil {
+			return c.JSON(404, map[string]interface{}{"error": err.Error()})
+		}
+		return c.JSON(200, state)
+	})
+
+	for action, control := range map[string]func(string) error{
+		"cancel": engine.CancelExecution,
+		"pause":  engine.PauseExecution,
+		"resume": engine.ResumeExecution,
+	} {
+		action, control := action, control
+		routes.POSTFast("/executions/:id/"+action, func(c *web.FastRequestContext) error {
+			execID := c.Param("id")
+			if engine.executionStatus(execID) == "" {
+				return c.JSON(404, map[string]interface{}{"error": "execution not found: " + execID})
+			}
+			if err := control(execID); err != nil {
+				return c.JSON(409, map[string]interface{}{"error": err.Error()})
+			}
+			return c.JSON(200, map[string]interface{}{"executionId": execID, "status": engine.executionStatus(execID)})
+		})
+	}
+
+	routes.POSTFast("/executions/:id/signal", func(c *web.FastRequestContext) error {
+		execID := c.Param("id")
+		if engine.executionStatus(execID) == "" {
+			return c.JSON(404, map[string]interface{}{"error": "execution not found: " + execID})
+		}
+		var event interface{}
+		if len(c.RequestCtx.PostBody()) > 0 {
+			if err := c.BindJSON(&event, web.BindOptions{MaxBytes: web.DefaultBindMaxBytes}); err != nil {
+				return c.JSON(web.BindErrorStatus(err), map[string]interface{}{"error": err.Error()})
+			}
+		}
+		resumed, err := engine.SignalExecution(execID, event)
+		if err != nil {
+			return c.JSON(409, map[string]interface{}{"error": err.Error()})
+		}
+		return c.JSON(200, map[string]interface{}{"executionId": execID, "resumed": resumed})
+	})
+
+	routes.POSTFast("/cleanup", func(c *web.FastRequestContext) error {
+		maxAge := DefaultAdminCleanupAge
+		if s := c.Query("maxAge"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d < 0 {
+				return c.JSON(400, map[string]interface{}{"error": "invalid maxAge"})
+			}
+			maxAge = d
+		}
+		return c.JSON(200, map[string]interface{}{"removed": engine.CleanupOldExecutions(maxAge)})
+	})
+}
+
+// executionStatus returns executionID's status, read under the engine lock
+func (e *Engine) executionStatus(executionID string) ExecutionStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if state, ok := e.executions[executionID]; ok {
+		return state.Status
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
+)
+
+// adminRequest serves one request through router and decodes the JSON response
+func adminRequest(t *testing.T, gocmd core.GoCMD, router *web.FastRouter, method, uri, body string) (int, map[string]interface{}) {
+	t.Helper()
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod(method)
+	reqCtx.Request.SetRequestURI(uri)
+	reqCtx.Request.SetBodyString(body)
+	c := &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         reqCtx,
+		GoCMD:              gocmd,
+		EventBus:           gocmd.EventBus(),
+		Params:             make(map[string]string),
+	}
+	router.ServeFastHTTP(c)
+	var got map[string]interface{}
+	if err := json.Unmarshal(reqCtx.Response.Body(), &got); err != nil {
+		t.Fatalf("%s %s: invalid JSON response %q", method, uri, reqCtx.Response.Body())
+	}
+	return reqCtx.Response.StatusCode(), got
+}
+
+func TestAdminRoutes(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+	if err := engine.RegisterWorkflow(waitEventWorkflow("")); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.RegisterWorkflow(NewWorkflowBuilder("quick", "Quick").AddNode("start", "noop").Done().Build()); err != nil {
+		t.Fatal(err)
+	}
+
+	router := web.NewFastRouter()
+	AdminRoutes(router.Group("/admin/workflows"), engine)
+
+	waiting, _ := engine.ExecuteWorkflow(context.Background(), "await-payment", map[string]interface{}{"orderId": "1"})
+	quick, _ := engine.ExecuteWorkflow(context.Background(), "quick", nil)
+	waitForSuspensions(t, engine, 1)
+	waitForStatus(t, engine, quick, ExecutionStatusCompleted)
+
+	status, got := adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions?status=running", "")
+	executions, _ := got["executions"].([]interface{})
+	if status != 200 || len(executions) != 1 || executions[0].(map[string]interface{})["executionId"] != waiting {
+		t.Fatalf("GET /executions?status=running = %d %v, want only %s", status, got, waiting)
+	}
+	if _, got = adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions?workflowId=quick", ""); len(got["executions"].([]interface{})) != 1 {
+		t.Errorf("GET /executions?workflowId=quick = %v, want one execution", got)
+	}
+	if status, _ = adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions?limit=x", ""); status != 400 {
+		t.Errorf("GET /executions?limit=x status = %d, want 400", status)
+	}
+	if status, got = adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions/"+waiting, ""); status != 200 || got["context"] == nil {
+		t.Errorf("GET /executions/:id = %d %v, want the full state", status, got)
+	}
+	if status, _ = adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions/missing", ""); status != 404 {
+		t.Errorf("GET /executions/missing status = %d, want 404", status)
+	}
+
+	// Control: a finished execution can't be paused
+	if status, got = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+waiting+"/pause", ""); status != 200 || got["status"] != string(ExecutionStatusPaused) {
+		t.Errorf("POST pause = %d %v, want paused", status, got)
+	}
+	if status, got = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+waiting+"/resume", ""); status != 200 || got["status"] != string(ExecutionStatusRunning) {
+		t.Errorf("POST resume = %d %v, want running", status, got)
+	}
+	if status, _ = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+quick+"/pause", ""); status != 409 {
+		t.Errorf("POST pause of a completed execution status = %d, want 409", status)
+	}
+
+	if status, _ = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+waiting+"/signal", `{"pad":"`+strings.Repeat("x", web.DefaultBindMaxBytes)+`"}`); status != 413 {
+		t.Errorf("POST signal with an oversized body status = %d, want 413", status)
+	}
+	// Signal resumes the waitevent node without a matching correlation value
+	if status, got = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+waiting+"/signal", `{"orderId":"other"}`); status != 200 || got["resumed"] != float64(1) {
+		t.Fatalf("POST signal = %d %v, want 1 resumed", status, got)
+	}
+	waitForStatus(t, engine, waiting, ExecutionStatusCompleted)
+	if status, _ = adminRequest(t, gocmd, router, "POST", "/admin/workflows/executions/"+waiting+"/signal", "{}"); status != 409 {
+		t.Errorf("POST signal to an execution not waiting status = %d, want 409", status)
+	}
+
+	if status, got = adminRequest(t, gocmd, router, "POST", "/admin/workflows/cleanup?maxAge=0s", ""); status != 200 || got["removed"] != float64(2) {
+		t.Errorf("POST cleanup = %d %v, want 2 removed", status, got)
+	}
+	if _, got = adminRequest(t, gocmd, router, "GET", "/admin/workflows/executions", ""); len(got["executions"].([]interface{})) != 0 {
+		t.Errorf("executions after cleanup = %v, want none", got)
+	}
+}
@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// AuditSource is the AuditEntry.Source of the entries the engine records
+const AuditSource = "workflow"
+
+// EventExecutionStarted is recorded in the audit trail when an execution starts;
+// it is not published as an ExecutionEvent
+const EventExecutionStarted ExecutionEventType = "execution.started"
+
+// SetAuditSink makes the engine record every execution start, node start,
+// completion and failure, and execution end to sink, alongside the in-memory
+// ExecutionState; nil stops recording. Record is called on the engine's
+// goroutines, so sink must not block (see core.FileAuditSink, core.EventBusAuditSink).
+func (e *Engine) SetAuditSink(sink core.AuditSink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.audit = sink
+}
+
+// record writes an execution event to the audit sink, if any; callers don't hold e.mu
+func (e *Engine) record(event ExecutionEvent) {
+	e.mu.RLock()
+	sink := e.audit
+	var workflowID string
+	if state, ok := e.executions[event.ExecutionID]; ok {
+		workflowID = state.WorkflowID
+	}
+	e.mu.RUnlock()
+	if sink == nil {
+		return
+	}
+
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	sink.Record(core.AuditEntry{
+		Time:       timestamp,
+		Source:     AuditSource,
+		Event:      string(event.Type),
+		Definition: workflowID,
+		InstanceID: event.ExecutionID,
+		Step:       event.NodeID,
+		Status:     string(event.Status),
+		Error:      event.Error,
+	})
+}
@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+type recordingAuditSink struct {
+	mu      sync.Mutex
+	entries []core.AuditEntry
+}
+
+func (s *recordingAuditSink) Record(entry core.AuditEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+func (s *recordingAuditSink) events() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []string
+	for _, entry := range s.entries {
+		events = append(events, entry.Event+":"+entry.Step)
+	}
+	return events
+}
+
+func TestEngine_AuditSink(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+	sink := &recordingAuditSink{}
+	engine.SetAuditSink(sink)
+
+	def := NewWorkflowBuilder("audited", "Audited").
+		AddNode("start", "manual").Next("step").Done().
+		AddNode("step", "noop").Done().
+		Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err := engine.ExecuteWorkflow(context.Background(), "audited", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+
+	want := []string{
+		"execution.started:",
+		"node.started:start", "node.completed:start",
+		"node.started:step", "node.completed:step",
+		"execution.finished:",
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sink.events()) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	got := sink.events()
+	if len(got) != len(want) {
+		t.Fatalf("audit events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("audit event %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	last := sink.entries[len(sink.entries)-1]
+	if last.Source != AuditSource || last.Definition != "audited" || last.InstanceID != execID || last.Status != string(ExecutionStatusCompleted) {
+		t.Errorf("last entry = %+v", last)
+	}
+}
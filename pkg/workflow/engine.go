@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fluxorio/fluxor/pkg/core"
-	"github.com/google/uuid"
 )
 
 // Engine implements WorkflowEngine using EventBus.
@@ -25,6 +25,12 @@ type Engine struct {
 	mergeStates map[string]*mergeState // executionID:nodeID -> merge state
 	mergeMu     sync.Mutex
 
+	// Parallel sections (guarded by mergeMu; see parallel.go)
+	branchTags  map[string]*branchTag   // executionID:nodeID -> branch the node runs in
+	parallels   map[string]*parallelRun // executionID:parallelID -> latest run
+	joins       map[string]*joinState   // executionID:joinID -> branches settled in that run
+	parallelGen int
+
 	// Active node tracking for better completion detection
 	activeNodes map[string]map[string]bool // executionID -> nodeID -> true
 	activeMu    sync.Mutex
@@ -32,6 +38,27 @@ type Engine struct {
 	// Context cancellation for executions
 	execContexts map[string]context.CancelFunc // executionID -> cancel function
 	execCtxMu    sync.Mutex
+
+	// Suspended wait-event nodes
+	suspensions   map[string]*suspension   // executionID:nodeID -> suspension
+	waitConsumers map[string]core.Consumer // address -> consumer shared by its suspensions
+	suspendMu     sync.Mutex
+	store         SuspensionStore // Optional: persists suspensions across restarts
+
+	// Node launches held back while an execution is paused (guarded by mu)
+	held map[string][]heldNode // executionID -> launches
+
+	// Wrap every node handler call, outermost first (guarded by mu; see Use)
+	interceptors []NodeInterceptor
+
+	// Creates execution IDs (guarded by mu; see SetIDGenerator)
+	ids IDGenerator
+
+	// Optional audit trail (guarded by mu; see SetAuditSink)
+	audit core.AuditSink
+
+	// Called with each workflow RegisterWorkflow stores (guarded by mu; see onRegister)
+	registerHooks []func(def *WorkflowDefinition) error
 }
 
 type mergeState struct {
@@ -43,14 +70,21 @@ type mergeState struct {
 // NewEngine creates a new workflow engine.
 func NewEngine(eventBus core.EventBus) *Engine {
 	return &Engine{
-		eventBus:     eventBus,
-		registry:     NewNodeRegistry(),
-		workflows:    make(map[string]*WorkflowDefinition),
-		executions:   make(map[string]*ExecutionState),
-		mergeStates:  make(map[string]*mergeState),
-		activeNodes:  make(map[string]map[string]bool),
-		execContexts: make(map[string]context.CancelFunc),
-		logger:       core.NewDefaultLogger(),
+		eventBus:      eventBus,
+		registry:      NewNodeRegistry(),
+		workflows:     make(map[string]*WorkflowDefinition),
+		executions:    make(map[string]*ExecutionState),
+		mergeStates:   make(map[string]*mergeState),
+		branchTags:    make(map[string]*branchTag),
+		parallels:     make(map[string]*parallelRun),
+		joins:         make(map[string]*joinState),
+		activeNodes:   make(map[string]map[string]bool),
+		execContexts:  make(map[string]context.CancelFunc),
+		suspensions:   make(map[string]*suspension),
+		waitConsumers: make(map[string]core.Consumer),
+		held:          make(map[string][]heldNode),
+		logger:        core.NewDefaultLogger(),
+		ids:           UUIDGenerator{},
 	}
 }
 
@@ -98,18 +132,64 @@ func (e *Engine) RegisterWorkflow(def *WorkflowDefinition) error {
 				return fmt.Errorf("node %s references unknown node %s in falseNext", node.ID, next)
 			}
 		}
+		for _, next := range node.OnError {
+			if !nodeIDs[next] {
+				return fmt.Errorf("node %s references unknown node %s in onError", node.ID, next)
+			}
+		}
+	}
+
+	if err := validateJoins(def); err != nil {
+		return err
+	}
+
+	if err := checkSchema(def.InputSchema, ""); err != nil {
+		return fmt.Errorf("workflow %s inputSchema: %w", def.ID, err)
+	}
+	if err := checkSchema(def.OutputSchema, ""); err != nil {
+		return fmt.Errorf("workflow %s outputSchema: %w", def.ID, err)
+	}
+	if _, err := scheduleTriggers(def); err != nil {
+		return err
+	}
+	if _, err := eventTriggers(def); err != nil {
+		return err
+	}
+
+	// Reject cycles (unless they go through a loop node) and warn about dead nodes
+	unreachable, err := validateGraph(def)
+	if err != nil {
+		return err
+	}
+	if len(unreachable) > 0 {
+		e.logger.Info(fmt.Sprintf("workflow %s has unreachable nodes: %s", def.ID, strings.Join(unreachable, ", ")))
 	}
 
 	e.mu.Lock()
 	e.workflows[def.ID] = def
+	hooks := e.registerHooks
 	e.mu.Unlock()
 
 	// Register EventBus consumers for this workflow
 	e.registerWorkflowConsumers(def)
 
+	for _, hook := range hooks {
+		if err := hook(def); err != nil {
+			return fmt.Errorf("workflow %s: %w", def.ID, err)
+		}
+	}
 	return nil
 }
 
+// onRegister calls hook with each workflow registered from now on, after it is
+// stored; an error from hook is returned by RegisterWorkflow. The verticle uses
+// it to start triggers for workflows registered through Engine() after Start.
+func (e *Engine) onRegister(hook func(def *WorkflowDefinition) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.registerHooks = append(e.registerHooks, hook)
+}
+
 // registerWorkflowConsumers sets up EventBus consumers for workflow execution.
 func (e *Engine) registerWorkflowConsumers(def *WorkflowDefinition) {
 	// Consumer for workflow execution events
@@ -151,13 +231,19 @@ func (e *Engine) ExecuteWorkflow(ctx context.Context, workflowID string, input i
 func (e *Engine) startExecution(ctx context.Context, workflowID string, input interface{}) (string, error) {
 	e.mu.RLock()
 	def, ok := e.workflows[workflowID]
+	ids := e.ids
 	e.mu.RUnlock()
 
 	if !ok {
 		return "", fmt.Errorf("workflow not found: %s", workflowID)
 	}
 
-	executionID := uuid.New().String()
+	// Reject bad input at the boundary instead of deep inside a node
+	if err := validateSchema(def.InputSchema, input); err != nil {
+		return "", fmt.Errorf("invalid input for workflow %s: %w", workflowID, err)
+	}
+
+	executionID := ids.NewID()
 
 	// Create cancellable context for this execution
 	execCtx, cancel := context.WithCancel(ctx)
@@ -193,6 +279,7 @@ func (e *Engine) startExecution(ctx context.Context, workflowID string, input in
 	e.mu.Lock()
 	e.executions[executionID] = state
 	e.mu.Unlock()
+	e.record(ExecutionEvent{Type: EventExecutionStarted, ExecutionID: executionID, Status: ExecutionStatusRunning})
 
 	// Initialize active nodes tracking
 	e.activeMu.Lock()
@@ -201,7 +288,7 @@ func (e *Engine) startExecution(ctx context.Context, workflowID string, input in
 
 	// Find and execute trigger/start nodes
 	for _, node := range def.Nodes {
-		if e.isStartNode(&node, def) {
+		if isStartNode(&node, def) {
 			e.markNodeActive(executionID, node.ID)
 			go e.executeNode(execCtx, def, &node, execCtxData, input)
 		}
@@ -210,26 +297,16 @@ func (e *Engine) startExecution(ctx context.Context, workflowID string, input in
 	return executionID, nil
 }
 
-func (e *Engine) isStartNode(node *NodeDefinition, def *WorkflowDefinition) bool {
+func isStartNode(node *NodeDefinition, def *WorkflowDefinition) bool {
 	// A start node is either a trigger type or has no incoming connections
 	switch NodeType(node.Type) {
 	case NodeTypeWebhook, NodeTypeSchedule, NodeTypeEvent, NodeTypeManual:
 		return true
 	}
 
-	// Check if any node points to this node
-	for _, n := range def.Nodes {
-		for _, next := range n.Next {
-			if next == node.ID {
-				return false
-			}
-		}
-		for _, next := range n.TrueNext {
-			if next == node.ID {
-				return false
-			}
-		}
-		for _, next := range n.FalseNext {
+	// Check if any node points to this node, including through OnError
+	for i := range def.Nodes {
+		for _, next := range nodeEdges(&def.Nodes[i]) {
 			if next == node.ID {
 				return false
 			}
@@ -248,7 +325,25 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 	default:
 	}
 
+	e.emit(ExecutionEvent{Type: EventNodeStarted, ExecutionID: execCtx.ExecutionID, NodeID: node.ID})
 	nodeType := NodeType(node.Type)
+
+	// The node sees its mapped input; OnError edges still get the original
+	nodeData := input
+	if node.InputMapping != nil {
+		nodeData = applyMapping(node.InputMapping, input)
+	}
+
+	// Wait-event nodes suspend instead of running a handler; the node stays
+	// active (without holding a goroutine) until the event or timeout resumes it
+	if nodeType == NodeTypeWaitEvent {
+		if err := e.suspend(ctx, def, node, execCtx, nodeData); err != nil {
+			e.failNode(ctx, def, node, execCtx, input, err)
+			e.markNodeInactive(execCtx.ExecutionID, node.ID)
+		}
+		return
+	}
+
 	handler, ok := e.registry.Get(nodeType)
 	if !ok {
 		e.logger.Error(fmt.Sprintf("unknown node type: %s", node.Type))
@@ -256,6 +351,7 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 		e.markNodeInactive(execCtx.ExecutionID, node.ID)
 		return
 	}
+	handler = e.intercept(node, handler)
 
 	// Apply timeout if configured
 	nodeCtx := ctx
@@ -272,7 +368,7 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 
 	// Prepare input
 	nodeInput := &NodeInput{
-		Data:        input,
+		Data:        nodeData,
 		Context:     execCtx,
 		Config:      node.Config,
 		TriggerData: execCtx.Data["input"],
@@ -316,26 +412,59 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 
 	// Handle error
 	if err != nil {
-		e.recordError(execCtx, node.ID, err.Error())
-		if len(node.OnError) > 0 {
-			for _, nextID := range node.OnError {
-				nextNode := e.findNode(def, nextID)
-				if nextNode != nil {
-					e.markNodeActive(execCtx.ExecutionID, nextID)
-					go e.executeNode(ctx, def, nextNode, execCtx, input)
-				}
+		e.failNode(ctx, def, node, execCtx, input, err)
+		return
+	}
+
+	e.advance(ctx, def, node, execCtx, output)
+}
+
+// failNode records a node error and follows its OnError edges.
+func (e *Engine) failNode(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, execCtx *ExecutionContext, input interface{}, err error) {
+	e.recordError(execCtx, node.ID, err.Error())
+	if len(node.OnError) > 0 {
+		e.tagNext(def, execCtx.ExecutionID, node, node.OnError, false)
+		for _, nextID := range node.OnError {
+			nextNode := e.findNode(def, nextID)
+			if nextNode != nil {
+				e.markRecovering(execCtx.ExecutionID, nextID)
+				e.markNodeActive(execCtx.ExecutionID, nextID)
+				e.launchNode(ctx, def, nextNode, execCtx, input)
 			}
-		} else {
-			// No error handler - check if execution should complete
-			e.checkExecutionComplete(execCtx.ExecutionID)
 		}
-		return
+	} else {
+		// No error handler - a branch of a parallel node fails at its join
+		e.failBranch(ctx, def, node, execCtx, err)
+		e.checkExecutionComplete(execCtx.ExecutionID)
+	}
+}
+
+// advance stores a node's output and schedules the nodes that follow it.
+func (e *Engine) advance(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, execCtx *ExecutionContext, output *NodeOutput) {
+	// Determine next nodes (before mapping, so condition results still count)
+	nextNodes := e.determineNextNodes(node, output)
+	if node.OutputMapping != nil {
+		mapped := *output
+		mapped.Data = applyMapping(node.OutputMapping, output.Data)
+		output = &mapped
 	}
 
-	// Store output
+	// Store output; a node that ends a path also sets the execution output
 	e.mu.Lock()
 	execCtx.NodeOutputs[node.ID] = output.Data
+	recovering := false
+	if state, ok := e.executions[execCtx.ExecutionID]; ok {
+		setNodeResult(state, node.ID, NodeResult{Status: NodeStatusCompleted})
+		recovering = state.recovering[node.ID]
+		if output.Stop || len(nextNodes) == 0 {
+			state.Output = output.Data
+			if !recovering {
+				state.succeededPaths++
+			}
+		}
+	}
 	e.mu.Unlock()
+	e.emit(ExecutionEvent{Type: EventNodeCompleted, ExecutionID: execCtx.ExecutionID, NodeID: node.ID})
 
 	// Check if workflow should stop
 	if output.Stop {
@@ -343,8 +472,8 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 		return
 	}
 
-	// Determine next nodes
-	nextNodes := e.determineNextNodes(node, output)
+	// Branches of a parallel node carry its tag to the join
+	e.tagNext(def, execCtx.ExecutionID, node, nextNodes, NodeType(node.Type) == NodeTypeParallel)
 
 	// Execute next nodes
 	for _, nextID := range nextNodes {
@@ -357,12 +486,19 @@ func (e *Engine) executeNode(ctx context.Context, def *WorkflowDefinition, node
 
 		nextNode := e.findNode(def, nextID)
 		if nextNode != nil {
-			// Handle merge nodes
-			if NodeType(nextNode.Type) == NodeTypeMerge {
+			// Nodes after an error handler are part of its recovery path
+			if recovering {
+				e.markRecovering(execCtx.ExecutionID, nextID)
+			}
+			// Merge and join nodes wait for their inputs
+			switch NodeType(nextNode.Type) {
+			case NodeTypeMerge:
 				e.handleMergeInput(ctx, def, nextNode, execCtx, output.Data)
-			} else {
+			case NodeTypeJoin:
+				e.arriveAtJoin(ctx, def, nextNode, execCtx, node, output.Data)
+			default:
 				e.markNodeActive(execCtx.ExecutionID, nextID)
-				go e.executeNode(ctx, def, nextNode, execCtx, output.Data)
+				e.launchNode(ctx, def, nextNode, execCtx, output.Data)
 			}
 		}
 	}
@@ -455,7 +591,7 @@ func (e *Engine) handleMergeInput(ctx context.Context, def *WorkflowDefinition,
 		delete(e.mergeStates, key)
 		e.mergeMu.Unlock()
 		// Continue execution with merged data
-		go e.executeNode(ctx, def, node, execCtx, state.data)
+		e.launchNode(ctx, def, node, execCtx, state.data)
 	} else {
 		e.mergeMu.Unlock()
 	}
@@ -481,19 +617,51 @@ func (e *Engine) handleNodeExecution(ctx context.Context, def *WorkflowDefinitio
 		return fmt.Errorf("execution not found: %s", req.ExecutionID)
 	}
 
-	go e.executeNode(ctx, def, node, state.Context, req.Data)
+	e.launchNode(ctx, def, node, state.Context, req.Data)
 	return nil
 }
 
 func (e *Engine) recordError(execCtx *ExecutionContext, nodeID, message string) {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	execCtx.Errors = append(execCtx.Errors, ExecutionError{
 		NodeID:    nodeID,
 		Message:   message,
 		Timestamp: time.Now(),
 	})
+	if state, ok := e.executions[execCtx.ExecutionID]; ok {
+		setNodeResult(state, nodeID, NodeResult{Status: NodeStatusFailed, Error: message})
+	}
+	e.mu.Unlock()
+	e.emit(ExecutionEvent{Type: EventNodeFailed, ExecutionID: execCtx.ExecutionID, NodeID: nodeID, Error: message})
+}
+
+// setNodeResult records a node's outcome and updates the counts; callers hold e.mu.
+func setNodeResult(state *ExecutionState, nodeID string, result NodeResult) {
+	if state.NodeResults == nil {
+		state.NodeResults = make(map[string]NodeResult)
+	}
+	state.NodeResults[nodeID] = result
+	state.CompletedNodes, state.FailedNodes = 0, 0
+	for _, r := range state.NodeResults {
+		if r.Status == NodeStatusFailed {
+			state.FailedNodes++
+		} else {
+			state.CompletedNodes++
+		}
+	}
+}
+
+// markRecovering marks a node as reached through an onError edge, so paths
+// through it don't count towards partial success.
+func (e *Engine) markRecovering(executionID, nodeID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if state, ok := e.executions[executionID]; ok {
+		if state.recovering == nil {
+			state.recovering = make(map[string]bool)
+		}
+		state.recovering[nodeID] = true
+	}
 }
 
 func (e *Engine) completeExecution(executionID string, err error) {
@@ -507,13 +675,33 @@ func (e *Engine) completeExecution(executionID string, err error) {
 	now := time.Now()
 	state.EndTime = &now
 
-	if err != nil {
+	if err == nil && state.Status == ExecutionStatusRunning {
+		if def, ok := e.workflows[state.WorkflowID]; ok {
+			if verr := validateSchema(def.OutputSchema, state.Output); verr != nil {
+				err = fmt.Errorf("invalid output for workflow %s: %w", def.ID, verr)
+			}
+		}
+	}
+
+	// Node errors fail the execution unless another branch succeeded
+	errCount := len(state.Context.Errors)
+	switch {
+	case err != nil:
 		state.Status = ExecutionStatusFailed
 		state.Error = err.Error()
-	} else {
+	case errCount == 0:
 		state.Status = ExecutionStatusCompleted
+	case state.succeededPaths > 0:
+		state.Status = ExecutionStatusPartialSuccess
+		state.Error = fmt.Sprintf("workflow had %d errors", errCount)
+	default:
+		state.Status = ExecutionStatusFailed
+		state.Error = fmt.Sprintf("workflow had %d errors", errCount)
 	}
+	finished := ExecutionEvent{Type: EventExecutionFinished, ExecutionID: executionID, Status: state.Status, Error: state.Error}
+	delete(e.held, executionID)
 	e.mu.Unlock()
+	defer e.emit(finished)
 
 	// Clean up execution resources
 	e.execCtxMu.Lock()
@@ -524,22 +712,19 @@ func (e *Engine) completeExecution(executionID string, err error) {
 	delete(e.activeNodes, executionID)
 	e.activeMu.Unlock()
 
-	// Clean up merge states for this execution
-	e.mergeMu.Lock()
-	for key := range e.mergeStates {
-		if strings.HasPrefix(key, executionID+":") {
-			delete(e.mergeStates, key)
-		}
-	}
-	e.mergeMu.Unlock()
+	e.dropSuspensions(executionID)
+
+	// Clean up merge and join states for this execution
+	e.dropMergeState(executionID)
 }
 
 func (e *Engine) checkExecutionComplete(executionID string) {
 	e.mu.RLock()
 	state, ok := e.executions[executionID]
+	running := ok && state.Status == ExecutionStatusRunning
 	e.mu.RUnlock()
 
-	if !ok || state.Status != ExecutionStatusRunning {
+	if !running {
 		return
 	}
 
@@ -551,11 +736,7 @@ func (e *Engine) checkExecutionComplete(executionID string) {
 
 	// Only complete if no active nodes remain
 	if !hasActiveNodes {
-		if len(state.Context.Errors) == 0 {
-			e.completeExecution(executionID, nil)
-		} else {
-			e.completeExecution(executionID, fmt.Errorf("workflow had %d errors", len(state.Context.Errors)))
-		}
+		e.completeExecution(executionID, nil)
 	}
 }
 
@@ -594,7 +775,7 @@ func (e *Engine) GetExecution(executionID string) (*ExecutionContext, error) {
 	return state.Context, nil
 }
 
-// CancelExecution cancels a running execution.
+// CancelExecution cancels a running or paused execution.
 func (e *Engine) CancelExecution(executionID string) error {
 	e.mu.Lock()
 	state, ok := e.executions[executionID]
@@ -603,7 +784,7 @@ func (e *Engine) CancelExecution(executionID string) error {
 		return fmt.Errorf("execution not found: %s", executionID)
 	}
 
-	if state.Status != ExecutionStatusRunning {
+	if state.Status != ExecutionStatusRunning && state.Status != ExecutionStatusPaused {
 		e.mu.Unlock()
 		return fmt.Errorf("execution is not running")
 	}
@@ -611,7 +792,9 @@ func (e *Engine) CancelExecution(executionID string) error {
 	now := time.Now()
 	state.EndTime = &now
 	state.Status = ExecutionStatusCancelled
+	delete(e.held, executionID)
 	e.mu.Unlock()
+	defer e.emit(ExecutionEvent{Type: EventExecutionFinished, ExecutionID: executionID, Status: ExecutionStatusCancelled})
 
 	// Cancel the execution context to stop all running nodes
 	e.execCtxMu.Lock()
@@ -627,14 +810,10 @@ func (e *Engine) CancelExecution(executionID string) error {
 	delete(e.activeNodes, executionID)
 	e.activeMu.Unlock()
 
-	// Clean up merge states
-	e.mergeMu.Lock()
-	for key := range e.mergeStates {
-		if strings.HasPrefix(key, executionID+":") {
-			delete(e.mergeStates, key)
-		}
-	}
-	e.mergeMu.Unlock()
+	e.dropSuspensions(executionID)
+
+	// Clean up merge and join states
+	e.dropMergeState(executionID)
 
 	return nil
 }
@@ -651,7 +830,8 @@ func (e *Engine) ListWorkflows() []*WorkflowDefinition {
 	return result
 }
 
-// GetExecutionState returns the full execution state.
+// GetExecutionState returns a snapshot of the full execution state, copied
+// under the engine lock so it can be read or encoded while the execution runs.
 func (e *Engine) GetExecutionState(executionID string) (*ExecutionState, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
@@ -661,7 +841,127 @@ func (e *Engine) GetExecutionState(executionID string) (*ExecutionState, error)
 		return nil, fmt.Errorf("execution not found: %s", executionID)
 	}
 
-	return state, nil
+	return state.snapshot(), nil
+}
+
+// snapshot deep-copies the state's maps and slices; callers hold e.mu.
+// Values nodes put in the context are copied when they are JSON-style maps
+// or slices, and shared otherwise.
+func (s *ExecutionState) snapshot() *ExecutionState {
+	c := *s
+	c.recovering = nil
+	if s.EndTime != nil {
+		t := *s.EndTime
+		c.EndTime = &t
+	}
+	if s.PausedAt != nil {
+		t := *s.PausedAt
+		c.PausedAt = &t
+	}
+	c.Output = copyValue(s.Output)
+	if s.NodeResults != nil {
+		c.NodeResults = make(map[string]NodeResult, len(s.NodeResults))
+		for id, r := range s.NodeResults {
+			c.NodeResults[id] = r
+		}
+	}
+	if s.Context != nil {
+		ctx := *s.Context
+		ctx.Data = copyMap(s.Context.Data)
+		ctx.NodeOutputs = copyMap(s.Context.NodeOutputs)
+		ctx.Variables = copyMap(s.Context.Variables)
+		ctx.Errors = append([]ExecutionError(nil), s.Context.Errors...)
+		c.Context = &ctx
+	}
+	return &c
+}
+
+// copyMap deep-copies m with copyValue
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = copyValue(v)
+	}
+	return c
+}
+
+// copyValue deep-copies nested maps and slices, returning other values as is
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = copyValue(item)
+		}
+		return c
+	}
+	return v
+}
+
+// ExecutionFilter selects executions for ListExecutions; zero fields match all
+type ExecutionFilter struct {
+	WorkflowID string
+	Statuses   []ExecutionStatus // Any of these
+	Limit      int               // At most this many, newest first
+}
+
+// ExecutionSummary is an execution without its context, for listings
+type ExecutionSummary struct {
+	ExecutionID    string          `json:"executionId"`
+	WorkflowID     string          `json:"workflowId"`
+	Status         ExecutionStatus `json:"status"`
+	StartTime      time.Time       `json:"startTime"`
+	EndTime        *time.Time      `json:"endTime,omitempty"`
+	PausedAt       *time.Time      `json:"pausedAt,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	CompletedNodes int             `json:"completedNodes"`
+	FailedNodes    int             `json:"failedNodes"`
+}
+
+// ListExecutions returns the executions matching filter, newest first.
+func (e *Engine) ListExecutions(filter ExecutionFilter) []ExecutionSummary {
+	e.mu.RLock()
+	result := make([]ExecutionSummary, 0, len(e.executions))
+	for _, state := range e.executions {
+		if filter.WorkflowID != "" && state.WorkflowID != filter.WorkflowID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, state.Status) {
+			continue
+		}
+		result = append(result, ExecutionSummary{
+			ExecutionID:    state.ExecutionID,
+			WorkflowID:     state.WorkflowID,
+			Status:         state.Status,
+			StartTime:      state.StartTime,
+			EndTime:        state.EndTime,
+			PausedAt:       state.PausedAt,
+			Error:          state.Error,
+			CompletedNodes: state.CompletedNodes,
+			FailedNodes:    state.FailedNodes,
+		})
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].StartTime.After(result[j].StartTime) })
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result
+}
+
+func containsStatus(statuses []ExecutionStatus, status ExecutionStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
 }
 
 // CleanupOldExecutions removes executions older than the specified duration.
@@ -675,7 +975,7 @@ func (e *Engine) CleanupOldExecutions(maxAge time.Duration) int {
 
 	for execID, state := range e.executions {
 		// Only clean up completed/failed/cancelled executions
-		if state.Status != ExecutionStatusRunning && state.Status != ExecutionStatusPending {
+		if state.Status != ExecutionStatusRunning && state.Status != ExecutionStatusPending && state.Status != ExecutionStatusPaused {
 			if state.EndTime != nil && now.Sub(*state.EndTime) > maxAge {
 				delete(e.executions, execID)
 				cleaned++
@@ -689,14 +989,8 @@ func (e *Engine) CleanupOldExecutions(maxAge time.Duration) int {
 				delete(e.activeNodes, execID)
 				e.activeMu.Unlock()
 
-				// Clean up merge states
-				e.mergeMu.Lock()
-				for key := range e.mergeStates {
-					if strings.HasPrefix(key, execID+":") {
-						delete(e.mergeStates, key)
-					}
-				}
-				e.mergeMu.Unlock()
+				// Clean up merge and join states
+				e.dropMergeState(execID)
 			}
 		}
 	}
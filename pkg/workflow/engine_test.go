@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestEngine_PartialSuccess(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+	engine.RegisterNodeHandler("fail", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		return nil, errors.New("smtp: connection refused")
+	})
+
+	fanOut := NewWorkflowBuilder("fan-out", "Fan out").
+		AddNode("start", "noop").Next("charge", "notify").Done().
+		AddNode("charge", "noop").Done().
+		AddNode("notify", "fail").Done().
+		Build()
+	allFail := NewWorkflowBuilder("all-fail", "All fail").
+		AddNode("start", "noop").Next("notify").Done().
+		AddNode("notify", "fail").Done().
+		Build()
+	recovered := NewWorkflowBuilder("recovered", "Recovered").
+		AddNode("start", "noop").Next("notify").Done().
+		AddNode("notify", "fail").OnError("alert").Done().
+		AddNode("alert", "noop").Done().
+		Build()
+	for _, def := range []*WorkflowDefinition{fanOut, allFail, recovered} {
+		if err := engine.RegisterWorkflow(def); err != nil {
+			t.Fatalf("RegisterWorkflow() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		workflowID string
+		want       ExecutionStatus
+		completed  int
+		failed     int
+	}{
+		{"fan-out", ExecutionStatusPartialSuccess, 2, 1},
+		{"all-fail", ExecutionStatusFailed, 1, 1},
+		// A path through an error handler doesn't count as a successful branch
+		{"recovered", ExecutionStatusFailed, 2, 1},
+	}
+	for _, tt := range tests {
+		execID, err := engine.ExecuteWorkflow(context.Background(), tt.workflowID, map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("ExecuteWorkflow(%s) error = %v", tt.workflowID, err)
+		}
+		state := waitForStatus(t, engine, execID, tt.want)
+		if state.CompletedNodes != tt.completed || state.FailedNodes != tt.failed {
+			t.Errorf("%s: completed/failed = %d/%d, want %d/%d", tt.workflowID, state.CompletedNodes, state.FailedNodes, tt.completed, tt.failed)
+		}
+		if r := state.NodeResults["notify"]; r.Status != NodeStatusFailed || r.Error != "smtp: connection refused" {
+			t.Errorf("%s: notify result = %+v", tt.workflowID, r)
+		}
+		if r := state.NodeResults["start"]; r.Status != NodeStatusCompleted {
+			t.Errorf("%s: start result = %+v", tt.workflowID, r)
+		}
+	}
+}
+
+func TestEngine_GetExecutionStateIsSnapshot(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+	def := NewWorkflowBuilder("snapshot", "Snapshot").
+		AddNode("start", "noop").Done().
+		Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err := engine.ExecuteWorkflow(context.Background(), "snapshot", map[string]interface{}{"order": map[string]interface{}{"id": "1"}})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state := waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+
+	// Changing the snapshot leaves the engine's state alone
+	state.Status = ExecutionStatusFailed
+	state.NodeResults["start"] = NodeResult{Status: NodeStatusFailed}
+	state.Context.NodeOutputs["start"].(map[string]interface{})["order"].(map[string]interface{})["id"] = "2"
+
+	again, _ := engine.GetExecutionState(execID)
+	if again.Status != ExecutionStatusCompleted || again.NodeResults["start"].Status != NodeStatusCompleted {
+		t.Errorf("state after changing a snapshot = %s, %+v", again.Status, again.NodeResults["start"])
+	}
+	if id := again.Context.NodeOutputs["start"].(map[string]interface{})["order"].(map[string]interface{})["id"]; id != "1" {
+		t.Errorf("nested node output after changing a snapshot = %v, want 1", id)
+	}
+}
@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// Event trigger config (on the workflow's "event" node):
+//   - "address": EventBus address whose messages start the workflow (required);
+//     the message body is the input
+//   - "reply": what a request (a message with a reply address) gets back:
+//     "executionId" (default) answers at once with the execution and workflow IDs,
+//     "result" awaits the execution and answers with its output, failing the
+//     request if the execution fails
+//   - "responseTimeout": how long "result" awaits (default: 30s); an execution
+//     still running then is answered with its ID and status
+//
+// Published and sent messages just start the workflow.
+const (
+	EventReplyExecutionID           = "executionId"
+	EventReplyResult                = "result"
+	DefaultEventTriggerReplyTimeout = 30 * time.Second
+)
+
+// eventTrigger is a parsed event node
+type eventTrigger struct {
+	workflowID string
+	address    string
+	reply      string
+	timeout    time.Duration
+}
+
+// eventTriggers parses the event nodes of def
+func eventTriggers(def *WorkflowDefinition) ([]*eventTrigger, error) {
+	var triggers []*eventTrigger
+	for _, node := range def.Nodes {
+		if NodeType(node.Type) != NodeTypeEvent {
+			continue
+		}
+		t, err := parseEventTrigger(def.ID, node.Config)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %s event node %s: %w", def.ID, node.ID, err)
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, nil
+}
+
+func parseEventTrigger(workflowID string, config map[string]interface{}) (*eventTrigger, error) {
+	t := &eventTrigger{
+		workflowID: workflowID,
+		reply:      EventReplyExecutionID,
+		timeout:    DefaultEventTriggerReplyTimeout,
+	}
+	t.address, _ = config["address"].(string)
+	if err := core.ValidateAddress(t.address); err != nil {
+		return nil, err
+	}
+	if reply, ok := config["reply"].(string); ok && reply != "" {
+		if reply != EventReplyExecutionID && reply != EventReplyResult {
+			return nil, fmt.Errorf("invalid reply %q", reply)
+		}
+		t.reply = reply
+	}
+	if timeout, ok := config["responseTimeout"].(string); ok && timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid responseTimeout %q", timeout)
+		}
+		t.timeout = d
+	}
+	return t, nil
+}
+
+// handle starts t's workflow with msg's body and answers requests
+func (t *eventTrigger) handle(engine *Engine) core.MessageHandler {
+	return func(ctx core.FluxorContext, msg core.Message) error {
+		var input interface{}
+		if body, ok := msg.Body().([]byte); ok {
+			if err := json.Unmarshal(body, &input); err != nil {
+				input = string(body)
+			}
+		} else {
+			input = msg.Body()
+		}
+
+		execID, err := engine.ExecuteWorkflow(ctx.Context(), t.workflowID, input)
+		if err != nil {
+			if msg.ReplyAddress() != "" {
+				return msg.Fail(400, err.Error())
+			}
+			return err
+		}
+		if msg.ReplyAddress() == "" {
+			return nil
+		}
+		accepted := map[string]interface{}{
+			"executionId": execID,
+			"workflowId":  t.workflowID,
+		}
+		if t.reply != EventReplyResult {
+			return msg.Reply(accepted)
+		}
+
+		// Await the result on the blocking pool, so a long execution doesn't hold
+		// up the other messages to this address
+		ctx.ExecuteBlocking(func() (interface{}, error) {
+			return nil, t.replyResult(ctx.Context(), engine, msg, accepted)
+		}).OnComplete(func(_ interface{}, err error) {
+			if err != nil {
+				engine.logger.Error(fmt.Sprintf("event trigger %s: reply for execution %s failed: %v", t.address, execID, err))
+			}
+		})
+		return nil
+	}
+}
+
+// replyResult awaits the execution in accepted for up to t.timeout and answers
+// msg with its output, its failure, or its ID and status if still running
+func (t *eventTrigger) replyResult(ctx context.Context, engine *Engine, msg core.Message, accepted map[string]interface{}) error {
+	execID := accepted["executionId"].(string)
+	waitCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	status, output, execErr := engine.awaitExecution(waitCtx, execID)
+	switch status {
+	case ExecutionStatusCompleted, ExecutionStatusPartialSuccess:
+		return msg.Reply(output)
+	case ExecutionStatusFailed, ExecutionStatusCancelled:
+		return msg.Fail(500, fmt.Sprintf("execution %s %s: %s", execID, status, execErr))
+	default:
+		// Still running (or paused) when the timeout hit; poll the execution
+		accepted["status"] = status
+		return msg.Reply(accepted)
+	}
+}
+
+// eventTriggerSet keeps the consumers of the event nodes of registered workflows
+type eventTriggerSet struct {
+	engine   *Engine
+	eventBus core.EventBus
+
+	mu         sync.Mutex
+	consumers  map[string][]core.Consumer // workflowID -> its event node consumers
+	configured []core.Consumer            // From WorkflowVerticleConfig.EventTriggers
+	stopped    bool
+}
+
+func newEventTriggerSet(engine *Engine, eventBus core.EventBus) *eventTriggerSet {
+	return &eventTriggerSet{
+		engine:    engine,
+		eventBus:  eventBus,
+		consumers: make(map[string][]core.Consumer),
+	}
+}
+
+// add subscribes the event nodes of def, replacing the consumers of a workflow
+// registered earlier under the same ID
+func (s *eventTriggerSet) add(def *WorkflowDefinition) error {
+	triggers, err := eventTriggers(def)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.consumers[def.ID] {
+		_ = c.Unregister()
+	}
+	delete(s.consumers, def.ID)
+	if s.stopped {
+		return nil
+	}
+	for _, t := range triggers {
+		s.consumers[def.ID] = append(s.consumers[def.ID], s.eventBus.Consumer(t.address).Handler(t.handle(s.engine)))
+	}
+	return nil
+}
+
+// addConfig subscribes a trigger from WorkflowVerticleConfig.EventTriggers
+func (s *eventTriggerSet) addConfig(config EventTriggerConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := registerEventTrigger(s.eventBus, s.engine, config)
+	if err != nil {
+		return err
+	}
+	s.configured = append(s.configured, c)
+	return nil
+}
+
+// stop unregisters every event trigger; running executions continue
+func (s *eventTriggerSet) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for id, consumers := range s.consumers {
+		for _, c := range consumers {
+			_ = c.Unregister()
+		}
+		delete(s.consumers, id)
+	}
+	for _, c := range s.configured {
+		_ = c.Unregister()
+	}
+	s.configured = nil
+}
@@ -0,0 +1,169 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestEngine_RegisterWorkflowRejectsBadEventTrigger(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	for name, config := range map[string]map[string]interface{}{
+		"no address":  {},
+		"bad reply":   {"address": "orders.created", "reply": "everything"},
+		"bad timeout": {"address": "orders.created", "reply": "result", "responseTimeout": "soon"},
+	} {
+		def := NewWorkflowBuilder("bad", "Bad").AddNode("on", "event").Config(config).Done().Build()
+		if err := engine.RegisterWorkflow(def); err == nil {
+			t.Errorf("%s: RegisterWorkflow() = nil, want an error", name)
+		}
+	}
+}
+
+func TestEventTriggers(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	engine := NewEngine(eb)
+
+	started := make(chan interface{}, 10)
+	engine.RegisterNodeHandler("greet", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		data, _ := input.Data.(map[string]interface{})
+		started <- data["name"]
+		if data["name"] == "fail" {
+			return nil, errors.New("greeting failed")
+		}
+		return &NodeOutput{Data: map[string]interface{}{"greeting": "hello " + data["name"].(string)}}, nil
+	})
+	onCreated := NewWorkflowBuilder("on-created", "On created").
+		AddNode("on", "event").Config(map[string]interface{}{"address": "users.created"}).Next("greet").Done().
+		AddNode("greet", "greet").Done().
+		Build()
+	greeter := NewWorkflowBuilder("greeter", "Greeter").
+		AddNode("on", "event").Config(map[string]interface{}{"address": "users.greet", "reply": "result"}).Next("greet").Done().
+		AddNode("greet", "greet").Next("out").Done().
+		AddNode("out", "respond").Done().
+		Build()
+	events := newEventTriggerSet(engine, eb)
+	for _, def := range []*WorkflowDefinition{onCreated, greeter} {
+		if err := engine.RegisterWorkflow(def); err != nil {
+			t.Fatal(err)
+		}
+		if err := events.add(def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nextStart := func() interface{} {
+		t.Helper()
+		select {
+		case name := <-started:
+			return name
+		case <-time.After(2 * time.Second):
+			t.Fatal("workflow not started")
+			return nil
+		}
+	}
+
+	// A published event starts the workflow with the body as input
+	if err := eb.Publish("users.created", map[string]interface{}{"name": "ada"}); err != nil {
+		t.Fatal(err)
+	}
+	if name := nextStart(); name != "ada" {
+		t.Errorf("input name = %v, want ada", name)
+	}
+
+	// A request gets the execution ID by default
+	reply, err := eb.Request("users.created", map[string]interface{}{"name": "bob"}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var accepted map[string]interface{}
+	if err := reply.DecodeBody(&accepted); err != nil || accepted["executionId"] == "" || accepted["workflowId"] != "on-created" {
+		t.Errorf("reply = %v, %v, want the execution ID", accepted, err)
+	}
+	nextStart()
+
+	// ... or the result with reply "result"
+	reply, err = eb.Request("users.greet", map[string]interface{}{"name": "cy"}, 2*time.Second)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	var result map[string]interface{}
+	if err := reply.DecodeBody(&result); err != nil || result["greeting"] != "hello cy" {
+		t.Errorf("result = %v, %v, want the greeting", result, err)
+	}
+	nextStart()
+
+	reply, err = eb.Request("users.greet", map[string]interface{}{"name": "fail"}, 2*time.Second)
+	if err == nil {
+		err = core.ReplyError(reply)
+	}
+	if err == nil {
+		t.Error("request for a failing execution succeeded")
+	}
+	nextStart()
+
+	// Stopped triggers start nothing
+	events.stop()
+	_ = eb.Publish("users.created", map[string]interface{}{"name": "late"})
+	select {
+	case name := <-started:
+		t.Errorf("workflow started for %v after stop", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEventTriggers_ResultReplyDoesNotBlockAddress(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	engine := NewEngine(eb)
+
+	release := make(chan struct{})
+	engine.RegisterNodeHandler("greet", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		data, _ := input.Data.(map[string]interface{})
+		if data["name"] == "slow" {
+			<-release
+		}
+		return &NodeOutput{Data: map[string]interface{}{"greeting": "hello " + data["name"].(string)}}, nil
+	})
+	greeter := NewWorkflowBuilder("greeter", "Greeter").
+		AddNode("on", "event").Config(map[string]interface{}{"address": "users.greet", "reply": "result"}).Next("greet").Done().
+		AddNode("greet", "greet").Done().
+		Build()
+	if err := engine.RegisterWorkflow(greeter); err != nil {
+		t.Fatal(err)
+	}
+	events := newEventTriggerSet(engine, eb)
+	defer events.stop()
+	if err := events.add(greeter); err != nil {
+		t.Fatal(err)
+	}
+
+	// A request awaiting its result doesn't hold up the next one
+	slow := make(chan error, 1)
+	go func() {
+		_, err := eb.Request("users.greet", map[string]interface{}{"name": "slow"}, 2*time.Second)
+		slow <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	reply, err := eb.Request("users.greet", map[string]interface{}{"name": "dee"}, time.Second)
+	if err != nil {
+		t.Fatalf("Request() behind a waiting request error = %v", err)
+	}
+	var result map[string]interface{}
+	if err := reply.DecodeBody(&result); err != nil || result["greeting"] != "hello dee" {
+		t.Errorf("result = %v, %v, want the greeting", result, err)
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Errorf("slow Request() error = %v", err)
+	}
+}
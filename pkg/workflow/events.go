@@ -0,0 +1,48 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// ExecutionEventType identifies a progress event of an execution.
+type ExecutionEventType string
+
+const (
+	EventNodeStarted       ExecutionEventType = "node.started"
+	EventNodeCompleted     ExecutionEventType = "node.completed"
+	EventNodeFailed        ExecutionEventType = "node.failed"
+	EventExecutionFinished ExecutionEventType = "execution.finished" // Last event; Status is final
+)
+
+// ExecutionEvent reports an execution's progress. The engine publishes them on
+// ExecutionEventsAddress as nodes run, for live views such as the SSE stream;
+// on the in-memory bus only while the address has a consumer or tap.
+type ExecutionEvent struct {
+	Type        ExecutionEventType `json:"type"`
+	ExecutionID string             `json:"executionId"`
+	NodeID      string             `json:"nodeId,omitempty"`
+	Status      ExecutionStatus    `json:"status,omitempty"` // Set on EventExecutionFinished
+	Error       string             `json:"error,omitempty"`
+	Timestamp   time.Time          `json:"timestamp"`
+}
+
+// ExecutionEventsAddress returns the EventBus address an execution's events are published to.
+func ExecutionEventsAddress(executionID string) string {
+	return "workflow.execution." + executionID + ".events"
+}
+
+// emit records an execution event in the audit trail and publishes it when the
+// execution is watched; best-effort, progress never waits on watchers
+func (e *Engine) emit(event ExecutionEvent) {
+	event.Timestamp = time.Now()
+	e.record(event)
+	address := ExecutionEventsAddress(event.ExecutionID)
+	if !core.HasListeners(e.eventBus, address) {
+		return
+	}
+	if err := e.eventBus.Publish(address, event); err != nil {
+		e.logger.Debug("execution event dropped: " + err.Error())
+	}
+}
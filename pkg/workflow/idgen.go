@@ -0,0 +1,32 @@
+package workflow
+
+import "github.com/google/uuid"
+
+// IDGenerator creates execution IDs
+// Swap in ULIDs for time-sortable IDs or a counter for deterministic tests
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts a function to IDGenerator
+type IDGeneratorFunc func() string
+
+// NewID implements IDGenerator
+func (f IDGeneratorFunc) NewID() string { return f() }
+
+// UUIDGenerator generates random UUID v4 IDs (the engine default)
+type UUIDGenerator struct{}
+
+// NewID implements IDGenerator
+func (UUIDGenerator) NewID() string { return uuid.New().String() }
+
+// SetIDGenerator sets the generator for new execution IDs; nil restores UUIDGenerator.
+// IDs must be unique for the lifetime of the engine.
+func (e *Engine) SetIDGenerator(gen IDGenerator) {
+	if gen == nil {
+		gen = UUIDGenerator{}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ids = gen
+}
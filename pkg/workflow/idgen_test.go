@@ -0,0 +1,46 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestEngine_SetIDGenerator(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	n := 0
+	engine.SetIDGenerator(IDGeneratorFunc(func() string {
+		n++
+		return fmt.Sprintf("exec-%d", n)
+	}))
+	def := NewWorkflowBuilder("ids", "IDs").AddNode("start", "noop").Done().Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	for _, want := range []string{"exec-1", "exec-2"} {
+		execID, err := engine.ExecuteWorkflow(context.Background(), "ids", nil)
+		if err != nil {
+			t.Fatalf("ExecuteWorkflow() error = %v", err)
+		}
+		if execID != want {
+			t.Errorf("execution ID = %q, want %q", execID, want)
+		}
+		waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+	}
+
+	// nil restores the UUID default
+	engine.SetIDGenerator(nil)
+	execID, err := engine.ExecuteWorkflow(context.Background(), "ids", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	if len(execID) != 36 {
+		t.Errorf("execution ID = %q, want a UUID", execID)
+	}
+}
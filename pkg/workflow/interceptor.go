@@ -0,0 +1,38 @@
+package workflow
+
+import "context"
+
+// NodeInterceptor wraps node handler calls for cross-cutting concerns (audit,
+// metrics, policy, injecting secrets) without changing each handler. Call next
+// to proceed; return without calling it to short-circuit, or transform its output.
+type NodeInterceptor func(ctx context.Context, node *NodeDefinition, input *NodeInput, next NodeHandler) (*NodeOutput, error)
+
+// Use adds interceptors around every node handler call. The first added is the
+// outermost. Interceptors run once per attempt, so a node with RetryCount 3 may
+// pass through them three times; wait-event nodes have no handler and skip them.
+// Fail-fast: panics on a nil interceptor
+func (e *Engine) Use(interceptors ...NodeInterceptor) {
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			panic("workflow: interceptor cannot be nil")
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.interceptors = append(e.interceptors, interceptors...)
+}
+
+// intercept chains the engine's interceptors around handler for node
+func (e *Engine) intercept(node *NodeDefinition, handler NodeHandler) NodeHandler {
+	e.mu.RLock()
+	interceptors := e.interceptors
+	e.mu.RUnlock()
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+			return interceptor(ctx, node, input, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestEngine_Use(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	var handled []string
+	var mu sync.Mutex
+	engine.RegisterNodeHandler("step", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		mu.Lock()
+		handled = append(handled, input.Config["name"].(string))
+		mu.Unlock()
+		return &NodeOutput{Data: map[string]interface{}{"secret": input.Config["apiKey"]}}, nil
+	})
+
+	var calls []string
+	engine.Use(
+		// Audit: outermost, sees every node
+		func(ctx context.Context, node *NodeDefinition, input *NodeInput, next NodeHandler) (*NodeOutput, error) {
+			mu.Lock()
+			calls = append(calls, "audit:"+node.ID)
+			mu.Unlock()
+			return next(ctx, input)
+		},
+		// Policy: blocks a node without running its handler
+		func(ctx context.Context, node *NodeDefinition, input *NodeInput, next NodeHandler) (*NodeOutput, error) {
+			if node.ID == "blocked" {
+				return nil, errors.New("denied by policy")
+			}
+			return next(ctx, input)
+		},
+		// Secrets: injected into the input, redacted from the output
+		func(ctx context.Context, node *NodeDefinition, input *NodeInput, next NodeHandler) (*NodeOutput, error) {
+			if input.Config == nil {
+				return next(ctx, input)
+			}
+			input.Config["apiKey"] = "s3cret"
+			output, err := next(ctx, input)
+			if err == nil {
+				output.Data = map[string]interface{}{"secret": "[redacted]"}
+			}
+			return output, err
+		},
+	)
+
+	def := NewWorkflowBuilder("intercepted", "Intercepted").
+		AddNode("start", "noop").Next("call", "blocked").Done().
+		AddNode("call", "step").Config(map[string]interface{}{"name": "call"}).Done().
+		AddNode("blocked", "step").Config(map[string]interface{}{"name": "blocked"}).Done().
+		Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err := engine.ExecuteWorkflow(context.Background(), "intercepted", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state := waitForStatus(t, engine, execID, ExecutionStatusPartialSuccess)
+
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(handled, []string{"call"}) {
+		t.Errorf("handled = %v, want only call", handled)
+	}
+	if len(calls) != 3 {
+		t.Errorf("audit calls = %v, want start, call and blocked", calls)
+	}
+	if r := state.NodeResults["blocked"]; r.Error != "denied by policy" {
+		t.Errorf("blocked result = %+v", r)
+	}
+	if got := state.Context.NodeOutputs["call"]; !reflect.DeepEqual(got, map[string]interface{}{"secret": "[redacted]"}) {
+		t.Errorf("call output = %v", got)
+	}
+}
+
+func TestEngine_Use_NilPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Use(nil) should panic")
+		}
+	}()
+	NewEngine(nil).Use(nil)
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mappingExpr matches a {{field}} or {{ $.field.nested }} placeholder
+var mappingExpr = regexp.MustCompile(`\{\{\s*(?:\$\.?)?([^{}\s]*)\s*\}\}`)
+
+// applyMapping builds a new value from data using a NodeDefinition InputMapping or
+// OutputMapping. Each entry of mapping becomes a field of the result:
+//   - a string that is exactly one placeholder ("{{ $.user.id }}") keeps the
+//     referenced value as-is (objects, numbers, arrays)
+//   - other strings are rendered as templates ("Hello {{ $.user.name }}")
+//   - nested maps are mapped recursively; anything else is a literal
+//
+// "$" refers to data itself. Paths that don't exist resolve to nil; in templates
+// their placeholder is kept, as in every other node template.
+func applyMapping(mapping map[string]interface{}, data interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(mapping))
+	for key, value := range mapping {
+		switch v := value.(type) {
+		case string:
+			result[key] = mapValue(v, data)
+		case map[string]interface{}:
+			result[key] = applyMapping(v, data)
+		default:
+			result[key] = v
+		}
+	}
+	return result
+}
+
+// mapValue resolves one mapping string against data
+func mapValue(expr string, data interface{}) interface{} {
+	if m := mappingExpr.FindStringSubmatchIndex(expr); m != nil && m[0] == 0 && m[1] == len(expr) {
+		return lookupPath(data, expr[m[2]:m[3]])
+	}
+	return processTemplate(expr, data)
+}
+
+// processTemplate replaces {{field}} and {{ $.field.nested }} placeholders with
+// values from data, walking paths of any depth. Placeholders that don't resolve
+// are left as they are. Every node template and mapping renders through it.
+func processTemplate(template string, data interface{}) string {
+	return mappingExpr.ReplaceAllStringFunc(template, func(placeholder string) string {
+		path := mappingExpr.FindStringSubmatch(placeholder)[1]
+		value := lookupPath(data, path)
+		if path == "" || value == nil {
+			return placeholder
+		}
+		return fmt.Sprintf("%v", value)
+	})
+}
+
+// lookupPath walks a dotted path ("user.roles.0") through maps and slices.
+// An empty path returns data.
+func lookupPath(data interface{}, path string) interface{} {
+	if path == "" {
+		return data
+	}
+	current := data
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			current = v[part]
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			current = v[i]
+		default:
+			return nil
+		}
+	}
+	return current
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestApplyMapping(t *testing.T) {
+	data := map[string]interface{}{
+		"user":  map[string]interface{}{"id": 7, "name": "Ada", "roles": []interface{}{"admin", "dev"}},
+		"total": 42.5,
+	}
+	got := applyMapping(map[string]interface{}{
+		"userId":   "{{ $.user.id }}",
+		"user":     "{{$.user}}",
+		"greeting": "Hello {{ $.user.name }} ({{user.roles.0}})",
+		"partial":  "Dear {{ $.user.title }} {{ $.user.name }}",
+		"missing":  "{{ $.nope.deeper }}",
+		"nested":   map[string]interface{}{"amount": "{{ $.total }}"},
+		"all":      "{{ $ }}",
+		"literal":  true,
+	}, data)
+
+	want := map[string]interface{}{
+		"userId":   7,
+		"user":     data["user"],
+		"greeting": "Hello Ada (admin)",
+		"partial":  "Dear {{ $.user.title }} Ada",
+		"missing":  nil,
+		"nested":   map[string]interface{}{"amount": 42.5},
+		"all":      data,
+		"literal":  true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applyMapping() = %v, want %v", got, want)
+	}
+}
+
+func TestProcessTemplate_NestedPaths(t *testing.T) {
+	data := map[string]interface{}{
+		"order": map[string]interface{}{
+			"customer": map[string]interface{}{"address": map[string]interface{}{"city": "Oslo"}},
+			"items":    []interface{}{map[string]interface{}{"sku": "a-1"}},
+		},
+	}
+	got := processTemplate("{{ $.order.customer.address.city }}/{{order.items.0.sku}}/{{ $.missing }}", data)
+	if want := "Oslo/a-1/{{ $.missing }}"; got != want {
+		t.Errorf("processTemplate() = %q, want %q", got, want)
+	}
+}
+
+func TestEngine_NodeMappings(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	var received interface{}
+	engine.RegisterNodeHandler("lookup", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		received = input.Data
+		return &NodeOutput{Data: map[string]interface{}{
+			"customer": map[string]interface{}{"id": "c-1", "tier": "gold"},
+			"internal": "not for downstream",
+		}}, nil
+	})
+
+	def := NewWorkflowBuilder("mapped", "Mapped").
+		AddNode("start", "noop").Next("lookup").Done().
+		AddNode("lookup", "lookup").
+		InputMapping(map[string]interface{}{"id": "{{ $.order.customerId }}"}).
+		OutputMapping(map[string]interface{}{"customerId": "{{ $.customer.id }}", "tier": "{{ $.customer.tier }}"}).
+		Done().
+		Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	execID, err := engine.ExecuteWorkflow(context.Background(), "mapped", map[string]interface{}{
+		"order": map[string]interface{}{"customerId": "c-1", "amount": 10},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state := waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	if want := map[string]interface{}{"id": "c-1"}; !reflect.DeepEqual(received, want) {
+		t.Errorf("node input = %v, want %v", received, want)
+	}
+	if want := map[string]interface{}{"customerId": "c-1", "tier": "gold"}; !reflect.DeepEqual(state.Output, want) {
+		t.Errorf("output = %v, want %v", state.Output, want)
+	}
+}
@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMermaid renders the workflow's node graph as a Mermaid flowchart, for docs
+// and dashboards. Condition branches are labelled "true"/"false" and error
+// edges are dotted. Nodes get generated IDs (n0, n1, ...) since workflow IDs
+// such as "end" are Mermaid keywords; labels show the node name (or ID) and type.
+func (def *WorkflowDefinition) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	ids := make(map[string]string, len(def.Nodes))
+	for i, node := range def.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+	}
+	missing := len(def.Nodes)
+	ref := func(nodeID string) string {
+		if id, ok := ids[nodeID]; ok {
+			return id
+		}
+		// Dangling edge (RegisterWorkflow rejects these); still show it
+		id := fmt.Sprintf("n%d", missing)
+		missing++
+		ids[nodeID] = id
+		fmt.Fprintf(&b, "    %s[\"%s (missing)\"]\n", id, mermaidEscape(nodeID))
+		return id
+	}
+
+	for _, node := range def.Nodes {
+		label := node.Name
+		if label == "" {
+			label = node.ID
+		}
+		shape := "[\"%s<br/><i>%s</i>\"]"
+		if NodeType(node.Type) == NodeTypeCondition {
+			shape = "{\"%s<br/><i>%s</i>\"}"
+		}
+		fmt.Fprintf(&b, "    %s"+shape+"\n", ids[node.ID], mermaidEscape(label), mermaidEscape(node.Type))
+	}
+
+	for _, node := range def.Nodes {
+		from := ids[node.ID]
+		for _, next := range node.Next {
+			fmt.Fprintf(&b, "    %s --> %s\n", from, ref(next))
+		}
+		for _, next := range node.TrueNext {
+			fmt.Fprintf(&b, "    %s -->|true| %s\n", from, ref(next))
+		}
+		for _, next := range node.FalseNext {
+			fmt.Fprintf(&b, "    %s -->|false| %s\n", from, ref(next))
+		}
+		for _, next := range node.OnError {
+			fmt.Fprintf(&b, "    %s -.->|error| %s\n", from, ref(next))
+		}
+	}
+	return b.String()
+}
+
+// mermaidEscape makes text safe inside a quoted Mermaid label
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(s)
+}
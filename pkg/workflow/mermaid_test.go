@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorkflowDefinition_ToMermaid(t *testing.T) {
+	def := NewWorkflowBuilder("orders", "Orders").
+		AddNode("start", "manual").Next("check").Done().
+		AddNode("check", "condition").Name(`Amount > "100"`).TrueNext("approve").FalseNext("end").Done().
+		AddNode("approve", "http").Next("end").OnError("alert").Done().
+		AddNode("alert", "noop").Done().
+		AddNode("end", "noop").Done().
+		Build()
+
+	got := def.ToMermaid()
+	want := `flowchart TD
+    n0["start<br/><i>manual</i>"]
+    n1{"Amount #gt; #quot;100#quot;<br/><i>condition</i>"}
+    n2["approve<br/><i>http</i>"]
+    n3["alert<br/><i>noop</i>"]
+    n4["end<br/><i>noop</i>"]
+    n0 --> n1
+    n1 -->|true| n2
+    n1 -->|false| n4
+    n2 --> n4
+    n2 -.->|error| n3
+`
+	if got != want {
+		t.Errorf("ToMermaid() =\n%s\nwant\n%s", got, want)
+	}
+
+	def.Nodes[0].Next = []string{"ghost"}
+	if got := def.ToMermaid(); !strings.Contains(got, `n5["ghost (missing)"]`) || !strings.Contains(got, "n0 --> n5") {
+		t.Errorf("dangling edge not rendered:\n%s", got)
+	}
+}
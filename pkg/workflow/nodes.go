@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
 )
 
 // noOpHandler passes data through unchanged.
@@ -97,19 +99,10 @@ func evaluateCondition(actual interface{}, operator string, expected interface{}
 	}
 }
 
+// toFloat reads a number (including json.Number); anything else is 0
 func toFloat(v interface{}) float64 {
-	switch n := v.(type) {
-	case int:
-		return float64(n)
-	case int64:
-		return float64(n)
-	case float64:
-		return n
-	case float32:
-		return float64(n)
-	default:
-		return 0
-	}
+	f, _ := core.AsFloat64(v)
+	return f
 }
 
 func contains(actual, expected interface{}) bool {
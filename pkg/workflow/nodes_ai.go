@@ -182,8 +182,7 @@ func AINodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
 	setProviderHeaders(req, provider, apiKey)
 
 	// Execute request
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
+	resp, err := sendHTTP(req)
 	if err != nil {
 		return nil, fmt.Errorf("ai request failed: %w", err)
 	}
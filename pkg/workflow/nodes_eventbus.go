@@ -90,32 +90,26 @@ func CreateEventBusHandler(eventBus core.EventBus) NodeHandler {
 type EventTriggerConfig struct {
 	Address    string `json:"address"`
 	WorkflowID string `json:"workflowId"`
+
+	// Reply and ResponseTimeout work as on an event node (see event_trigger.go)
+	Reply           string `json:"reply,omitempty"`
+	ResponseTimeout string `json:"responseTimeout,omitempty"`
 }
 
 // RegisterEventTrigger registers an EventBus consumer that triggers a workflow.
 func RegisterEventTrigger(eventBus core.EventBus, engine *Engine, config EventTriggerConfig) error {
-	consumer := eventBus.Consumer(config.Address)
-	consumer.Handler(func(ctx core.FluxorContext, msg core.Message) error {
-		var input interface{}
-		if bodyBytes, ok := msg.Body().([]byte); ok {
-			if err := json.Unmarshal(bodyBytes, &input); err != nil {
-				input = string(bodyBytes)
-			}
-		} else {
-			input = msg.Body()
-		}
-
-		execID, err := engine.ExecuteWorkflow(ctx.Context(), config.WorkflowID, input)
-		if err != nil {
-			return err
-		}
+	_, err := registerEventTrigger(eventBus, engine, config)
+	return err
+}
 
-		// If the message expects a reply, send the execution ID
-		return msg.Reply(map[string]interface{}{
-			"executionId": execID,
-			"workflowId":  config.WorkflowID,
-		})
+func registerEventTrigger(eventBus core.EventBus, engine *Engine, config EventTriggerConfig) (core.Consumer, error) {
+	t, err := parseEventTrigger(config.WorkflowID, map[string]interface{}{
+		"address":         config.Address,
+		"reply":           config.Reply,
+		"responseTimeout": config.ResponseTimeout,
 	})
-
-	return nil
+	if err != nil {
+		return nil, fmt.Errorf("event trigger for workflow %s: %w", config.WorkflowID, err)
+	}
+	return eventBus.Consumer(t.address).Handler(t.handle(engine)), nil
 }
@@ -9,11 +9,14 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
 )
 
-func init() {
-	// Register HTTP node handler
-}
+// defaultRedactedHeaders are never written to logs in clear text
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
 
 // HTTPNodeHandler handles HTTP request nodes.
 func HTTPNodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
@@ -24,6 +27,11 @@ func HTTPNodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, error)
 	// - "body": request body
 	// - "timeout": request timeout (default: 30s)
 	// - "responseType": "json" (default), "text", "binary"
+	// - "failOnStatus": status codes returned as errors so retryCount/onError apply,
+	//   e.g. [429, 503] or ["5xx"] (default: none, every status is an output)
+	// - "redactHeaders": extra header names to mask in logs (Authorization, Cookie etc. always are)
+	//
+	// Templates: {{field}} or {{ $.field.nested }} against the node input
 
 	url, ok := input.Config["url"].(string)
 	if !ok || url == "" {
@@ -87,14 +95,24 @@ func HTTPNodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, error)
 		}
 	}
 
+	// Log with sensitive headers masked
+	sensitive := sensitiveHeaders(input.Config)
+	core.Debug(fmt.Sprintf("http node request: %s %s headers=%v", method, url, redactHeaders(req.Header, sensitive)))
+
 	// Execute request
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
+	resp, err := sendHTTP(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	core.Debug(fmt.Sprintf("http node response: %s %s status=%d headers=%v", method, url, resp.StatusCode, redactHeaders(resp.Header, sensitive)))
+
+	// Failure statuses become errors so the node's retry policy and onError edges apply
+	if isFailureStatus(resp.StatusCode, input.Config) {
+		return nil, fmt.Errorf("%s %s returned status %d", method, url, resp.StatusCode)
+	}
+
 	// Read response
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -132,28 +150,44 @@ func HTTPNodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, error)
 	}, nil
 }
 
-// processTemplate replaces {{field}} placeholders with values from data.
-func processTemplate(template string, data interface{}) string {
-	dataMap, ok := data.(map[string]interface{})
-	if !ok {
-		return template
-	}
-
-	result := template
-	for key, value := range dataMap {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprintf("%v", value))
+// sendHTTP sends req through web.SharedHTTPClient, so nodes share its connection
+// pool, retry and circuit-breaker settings and metrics. The deadline comes from
+// req's context; the returned body is already read.
+func sendHTTP(req *http.Request) (*http.Response, error) {
+	freq := fasthttp.AcquireRequest()
+	fresp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(freq)
+	defer fasthttp.ReleaseResponse(fresp)
 
-		// Also support dot notation for nested access
-		if nested, ok := value.(map[string]interface{}); ok {
-			for nestedKey, nestedValue := range nested {
-				nestedPlaceholder := fmt.Sprintf("{{%s.%s}}", key, nestedKey)
-				result = strings.ReplaceAll(result, nestedPlaceholder, fmt.Sprintf("%v", nestedValue))
-			}
+	freq.SetRequestURI(req.URL.String())
+	freq.Header.SetMethod(req.Method)
+	for k, values := range req.Header {
+		for _, v := range values {
+			freq.Header.Add(k, v)
 		}
 	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		freq.SetBody(body)
+	}
 
-	return result
+	if err := web.SharedHTTPClient().Do(req.Context(), freq, fresp); err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	fresp.Header.VisitAll(func(k, v []byte) {
+		header.Add(string(k), string(v))
+	})
+	return &http.Response{
+		StatusCode: fresp.StatusCode(),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(append([]byte(nil), fresp.Body()...))),
+		Request:    req,
+	}, nil
 }
 
 func processTemplateMap(m map[string]interface{}, data interface{}) map[string]interface{} {
@@ -171,6 +205,68 @@ func processTemplateMap(m map[string]interface{}, data interface{}) map[string]i
 	return result
 }
 
+// sensitiveHeaders returns the canonical names of headers to mask in logs
+func sensitiveHeaders(config map[string]interface{}) map[string]bool {
+	sensitive := make(map[string]bool, len(defaultRedactedHeaders))
+	for _, h := range defaultRedactedHeaders {
+		sensitive[http.CanonicalHeaderKey(h)] = true
+	}
+	if extra, ok := config["redactHeaders"].([]interface{}); ok {
+		for _, h := range extra {
+			if name, ok := h.(string); ok {
+				sensitive[http.CanonicalHeaderKey(name)] = true
+			}
+		}
+	}
+	return sensitive
+}
+
+// redactHeaders flattens headers for logging, masking sensitive values
+func redactHeaders(h http.Header, sensitive map[string]bool) map[string]string {
+	result := headerToMap(h)
+	for k := range result {
+		if sensitive[http.CanonicalHeaderKey(k)] {
+			result[k] = "[REDACTED]"
+		}
+	}
+	return result
+}
+
+// isFailureStatus reports whether a response status is listed in the node's
+// failOnStatus config, as a code or a class such as "5xx"
+func isFailureStatus(status int, config map[string]interface{}) bool {
+	var codes []interface{}
+	switch c := config["failOnStatus"].(type) {
+	case []interface{}:
+		codes = c
+	case []int:
+		for _, code := range c {
+			codes = append(codes, code)
+		}
+	case []string:
+		for _, code := range c {
+			codes = append(codes, code)
+		}
+	}
+	for _, c := range codes {
+		switch code := c.(type) {
+		case float64:
+			if int(code) == status {
+				return true
+			}
+		case int:
+			if code == status {
+				return true
+			}
+		case string:
+			if len(code) == 3 && strings.HasSuffix(strings.ToLower(code), "xx") && code[0] == byte('0'+status/100) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func headerToMap(h http.Header) map[string]string {
 	result := make(map[string]string)
 	for k, v := range h {
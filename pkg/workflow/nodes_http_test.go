@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPNodeHandler_TemplateAndJSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/42" {
+			t.Errorf("path = %s, want /users/42", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want templated token", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"not found"}`))
+	}))
+	defer server.Close()
+
+	output, err := HTTPNodeHandler(context.Background(), &NodeInput{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"id": 42},
+			"auth": map[string]interface{}{"token": "secret"},
+		},
+		Config: map[string]interface{}{
+			"url":     server.URL + "/users/{{ $.user.id }}",
+			"headers": map[string]interface{}{"Authorization": "Bearer {{auth.token}}"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("HTTPNodeHandler() error = %v", err)
+	}
+
+	data := output.Data.(map[string]interface{})
+	if data["statusCode"] != http.StatusNotFound {
+		t.Errorf("statusCode = %v, want 404 (4xx is not a failure by default)", data["statusCode"])
+	}
+	body, _ := data["body"].(map[string]interface{})
+	if body["error"] != "not found" {
+		t.Errorf("body = %v, want parsed JSON", data["body"])
+	}
+}
+
+func TestHTTPNodeHandler_FailOnStatus(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	input := &NodeInput{Config: map[string]interface{}{"url": server.URL}}
+	output, err := HTTPNodeHandler(context.Background(), input)
+	if err != nil {
+		t.Fatalf("5xx response without failOnStatus error = %v, want it as output", err)
+	}
+	if code := output.Data.(map[string]interface{})["statusCode"]; code != status {
+		t.Errorf("statusCode = %v, want %d", code, status)
+	}
+
+	for _, failOn := range []interface{}{
+		[]interface{}{"5xx"},
+		[]interface{}{float64(503)},
+		[]int{503},
+	} {
+		input.Config["failOnStatus"] = failOn
+		if _, err := HTTPNodeHandler(context.Background(), input); err == nil {
+			t.Errorf("failOnStatus %v: 503 should fail the node", failOn)
+		}
+	}
+
+	status = http.StatusTooManyRequests
+	input.Config["failOnStatus"] = []interface{}{"5xx", float64(429)}
+	if _, err := HTTPNodeHandler(context.Background(), input); err == nil {
+		t.Error("status listed in failOnStatus should fail the node")
+	}
+	input.Config["failOnStatus"] = []interface{}{"5xx"}
+	if _, err := HTTPNodeHandler(context.Background(), input); err != nil {
+		t.Errorf("429 with failOnStatus [5xx] error = %v, want it as output", err)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("X-Tenant-Token", "abc")
+	h.Set("Accept", "application/json")
+
+	redacted := redactHeaders(h, sensitiveHeaders(map[string]interface{}{
+		"redactHeaders": []interface{}{"x-tenant-token"},
+	}))
+	if redacted["Authorization"] != "[REDACTED]" {
+		t.Errorf("Authorization = %q, should be redacted by default", redacted["Authorization"])
+	}
+	if redacted["X-Tenant-Token"] != "[REDACTED]" {
+		t.Errorf("X-Tenant-Token = %q, should be redacted when configured", redacted["X-Tenant-Token"])
+	}
+	if redacted["Accept"] != "application/json" {
+		t.Errorf("Accept = %q, should be kept", redacted["Accept"])
+	}
+}
+
+func TestNodeRegistry_HTTPBuiltin(t *testing.T) {
+	if _, ok := NewNodeRegistry().Get(NodeTypeHTTP); !ok {
+		t.Error("http node should be registered as a built-in")
+	}
+}
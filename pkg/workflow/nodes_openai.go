@@ -209,8 +209,7 @@ func OpenAINodeHandler(ctx context.Context, input *NodeInput) (*NodeOutput, erro
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 
 	// Execute request
-	client := &http.Client{Timeout: timeout}
-	resp, err := client.Do(req)
+	resp, err := sendHTTP(req)
 	if err != nil {
 		return nil, fmt.Errorf("openai request failed: %w", err)
 	}
@@ -0,0 +1,354 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// Suspension records a wait-event node that is waiting for an EventBus message.
+// It is serializable (see Serializer) so a SuspensionStore can persist it across restarts.
+type Suspension struct {
+	ExecutionID      string            `json:"executionId"`
+	WorkflowID       string            `json:"workflowId"`
+	NodeID           string            `json:"nodeId"`
+	Address          string            `json:"address"`                    // EventBus address to wait on
+	CorrelationKey   string            `json:"correlationKey,omitempty"`   // Event field that must match
+	CorrelationValue string            `json:"correlationValue,omitempty"` // Expected value of CorrelationKey
+	Deadline         *time.Time        `json:"deadline,omitempty"`         // Resume with an error after this time
+	SuspendedAt      time.Time         `json:"suspendedAt"`
+	Input            interface{}       `json:"input"`   // Node input, passed on as "_input"
+	Context          *ExecutionContext `json:"context"` // Execution context to resume with
+}
+
+// suspension is a live Suspension with the state needed to resume it
+type suspension struct {
+	record *Suspension
+	ctx    context.Context
+	def    *WorkflowDefinition
+	node   *NodeDefinition
+	timer  *time.Timer
+}
+
+// matches reports whether an event satisfies the suspension's correlation
+func (s *suspension) matches(event interface{}) bool {
+	if s.record.CorrelationKey == "" {
+		return true
+	}
+	data, ok := event.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	value, ok := data[s.record.CorrelationKey]
+	return ok && fmt.Sprintf("%v", value) == s.record.CorrelationValue
+}
+
+// SetSuspensionStore sets the store used to persist suspended wait-event nodes.
+// Call RestoreSuspensions after registering workflows to resume them after a restart.
+func (e *Engine) SetSuspensionStore(store SuspensionStore) {
+	e.suspendMu.Lock()
+	defer e.suspendMu.Unlock()
+	e.store = store
+}
+
+// suspend parks a wait-event node until a matching event arrives or its timeout fires.
+//
+// Config:
+//   - "address": EventBus address to wait on (required, supports {{field}} templates)
+//   - "correlationKey": event field that must equal "correlationValue" (optional)
+//   - "correlationValue": expected value (supports templates; default: the input's correlationKey field)
+//   - "timeout": maximum wait, e.g. "24h" (optional); on timeout the node fails and follows onError
+func (e *Engine) suspend(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, execCtx *ExecutionContext, input interface{}) error {
+	address, ok := node.Config["address"].(string)
+	if !ok || address == "" {
+		return fmt.Errorf("waitevent node requires 'address' config")
+	}
+
+	record := &Suspension{
+		ExecutionID: execCtx.ExecutionID,
+		WorkflowID:  def.ID,
+		NodeID:      node.ID,
+		Address:     processTemplate(address, input),
+		SuspendedAt: time.Now(),
+		Input:       input,
+		Context:     execCtx,
+	}
+
+	if key, ok := node.Config["correlationKey"].(string); ok && key != "" {
+		record.CorrelationKey = key
+		if value, ok := node.Config["correlationValue"].(string); ok {
+			record.CorrelationValue = processTemplate(value, input)
+		} else if data, ok := input.(map[string]interface{}); ok && data[key] != nil {
+			record.CorrelationValue = fmt.Sprintf("%v", data[key])
+		} else {
+			return fmt.Errorf("waitevent node %s has no value for correlation key %s", node.ID, key)
+		}
+	}
+
+	if t, ok := node.Config["timeout"].(string); ok && t != "" {
+		timeout, err := time.ParseDuration(t)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %s", t)
+		}
+		deadline := record.SuspendedAt.Add(timeout)
+		record.Deadline = &deadline
+	}
+
+	e.suspendMu.Lock()
+	store := e.store
+	e.suspendMu.Unlock()
+	if store != nil {
+		e.mu.RLock()
+		err := store.Save(record)
+		e.mu.RUnlock()
+		if err != nil {
+			return fmt.Errorf("persist suspension failed: %w", err)
+		}
+	}
+
+	e.waitFor(ctx, def, node, record)
+	return nil
+}
+
+// waitFor registers a suspension with the shared consumer for its address and arms its timeout.
+func (e *Engine) waitFor(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, record *Suspension) {
+	s := &suspension{record: record, ctx: ctx, def: def, node: node}
+	key := fmt.Sprintf("%s:%s", record.ExecutionID, record.NodeID)
+
+	e.suspendMu.Lock()
+	defer e.suspendMu.Unlock()
+
+	e.suspensions[key] = s
+	if _, ok := e.waitConsumers[record.Address]; !ok {
+		address := record.Address
+		e.waitConsumers[address] = e.eventBus.Consumer(address).Handler(func(ctx core.FluxorContext, msg core.Message) error {
+			e.handleWaitEvent(address, msg)
+			return nil
+		})
+	}
+
+	if record.Deadline != nil {
+		s.timer = time.AfterFunc(time.Until(*record.Deadline), func() {
+			e.timeoutSuspension(key)
+		})
+	}
+}
+
+// handleWaitEvent resumes every suspension on address that the event matches.
+func (e *Engine) handleWaitEvent(address string, msg core.Message) {
+	var event interface{}
+	if bodyBytes, ok := msg.Body().([]byte); ok {
+		if err := json.Unmarshal(bodyBytes, &event); err != nil {
+			event = string(bodyBytes)
+		}
+	} else {
+		event = msg.Body()
+	}
+
+	e.suspendMu.Lock()
+	var matched []*suspension
+	for key, s := range e.suspensions {
+		if s.record.Address == address && s.matches(event) {
+			matched = append(matched, s)
+			e.removeSuspensionLocked(key, s)
+		}
+	}
+	e.suspendMu.Unlock()
+
+	for _, s := range matched {
+		go e.resume(s, event)
+	}
+}
+
+// SignalExecution delivers event to every wait-event node executionID is suspended
+// on, ignoring their address and correlation, e.g. to unblock an execution whose
+// event was lost. Returns how many nodes resumed; an error if the execution is
+// unknown or isn't waiting for an event.
+func (e *Engine) SignalExecution(executionID string, event interface{}) (int, error) {
+	if _, err := e.GetExecutionState(executionID); err != nil {
+		return 0, err
+	}
+
+	e.suspendMu.Lock()
+	var matched []*suspension
+	for key, s := range e.suspensions {
+		if s.record.ExecutionID == executionID {
+			matched = append(matched, s)
+			e.removeSuspensionLocked(key, s)
+		}
+	}
+	e.suspendMu.Unlock()
+	if len(matched) == 0 {
+		return 0, fmt.Errorf("execution %s is not waiting for an event", executionID)
+	}
+
+	for _, s := range matched {
+		go e.resume(s, event)
+	}
+	return len(matched), nil
+}
+
+// resume continues the workflow after a wait-event node received its event.
+func (e *Engine) resume(s *suspension, event interface{}) {
+	execCtx := s.record.Context
+	defer e.markNodeInactive(execCtx.ExecutionID, s.node.ID)
+
+	select {
+	case <-s.ctx.Done():
+		return
+	default:
+	}
+
+	e.advance(s.ctx, s.def, s.node, execCtx, &NodeOutput{
+		Data: map[string]interface{}{
+			"event":  event,
+			"_input": s.record.Input,
+		},
+	})
+}
+
+// timeoutSuspension fails a wait-event node whose deadline passed without a matching event.
+func (e *Engine) timeoutSuspension(key string) {
+	e.suspendMu.Lock()
+	s, ok := e.suspensions[key]
+	if ok {
+		e.removeSuspensionLocked(key, s)
+	}
+	e.suspendMu.Unlock()
+	if !ok {
+		return
+	}
+
+	execCtx := s.record.Context
+	defer e.markNodeInactive(execCtx.ExecutionID, s.node.ID)
+	e.failNode(s.ctx, s.def, s.node, execCtx, s.record.Input,
+		fmt.Errorf("timed out waiting for event on %s", s.record.Address))
+}
+
+// dropSuspensions discards the suspensions of a finished or cancelled execution.
+func (e *Engine) dropSuspensions(executionID string) {
+	e.suspendMu.Lock()
+	defer e.suspendMu.Unlock()
+	for key, s := range e.suspensions {
+		if s.record.ExecutionID == executionID {
+			e.removeSuspensionLocked(key, s)
+		}
+	}
+}
+
+// removeSuspensionLocked forgets a suspension, stops its timer, deletes it from the store
+// and unregisters the address consumer once nothing waits on it. Caller holds suspendMu.
+func (e *Engine) removeSuspensionLocked(key string, s *suspension) {
+	delete(e.suspensions, key)
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	if e.store != nil {
+		if err := e.store.Delete(s.record.ExecutionID, s.record.NodeID); err != nil {
+			e.logger.Error(fmt.Sprintf("failed to delete suspension %s: %v", key, err))
+		}
+	}
+
+	for _, other := range e.suspensions {
+		if other.record.Address == s.record.Address {
+			return
+		}
+	}
+	if consumer, ok := e.waitConsumers[s.record.Address]; ok {
+		delete(e.waitConsumers, s.record.Address)
+		if err := consumer.Unregister(); err != nil {
+			// Best-effort cleanup; ignore on error.
+		}
+	}
+}
+
+// RestoreSuspensions reloads persisted suspensions (e.g. after a restart) and resumes
+// waiting on them. Workflows must be registered first; suspensions of unknown
+// workflows are left in the store. Returns the number of suspensions restored.
+func (e *Engine) RestoreSuspensions(ctx context.Context) (int, error) {
+	e.suspendMu.Lock()
+	store := e.store
+	e.suspendMu.Unlock()
+	if store == nil {
+		return 0, nil
+	}
+
+	records, err := store.List()
+	if err != nil {
+		return 0, fmt.Errorf("list suspensions failed: %w", err)
+	}
+
+	restored := 0
+	contexts := make(map[string]context.Context) // Executions recreated by this call
+	for _, record := range records {
+		if record.Context == nil {
+			e.logger.Error(fmt.Sprintf("suspension %s:%s has no execution context", record.ExecutionID, record.NodeID))
+			continue
+		}
+
+		e.mu.Lock()
+		def, ok := e.workflows[record.WorkflowID]
+		if !ok {
+			e.mu.Unlock()
+			e.logger.Info(fmt.Sprintf("skipping suspension for unregistered workflow %s", record.WorkflowID))
+			continue
+		}
+		node := e.findNode(def, record.NodeID)
+		if node == nil {
+			e.mu.Unlock()
+			e.logger.Error(fmt.Sprintf("workflow %s has no node %s; dropping suspension", record.WorkflowID, record.NodeID))
+			if err := store.Delete(record.ExecutionID, record.NodeID); err != nil {
+				// Best-effort cleanup; ignore on error.
+			}
+			continue
+		}
+		state, exists := e.executions[record.ExecutionID]
+		execCtx, restoring := contexts[record.ExecutionID]
+		if exists && !restoring {
+			// Execution is live in this process and already waiting
+			e.mu.Unlock()
+			continue
+		}
+		if !exists {
+			restoreContextMaps(record.Context)
+			state = &ExecutionState{
+				ExecutionID: record.ExecutionID,
+				WorkflowID:  record.WorkflowID,
+				Status:      ExecutionStatusRunning,
+				StartTime:   record.Context.StartTime,
+				Context:     record.Context,
+			}
+			e.executions[record.ExecutionID] = state
+
+			var cancel context.CancelFunc
+			execCtx, cancel = context.WithCancel(ctx)
+			contexts[record.ExecutionID] = execCtx
+			e.execCtxMu.Lock()
+			e.execContexts[record.ExecutionID] = cancel
+			e.execCtxMu.Unlock()
+		}
+		record.Context = state.Context
+		e.mu.Unlock()
+
+		e.markNodeActive(record.ExecutionID, record.NodeID)
+		e.waitFor(execCtx, def, node, record)
+		restored++
+	}
+	return restored, nil
+}
+
+// restoreContextMaps ensures maps decoded from JSON are non-nil before nodes write to them
+func restoreContextMaps(execCtx *ExecutionContext) {
+	if execCtx.Data == nil {
+		execCtx.Data = make(map[string]interface{})
+	}
+	if execCtx.NodeOutputs == nil {
+		execCtx.NodeOutputs = make(map[string]interface{})
+	}
+	if execCtx.Variables == nil {
+		execCtx.Variables = make(map[string]interface{})
+	}
+}
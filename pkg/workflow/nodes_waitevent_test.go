@@ -0,0 +1,178 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func waitEventWorkflow(timeout string) *WorkflowDefinition {
+	config := map[string]interface{}{
+		"address":        "payments.completed",
+		"correlationKey": "orderId",
+	}
+	if timeout != "" {
+		config["timeout"] = timeout
+	}
+	return &WorkflowDefinition{
+		ID: "await-payment",
+		Nodes: []NodeDefinition{
+			{ID: "start", Type: "noop", Next: []string{"wait-payment"}},
+			{ID: "wait-payment", Type: "waitevent", Config: config, Next: []string{"ship"}, OnError: []string{"cancel"}},
+			{ID: "ship", Type: "noop"},
+			{ID: "cancel", Type: "noop"},
+		},
+	}
+}
+
+func waitForStatus(t *testing.T, engine *Engine, executionID string, want ExecutionStatus) *ExecutionState {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := engine.GetExecutionState(executionID)
+		if err != nil {
+			t.Fatalf("GetExecutionState() error = %v", err)
+		}
+		if state.Status == want {
+			return state
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("execution %s did not reach status %s", executionID, want)
+	return nil
+}
+
+func waitForSuspensions(t *testing.T, engine *Engine, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		engine.suspendMu.Lock()
+		n := len(engine.suspensions)
+		engine.suspendMu.Unlock()
+		if n == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("engine did not reach %d suspensions", want)
+}
+
+func TestWaitEvent_ResumesOnCorrelatedEvent(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	eb := gocmd.EventBus()
+	engine := NewEngine(eb)
+	if err := engine.RegisterWorkflow(waitEventWorkflow("")); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	execID, err := engine.ExecuteWorkflow(context.Background(), "await-payment", map[string]interface{}{"orderId": "42"})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	waitForSuspensions(t, engine, 1)
+
+	// Suspended executions are not complete
+	if err := eb.Publish("payments.completed", map[string]interface{}{"orderId": "41"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	state, _ := engine.GetExecutionState(execID)
+	engine.mu.RLock()
+	status := state.Status
+	engine.mu.RUnlock()
+	if status != ExecutionStatusRunning {
+		t.Fatalf("status = %s, uncorrelated event should not resume", status)
+	}
+
+	if err := eb.Send("payments.completed", map[string]interface{}{"orderId": "42", "amount": 10}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	state = waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	output, ok := state.Context.NodeOutputs["wait-payment"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("wait-payment output = %v", state.Context.NodeOutputs["wait-payment"])
+	}
+	if event, _ := output["event"].(map[string]interface{}); event["amount"] != float64(10) {
+		t.Errorf("event = %v, want amount 10", output["event"])
+	}
+	if _, ok := state.Context.NodeOutputs["ship"]; !ok {
+		t.Error("ship node should run after the event")
+	}
+}
+
+func TestWaitEvent_TimeoutFollowsOnError(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+	if err := engine.RegisterWorkflow(waitEventWorkflow("50ms")); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	execID, err := engine.ExecuteWorkflow(context.Background(), "await-payment", map[string]interface{}{"orderId": "42"})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state := waitForStatus(t, engine, execID, ExecutionStatusFailed)
+
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	if _, ok := state.Context.NodeOutputs["cancel"]; !ok {
+		t.Error("cancel node should run after the timeout")
+	}
+	if _, ok := state.Context.NodeOutputs["ship"]; ok {
+		t.Error("ship node should not run after the timeout")
+	}
+}
+
+func TestWaitEvent_RestoreFromStore(t *testing.T) {
+	store, err := NewFileSuspensionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileSuspensionStore() error = %v", err)
+	}
+
+	// First engine suspends and is then discarded (simulated restart)
+	gocmd1 := core.NewGoCMD(context.Background())
+	engine1 := NewEngine(gocmd1.EventBus())
+	engine1.SetSuspensionStore(store)
+	if err := engine1.RegisterWorkflow(waitEventWorkflow("")); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err := engine1.ExecuteWorkflow(context.Background(), "await-payment", map[string]interface{}{"orderId": "7"})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	waitForSuspensions(t, engine1, 1)
+	gocmd1.Close()
+
+	stored, err := store.List()
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("store.List() = %d suspensions, %v; want 1", len(stored), err)
+	}
+
+	gocmd2 := core.NewGoCMD(context.Background())
+	defer gocmd2.Close()
+	engine2 := NewEngine(gocmd2.EventBus())
+	engine2.SetSuspensionStore(store)
+	if err := engine2.RegisterWorkflow(waitEventWorkflow("")); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	restored, err := engine2.RestoreSuspensions(context.Background())
+	if err != nil || restored != 1 {
+		t.Fatalf("RestoreSuspensions() = %d, %v; want 1", restored, err)
+	}
+
+	if err := gocmd2.EventBus().Publish("payments.completed", map[string]interface{}{"orderId": 7}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	waitForStatus(t, engine2, execID, ExecutionStatusCompleted)
+
+	if stored, _ := store.List(); len(stored) != 0 {
+		t.Errorf("store has %d suspensions after resume, want 0", len(stored))
+	}
+}
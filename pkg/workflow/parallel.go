@@ -0,0 +1,299 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Join strategies and failure policies (config "strategy" and "onFailure")
+const (
+	joinAll    = "all"    // Every branch (default)
+	joinAny    = "any"    // The first branch to finish
+	joinFirstN = "firstN" // The first "n" branches to finish
+
+	joinFailFast = "failFast" // A failed branch fails the join (default)
+	joinCollect  = "collect"  // Failed branches are passed to the join under "errors"
+)
+
+// joinSpec is the parsed config of a join node
+type joinSpec struct {
+	parallel  string
+	branches  []string
+	strategy  string
+	n         int // Branches needed
+	onFailure string
+}
+
+// parseJoin reads a join node's config against the workflow; RegisterWorkflow
+// rejects workflows where it fails
+func parseJoin(def *WorkflowDefinition, node *NodeDefinition) (joinSpec, error) {
+	spec := joinSpec{strategy: joinAll, onFailure: joinFailFast}
+	spec.parallel, _ = node.Config["parallel"].(string)
+	if spec.parallel == "" {
+		return spec, fmt.Errorf("join node %s: config parallel is required", node.ID)
+	}
+	var parallel *NodeDefinition
+	for i := range def.Nodes {
+		if def.Nodes[i].ID == spec.parallel {
+			parallel = &def.Nodes[i]
+		}
+	}
+	if parallel == nil || NodeType(parallel.Type) != NodeTypeParallel {
+		return spec, fmt.Errorf("join node %s: %s is not a parallel node", node.ID, spec.parallel)
+	}
+
+	// Branches default to every branch of the parallel node
+	spec.branches = parallel.Next
+	if raw, ok := node.Config["branches"]; ok {
+		spec.branches = nil
+		list, _ := raw.([]interface{})
+		if strs, ok := raw.([]string); ok {
+			for _, s := range strs {
+				list = append(list, s)
+			}
+		}
+		for _, b := range list {
+			branch, _ := b.(string)
+			if !containsString(parallel.Next, branch) {
+				return spec, fmt.Errorf("join node %s: %v is not a branch of %s", node.ID, b, spec.parallel)
+			}
+			spec.branches = append(spec.branches, branch)
+		}
+	}
+	if len(spec.branches) == 0 {
+		return spec, fmt.Errorf("join node %s: no branches to wait for", node.ID)
+	}
+
+	if s, ok := node.Config["strategy"].(string); ok && s != "" {
+		spec.strategy = s
+	}
+	switch spec.strategy {
+	case joinAll:
+		spec.n = len(spec.branches)
+	case joinAny:
+		spec.n = 1
+	case joinFirstN:
+		spec.n = int(toFloat(node.Config["n"]))
+		if spec.n < 1 || spec.n > len(spec.branches) {
+			return spec, fmt.Errorf("join node %s: n must be between 1 and %d", node.ID, len(spec.branches))
+		}
+	default:
+		return spec, fmt.Errorf("join node %s: unknown strategy %q", node.ID, spec.strategy)
+	}
+
+	if f, ok := node.Config["onFailure"].(string); ok && f != "" {
+		spec.onFailure = f
+	}
+	if spec.onFailure != joinFailFast && spec.onFailure != joinCollect {
+		return spec, fmt.Errorf("join node %s: unknown onFailure %q", node.ID, spec.onFailure)
+	}
+	return spec, nil
+}
+
+// validateJoins checks every parallel and join node of a workflow
+func validateJoins(def *WorkflowDefinition) error {
+	for i := range def.Nodes {
+		node := &def.Nodes[i]
+		switch NodeType(node.Type) {
+		case NodeTypeParallel:
+			if len(node.Next) == 0 {
+				return fmt.Errorf("parallel node %s has no branches", node.ID)
+			}
+		case NodeTypeJoin:
+			if _, err := parseJoin(def, node); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// branchTag marks a node as running in a branch of a parallel node. Nodes
+// inherit the tag of the node that launched them, so a branch can be several
+// nodes long and the join knows which branch arrived.
+type branchTag struct {
+	parallel string
+	branch   string // ID of the branch's first node
+	gen      int    // Run of the parallel node, so a loop's earlier runs are ignored
+}
+
+// parallelRun is the latest run of a parallel node in an execution
+type parallelRun struct {
+	gen   int
+	outer *branchTag // Tag of the parallel node itself, passed on to its joins
+}
+
+// joinState collects the branches that reached a join in one parallel run
+type joinState struct {
+	gen     int
+	done    bool
+	results map[string]interface{} // Branch -> data it passed to the join
+	errors  map[string]string      // Branch -> error
+}
+
+// tagNext passes the branch tag of node on to the nodes it launches (joins
+// excluded: they get their parallel node's tag when they fire). With split set
+// (a parallel node that succeeded) it starts a new run with one branch per
+// next node instead.
+func (e *Engine) tagNext(def *WorkflowDefinition, executionID string, node *NodeDefinition, nextNodes []string, split bool) {
+	e.mergeMu.Lock()
+	defer e.mergeMu.Unlock()
+
+	tag := e.branchTags[executionID+":"+node.ID]
+	if split {
+		e.parallelGen++
+		e.parallels[executionID+":"+node.ID] = &parallelRun{gen: e.parallelGen, outer: tag}
+	}
+	for _, nextID := range nextNodes {
+		next := e.findNode(def, nextID)
+		if next == nil || NodeType(next.Type) == NodeTypeJoin {
+			continue
+		}
+		key := executionID + ":" + nextID
+		switch {
+		case split:
+			e.branchTags[key] = &branchTag{parallel: node.ID, branch: nextID, gen: e.parallelGen}
+		case tag != nil:
+			e.branchTags[key] = tag
+		default:
+			delete(e.branchTags, key)
+		}
+	}
+}
+
+// arriveAtJoin records that the branch from ran in reached join with data,
+// and launches the join once its strategy is satisfied.
+func (e *Engine) arriveAtJoin(ctx context.Context, def *WorkflowDefinition, join *NodeDefinition, execCtx *ExecutionContext, from *NodeDefinition, data interface{}) {
+	spec, _ := parseJoin(def, join)
+	e.mergeMu.Lock()
+	tag := e.branchTags[execCtx.ExecutionID+":"+from.ID]
+	e.mergeMu.Unlock()
+	if tag == nil || tag.parallel != spec.parallel {
+		e.recordError(execCtx, join.ID, fmt.Sprintf("node %s is not in a branch of %s", from.ID, spec.parallel))
+		return
+	}
+	if !containsString(spec.branches, tag.branch) {
+		return // Branches the join doesn't wait for are ignored, like late ones
+	}
+	e.settleBranch(ctx, def, join, spec, execCtx, tag, data, "")
+}
+
+// failBranch reports a failed node that has no onError edges to the joins
+// waiting for its branch
+func (e *Engine) failBranch(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, execCtx *ExecutionContext, err error) {
+	e.mergeMu.Lock()
+	tag := e.branchTags[execCtx.ExecutionID+":"+node.ID]
+	e.mergeMu.Unlock()
+	if tag == nil {
+		return
+	}
+	for i := range def.Nodes {
+		join := &def.Nodes[i]
+		if NodeType(join.Type) != NodeTypeJoin {
+			continue
+		}
+		if spec, perr := parseJoin(def, join); perr == nil && spec.parallel == tag.parallel && containsString(spec.branches, tag.branch) {
+			e.settleBranch(ctx, def, join, spec, execCtx, tag, nil, fmt.Sprintf("%s: %v", node.ID, err))
+		}
+	}
+}
+
+// settleBranch records a branch's result (or failure if errMsg is set) at a
+// join, then fires or fails the join when the outcome is decided
+func (e *Engine) settleBranch(ctx context.Context, def *WorkflowDefinition, join *NodeDefinition, spec joinSpec, execCtx *ExecutionContext, tag *branchTag, data interface{}, errMsg string) {
+	key := execCtx.ExecutionID + ":" + join.ID
+
+	e.mergeMu.Lock()
+	run := e.parallels[execCtx.ExecutionID+":"+spec.parallel]
+	if run == nil || run.gen != tag.gen {
+		e.mergeMu.Unlock() // An earlier run of the parallel node
+		return
+	}
+	state := e.joins[key]
+	if state == nil || state.gen != run.gen {
+		state = &joinState{gen: run.gen, results: make(map[string]interface{}), errors: make(map[string]string)}
+		e.joins[key] = state
+	}
+	if state.done {
+		e.mergeMu.Unlock() // Decided already, e.g. a late branch of an "any" join
+		return
+	}
+	if errMsg != "" {
+		state.errors[tag.branch] = errMsg
+	} else {
+		state.results[tag.branch] = data
+	}
+
+	// "all" waits for every branch to settle (collecting failures); "any" and
+	// "firstN" fire once n branches succeeded, or fail once that can't happen
+	var fail error
+	settled := len(state.results) + len(state.errors)
+	switch {
+	case errMsg != "" && spec.onFailure == joinFailFast:
+		fail = fmt.Errorf("branch %s failed: %s", tag.branch, errMsg)
+	case spec.strategy == joinAll && settled < len(spec.branches):
+		e.mergeMu.Unlock() // Waiting for more branches
+		return
+	case spec.strategy == joinAll:
+		if len(state.results) == 0 {
+			fail = fmt.Errorf("all %d branches failed", len(spec.branches))
+		}
+	case len(spec.branches)-len(state.errors) < spec.n:
+		fail = fmt.Errorf("%d of %d branches failed", len(state.errors), len(spec.branches))
+	case len(state.results) < spec.n:
+		e.mergeMu.Unlock() // Waiting for more branches
+		return
+	}
+	state.done = true
+	input := map[string]interface{}{"results": state.results, "errors": state.errors}
+	if run.outer != nil {
+		e.branchTags[key] = run.outer
+	} else {
+		delete(e.branchTags, key)
+	}
+	e.mergeMu.Unlock()
+
+	if fail != nil {
+		e.failNode(ctx, def, join, execCtx, input, fail)
+		return
+	}
+	e.markNodeActive(execCtx.ExecutionID, join.ID)
+	e.launchNode(ctx, def, join, execCtx, input)
+}
+
+// dropMergeState forgets the merge and join bookkeeping of an execution
+func (e *Engine) dropMergeState(executionID string) {
+	prefix := executionID + ":"
+	e.mergeMu.Lock()
+	defer e.mergeMu.Unlock()
+	for key := range e.mergeStates {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.mergeStates, key)
+		}
+	}
+	for key := range e.branchTags {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.branchTags, key)
+		}
+	}
+	for key := range e.parallels {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.parallels, key)
+		}
+	}
+	for key := range e.joins {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.joins, key)
+		}
+	}
+}
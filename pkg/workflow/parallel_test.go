@@ -0,0 +1,168 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// newParallelEngine registers "slow" (sleeps config delay, then passes its
+// input on), "fail" and "capture" (sends its input to the returned channel)
+func newParallelEngine(t *testing.T) (*Engine, chan interface{}) {
+	t.Helper()
+	gocmd := core.NewGoCMD(context.Background())
+	t.Cleanup(func() { gocmd.Close() })
+	engine := NewEngine(gocmd.EventBus())
+	captured := make(chan interface{}, 4)
+	engine.RegisterNodeHandler("slow", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		delay, _ := time.ParseDuration(input.Config["delay"].(string))
+		time.Sleep(delay)
+		return &NodeOutput{Data: input.Config["delay"]}, nil
+	})
+	engine.RegisterNodeHandler("fail", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		return nil, errors.New("boom")
+	})
+	engine.RegisterNodeHandler("capture", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		captured <- input.Data
+		return &NodeOutput{Data: input.Data}, nil
+	})
+	return engine, captured
+}
+
+// parallelWorkflow is start -> p -> branches a, b, c -> j -> after; b is two
+// nodes long (b -> b2)
+func parallelWorkflow(id string, join map[string]interface{}, types map[string]string) *WorkflowDefinition {
+	join["parallel"] = "p"
+	nodeType := func(id string) string {
+		if t, ok := types[id]; ok {
+			return t
+		}
+		return "slow"
+	}
+	return NewWorkflowBuilder(id, id).
+		AddNode("start", "manual").Next("p").Done().
+		AddNode("p", "parallel").Next("a", "b", "c").Done().
+		AddNode("a", nodeType("a")).Config(map[string]interface{}{"delay": "10ms"}).Next("j").Done().
+		AddNode("b", "noop").Next("b2").Done().
+		AddNode("b2", nodeType("b2")).Config(map[string]interface{}{"delay": "30ms"}).Next("j").Done().
+		AddNode("c", nodeType("c")).Config(map[string]interface{}{"delay": "200ms"}).Next("j").Done().
+		AddNode("j", "join").Config(join).Next("after").Done().
+		AddNode("after", "capture").Done().
+		Build()
+}
+
+func TestEngine_ParallelJoin(t *testing.T) {
+	tests := []struct {
+		name    string
+		join    map[string]interface{}
+		types   map[string]string
+		want    ExecutionStatus
+		results []string // Branches in the join's input; nil when the join fails
+		errors  []string
+	}{
+		{"all", map[string]interface{}{}, nil, ExecutionStatusCompleted, []string{"a", "b", "c"}, nil},
+		{"any", map[string]interface{}{"strategy": "any"}, nil, ExecutionStatusCompleted, []string{"a"}, nil},
+		{"firstN", map[string]interface{}{"strategy": "firstN", "n": 2}, nil, ExecutionStatusCompleted, []string{"a", "b"}, nil},
+		{"declared branches", map[string]interface{}{"branches": []interface{}{"a", "b"}}, nil, ExecutionStatusCompleted, []string{"a", "b"}, nil},
+		{"fail fast", map[string]interface{}{}, map[string]string{"b2": "fail"}, ExecutionStatusFailed, nil, nil},
+		{"collect", map[string]interface{}{"onFailure": "collect"}, map[string]string{"b2": "fail"}, ExecutionStatusPartialSuccess, []string{"a", "c"}, []string{"b"}},
+		{"collect unsatisfiable", map[string]interface{}{"strategy": "firstN", "n": 2, "onFailure": "collect"}, map[string]string{"a": "fail", "b2": "fail"}, ExecutionStatusFailed, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, captured := newParallelEngine(t)
+			if err := engine.RegisterWorkflow(parallelWorkflow("par", tt.join, tt.types)); err != nil {
+				t.Fatalf("RegisterWorkflow() error = %v", err)
+			}
+			execID, err := engine.ExecuteWorkflow(context.Background(), "par", map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("ExecuteWorkflow() error = %v", err)
+			}
+			state := waitForStatus(t, engine, execID, tt.want)
+
+			if tt.results == nil {
+				engine.mu.RLock()
+				r := state.NodeResults["j"]
+				engine.mu.RUnlock()
+				if r.Status != NodeStatusFailed {
+					t.Errorf("join result = %+v, want failed", r)
+				}
+				select {
+				case got := <-captured:
+					t.Errorf("node after a failed join ran with %v", got)
+				default:
+				}
+				return
+			}
+
+			var input map[string]interface{}
+			select {
+			case got := <-captured:
+				input = got.(map[string]interface{})
+			default:
+				t.Fatal("node after the join did not run")
+			}
+			if got := sortedKeys(input["results"]); strings.Join(got, ",") != strings.Join(tt.results, ",") {
+				t.Errorf("results = %v, want branches %v", input["results"], tt.results)
+			}
+			if got := sortedKeys(input["errors"]); strings.Join(got, ",") != strings.Join(tt.errors, ",") {
+				t.Errorf("errors = %v, want branches %v", input["errors"], tt.errors)
+			}
+			if results := input["results"].(map[string]interface{}); results["b"] != nil && results["b"] != "30ms" {
+				t.Errorf("branch b result = %v, want the output of its last node", results["b"])
+			}
+		})
+	}
+}
+
+// sortedKeys returns the keys of a join input map
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestEngine_ParallelJoinValidation(t *testing.T) {
+	engine, _ := newParallelEngine(t)
+	tests := []struct {
+		name string
+		join map[string]interface{}
+		want string
+	}{
+		{"unknown parallel", map[string]interface{}{"parallel": "start"}, "not a parallel node"},
+		{"unknown branch", map[string]interface{}{"branches": []interface{}{"a", "after"}}, "not a branch of p"},
+		{"unknown strategy", map[string]interface{}{"strategy": "most"}, "unknown strategy"},
+		{"n out of range", map[string]interface{}{"strategy": "firstN", "n": 4}, "n must be between 1 and 3"},
+		{"unknown onFailure", map[string]interface{}{"onFailure": "ignore"}, "unknown onFailure"},
+	}
+	for _, tt := range tests {
+		def := parallelWorkflow("bad", map[string]interface{}{}, nil)
+		for k, v := range tt.join {
+			def.Nodes[6].Config[k] = v
+		}
+		err := engine.RegisterWorkflow(def)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: RegisterWorkflow() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	noBranches := NewWorkflowBuilder("empty", "empty").AddNode("p", "parallel").Done().Build()
+	if err := engine.RegisterWorkflow(noBranches); err == nil {
+		t.Error("RegisterWorkflow() should reject a parallel node without branches")
+	}
+}
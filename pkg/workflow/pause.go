@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// heldNode is a node launch deferred until its execution resumes.
+type heldNode struct {
+	ctx     context.Context
+	def     *WorkflowDefinition
+	node    *NodeDefinition
+	execCtx *ExecutionContext
+	input   interface{}
+}
+
+// launchNode runs a node in its own goroutine, or holds it back while the
+// execution is paused. Held nodes stay active so the execution cannot complete.
+func (e *Engine) launchNode(ctx context.Context, def *WorkflowDefinition, node *NodeDefinition, execCtx *ExecutionContext, input interface{}) {
+	executionID := execCtx.ExecutionID
+
+	e.mu.Lock()
+	if state, ok := e.executions[executionID]; ok && state.Status == ExecutionStatusPaused {
+		e.held[executionID] = append(e.held[executionID], heldNode{
+			ctx:     ctx,
+			def:     def,
+			node:    node,
+			execCtx: execCtx,
+			input:   input,
+		})
+		e.mu.Unlock()
+		e.markNodeActive(executionID, node.ID)
+		return
+	}
+	e.mu.Unlock()
+
+	go e.executeNode(ctx, def, node, execCtx, input)
+}
+
+// PauseExecution stops a running execution from launching new nodes.
+// Nodes already in flight run to completion; the nodes they lead to are held
+// until ResumeExecution.
+func (e *Engine) PauseExecution(executionID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, ok := e.executions[executionID]
+	if !ok {
+		return fmt.Errorf("execution not found: %s", executionID)
+	}
+	if state.Status != ExecutionStatusRunning {
+		return fmt.Errorf("execution is not running")
+	}
+
+	now := time.Now()
+	state.Status = ExecutionStatusPaused
+	state.PausedAt = &now
+	return nil
+}
+
+// ResumeExecution continues a paused execution, launching the nodes held
+// while it was paused.
+func (e *Engine) ResumeExecution(executionID string) error {
+	e.mu.Lock()
+	state, ok := e.executions[executionID]
+	if !ok {
+		e.mu.Unlock()
+		return fmt.Errorf("execution not found: %s", executionID)
+	}
+	if state.Status != ExecutionStatusPaused {
+		e.mu.Unlock()
+		return fmt.Errorf("execution is not paused")
+	}
+
+	state.Status = ExecutionStatusRunning
+	state.PausedAt = nil
+	held := e.held[executionID]
+	delete(e.held, executionID)
+	e.mu.Unlock()
+
+	for _, h := range held {
+		go e.executeNode(h.ctx, h.def, h.node, h.execCtx, h.input)
+	}
+
+	// Paths that ended while paused could not complete the execution
+	e.checkExecutionComplete(executionID)
+	return nil
+}
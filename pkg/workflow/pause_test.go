@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+// gatedEngine runs start -> step -> end, where start blocks until release is closed
+func gatedEngine(t *testing.T) (*Engine, chan struct{}) {
+	t.Helper()
+	gocmd := core.NewGoCMD(context.Background())
+	t.Cleanup(func() { gocmd.Close() })
+	engine := NewEngine(gocmd.EventBus())
+
+	release := make(chan struct{})
+	engine.RegisterNodeHandler("gate", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return &NodeOutput{Data: input.Data}, nil
+	})
+	def := &WorkflowDefinition{
+		ID: "gated",
+		Nodes: []NodeDefinition{
+			{ID: "start", Type: "gate", Next: []string{"step"}},
+			{ID: "step", Type: "noop", Next: []string{"end"}},
+			{ID: "end", Type: "noop"},
+		},
+	}
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	return engine, release
+}
+
+func TestPauseExecution_HoldsNextNodesUntilResume(t *testing.T) {
+	engine, release := gatedEngine(t)
+	execID, err := engine.ExecuteWorkflow(context.Background(), "gated", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+
+	if err := engine.PauseExecution(execID); err != nil {
+		t.Fatalf("PauseExecution() error = %v", err)
+	}
+	if err := engine.PauseExecution(execID); err == nil {
+		t.Error("pausing a paused execution should fail")
+	}
+
+	// The in-flight node finishes; the one after it is held
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	state := waitForStatus(t, engine, execID, ExecutionStatusPaused)
+	engine.mu.RLock()
+	_, startDone := state.Context.NodeOutputs["start"]
+	_, stepDone := state.Context.NodeOutputs["step"]
+	pausedAt := state.PausedAt
+	engine.mu.RUnlock()
+	if !startDone {
+		t.Error("in-flight start node should finish while paused")
+	}
+	if stepDone {
+		t.Error("step node should not run while paused")
+	}
+	if pausedAt == nil {
+		t.Error("PausedAt should be set")
+	}
+
+	if n := engine.CleanupOldExecutions(0); n != 0 {
+		t.Errorf("CleanupOldExecutions() = %d, paused executions must be kept", n)
+	}
+
+	if err := engine.ResumeExecution(execID); err != nil {
+		t.Fatalf("ResumeExecution() error = %v", err)
+	}
+	state = waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	for _, id := range []string{"step", "end"} {
+		if _, ok := state.Context.NodeOutputs[id]; !ok {
+			t.Errorf("%s node should run after resume", id)
+		}
+	}
+	if state.PausedAt != nil {
+		t.Error("PausedAt should be cleared on resume")
+	}
+}
+
+func TestPauseExecution_ResumeCompletesEndedExecution(t *testing.T) {
+	engine, release := gatedEngine(t)
+	def := &WorkflowDefinition{
+		ID:    "single",
+		Nodes: []NodeDefinition{{ID: "only", Type: "gate"}},
+	}
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err := engine.ExecuteWorkflow(context.Background(), "single", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	if err := engine.PauseExecution(execID); err != nil {
+		t.Fatalf("PauseExecution() error = %v", err)
+	}
+
+	// The last node finishing does not complete a paused execution
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	waitForStatus(t, engine, execID, ExecutionStatusPaused)
+
+	if err := engine.ResumeExecution(execID); err != nil {
+		t.Fatalf("ResumeExecution() error = %v", err)
+	}
+	waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+}
+
+func TestPauseExecution_CancelPaused(t *testing.T) {
+	engine, release := gatedEngine(t)
+	defer close(release)
+	execID, err := engine.ExecuteWorkflow(context.Background(), "gated", nil)
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	if err := engine.ResumeExecution(execID); err == nil {
+		t.Error("resuming a running execution should fail")
+	}
+	if err := engine.PauseExecution(execID); err != nil {
+		t.Fatalf("PauseExecution() error = %v", err)
+	}
+	if err := engine.CancelExecution(execID); err != nil {
+		t.Fatalf("CancelExecution() error = %v", err)
+	}
+	waitForStatus(t, engine, execID, ExecutionStatusCancelled)
+	if err := engine.ResumeExecution(execID); err == nil {
+		t.Error("resuming a cancelled execution should fail")
+	}
+	if err := engine.PauseExecution("missing"); err == nil {
+		t.Error("pausing an unknown execution should fail")
+	}
+}
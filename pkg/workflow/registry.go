@@ -35,6 +35,14 @@ func (r *nodeRegistry) Get(nodeType NodeType) (NodeHandler, bool) {
 func (r *nodeRegistry) registerBuiltins() {
 	// Register all built-in node handlers
 	r.handlers[NodeTypeNoOp] = noOpHandler
+
+	// Triggers pass the workflow input on; respond passes on what it received,
+	// which becomes the execution output
+	r.handlers[NodeTypeWebhook] = noOpHandler
+	r.handlers[NodeTypeManual] = noOpHandler
+	r.handlers[NodeTypeSchedule] = noOpHandler
+	r.handlers[NodeTypeEvent] = noOpHandler
+	r.handlers[NodeTypeRespond] = noOpHandler
 	r.handlers[NodeTypeSet] = setHandler
 	r.handlers[NodeTypeCondition] = conditionHandler
 	r.handlers[NodeTypeWait] = waitHandler
@@ -42,5 +50,8 @@ func (r *nodeRegistry) registerBuiltins() {
 	r.handlers[NodeTypeLoop] = loopHandler
 	r.handlers[NodeTypeSplit] = splitHandler
 	r.handlers[NodeTypeMerge] = mergeHandler
+	r.handlers[NodeTypeParallel] = noOpHandler // Every branch gets the parallel node's input
+	r.handlers[NodeTypeJoin] = noOpHandler     // The engine builds the join's input
 	r.handlers[NodeTypeSwitch] = switchHandler
+	r.handlers[NodeTypeHTTP] = HTTPNodeHandler
 }
@@ -0,0 +1,361 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule trigger config (on the workflow's "schedule" node), one of:
+//   - "cron": five fields, minute hour day-of-month month day-of-week, each "*",
+//     a number, a range "1-5", a step "*/15" or "1-30/2", or a comma list; or
+//     @yearly, @monthly, @weekly, @daily, @hourly. Day of week 0 and 7 are Sunday.
+//   - "interval": a Go duration ("30s", "5m") between runs
+//
+// and optionally:
+//   - "timezone": IANA zone the cron fields are read in (default: local time)
+//   - "overlap": "skip" (default) leaves a run out while the previous one is still
+//     running or paused; "allow" starts it anyway
+//   - "missedRuns": when the scheduler wakes up after a later run was already due
+//     (e.g. the host was suspended), "skip" (default) waits for the next run and
+//     "runOnce" starts one run for everything missed
+//
+// Each run gets {"scheduledAt": RFC 3339 time} as input.
+const (
+	ScheduleOverlapSkip   = "skip"
+	ScheduleOverlapAllow  = "allow"
+	ScheduleMissedSkip    = "skip"
+	ScheduleMissedRunOnce = "runOnce"
+)
+
+// cronSearchYears bounds the search for a cron expression's next run
+const cronSearchYears = 5
+
+// schedule computes run times
+type schedule interface {
+	// next returns the first run time after t
+	next(t time.Time) time.Time
+}
+
+// scheduleTrigger is a parsed schedule node
+type scheduleTrigger struct {
+	workflowID string
+	schedule   schedule
+	overlap    string
+	missedRuns string
+}
+
+// scheduleTriggers parses the schedule nodes of def
+func scheduleTriggers(def *WorkflowDefinition) ([]*scheduleTrigger, error) {
+	var triggers []*scheduleTrigger
+	for _, node := range def.Nodes {
+		if NodeType(node.Type) != NodeTypeSchedule {
+			continue
+		}
+		t, err := parseScheduleTrigger(def.ID, node.Config)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %s schedule node %s: %w", def.ID, node.ID, err)
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, nil
+}
+
+func parseScheduleTrigger(workflowID string, config map[string]interface{}) (*scheduleTrigger, error) {
+	t := &scheduleTrigger{
+		workflowID: workflowID,
+		overlap:    ScheduleOverlapSkip,
+		missedRuns: ScheduleMissedSkip,
+	}
+
+	spec, _ := config["cron"].(string)
+	interval, _ := config["interval"].(string)
+	switch {
+	case spec != "" && interval != "":
+		return nil, fmt.Errorf("set either cron or interval, not both")
+	case spec != "":
+		loc := time.Local
+		if tz, _ := config["timezone"].(string); tz != "" {
+			l, err := time.LoadLocation(tz)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+			}
+			loc = l
+		}
+		s, err := parseCron(spec, loc)
+		if err != nil {
+			return nil, err
+		}
+		t.schedule = s
+	case interval != "":
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q: %w", interval, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("interval must be positive")
+		}
+		t.schedule = intervalSchedule{every: d}
+	default:
+		return nil, fmt.Errorf("cron or interval is required")
+	}
+
+	if overlap, ok := config["overlap"].(string); ok {
+		if overlap != ScheduleOverlapSkip && overlap != ScheduleOverlapAllow {
+			return nil, fmt.Errorf("invalid overlap policy %q", overlap)
+		}
+		t.overlap = overlap
+	}
+	if missed, ok := config["missedRuns"].(string); ok {
+		if missed != ScheduleMissedSkip && missed != ScheduleMissedRunOnce {
+			return nil, fmt.Errorf("invalid missedRuns policy %q", missed)
+		}
+		t.missedRuns = missed
+	}
+	return t, nil
+}
+
+// intervalSchedule runs every fixed duration
+type intervalSchedule struct {
+	every time.Duration
+}
+
+func (s intervalSchedule) next(t time.Time) time.Time {
+	return t.Add(s.every)
+}
+
+// cronSchedule holds one bit per allowed value of each cron field
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool // "*" fields; a day matches either restricted field, as in cron
+	loc                           *time.Location
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron parses a five-field cron expression read in loc
+func parseCron(spec string, loc *time.Location) (*cronSchedule, error) {
+	if expanded, ok := cronDescriptors[strings.TrimSpace(spec)]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: want 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &cronSchedule{loc: loc}
+	var err error
+	bounds := []struct {
+		field    *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dom, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dow, 0, 7},
+	}
+	for i, b := range bounds {
+		if *b.field, err = parseCronField(fields[i], b.min, b.max); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // 7 is Sunday too
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return s, nil
+}
+
+// parseCronField returns the bit set of the values a field allows
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if hasStep {
+				hi = max // "5/15" means 5, 20, 35, ...
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{} // e.g. "0 0 30 2 *" never runs
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// scheduler starts the executions of scheduled workflows until stopped
+type scheduler struct {
+	engine *Engine
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	loops map[string]context.CancelFunc // workflowID -> stops its schedule loops
+}
+
+func newScheduler(engine *Engine) *scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &scheduler{
+		engine: engine,
+		ctx:    ctx,
+		cancel: cancel,
+		loops:  make(map[string]context.CancelFunc),
+	}
+}
+
+// add starts the schedule nodes of def, replacing the schedules of a workflow
+// registered earlier under the same ID
+func (s *scheduler) add(def *WorkflowDefinition) error {
+	triggers, err := scheduleTriggers(def)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stop, ok := s.loops[def.ID]; ok {
+		stop()
+		delete(s.loops, def.ID)
+	}
+	if len(triggers) == 0 || s.ctx.Err() != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.loops[def.ID] = cancel
+	for _, t := range triggers {
+		s.wg.Add(1)
+		go s.run(ctx, t)
+	}
+	return nil
+}
+
+// stop stops every schedule and waits for the loops to exit; running executions continue
+func (s *scheduler) stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// run starts t's workflow at each scheduled time until ctx is done
+func (s *scheduler) run(ctx context.Context, t *scheduleTrigger) {
+	defer s.wg.Done()
+
+	var last string // Execution ID of the previous run
+	due := t.schedule.next(time.Now())
+	for !due.IsZero() {
+		timer := time.NewTimer(time.Until(due))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		next := t.schedule.next(due)
+		late := !next.IsZero() && !next.After(now)
+		if late {
+			next = t.schedule.next(now)
+		}
+		if !late || t.missedRuns == ScheduleMissedRunOnce {
+			if id, ok := s.fire(t, due, last); ok {
+				last = id
+			}
+		}
+		due = next
+	}
+}
+
+// fire starts one run unless the overlap policy leaves it out
+func (s *scheduler) fire(t *scheduleTrigger, due time.Time, last string) (string, bool) {
+	if t.overlap == ScheduleOverlapSkip && last != "" && s.engine.executionActive(last) {
+		s.engine.logger.Info(fmt.Sprintf("workflow %s: skipping scheduled run at %s, previous execution %s still active",
+			t.workflowID, due.Format(time.RFC3339), last))
+		return "", false
+	}
+	// Not tied to the scheduler: stopping the schedule leaves started runs alone
+	execID, err := s.engine.ExecuteWorkflow(context.Background(), t.workflowID, map[string]interface{}{
+		"scheduledAt": due.Format(time.RFC3339),
+	})
+	if err != nil {
+		s.engine.logger.Error(fmt.Sprintf("workflow %s: scheduled run failed to start: %v", t.workflowID, err))
+		return "", false
+	}
+	return execID, true
+}
+
+// executionActive reports whether an execution is still running or paused
+func (e *Engine) executionActive(executionID string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	state, ok := e.executions[executionID]
+	if !ok {
+		return false
+	}
+	return state.Status == ExecutionStatusRunning || state.Status == ExecutionStatusPaused || state.Status == ExecutionStatusPending
+}
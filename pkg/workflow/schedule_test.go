@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestParseCron(t *testing.T) {
+	base := time.Date(2026, time.March, 10, 14, 7, 30, 0, time.UTC) // a Tuesday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 10, 14, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 10, 14, 15, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC)},
+		{"30 2 1 * *", time.Date(2026, time.April, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 12 13 * 5", time.Date(2026, time.March, 13, 12, 0, 0, 0, time.UTC)}, // day 13 or a Friday
+		{"@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := parseCron(tt.spec, time.UTC)
+		if err != nil {
+			t.Errorf("parseCron(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got := s.next(base); !got.Equal(tt.want) {
+			t.Errorf("parseCron(%q).next() = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := parseCron(spec, time.UTC); err == nil {
+			t.Errorf("parseCron(%q) should fail", spec)
+		}
+	}
+}
+
+func TestEngine_RegisterWorkflowRejectsBadSchedule(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	for _, config := range []map[string]interface{}{
+		{},
+		{"cron": "* * *"},
+		{"interval": "-1s"},
+		{"interval": "1m", "cron": "* * * * *"},
+		{"interval": "1m", "overlap": "queue"},
+	} {
+		def := NewWorkflowBuilder("bad", "Bad").AddNode("tick", "schedule").Config(config).Done().Build()
+		if err := engine.RegisterWorkflow(def); err == nil {
+			t.Errorf("RegisterWorkflow() with schedule config %v should fail", config)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	var runs int32
+	release := make(chan struct{})
+	engine.RegisterNodeHandler("job", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			<-release // The first run outlasts several ticks
+		}
+		return &NodeOutput{Data: input.Data}, nil
+	})
+	def := NewWorkflowBuilder("ticker", "Ticker").
+		AddNode("tick", "schedule").Config(map[string]interface{}{"interval": "20ms"}).Next("job").Done().
+		AddNode("job", "job").Done().
+		Build()
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	s := newScheduler(engine)
+	if err := s.add(def); err != nil {
+		t.Fatalf("add() error = %v", err)
+	}
+
+	// Overlapping runs are skipped while the first is still running
+	time.Sleep(150 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("runs while the first is active = %d, want 1", got)
+	}
+	close(release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&runs) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&runs); got < 3 {
+		t.Fatalf("runs = %d, want at least 3", got)
+	}
+
+	// Nothing starts once the scheduler is stopped
+	s.stop()
+	time.Sleep(20 * time.Millisecond)
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got != stopped {
+		t.Errorf("runs after stop = %d, want %d", got, stopped)
+	}
+}
+
+func TestWorkflowVerticle_SchedulesWorkflowsRegisteredAfterStart(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	v := NewWorkflowVerticle(nil)
+	dep, err := gocmd.DeployVerticleH(v)
+	if err != nil {
+		t.Fatalf("DeployVerticleH() error = %v", err)
+	}
+	for deadline := time.Now().Add(2 * time.Second); dep.State() != core.DeploymentStateStarted; time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("verticle state = %v, want started", dep.State())
+		}
+	}
+
+	ran := make(chan struct{}, 10)
+	v.Engine().RegisterNodeHandler("job", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		ran <- struct{}{}
+		return &NodeOutput{Data: input.Data}, nil
+	})
+	def := NewWorkflowBuilder("late", "Late").
+		AddNode("tick", "schedule").Config(map[string]interface{}{"interval": "20ms"}).Next("job").Done().
+		AddNode("job", "job").Done().
+		Build()
+	if err := v.Engine().RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("workflow registered after Start never ran on its schedule")
+	}
+}
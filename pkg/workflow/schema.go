@@ -0,0 +1,154 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldSpec describes one field of a workflow input or output.
+type FieldSpec struct {
+	// Type is one of string, number, integer, boolean, object, array (empty = any)
+	Type string `json:"type,omitempty"`
+
+	// Required fails validation when the field is missing or null
+	Required bool `json:"required,omitempty"`
+
+	// Fields describes nested fields when Type is object
+	Fields map[string]FieldSpec `json:"fields,omitempty"`
+}
+
+var schemaTypes = map[string]bool{
+	"": true, "string": true, "number": true, "integer": true, "boolean": true, "object": true, "array": true,
+}
+
+// checkSchema rejects unknown field types at registration time
+func checkSchema(schema map[string]FieldSpec, path string) error {
+	for name, spec := range schema {
+		if !schemaTypes[spec.Type] {
+			return fmt.Errorf("field %q has unknown type %q", joinPath(path, name), spec.Type)
+		}
+		if len(spec.Fields) > 0 && spec.Type != "object" && spec.Type != "" {
+			return fmt.Errorf("field %q has nested fields but type %q", joinPath(path, name), spec.Type)
+		}
+		if err := checkSchema(spec.Fields, joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSchema checks value against the field specs and reports every violation.
+// An empty schema accepts anything; a non-empty one requires an object.
+func validateSchema(schema map[string]FieldSpec, value interface{}) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	var problems []string
+	validateFields(schema, value, "", &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validateFields(schema map[string]FieldSpec, value interface{}, path string, problems *[]string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		if path == "" {
+			*problems = append(*problems, fmt.Sprintf("must be an object, got %s", typeName(value)))
+		} else {
+			*problems = append(*problems, fmt.Sprintf("field %q must be object, got %s", path, typeName(value)))
+		}
+		return
+	}
+
+	// Sorted for stable error messages
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := schema[name]
+		fieldPath := joinPath(path, name)
+		v, present := obj[name]
+		if !present || v == nil {
+			if spec.Required {
+				*problems = append(*problems, fmt.Sprintf("field %q is required", fieldPath))
+			}
+			continue
+		}
+		if spec.Type != "" && !matchesType(spec.Type, v) {
+			*problems = append(*problems, fmt.Sprintf("field %q must be %s, got %s", fieldPath, spec.Type, typeName(v)))
+			continue
+		}
+		if len(spec.Fields) > 0 {
+			validateFields(spec.Fields, v, fieldPath, problems)
+		}
+	}
+}
+
+func matchesType(want string, v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch want {
+	case "string":
+		return rv.Kind() == reflect.String
+	case "boolean":
+		return rv.Kind() == reflect.Bool
+	case "number":
+		return isNumberKind(rv.Kind())
+	case "integer":
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			return f == math.Trunc(f) // JSON decodes all numbers as float64
+		}
+		return isNumberKind(rv.Kind())
+	case "object":
+		return rv.Kind() == reflect.Map
+	case "array":
+		return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+	}
+	return true
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func typeName(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	if isNumberKind(reflect.ValueOf(v).Kind()) {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
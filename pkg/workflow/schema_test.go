@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+var orderSchema = map[string]FieldSpec{
+	"orderId": {Type: "string", Required: true},
+	"amount":  {Type: "number", Required: true},
+	"items":   {Type: "array"},
+	"customer": {Type: "object", Fields: map[string]FieldSpec{
+		"id": {Type: "integer", Required: true},
+	}},
+}
+
+func TestValidateSchema(t *testing.T) {
+	valid := map[string]interface{}{
+		"orderId":  "o-1",
+		"amount":   12.5,
+		"items":    []interface{}{"a"},
+		"customer": map[string]interface{}{"id": float64(7)},
+	}
+	if err := validateSchema(orderSchema, valid); err != nil {
+		t.Errorf("validateSchema(valid) error = %v", err)
+	}
+
+	err := validateSchema(orderSchema, map[string]interface{}{
+		"amount":   "12",
+		"customer": map[string]interface{}{"id": 1.5},
+	})
+	if err == nil {
+		t.Fatal("validateSchema() should reject invalid input")
+	}
+	for _, want := range []string{
+		`field "amount" must be number, got string`,
+		`field "customer.id" must be integer, got number`,
+		`field "orderId" is required`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+
+	if err := validateSchema(orderSchema, "not an object"); err == nil {
+		t.Error("validateSchema() should require an object")
+	}
+	if err := validateSchema(nil, "anything"); err != nil {
+		t.Errorf("empty schema should accept anything, got %v", err)
+	}
+}
+
+func TestEngine_RegisterWorkflow_RejectsUnknownSchemaType(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	err := engine.RegisterWorkflow(&WorkflowDefinition{
+		ID:          "bad-schema",
+		Nodes:       []NodeDefinition{{ID: "start", Type: "noop"}},
+		InputSchema: map[string]FieldSpec{"n": {Type: "int"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `unknown type "int"`) {
+		t.Errorf("RegisterWorkflow() error = %v, want unknown type", err)
+	}
+}
+
+func TestEngine_ExecuteWorkflow_ValidatesInputAndOutput(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	def := &WorkflowDefinition{
+		ID: "checkout",
+		Nodes: []NodeDefinition{
+			{ID: "start", Type: "noop", Next: []string{"finish"}},
+			{ID: "finish", Type: "set", Config: map[string]interface{}{
+				"values": map[string]interface{}{"status": "done"},
+			}},
+		},
+		InputSchema:  orderSchema,
+		OutputSchema: map[string]FieldSpec{"status": {Type: "string", Required: true}},
+	}
+	if err := engine.RegisterWorkflow(def); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+
+	if _, err := engine.ExecuteWorkflow(context.Background(), "checkout", map[string]interface{}{"orderId": 1}); err == nil {
+		t.Fatal("ExecuteWorkflow() should reject input that violates the schema")
+	}
+
+	execID, err := engine.ExecuteWorkflow(context.Background(), "checkout", map[string]interface{}{"orderId": "o-1", "amount": 3})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state := waitForStatus(t, engine, execID, ExecutionStatusCompleted)
+	engine.mu.RLock()
+	output, _ := state.Output.(map[string]interface{})
+	engine.mu.RUnlock()
+	if output["status"] != "done" {
+		t.Errorf("Output = %v, want the finish node output", state.Output)
+	}
+
+	// Output contract violations fail the execution
+	bad := *def
+	bad.ID = "checkout-bad-output"
+	bad.OutputSchema = map[string]FieldSpec{"total": {Type: "number", Required: true}}
+	if err := engine.RegisterWorkflow(&bad); err != nil {
+		t.Fatalf("RegisterWorkflow() error = %v", err)
+	}
+	execID, err = engine.ExecuteWorkflow(context.Background(), "checkout-bad-output", map[string]interface{}{"orderId": "o-2", "amount": 3})
+	if err != nil {
+		t.Fatalf("ExecuteWorkflow() error = %v", err)
+	}
+	state = waitForStatus(t, engine, execID, ExecutionStatusFailed)
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	if !strings.Contains(state.Error, `field "total" is required`) {
+		t.Errorf("Error = %q, want output validation failure", state.Error)
+	}
+}
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Serializer encodes the state a store persists, such as suspended executions.
+//
+// Everything nodes and actions put in an ExecutionContext (Data, NodeOutputs,
+// Variables) and a node's input must survive a round trip through it: stores
+// check each value before saving and fail with an error naming the one that
+// doesn't, instead of losing it. With JSONSerializer (the default) that means
+// JSON values (maps, slices, strings, numbers, bools, time.Time, structs with
+// exported fields); channels and functions are rejected, and structs come back as
+// maps. GobSerializer keeps Go types, for single-node setups.
+type Serializer interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+
+	// Extension is the file extension of encoded data, e.g. ".json"
+	Extension() string
+}
+
+// JSONSerializer encodes with encoding/json. Its output is readable and
+// portable across processes and languages.
+type JSONSerializer struct{}
+
+// Marshal implements Serializer.
+func (JSONSerializer) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal implements Serializer.
+func (JSONSerializer) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// Extension implements Serializer.
+func (JSONSerializer) Extension() string { return ".json" }
+
+// GobSerializer encodes with encoding/gob, keeping Go types that JSON flattens
+// (structs come back as themselves, integers stay integers). Concrete types
+// stored in interface{} values must be registered with gob.Register, except the
+// generic maps and slices, time.Time and time.Duration registered here. Data is
+// tied to the program's types, so use it for single-node setups.
+type GobSerializer struct{}
+
+func init() {
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(time.Time{})
+	gob.Register(time.Duration(0))
+}
+
+// Marshal implements Serializer.
+func (GobSerializer) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements Serializer.
+func (GobSerializer) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// Extension implements Serializer.
+func (GobSerializer) Extension() string { return ".gob" }
+
+// checkSerializable round-trips each value of s's execution context and input
+// through serializer, naming the first one that can't be stored
+func checkSerializable(serializer Serializer, s *Suspension) error {
+	if err := roundTrip(serializer, "input", s.Input); err != nil {
+		return err
+	}
+	if s.Context == nil {
+		return nil
+	}
+	for _, group := range []struct {
+		kind   string
+		values map[string]interface{}
+	}{
+		{"data", s.Context.Data},
+		{"node output", s.Context.NodeOutputs},
+		{"variable", s.Context.Variables},
+	} {
+		names := make([]string, 0, len(group.values))
+		for name := range group.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := roundTrip(serializer, fmt.Sprintf("%s %q", group.kind, name), group.values[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// roundTrip encodes value the way it sits in the context (an interface{} in a
+// map) and decodes it back
+func roundTrip(serializer Serializer, what string, value interface{}) error {
+	data, err := serializer.Marshal(map[string]interface{}{"v": value})
+	if err == nil {
+		err = serializer.Unmarshal(data, &map[string]interface{}{})
+	}
+	if err != nil {
+		return fmt.Errorf("%s (%T) cannot be serialized: %w", what, value, err)
+	}
+	return nil
+}
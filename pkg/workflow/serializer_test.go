@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"encoding/gob"
+	"strings"
+	"testing"
+	"time"
+)
+
+type shipment struct {
+	Carrier string
+	Weight  int
+}
+
+func init() {
+	gob.Register(shipment{})
+}
+
+func testSuspension(variables map[string]interface{}) *Suspension {
+	return &Suspension{
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf",
+		NodeID:      "wait",
+		Address:     "payments.completed",
+		SuspendedAt: time.Now(),
+		Input:       map[string]interface{}{"orderId": "42"},
+		Context: &ExecutionContext{
+			WorkflowID:  "wf",
+			ExecutionID: "exec-1",
+			Data:        map[string]interface{}{},
+			NodeOutputs: map[string]interface{}{"start": map[string]interface{}{"ok": true}},
+			Variables:   variables,
+		},
+	}
+}
+
+func TestFileSuspensionStore_RejectsUnserializableVariable(t *testing.T) {
+	for _, serializer := range []Serializer{JSONSerializer{}, GobSerializer{}} {
+		store, err := NewFileSuspensionStoreWithSerializer(t.TempDir(), serializer)
+		if err != nil {
+			t.Fatalf("NewFileSuspensionStoreWithSerializer() error = %v", err)
+		}
+		err = store.Save(testSuspension(map[string]interface{}{"ok": "fine", "done": make(chan struct{})}))
+		if err == nil || !strings.Contains(err.Error(), `variable "done"`) {
+			t.Errorf("%T: Save() with a channel variable error = %v, want one naming the variable", serializer, err)
+		}
+		if stored, _ := store.List(); len(stored) != 0 {
+			t.Errorf("%T: store has %d suspensions after a failed Save, want 0", serializer, len(stored))
+		}
+	}
+}
+
+func TestFileSuspensionStore_Gob(t *testing.T) {
+	store, err := NewFileSuspensionStoreWithSerializer(t.TempDir(), GobSerializer{})
+	if err != nil {
+		t.Fatalf("NewFileSuspensionStoreWithSerializer() error = %v", err)
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := store.Save(testSuspension(map[string]interface{}{
+		"shipment": shipment{Carrier: "ups", Weight: 3},
+		"at":       at,
+		"count":    7,
+	})); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	stored, err := store.List()
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("List() = %d suspensions, %v; want 1", len(stored), err)
+	}
+	vars := stored[0].Context.Variables
+	if got, ok := vars["shipment"].(shipment); !ok || got.Carrier != "ups" || got.Weight != 3 {
+		t.Errorf("shipment = %#v, want shipment{ups 3}", vars["shipment"])
+	}
+	if got, ok := vars["at"].(time.Time); !ok || !got.Equal(at) {
+		t.Errorf("at = %#v, want %v", vars["at"], at)
+	}
+	if got, ok := vars["count"].(int); !ok || got != 7 {
+		t.Errorf("count = %#v, want int 7", vars["count"])
+	}
+
+	// JSON keeps values, not Go types
+	jsonStore, _ := NewFileSuspensionStore(t.TempDir())
+	if err := jsonStore.Save(testSuspension(map[string]interface{}{"shipment": shipment{Carrier: "ups"}})); err != nil {
+		t.Fatalf("JSON Save() error = %v", err)
+	}
+	stored, _ = jsonStore.List()
+	if got, ok := stored[0].Context.Variables["shipment"].(map[string]interface{}); !ok || got["Carrier"] != "ups" {
+		t.Errorf("JSON shipment = %#v, want a map", stored[0].Context.Variables["shipment"])
+	}
+}
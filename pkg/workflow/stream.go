@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+)
+
+// streamHeartbeat keeps idle SSE connections (and proxies) from timing out
+const streamHeartbeat = 15 * time.Second
+
+// handleExecutionStream serves GET /executions/:id/stream as Server-Sent Events:
+// a "snapshot" event with the current ExecutionState, then one event per
+// ExecutionEvent (named by its type) until the execution finishes or is cancelled.
+func (v *WorkflowVerticle) handleExecutionStream(c *web.FastRequestContext) error {
+	execID := c.Param("id")
+	if _, err := v.engine.GetExecutionState(execID); err != nil {
+		return c.JSON(404, map[string]interface{}{"error": err.Error()})
+	}
+
+	// Subscribe before taking the snapshot so no event falls in between
+	events := make(chan ExecutionEvent, 64)
+	done := make(chan struct{})
+	consumer := v.engine.eventBus.Consumer(ExecutionEventsAddress(execID)).Handler(func(ctx core.FluxorContext, msg core.Message) error {
+		var event ExecutionEvent
+		if err := msg.DecodeBody(&event); err != nil {
+			return err
+		}
+		select {
+		case events <- event:
+		case <-done:
+		}
+		return nil
+	})
+	select {
+	case <-consumer.Ready():
+	case <-time.After(5 * time.Second):
+		close(done)
+		consumer.Unregister()
+		return c.JSON(503, map[string]interface{}{"error": "event stream unavailable"})
+	}
+	snapshot, finished := v.engine.executionSnapshot(execID)
+
+	c.RequestCtx.SetContentType("text/event-stream")
+	c.RequestCtx.Response.Header.Set("Cache-Control", "no-cache")
+	c.RequestCtx.Response.Header.Set("X-Accel-Buffering", "no")
+	conn := c.RequestCtx.Conn()
+	stop := c.GoCMD.Context().Done()
+	c.RequestCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer consumer.Unregister()
+		defer close(done)
+
+		// Streams outlive the server's WriteTimeout; extend it per write
+		flush := func() bool {
+			if conn != nil {
+				conn.SetWriteDeadline(time.Now().Add(2 * streamHeartbeat))
+			}
+			return w.Flush() == nil
+		}
+		if !writeSSE(w, "snapshot", snapshot) || !flush() || finished {
+			return
+		}
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case event := <-events:
+				if !writeSSE(w, string(event.Type), event) || !flush() {
+					return
+				}
+				if event.Type == EventExecutionFinished {
+					return
+				}
+			case <-heartbeat.C:
+				// Comment line; also detects clients that went away
+				if _, err := w.WriteString(": ping\n\n"); err != nil || !flush() {
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// writeSSE writes one Server-Sent Event with a JSON data line
+func writeSSE(w *bufio.Writer, event string, data interface{}) bool {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	return err == nil
+}
+
+// executionSnapshot returns a JSON-encoded copy of the execution state and
+// whether it has already finished
+func (e *Engine) executionSnapshot(executionID string) (json.RawMessage, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	state, ok := e.executions[executionID]
+	if !ok {
+		return json.RawMessage("null"), true
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		b = []byte("null")
+	}
+	switch state.Status {
+	case ExecutionStatusCompleted, ExecutionStatusPartialSuccess, ExecutionStatusFailed, ExecutionStatusCancelled:
+		return b, true
+	}
+	return b, false
+}
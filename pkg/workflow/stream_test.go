@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
+)
+
+func newStreamRequest(gocmd core.GoCMD, executionID string) *web.FastRequestContext {
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod("GET")
+	reqCtx.Request.SetRequestURI("/executions/" + executionID + "/stream")
+	return &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         reqCtx,
+		GoCMD:              gocmd,
+		EventBus:           gocmd.EventBus(),
+		Params:             map[string]string{"id": executionID},
+	}
+}
+
+func TestWorkflowVerticle_ExecutionStream(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	v := &WorkflowVerticle{engine: NewEngine(gocmd.EventBus())}
+
+	release := make(chan struct{})
+	v.engine.RegisterNodeHandler("gate", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		<-release
+		return &NodeOutput{Data: input.Data}, nil
+	})
+	def := NewWorkflowBuilder("gated", "Gated").
+		AddNode("start", "noop").Next("gate").Done().
+		AddNode("gate", "gate").Next("finish").Done().
+		AddNode("finish", "noop").Done().
+		Build()
+	if err := v.engine.RegisterWorkflow(def); err != nil {
+		t.Fatal(err)
+	}
+	execID, err := v.engine.ExecuteWorkflow(context.Background(), "gated", map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newStreamRequest(gocmd, execID)
+	if err := v.handleExecutionStream(c); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+
+	// Body reads the stream until the server closes it
+	body := string(c.RequestCtx.Response.Body())
+	if ct := string(c.RequestCtx.Response.Header.ContentType()); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.HasPrefix(body, "event: snapshot\ndata: {") {
+		t.Errorf("stream should start with a snapshot:\n%s", body)
+	}
+	for _, want := range []string{
+		"event: node.completed\ndata: {\"type\":\"node.completed\",\"executionId\":\"" + execID + "\",\"nodeId\":\"gate\"",
+		"event: node.started\ndata: {\"type\":\"node.started\",\"executionId\":\"" + execID + "\",\"nodeId\":\"finish\"",
+		"event: execution.finished\ndata: {\"type\":\"execution.finished\",\"executionId\":\"" + execID + "\",\"status\":\"completed\"",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stream missing %q:\n%s", want, body)
+		}
+	}
+
+	// A finished execution streams only its snapshot
+	c = newStreamRequest(gocmd, execID)
+	v.handleExecutionStream(c)
+	if body := string(c.RequestCtx.Response.Body()); strings.Count(body, "event: ") != 1 || !strings.Contains(body, `"status":"completed"`) {
+		t.Errorf("finished execution stream:\n%s", body)
+	}
+
+	c = newStreamRequest(gocmd, "missing")
+	v.handleExecutionStream(c)
+	if c.RequestCtx.Response.StatusCode() != 404 {
+		t.Errorf("unknown execution status = %d, want 404", c.RequestCtx.Response.StatusCode())
+	}
+}
@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SuspensionStore persists suspended wait-event nodes so they survive a restart.
+type SuspensionStore interface {
+	// Save stores (or replaces) a suspension
+	Save(s *Suspension) error
+
+	// Delete removes the suspension of a node in an execution; missing entries are not an error
+	Delete(executionID, nodeID string) error
+
+	// List returns all stored suspensions
+	List() ([]*Suspension, error)
+}
+
+// FileSuspensionStore stores each suspension as a file in a directory, JSON-encoded
+// unless another Serializer is given.
+type FileSuspensionStore struct {
+	dir        string
+	serializer Serializer
+	mu         sync.Mutex
+}
+
+// NewFileSuspensionStore creates a JSON file-backed store, creating dir if needed.
+func NewFileSuspensionStore(dir string) (*FileSuspensionStore, error) {
+	return NewFileSuspensionStoreWithSerializer(dir, JSONSerializer{})
+}
+
+// NewFileSuspensionStoreWithSerializer creates a file-backed store encoding with
+// serializer, e.g. GobSerializer to keep Go types in execution variables.
+func NewFileSuspensionStoreWithSerializer(dir string, serializer Serializer) (*FileSuspensionStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("suspension store directory is required")
+	}
+	if serializer == nil {
+		return nil, fmt.Errorf("suspension store serializer is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create suspension store directory: %w", err)
+	}
+	return &FileSuspensionStore{dir: dir, serializer: serializer}, nil
+}
+
+// Save implements SuspensionStore. It fails, naming the value, if the input or
+// a value in the execution context can't round-trip through the serializer.
+func (s *FileSuspensionStore) Save(suspension *Suspension) error {
+	if err := checkSerializable(s.serializer, suspension); err != nil {
+		return err
+	}
+	data, err := s.serializer.Marshal(suspension)
+	if err != nil {
+		return fmt.Errorf("encode suspension: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Write then rename so a crash never leaves a truncated file
+	path := s.path(suspension.ExecutionID, suspension.NodeID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Delete implements SuspensionStore.
+func (s *FileSuspensionStore) Delete(executionID, nodeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(executionID, nodeID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List implements SuspensionStore.
+func (s *FileSuspensionStore) List() ([]*Suspension, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Suspension
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), s.serializer.Extension()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var suspension Suspension
+		if err := s.serializer.Unmarshal(data, &suspension); err != nil {
+			return nil, fmt.Errorf("decode suspension %s: %w", entry.Name(), err)
+		}
+		result = append(result, &suspension)
+	}
+	return result, nil
+}
+
+// path returns the file for a suspension; IDs are escaped so they cannot leave dir
+func (s *FileSuspensionStore) path(executionID, nodeID string) string {
+	return filepath.Join(s.dir, url.PathEscape(executionID)+"_"+url.PathEscape(nodeID)+s.serializer.Extension())
+}
@@ -31,6 +31,10 @@ type WorkflowDefinition struct {
 	Version     string                 `json:"version,omitempty"`
 	Nodes       []NodeDefinition       `json:"nodes"`
 	Settings    map[string]interface{} `json:"settings,omitempty"`
+
+	// Optional contracts checked by ExecuteWorkflow (input) and on completion (output)
+	InputSchema  map[string]FieldSpec `json:"inputSchema,omitempty"`
+	OutputSchema map[string]FieldSpec `json:"outputSchema,omitempty"`
 }
 
 // NodeDefinition defines a single node in the workflow.
@@ -45,6 +49,10 @@ type NodeDefinition struct {
 	FalseNext  []string               `json:"falseNext,omitempty"`  // For condition nodes
 	RetryCount int                    `json:"retryCount,omitempty"` // Retry on failure
 	Timeout    string                 `json:"timeout,omitempty"`    // Execution timeout
+
+	// Optional declarative reshaping, e.g. {"message": "{{ $.response }}"}; see applyMapping
+	InputMapping  map[string]interface{} `json:"inputMapping,omitempty"`  // Builds the data the node receives
+	OutputMapping map[string]interface{} `json:"outputMapping,omitempty"` // Builds the data passed to next nodes
 }
 
 // NodeType represents the type of workflow node.
@@ -70,10 +78,13 @@ const (
 	NodeTypeCondition   NodeType = "condition"   // If/else branching
 	NodeTypeSplit       NodeType = "split"       // Parallel execution
 	NodeTypeMerge       NodeType = "merge"       // Wait for multiple inputs
+	NodeTypeParallel    NodeType = "parallel"    // Start named branches concurrently
+	NodeTypeJoin        NodeType = "join"        // Wait for the branches of a parallel node
 	NodeTypeLoop        NodeType = "loop"        // Iterate over items
 	NodeTypeDynamicLoop NodeType = "dynamicloop" // Dynamic loop based on data
 	NodeTypeSwitch      NodeType = "switch"      // Multi-way branching
 	NodeTypeWait        NodeType = "wait"        // Delay execution
+	NodeTypeWaitEvent   NodeType = "waitevent"   // Suspend until an EventBus event arrives
 	NodeTypeSubWorkflow NodeType = "subworkflow" // Execute nested workflow
 
 	// Utility nodes
@@ -83,6 +94,8 @@ const (
 )
 
 // ExecutionContext holds the context for a workflow execution.
+// Values stored in Data, NodeOutputs and Variables must be serializable (see
+// Serializer) for the execution to be persisted, e.g. by a SuspensionStore.
 type ExecutionContext struct {
 	WorkflowID  string                 `json:"workflowId"`
 	ExecutionID string                 `json:"executionId"`
@@ -150,11 +163,30 @@ type ExecutionStatus string
 const (
 	ExecutionStatusPending   ExecutionStatus = "pending"
 	ExecutionStatusRunning   ExecutionStatus = "running"
+	ExecutionStatusPaused    ExecutionStatus = "paused"
 	ExecutionStatusCompleted ExecutionStatus = "completed"
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 	ExecutionStatusCancelled ExecutionStatus = "cancelled"
+
+	// ExecutionStatusPartialSuccess: some nodes failed, but at least one path
+	// that did not go through an onError edge ran to its end
+	ExecutionStatusPartialSuccess ExecutionStatus = "partial_success"
 )
 
+// NodeStatus is the outcome of a node in an execution.
+type NodeStatus string
+
+const (
+	NodeStatusCompleted NodeStatus = "completed"
+	NodeStatusFailed    NodeStatus = "failed"
+)
+
+// NodeResult records how a node's last run ended.
+type NodeResult struct {
+	Status NodeStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+}
+
 // ExecutionState tracks the state of a workflow execution.
 type ExecutionState struct {
 	ExecutionID string            `json:"executionId"`
@@ -162,6 +194,16 @@ type ExecutionState struct {
 	Status      ExecutionStatus   `json:"status"`
 	StartTime   time.Time         `json:"startTime"`
 	EndTime     *time.Time        `json:"endTime,omitempty"`
+	PausedAt    *time.Time        `json:"pausedAt,omitempty"`
 	Context     *ExecutionContext `json:"context"`
+	Output      interface{}       `json:"output,omitempty"` // Output of the last node that ended a path
 	Error       string            `json:"error,omitempty"`
+
+	// Per-node outcomes; a node that runs more than once keeps its last result
+	NodeResults    map[string]NodeResult `json:"nodeResults,omitempty"`
+	CompletedNodes int                   `json:"completedNodes"`
+	FailedNodes    int                   `json:"failedNodes"`
+
+	recovering     map[string]bool // nodes reached through onError edges
+	succeededPaths int             // paths that ended outside error handling
 }
@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateGraph checks the node graph of a workflow for cycles and unreachable nodes.
+// Edges are Next, TrueNext, FalseNext and OnError. A cycle is only allowed when it
+// passes through a loop or dynamicloop node, which opts the workflow into iteration.
+// Returns the IDs of nodes that no start node can reach (a warning, not an error).
+func validateGraph(def *WorkflowDefinition) ([]string, error) {
+	index := make(map[string]int, len(def.Nodes))
+	for i, node := range def.Nodes {
+		index[node.ID] = i
+	}
+
+	// DFS with colors: 0 = unvisited, 1 = on stack, 2 = done
+	color := make([]int, len(def.Nodes))
+	var stack []int
+	var visit func(i int) error
+	visit = func(i int) error {
+		color[i] = 1
+		stack = append(stack, i)
+		for _, next := range nodeEdges(&def.Nodes[i]) {
+			j := index[next]
+			switch color[j] {
+			case 0:
+				if err := visit(j); err != nil {
+					return err
+				}
+			case 1:
+				if cycle := cycleFrom(stack, j); !allowsLoop(def, cycle) {
+					return fmt.Errorf("workflow %s contains a cycle: %s", def.ID, formatCycle(def, cycle))
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[i] = 2
+		return nil
+	}
+	for i := range def.Nodes {
+		if color[i] == 0 {
+			if err := visit(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Reachability from the nodes the engine starts with
+	reached := make([]bool, len(def.Nodes))
+	var queue []int
+	for i := range def.Nodes {
+		if isStartNode(&def.Nodes[i], def) {
+			reached[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, next := range nodeEdges(&def.Nodes[i]) {
+			if j := index[next]; !reached[j] {
+				reached[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	var unreachable []string
+	for i, node := range def.Nodes {
+		if !reached[i] {
+			unreachable = append(unreachable, node.ID)
+		}
+	}
+	return unreachable, nil
+}
+
+// nodeEdges returns every outgoing edge of a node
+func nodeEdges(node *NodeDefinition) []string {
+	edges := make([]string, 0, len(node.Next)+len(node.TrueNext)+len(node.FalseNext)+len(node.OnError))
+	edges = append(edges, node.Next...)
+	edges = append(edges, node.TrueNext...)
+	edges = append(edges, node.FalseNext...)
+	edges = append(edges, node.OnError...)
+	return edges
+}
+
+// cycleFrom returns the node indices on the DFS stack from start to the top
+func cycleFrom(stack []int, start int) []int {
+	for k := len(stack) - 1; k >= 0; k-- {
+		if stack[k] == start {
+			return stack[k:]
+		}
+	}
+	return nil
+}
+
+// allowsLoop reports whether a cycle contains a loop node
+func allowsLoop(def *WorkflowDefinition, cycle []int) bool {
+	for _, i := range cycle {
+		switch NodeType(def.Nodes[i].Type) {
+		case NodeTypeLoop, NodeTypeDynamicLoop:
+			return true
+		}
+	}
+	return false
+}
+
+// formatCycle renders a cycle as "a -> b -> a"
+func formatCycle(def *WorkflowDefinition, cycle []int) string {
+	ids := make([]string, 0, len(cycle)+1)
+	for _, i := range cycle {
+		ids = append(ids, def.Nodes[i].ID)
+	}
+	ids = append(ids, def.Nodes[cycle[0]].ID)
+	return strings.Join(ids, " -> ")
+}
@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+)
+
+func TestValidateGraph_RejectsCycle(t *testing.T) {
+	def := &WorkflowDefinition{
+		ID: "cyclic",
+		Nodes: []NodeDefinition{
+			{ID: "trigger", Type: "manual", Next: []string{"a"}},
+			{ID: "a", Type: "function", Next: []string{"b"}},
+			{ID: "b", Type: "condition", TrueNext: []string{"c"}, FalseNext: []string{"a"}},
+			{ID: "c", Type: "noop"},
+		},
+	}
+
+	_, err := validateGraph(def)
+	if err == nil {
+		t.Fatal("validateGraph() should reject a cycle")
+	}
+	if !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("error %q should name the cycle", err)
+	}
+}
+
+func TestValidateGraph_CycleThroughOnError(t *testing.T) {
+	def := &WorkflowDefinition{
+		ID: "retry-forever",
+		Nodes: []NodeDefinition{
+			{ID: "trigger", Type: "manual", Next: []string{"call"}},
+			{ID: "call", Type: "http", OnError: []string{"call"}},
+		},
+	}
+
+	if _, err := validateGraph(def); err == nil {
+		t.Error("validateGraph() should reject a self-loop through onError")
+	}
+}
+
+func TestValidateGraph_AllowsLoopNode(t *testing.T) {
+	def := &WorkflowDefinition{
+		ID: "iterate",
+		Nodes: []NodeDefinition{
+			{ID: "trigger", Type: "manual", Next: []string{"each"}},
+			{ID: "each", Type: "loop", Next: []string{"process"}},
+			{ID: "process", Type: "function", Next: []string{"each"}},
+		},
+	}
+
+	if _, err := validateGraph(def); err != nil {
+		t.Errorf("validateGraph() error = %v, loop nodes opt into cycles", err)
+	}
+}
+
+func TestValidateGraph_Unreachable(t *testing.T) {
+	def := &WorkflowDefinition{
+		ID: "dead-nodes",
+		Nodes: []NodeDefinition{
+			{ID: "trigger", Type: "manual", Next: []string{"done"}},
+			{ID: "done", Type: "noop"},
+			{ID: "orphan-loop", Type: "loop", Next: []string{"orphan-body"}},
+			{ID: "orphan-body", Type: "function", Next: []string{"orphan-loop"}},
+		},
+	}
+
+	unreachable, err := validateGraph(def)
+	if err != nil {
+		t.Fatalf("validateGraph() error = %v", err)
+	}
+	if want := []string{"orphan-loop", "orphan-body"}; !reflect.DeepEqual(unreachable, want) {
+		t.Errorf("unreachable = %v, want %v", unreachable, want)
+	}
+}
+
+func TestIsStartNode_OnErrorTarget(t *testing.T) {
+	def := &WorkflowDefinition{
+		ID: "on-error",
+		Nodes: []NodeDefinition{
+			{ID: "work", Type: "function", OnError: []string{"recover"}},
+			{ID: "recover", Type: "noop"},
+		},
+	}
+	if !isStartNode(&def.Nodes[0], def) {
+		t.Error("work should be a start node")
+	}
+	if isStartNode(&def.Nodes[1], def) {
+		t.Error("an OnError target should not be a start node")
+	}
+	if unreachable, err := validateGraph(def); err != nil || len(unreachable) != 0 {
+		t.Errorf("validateGraph() = %v, %v; want no unreachable nodes", unreachable, err)
+	}
+}
+
+func TestEngine_RegisterWorkflow_RejectsCycle(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	engine := NewEngine(gocmd.EventBus())
+
+	err := engine.RegisterWorkflow(&WorkflowDefinition{
+		ID: "cyclic",
+		Nodes: []NodeDefinition{
+			{ID: "a", Type: "function", Next: []string{"b"}},
+			{ID: "b", Type: "function", Next: []string{"a"}},
+		},
+	})
+	if err == nil {
+		t.Fatal("RegisterWorkflow() should reject a cyclic workflow")
+	}
+	if n := len(engine.ListWorkflows()); n != 0 {
+		t.Errorf("ListWorkflows() has %d workflows, cyclic workflow should not be registered", n)
+	}
+}
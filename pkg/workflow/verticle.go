@@ -15,7 +15,11 @@ type WorkflowVerticle struct {
 	engine           *Engine
 	functionRegistry *FunctionRegistry
 	server           *web.FastHTTPServer
+	schedules        *scheduler
+	events           *eventTriggerSet
+	eventTriggers    []EventTriggerConfig
 	httpAddr         string
+	idGenerator      IDGenerator
 }
 
 // WorkflowVerticleConfig configures the workflow verticle.
@@ -28,6 +32,9 @@ type WorkflowVerticleConfig struct {
 
 	// EventTriggers to set up on start
 	EventTriggers []EventTriggerConfig
+
+	// IDGenerator creates execution IDs (default: UUIDGenerator)
+	IDGenerator IDGenerator
 }
 
 // NewWorkflowVerticle creates a new workflow verticle.
@@ -37,6 +44,8 @@ func NewWorkflowVerticle(config *WorkflowVerticleConfig) *WorkflowVerticle {
 	}
 	if config != nil {
 		v.httpAddr = config.HTTPAddr
+		v.eventTriggers = config.EventTriggers
+		v.idGenerator = config.IDGenerator
 	}
 	return v
 }
@@ -57,6 +66,7 @@ func (v *WorkflowVerticle) Engine() *Engine {
 func (v *WorkflowVerticle) Start(ctx core.FluxorContext) error {
 	// Create workflow engine with EventBus
 	v.engine = NewEngine(ctx.EventBus())
+	v.engine.SetIDGenerator(v.idGenerator)
 
 	// Register node handlers that require runtime dependencies
 	v.engine.RegisterNodeHandler(NodeTypeHTTP, HTTPNodeHandler)
@@ -76,6 +86,16 @@ func (v *WorkflowVerticle) Start(ctx core.FluxorContext) error {
 	v.engine.RegisterNodeHandler(NodeType("aimodule.embed"), AIEmbedNodeHandler)
 	v.engine.RegisterNodeHandler(NodeType("aimodule.toolcall"), AIChatNodeHandler)
 
+	// Run the workflows that have a schedule trigger (see schedule.go); both this
+	// and the event triggers below cover workflows registered through Engine()
+	// after Start
+	v.schedules = newScheduler(v.engine)
+	v.engine.onRegister(v.schedules.add)
+
+	// Start the workflows whose event node address gets a message (see event_trigger.go)
+	v.events = newEventTriggerSet(v.engine, ctx.EventBus())
+	v.engine.onRegister(v.events.add)
+
 	// Load workflows from config
 	if workflows, ok := ctx.Config()["workflows"].([]interface{}); ok {
 		for _, wf := range workflows {
@@ -92,6 +112,13 @@ func (v *WorkflowVerticle) Start(ctx core.FluxorContext) error {
 		}
 	}
 
+	// Event triggers from WorkflowVerticleConfig.EventTriggers
+	for _, trigger := range v.eventTriggers {
+		if err := v.events.addConfig(trigger); err != nil {
+			return err
+		}
+	}
+
 	// Start HTTP API if configured
 	if v.httpAddr != "" {
 		if err := v.startHTTPAPI(ctx); err != nil {
@@ -104,6 +131,12 @@ func (v *WorkflowVerticle) Start(ctx core.FluxorContext) error {
 
 // Stop implements core.Verticle.
 func (v *WorkflowVerticle) Stop(ctx core.FluxorContext) error {
+	if v.schedules != nil {
+		v.schedules.stop()
+	}
+	if v.events != nil {
+		v.events.stop()
+	}
 	if v.server != nil {
 		return v.server.Stop()
 	}
@@ -159,6 +192,9 @@ func (v *WorkflowVerticle) startHTTPAPI(ctx core.FluxorContext) error {
 		})
 	})
 
+	// Webhook trigger: starts the workflow through its webhook node (see handleWebhook)
+	router.POSTFast("/webhooks/:id", v.handleWebhook)
+
 	// Get execution status
 	router.GETFast("/executions/:id", func(c *web.FastRequestContext) error {
 		execID := c.Param("id")
@@ -169,6 +205,9 @@ func (v *WorkflowVerticle) startHTTPAPI(ctx core.FluxorContext) error {
 		return c.JSON(200, state)
 	})
 
+	// Live progress as Server-Sent Events (see handleExecutionStream)
+	router.GETFast("/executions/:id/stream", v.handleExecutionStream)
+
 	// Cancel execution
 	router.POSTFast("/executions/:id/cancel", func(c *web.FastRequestContext) error {
 		execID := c.Param("id")
@@ -180,6 +219,28 @@ func (v *WorkflowVerticle) startHTTPAPI(ctx core.FluxorContext) error {
 		})
 	})
 
+	// Pause execution
+	router.POSTFast("/executions/:id/pause", func(c *web.FastRequestContext) error {
+		execID := c.Param("id")
+		if err := v.engine.PauseExecution(execID); err != nil {
+			return c.JSON(400, map[string]interface{}{"error": err.Error()})
+		}
+		return c.JSON(200, map[string]interface{}{
+			"message": "execution paused",
+		})
+	})
+
+	// Resume execution
+	router.POSTFast("/executions/:id/resume", func(c *web.FastRequestContext) error {
+		execID := c.Param("id")
+		if err := v.engine.ResumeExecution(execID); err != nil {
+			return c.JSON(400, map[string]interface{}{"error": err.Error()})
+		}
+		return c.JSON(200, map[string]interface{}{
+			"message": "execution resumed",
+		})
+	})
+
 	// Health check
 	router.GETFast("/health", func(c *web.FastRequestContext) error {
 		return c.JSON(200, map[string]interface{}{
@@ -286,6 +347,18 @@ func (n *NodeBuilder) Timeout(d time.Duration) *NodeBuilder {
 	return n
 }
 
+// InputMapping sets the mapping applied to the node's input.
+func (n *NodeBuilder) InputMapping(mapping map[string]interface{}) *NodeBuilder {
+	n.node().InputMapping = mapping
+	return n
+}
+
+// OutputMapping sets the mapping applied to the node's output.
+func (n *NodeBuilder) OutputMapping(mapping map[string]interface{}) *NodeBuilder {
+	n.node().OutputMapping = mapping
+	return n
+}
+
 // Done returns to the workflow builder.
 func (n *NodeBuilder) Done() *WorkflowBuilder {
 	return n.workflow
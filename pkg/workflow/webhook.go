@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fluxorio/fluxor/pkg/web"
+)
+
+// Webhook trigger config (on the workflow's "webhook" node):
+//   - "secret": if set, requests must carry an HMAC-SHA256 of the raw body,
+//     hex-encoded, in the signature header ("sha256=" prefix optional)
+//   - "signatureHeader": header holding the signature (default: X-Webhook-Signature)
+//   - "responseTimeout": how long a workflow with a "respond" node is awaited (default: 30s)
+const (
+	DefaultWebhookSignatureHeader = "X-Webhook-Signature"
+	DefaultWebhookResponseTimeout = 30 * time.Second
+)
+
+// handleWebhook serves POST /webhooks/:id. The JSON body becomes the workflow
+// input. Workflows with a respond node are awaited and answer with their output;
+// others answer 202 with the execution ID.
+func (v *WorkflowVerticle) handleWebhook(c *web.FastRequestContext) error {
+	workflowID := c.Param("id")
+	def, trigger := v.webhookTrigger(workflowID)
+	if trigger == nil {
+		return c.JSON(404, map[string]interface{}{"error": fmt.Sprintf("no webhook trigger for workflow: %s", workflowID)})
+	}
+
+	body := c.RequestCtx.PostBody()
+	if secret, _ := trigger.Config["secret"].(string); secret != "" {
+		header := DefaultWebhookSignatureHeader
+		if h, ok := trigger.Config["signatureHeader"].(string); ok && h != "" {
+			header = h
+		}
+		if !validWebhookSignature(secret, body, string(c.RequestCtx.Request.Header.Peek(header))) {
+			return c.JSON(401, map[string]interface{}{"error": "invalid webhook signature"})
+		}
+	}
+
+	var input interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &input); err != nil {
+			return c.JSON(400, map[string]interface{}{"error": "invalid JSON body"})
+		}
+	}
+
+	execID, err := v.engine.ExecuteWorkflow(c.Context(), workflowID, input)
+	if err != nil {
+		return c.JSON(400, map[string]interface{}{"error": err.Error()})
+	}
+	accepted := map[string]interface{}{
+		"executionId": execID,
+		"workflowId":  workflowID,
+	}
+	if !hasRespondNode(def) {
+		return c.JSON(202, accepted)
+	}
+
+	timeout := DefaultWebhookResponseTimeout
+	if t, ok := trigger.Config["responseTimeout"].(string); ok {
+		if d, err := time.ParseDuration(t); err == nil {
+			timeout = d
+		}
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), timeout)
+	defer cancel()
+	status, output, execErr := v.engine.awaitExecution(ctx, execID)
+
+	c.RequestCtx.Response.Header.Set("X-Execution-ID", execID)
+	switch status {
+	case ExecutionStatusCompleted, ExecutionStatusPartialSuccess:
+		c.RequestCtx.Response.Header.Set("X-Execution-Status", string(status))
+		return c.JSON(200, output)
+	case ExecutionStatusFailed, ExecutionStatusCancelled:
+		accepted["status"] = status
+		accepted["error"] = execErr
+		return c.JSON(500, accepted)
+	default:
+		// Still running (or paused) when the timeout hit; poll /executions/:id
+		accepted["status"] = status
+		return c.JSON(202, accepted)
+	}
+}
+
+// webhookTrigger returns the workflow and its first webhook node
+func (v *WorkflowVerticle) webhookTrigger(workflowID string) (*WorkflowDefinition, *NodeDefinition) {
+	v.engine.mu.RLock()
+	def, ok := v.engine.workflows[workflowID]
+	v.engine.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	for i := range def.Nodes {
+		if NodeType(def.Nodes[i].Type) == NodeTypeWebhook {
+			return def, &def.Nodes[i]
+		}
+	}
+	return def, nil
+}
+
+func hasRespondNode(def *WorkflowDefinition) bool {
+	for _, node := range def.Nodes {
+		if NodeType(node.Type) == NodeTypeRespond {
+			return true
+		}
+	}
+	return false
+}
+
+// validWebhookSignature checks signature against the HMAC-SHA256 of body
+func validWebhookSignature(secret string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// awaitExecution polls an execution until it finishes or ctx is done and
+// returns its last seen status, output and error message.
+func (e *Engine) awaitExecution(ctx context.Context, executionID string) (ExecutionStatus, interface{}, string) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		e.mu.RLock()
+		var status ExecutionStatus
+		var output interface{}
+		var errMsg string
+		if state, ok := e.executions[executionID]; ok {
+			status, output, errMsg = state.Status, state.Output, state.Error
+		}
+		e.mu.RUnlock()
+
+		switch status {
+		case ExecutionStatusCompleted, ExecutionStatusPartialSuccess, ExecutionStatusFailed, ExecutionStatusCancelled, "":
+			return status, output, errMsg
+		}
+		select {
+		case <-ctx.Done():
+			return status, output, errMsg
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/fluxorio/fluxor/pkg/core"
+	"github.com/fluxorio/fluxor/pkg/web"
+	"github.com/valyala/fasthttp"
+)
+
+func newWebhookRequest(gocmd core.GoCMD, workflowID, body string, headers map[string]string) *web.FastRequestContext {
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod("POST")
+	reqCtx.Request.SetRequestURI("/webhooks/" + workflowID)
+	reqCtx.Request.SetBodyString(body)
+	for k, v := range headers {
+		reqCtx.Request.Header.Set(k, v)
+	}
+	return &web.FastRequestContext{
+		BaseRequestContext: core.NewBaseRequestContext(),
+		RequestCtx:         reqCtx,
+		GoCMD:              gocmd,
+		EventBus:           gocmd.EventBus(),
+		Params:             map[string]string{"id": workflowID},
+	}
+}
+
+func TestWorkflowVerticle_Webhook(t *testing.T) {
+	gocmd := core.NewGoCMD(context.Background())
+	defer gocmd.Close()
+	v := &WorkflowVerticle{engine: NewEngine(gocmd.EventBus())}
+
+	v.engine.RegisterNodeHandler("greet", func(ctx context.Context, input *NodeInput) (*NodeOutput, error) {
+		data := input.Data.(map[string]interface{})
+		return &NodeOutput{Data: map[string]interface{}{"greeting": "hello " + data["name"].(string)}}, nil
+	})
+	sync := NewWorkflowBuilder("sync", "Sync").
+		AddNode("trigger", "webhook").Config(map[string]interface{}{"secret": "s3cr3t"}).Next("greet").Done().
+		AddNode("greet", "greet").Next("out").Done().
+		AddNode("out", "respond").Done().
+		Build()
+	async := NewWorkflowBuilder("async", "Async").
+		AddNode("trigger", "webhook").Next("greet").Done().
+		AddNode("greet", "greet").Done().
+		Build()
+	plain := NewWorkflowBuilder("plain", "Plain").AddNode("start", "noop").Done().Build()
+	for _, def := range []*WorkflowDefinition{sync, async, plain} {
+		if err := v.engine.RegisterWorkflow(def); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	body := `{"name":"ada"}`
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	// Synchronous: the respond node's input is the response
+	c := newWebhookRequest(gocmd, "sync", body, map[string]string{"X-Webhook-Signature": signature})
+	if err := v.handleWebhook(c); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(c.RequestCtx.Response.Body(), &got)
+	if c.RequestCtx.Response.StatusCode() != 200 || got["greeting"] != "hello ada" {
+		t.Errorf("sync response = %d %s", c.RequestCtx.Response.StatusCode(), c.RequestCtx.Response.Body())
+	}
+	if len(c.RequestCtx.Response.Header.Peek("X-Execution-ID")) == 0 {
+		t.Error("sync response should carry X-Execution-ID")
+	}
+
+	// Bad or missing signatures are rejected before anything runs
+	for _, sig := range []string{"", "sha256=00ff", "not-hex"} {
+		c = newWebhookRequest(gocmd, "sync", body, map[string]string{"X-Webhook-Signature": sig})
+		v.handleWebhook(c)
+		if c.RequestCtx.Response.StatusCode() != 401 {
+			t.Errorf("signature %q: status = %d, want 401", sig, c.RequestCtx.Response.StatusCode())
+		}
+	}
+
+	// Asynchronous: 202 with the execution ID
+	c = newWebhookRequest(gocmd, "async", body, nil)
+	v.handleWebhook(c)
+	var accepted map[string]interface{}
+	json.Unmarshal(c.RequestCtx.Response.Body(), &accepted)
+	if c.RequestCtx.Response.StatusCode() != 202 || accepted["executionId"] == "" {
+		t.Fatalf("async response = %d %s", c.RequestCtx.Response.StatusCode(), c.RequestCtx.Response.Body())
+	}
+	state := waitForStatus(t, v.engine, accepted["executionId"].(string), ExecutionStatusCompleted)
+	if state.Output.(map[string]interface{})["greeting"] != "hello ada" {
+		t.Errorf("async output = %v", state.Output)
+	}
+
+	// Invalid JSON, unknown workflows and workflows without a webhook node
+	c = newWebhookRequest(gocmd, "async", "{", nil)
+	v.handleWebhook(c)
+	if c.RequestCtx.Response.StatusCode() != 400 {
+		t.Errorf("invalid JSON: status = %d, want 400", c.RequestCtx.Response.StatusCode())
+	}
+	for _, id := range []string{"missing", "plain"} {
+		c = newWebhookRequest(gocmd, id, body, nil)
+		v.handleWebhook(c)
+		if c.RequestCtx.Response.StatusCode() != 404 {
+			t.Errorf("%s: status = %d, want 404", id, c.RequestCtx.Response.StatusCode())
+		}
+	}
+}